@@ -0,0 +1,173 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// State is the state of a CircuitBreaker
+type State int
+
+const (
+	StateClosed State = iota
+	StateHalfOpen
+	StateOpen
+)
+
+// ErrOpenState is returned when the CircuitBreaker is open or its half-open probe is in flight
+var ErrOpenState = errors.New("circuit breaker is open")
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "open"
+	}
+}
+
+// CircuitBreaker fast-fails calls to a backend after maxFailures consecutive failures. Once openTimeout has elapsed,
+// it lets a single probe call through in the half-open state and closes again if the probe succeeds. A nil
+// CircuitBreaker lets all calls through
+type CircuitBreaker struct {
+	failures    uint32
+	maxFailures uint32
+	mutex       sync.Mutex
+	name        string
+	openTimeout time.Duration
+	openedAt    time.Time
+	probing     bool
+	state       State
+}
+
+// NewCircuitBreaker creates a new instance of a CircuitBreaker
+func NewCircuitBreaker(name string, maxFailures uint32, openTimeout time.Duration) *CircuitBreaker {
+	if maxFailures == 0 {
+		maxFailures = 1
+	}
+
+	return &CircuitBreaker{maxFailures: maxFailures, name: name, openTimeout: openTimeout}
+}
+
+// Allow checks if a call can go through. If so, the caller must report its outcome with the returned done function
+func (cb *CircuitBreaker) Allow() (func(success bool), error) {
+	if cb == nil {
+		return func(bool) {}, nil
+	}
+
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	if cb.state == StateOpen {
+		if time.Since(cb.openedAt) < cb.openTimeout {
+			return nil, ErrOpenState
+		}
+		cb.setState(StateHalfOpen)
+	}
+
+	if cb.state == StateHalfOpen {
+		if cb.probing {
+			return nil, ErrOpenState
+		}
+		cb.probing = true
+	}
+
+	var once sync.Once
+	return func(success bool) {
+		once.Do(func() {
+			cb.done(success)
+		})
+	}, nil
+}
+
+// Execute runs fn if the CircuitBreaker allows it, and counts a non-nil error returned by fn as a failure if
+// isFailure returns true for it
+func (cb *CircuitBreaker) Execute(fn func() error, isFailure func(error) bool) error {
+	done, err := cb.Allow()
+	if err != nil {
+		return err
+	}
+
+	err = fn()
+	done(err == nil || !isFailure(err))
+	return err
+}
+
+// State returns the current state of the CircuitBreaker
+func (cb *CircuitBreaker) State() State {
+	if cb == nil {
+		return StateClosed
+	}
+
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	if cb.state == StateOpen && time.Since(cb.openedAt) >= cb.openTimeout {
+		return StateHalfOpen
+	}
+
+	return cb.state
+}
+
+func (cb *CircuitBreaker) done(success bool) {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	if cb.state == StateHalfOpen {
+		cb.probing = false
+		if success {
+			cb.setState(StateClosed)
+		} else {
+			cb.setState(StateOpen)
+		}
+		return
+	}
+
+	if success {
+		cb.failures = 0
+		return
+	}
+
+	cb.failures++
+	if cb.state == StateClosed && cb.failures >= cb.maxFailures {
+		cb.setState(StateOpen)
+	}
+}
+
+func (cb *CircuitBreaker) setState(state State) {
+	if cb.state == state {
+		return
+	}
+
+	log.Warnf("Circuit breaker %s changed from %s to %s", cb.name, cb.state, state)
+	cb.failures = 0
+	cb.state = state
+	if state == StateOpen {
+		cb.openedAt = time.Now()
+	}
+}
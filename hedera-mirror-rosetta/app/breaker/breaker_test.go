@@ -0,0 +1,142 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errBackend = errors.New("backend failure")
+
+func alwaysFailure(error) bool {
+	return true
+}
+
+func fail() error {
+	return errBackend
+}
+
+func succeed() error {
+	return nil
+}
+
+func TestCircuitBreakerOpensAfterMaxFailures(t *testing.T) {
+	// given
+	cb := NewCircuitBreaker("test", 2, time.Hour)
+
+	// when
+	err1 := cb.Execute(fail, alwaysFailure)
+	state1 := cb.State()
+	err2 := cb.Execute(fail, alwaysFailure)
+	err3 := cb.Execute(succeed, alwaysFailure)
+
+	// then
+	assert.Equal(t, errBackend, err1)
+	assert.Equal(t, StateClosed, state1)
+	assert.Equal(t, errBackend, err2)
+	assert.Equal(t, ErrOpenState, err3)
+	assert.Equal(t, StateOpen, cb.State())
+}
+
+func TestCircuitBreakerSuccessResetsFailures(t *testing.T) {
+	// given
+	cb := NewCircuitBreaker("test", 2, time.Hour)
+
+	// when
+	_ = cb.Execute(fail, alwaysFailure)
+	_ = cb.Execute(succeed, alwaysFailure)
+	_ = cb.Execute(fail, alwaysFailure)
+
+	// then
+	assert.Equal(t, StateClosed, cb.State())
+}
+
+func TestCircuitBreakerIgnoresNonFailure(t *testing.T) {
+	// given
+	cb := NewCircuitBreaker("test", 1, time.Hour)
+
+	// when
+	err := cb.Execute(fail, func(error) bool { return false })
+
+	// then
+	assert.Equal(t, errBackend, err)
+	assert.Equal(t, StateClosed, cb.State())
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	tests := []struct {
+		name     string
+		probe    func() error
+		expected State
+	}{
+		{name: "ProbeSucceeds", probe: succeed, expected: StateClosed},
+		{name: "ProbeFails", probe: fail, expected: StateOpen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			cb := NewCircuitBreaker("test", 1, time.Millisecond)
+			_ = cb.Execute(fail, alwaysFailure)
+			time.Sleep(5 * time.Millisecond)
+			assert.Equal(t, StateHalfOpen, cb.State())
+
+			// when
+			done, err := cb.Allow()
+			_, concurrentErr := cb.Allow()
+			done(tt.probe() == nil)
+
+			// then
+			assert.NoError(t, err)
+			assert.Equal(t, ErrOpenState, concurrentErr)
+			assert.Equal(t, tt.expected, cb.State())
+		})
+	}
+}
+
+func TestCircuitBreakerDoneIsIdempotent(t *testing.T) {
+	// given
+	cb := NewCircuitBreaker("test", 2, time.Hour)
+	done, _ := cb.Allow()
+
+	// when
+	done(false)
+	done(false)
+
+	// then
+	assert.Equal(t, uint32(1), cb.failures)
+}
+
+func TestNilCircuitBreaker(t *testing.T) {
+	// given
+	var cb *CircuitBreaker
+
+	// when
+	err := cb.Execute(fail, alwaysFailure)
+
+	// then
+	assert.Equal(t, errBackend, err)
+	assert.Equal(t, StateClosed, cb.State())
+}
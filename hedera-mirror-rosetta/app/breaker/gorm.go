@@ -0,0 +1,72 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package breaker
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+const doneKey = "breaker:done"
+
+// ProtectDb registers gorm callbacks on dbClient so that queries fail fast with ErrOpenState while cb is open. Any
+// query error other than gorm.ErrRecordNotFound and context cancellation counts as a failure
+func ProtectDb(dbClient *gorm.DB, cb *CircuitBreaker) error {
+	callback := dbClient.Callback()
+	if err := callback.Query().Before("gorm:query").Register("breaker:before_query", allow(cb)); err != nil {
+		return err
+	}
+	if err := callback.Query().After("gorm:query").Register("breaker:after_query", done); err != nil {
+		return err
+	}
+	if err := callback.Row().Before("gorm:row").Register("breaker:before_row", allow(cb)); err != nil {
+		return err
+	}
+	return callback.Row().After("gorm:row").Register("breaker:after_row", done)
+}
+
+// IsDbFailure returns true if err indicates the database is failing
+func IsDbFailure(err error) bool {
+	return !errors.Is(err, gorm.ErrRecordNotFound) && !errors.Is(err, context.Canceled)
+}
+
+func allow(cb *CircuitBreaker) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Error != nil {
+			return
+		}
+
+		doneFn, err := cb.Allow()
+		if err != nil {
+			_ = db.AddError(err)
+			return
+		}
+		db.InstanceSet(doneKey, doneFn)
+	}
+}
+
+func done(db *gorm.DB) {
+	if value, ok := db.InstanceGet(doneKey); ok {
+		value.(func(bool))(db.Error == nil || !IsDbFailure(db.Error))
+	}
+}
@@ -0,0 +1,79 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+const query = "SELECT index FROM record_file"
+
+type recordFile struct {
+	Index int64
+}
+
+func TestProtectDb(t *testing.T) {
+	// given
+	dbClient, mock := mocks.DatabaseMock(t)
+	cb := NewCircuitBreaker("database", 1, time.Hour)
+	assert.NoError(t, ProtectDb(dbClient, cb))
+	mock.ExpectQuery(query).WillReturnError(errors.New("connection refused"))
+
+	// when
+	err1 := dbClient.Raw(query).First(&recordFile{}).Error
+	err2 := dbClient.Raw(query).Scan(&[]recordFile{}).Error
+
+	// then
+	assert.EqualError(t, err1, "connection refused")
+	assert.ErrorIs(t, err2, ErrOpenState)
+	assert.Equal(t, StateOpen, cb.State())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProtectDbRecordNotFound(t *testing.T) {
+	// given
+	dbClient, mock := mocks.DatabaseMock(t)
+	cb := NewCircuitBreaker("database", 1, time.Hour)
+	assert.NoError(t, ProtectDb(dbClient, cb))
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"index"}))
+
+	// when
+	err := dbClient.Raw(query).First(&recordFile{}).Error
+
+	// then
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+	assert.Equal(t, StateClosed, cb.State())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIsDbFailure(t *testing.T) {
+	assert.True(t, IsDbFailure(errors.New("connection refused")))
+	assert.False(t, IsDbFailure(gorm.ErrRecordNotFound))
+	assert.False(t, IsDbFailure(context.Canceled))
+}
@@ -0,0 +1,268 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package canary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/metrics"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/base"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	rosettaTypes "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/types"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	checksName  = "hedera_mirror_rosetta_canary_checks_total"
+	checksHelp  = "The number of canary self-checks by outcome"
+	latencyName = "hedera_mirror_rosetta_canary_latency_seconds"
+	latencyHelp = "The end-to-end latency of the last successful canary self-check, from construction to the block"
+
+	outcomeFailure = "failure"
+	outcomeSuccess = "success"
+	outcomeTimeout = "timeout"
+
+	defaultPollInterval = time.Second
+	resultSuccess       = "SUCCESS"
+)
+
+var errSlaExceeded = errors.New("the transaction isn't in a block within the SLA")
+
+// Canary periodically constructs, signs with the operator key, and submits a 1 tinybar self-transfer of the operator
+// account through the construction API, then checks the transaction reaches a block within the SLA. The outcome and
+// the end-to-end latency are recorded as metrics
+type Canary struct {
+	baseService         base.BaseService
+	constructionService server.ConstructionAPIServicer
+	interval            time.Duration
+	network             *rTypes.NetworkIdentifier
+	operatorId          hedera.AccountID
+	operatorKey         hedera.PrivateKey
+	pollInterval        time.Duration
+	registry            *metrics.Registry
+	settings            *config.Settings
+	sla                 time.Duration
+	stop                chan struct{}
+}
+
+// New creates a new instance of a Canary. It returns an error if the operator id or key is invalid, or the interval or
+// the SLA isn't positive. The self-transfers are in the hbar currency of the settings
+func New(
+	baseService base.BaseService,
+	constructionService server.ConstructionAPIServicer,
+	network *rTypes.NetworkIdentifier,
+	canaryConfig rosettaTypes.Canary,
+	registry *metrics.Registry,
+	settings *config.Settings,
+) (*Canary, error) {
+	operatorId, err := hedera.AccountIDFromString(canaryConfig.OperatorId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid operator id %q: %w", canaryConfig.OperatorId, err)
+	}
+
+	operatorKey, err := hedera.PrivateKeyFromString(canaryConfig.OperatorKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid operator key: %w", err)
+	}
+
+	if canaryConfig.Interval <= 0 || canaryConfig.Sla <= 0 {
+		return nil, errors.New("the interval and the SLA must be positive")
+	}
+
+	return &Canary{
+		baseService:         baseService,
+		constructionService: constructionService,
+		interval:            time.Duration(canaryConfig.Interval) * time.Millisecond,
+		network:             network,
+		operatorId:          operatorId,
+		operatorKey:         operatorKey,
+		pollInterval:        defaultPollInterval,
+		registry:            registry,
+		settings:            settings,
+		sla:                 time.Duration(canaryConfig.Sla) * time.Millisecond,
+		stop:                make(chan struct{}),
+	}, nil
+}
+
+// Start starts the periodic self-checks in a new goroutine
+func (c *Canary) Start() {
+	go c.run()
+}
+
+// Stop stops the periodic self-checks
+func (c *Canary) Stop() {
+	close(c.stop)
+}
+
+func (c *Canary) run() {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.check()
+		}
+	}
+}
+
+// check runs a self-check and records its outcome, and the latency if it succeeds
+func (c *Canary) check() {
+	start := time.Now()
+	hash, err := c.submit()
+	if err == nil {
+		err = c.waitForBlock(hash, start.Add(c.sla))
+	}
+
+	outcome := outcomeSuccess
+	switch {
+	case err == errSlaExceeded:
+		outcome = outcomeTimeout
+		log.Warnf("Canary transaction %s isn't in a block within %s", hash, c.sla)
+	case err != nil:
+		outcome = outcomeFailure
+		log.Errorf("Canary self-check failed: %s", err)
+	default:
+		latency := time.Since(start)
+		c.registry.Set(latencyName, latencyHelp, latency.Seconds())
+		log.Debugf("Canary transaction %s is in a block after %s", hash, latency)
+	}
+	c.registry.IncrementWithLabels(checksName, checksHelp, map[string]string{"outcome": outcome})
+}
+
+// submit constructs, signs, and submits the self-transfer, and returns the transaction hash
+func (c *Canary) submit() (string, error) {
+	ctx := context.Background()
+	operations := []*rTypes.Operation{c.newTransferOperation(0, "-1"), c.newTransferOperation(1, "1")}
+
+	preprocessResponse, rErr := c.constructionService.ConstructionPreprocess(ctx,
+		&rTypes.ConstructionPreprocessRequest{NetworkIdentifier: c.network, Operations: operations})
+	if rErr != nil {
+		return "", fmt.Errorf("failed to preprocess: %s", rErr.Message)
+	}
+
+	metadataResponse, rErr := c.constructionService.ConstructionMetadata(ctx, &rTypes.ConstructionMetadataRequest{
+		NetworkIdentifier: c.network,
+		Options:           preprocessResponse.Options,
+	})
+	if rErr != nil {
+		return "", fmt.Errorf("failed to get the metadata: %s", rErr.Message)
+	}
+
+	payloadsResponse, rErr := c.constructionService.ConstructionPayloads(ctx, &rTypes.ConstructionPayloadsRequest{
+		NetworkIdentifier: c.network,
+		Operations:        operations,
+		Metadata:          metadataResponse.Metadata,
+	})
+	if rErr != nil {
+		return "", fmt.Errorf("failed to create the payloads: %s", rErr.Message)
+	}
+
+	publicKey := &rTypes.PublicKey{Bytes: c.operatorKey.PublicKey().Bytes(), CurveType: rTypes.Edwards25519}
+	signatures := make([]*rTypes.Signature, 0, len(payloadsResponse.Payloads))
+	for _, payload := range payloadsResponse.Payloads {
+		signatures = append(signatures, &rTypes.Signature{
+			SigningPayload: payload,
+			PublicKey:      publicKey,
+			SignatureType:  rTypes.Ed25519,
+			Bytes:          c.operatorKey.Sign(payload.Bytes),
+		})
+	}
+
+	combineResponse, rErr := c.constructionService.ConstructionCombine(ctx, &rTypes.ConstructionCombineRequest{
+		NetworkIdentifier:   c.network,
+		UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+		Signatures:          signatures,
+	})
+	if rErr != nil {
+		return "", fmt.Errorf("failed to combine the signatures: %s", rErr.Message)
+	}
+
+	submitResponse, rErr := c.constructionService.ConstructionSubmit(ctx, &rTypes.ConstructionSubmitRequest{
+		NetworkIdentifier: c.network,
+		SignedTransaction: combineResponse.SignedTransaction,
+	})
+	if rErr != nil {
+		return "", fmt.Errorf("failed to submit: %s", rErr.Message)
+	}
+
+	return submitResponse.TransactionIdentifier.Hash, nil
+}
+
+// waitForBlock polls the mirror node until the transaction with the hash is in a block, or the deadline passes. It
+// returns an error if the transaction fails
+func (c *Canary) waitForBlock(hash string, deadline time.Time) error {
+	ticker := time.NewTicker(c.pollInterval)
+	defer ticker.Stop()
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+
+	for {
+		found, err := c.isInBlock(hash)
+		if err != nil || found {
+			return err
+		}
+
+		select {
+		case <-c.stop:
+			return errors.New("the canary is stopped")
+		case <-timer.C:
+			return errSlaExceeded
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Canary) isInBlock(hash string) (bool, error) {
+	rawTransactions, rErr := c.baseService.FindRawByHash(hash)
+	if rErr != nil || len(rawTransactions) == 0 {
+		return false, nil
+	}
+
+	rawTransaction := rawTransactions[0]
+	if rawTransaction.Result != resultSuccess {
+		return false, fmt.Errorf("transaction %s failed with %s", hash, rawTransaction.Result)
+	}
+
+	if _, rErr = c.baseService.FindByConsensusTimestamp(rawTransaction.ConsensusTimestamp); rErr != nil {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (c *Canary) newTransferOperation(index int64, amount string) *rTypes.Operation {
+	return &rTypes.Operation{
+		OperationIdentifier: &rTypes.OperationIdentifier{Index: index},
+		Type:                config.OperationTypeCryptoTransfer,
+		Account:             types.NewAccountIdentifier(c.operatorId.String(), c.settings.CurrencyHbar, c.settings),
+		Amount:              &rTypes.Amount{Value: amount, Currency: c.settings.CurrencyHbar},
+	}
+}
@@ -0,0 +1,255 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package canary
+
+import (
+	"context"
+	"crypto/ed25519"
+	"testing"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/metrics"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/base"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/mocks/repository"
+	rosettaTypes "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	consensusTimestamp = int64(1000)
+	operatorKey        = "302e020100300506032b6570042204207904b9687878e08e101723f7b724cd61a42bbff93923177bf3fcc2240b0dd3bc"
+	transactionHash    = "0x0a0b"
+)
+
+var (
+	canaryConfig = rosettaTypes.Canary{
+		Enabled:     true,
+		Interval:    300000,
+		OperatorId:  "0.0.1001",
+		OperatorKey: operatorKey,
+		Sla:         30000,
+	}
+	payloadBytes = []byte{1, 2, 3}
+)
+
+// fakeConstructionService returns canned responses, and verifies the signatures passed to combine
+type fakeConstructionService struct {
+	server.ConstructionAPIServicer
+	operations []*rTypes.Operation
+	submitErr  *rTypes.Error
+}
+
+func (f *fakeConstructionService) ConstructionPreprocess(
+	_ context.Context,
+	request *rTypes.ConstructionPreprocessRequest,
+) (*rTypes.ConstructionPreprocessResponse, *rTypes.Error) {
+	f.operations = request.Operations
+	return &rTypes.ConstructionPreprocessResponse{Options: map[string]interface{}{"operation_type": "CRYPTOTRANSFER"}},
+		nil
+}
+
+func (f *fakeConstructionService) ConstructionMetadata(
+	context.Context,
+	*rTypes.ConstructionMetadataRequest,
+) (*rTypes.ConstructionMetadataResponse, *rTypes.Error) {
+	return &rTypes.ConstructionMetadataResponse{Metadata: map[string]interface{}{}}, nil
+}
+
+func (f *fakeConstructionService) ConstructionPayloads(
+	context.Context,
+	*rTypes.ConstructionPayloadsRequest,
+) (*rTypes.ConstructionPayloadsResponse, *rTypes.Error) {
+	return &rTypes.ConstructionPayloadsResponse{
+		UnsignedTransaction: "0xaa",
+		Payloads: []*rTypes.SigningPayload{{
+			AccountIdentifier: &rTypes.AccountIdentifier{Address: canaryConfig.OperatorId},
+			Bytes:             payloadBytes,
+			SignatureType:     rTypes.Ed25519,
+		}},
+	}, nil
+}
+
+func (f *fakeConstructionService) ConstructionCombine(
+	_ context.Context,
+	request *rTypes.ConstructionCombineRequest,
+) (*rTypes.ConstructionCombineResponse, *rTypes.Error) {
+	for _, signature := range request.Signatures {
+		if !ed25519.Verify(signature.PublicKey.Bytes, payloadBytes, signature.Bytes) {
+			return nil, errors.ErrInvalidSignatureVerification
+		}
+	}
+	return &rTypes.ConstructionCombineResponse{SignedTransaction: "0xbb"}, nil
+}
+
+func (f *fakeConstructionService) ConstructionSubmit(
+	context.Context,
+	*rTypes.ConstructionSubmitRequest,
+) (*rTypes.TransactionIdentifierResponse, *rTypes.Error) {
+	if f.submitErr != nil {
+		return nil, f.submitErr
+	}
+	return &rTypes.TransactionIdentifierResponse{
+		TransactionIdentifier: &rTypes.TransactionIdentifier{Hash: transactionHash},
+	}, nil
+}
+
+func TestNew(t *testing.T) {
+	// when
+	canary, err := New(base.BaseService{}, nil, nil, canaryConfig, nil, config.NewSettings())
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "0.0.1001", canary.operatorId.String())
+	assert.Equal(t, 5*time.Minute, canary.interval)
+	assert.Equal(t, 30*time.Second, canary.sla)
+}
+
+func TestNewInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		update func(*rosettaTypes.Canary)
+	}{
+		{name: "operator id", update: func(c *rosettaTypes.Canary) { c.OperatorId = "0.0.a" }},
+		{name: "operator key", update: func(c *rosettaTypes.Canary) { c.OperatorKey = "" }},
+		{name: "interval", update: func(c *rosettaTypes.Canary) { c.Interval = 0 }},
+		{name: "sla", update: func(c *rosettaTypes.Canary) { c.Sla = -1 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			invalidConfig := canaryConfig
+			tt.update(&invalidConfig)
+
+			// when
+			canary, err := New(base.BaseService{}, nil, nil, invalidConfig, nil, config.NewSettings())
+
+			// then
+			assert.Error(t, err)
+			assert.Nil(t, canary)
+		})
+	}
+}
+
+func TestCheckSuccess(t *testing.T) {
+	// given
+	constructionService := &fakeConstructionService{}
+	canary, registry, blockRepo, transactionRepo := setupCanary(t, constructionService)
+	transactionRepo.On("FindRawByHash", transactionHash).Return([]*types.RawTransaction{}, errors.ErrTransactionNotFound).
+		Once()
+	transactionRepo.On("FindRawByHash", transactionHash).Return(
+		[]*types.RawTransaction{{ConsensusTimestamp: consensusTimestamp, Result: resultSuccess}},
+		repository.NilError,
+	)
+	blockRepo.On("FindByConsensusTimestamp", consensusTimestamp).Return(&types.Block{Index: 5}, repository.NilError)
+
+	// when
+	canary.check()
+
+	// then
+	body := registry.String()
+	assert.Contains(t, body, checksName+"{outcome=\"success\"} 1\n")
+	assert.Contains(t, body, "# TYPE "+latencyName+" gauge\n")
+	assert.Len(t, constructionService.operations, 2)
+	assert.Equal(t, "-1", constructionService.operations[0].Amount.Value)
+	assert.Equal(t, "1", constructionService.operations[1].Amount.Value)
+	for _, operation := range constructionService.operations {
+		assert.Equal(t, canaryConfig.OperatorId, operation.Account.Address)
+	}
+	transactionRepo.AssertNumberOfCalls(t, "FindRawByHash", 2)
+}
+
+func TestCheckTimeout(t *testing.T) {
+	// given
+	canary, registry, _, transactionRepo := setupCanary(t, &fakeConstructionService{})
+	canary.sla = 10 * time.Millisecond
+	transactionRepo.On("FindRawByHash", transactionHash).Return([]*types.RawTransaction{}, errors.ErrTransactionNotFound)
+
+	// when
+	canary.check()
+
+	// then
+	body := registry.String()
+	assert.Contains(t, body, checksName+"{outcome=\"timeout\"} 1\n")
+	assert.NotContains(t, body, latencyName)
+}
+
+func TestCheckFailedTransaction(t *testing.T) {
+	// given
+	canary, registry, blockRepo, transactionRepo := setupCanary(t, &fakeConstructionService{})
+	transactionRepo.On("FindRawByHash", transactionHash).Return(
+		[]*types.RawTransaction{{ConsensusTimestamp: consensusTimestamp, Result: "INSUFFICIENT_PAYER_BALANCE"}},
+		repository.NilError,
+	)
+
+	// when
+	canary.check()
+
+	// then
+	assert.Contains(t, registry.String(), checksName+"{outcome=\"failure\"} 1\n")
+	blockRepo.AssertNotCalled(t, "FindByConsensusTimestamp", mock.Anything)
+}
+
+func TestCheckSubmitFailure(t *testing.T) {
+	// given
+	constructionService := &fakeConstructionService{submitErr: errors.ErrTransactionSubmissionFailed}
+	canary, registry, _, transactionRepo := setupCanary(t, constructionService)
+
+	// when
+	canary.check()
+
+	// then
+	assert.Contains(t, registry.String(), checksName+"{outcome=\"failure\"} 1\n")
+	transactionRepo.AssertNotCalled(t, "FindRawByHash", mock.Anything)
+}
+
+func TestStartStop(t *testing.T) {
+	// given
+	canary, _, _, _ := setupCanary(t, &fakeConstructionService{})
+
+	// when, then
+	assert.NotPanics(t, func() {
+		canary.Start()
+		canary.Stop()
+	})
+}
+
+func setupCanary(t *testing.T, constructionService server.ConstructionAPIServicer) (
+	*Canary,
+	*metrics.Registry,
+	*repository.MockBlockRepository,
+	*repository.MockTransactionRepository,
+) {
+	blockRepo := &repository.MockBlockRepository{}
+	transactionRepo := &repository.MockTransactionRepository{}
+	registry := metrics.NewRegistry()
+	baseService := base.NewBaseService(blockRepo, transactionRepo)
+	canary, err := New(baseService, constructionService, nil, canaryConfig, registry, config.NewSettings())
+	assert.NoError(t, err)
+	canary.pollInterval = time.Millisecond
+	return canary, registry, blockRepo, transactionRepo
+}
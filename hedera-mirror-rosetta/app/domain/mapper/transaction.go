@@ -0,0 +1,251 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+)
+
+const (
+	// FailedAmountsIntended keeps the amounts a failed transaction intended to transfer in its operations with the
+	// failure status, which is useful for analytics
+	FailedAmountsIntended = "intended"
+	// FailedAmountsZero zeroes the amounts in the operations with the failure status of a failed transaction, so only
+	// the fee operations move hbar
+	FailedAmountsZero = "zero"
+)
+
+// systemTransactionTypes are the transaction types submitted by privileged accounts, which usually pay no fee. Such a
+// transaction always has an operation of the payer so it's visible in the block even without any transfer
+var systemTransactionTypes = map[int16]bool{
+	types.TransactionTypeFreeze:         true,
+	types.TransactionTypeSystemDelete:   true,
+	types.TransactionTypeSystemUndelete: true,
+}
+
+// ToTransaction assembles the transaction from its records, which share the hash. The hash and the metadata are the
+// first record's, and the rest of the records, e.g., the duplicates and the failed attempts, are listed in the attempts
+// metadata with their consensus timestamps, charged fees, and results, so the fees charged for them can be told apart.
+// The hbar transfers not in the transaction body are fees, so their operations have the success status regardless of
+// the record result. The amounts of the other operations of a failed record are zeroed if failedAmounts is
+// FailedAmountsZero. The consensus timestamps of the attempts have their RFC3339 strings if timestampStrings is set
+func ToTransaction(
+	records []*types.TransactionRecord,
+	success string,
+	failedAmounts string,
+	timestampStrings bool,
+) *types.Transaction {
+	feeDebited := int64(0)
+	operations := make([]*types.Operation, 0)
+
+	for _, record := range records {
+		// the intended transfers of a failed record never happened, so its crypto transfers are all fees
+		nonFeeTransferMap := make(map[int64]int64)
+		if record.Result == success {
+			nonFeeTransferMap = aggregateNonFeeTransfers(record.NonFeeTransfers)
+		}
+		adjustedCryptoTransfers := adjustCryptoTransfers(record.CryptoTransfers, nonFeeTransferMap)
+		for _, transfer := range adjustedCryptoTransfers {
+			if value := getHbarValue(transfer); value < 0 {
+				feeDebited -= value
+			}
+		}
+
+		nonFeeTransfers := record.NonFeeTransfers
+		tokenTransfers := record.TokenTransfers
+		if record.Result != success && failedAmounts == FailedAmountsZero {
+			nonFeeTransfers = zeroAmounts(nonFeeTransfers)
+			tokenTransfers = zeroAmounts(tokenTransfers)
+		}
+
+		operations = appendTransferOperations(record.Result, record.TypeName, nonFeeTransfers, operations)
+		operations = appendTransferOperations(success, record.TypeName, adjustedCryptoTransfers, operations)
+		operations = appendTransferOperations(record.Result, record.TypeName, tokenTransfers, operations)
+
+		if record.Token != nil {
+			operations = append(operations, getTokenOperation(len(operations), record))
+		}
+
+		if systemTransactionTypes[record.Type] {
+			operations = append(operations, getSystemOperation(len(operations), record))
+		}
+	}
+
+	types.SortOperations(operations)
+	return &types.Transaction{
+		Hash:       records[0].Hash,
+		Metadata:   getMetadata(records, timestampStrings),
+		Operations: operations,
+		FeeDebited: feeDebited,
+	}
+}
+
+// CheckFailedAmounts returns an error if failedAmounts isn't a supported mode, empty is the same as
+// FailedAmountsIntended
+func CheckFailedAmounts(failedAmounts string) error {
+	switch failedAmounts {
+	case "", FailedAmountsIntended, FailedAmountsZero:
+		return nil
+	default:
+		return fmt.Errorf("unsupported failed amounts mode %s", failedAmounts)
+	}
+}
+
+// getMetadata returns the first record's metadata, with the attempts metadata if there are more records
+func getMetadata(records []*types.TransactionRecord, timestampStrings bool) map[string]interface{} {
+	if len(records) == 1 {
+		return records[0].Metadata
+	}
+
+	metadata := make(map[string]interface{}, len(records[0].Metadata)+1)
+	for key, value := range records[0].Metadata {
+		metadata[key] = value
+	}
+
+	attempts := make([]map[string]interface{}, 0, len(records)-1)
+	for _, record := range records[1:] {
+		attempt := map[string]interface{}{
+			"charged_fee": record.Metadata["charged_fee"],
+			"result":      record.Result,
+		}
+		if consensusTimestamp, ok := record.Metadata["consensus_timestamp"].(int64); ok {
+			types.AddTimestampMetadata(attempt, "consensus_timestamp", consensusTimestamp, timestampStrings)
+		}
+		attempts = append(attempts, attempt)
+	}
+	metadata["attempts"] = attempts
+
+	return metadata
+}
+
+func appendTransferOperations(
+	status string,
+	transactionType string,
+	transfers []types.Transfer,
+	operations []*types.Operation,
+) []*types.Operation {
+	for _, transfer := range transfers {
+		operations = append(operations, &types.Operation{
+			Index:   int64(len(operations)),
+			Type:    transactionType,
+			Status:  status,
+			Account: transfer.Account,
+			Amount:  transfer.Amount,
+		})
+	}
+	return operations
+}
+
+// adjustCryptoTransfers aggregates the hbar transfers of each account and subtracts the non-fee transfers, the
+// accounts whose fee transfers net to zero are dropped
+func adjustCryptoTransfers(cryptoTransfers []types.Transfer, nonFeeTransferMap map[int64]int64) []types.Transfer {
+	accounts := make(map[int64]types.Account)
+	cryptoTransferMap := make(map[int64]int64)
+	for _, transfer := range cryptoTransfers {
+		key := transfer.Account.EncodedId
+		accounts[key] = transfer.Account
+		cryptoTransferMap[key] += getHbarValue(transfer)
+	}
+
+	adjusted := make([]types.Transfer, 0, len(cryptoTransfers))
+	for key, aggregated := range cryptoTransferMap {
+		amount := aggregated - nonFeeTransferMap[key]
+		if amount != 0 {
+			adjusted = append(adjusted, types.Transfer{
+				Account: accounts[key],
+				Amount:  &types.HbarAmount{Value: amount},
+			})
+		}
+	}
+
+	return adjusted
+}
+
+func aggregateNonFeeTransfers(nonFeeTransfers []types.Transfer) map[int64]int64 {
+	nonFeeTransferMap := make(map[int64]int64)
+
+	// the original transfer list from the transaction body
+	for _, transfer := range nonFeeTransfers {
+		// the original transfer list may have multiple entries for one entity, so accumulate it
+		nonFeeTransferMap[transfer.Account.EncodedId] += getHbarValue(transfer)
+	}
+
+	return nonFeeTransferMap
+}
+
+// zeroAmounts returns a copy of the transfers with the amounts set to zero, the token of a token amount is kept
+func zeroAmounts(transfers []types.Transfer) []types.Transfer {
+	zeroed := make([]types.Transfer, 0, len(transfers))
+	for _, transfer := range transfers {
+		var amount types.Amount = &types.HbarAmount{}
+		if tokenAmount, ok := transfer.Amount.(*types.TokenAmount); ok {
+			zeroedTokenAmount := *tokenAmount
+			zeroedTokenAmount.Value = 0
+			amount = &zeroedTokenAmount
+		}
+		zeroed = append(zeroed, types.Transfer{Account: transfer.Account, Amount: amount})
+	}
+	return zeroed
+}
+
+func getHbarValue(transfer types.Transfer) int64 {
+	if amount, ok := transfer.Amount.(*types.HbarAmount); ok {
+		return amount.Value
+	}
+	return 0
+}
+
+// getSystemOperation returns the operation of the payer without amount for a system transaction. The affected entity
+// of a system delete or undelete is the entity_id in the transaction metadata
+func getSystemOperation(index int, record *types.TransactionRecord) *types.Operation {
+	return &types.Operation{
+		Index:   int64(index),
+		Type:    record.TypeName,
+		Status:  record.Result,
+		Account: record.Payer,
+	}
+}
+
+func getTokenOperation(index int, record *types.TransactionRecord) *types.Operation {
+	token := record.Token
+	operation := &types.Operation{
+		Index:   int64(index),
+		Type:    record.TypeName,
+		Status:  record.Result,
+		Account: record.Payer,
+		Amount:  &types.TokenAmount{TokenId: token.TokenId, Decimals: int64(token.Decimals)},
+	}
+
+	if record.Type == types.TransactionTypeTokenCreation {
+		// token creation shouldn't have Amount
+		operation.Amount = nil
+		// best effort for immutable fields
+		operation.Metadata = map[string]interface{}{
+			"decimals":       int64(token.Decimals),
+			"freeze_default": token.FreezeDefault,
+			"initial_supply": token.InitialSupply,
+		}
+	}
+
+	return operation
+}
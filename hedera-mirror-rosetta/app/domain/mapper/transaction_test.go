@@ -0,0 +1,294 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package mapper
+
+import (
+	"testing"
+
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	resultFail    = "INSUFFICIENT_ACCOUNT_BALANCE"
+	resultSuccess = "SUCCESS"
+)
+
+var (
+	payer    = types.Account{EntityId: entityid.EntityId{EntityNum: 58, EncodedId: 58}}
+	node     = types.Account{EntityId: entityid.EntityId{EntityNum: 3, EncodedId: 3}}
+	receiver = types.Account{EntityId: entityid.EntityId{EntityNum: 59, EncodedId: 59}}
+	tokenId  = entityid.EntityId{EntityNum: 1001, EncodedId: 1001}
+)
+
+func TestToTransaction(t *testing.T) {
+	// given
+	record := &types.TransactionRecord{
+		CryptoTransfers: []types.Transfer{
+			newHbarTransfer(payer, -15),
+			newHbarTransfer(node, 5),
+			newHbarTransfer(receiver, 10),
+		},
+		Hash:            "0x0102",
+		Metadata:        map[string]interface{}{"memo": "transfer"},
+		NonFeeTransfers: []types.Transfer{newHbarTransfer(payer, -10), newHbarTransfer(receiver, 10)},
+		Payer:           payer,
+		Result:          resultSuccess,
+		Type:            14,
+		TypeName:        "CRYPTOTRANSFER",
+	}
+	expected := &types.Transaction{
+		Hash:     "0x0102",
+		Metadata: map[string]interface{}{"memo": "transfer"},
+		Operations: []*types.Operation{
+			{Index: 0, Type: "CRYPTOTRANSFER", Status: resultSuccess, Account: node, Amount: &types.HbarAmount{Value: 5}},
+			{Index: 1, Type: "CRYPTOTRANSFER", Status: resultSuccess, Account: payer, Amount: &types.HbarAmount{Value: -10}},
+			{Index: 2, Type: "CRYPTOTRANSFER", Status: resultSuccess, Account: payer, Amount: &types.HbarAmount{Value: -5}},
+			{Index: 3, Type: "CRYPTOTRANSFER", Status: resultSuccess, Account: receiver, Amount: &types.HbarAmount{Value: 10}},
+		},
+		FeeDebited: 5,
+	}
+
+	// when
+	actual := ToTransaction([]*types.TransactionRecord{record}, resultSuccess, FailedAmountsZero, false)
+
+	// then
+	assert.Equal(t, expected, actual)
+}
+
+func TestToTransactionFailedIntendedAmounts(t *testing.T) {
+	for _, failedAmounts := range []string{"", FailedAmountsIntended} {
+		t.Run(failedAmounts, func(t *testing.T) {
+			// given
+			record := newFailedTransferRecord()
+			expected := []*types.Operation{
+				{Index: 0, Type: "CRYPTOTRANSFER", Status: resultSuccess, Account: node, Amount: &types.HbarAmount{Value: 5}},
+				{Index: 1, Type: "CRYPTOTRANSFER", Status: resultFail, Account: payer, Amount: &types.HbarAmount{Value: -10}},
+				{Index: 2, Type: "CRYPTOTRANSFER", Status: resultSuccess, Account: payer, Amount: &types.HbarAmount{Value: -5}},
+				{Index: 3, Type: "CRYPTOTRANSFER", Status: resultFail, Account: receiver, Amount: &types.HbarAmount{Value: 10}},
+				{Index: 4, Type: "CRYPTOTRANSFER", Status: resultFail, Account: receiver,
+					Amount: newTokenTransfer(receiver, 10).Amount},
+			}
+
+			// when
+			actual := ToTransaction([]*types.TransactionRecord{record}, resultSuccess, failedAmounts, false)
+
+			// then
+			assert.Equal(t, expected, actual.Operations)
+			assert.Equal(t, int64(5), actual.FeeDebited)
+		})
+	}
+}
+
+func TestToTransactionFailedZeroAmounts(t *testing.T) {
+	// given
+	record := newFailedTransferRecord()
+	expected := []*types.Operation{
+		{Index: 0, Type: "CRYPTOTRANSFER", Status: resultSuccess, Account: node, Amount: &types.HbarAmount{Value: 5}},
+		{Index: 1, Type: "CRYPTOTRANSFER", Status: resultSuccess, Account: payer, Amount: &types.HbarAmount{Value: -5}},
+		{Index: 2, Type: "CRYPTOTRANSFER", Status: resultFail, Account: payer, Amount: &types.HbarAmount{}},
+		{Index: 3, Type: "CRYPTOTRANSFER", Status: resultFail, Account: receiver, Amount: &types.HbarAmount{}},
+		{Index: 4, Type: "CRYPTOTRANSFER", Status: resultFail, Account: receiver,
+			Amount: newTokenTransfer(receiver, 0).Amount},
+	}
+
+	// when
+	actual := ToTransaction([]*types.TransactionRecord{record}, resultSuccess, FailedAmountsZero, false)
+
+	// then
+	assert.Equal(t, expected, actual.Operations)
+	assert.Equal(t, int64(5), actual.FeeDebited)
+	assert.Equal(t, newFailedTransferRecord(), record)
+}
+
+func TestCheckFailedAmounts(t *testing.T) {
+	for _, failedAmounts := range []string{"", FailedAmountsIntended, FailedAmountsZero} {
+		assert.NoError(t, CheckFailedAmounts(failedAmounts))
+	}
+	assert.Error(t, CheckFailedAmounts("fee"))
+}
+
+func TestToTransactionWithAttempts(t *testing.T) {
+	// given
+	newRecord := func(consensusTimestamp int64, result string) *types.TransactionRecord {
+		return &types.TransactionRecord{
+			CryptoTransfers: []types.Transfer{newHbarTransfer(payer, -15), newHbarTransfer(node, 15)},
+			Hash:            "0x0102",
+			Metadata:        map[string]interface{}{"charged_fee": int64(15), "consensus_timestamp": consensusTimestamp},
+			Payer:           payer,
+			Result:          result,
+			Type:            14,
+			TypeName:        "CRYPTOTRANSFER",
+		}
+	}
+	records := []*types.TransactionRecord{
+		newRecord(10, resultSuccess),
+		newRecord(11, resultFail),
+		newRecord(12, "DUPLICATE_TRANSACTION"),
+	}
+	expectedMetadata := map[string]interface{}{
+		"attempts": []map[string]interface{}{
+			{"charged_fee": int64(15), "consensus_timestamp": int64(11), "result": resultFail},
+			{"charged_fee": int64(15), "consensus_timestamp": int64(12), "result": "DUPLICATE_TRANSACTION"},
+		},
+		"charged_fee":         int64(15),
+		"consensus_timestamp": int64(10),
+	}
+
+	// when
+	actual := ToTransaction(records, resultSuccess, FailedAmountsIntended, false)
+
+	// then
+	assert.Equal(t, expectedMetadata, actual.Metadata)
+	assert.Len(t, actual.Operations, 6)
+	assert.Equal(t, int64(45), actual.FeeDebited)
+	assert.Equal(t, map[string]interface{}{"charged_fee": int64(15), "consensus_timestamp": int64(10)},
+		records[0].Metadata)
+}
+
+func TestToTransactionTokenCreation(t *testing.T) {
+	// given
+	record := &types.TransactionRecord{
+		Hash:           "0x0102",
+		Payer:          payer,
+		Result:         resultSuccess,
+		Token:          &types.Token{TokenId: tokenId, Decimals: 2, FreezeDefault: true, InitialSupply: 100},
+		TokenTransfers: []types.Transfer{newTokenTransfer(payer, 100)},
+		Type:           types.TransactionTypeTokenCreation,
+		TypeName:       "TOKENCREATION",
+	}
+	expected := []*types.Operation{
+		{
+			Index:   0,
+			Type:    "TOKENCREATION",
+			Status:  resultSuccess,
+			Account: payer,
+			Metadata: map[string]interface{}{
+				"decimals":       int64(2),
+				"freeze_default": true,
+				"initial_supply": int64(100),
+			},
+		},
+		{Index: 1, Type: "TOKENCREATION", Status: resultSuccess, Account: payer, Amount: newTokenTransfer(payer, 100).Amount},
+	}
+
+	// when
+	actual := ToTransaction([]*types.TransactionRecord{record}, resultSuccess, FailedAmountsIntended, false)
+
+	// then
+	assert.Equal(t, expected, actual.Operations)
+}
+
+func TestToTransactionTokenOperation(t *testing.T) {
+	// given
+	record := &types.TransactionRecord{
+		Payer:    payer,
+		Result:   resultSuccess,
+		Token:    &types.Token{TokenId: tokenId, Decimals: 2},
+		Type:     types.TransactionTypeTokenDeletion,
+		TypeName: "TOKENDELETION",
+	}
+	expected := []*types.Operation{
+		{
+			Index:   0,
+			Type:    "TOKENDELETION",
+			Status:  resultSuccess,
+			Account: payer,
+			Amount:  &types.TokenAmount{TokenId: tokenId, Decimals: 2},
+		},
+	}
+
+	// when
+	actual := ToTransaction([]*types.TransactionRecord{record}, resultSuccess, FailedAmountsIntended, false)
+
+	// then
+	assert.Equal(t, expected, actual.Operations)
+}
+
+func TestToTransactionSystemOperation(t *testing.T) {
+	// given
+	records := []*types.TransactionRecord{
+		{Payer: payer, Result: resultSuccess, Type: types.TransactionTypeFreeze, TypeName: "FREEZE"},
+		{Payer: payer, Result: resultSuccess, Type: 14, TypeName: "CRYPTOTRANSFER"},
+	}
+	expected := []*types.Operation{{Index: 0, Type: "FREEZE", Status: resultSuccess, Account: payer}}
+
+	// when
+	actual := ToTransaction(records, resultSuccess, FailedAmountsIntended, false)
+
+	// then
+	assert.Equal(t, expected, actual.Operations)
+}
+
+func TestAdjustCryptoTransfers(t *testing.T) {
+	// given
+	cryptoTransfers := []types.Transfer{
+		newHbarTransfer(payer, -10),
+		newHbarTransfer(payer, -5),
+		newHbarTransfer(receiver, 10),
+		newHbarTransfer(node, 5),
+	}
+	nonFeeTransferMap := map[int64]int64{payer.EncodedId: -10, receiver.EncodedId: 10}
+
+	// when
+	actual := adjustCryptoTransfers(cryptoTransfers, nonFeeTransferMap)
+
+	// then
+	assert.ElementsMatch(t, []types.Transfer{newHbarTransfer(payer, -5), newHbarTransfer(node, 5)}, actual)
+}
+
+func TestAggregateNonFeeTransfers(t *testing.T) {
+	// given
+	nonFeeTransfers := []types.Transfer{
+		newHbarTransfer(payer, -10),
+		newHbarTransfer(payer, -5),
+		newHbarTransfer(receiver, 15),
+	}
+
+	// when
+	actual := aggregateNonFeeTransfers(nonFeeTransfers)
+
+	// then
+	assert.Equal(t, map[int64]int64{payer.EncodedId: -15, receiver.EncodedId: 15}, actual)
+}
+
+func newHbarTransfer(account types.Account, amount int64) types.Transfer {
+	return types.Transfer{Account: account, Amount: &types.HbarAmount{Value: amount}}
+}
+
+// newFailedTransferRecord returns a failed crypto transfer whose crypto transfers are only the fee transfers
+func newFailedTransferRecord() *types.TransactionRecord {
+	return &types.TransactionRecord{
+		CryptoTransfers: []types.Transfer{newHbarTransfer(payer, -5), newHbarTransfer(node, 5)},
+		Hash:            "0x0102",
+		NonFeeTransfers: []types.Transfer{newHbarTransfer(payer, -10), newHbarTransfer(receiver, 10)},
+		Payer:           payer,
+		Result:          resultFail,
+		TokenTransfers:  []types.Transfer{newTokenTransfer(receiver, 10)},
+		Type:            14,
+		TypeName:        "CRYPTOTRANSFER",
+	}
+}
+
+func newTokenTransfer(account types.Account, amount int64) types.Transfer {
+	return types.Transfer{Account: account, Amount: &types.TokenAmount{Decimals: 2, TokenId: tokenId, Value: amount}}
+}
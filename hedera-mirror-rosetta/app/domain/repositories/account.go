@@ -27,5 +27,14 @@ import (
 
 // AccountRepository Interface that all AccountRepository structs must implement
 type AccountRepository interface {
-	RetrieveBalanceAtBlock(addressStr string, consensusEnd int64) ([]types.Amount, *rTypes.Error)
+	FindByPublicKey(publicKey []byte) ([]types.Account, *rTypes.Error)
+	FindExpiry(addressStr string) (*types.AccountExpiry, *rTypes.Error)
+	FindKey(addressStr string) ([]byte, *rTypes.Error)
+	RetrieveBalanceAtBlock(
+		addressStr string,
+		consensusEnd int64,
+		tokenIds []int64,
+		afterTokenId int64,
+		limit int,
+	) ([]types.Amount, *rTypes.Error)
 }
@@ -27,9 +27,12 @@ import (
 
 // BlockRepository Interface that all BlockRepository structs must implement
 type BlockRepository interface {
+	FindBetweenIndexes(start int64, end int64) ([]*types.Block, *rTypes.Error)
+	FindByConsensusTimestamp(timestamp int64) (*types.Block, *rTypes.Error)
 	FindByIndex(index int64) (*types.Block, *rTypes.Error)
 	FindByHash(hash string) (*types.Block, *rTypes.Error)
 	FindByIdentifier(index int64, hash string) (*types.Block, *rTypes.Error)
+	RefreshLatest() (*types.Block, *rTypes.Error)
 	RetrieveGenesis() (*types.Block, *rTypes.Error)
 	RetrieveLatest() (*types.Block, *rTypes.Error)
 }
@@ -28,4 +28,6 @@ import (
 // TokenRepository Interface that all TokenRepository structs must implement
 type TokenRepository interface {
 	Find(tokenIdStr string) (*types.Token, *rTypes.Error)
+	FindAt(tokenIdStr string, consensusTimestamp int64) (*types.Token, *rTypes.Error)
+	FindInfoAt(tokenIdStr string, consensusTimestamp int64) (*types.TokenInfo, *rTypes.Error)
 }
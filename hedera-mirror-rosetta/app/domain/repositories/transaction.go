@@ -29,6 +29,13 @@ import (
 type TransactionRepository interface {
 	FindByHashInBlock(identifier string, consensusStart int64, consensusEnd int64) (*types.Transaction, *rTypes.Error)
 	FindBetween(start int64, end int64) ([]*types.Transaction, *rTypes.Error)
+	FindBetweenWithMaxOperations(start int64, end int64, maxOperations int) (
+		[]*types.Transaction,
+		[]string,
+		*rTypes.Error,
+	)
+	FindByTransactionId(transactionId types.TransactionId) ([]*types.Transaction, *rTypes.Error)
+	FindRawByHash(hash string) ([]*types.RawTransaction, *rTypes.Error)
 	Results() (map[int]string, *rTypes.Error)
 	Types() (map[int]string, *rTypes.Error)
 	TypesAsArray() ([]string, *rTypes.Error)
@@ -21,11 +21,13 @@
 package entityid
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/parse"
+	"github.com/hashgraph/hedera-sdk-go/v2"
 )
 
 const (
@@ -35,9 +37,14 @@ const (
 	shardMask  int64 = (int64(1) << shardBits) - 1
 	realmMask  int64 = (int64(1) << realmBits) - 1
 	numberMask int64 = (int64(1) << numberBits) - 1
+
+	checksumLength = 5
 )
 
 var (
+	// ErrChecksumMismatch is returned when the checksum of an entity id doesn't match the configured network
+	ErrChecksumMismatch = errors.New("entity id checksum mismatch")
+
 	errorEntity   = fmt.Errorf("invalid entity")
 	errorEntityId = fmt.Errorf("invalid entityId")
 	errorShardId  = fmt.Errorf("invalid shardId")
@@ -60,23 +67,35 @@ func (e *EntityId) String() string {
 	return fmt.Sprintf("%d.%d.%d", e.ShardNum, e.RealmNum, e.EntityNum)
 }
 
-func (e *EntityId) UnmarshalJSON(data []byte) error {
-	str := parse.SafeUnquote(string(data))
-
-	var entityId EntityId
-	var err error
-	if strings.Contains(str, ".") {
-		entityId, err = FromString(str)
-	} else {
-		var encodedId int64
-		encodedId, err = strconv.ParseInt(str, 10, 64)
-		if err != nil {
-			return err
-		}
+// StringWithChecksum returns the shard.realm.num string with the checksum of the network with ledgerId, e.g.,
+// 0.0.123-vfmkw for mainnet
+func (e *EntityId) StringWithChecksum(ledgerId []byte) string {
+	address := e.String()
+	return fmt.Sprintf("%s-%s", address, checksum(ledgerId, address))
+}
 
-		entityId, err = Decode(encodedId)
-	}
+// ToSdkAccountId returns the entity id as a hedera.AccountID
+func (e *EntityId) ToSdkAccountId() hedera.AccountID {
+	return hedera.AccountID{Shard: uint64(e.ShardNum), Realm: uint64(e.RealmNum), Account: uint64(e.EntityNum)}
+}
 
+// ToSdkScheduleId returns the entity id as a hedera.ScheduleID
+func (e *EntityId) ToSdkScheduleId() hedera.ScheduleID {
+	return hedera.ScheduleID{Shard: uint64(e.ShardNum), Realm: uint64(e.RealmNum), Schedule: uint64(e.EntityNum)}
+}
+
+// ToSdkTokenId returns the entity id as a hedera.TokenID
+func (e *EntityId) ToSdkTokenId() hedera.TokenID {
+	return hedera.TokenID{Shard: uint64(e.ShardNum), Realm: uint64(e.RealmNum), Token: uint64(e.EntityNum)}
+}
+
+// ToSdkTopicId returns the entity id as a hedera.TopicID
+func (e *EntityId) ToSdkTopicId() hedera.TopicID {
+	return hedera.TopicID{Shard: uint64(e.ShardNum), Realm: uint64(e.RealmNum), Topic: uint64(e.EntityNum)}
+}
+
+func (e *EntityId) UnmarshalJSON(data []byte) error {
+	entityId, err := Parse(parse.SafeUnquote(string(data)))
 	if err != nil {
 		return err
 	}
@@ -111,7 +130,44 @@ func Decode(encodedID int64) (EntityId, error) {
 	}, nil
 }
 
+// FromSdkAccountId returns the EntityId of the hedera.AccountID
+func FromSdkAccountId(accountId hedera.AccountID) (EntityId, error) {
+	return fromNums(int64(accountId.Shard), int64(accountId.Realm), int64(accountId.Account))
+}
+
+// FromSdkTokenId returns the EntityId of the hedera.TokenID
+func FromSdkTokenId(tokenId hedera.TokenID) (EntityId, error) {
+	return fromNums(int64(tokenId.Shard), int64(tokenId.Realm), int64(tokenId.Token))
+}
+
+// Parse parses the entity id in either the shard.realm.num form or the encoded id form
+func Parse(entityId string) (EntityId, error) {
+	if strings.Contains(entityId, ".") {
+		return FromString(entityId)
+	}
+
+	encodedId, err := strconv.ParseInt(entityId, 10, 64)
+	if err != nil {
+		return EntityId{}, err
+	}
+
+	return Decode(encodedId)
+}
+
+// FromString parses the entity id in the shard.realm.num form. An entity id with a checksum is rejected since there's
+// no ledger id to validate it against, use FromStringWithLedgerId instead
 func FromString(entityId string) (EntityId, error) {
+	return FromStringWithLedgerId(entityId, nil)
+}
+
+// FromStringWithLedgerId parses the entity id in the shard.realm.num form with an optional checksum, e.g.,
+// 0.0.123-vfmkw. The checksum is validated against ledgerId, so an entity id with a checksum is rejected if ledgerId is
+// nil
+func FromStringWithLedgerId(entityId string, ledgerId []byte) (EntityId, error) {
+	if index := strings.Index(entityId, "-"); index != -1 {
+		return fromStringWithChecksum(entityId[:index], entityId[index+1:], ledgerId)
+	}
+
 	inputs := strings.Split(entityId, ".")
 	if len(inputs) != 3 {
 		return EntityId{}, errorEntity
@@ -132,6 +188,27 @@ func FromString(entityId string) (EntityId, error) {
 		return EntityId{}, errorEntityId
 	}
 
+	return fromNums(shardNum, realmNum, entityNum)
+}
+
+func fromStringWithChecksum(address string, givenChecksum string, ledgerId []byte) (EntityId, error) {
+	if len(givenChecksum) != checksumLength || strings.Trim(givenChecksum, "abcdefghijklmnopqrstuvwxyz") != "" {
+		return EntityId{}, errorEntity
+	}
+
+	entityId, err := FromString(address)
+	if err != nil {
+		return EntityId{}, err
+	}
+
+	if ledgerId == nil || checksum(ledgerId, entityId.String()) != givenChecksum {
+		return EntityId{}, ErrChecksumMismatch
+	}
+
+	return entityId, nil
+}
+
+func fromNums(shardNum int64, realmNum int64, entityNum int64) (EntityId, error) {
 	encodedId, err := Encode(shardNum, realmNum, entityNum)
 	if err != nil {
 		return EntityId{}, err
@@ -144,3 +221,46 @@ func FromString(entityId string) (EntityId, error) {
 		EncodedId: encodedId,
 	}, nil
 }
+
+// checksum calculates the checksum of the shard.realm.num address for the network with ledgerId as defined in HIP-15
+func checksum(ledgerId []byte, address string) string {
+	const (
+		p3 = 26 * 26 * 26
+		p5 = 26 * 26 * 26 * 26 * 26
+		m  = 1000003
+		w  = 31
+	)
+
+	// weighted sum of the digits, and the sums of the digits at the even and the odd positions, with '.' as 10
+	s, s0, s1 := 0, 0, 0
+	for i, char := range address {
+		digit := 10
+		if char != '.' {
+			digit = int(char - '0')
+		}
+
+		s = (w*s + digit) % p3
+		if i%2 == 0 {
+			s0 = (s0 + digit) % 11
+		} else {
+			s1 = (s1 + digit) % 11
+		}
+	}
+
+	// hash of the ledger id followed by 6 zero bytes
+	sh := 0
+	for _, b := range append(append([]byte{}, ledgerId...), make([]byte, 6)...) {
+		sh = (w*sh + int(b)) % p5
+	}
+
+	c := ((((len(address)%5)*11+s0)*11+s1)*p3 + s + sh) % p5
+	c = (c * m) % p5
+
+	letters := make([]byte, checksumLength)
+	for i := checksumLength - 1; i >= 0; i-- {
+		letters[i] = byte('a' + c%26)
+		c /= 26
+	}
+
+	return string(letters)
+}
@@ -26,6 +26,7 @@ import (
 	"math"
 	"testing"
 
+	"github.com/hashgraph/hedera-sdk-go/v2"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -208,6 +209,51 @@ func TestEntityIdFromString(t *testing.T) {
 	}
 }
 
+func TestEntityIdFromStringWithChecksum(t *testing.T) {
+	var tests = []struct {
+		name     string
+		entityId string
+		ledgerId []byte
+		expected EntityId
+		err      error
+	}{
+		{
+			name:     "Mainnet",
+			entityId: "0.0.123-vfmkw",
+			ledgerId: []byte{0},
+			expected: EntityId{EntityNum: 123, EncodedId: 123},
+		},
+		{
+			name:     "Testnet",
+			entityId: "0.0.123-esxsf",
+			ledgerId: []byte{1},
+			expected: EntityId{EntityNum: 123, EncodedId: 123},
+		},
+		{name: "OtherNetwork", entityId: "0.0.123-vfmkw", ledgerId: []byte{1}, err: ErrChecksumMismatch},
+		{name: "NoLedgerId", entityId: "0.0.123-vfmkw", err: ErrChecksumMismatch},
+		{name: "WrongChecksum", entityId: "0.0.124-vfmkw", ledgerId: []byte{0}, err: ErrChecksumMismatch},
+		{name: "InvalidChecksum", entityId: "0.0.123-VFMKW", ledgerId: []byte{0}, err: errorEntity},
+		{name: "ShortChecksum", entityId: "0.0.123-vfmk", ledgerId: []byte{0}, err: errorEntity},
+		{name: "InvalidEntityId", entityId: "0.0.a-vfmkw", ledgerId: []byte{0}, err: errorEntityId},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := FromStringWithLedgerId(tt.entityId, tt.ledgerId)
+
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestEntityIdFromStringRejectsChecksum(t *testing.T) {
+	actual, err := FromString("0.0.123-vfmkw")
+
+	assert.Equal(t, ErrChecksumMismatch, err)
+	assert.Equal(t, EntityId{}, actual)
+}
+
 func TestEntityIdFromStringThrows(t *testing.T) {
 	for _, tt := range invalidEntityIdStrs {
 		res, err := FromString(tt)
@@ -255,3 +301,77 @@ func TestEntityIdDecodeThrows(t *testing.T) {
 	assert.Equal(t, EntityId{}, res)
 	assert.Error(t, err)
 }
+
+func TestEntityIdParse(t *testing.T) {
+	var testData = []struct {
+		entity   string
+		expected EntityId
+	}{
+		{"0.0.10", EntityId{EntityNum: 10, EncodedId: 10}},
+		{"10", EntityId{EntityNum: 10, EncodedId: 10}},
+		{"10.10.10", EntityId{ShardNum: 10, RealmNum: 10, EntityNum: 10, EncodedId: 2814792716779530}},
+		{"2814792716779530", EntityId{ShardNum: 10, RealmNum: 10, EntityNum: 10, EncodedId: 2814792716779530}},
+	}
+
+	for _, tt := range testData {
+		res, err := Parse(tt.entity)
+		assert.Nil(t, err)
+		assert.Equal(t, tt.expected, res)
+	}
+}
+
+func TestEntityIdParseThrows(t *testing.T) {
+	for _, tt := range append(invalidEntityIdStrs, "-1", "0.0.4294967296") {
+		res, err := Parse(tt)
+		assert.Equal(t, EntityId{}, res)
+		assert.Error(t, err)
+	}
+}
+
+func TestEntityIdStringWithChecksum(t *testing.T) {
+	var testData = []struct {
+		entityId EntityId
+		ledgerId []byte
+		expected string
+	}{
+		{EntityId{EntityNum: 123, EncodedId: 123}, []byte{0}, "0.0.123-vfmkw"},
+		{EntityId{EntityNum: 3, EncodedId: 3}, []byte{0}, "0.0.3-tzfmz"},
+		{EntityId{}, []byte{0}, "0.0.0-uvnqa"},
+		{EntityId{ShardNum: 1, RealmNum: 2, EntityNum: 98765}, []byte{0}, "1.2.98765-kjbck"},
+		{EntityId{EntityNum: 123, EncodedId: 123}, []byte{1}, "0.0.123-esxsf"},
+		{EntityId{EntityNum: 123, EncodedId: 123}, []byte{2}, "0.0.123-ogizo"},
+	}
+
+	for _, tt := range testData {
+		assert.Equal(t, tt.expected, tt.entityId.StringWithChecksum(tt.ledgerId))
+	}
+}
+
+func TestEntityIdToSdkIds(t *testing.T) {
+	entityId := EntityId{ShardNum: 1, RealmNum: 2, EntityNum: 3}
+
+	assert.Equal(t, hedera.AccountID{Shard: 1, Realm: 2, Account: 3}, entityId.ToSdkAccountId())
+	assert.Equal(t, hedera.ScheduleID{Shard: 1, Realm: 2, Schedule: 3}, entityId.ToSdkScheduleId())
+	assert.Equal(t, hedera.TokenID{Shard: 1, Realm: 2, Token: 3}, entityId.ToSdkTokenId())
+	assert.Equal(t, hedera.TopicID{Shard: 1, Realm: 2, Topic: 3}, entityId.ToSdkTopicId())
+}
+
+func TestEntityIdFromSdkIds(t *testing.T) {
+	expected := EntityId{ShardNum: 10, RealmNum: 10, EntityNum: 10, EncodedId: 2814792716779530}
+
+	actual, err := FromSdkAccountId(hedera.AccountID{Shard: 10, Realm: 10, Account: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+
+	actual, err = FromSdkTokenId(hedera.TokenID{Shard: 10, Realm: 10, Token: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestEntityIdFromSdkIdsThrows(t *testing.T) {
+	_, err := FromSdkAccountId(hedera.AccountID{Account: uint64(numberMask) + 1})
+	assert.Error(t, err)
+
+	_, err = FromSdkTokenId(hedera.TokenID{Shard: uint64(shardMask) + 1})
+	assert.Error(t, err)
+}
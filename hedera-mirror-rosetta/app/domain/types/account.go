@@ -21,9 +21,12 @@
 package types
 
 import (
+	goErrors "errors"
+
 	rTypes "github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
 )
 
 // Account is domain level struct used to represent Rosetta Account
@@ -31,6 +34,41 @@ type Account struct {
 	entityid.EntityId
 }
 
+// AccountExpiry is domain level struct used to represent when an account expires and the period it's auto renewed for
+// in seconds. Either is 0 if it's unknown. The deleted timestamp is when the account was deleted, or 0 if it's not
+type AccountExpiry struct {
+	AutoRenewPeriod     int64
+	DeletedTimestamp    int64
+	ExpirationTimestamp int64
+}
+
+// IsDeletedAt returns true if the account is deleted at the consensus timestamp
+func (a *AccountExpiry) IsDeletedAt(consensusTimestamp int64) bool {
+	return a.DeletedTimestamp != 0 && a.DeletedTimestamp <= consensusTimestamp
+}
+
+// ToMetadata returns the account expiry as a map to be used in rosetta metadata. The account is pending removal if it
+// has expired at the consensus timestamp, i.e., it's in the grace period before it's removed unless renewed. A deleted
+// account is never pending removal
+func (a *AccountExpiry) ToMetadata(consensusTimestamp int64, timestampStrings bool) map[string]interface{} {
+	deleted := a.IsDeletedAt(consensusTimestamp)
+	metadata := map[string]interface{}{
+		"pending_removal": !deleted && a.ExpirationTimestamp != 0 && a.ExpirationTimestamp <= consensusTimestamp,
+	}
+	if a.AutoRenewPeriod != 0 {
+		metadata["auto_renew_period"] = a.AutoRenewPeriod
+	}
+	if a.ExpirationTimestamp != 0 {
+		AddTimestampMetadata(metadata, "expiration_timestamp", a.ExpirationTimestamp, timestampStrings)
+	}
+	if deleted {
+		metadata["deleted"] = true
+		AddTimestampMetadata(metadata, "deleted_timestamp", a.DeletedTimestamp, timestampStrings)
+	}
+
+	return metadata
+}
+
 // NewAccountFromEncodedID - creates new instance of Account struct
 func NewAccountFromEncodedID(encodedID int64) (Account, error) {
 	entityId, err := entityid.Decode(encodedID)
@@ -46,10 +84,34 @@ func (a Account) ToRosetta() *rTypes.AccountIdentifier {
 	return &rTypes.AccountIdentifier{Address: a.String()}
 }
 
-// AccountFromString populates domain type Account from String Account
+// NewAccountIdentifier returns Rosetta type AccountIdentifier of the address holding the currency. If
+// settings.TokenSubAccounts is set, a token is held by the sub-account with the token id as the address
+func NewAccountIdentifier(
+	address string,
+	currency *rTypes.Currency,
+	settings *config.Settings,
+) *rTypes.AccountIdentifier {
+	accountIdentifier := &rTypes.AccountIdentifier{Address: address}
+	if settings.TokenSubAccounts && currency != nil && currency.Symbol != settings.CurrencyHbar.Symbol {
+		accountIdentifier.SubAccount = &rTypes.SubAccountIdentifier{Address: currency.Symbol}
+	}
+
+	return accountIdentifier
+}
+
+// AccountFromString populates domain type Account from String Account, which can't have a checksum
 func AccountFromString(account string) (Account, *rTypes.Error) {
-	entityId, err := entityid.FromString(account)
+	return AccountFromStringWithLedgerId(account, nil)
+}
+
+// AccountFromStringWithLedgerId populates domain type Account from String Account, which can have the checksum of the
+// network with ledgerId
+func AccountFromStringWithLedgerId(account string, ledgerId []byte) (Account, *rTypes.Error) {
+	entityId, err := entityid.FromStringWithLedgerId(account, ledgerId)
 	if err != nil {
+		if goErrors.Is(err, entityid.ErrChecksumMismatch) {
+			return Account{}, errors.ErrEntityIdChecksumMismatch
+		}
 		return Account{}, errors.ErrInvalidAccount
 	}
 	return Account{entityId}, nil
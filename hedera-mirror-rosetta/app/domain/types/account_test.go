@@ -26,6 +26,7 @@ import (
 	"github.com/coinbase/rosetta-sdk-go/types"
 	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -63,6 +64,38 @@ func TestToRosettaAccount(t *testing.T) {
 	assert.Equal(t, expectedAccount(), rosettaAccount)
 }
 
+func TestNewAccountIdentifier(t *testing.T) {
+	tokenCurrency := &types.Currency{Symbol: "0.0.1580", Decimals: 9}
+	tokenSubAccount := &types.SubAccountIdentifier{Address: "0.0.1580"}
+
+	var tests = []struct {
+		name             string
+		currency         *types.Currency
+		tokenSubAccounts bool
+		expected         *types.SubAccountIdentifier
+	}{
+		{name: "Hbar", currency: config.CurrencyHbar},
+		{name: "Token", currency: tokenCurrency},
+		{name: "NilCurrencyWithTokenSubAccounts", tokenSubAccounts: true},
+		{name: "HbarWithTokenSubAccounts", currency: config.CurrencyHbar, tokenSubAccounts: true},
+		{name: "TokenWithTokenSubAccounts", currency: tokenCurrency, tokenSubAccounts: true, expected: tokenSubAccount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given:
+			settings := config.NewSettings()
+			settings.TokenSubAccounts = tt.tokenSubAccounts
+
+			// when:
+			actual := NewAccountIdentifier("0.0.1", tt.currency, settings)
+
+			// then:
+			assert.Equal(t, &types.AccountIdentifier{Address: "0.0.1", SubAccount: tt.expected}, actual)
+		})
+	}
+}
+
 func TestNewAccountFromEncodedID(t *testing.T) {
 	// given:
 	var testData = []struct {
@@ -162,3 +195,110 @@ func TestAccountFromStringThrows(t *testing.T) {
 		assert.Equal(t, errors.ErrInvalidAccount, err)
 	}
 }
+
+func TestAccountFromStringWithLedgerId(t *testing.T) {
+	// given
+	settings := config.NewSettings()
+	settings.ConfigureLedgerId("mainnet")
+
+	// when
+	res, err := AccountFromStringWithLedgerId("0.0.123-vfmkw", settings.LedgerId)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, exampleAccountWith(0, 0, 123), res)
+}
+
+func TestAccountFromStringWithLedgerIdThrowsChecksumMismatch(t *testing.T) {
+	var tests = []struct {
+		name     string
+		ledgerId []byte
+	}{
+		{name: "OtherNetwork", ledgerId: []byte{1}},
+		{name: "NoLedgerId"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			res, err := AccountFromStringWithLedgerId("0.0.123-vfmkw", tt.ledgerId)
+
+			// then
+			assert.Equal(t, zeroAccount, res)
+			assert.Equal(t, errors.ErrEntityIdChecksumMismatch, err)
+		})
+	}
+}
+
+func TestAccountFromStringThrowsChecksumMismatch(t *testing.T) {
+	// when
+	res, err := AccountFromString("0.0.123-vfmkw")
+
+	// then
+	assert.Equal(t, zeroAccount, res)
+	assert.Equal(t, errors.ErrEntityIdChecksumMismatch, err)
+}
+
+func TestAccountExpiryToMetadata(t *testing.T) {
+	var tests = []struct {
+		name               string
+		expiry             *AccountExpiry
+		consensusTimestamp int64
+		expected           map[string]interface{}
+	}{
+		{
+			name:               "NotExpired",
+			expiry:             &AccountExpiry{AutoRenewPeriod: 100, ExpirationTimestamp: 1000},
+			consensusTimestamp: 999,
+			expected: map[string]interface{}{
+				"auto_renew_period":    int64(100),
+				"expiration_timestamp": int64(1000),
+				"pending_removal":      false,
+			},
+		},
+		{
+			name:               "Expired",
+			expiry:             &AccountExpiry{AutoRenewPeriod: 100, ExpirationTimestamp: 1000},
+			consensusTimestamp: 1000,
+			expected: map[string]interface{}{
+				"auto_renew_period":    int64(100),
+				"expiration_timestamp": int64(1000),
+				"pending_removal":      true,
+			},
+		},
+		{
+			name:               "Deleted",
+			expiry:             &AccountExpiry{AutoRenewPeriod: 100, DeletedTimestamp: 900, ExpirationTimestamp: 1000},
+			consensusTimestamp: 1000,
+			expected: map[string]interface{}{
+				"auto_renew_period":    int64(100),
+				"deleted":              true,
+				"deleted_timestamp":    int64(900),
+				"expiration_timestamp": int64(1000),
+				"pending_removal":      false,
+			},
+		},
+		{
+			name:               "DeletedLater",
+			expiry:             &AccountExpiry{AutoRenewPeriod: 100, DeletedTimestamp: 1001, ExpirationTimestamp: 1000},
+			consensusTimestamp: 1000,
+			expected: map[string]interface{}{
+				"auto_renew_period":    int64(100),
+				"expiration_timestamp": int64(1000),
+				"pending_removal":      true,
+			},
+		},
+		{
+			name:               "Unknown",
+			expiry:             &AccountExpiry{},
+			consensusTimestamp: 1000,
+			expected:           map[string]interface{}{"pending_removal": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.expiry.ToMetadata(tt.consensusTimestamp, false))
+		})
+	}
+}
@@ -0,0 +1,105 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package types
+
+import (
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+)
+
+// AddressBook is domain level struct used to represent the network address book with its nodes
+type AddressBook struct {
+	ConsensusTimestamp int64
+	FileId             entityid.EntityId
+	Nodes              []*AddressBookNode
+}
+
+// AddressBookNode is domain level struct used to represent a consensus node in the address book
+type AddressBookNode struct {
+	Description      string
+	NodeAccountId    Account
+	NodeCertHash     string
+	NodeId           int64
+	PublicKey        string
+	ServiceEndpoints []ServiceEndpoint
+	Stake            int64
+}
+
+// ServiceEndpoint is domain level struct used to represent a gRPC endpoint of a consensus node
+type ServiceEndpoint struct {
+	IpAddressV4 string
+	Port        int32
+}
+
+// ToMetadata returns the address book as a map to be used in rosetta metadata
+func (a *AddressBook) ToMetadata(timestampStrings bool) map[string]interface{} {
+	nodes := make([]map[string]interface{}, 0, len(a.Nodes))
+	for _, node := range a.Nodes {
+		nodes = append(nodes, node.ToMetadata())
+	}
+
+	metadata := map[string]interface{}{
+		"file_id": a.FileId.String(),
+		"nodes":   nodes,
+	}
+	AddTimestampMetadata(metadata, "consensus_timestamp", a.ConsensusTimestamp, timestampStrings)
+
+	return metadata
+}
+
+// ToMetadata returns the node as a map to be used in rosetta metadata
+func (n *AddressBookNode) ToMetadata() map[string]interface{} {
+	return map[string]interface{}{
+		"description":       n.Description,
+		"node_account_id":   n.NodeAccountId.String(),
+		"node_cert_hash":    n.NodeCertHash,
+		"node_id":           n.NodeId,
+		"public_key":        n.PublicKey,
+		"service_endpoints": n.serviceEndpointsMetadata(),
+		"stake":             n.Stake,
+	}
+}
+
+// ToRosettaPeer returns the node as a rosetta peer identified by its node account id. The keys and the certificate
+// hash are left out of the metadata since the peers are polled by the clients with /network/status
+func (n *AddressBookNode) ToRosettaPeer() *rTypes.Peer {
+	return &rTypes.Peer{
+		PeerID: n.NodeAccountId.String(),
+		Metadata: map[string]interface{}{
+			"description":       n.Description,
+			"node_id":           n.NodeId,
+			"service_endpoints": n.serviceEndpointsMetadata(),
+			"stake":             n.Stake,
+		},
+	}
+}
+
+func (n *AddressBookNode) serviceEndpointsMetadata() []map[string]interface{} {
+	serviceEndpoints := make([]map[string]interface{}, 0, len(n.ServiceEndpoints))
+	for _, serviceEndpoint := range n.ServiceEndpoints {
+		serviceEndpoints = append(serviceEndpoints, map[string]interface{}{
+			"ip_address_v4": serviceEndpoint.IpAddressV4,
+			"port":          serviceEndpoint.Port,
+		})
+	}
+
+	return serviceEndpoints
+}
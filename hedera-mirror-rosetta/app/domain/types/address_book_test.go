@@ -0,0 +1,116 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package types
+
+import (
+	"testing"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddressBookToMetadata(t *testing.T) {
+	// given
+	addressBook := &AddressBook{
+		ConsensusTimestamp: 100,
+		FileId:             entityid.EntityId{EntityNum: 102, EncodedId: 102},
+		Nodes: []*AddressBookNode{
+			{
+				Description:   "node 0",
+				NodeAccountId: Account{EntityId: entityid.EntityId{EntityNum: 3, EncodedId: 3}},
+				NodeCertHash:  "0a0b",
+				NodeId:        0,
+				PublicKey:     "308201a2",
+				ServiceEndpoints: []ServiceEndpoint{
+					{IpAddressV4: "10.0.0.1", Port: 50211},
+					{IpAddressV4: "10.0.0.1", Port: 50212},
+				},
+				Stake: 100,
+			},
+			{
+				NodeAccountId: Account{EntityId: entityid.EntityId{EntityNum: 4, EncodedId: 4}},
+				NodeId:        1,
+			},
+		},
+	}
+	expected := map[string]interface{}{
+		"consensus_timestamp": int64(100),
+		"file_id":             "0.0.102",
+		"nodes": []map[string]interface{}{
+			{
+				"description":     "node 0",
+				"node_account_id": "0.0.3",
+				"node_cert_hash":  "0a0b",
+				"node_id":         int64(0),
+				"public_key":      "308201a2",
+				"service_endpoints": []map[string]interface{}{
+					{"ip_address_v4": "10.0.0.1", "port": int32(50211)},
+					{"ip_address_v4": "10.0.0.1", "port": int32(50212)},
+				},
+				"stake": int64(100),
+			},
+			{
+				"description":       "",
+				"node_account_id":   "0.0.4",
+				"node_cert_hash":    "",
+				"node_id":           int64(1),
+				"public_key":        "",
+				"service_endpoints": []map[string]interface{}{},
+				"stake":             int64(0),
+			},
+		},
+	}
+
+	// when
+	actual := addressBook.ToMetadata(false)
+
+	// then
+	assert.Equal(t, expected, actual)
+}
+
+func TestAddressBookNodeToRosettaPeer(t *testing.T) {
+	// given
+	node := &AddressBookNode{
+		Description:      "node 0",
+		NodeAccountId:    Account{EntityId: entityid.EntityId{EntityNum: 3, EncodedId: 3}},
+		NodeCertHash:     "0a0b",
+		NodeId:           0,
+		PublicKey:        "308201a2",
+		ServiceEndpoints: []ServiceEndpoint{{IpAddressV4: "10.0.0.1", Port: 50211}},
+		Stake:            100,
+	}
+	expected := &rTypes.Peer{
+		PeerID: "0.0.3",
+		Metadata: map[string]interface{}{
+			"description":       "node 0",
+			"node_id":           int64(0),
+			"service_endpoints": []map[string]interface{}{{"ip_address_v4": "10.0.0.1", "port": int32(50211)}},
+			"stake":             int64(100),
+		},
+	}
+
+	// when
+	actual := node.ToRosettaPeer()
+
+	// then
+	assert.Equal(t, expected, actual)
+}
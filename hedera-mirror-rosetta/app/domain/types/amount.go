@@ -29,18 +29,18 @@ import (
 )
 
 type Amount interface {
-	ToRosetta() *rTypes.Amount
+	ToRosetta(settings *config.Settings) *rTypes.Amount
 }
 
 type HbarAmount struct {
 	Value int64
 }
 
-// ToRosetta returns Rosetta type Amount with hbar currency
-func (h *HbarAmount) ToRosetta() *rTypes.Amount {
+// ToRosetta returns Rosetta type Amount with the hbar currency of the settings
+func (h *HbarAmount) ToRosetta(settings *config.Settings) *rTypes.Amount {
 	return &rTypes.Amount{
 		Value:    strconv.FormatInt(h.Value, 10),
-		Currency: config.CurrencyHbar,
+		Currency: settings.CurrencyHbar,
 	}
 }
 
@@ -52,7 +52,7 @@ type TokenAmount struct {
 }
 
 // ToRosetta returns Rosetta type Amount with the token's currency
-func (t *TokenAmount) ToRosetta() *rTypes.Amount {
+func (t *TokenAmount) ToRosetta(*config.Settings) *rTypes.Amount {
 	return &rTypes.Amount{
 		Value: strconv.FormatInt(t.Value, 10),
 		Currency: &rTypes.Currency{
@@ -47,7 +47,7 @@ func TestHbarAmountToRosettaAmount(t *testing.T) {
 	// given
 
 	// when:
-	actual := hbarAmount.ToRosetta()
+	actual := hbarAmount.ToRosetta(config.NewSettings())
 
 	// then:
 	assert.Equal(t, hbarRosettaAmount, actual)
@@ -57,7 +57,7 @@ func TestTokenAmountToRosettaAmount(t *testing.T) {
 	// given
 
 	// when:
-	actual := tokenAmount.ToRosetta()
+	actual := tokenAmount.ToRosetta(config.NewSettings())
 
 	// then:
 	assert.Equal(t, tokenRosettaAmount, actual)
@@ -22,6 +22,7 @@ package types
 
 import (
 	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/hex"
 	"time"
 )
@@ -38,10 +39,16 @@ type Block struct {
 }
 
 // ToRosetta returns Rosetta type Block from the current domain type Block
-func (b *Block) ToRosetta() *rTypes.Block {
-	transactions := make([]*rTypes.Transaction, len(b.Transactions))
+func (b *Block) ToRosetta(settings *config.Settings) *rTypes.Block {
+	return b.ToRosettaWithAllocator(settings, nil)
+}
+
+// ToRosettaWithAllocator returns Rosetta type Block from the current domain type Block with the structs of the
+// transactions handed out by allocator
+func (b *Block) ToRosettaWithAllocator(settings *config.Settings, allocator *RosettaAllocator) *rTypes.Block {
+	transactions := allocator.newTransactions(len(b.Transactions))
 	for i, t := range b.Transactions {
-		transactions[i] = t.ToRosetta()
+		transactions[i] = t.ToRosettaWithAllocator(settings, allocator)
 	}
 
 	return &rTypes.Block{
@@ -22,6 +22,7 @@ package types
 
 import (
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -66,7 +67,7 @@ func expectedBlock() *types.Block {
 
 func TestToRosettaBlock(t *testing.T) {
 	// when:
-	rosettaBlockResult := exampleBlock().ToRosetta()
+	rosettaBlockResult := exampleBlock().ToRosetta(config.NewSettings())
 
 	// then:
 	assert.Equal(t, expectedBlock(), rosettaBlockResult)
@@ -0,0 +1,66 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package types
+
+// ExchangeRate is domain level struct used to represent an hbar to USD cent exchange rate
+type ExchangeRate struct {
+	CentEquiv      int32
+	ExpirationTime int64
+	HbarEquiv      int32
+}
+
+// ExchangeRateSet is domain level struct used to represent the content of the exchange rate file 0.0.112
+type ExchangeRateSet struct {
+	ConsensusTimestamp int64
+	CurrentRate        ExchangeRate
+	NextRate           ExchangeRate
+}
+
+// ToTinybars converts the tinycents to tinybars at the exchange rate, rounded up so a fee converted isn't
+// underestimated
+func (e ExchangeRate) ToTinybars(tinycents int64) int64 {
+	if e.CentEquiv <= 0 {
+		return 0
+	}
+
+	centEquiv := int64(e.CentEquiv)
+	return (tinycents*int64(e.HbarEquiv) + centEquiv - 1) / centEquiv
+}
+
+// ToMetadata returns the exchange rate as a map to be used in rosetta metadata
+func (e ExchangeRate) ToMetadata() map[string]interface{} {
+	return map[string]interface{}{
+		"cent_equivalent": e.CentEquiv,
+		"expiration_time": e.ExpirationTime,
+		"hbar_equivalent": e.HbarEquiv,
+	}
+}
+
+// ToMetadata returns the exchange rate set as a map to be used in rosetta metadata
+func (e *ExchangeRateSet) ToMetadata(timestampStrings bool) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"current_rate": e.CurrentRate.ToMetadata(),
+		"next_rate":    e.NextRate.ToMetadata(),
+	}
+	AddTimestampMetadata(metadata, "consensus_timestamp", e.ConsensusTimestamp, timestampStrings)
+
+	return metadata
+}
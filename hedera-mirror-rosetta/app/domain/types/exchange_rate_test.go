@@ -0,0 +1,75 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExchangeRateSetToMetadata(t *testing.T) {
+	// given
+	exchangeRateSet := &ExchangeRateSet{
+		ConsensusTimestamp: 100,
+		CurrentRate:        ExchangeRate{CentEquiv: 12, ExpirationTime: 1000, HbarEquiv: 1},
+		NextRate:           ExchangeRate{CentEquiv: 15, ExpirationTime: 2000, HbarEquiv: 1},
+	}
+	expected := map[string]interface{}{
+		"consensus_timestamp": int64(100),
+		"current_rate": map[string]interface{}{
+			"cent_equivalent": int32(12),
+			"expiration_time": int64(1000),
+			"hbar_equivalent": int32(1),
+		},
+		"next_rate": map[string]interface{}{
+			"cent_equivalent": int32(15),
+			"expiration_time": int64(2000),
+			"hbar_equivalent": int32(1),
+		},
+	}
+
+	// when
+	actual := exchangeRateSet.ToMetadata(false)
+
+	// then
+	assert.Equal(t, expected, actual)
+}
+
+func TestExchangeRateToTinybars(t *testing.T) {
+	var tests = []struct {
+		name         string
+		exchangeRate ExchangeRate
+		tinycents    int64
+		expected     int64
+	}{
+		{name: "Exact", exchangeRate: ExchangeRate{CentEquiv: 12, HbarEquiv: 1}, tinycents: 1200, expected: 100},
+		{name: "RoundedUp", exchangeRate: ExchangeRate{CentEquiv: 12, HbarEquiv: 1}, tinycents: 1201, expected: 101},
+		{name: "Zero", exchangeRate: ExchangeRate{CentEquiv: 12, HbarEquiv: 1}, expected: 0},
+		{name: "InvalidRate", exchangeRate: ExchangeRate{HbarEquiv: 1}, tinycents: 1200, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.exchangeRate.ToTinybars(tt.tinycents))
+		})
+	}
+}
@@ -0,0 +1,173 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package types
+
+// feeDivisorFactor is the factor the fee schedule prices are scaled by, i.e., they're in thousandths of a tinycent
+const feeDivisorFactor = 1000
+
+// subTypeDefault is the sub type of the resource prices without a special scope
+const subTypeDefault = "DEFAULT"
+
+// FeeComponents is domain level struct used to represent the resource prices of one of the node, network, and service
+// fee components
+type FeeComponents struct {
+	Bpr      int64
+	Bpt      int64
+	Constant int64
+	Gas      int64
+	Max      int64
+	Min      int64
+	Rbh      int64
+	Sbh      int64
+	Sbpr     int64
+	Tv       int64
+	Vpt      int64
+}
+
+// FeeData is domain level struct used to represent the resource prices of a transaction sub type
+type FeeData struct {
+	Network FeeComponents
+	Node    FeeComponents
+	Service FeeComponents
+	SubType string
+}
+
+// TransactionFeeSchedule is domain level struct used to represent the resource prices of a hedera functionality
+type TransactionFeeSchedule struct {
+	Fees          []FeeData
+	Functionality string
+}
+
+// FeeSchedule is domain level struct used to represent a fee schedule and the time in seconds it expires
+type FeeSchedule struct {
+	ExpiryTime              int64
+	TransactionFeeSchedules []TransactionFeeSchedule
+}
+
+// FeeScheduleSet is domain level struct used to represent the content of the fee schedule file 0.0.111
+type FeeScheduleSet struct {
+	ConsensusTimestamp int64
+	CurrentSchedule    FeeSchedule
+	NextSchedule       FeeSchedule
+}
+
+// ToMetadata returns the fee components as a map to be used in rosetta metadata
+func (f FeeComponents) ToMetadata() map[string]interface{} {
+	return map[string]interface{}{
+		"bpr":      f.Bpr,
+		"bpt":      f.Bpt,
+		"constant": f.Constant,
+		"gas":      f.Gas,
+		"max":      f.Max,
+		"min":      f.Min,
+		"rbh":      f.Rbh,
+		"sbh":      f.Sbh,
+		"sbpr":     f.Sbpr,
+		"tv":       f.Tv,
+		"vpt":      f.Vpt,
+	}
+}
+
+// ToMetadata returns the fee data as a map to be used in rosetta metadata
+func (f FeeData) ToMetadata() map[string]interface{} {
+	return map[string]interface{}{
+		"network":  f.Network.ToMetadata(),
+		"node":     f.Node.ToMetadata(),
+		"service":  f.Service.ToMetadata(),
+		"sub_type": f.SubType,
+	}
+}
+
+// ToMetadata returns the transaction fee schedule as a map to be used in rosetta metadata
+func (t TransactionFeeSchedule) ToMetadata() map[string]interface{} {
+	fees := make([]map[string]interface{}, 0, len(t.Fees))
+	for _, fee := range t.Fees {
+		fees = append(fees, fee.ToMetadata())
+	}
+
+	return map[string]interface{}{
+		"fees":                 fees,
+		"hedera_functionality": t.Functionality,
+	}
+}
+
+// ToMetadata returns the fee schedule as a map to be used in rosetta metadata. Only the transaction fee schedule of
+// the functionality is included unless it's empty
+func (f FeeSchedule) ToMetadata(functionality string) map[string]interface{} {
+	transactionFeeSchedules := make([]map[string]interface{}, 0, len(f.TransactionFeeSchedules))
+	for _, transactionFeeSchedule := range f.TransactionFeeSchedules {
+		if functionality == "" || transactionFeeSchedule.Functionality == functionality {
+			transactionFeeSchedules = append(transactionFeeSchedules, transactionFeeSchedule.ToMetadata())
+		}
+	}
+
+	return map[string]interface{}{
+		"expiry_time":               f.ExpiryTime,
+		"transaction_fee_schedules": transactionFeeSchedules,
+	}
+}
+
+// EstimateFee returns the estimated fee in tinycents of a transaction of the functionality with the number of
+// signatures, and whether the fee schedule prices the functionality. Only the constant and the per signature prices of
+// the default sub type are accounted for, since the usage of the other resources isn't known before the transaction
+// is built
+func (f FeeSchedule) EstimateFee(functionality string, signatures int64) (int64, bool) {
+	for _, transactionFeeSchedule := range f.TransactionFeeSchedules {
+		if transactionFeeSchedule.Functionality != functionality {
+			continue
+		}
+
+		for _, fee := range transactionFeeSchedule.Fees {
+			if fee.SubType == subTypeDefault {
+				return fee.Node.estimateFee(signatures) + fee.Network.estimateFee(signatures) +
+					fee.Service.estimateFee(signatures), true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// ToMetadata returns the fee schedule set as a map to be used in rosetta metadata, see FeeSchedule.ToMetadata for the
+// functionality
+func (f *FeeScheduleSet) ToMetadata(functionality string, timestampStrings bool) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"current_fee_schedule": f.CurrentSchedule.ToMetadata(functionality),
+		"next_fee_schedule":    f.NextSchedule.ToMetadata(functionality),
+	}
+	AddTimestampMetadata(metadata, "consensus_timestamp", f.ConsensusTimestamp, timestampStrings)
+
+	return metadata
+}
+
+// estimateFee returns the price in tinycents of the component, bounded by its min and max the same way the network
+// does
+func (f FeeComponents) estimateFee(signatures int64) int64 {
+	fee := f.Constant + f.Vpt*signatures
+	if fee > f.Max {
+		fee = f.Max
+	}
+	if fee < f.Min {
+		fee = f.Min
+	}
+
+	return fee / feeDivisorFactor
+}
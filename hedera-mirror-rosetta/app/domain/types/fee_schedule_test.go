@@ -0,0 +1,173 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeeScheduleSetToMetadata(t *testing.T) {
+	// given
+	feeScheduleSet := &FeeScheduleSet{
+		ConsensusTimestamp: 100,
+		CurrentSchedule: FeeSchedule{
+			ExpiryTime: 1000,
+			TransactionFeeSchedules: []TransactionFeeSchedule{
+				{
+					Fees: []FeeData{
+						{
+							Network: FeeComponents{Constant: 2, Max: 100},
+							Node:    FeeComponents{Constant: 1, Max: 100, Vpt: 3},
+							Service: FeeComponents{Bpr: 4, Bpt: 5, Gas: 6, Min: 7, Rbh: 8, Sbh: 9, Sbpr: 10, Tv: 11},
+							SubType: "DEFAULT",
+						},
+					},
+					Functionality: "CryptoTransfer",
+				},
+				{Fees: []FeeData{}, Functionality: "TokenMint"},
+			},
+		},
+		NextSchedule: FeeSchedule{ExpiryTime: 2000},
+	}
+	components := func(bpr, bpt, constant, gas, max, min, rbh, sbh, sbpr, tv, vpt int64) map[string]interface{} {
+		return map[string]interface{}{
+			"bpr":      bpr,
+			"bpt":      bpt,
+			"constant": constant,
+			"gas":      gas,
+			"max":      max,
+			"min":      min,
+			"rbh":      rbh,
+			"sbh":      sbh,
+			"sbpr":     sbpr,
+			"tv":       tv,
+			"vpt":      vpt,
+		}
+	}
+	cryptoTransfer := map[string]interface{}{
+		"fees": []map[string]interface{}{
+			{
+				"network":  components(0, 0, 2, 0, 100, 0, 0, 0, 0, 0, 0),
+				"node":     components(0, 0, 1, 0, 100, 0, 0, 0, 0, 0, 3),
+				"service":  components(4, 5, 0, 6, 0, 7, 8, 9, 10, 11, 0),
+				"sub_type": "DEFAULT",
+			},
+		},
+		"hedera_functionality": "CryptoTransfer",
+	}
+	tokenMint := map[string]interface{}{
+		"fees":                 []map[string]interface{}{},
+		"hedera_functionality": "TokenMint",
+	}
+	nextFeeSchedule := map[string]interface{}{
+		"expiry_time":               int64(2000),
+		"transaction_fee_schedules": []map[string]interface{}{},
+	}
+
+	var tests = []struct {
+		name          string
+		functionality string
+		expected      []map[string]interface{}
+	}{
+		{name: "All", expected: []map[string]interface{}{cryptoTransfer, tokenMint}},
+		{name: "Functionality", functionality: "TokenMint", expected: []map[string]interface{}{tokenMint}},
+		{name: "UnknownFunctionality", functionality: "Unknown", expected: []map[string]interface{}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			actual := feeScheduleSet.ToMetadata(tt.functionality, false)
+
+			// then
+			assert.Equal(t, map[string]interface{}{
+				"consensus_timestamp": int64(100),
+				"current_fee_schedule": map[string]interface{}{
+					"expiry_time":               int64(1000),
+					"transaction_fee_schedules": tt.expected,
+				},
+				"next_fee_schedule": nextFeeSchedule,
+			}, actual)
+		})
+	}
+}
+
+func TestFeeScheduleEstimateFee(t *testing.T) {
+	// given
+	feeSchedule := FeeSchedule{
+		TransactionFeeSchedules: []TransactionFeeSchedule{
+			{
+				Fees: []FeeData{
+					{
+						Network: FeeComponents{Constant: 100000000, Max: 1000000000},
+						Node:    FeeComponents{Constant: 100000000, Max: 1000000000},
+						Service: FeeComponents{Constant: 100000000, Max: 1000000000},
+						SubType: "TOKEN_FUNGIBLE_COMMON",
+					},
+					{
+						Network: FeeComponents{Constant: 20000, Max: 1000000000},
+						Node:    FeeComponents{Constant: 10000, Max: 1000000000, Vpt: 5000},
+						Service: FeeComponents{Constant: 30000, Max: 1000000000},
+						SubType: "DEFAULT",
+					},
+				},
+				Functionality: "CryptoTransfer",
+			},
+			{
+				Fees: []FeeData{
+					{
+						Network: FeeComponents{Constant: 1000, Max: 5000},
+						Node:    FeeComponents{Constant: 1000, Max: 1000000, Min: 8000},
+						Service: FeeComponents{Constant: 1000000, Max: 90000},
+						SubType: "DEFAULT",
+					},
+				},
+				Functionality: "TokenMint",
+			},
+		},
+	}
+
+	var tests = []struct {
+		name          string
+		functionality string
+		signatures    int64
+		expected      int64
+		found         bool
+	}{
+		{name: "OneSignature", functionality: "CryptoTransfer", signatures: 1, expected: 65, found: true},
+		{name: "ThreeSignatures", functionality: "CryptoTransfer", signatures: 3, expected: 75, found: true},
+		{name: "Bounded", functionality: "TokenMint", signatures: 1, expected: 99, found: true},
+		{name: "NotPriced", functionality: "TokenBurn", signatures: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			actual, found := feeSchedule.EstimateFee(tt.functionality, tt.signatures)
+
+			// then
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package types
+
+const (
+	NftTransferTypeBurn     = "BURN"
+	NftTransferTypeMint     = "MINT"
+	NftTransferTypeTransfer = "TRANSFER"
+	NftTransferTypeWipe     = "WIPE"
+)
+
+// NftTransfer is domain level struct used to represent a change of the owner of an nft serial. The sender is nil for a
+// mint, and the receiver is nil for a burn or a wipe
+type NftTransfer struct {
+	ConsensusTimestamp int64
+	Receiver           *Account
+	Sender             *Account
+	Type               string
+}
+
+// ToMetadata returns the nft transfer as a map to be used in rosetta metadata
+func (n *NftTransfer) ToMetadata(timestampStrings bool) map[string]interface{} {
+	metadata := map[string]interface{}{"type": n.Type}
+	AddTimestampMetadata(metadata, "consensus_timestamp", n.ConsensusTimestamp, timestampStrings)
+
+	if n.Receiver != nil {
+		metadata["receiver_account_id"] = n.Receiver.String()
+	}
+
+	if n.Sender != nil {
+		metadata["sender_account_id"] = n.Sender.String()
+	}
+
+	return metadata
+}
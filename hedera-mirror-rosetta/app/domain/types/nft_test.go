@@ -0,0 +1,79 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package types
+
+import (
+	"testing"
+
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNftTransferToMetadata(t *testing.T) {
+	receiver := &Account{EntityId: entityid.EntityId{EntityNum: 1001, EncodedId: 1001}}
+	sender := &Account{EntityId: entityid.EntityId{EntityNum: 1002, EncodedId: 1002}}
+
+	var tests = []struct {
+		name     string
+		input    *NftTransfer
+		expected map[string]interface{}
+	}{
+		{
+			name:  "Mint",
+			input: &NftTransfer{ConsensusTimestamp: 100, Receiver: receiver, Type: NftTransferTypeMint},
+			expected: map[string]interface{}{
+				"consensus_timestamp": int64(100),
+				"receiver_account_id": "0.0.1001",
+				"type":                "MINT",
+			},
+		},
+		{
+			name: "Transfer",
+			input: &NftTransfer{
+				ConsensusTimestamp: 101,
+				Receiver:           receiver,
+				Sender:             sender,
+				Type:               NftTransferTypeTransfer,
+			},
+			expected: map[string]interface{}{
+				"consensus_timestamp": int64(101),
+				"receiver_account_id": "0.0.1001",
+				"sender_account_id":   "0.0.1002",
+				"type":                "TRANSFER",
+			},
+		},
+		{
+			name:  "Burn",
+			input: &NftTransfer{ConsensusTimestamp: 102, Sender: sender, Type: NftTransferTypeBurn},
+			expected: map[string]interface{}{
+				"consensus_timestamp": int64(102),
+				"sender_account_id":   "0.0.1002",
+				"type":                "BURN",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.input.ToMetadata(false))
+		})
+	}
+}
@@ -21,7 +21,10 @@
 package types
 
 import (
+	"sort"
+
 	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
 )
 
 // Operation is domain level struct used to represent Operation within Transaction
@@ -35,22 +38,66 @@ type Operation struct {
 }
 
 // ToRosetta returns Rosetta type Operation from the current domain type Operation
-func (o *Operation) ToRosetta() *rTypes.Operation {
+func (o *Operation) ToRosetta(settings *config.Settings) *rTypes.Operation {
+	return o.ToRosettaWithAllocator(settings, nil)
+}
+
+// ToRosettaWithAllocator returns Rosetta type Operation from the current domain type Operation with the structs
+// handed out by allocator
+func (o *Operation) ToRosettaWithAllocator(settings *config.Settings, allocator *RosettaAllocator) *rTypes.Operation {
 	var amount *rTypes.Amount
+	var currency *rTypes.Currency
 	if o.Amount != nil {
-		amount = o.Amount.ToRosetta()
+		amount = allocator.newAmount(o.Amount, settings)
+		currency = amount.Currency
+	}
+
+	rOperation := allocator.newOperation()
+	rOperation.OperationIdentifier.Index = o.Index
+	rOperation.RelatedOperations = []*rTypes.OperationIdentifier{}
+	rOperation.Type = o.Type
+	rOperation.Status = &o.Status
+	rOperation.Account = allocator.newAccountIdentifier(o.Account.String(), currency, settings)
+	rOperation.Amount = amount
+	rOperation.Metadata = o.Metadata
+	return rOperation
+}
+
+// SortOperations sorts the operations in the canonical order, i.e., by account, then amount, then token with hbar
+// before any token, and reassigns the 0-based contiguous operation indexes. Operations with equal sort keys keep their
+// relative order
+func SortOperations(operations []*Operation) {
+	sort.SliceStable(operations, func(i, j int) bool {
+		first := operations[i]
+		second := operations[j]
+
+		if first.Account.EncodedId != second.Account.EncodedId {
+			return first.Account.EncodedId < second.Account.EncodedId
+		}
+
+		firstValue, firstTokenId := getAmountSortKeys(first.Amount)
+		secondValue, secondTokenId := getAmountSortKeys(second.Amount)
+		if firstValue != secondValue {
+			return firstValue < secondValue
+		}
+
+		return firstTokenId < secondTokenId
+	})
+
+	for i, operation := range operations {
+		operation.Index = int64(i)
 	}
+}
 
-	rOperation := rTypes.Operation{
-		OperationIdentifier: &rTypes.OperationIdentifier{
-			Index: o.Index,
-		},
-		RelatedOperations: []*rTypes.OperationIdentifier{},
-		Type:              o.Type,
-		Status:            &o.Status,
-		Account:           o.Account.ToRosetta(),
-		Amount:            amount,
-		Metadata:          o.Metadata,
+// getAmountSortKeys returns the value and the encoded token id of the amount. The token id is 0 for hbar and both are
+// 0 for nil amount
+func getAmountSortKeys(amount Amount) (int64, int64) {
+	switch a := amount.(type) {
+	case *HbarAmount:
+		return a.Value, 0
+	case *TokenAmount:
+		return a.Value, a.TokenId.EncodedId
+	default:
+		return 0, 0
 	}
-	return &rOperation
 }
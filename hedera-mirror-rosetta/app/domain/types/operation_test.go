@@ -25,6 +25,7 @@ import (
 
 	"github.com/coinbase/rosetta-sdk-go/types"
 	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -83,11 +84,57 @@ func TestToRosettaOperation(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// when:
-			rosettaOperation := tt.input.ToRosetta()
+			rosettaOperation := tt.input.ToRosetta(config.NewSettings())
 
 			// then:
 			assert.Equal(t, tt.expected, rosettaOperation)
 		})
 	}
+}
+
+func TestToRosettaOperationWithTokenSubAccounts(t *testing.T) {
+	// given:
+	settings := config.NewSettings()
+	settings.TokenSubAccounts = true
+	expected := expectedOperation(tokenRosettaAmount)
+	expected.Account.SubAccount = &types.SubAccountIdentifier{Address: "0.0.1580"}
+
+	// when:
+	actual := exampleOperation(tokenAmount).ToRosetta(settings)
+
+	// then:
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, expectedOperation(hbarRosettaAmount), exampleOperation(hbarAmount).ToRosetta(settings))
+}
+
+func TestSortOperations(t *testing.T) {
+	// given:
+	account1 := Account{entityid.EntityId{EntityNum: 1, EncodedId: 1}}
+	account2 := Account{entityid.EntityId{EntityNum: 2, EncodedId: 2}}
+	token1 := entityid.EntityId{EntityNum: 10, EncodedId: 10}
+	token2 := entityid.EntityId{EntityNum: 11, EncodedId: 11}
+	operations := []*Operation{
+		{Index: 0, Account: account2, Amount: &HbarAmount{Value: 5}, Type: "first"},
+		{Index: 1, Account: account1, Amount: &TokenAmount{TokenId: token2, Value: -5}},
+		{Index: 2, Account: account1, Amount: &HbarAmount{Value: -5}},
+		{Index: 3, Account: account1, Amount: &TokenAmount{TokenId: token1, Value: -5}},
+		{Index: 4, Account: account2, Amount: &HbarAmount{Value: 5}, Type: "second"},
+		{Index: 5, Account: account1, Amount: &HbarAmount{Value: -10}},
+		{Index: 6, Account: account1},
+	}
+	expected := []*Operation{
+		{Index: 0, Account: account1, Amount: &HbarAmount{Value: -10}},
+		{Index: 1, Account: account1, Amount: &HbarAmount{Value: -5}},
+		{Index: 2, Account: account1, Amount: &TokenAmount{TokenId: token1, Value: -5}},
+		{Index: 3, Account: account1, Amount: &TokenAmount{TokenId: token2, Value: -5}},
+		{Index: 4, Account: account1},
+		{Index: 5, Account: account2, Amount: &HbarAmount{Value: 5}, Type: "first"},
+		{Index: 6, Account: account2, Amount: &HbarAmount{Value: 5}, Type: "second"},
+	}
+
+	// when:
+	SortOperations(operations)
 
+	// then:
+	assert.Equal(t, expected, operations)
 }
@@ -0,0 +1,341 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package types
+
+import (
+	"strconv"
+	"sync"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+)
+
+const (
+	minRosettaSlabSize = 256
+
+	// slabs grown beyond maxRosettaSlabSize by an unusually large block are dropped on release, so the pooled
+	// allocators don't pin the memory of a few huge blocks
+	maxRosettaSlabSize = 64 * 1024
+)
+
+var rosettaAllocatorPool = sync.Pool{New: func() interface{} { return &RosettaAllocator{} }}
+
+// RosettaAllocator hands out the per-transaction and per-operation Rosetta structs of a block from slabs which are
+// reused across requests, instead of allocating each struct on the heap. The structs are only valid until the
+// allocator is released. A nil RosettaAllocator allocates each struct on the heap
+type RosettaAllocator struct {
+	accounts               []rTypes.AccountIdentifier
+	amounts                []rTypes.Amount
+	currencies             []rTypes.Currency
+	operationIdentifiers   []rTypes.OperationIdentifier
+	operationPointers      []*rTypes.Operation
+	operations             []rTypes.Operation
+	subAccounts            []rTypes.SubAccountIdentifier
+	transactionIdentifiers []rTypes.TransactionIdentifier
+	transactionPointers    []*rTypes.Transaction
+	transactions           []rTypes.Transaction
+}
+
+// GetRosettaAllocator gets a RosettaAllocator from the pool
+func GetRosettaAllocator() *RosettaAllocator {
+	return rosettaAllocatorPool.Get().(*RosettaAllocator)
+}
+
+// Release puts the allocator back to the pool. The structs handed out by the allocator must not be used afterwards
+func (a *RosettaAllocator) Release() {
+	if a == nil {
+		return
+	}
+
+	a.accounts = resetAccountIdentifiers(a.accounts)
+	a.amounts = resetAmounts(a.amounts)
+	a.currencies = resetCurrencies(a.currencies)
+	a.operationIdentifiers = resetOperationIdentifiers(a.operationIdentifiers)
+	a.operationPointers = resetOperationPointers(a.operationPointers)
+	a.operations = resetOperations(a.operations)
+	a.subAccounts = resetSubAccountIdentifiers(a.subAccounts)
+	a.transactionIdentifiers = resetTransactionIdentifiers(a.transactionIdentifiers)
+	a.transactionPointers = resetTransactionPointers(a.transactionPointers)
+	a.transactions = resetTransactions(a.transactions)
+	rosettaAllocatorPool.Put(a)
+}
+
+func (a *RosettaAllocator) newAccountIdentifier(
+	address string,
+	currency *rTypes.Currency,
+	settings *config.Settings,
+) *rTypes.AccountIdentifier {
+	if a == nil {
+		return NewAccountIdentifier(address, currency, settings)
+	}
+
+	if len(a.accounts) == cap(a.accounts) {
+		a.accounts = make([]rTypes.AccountIdentifier, 0, getSlabSize(cap(a.accounts)))
+	}
+	a.accounts = a.accounts[:len(a.accounts)+1]
+	accountIdentifier := &a.accounts[len(a.accounts)-1]
+	*accountIdentifier = rTypes.AccountIdentifier{Address: address}
+
+	if settings.TokenSubAccounts && currency != nil && currency.Symbol != settings.CurrencyHbar.Symbol {
+		if len(a.subAccounts) == cap(a.subAccounts) {
+			a.subAccounts = make([]rTypes.SubAccountIdentifier, 0, getSlabSize(cap(a.subAccounts)))
+		}
+		a.subAccounts = a.subAccounts[:len(a.subAccounts)+1]
+		subAccount := &a.subAccounts[len(a.subAccounts)-1]
+		*subAccount = rTypes.SubAccountIdentifier{Address: currency.Symbol}
+		accountIdentifier.SubAccount = subAccount
+	}
+
+	return accountIdentifier
+}
+
+func (a *RosettaAllocator) newAmount(amount Amount, settings *config.Settings) *rTypes.Amount {
+	if a == nil {
+		return amount.ToRosetta(settings)
+	}
+
+	var value string
+	var currency *rTypes.Currency
+	switch typedAmount := amount.(type) {
+	case *HbarAmount:
+		value = strconv.FormatInt(typedAmount.Value, 10)
+		currency = settings.CurrencyHbar
+	case *TokenAmount:
+		if len(a.currencies) == cap(a.currencies) {
+			a.currencies = make([]rTypes.Currency, 0, getSlabSize(cap(a.currencies)))
+		}
+		a.currencies = a.currencies[:len(a.currencies)+1]
+		currency = &a.currencies[len(a.currencies)-1]
+		*currency = rTypes.Currency{Symbol: typedAmount.TokenId.String(), Decimals: int32(typedAmount.Decimals)}
+		value = strconv.FormatInt(typedAmount.Value, 10)
+	default:
+		return amount.ToRosetta(settings)
+	}
+
+	if len(a.amounts) == cap(a.amounts) {
+		a.amounts = make([]rTypes.Amount, 0, getSlabSize(cap(a.amounts)))
+	}
+	a.amounts = a.amounts[:len(a.amounts)+1]
+	rAmount := &a.amounts[len(a.amounts)-1]
+	*rAmount = rTypes.Amount{Value: value, Currency: currency}
+	return rAmount
+}
+
+func (a *RosettaAllocator) newOperation() *rTypes.Operation {
+	if a == nil {
+		return &rTypes.Operation{OperationIdentifier: &rTypes.OperationIdentifier{}}
+	}
+
+	if len(a.operations) == cap(a.operations) {
+		a.operations = make([]rTypes.Operation, 0, getSlabSize(cap(a.operations)))
+	}
+	a.operations = a.operations[:len(a.operations)+1]
+	operation := &a.operations[len(a.operations)-1]
+
+	if len(a.operationIdentifiers) == cap(a.operationIdentifiers) {
+		a.operationIdentifiers = make([]rTypes.OperationIdentifier, 0, getSlabSize(cap(a.operationIdentifiers)))
+	}
+	a.operationIdentifiers = a.operationIdentifiers[:len(a.operationIdentifiers)+1]
+	operationIdentifier := &a.operationIdentifiers[len(a.operationIdentifiers)-1]
+	*operationIdentifier = rTypes.OperationIdentifier{}
+
+	*operation = rTypes.Operation{OperationIdentifier: operationIdentifier}
+	return operation
+}
+
+func (a *RosettaAllocator) newOperations(count int) []*rTypes.Operation {
+	if a == nil || count > maxRosettaSlabSize {
+		return make([]*rTypes.Operation, count)
+	}
+
+	if len(a.operationPointers)+count > cap(a.operationPointers) {
+		size := getSlabSize(cap(a.operationPointers))
+		for size < count {
+			size *= 2
+		}
+		a.operationPointers = make([]*rTypes.Operation, 0, size)
+	}
+	start := len(a.operationPointers)
+	a.operationPointers = a.operationPointers[:start+count]
+	return a.operationPointers[start : start+count : start+count]
+}
+
+func (a *RosettaAllocator) newTransaction(hash string) *rTypes.Transaction {
+	if a == nil {
+		return &rTypes.Transaction{TransactionIdentifier: &rTypes.TransactionIdentifier{Hash: hash}}
+	}
+
+	if len(a.transactions) == cap(a.transactions) {
+		a.transactions = make([]rTypes.Transaction, 0, getSlabSize(cap(a.transactions)))
+	}
+	a.transactions = a.transactions[:len(a.transactions)+1]
+	transaction := &a.transactions[len(a.transactions)-1]
+
+	if len(a.transactionIdentifiers) == cap(a.transactionIdentifiers) {
+		size := getSlabSize(cap(a.transactionIdentifiers))
+		a.transactionIdentifiers = make([]rTypes.TransactionIdentifier, 0, size)
+	}
+	a.transactionIdentifiers = a.transactionIdentifiers[:len(a.transactionIdentifiers)+1]
+	transactionIdentifier := &a.transactionIdentifiers[len(a.transactionIdentifiers)-1]
+	*transactionIdentifier = rTypes.TransactionIdentifier{Hash: hash}
+
+	*transaction = rTypes.Transaction{TransactionIdentifier: transactionIdentifier}
+	return transaction
+}
+
+func (a *RosettaAllocator) newTransactions(count int) []*rTypes.Transaction {
+	if a == nil || count > maxRosettaSlabSize {
+		return make([]*rTypes.Transaction, count)
+	}
+
+	if len(a.transactionPointers)+count > cap(a.transactionPointers) {
+		size := getSlabSize(cap(a.transactionPointers))
+		for size < count {
+			size *= 2
+		}
+		a.transactionPointers = make([]*rTypes.Transaction, 0, size)
+	}
+	start := len(a.transactionPointers)
+	a.transactionPointers = a.transactionPointers[:start+count]
+	return a.transactionPointers[start : start+count : start+count]
+}
+
+// reset* clear the slab so it doesn't keep the structs referenced by the released block alive, and drop it if it's
+// grown too large
+
+func resetAccountIdentifiers(slab []rTypes.AccountIdentifier) []rTypes.AccountIdentifier {
+	if cap(slab) > maxRosettaSlabSize {
+		return nil
+	}
+
+	for i := range slab {
+		slab[i] = rTypes.AccountIdentifier{}
+	}
+	return slab[:0]
+}
+
+func resetAmounts(slab []rTypes.Amount) []rTypes.Amount {
+	if cap(slab) > maxRosettaSlabSize {
+		return nil
+	}
+
+	for i := range slab {
+		slab[i] = rTypes.Amount{}
+	}
+	return slab[:0]
+}
+
+func resetCurrencies(slab []rTypes.Currency) []rTypes.Currency {
+	if cap(slab) > maxRosettaSlabSize {
+		return nil
+	}
+
+	for i := range slab {
+		slab[i] = rTypes.Currency{}
+	}
+	return slab[:0]
+}
+
+func resetOperationIdentifiers(slab []rTypes.OperationIdentifier) []rTypes.OperationIdentifier {
+	if cap(slab) > maxRosettaSlabSize {
+		return nil
+	}
+
+	for i := range slab {
+		slab[i] = rTypes.OperationIdentifier{}
+	}
+	return slab[:0]
+}
+
+func resetOperationPointers(slab []*rTypes.Operation) []*rTypes.Operation {
+	if cap(slab) > maxRosettaSlabSize {
+		return nil
+	}
+
+	for i := range slab {
+		slab[i] = nil
+	}
+	return slab[:0]
+}
+
+func resetOperations(slab []rTypes.Operation) []rTypes.Operation {
+	if cap(slab) > maxRosettaSlabSize {
+		return nil
+	}
+
+	for i := range slab {
+		slab[i] = rTypes.Operation{}
+	}
+	return slab[:0]
+}
+
+func resetSubAccountIdentifiers(slab []rTypes.SubAccountIdentifier) []rTypes.SubAccountIdentifier {
+	if cap(slab) > maxRosettaSlabSize {
+		return nil
+	}
+
+	for i := range slab {
+		slab[i] = rTypes.SubAccountIdentifier{}
+	}
+	return slab[:0]
+}
+
+func resetTransactionIdentifiers(slab []rTypes.TransactionIdentifier) []rTypes.TransactionIdentifier {
+	if cap(slab) > maxRosettaSlabSize {
+		return nil
+	}
+
+	for i := range slab {
+		slab[i] = rTypes.TransactionIdentifier{}
+	}
+	return slab[:0]
+}
+
+func resetTransactionPointers(slab []*rTypes.Transaction) []*rTypes.Transaction {
+	if cap(slab) > maxRosettaSlabSize {
+		return nil
+	}
+
+	for i := range slab {
+		slab[i] = nil
+	}
+	return slab[:0]
+}
+
+func resetTransactions(slab []rTypes.Transaction) []rTypes.Transaction {
+	if cap(slab) > maxRosettaSlabSize {
+		return nil
+	}
+
+	for i := range slab {
+		slab[i] = rTypes.Transaction{}
+	}
+	return slab[:0]
+}
+
+// getSlabSize returns the size of the next slab, which doubles the size of the current full one
+func getSlabSize(current int) int {
+	if current < minRosettaSlabSize {
+		return minRosettaSlabSize
+	}
+
+	return current * 2
+}
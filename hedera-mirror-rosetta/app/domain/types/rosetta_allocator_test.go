@@ -0,0 +1,171 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package types
+
+import (
+	"fmt"
+	"testing"
+
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func allocatorTestBlock(transactionCount int) *Block {
+	tokenId := entityid.EntityId{EntityNum: 1001, EncodedId: 1001}
+	transactions := make([]*Transaction, 0, transactionCount)
+	for i := 0; i < transactionCount; i++ {
+		transactions = append(transactions, &Transaction{
+			Hash:     fmt.Sprintf("hash%d", i),
+			Metadata: map[string]interface{}{"memo": fmt.Sprintf("memo%d", i)},
+			Operations: []*Operation{
+				{
+					Index:   0,
+					Type:    "CRYPTOTRANSFER",
+					Status:  "SUCCESS",
+					Account: Account{entityid.EntityId{EntityNum: int64(i), EncodedId: int64(i)}},
+					Amount:  &HbarAmount{Value: -int64(i)},
+				},
+				{
+					Index:   1,
+					Type:    "CRYPTOTRANSFER",
+					Status:  "SUCCESS",
+					Account: Account{entityid.EntityId{EntityNum: 98, EncodedId: 98}},
+					Amount:  &TokenAmount{Decimals: 2, TokenId: tokenId, Value: int64(i)},
+				},
+				{
+					Index:   2,
+					Type:    "TOKENCREATE",
+					Status:  "SUCCESS",
+					Account: Account{entityid.EntityId{EntityNum: 98, EncodedId: 98}},
+				},
+			},
+		})
+	}
+
+	return &Block{
+		Index:               10,
+		Hash:                "blockhash",
+		ConsensusStartNanos: 1000000,
+		ParentIndex:         9,
+		ParentHash:          "parenthash",
+		Transactions:        transactions,
+	}
+}
+
+func TestBlockToRosettaWithAllocator(t *testing.T) {
+	for _, tokenSubAccounts := range []bool{false, true} {
+		t.Run(fmt.Sprintf("TokenSubAccounts=%t", tokenSubAccounts), func(t *testing.T) {
+			// given
+			settings := config.NewSettings()
+			settings.TokenSubAccounts = tokenSubAccounts
+			block := allocatorTestBlock(600)
+			allocator := GetRosettaAllocator()
+			defer allocator.Release()
+
+			// when
+			actual := block.ToRosettaWithAllocator(settings, allocator)
+
+			// then
+			assert.Equal(t, block.ToRosetta(settings), actual)
+		})
+	}
+}
+
+func TestRosettaAllocatorReuse(t *testing.T) {
+	// given
+	settings := config.NewSettings()
+	allocator := &RosettaAllocator{}
+	allocatorTestBlock(300).ToRosettaWithAllocator(settings, allocator)
+	allocator.Release()
+	block := allocatorTestBlock(10)
+	block.Transactions[0].Operations = block.Transactions[0].Operations[:1]
+
+	// when
+	actual := block.ToRosettaWithAllocator(settings, allocator)
+
+	// then
+	assert.Equal(t, block.ToRosetta(settings), actual)
+	assert.Len(t, actual.Transactions, 10)
+	assert.Len(t, actual.Transactions[0].Operations, 1)
+	assert.Equal(t, 1, cap(actual.Transactions[0].Operations))
+}
+
+func TestRosettaAllocatorReleaseDropsLargeSlabs(t *testing.T) {
+	// given
+	settings := config.NewSettings()
+	allocator := &RosettaAllocator{}
+	allocatorTestBlock(maxRosettaSlabSize).ToRosettaWithAllocator(settings, allocator)
+
+	// when
+	allocator.Release()
+
+	// then
+	assert.Nil(t, allocator.operations)
+	assert.Nil(t, allocator.operationPointers)
+	assert.NotNil(t, allocator.transactions)
+	assert.Empty(t, allocator.transactions)
+}
+
+func TestRosettaAllocatorReducesAllocations(t *testing.T) {
+	// given
+	settings := config.NewSettings()
+	block := allocatorTestBlock(1000)
+	allocator := &RosettaAllocator{}
+	block.ToRosettaWithAllocator(settings, allocator)
+	allocator.Release()
+
+	// when
+	heapAllocs := testing.AllocsPerRun(10, func() { block.ToRosetta(settings) })
+	pooledAllocs := testing.AllocsPerRun(10, func() {
+		block.ToRosettaWithAllocator(settings, allocator)
+		allocator.Release()
+	})
+
+	// then
+	assert.Less(t, pooledAllocs, heapAllocs/2)
+}
+
+func TestNilRosettaAllocatorRelease(t *testing.T) {
+	var allocator *RosettaAllocator
+	assert.NotPanics(t, allocator.Release)
+}
+
+func BenchmarkBlockToRosetta(b *testing.B) {
+	settings := config.NewSettings()
+	block := allocatorTestBlock(5000)
+
+	b.Run("Heap", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			block.ToRosetta(settings)
+		}
+	})
+
+	b.Run("Pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			allocator := GetRosettaAllocator()
+			block.ToRosettaWithAllocator(settings, allocator)
+			allocator.Release()
+		}
+	})
+}
@@ -0,0 +1,95 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package types
+
+import (
+	"bytes"
+	"encoding/hex"
+
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+)
+
+const (
+	ScheduleStatusDeleted  = "DELETED"
+	ScheduleStatusExecuted = "EXECUTED"
+	ScheduleStatusPending  = "PENDING"
+)
+
+// Schedule is domain level struct used to represent a scheduled transaction and its collected signatures
+type Schedule struct {
+	ConsensusTimestamp int64
+	CreatorAccountId   Account
+	Deleted            bool
+	ExecutedTimestamp  *int64
+	PayerAccountId     Account
+	ScheduleId         entityid.EntityId
+	Signatures         [][]byte
+	TransactionBody    []byte
+}
+
+// Status returns the execution status of the schedule
+func (s *Schedule) Status() string {
+	if s.ExecutedTimestamp != nil {
+		return ScheduleStatusExecuted
+	}
+
+	if s.Deleted {
+		return ScheduleStatusDeleted
+	}
+
+	return ScheduleStatusPending
+}
+
+// IsSignedBy returns true if one of the collected signatures is from the public key. A signature is recorded with
+// the public key prefix, so a match is a prefix match
+func (s *Schedule) IsSignedBy(publicKey []byte) bool {
+	for _, prefix := range s.Signatures {
+		if len(prefix) != 0 && bytes.HasPrefix(publicKey, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ToMetadata returns the schedule info as a map to be used in rosetta metadata
+func (s *Schedule) ToMetadata(timestampStrings bool) map[string]interface{} {
+	signatures := make([]string, 0, len(s.Signatures))
+	for _, prefix := range s.Signatures {
+		signatures = append(signatures, hex.EncodeToString(prefix))
+	}
+
+	metadata := map[string]interface{}{
+		"creator_account_id": s.CreatorAccountId.String(),
+		"payer_account_id":   s.PayerAccountId.String(),
+		"schedule_id":        s.ScheduleId.String(),
+		"signatures":         signatures,
+		"status":             s.Status(),
+		"transaction_body":   hex.EncodeToString(s.TransactionBody),
+	}
+	AddTimestampMetadata(metadata, "consensus_timestamp", s.ConsensusTimestamp, timestampStrings)
+
+	if s.ExecutedTimestamp != nil {
+		AddTimestampMetadata(metadata, "executed_timestamp", *s.ExecutedTimestamp, timestampStrings)
+	}
+
+	return metadata
+}
@@ -0,0 +1,90 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package types
+
+import (
+	"testing"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSchedule(executedTimestamp *int64, deleted bool) *Schedule {
+	return &Schedule{
+		ConsensusTimestamp: 10,
+		CreatorAccountId:   Account{EntityId: entityid.EntityId{EntityNum: 1001, EncodedId: 1001}},
+		Deleted:            deleted,
+		ExecutedTimestamp:  executedTimestamp,
+		PayerAccountId:     Account{EntityId: entityid.EntityId{EntityNum: 1002, EncodedId: 1002}},
+		ScheduleId:         entityid.EntityId{EntityNum: 1500, EncodedId: 1500},
+		Signatures:         [][]byte{{0x1, 0x2}, {}},
+		TransactionBody:    []byte{0xab, 0xcd},
+	}
+}
+
+func TestScheduleStatus(t *testing.T) {
+	executedTimestamp := int64(20)
+	var tests = []struct {
+		name     string
+		schedule *Schedule
+		expected string
+	}{
+		{name: "Pending", schedule: newSchedule(nil, false), expected: ScheduleStatusPending},
+		{name: "Deleted", schedule: newSchedule(nil, true), expected: ScheduleStatusDeleted},
+		{name: "Executed", schedule: newSchedule(&executedTimestamp, false), expected: ScheduleStatusExecuted},
+		{name: "ExecutedAndDeleted", schedule: newSchedule(&executedTimestamp, true), expected: ScheduleStatusExecuted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.schedule.Status())
+		})
+	}
+}
+
+func TestScheduleIsSignedBy(t *testing.T) {
+	schedule := newSchedule(nil, false)
+
+	assert.True(t, schedule.IsSignedBy([]byte{0x1, 0x2, 0x3}))
+	assert.False(t, schedule.IsSignedBy([]byte{0x1, 0x3}))
+	assert.False(t, schedule.IsSignedBy([]byte{}))
+}
+
+func TestScheduleToMetadata(t *testing.T) {
+	// given
+	executedTimestamp := int64(20)
+	expected := map[string]interface{}{
+		"consensus_timestamp": int64(10),
+		"creator_account_id":  "0.0.1001",
+		"executed_timestamp":  int64(20),
+		"payer_account_id":    "0.0.1002",
+		"schedule_id":         "0.0.1500",
+		"signatures":          []string{"0102", ""},
+		"status":              ScheduleStatusExecuted,
+		"transaction_body":    "abcd",
+	}
+
+	// when
+	actual := newSchedule(&executedTimestamp, false).ToMetadata(false)
+
+	// then
+	assert.Equal(t, expected, actual)
+}
@@ -0,0 +1,42 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package types
+
+import (
+	"time"
+)
+
+// timestampStringSuffix is appended to the metadata key of a nanosecond timestamp to get the key of its string form
+const timestampStringSuffix = "_rfc3339"
+
+// AddTimestampMetadata sets the nanoseconds since epoch timestamp in metadata under key. If timestampStrings is set,
+// it's also set as an RFC3339 string with nanosecond precision under key with the _rfc3339 suffix
+func AddTimestampMetadata(metadata map[string]interface{}, key string, nanos int64, timestampStrings bool) {
+	metadata[key] = nanos
+	if timestampStrings {
+		metadata[key+timestampStringSuffix] = FormatTimestamp(nanos)
+	}
+}
+
+// FormatTimestamp formats the nanoseconds since epoch timestamp as an RFC3339 string in UTC
+func FormatTimestamp(nanos int64) string {
+	return time.Unix(0, nanos).UTC().Format(time.RFC3339Nano)
+}
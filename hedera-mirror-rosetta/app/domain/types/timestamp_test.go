@@ -0,0 +1,66 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddTimestampMetadata(t *testing.T) {
+	var tests = []struct {
+		name             string
+		timestampStrings bool
+		expected         map[string]interface{}
+	}{
+		{
+			name:     "Nanos",
+			expected: map[string]interface{}{"consensus_timestamp": int64(1623101500123456789)},
+		},
+		{
+			name:             "NanosAndString",
+			timestampStrings: true,
+			expected: map[string]interface{}{
+				"consensus_timestamp":         int64(1623101500123456789),
+				"consensus_timestamp_rfc3339": "2021-06-07T21:31:40.123456789Z",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			metadata := map[string]interface{}{}
+
+			// when
+			AddTimestampMetadata(metadata, "consensus_timestamp", 1623101500123456789, tt.timestampStrings)
+
+			// then
+			assert.Equal(t, tt.expected, metadata)
+		})
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	assert.Equal(t, "1970-01-01T00:00:00Z", FormatTimestamp(0))
+	assert.Equal(t, "2021-06-07T21:31:40.1Z", FormatTimestamp(1623101500100000000))
+}
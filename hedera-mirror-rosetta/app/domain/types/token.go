@@ -28,18 +28,17 @@ import (
 
 // Token is domain level struct used to represent Token conceptual mapping in Hedera
 type Token struct {
-	TokenId  entityid.EntityId
-	Decimals uint32
-	Name     string
-	Symbol   string
+	TokenId       entityid.EntityId
+	Decimals      uint32
+	FreezeDefault bool
+	InitialSupply int64
+	Name          string
+	Symbol        string
 }
 
 func (t Token) ToHederaTokenId() *hedera.TokenID {
-	return &hedera.TokenID{
-		Shard: uint64(t.TokenId.ShardNum),
-		Realm: uint64(t.TokenId.RealmNum),
-		Token: uint64(t.TokenId.EntityNum),
-	}
+	tokenId := t.TokenId.ToSdkTokenId()
+	return &tokenId
 }
 
 func (t Token) ToRosettaCurrency() *rTypes.Currency {
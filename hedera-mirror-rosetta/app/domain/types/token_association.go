@@ -0,0 +1,65 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package types
+
+import entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+
+const (
+	TokenFreezeStatusFrozen        = "FROZEN"
+	TokenFreezeStatusNotApplicable = "NOT_APPLICABLE"
+	TokenFreezeStatusUnfrozen      = "UNFROZEN"
+
+	TokenKycStatusGranted       = "GRANTED"
+	TokenKycStatusNotApplicable = "NOT_APPLICABLE"
+	TokenKycStatusRevoked       = "REVOKED"
+)
+
+// TokenAssociation is domain level struct used to represent the relationship of an account with a token. The freeze
+// status and the kyc status are not applicable if the token has no freeze key and no kyc key respectively
+type TokenAssociation struct {
+	AccountId         entityid.EntityId
+	Associated        bool
+	CreatedTimestamp  int64
+	FreezeStatus      string
+	KycStatus         string
+	ModifiedTimestamp int64
+	TokenId           entityid.EntityId
+}
+
+// IsFrozen returns true if the account is frozen for the token
+func (t *TokenAssociation) IsFrozen() bool {
+	return t.FreezeStatus == TokenFreezeStatusFrozen
+}
+
+// ToMetadata returns the token association as a map to be used in rosetta metadata
+func (t *TokenAssociation) ToMetadata(timestampStrings bool) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"account_id":    t.AccountId.String(),
+		"associated":    t.Associated,
+		"freeze_status": t.FreezeStatus,
+		"kyc_status":    t.KycStatus,
+		"token_id":      t.TokenId.String(),
+	}
+	AddTimestampMetadata(metadata, "created_timestamp", t.CreatedTimestamp, timestampStrings)
+	AddTimestampMetadata(metadata, "modified_timestamp", t.ModifiedTimestamp, timestampStrings)
+
+	return metadata
+}
@@ -0,0 +1,62 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package types
+
+import (
+	"testing"
+
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenAssociationIsFrozen(t *testing.T) {
+	assert.True(t, (&TokenAssociation{FreezeStatus: TokenFreezeStatusFrozen}).IsFrozen())
+	assert.False(t, (&TokenAssociation{FreezeStatus: TokenFreezeStatusNotApplicable}).IsFrozen())
+	assert.False(t, (&TokenAssociation{FreezeStatus: TokenFreezeStatusUnfrozen}).IsFrozen())
+}
+
+func TestTokenAssociationToMetadata(t *testing.T) {
+	// given
+	tokenAssociation := &TokenAssociation{
+		AccountId:         entityid.EntityId{EntityNum: 1001, EncodedId: 1001},
+		Associated:        true,
+		CreatedTimestamp:  100,
+		FreezeStatus:      TokenFreezeStatusUnfrozen,
+		KycStatus:         TokenKycStatusGranted,
+		ModifiedTimestamp: 101,
+		TokenId:           entityid.EntityId{EntityNum: 2001, EncodedId: 2001},
+	}
+	expected := map[string]interface{}{
+		"account_id":         "0.0.1001",
+		"associated":         true,
+		"created_timestamp":  int64(100),
+		"freeze_status":      "UNFROZEN",
+		"kyc_status":         "GRANTED",
+		"modified_timestamp": int64(101),
+		"token_id":           "0.0.2001",
+	}
+
+	// when
+	actual := tokenAssociation.ToMetadata(false)
+
+	// then
+	assert.Equal(t, expected, actual)
+}
@@ -0,0 +1,144 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+package types
+
+import (
+	"encoding/hex"
+
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+)
+
+const (
+	CustomFeeTypeFixed      = "FIXED"
+	CustomFeeTypeFractional = "FRACTIONAL"
+	CustomFeeTypeRoyalty    = "ROYALTY"
+)
+
+// CustomFee is domain level struct used to represent a custom fee in the fee schedule of a token. A fixed fee charges
+// the amount in hbar or in the denominating token, a fractional fee charges the fraction of the transferred units
+// bounded by the minimum and the maximum amounts, and a royalty fee charges the fraction of the fungible value
+// exchanged for an nft with the optional fixed fallback amount
+type CustomFee struct {
+	Amount              int64
+	AmountDenominator   int64
+	CollectorAccountId  entityid.EntityId
+	DenominatingTokenId *entityid.EntityId
+	MaximumAmount       int64
+	MinimumAmount       int64
+	NetOfTransfers      bool
+	RoyaltyDenominator  int64
+	RoyaltyNumerator    int64
+	Type                string
+}
+
+// ToMetadata returns the custom fee as a map to be used in rosetta metadata
+func (c *CustomFee) ToMetadata() map[string]interface{} {
+	metadata := map[string]interface{}{
+		"collector_account_id": c.CollectorAccountId.String(),
+		"type":                 c.Type,
+	}
+
+	switch c.Type {
+	case CustomFeeTypeFractional:
+		metadata["denominator"] = c.AmountDenominator
+		metadata["maximum_amount"] = c.MaximumAmount
+		metadata["minimum_amount"] = c.MinimumAmount
+		metadata["net_of_transfers"] = c.NetOfTransfers
+		metadata["numerator"] = c.Amount
+	case CustomFeeTypeRoyalty:
+		metadata["denominator"] = c.RoyaltyDenominator
+		metadata["numerator"] = c.RoyaltyNumerator
+		if c.Amount != 0 {
+			metadata["fallback_fee"] = c.fixedFeeMetadata()
+		}
+	default:
+		for key, value := range c.fixedFeeMetadata() {
+			metadata[key] = value
+		}
+	}
+
+	return metadata
+}
+
+func (c *CustomFee) fixedFeeMetadata() map[string]interface{} {
+	metadata := map[string]interface{}{"amount": c.Amount, "denominating_token_id": nil}
+	if c.DenominatingTokenId != nil {
+		metadata["denominating_token_id"] = c.DenominatingTokenId.String()
+	}
+	return metadata
+}
+
+// TokenInfo is domain level struct used to represent the info of a token. The total supply and the custom fees are as
+// of a consensus timestamp, while the rest is the latest state of the token since only the current state is stored
+type TokenInfo struct {
+	Token
+	AdminKey          []byte
+	CustomFees        []CustomFee
+	FeeScheduleKey    []byte
+	FreezeKey         []byte
+	KycKey            []byte
+	MaxSupply         int64
+	SupplyKey         []byte
+	SupplyType        string
+	TotalSupply       int64
+	TreasuryAccountId Account
+	Type              string
+	WipeKey           []byte
+}
+
+// ToMetadata returns the token info as a map to be used in rosetta metadata. The keys are the hex encoded protobuf
+// keys, or nil if the token doesn't have them
+func (t *TokenInfo) ToMetadata() map[string]interface{} {
+	customFees := make([]map[string]interface{}, 0, len(t.CustomFees))
+	for i := range t.CustomFees {
+		customFees = append(customFees, t.CustomFees[i].ToMetadata())
+	}
+
+	keys := map[string]interface{}{}
+	for name, key := range map[string][]byte{
+		"admin_key":        t.AdminKey,
+		"fee_schedule_key": t.FeeScheduleKey,
+		"freeze_key":       t.FreezeKey,
+		"kyc_key":          t.KycKey,
+		"supply_key":       t.SupplyKey,
+		"wipe_key":         t.WipeKey,
+	} {
+		keys[name] = nil
+		if len(key) != 0 {
+			keys[name] = hex.EncodeToString(key)
+		}
+	}
+
+	return map[string]interface{}{
+		"custom_fees":         customFees,
+		"decimals":            t.Decimals,
+		"freeze_default":      t.FreezeDefault,
+		"initial_supply":      t.InitialSupply,
+		"keys":                keys,
+		"max_supply":          t.MaxSupply,
+		"name":                t.Name,
+		"supply_type":         t.SupplyType,
+		"symbol":              t.Symbol,
+		"token_id":            t.TokenId.String(),
+		"total_supply":        t.TotalSupply,
+		"treasury_account_id": t.TreasuryAccountId.String(),
+		"type":                t.Type,
+	}
+}
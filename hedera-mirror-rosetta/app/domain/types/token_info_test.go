@@ -0,0 +1,182 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+package types
+
+import (
+	"testing"
+
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomFeeToMetadata(t *testing.T) {
+	collector := entityid.EntityId{EntityNum: 98, EncodedId: 98}
+	denominatingTokenId := &entityid.EntityId{EntityNum: 2001, EncodedId: 2001}
+
+	var tests = []struct {
+		name      string
+		customFee CustomFee
+		expected  map[string]interface{}
+	}{
+		{
+			name:      "FixedHbar",
+			customFee: CustomFee{Amount: 10, CollectorAccountId: collector, Type: CustomFeeTypeFixed},
+			expected: map[string]interface{}{
+				"amount":                int64(10),
+				"collector_account_id":  "0.0.98",
+				"denominating_token_id": nil,
+				"type":                  CustomFeeTypeFixed,
+			},
+		},
+		{
+			name: "FixedToken",
+			customFee: CustomFee{
+				Amount:              10,
+				CollectorAccountId:  collector,
+				DenominatingTokenId: denominatingTokenId,
+				Type:                CustomFeeTypeFixed,
+			},
+			expected: map[string]interface{}{
+				"amount":                int64(10),
+				"collector_account_id":  "0.0.98",
+				"denominating_token_id": "0.0.2001",
+				"type":                  CustomFeeTypeFixed,
+			},
+		},
+		{
+			name: "Fractional",
+			customFee: CustomFee{
+				Amount:             1,
+				AmountDenominator:  10,
+				CollectorAccountId: collector,
+				MaximumAmount:      100,
+				MinimumAmount:      5,
+				NetOfTransfers:     true,
+				Type:               CustomFeeTypeFractional,
+			},
+			expected: map[string]interface{}{
+				"collector_account_id": "0.0.98",
+				"denominator":          int64(10),
+				"maximum_amount":       int64(100),
+				"minimum_amount":       int64(5),
+				"net_of_transfers":     true,
+				"numerator":            int64(1),
+				"type":                 CustomFeeTypeFractional,
+			},
+		},
+		{
+			name: "Royalty",
+			customFee: CustomFee{
+				CollectorAccountId: collector,
+				RoyaltyDenominator: 20,
+				RoyaltyNumerator:   3,
+				Type:               CustomFeeTypeRoyalty,
+			},
+			expected: map[string]interface{}{
+				"collector_account_id": "0.0.98",
+				"denominator":          int64(20),
+				"numerator":            int64(3),
+				"type":                 CustomFeeTypeRoyalty,
+			},
+		},
+		{
+			name: "RoyaltyWithFallbackFee",
+			customFee: CustomFee{
+				Amount:             50,
+				CollectorAccountId: collector,
+				RoyaltyDenominator: 20,
+				RoyaltyNumerator:   3,
+				Type:               CustomFeeTypeRoyalty,
+			},
+			expected: map[string]interface{}{
+				"collector_account_id": "0.0.98",
+				"denominator":          int64(20),
+				"fallback_fee":         map[string]interface{}{"amount": int64(50), "denominating_token_id": nil},
+				"numerator":            int64(3),
+				"type":                 CustomFeeTypeRoyalty,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.customFee.ToMetadata())
+		})
+	}
+}
+
+func TestTokenInfoToMetadata(t *testing.T) {
+	// given
+	tokenInfo := &TokenInfo{
+		Token: Token{
+			TokenId:       entityid.EntityId{EntityNum: 2000, EncodedId: 2000},
+			Decimals:      2,
+			FreezeDefault: true,
+			InitialSupply: 1000,
+			Name:          "teebar",
+			Symbol:        "foobar",
+		},
+		AdminKey: []byte{0x1, 0x2},
+		CustomFees: []CustomFee{
+			{Amount: 10, CollectorAccountId: entityid.EntityId{EntityNum: 98, EncodedId: 98}, Type: CustomFeeTypeFixed},
+		},
+		MaxSupply:         5000,
+		SupplyKey:         []byte{0xab},
+		SupplyType:        "FINITE",
+		TotalSupply:       1500,
+		TreasuryAccountId: Account{EntityId: entityid.EntityId{EntityNum: 1001, EncodedId: 1001}},
+		Type:              "FUNGIBLE_COMMON",
+	}
+	expected := map[string]interface{}{
+		"custom_fees": []map[string]interface{}{
+			{
+				"amount":                int64(10),
+				"collector_account_id":  "0.0.98",
+				"denominating_token_id": nil,
+				"type":                  CustomFeeTypeFixed,
+			},
+		},
+		"decimals":       uint32(2),
+		"freeze_default": true,
+		"initial_supply": int64(1000),
+		"keys": map[string]interface{}{
+			"admin_key":        "0102",
+			"fee_schedule_key": nil,
+			"freeze_key":       nil,
+			"kyc_key":          nil,
+			"supply_key":       "ab",
+			"wipe_key":         nil,
+		},
+		"max_supply":          int64(5000),
+		"name":                "teebar",
+		"supply_type":         "FINITE",
+		"symbol":              "foobar",
+		"token_id":            "0.0.2000",
+		"total_supply":        int64(1500),
+		"treasury_account_id": "0.0.1001",
+		"type":                "FUNGIBLE_COMMON",
+	}
+
+	// when
+	actual := tokenInfo.ToMetadata()
+
+	// then
+	assert.Equal(t, expected, actual)
+}
@@ -21,25 +21,119 @@
 package types
 
 import (
+	"encoding/hex"
+
 	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	hexUtils "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/hex"
+)
+
+// TransactionType* are the protobuf ids of the transaction types with dedicated handling
+const (
+	TransactionTypeConsensusSubmitMessage int16 = 27
+	TransactionTypeFreeze                 int16 = 23
+	TransactionTypeSystemDelete           int16 = 20
+	TransactionTypeSystemUndelete         int16 = 21
+	TransactionTypeTokenCreation          int16 = 29
+	TransactionTypeTokenDeletion          int16 = 35
+	TransactionTypeTokenUpdate            int16 = 36
+	TransactionTypeTokenWipe              int16 = 39
 )
 
 // Transaction is domain level struct used to represent Transaction conceptual mapping in Hedera
 type Transaction struct {
 	Hash       string
+	Metadata   map[string]interface{}
 	Operations []*Operation
+	// FeeDebited is the hbar debited by the fee transfers of all the records sharing the hash, i.e., the hbar transfers
+	// not in the transaction body. It isn't part of the Rosetta transaction and is only used to audit the operations
+	FeeDebited int64
+}
+
+// RawTransaction is the serialized transaction as submitted to the network, with the consensus timestamp and the result
+// of its record. TransactionBytes is nil unless the importer persists the transaction bytes
+type RawTransaction struct {
+	ConsensusTimestamp int64
+	Result             string
+	TransactionBytes   []byte
+}
+
+// ToMetadata returns the raw transaction as metadata with the transaction bytes hex encoded, or nil if they aren't
+// persisted
+func (r *RawTransaction) ToMetadata(timestampStrings bool) map[string]interface{} {
+	metadata := map[string]interface{}{"result": r.Result, "transaction_bytes": nil}
+	if r.TransactionBytes != nil {
+		metadata["transaction_bytes"] = hexUtils.SafeAddHexPrefix(hex.EncodeToString(r.TransactionBytes))
+	}
+	AddTimestampMetadata(metadata, "consensus_timestamp", r.ConsensusTimestamp, timestampStrings)
+	return metadata
 }
 
 // ToRosetta returns Rosetta type Transaction from the current domain type Transaction
-func (t *Transaction) ToRosetta() *rTypes.Transaction {
-	operations := make([]*rTypes.Operation, len(t.Operations))
+func (t *Transaction) ToRosetta(settings *config.Settings) *rTypes.Transaction {
+	return t.ToRosettaWithAllocator(settings, nil)
+}
+
+// ToRosettaWithAllocator returns Rosetta type Transaction from the current domain type Transaction with the structs
+// handed out by allocator
+func (t *Transaction) ToRosettaWithAllocator(
+	settings *config.Settings,
+	allocator *RosettaAllocator,
+) *rTypes.Transaction {
+	operations := allocator.newOperations(len(t.Operations))
 	for i, o := range t.Operations {
-		operations[i] = o.ToRosetta()
+		operations[i] = o.ToRosettaWithAllocator(settings, allocator)
 	}
 
-	rTransaction := &rTypes.Transaction{
-		TransactionIdentifier: &rTypes.TransactionIdentifier{Hash: t.Hash},
-		Operations:            operations,
-	}
+	rTransaction := allocator.newTransaction(t.Hash)
+	rTransaction.Operations = operations
+	rTransaction.Metadata = t.Metadata
 	return rTransaction
 }
+
+// SplitTransactions keeps the transactions in order as long as their operations add up to at most maxOperations, and
+// returns the hashes of the rest so they can be fetched one by one
+func SplitTransactions(transactions []*Transaction, maxOperations int) ([]*Transaction, []string) {
+	operationCount := 0
+	for i, transaction := range transactions {
+		operationCount += len(transaction.Operations)
+		if operationCount <= maxOperations {
+			continue
+		}
+
+		otherHashes := make([]string, 0, len(transactions)-i)
+		for _, other := range transactions[i:] {
+			otherHashes = append(otherHashes, other.Hash)
+		}
+		return transactions[:i], otherHashes
+	}
+
+	return transactions, nil
+}
+
+// OmitZeroAmounts removes the operations with a zero amount and reassigns the 0-based contiguous operation indexes.
+// Operations without an amount are kept
+func (t *Transaction) OmitZeroAmounts() {
+	operations := t.Operations[:0]
+	for _, operation := range t.Operations {
+		if isZeroAmount(operation.Amount) {
+			continue
+		}
+
+		operation.Index = int64(len(operations))
+		operations = append(operations, operation)
+	}
+
+	t.Operations = operations
+}
+
+func isZeroAmount(amount Amount) bool {
+	switch a := amount.(type) {
+	case *HbarAmount:
+		return a.Value == 0
+	case *TokenAmount:
+		return a.Value == 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,113 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+)
+
+const scheduledSuffix = "?scheduled"
+
+var errInvalidTransactionId = errors.New("invalid transaction id")
+
+// TransactionId is domain level struct used to represent the Hedera transaction id, the payer and the valid start of a
+// transaction. Scheduled is set for the id of the transaction executed by a schedule, and a positive Nonce identifies a
+// child transaction of the user transaction
+type TransactionId struct {
+	Nonce        int32
+	Payer        Account
+	Scheduled    bool
+	ValidStartNs int64
+}
+
+// String returns the transaction id in the format of shard.realm.num@seconds.nanos with the optional ?scheduled and
+// /nonce suffixes
+func (t TransactionId) String() string {
+	str := fmt.Sprintf("%s@%d.%09d", t.Payer.String(), t.ValidStartNs/1e9, t.ValidStartNs%1e9)
+	if t.Scheduled {
+		str += scheduledSuffix
+	}
+
+	if t.Nonce != 0 {
+		str += fmt.Sprintf("/%d", t.Nonce)
+	}
+
+	return str
+}
+
+// NewTransactionIdFromString parses the transaction id in the format of shard.realm.num@seconds.nanos with the
+// optional ?scheduled and /nonce suffixes, e.g., 0.0.2@1623101500.123456789?scheduled/1. The nanos are the number of
+// nanoseconds as the SDKs print them, so 0.0.2@1623101500.5 is 5 nanoseconds past the second. The format
+// shard.realm.num-seconds-nanos the mirror node REST API uses is accepted as well
+func NewTransactionIdFromString(transactionId string) (TransactionId, error) {
+	id := TransactionId{}
+	str := transactionId
+	if index := strings.LastIndex(str, "/"); index != -1 {
+		nonce, err := strconv.ParseInt(str[index+1:], 10, 32)
+		if err != nil || nonce < 0 {
+			return TransactionId{}, errInvalidTransactionId
+		}
+		id.Nonce = int32(nonce)
+		str = str[:index]
+	}
+
+	if strings.HasSuffix(str, scheduledSuffix) {
+		id.Scheduled = true
+		str = strings.TrimSuffix(str, scheduledSuffix)
+	}
+
+	var payer, seconds, nanos string
+	if parts := strings.Split(str, "@"); len(parts) == 2 {
+		validStart := strings.Split(parts[1], ".")
+		if len(validStart) != 2 {
+			return TransactionId{}, errInvalidTransactionId
+		}
+		payer, seconds, nanos = parts[0], validStart[0], validStart[1]
+	} else if parts := strings.Split(str, "-"); len(parts) == 3 {
+		payer, seconds, nanos = parts[0], parts[1], parts[2]
+	} else {
+		return TransactionId{}, errInvalidTransactionId
+	}
+
+	payerId, err := entityid.FromString(payer)
+	if err != nil {
+		return TransactionId{}, errInvalidTransactionId
+	}
+	id.Payer = Account{payerId}
+
+	validStartSeconds, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil || validStartSeconds < 0 {
+		return TransactionId{}, errInvalidTransactionId
+	}
+
+	validStartNanos, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil || validStartNanos < 0 || validStartNanos >= 1e9 {
+		return TransactionId{}, errInvalidTransactionId
+	}
+	id.ValidStartNs = validStartSeconds*1e9 + validStartNanos
+
+	return id, nil
+}
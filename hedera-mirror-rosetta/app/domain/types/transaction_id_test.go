@@ -0,0 +1,138 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package types
+
+import (
+	"testing"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	"github.com/stretchr/testify/assert"
+)
+
+var transactionIdPayer = Account{EntityId: entityid.EntityId{EntityNum: 1001, EncodedId: 1001}}
+
+func TestNewTransactionIdFromString(t *testing.T) {
+	var tests = []struct {
+		name     string
+		input    string
+		expected TransactionId
+	}{
+		{
+			name:     "Plain",
+			input:    "0.0.1001@1623101500.000000010",
+			expected: TransactionId{Payer: transactionIdPayer, ValidStartNs: 1623101500000000010},
+		},
+		{
+			name:     "UnpaddedNanos",
+			input:    "0.0.1001@1623101500.10",
+			expected: TransactionId{Payer: transactionIdPayer, ValidStartNs: 1623101500000000010},
+		},
+		{
+			name:     "Scheduled",
+			input:    "0.0.1001@1623101500.000000010?scheduled",
+			expected: TransactionId{Payer: transactionIdPayer, Scheduled: true, ValidStartNs: 1623101500000000010},
+		},
+		{
+			name:     "Nonce",
+			input:    "0.0.1001@1623101500.000000010/2",
+			expected: TransactionId{Nonce: 2, Payer: transactionIdPayer, ValidStartNs: 1623101500000000010},
+		},
+		{
+			name:  "ScheduledWithNonce",
+			input: "0.0.1001@1623101500.000000010?scheduled/2",
+			expected: TransactionId{
+				Nonce:        2,
+				Payer:        transactionIdPayer,
+				Scheduled:    true,
+				ValidStartNs: 1623101500000000010,
+			},
+		},
+		{
+			name:     "RestFormat",
+			input:    "0.0.1001-1623101500-000000010",
+			expected: TransactionId{Payer: transactionIdPayer, ValidStartNs: 1623101500000000010},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := NewTransactionIdFromString(tt.input)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestNewTransactionIdFromStringThrows(t *testing.T) {
+	var inputs = []string{
+		"",
+		"0.0.1001",
+		"0.0.1001@1623101500",
+		"0.0.1001@1623101500.1.2",
+		"0.0.1001@-1.10",
+		"0.0.1001@1623101500.1000000000",
+		"0.0.1001@1623101500.abc",
+		"0.0.1001@1623101500.10/abc",
+		"0.0.1001@1623101500.10/-1",
+		"0.0.1001@1623101500.10?executed",
+		"1001@1623101500.10",
+		"0.0.1001-1623101500",
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			_, err := NewTransactionIdFromString(input)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestTransactionIdString(t *testing.T) {
+	var tests = []struct {
+		name          string
+		transactionId TransactionId
+		expected      string
+	}{
+		{
+			name:          "Plain",
+			transactionId: TransactionId{Payer: transactionIdPayer, ValidStartNs: 1623101500000000010},
+			expected:      "0.0.1001@1623101500.000000010",
+		},
+		{
+			name: "ScheduledWithNonce",
+			transactionId: TransactionId{
+				Nonce:        2,
+				Payer:        transactionIdPayer,
+				Scheduled:    true,
+				ValidStartNs: 1623101500000000010,
+			},
+			expected: "0.0.1001@1623101500.000000010?scheduled/2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.transactionId.String())
+		})
+	}
+}
@@ -0,0 +1,36 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package types
+
+// TransactionRecord is domain level struct used to represent a transaction as the mirror node recorded it, before its
+// transfers are assembled into operations. A scheduled transaction has one record per execution step sharing the hash
+type TransactionRecord struct {
+	CryptoTransfers []Transfer
+	Hash            string
+	Metadata        map[string]interface{}
+	NonFeeTransfers []Transfer
+	Payer           Account
+	Result          string
+	Token           *Token
+	TokenTransfers  []Transfer
+	Type            int16
+	TypeName        string
+}
@@ -31,7 +31,8 @@ import (
 
 func exampleTransaction() *Transaction {
 	return &Transaction{
-		Hash: "somehash",
+		Hash:     "somehash",
+		Metadata: map[string]interface{}{"memo": "transfer", "consensus_timestamp": int64(1000)},
 		Operations: []*Operation{
 			{
 				Index:   1,
@@ -48,6 +49,7 @@ func expectedTransaction() *types.Transaction {
 	status := "pending"
 	return &types.Transaction{
 		TransactionIdentifier: &types.TransactionIdentifier{Hash: "somehash"},
+		Metadata:              map[string]interface{}{"memo": "transfer", "consensus_timestamp": int64(1000)},
 		Operations: []*types.Operation{
 			{
 				OperationIdentifier: &types.OperationIdentifier{Index: 1},
@@ -66,8 +68,77 @@ func TestToRosettaTransaction(t *testing.T) {
 	expectedTransaction := expectedTransaction()
 
 	// when:
-	rosettaTransaction := exampleTransaction().ToRosetta()
+	rosettaTransaction := exampleTransaction().ToRosetta(config.NewSettings())
 
 	// then:
 	assert.Equal(t, expectedTransaction, rosettaTransaction)
 }
+
+func TestOmitZeroAmounts(t *testing.T) {
+	// given
+	tokenId := entityid.EntityId{EntityNum: 1001, EncodedId: 1001}
+	transaction := &Transaction{
+		Hash: "somehash",
+		Operations: []*Operation{
+			{Index: 0, Type: "fee", Amount: &HbarAmount{}},
+			{Index: 1, Type: "transfer", Amount: &HbarAmount{Value: -10}},
+			{Index: 2, Type: "transfer", Amount: &TokenAmount{TokenId: tokenId}},
+			{Index: 3, Type: "transfer", Amount: &HbarAmount{Value: 10}},
+			{Index: 4, Type: "associate"},
+		},
+	}
+	expected := []*Operation{
+		{Index: 0, Type: "transfer", Amount: &HbarAmount{Value: -10}},
+		{Index: 1, Type: "transfer", Amount: &HbarAmount{Value: 10}},
+		{Index: 2, Type: "associate"},
+	}
+
+	// when
+	transaction.OmitZeroAmounts()
+
+	// then
+	assert.Equal(t, expected, transaction.Operations)
+}
+
+func TestSplitTransactions(t *testing.T) {
+	newTransaction := func(hash string, operationsLen int) *Transaction {
+		operations := make([]*Operation, 0, operationsLen)
+		for i := 0; i < operationsLen; i++ {
+			operations = append(operations, &Operation{Index: int64(i), Type: "transfer"})
+		}
+		return &Transaction{Hash: hash, Operations: operations}
+	}
+	transactions := []*Transaction{newTransaction("a", 2), newTransaction("b", 1), newTransaction("c", 1)}
+
+	var tests = []struct {
+		name                 string
+		maxOperations        int
+		expectedTransactions []*Transaction
+		expectedOtherHashes  []string
+	}{
+		{name: "AllFit", maxOperations: 4, expectedTransactions: transactions},
+		{
+			name:                 "Split",
+			maxOperations:        3,
+			expectedTransactions: transactions[:2],
+			expectedOtherHashes:  []string{"c"},
+		},
+		{
+			name:                 "FirstDoesNotFit",
+			maxOperations:        1,
+			expectedTransactions: transactions[:0],
+			expectedOtherHashes:  []string{"a", "b", "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			actualTransactions, actualOtherHashes := SplitTransactions(transactions, tt.maxOperations)
+
+			// then
+			assert.Equal(t, tt.expectedTransactions, actualTransactions)
+			assert.Equal(t, tt.expectedOtherHashes, actualOtherHashes)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package encoder
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+)
+
+type canonicalJSONEncoder struct {
+	encoder JSONEncoder
+}
+
+// NewCanonicalJSONEncoder wraps encoder so the encoding of a Rosetta response is deterministic. The operations of the
+// response are sorted in place in the canonical order, i.e., by account, then amount, then currency with hbar before
+// any token, and reindexed before encoder writes it. Map keys need no handling since both JSONEncoder implementations
+// write them sorted
+func NewCanonicalJSONEncoder(encoder JSONEncoder) JSONEncoder {
+	return canonicalJSONEncoder{encoder: encoder}
+}
+
+func (c canonicalJSONEncoder) Encode(w io.Writer, v interface{}) error {
+	switch value := v.(type) {
+	case *rTypes.BlockResponse:
+		if value != nil {
+			canonicalizeBlock(value.Block)
+		}
+	case *rTypes.Block:
+		canonicalizeBlock(value)
+	case *rTypes.BlockTransactionResponse:
+		if value != nil {
+			canonicalizeTransaction(value.Transaction)
+		}
+	case *rTypes.Transaction:
+		canonicalizeTransaction(value)
+	case *rTypes.ConstructionParseResponse:
+		if value != nil {
+			sortOperations(value.Operations)
+		}
+	case *rTypes.SearchTransactionsResponse:
+		if value != nil {
+			for _, blockTransaction := range value.Transactions {
+				canonicalizeTransaction(blockTransaction.Transaction)
+			}
+		}
+	}
+
+	return c.encoder.Encode(w, v)
+}
+
+func canonicalizeBlock(block *rTypes.Block) {
+	if block == nil {
+		return
+	}
+
+	for _, transaction := range block.Transactions {
+		canonicalizeTransaction(transaction)
+	}
+}
+
+func canonicalizeTransaction(transaction *rTypes.Transaction) {
+	if transaction != nil {
+		sortOperations(transaction.Operations)
+	}
+}
+
+// sortOperations sorts the operations in the canonical order and reassigns the 0-based contiguous operation indexes.
+// The related operations are updated to the new indexes and operations with equal sort keys keep their relative order
+func sortOperations(operations []*rTypes.Operation) {
+	sort.SliceStable(operations, func(i, j int) bool {
+		return compareOperations(operations[i], operations[j]) < 0
+	})
+
+	indexes := make(map[int64]int64, len(operations))
+	for i, operation := range operations {
+		if operation.OperationIdentifier == nil {
+			operation.OperationIdentifier = &rTypes.OperationIdentifier{}
+		}
+		indexes[operation.OperationIdentifier.Index] = int64(i)
+		operation.OperationIdentifier.Index = int64(i)
+	}
+
+	for _, operation := range operations {
+		for _, related := range operation.RelatedOperations {
+			if index, ok := indexes[related.Index]; ok {
+				related.Index = index
+			}
+		}
+	}
+}
+
+func compareOperations(first, second *rTypes.Operation) int {
+	var firstAddress, secondAddress string
+	if first.Account != nil {
+		firstAddress = first.Account.Address
+	}
+	if second.Account != nil {
+		secondAddress = second.Account.Address
+	}
+	if result := compareEntityIds(firstAddress, secondAddress); result != 0 {
+		return result
+	}
+
+	firstValue, firstSymbol := getAmountSortKeys(first.Amount)
+	secondValue, secondSymbol := getAmountSortKeys(second.Amount)
+	if result := compareValues(firstValue, secondValue); result != 0 {
+		return result
+	}
+
+	return compareEntityIds(firstSymbol, secondSymbol)
+}
+
+// compareEntityIds compares two strings by their encoded entity ids. A string which isn't an entity id, e.g., an alias
+// or the hbar symbol, sorts before any entity id, and such strings are compared lexicographically
+func compareEntityIds(first, second string) int {
+	firstId, firstErr := parseEntityId(first)
+	secondId, secondErr := parseEntityId(second)
+	switch {
+	case firstErr == nil && secondErr == nil:
+		return compareInt64(firstId.EncodedId, secondId.EncodedId)
+	case firstErr == nil:
+		return 1
+	case secondErr == nil:
+		return -1
+	default:
+		return strings.Compare(first, second)
+	}
+}
+
+// compareValues compares two amount values numerically, falling back to lexicographical comparison if either isn't an
+// int64
+func compareValues(first, second string) int {
+	firstValue, firstErr := strconv.ParseInt(first, 10, 64)
+	secondValue, secondErr := strconv.ParseInt(second, 10, 64)
+	if firstErr != nil || secondErr != nil {
+		return strings.Compare(first, second)
+	}
+
+	return compareInt64(firstValue, secondValue)
+}
+
+func compareInt64(first, second int64) int {
+	switch {
+	case first < second:
+		return -1
+	case first > second:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// getAmountSortKeys returns the value and the currency symbol of the amount. The value is "0" for nil amount
+func getAmountSortKeys(amount *rTypes.Amount) (string, string) {
+	if amount == nil {
+		return "0", ""
+	}
+
+	var symbol string
+	if amount.Currency != nil {
+		symbol = amount.Currency.Symbol
+	}
+
+	return amount.Value, symbol
+}
+
+// parseEntityId parses the entity id in the shard.realm.num form, ignoring the checksum if present
+func parseEntityId(value string) (entityid.EntityId, error) {
+	if index := strings.Index(value, "-"); index != -1 {
+		value = value[:index]
+	}
+
+	return entityid.FromString(value)
+}
@@ -0,0 +1,175 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package encoder
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	hbar  = &rTypes.Currency{Symbol: "HBAR", Decimals: 8}
+	token = &rTypes.Currency{
+		Symbol:   "0.0.1001",
+		Decimals: 2,
+		Metadata: map[string]interface{}{"type": "FUNGIBLE_COMMON"},
+	}
+)
+
+func newOperation(index int64, address string, value string, currency *rTypes.Currency) *rTypes.Operation {
+	return &rTypes.Operation{
+		OperationIdentifier: &rTypes.OperationIdentifier{Index: index},
+		RelatedOperations:   []*rTypes.OperationIdentifier{},
+		Type:                "CRYPTOTRANSFER",
+		Status:              rTypes.String("SUCCESS"),
+		Account:             &rTypes.AccountIdentifier{Address: address},
+		Amount:              &rTypes.Amount{Value: value, Currency: currency},
+	}
+}
+
+// shuffledBlockResponse returns the same block response each time except for the order of the operations, and the
+// order the metadata maps are populated in
+func shuffledBlockResponse(random *rand.Rand) *rTypes.BlockResponse {
+	operations := []*rTypes.Operation{
+		newOperation(0, "0.0.1000", "-10", hbar),
+		newOperation(1, "0.0.98", "10", hbar),
+		newOperation(2, "0.0.200", "-5", token),
+		newOperation(3, "0.0.200", "-5", hbar),
+		newOperation(4, "0.0.200", "5", hbar),
+		newOperation(5, "0xdeadbeef", "1", hbar),
+	}
+	random.Shuffle(len(operations), func(i, j int) { operations[i], operations[j] = operations[j], operations[i] })
+
+	metadata := make(map[string]interface{})
+	for _, i := range random.Perm(50) {
+		metadata[fmt.Sprintf("key%d", i)] = i
+	}
+
+	return &rTypes.BlockResponse{
+		Block: &rTypes.Block{
+			BlockIdentifier:       &rTypes.BlockIdentifier{Index: 100, Hash: "0xabc"},
+			ParentBlockIdentifier: &rTypes.BlockIdentifier{Index: 99, Hash: "0xdef"},
+			Timestamp:             1631234567890,
+			Transactions: []*rTypes.Transaction{
+				{
+					TransactionIdentifier: &rTypes.TransactionIdentifier{Hash: "0x123"},
+					Operations:            operations,
+					Metadata:              metadata,
+				},
+			},
+		},
+	}
+}
+
+func TestCanonicalJSONEncoderRepeatedResponsesByteIdentical(t *testing.T) {
+	for _, inner := range []JSONEncoder{NewJSONEncoder(), NewStdJSONEncoder()} {
+		t.Run(fmt.Sprintf("%T", inner), func(t *testing.T) {
+			// given
+			random := rand.New(rand.NewSource(1))
+			canonicalEncoder := NewCanonicalJSONEncoder(inner)
+			expected := &bytes.Buffer{}
+			assert.NoError(t, canonicalEncoder.Encode(expected, shuffledBlockResponse(random)))
+
+			for i := 0; i < 20; i++ {
+				actual := &bytes.Buffer{}
+
+				// when
+				err := canonicalEncoder.Encode(actual, shuffledBlockResponse(random))
+
+				// then
+				assert.NoError(t, err)
+				assert.Equal(t, expected.Bytes(), actual.Bytes())
+			}
+		})
+	}
+}
+
+func TestCanonicalJSONEncoderOperationOrder(t *testing.T) {
+	// given
+	response := shuffledBlockResponse(rand.New(rand.NewSource(2)))
+	expected := []*rTypes.Operation{
+		newOperation(0, "0xdeadbeef", "1", hbar),
+		newOperation(1, "0.0.98", "10", hbar),
+		newOperation(2, "0.0.200", "-5", hbar),
+		newOperation(3, "0.0.200", "-5", token),
+		newOperation(4, "0.0.200", "5", hbar),
+		newOperation(5, "0.0.1000", "-10", hbar),
+	}
+
+	// when
+	err := NewCanonicalJSONEncoder(NewJSONEncoder()).Encode(&bytes.Buffer{}, response)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, expected, response.Block.Transactions[0].Operations)
+}
+
+func TestCanonicalJSONEncoderRelatedOperations(t *testing.T) {
+	// given
+	first := newOperation(0, "0.0.200", "-5", hbar)
+	second := newOperation(1, "0.0.100", "5", hbar)
+	second.RelatedOperations = []*rTypes.OperationIdentifier{{Index: 0}}
+	response := &rTypes.ConstructionParseResponse{Operations: []*rTypes.Operation{first, second}}
+
+	// when
+	err := NewCanonicalJSONEncoder(NewJSONEncoder()).Encode(&bytes.Buffer{}, response)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []*rTypes.Operation{second, first}, response.Operations)
+	assert.Equal(t, int64(0), second.OperationIdentifier.Index)
+	assert.Equal(t, int64(1), first.OperationIdentifier.Index)
+	assert.Equal(t, []*rTypes.OperationIdentifier{{Index: 1}}, second.RelatedOperations)
+}
+
+func TestCanonicalJSONEncoderPassThrough(t *testing.T) {
+	var tests = []struct {
+		name  string
+		value interface{}
+	}{
+		{name: "Error", value: &rTypes.Error{Code: 101, Message: "Block not found", Retriable: true}},
+		{name: "NilBlockResponse", value: (*rTypes.BlockResponse)(nil)},
+		{name: "NilBlock", value: &rTypes.BlockResponse{}},
+		{name: "NilTransaction", value: &rTypes.BlockTransactionResponse{}},
+		{name: "Nil", value: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			expected := &bytes.Buffer{}
+			assert.NoError(t, NewJSONEncoder().Encode(expected, tt.value))
+			actual := &bytes.Buffer{}
+
+			// when
+			err := NewCanonicalJSONEncoder(NewJSONEncoder()).Encode(actual, tt.value)
+
+			// then
+			assert.NoError(t, err)
+			assert.Equal(t, expected.String(), actual.String())
+		})
+	}
+}
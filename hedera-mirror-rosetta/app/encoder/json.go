@@ -0,0 +1,95 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package encoder
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/segmentio/encoding/json"
+)
+
+const (
+	contentType = "application/json; charset=UTF-8"
+
+	// buffers grown beyond maxPooledBufferSize by an unusually large response aren't put back to the pool, so a few
+	// huge blocks don't pin their memory for the lifetime of the process
+	maxPooledBufferSize = 16 * 1024 * 1024
+)
+
+// JSONEncoder writes the JSON encoding of v followed by a newline to w, i.e., the output of encoding/json Encoder
+type JSONEncoder interface {
+	Encode(w io.Writer, v interface{}) error
+}
+
+type fastJSONEncoder struct{}
+
+func (fastJSONEncoder) Encode(w io.Writer, v interface{}) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+type stdJSONEncoder struct{}
+
+func (stdJSONEncoder) Encode(w io.Writer, v interface{}) error {
+	return stdjson.NewEncoder(w).Encode(v)
+}
+
+// NewJSONEncoder creates a JSONEncoder backed by segmentio/encoding, whose output is byte-identical to encoding/json
+// at a fraction of the CPU cost
+func NewJSONEncoder() JSONEncoder {
+	return fastJSONEncoder{}
+}
+
+// NewStdJSONEncoder creates a JSONEncoder backed by encoding/json
+func NewStdJSONEncoder() JSONEncoder {
+	return stdJSONEncoder{}
+}
+
+var bufferPool = sync.Pool{New: func() interface{} { return new(bytes.Buffer) }}
+
+// EncodeJSONResponse is the drop-in replacement of the rosetta-sdk-go server.EncodeJSONResponse. The response is
+// encoded into a pooled buffer first so an encoding error is reported with a 500 status instead of after the status
+// has been written
+func EncodeJSONResponse(encoder JSONEncoder, v interface{}, status int, w http.ResponseWriter) {
+	buffer := bufferPool.Get().(*bytes.Buffer)
+	defer putBuffer(buffer)
+
+	if err := encoder.Encode(buffer, v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_, _ = w.Write(buffer.Bytes())
+}
+
+func putBuffer(buffer *bytes.Buffer) {
+	if buffer.Cap() > maxPooledBufferSize {
+		return
+	}
+
+	buffer.Reset()
+	bufferPool.Put(buffer)
+}
@@ -0,0 +1,175 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package encoder
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func largeBlockResponse(transactionCount int) *rTypes.BlockResponse {
+	transactions := make([]*rTypes.Transaction, 0, transactionCount)
+	for i := 0; i < transactionCount; i++ {
+		index := int64(i)
+		transactions = append(transactions, &rTypes.Transaction{
+			TransactionIdentifier: &rTypes.TransactionIdentifier{Hash: fmt.Sprintf("0x%064x", i)},
+			Operations: []*rTypes.Operation{
+				{
+					OperationIdentifier: &rTypes.OperationIdentifier{Index: 0},
+					Type:                "CRYPTOTRANSFER",
+					Status:              rTypes.String("SUCCESS"),
+					Account:             &rTypes.AccountIdentifier{Address: fmt.Sprintf("0.0.%d", i)},
+					Amount: &rTypes.Amount{
+						Value:    fmt.Sprintf("-%d", i),
+						Currency: &rTypes.Currency{Symbol: "HBAR", Decimals: 8},
+					},
+				},
+				{
+					OperationIdentifier: &rTypes.OperationIdentifier{Index: 1, NetworkIndex: &index},
+					RelatedOperations:   []*rTypes.OperationIdentifier{{Index: 0}},
+					Type:                "CRYPTOTRANSFER",
+					Status:              rTypes.String("SUCCESS"),
+					Account:             &rTypes.AccountIdentifier{Address: "0.0.98"},
+					Amount: &rTypes.Amount{
+						Value: fmt.Sprintf("%d", i),
+						Currency: &rTypes.Currency{
+							Symbol:   "0.0.1001",
+							Decimals: 2,
+							Metadata: map[string]interface{}{"type": "FUNGIBLE_COMMON"},
+						},
+					},
+				},
+			},
+			Metadata: map[string]interface{}{
+				"memo":   "<a href=\"x\">&amp;</a>   ünïcödé \t\n",
+				"fee":    math.MaxInt64,
+				"rate":   0.000123456789,
+				"large":  1e21,
+				"flags":  []interface{}{true, false, nil},
+				"nested": map[string]interface{}{"z": 1, "a": []byte("bytes")},
+			},
+		})
+	}
+
+	return &rTypes.BlockResponse{
+		Block: &rTypes.Block{
+			BlockIdentifier:       &rTypes.BlockIdentifier{Index: 100, Hash: "0xabc"},
+			ParentBlockIdentifier: &rTypes.BlockIdentifier{Index: 99, Hash: "0xdef"},
+			Timestamp:             1631234567890,
+			Transactions:          transactions,
+			Metadata:              map[string]interface{}{"exchange_rate": map[string]interface{}{"cent": 12}},
+		},
+		OtherTransactions: []*rTypes.TransactionIdentifier{},
+	}
+}
+
+func TestJSONEncoderOutputIdenticalToStd(t *testing.T) {
+	var tests = []struct {
+		name  string
+		value interface{}
+	}{
+		{name: "LargeBlock", value: largeBlockResponse(1000)},
+		{name: "EmptyBlock", value: &rTypes.BlockResponse{}},
+		{name: "Error", value: &rTypes.Error{Code: 101, Message: "Block not found", Retriable: true}},
+		{name: "Nil", value: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			expected := &bytes.Buffer{}
+			assert.NoError(t, stdjson.NewEncoder(expected).Encode(tt.value))
+			actual := &bytes.Buffer{}
+
+			// when
+			err := NewJSONEncoder().Encode(actual, tt.value)
+
+			// then
+			assert.NoError(t, err)
+			assert.Equal(t, expected.String(), actual.String())
+		})
+	}
+}
+
+func TestEncodeJSONResponse(t *testing.T) {
+	for _, encoder := range []JSONEncoder{NewJSONEncoder(), NewStdJSONEncoder()} {
+		t.Run(fmt.Sprintf("%T", encoder), func(t *testing.T) {
+			// given
+			value := largeBlockResponse(10)
+			expected := &bytes.Buffer{}
+			_ = stdjson.NewEncoder(expected).Encode(value)
+			recorder := httptest.NewRecorder()
+
+			// when
+			EncodeJSONResponse(encoder, value, http.StatusOK, recorder)
+
+			// then
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, contentType, recorder.Header().Get("Content-Type"))
+			assert.Equal(t, expected.String(), recorder.Body.String())
+		})
+	}
+}
+
+func TestEncodeJSONResponseError(t *testing.T) {
+	// given
+	recorder := httptest.NewRecorder()
+
+	// when
+	EncodeJSONResponse(NewJSONEncoder(), map[string]interface{}{"value": make(chan int)}, http.StatusOK, recorder)
+
+	// then
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.NotEmpty(t, recorder.Body.String())
+}
+
+func TestPutBufferDropsLargeBuffer(t *testing.T) {
+	// given
+	buffer := bytes.NewBuffer(make([]byte, 0, maxPooledBufferSize+1))
+	buffer.WriteString("data")
+
+	// when
+	putBuffer(buffer)
+
+	// then
+	assert.Equal(t, 4, buffer.Len())
+}
+
+func BenchmarkJSONEncoder(b *testing.B) {
+	value := largeBlockResponse(5000)
+	for _, encoder := range []JSONEncoder{NewJSONEncoder(), NewStdJSONEncoder()} {
+		b.Run(fmt.Sprintf("%T", encoder), func(b *testing.B) {
+			buffer := &bytes.Buffer{}
+			for i := 0; i < b.N; i++ {
+				buffer.Reset()
+				_ = encoder.Encode(buffer, value)
+			}
+		})
+	}
+}
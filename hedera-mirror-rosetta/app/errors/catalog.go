@@ -0,0 +1,129 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package errors
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"gopkg.in/yaml.v2"
+)
+
+// DefaultLocale is the locale of the messages in Errors
+const DefaultLocale = "en"
+
+// Catalog holds the translated error messages, keyed by the lower case locale, e.g., de or pt-br, and then the error
+// code. Only the messages are translated, the codes and the retriable flags of the errors never change
+type Catalog map[string]map[int32]string
+
+// LoadCatalog reads the catalog from the yaml file, which maps each locale to the translated messages of the error
+// codes. Unknown locales or error codes are rejected so a typo doesn't silently fall back to English
+func LoadCatalog(file string) (Catalog, error) {
+	content, err := ioutil.ReadFile(file) // #nosec
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]map[int32]string
+	if err = yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the error message catalog %s: %w", file, err)
+	}
+
+	return NewCatalog(raw)
+}
+
+// NewCatalog validates the translated messages and returns the catalog
+func NewCatalog(messages map[string]map[int32]string) (Catalog, error) {
+	catalog := make(Catalog, len(messages))
+	for locale, translated := range messages {
+		normalized := strings.ToLower(strings.TrimSpace(locale))
+		if !isValidLocale(normalized) {
+			return nil, fmt.Errorf("invalid locale '%s' in the error message catalog", locale)
+		}
+
+		if normalized == DefaultLocale {
+			return nil, fmt.Errorf("locale '%s' is the default and can't be translated", locale)
+		}
+
+		for code, message := range translated {
+			if !codes[code] {
+				return nil, fmt.Errorf("unknown error code %d for locale '%s' in the error message catalog", code, locale)
+			}
+
+			if strings.TrimSpace(message) == "" {
+				return nil, fmt.Errorf("empty message of error code %d for locale '%s'", code, locale)
+			}
+		}
+
+		catalog[normalized] = translated
+	}
+
+	return catalog, nil
+}
+
+// HasLocale returns true if the catalog has translated messages for the locale
+func (c Catalog) HasLocale(locale string) bool {
+	_, ok := c[locale]
+	return ok
+}
+
+// Localize returns a copy of err with the message translated to the locale. err is returned as is if there is no
+// translation
+func (c Catalog) Localize(err *types.Error, locale string) *types.Error {
+	if err == nil {
+		return nil
+	}
+
+	message, ok := c[locale][err.Code]
+	if !ok || !codes[err.Code] {
+		return err
+	}
+
+	localized := *err
+	localized.Message = message
+	return &localized
+}
+
+// isValidLocale returns true if locale is a language tag of alphanumeric subtags separated by '-', starting with a
+// 2 to 8 letters primary language subtag
+func isValidLocale(locale string) bool {
+	subtags := strings.Split(locale, "-")
+	if len(subtags[0]) < 2 || len(subtags[0]) > 8 {
+		return false
+	}
+
+	for i, subtag := range subtags {
+		if len(subtag) == 0 || len(subtag) > 8 {
+			return false
+		}
+
+		for _, c := range subtag {
+			isLetter := c >= 'a' && c <= 'z'
+			if !isLetter && (i == 0 || c < '0' || c > '9') {
+				return false
+			}
+		}
+	}
+
+	return true
+}
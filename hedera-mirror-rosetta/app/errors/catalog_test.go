@@ -0,0 +1,101 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package errors
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadCatalog(t *testing.T) {
+	// given
+	file := filepath.Join(t.TempDir(), "messages.yml")
+	content := "de:\n  124: Ungültiges Argument\npt-BR:\n  124: Argumento inválido\n"
+	assert.NoError(t, ioutil.WriteFile(file, []byte(content), 0600))
+
+	// when
+	catalog, err := LoadCatalog(file)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, Catalog{
+		"de":    {ErrInvalidArgument.Code: "Ungültiges Argument"},
+		"pt-br": {ErrInvalidArgument.Code: "Argumento inválido"},
+	}, catalog)
+}
+
+func TestLoadCatalogMissingFile(t *testing.T) {
+	// when
+	catalog, err := LoadCatalog(filepath.Join(t.TempDir(), "missing.yml"))
+
+	// then
+	assert.Error(t, err)
+	assert.Nil(t, catalog)
+}
+
+func TestNewCatalogInvalid(t *testing.T) {
+	var tests = []struct {
+		name     string
+		messages map[string]map[int32]string
+	}{
+		{name: "DefaultLocale", messages: map[string]map[int32]string{"EN": {106: "Invalid"}}},
+		{name: "EmptyMessage", messages: map[string]map[int32]string{"de": {106: " "}}},
+		{name: "EmptyLocale", messages: map[string]map[int32]string{"": {106: "Ungültig"}}},
+		{name: "InvalidLocale", messages: map[string]map[int32]string{"de_DE": {106: "Ungültig"}}},
+		{name: "NumericPrimaryLanguage", messages: map[string]map[int32]string{"419": {106: "Inválido"}}},
+		{name: "UnknownCode", messages: map[string]map[int32]string{"de": {999: "Unbekannt"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			catalog, err := NewCatalog(tt.messages)
+
+			// then
+			assert.Error(t, err)
+			assert.Nil(t, catalog)
+		})
+	}
+}
+
+func TestCatalogLocalize(t *testing.T) {
+	// given
+	catalog, err := NewCatalog(map[string]map[int32]string{"de": {ErrInvalidArgument.Code: "Ungültiges Argument"}})
+	assert.NoError(t, err)
+	detailed := AddErrorDetails(ErrInvalidArgument, DetailField, "limit")
+
+	// when
+	actual := catalog.Localize(detailed, "de")
+
+	// then
+	assert.Equal(t, "Ungültiges Argument", actual.Message)
+	assert.Equal(t, detailed.Code, actual.Code)
+	assert.Equal(t, detailed.Retriable, actual.Retriable)
+	assert.Equal(t, detailed.Details, actual.Details)
+	assert.Equal(t, InvalidArgument, detailed.Message)
+	assert.Equal(t, InvalidArgument, ErrInvalidArgument.Message)
+	assert.Same(t, ErrBlockNotFound, catalog.Localize(ErrBlockNotFound, "de"))
+	assert.Same(t, ErrInvalidArgument, catalog.Localize(ErrInvalidArgument, "fr"))
+	assert.Nil(t, catalog.Localize(nil, "de"))
+}
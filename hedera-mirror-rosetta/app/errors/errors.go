@@ -21,9 +21,34 @@
 package errors
 
 import (
+	"fmt"
+
 	"github.com/coinbase/rosetta-sdk-go/types"
 )
 
+// Keys of the error details. The errors returned by the services are always from Errors, with a copy of the details
+// set to some of the keys below if more context is available
+const (
+	// DetailAccount is the address of the account the error is about
+	DetailAccount = "account"
+	// DetailCorrelationId is the id to correlate the failed request with its logs and error reports
+	DetailCorrelationId = "correlation_id"
+	// DetailCurrency is the symbol of the currency the error is about
+	DetailCurrency = "currency"
+	// DetailField is the name of the offending request field or call parameter
+	DetailField = "field"
+	// DetailFields are the names of the offending transaction fields
+	DetailFields = "fields"
+	// DetailHederaStatus is the response code returned by the consensus node
+	DetailHederaStatus = "hedera_status"
+	// DetailIndex is the index of the failed item in the request, e.g., a request in a batch or a signature
+	DetailIndex = "index"
+	// DetailReason is the description of the underlying failure
+	DetailReason = "reason"
+	// DetailTransactionType is the data type of the transaction body, e.g., consensusCreateTopic
+	DetailTransactionType = "transaction_type"
+)
+
 const (
 	AccountNotFound                string = "Account not found"
 	BlockNotFound                  string = "Block not found"
@@ -61,6 +86,29 @@ const (
 	TokenNotFound                  string = "Token not found"
 	InvalidTransaction             string = "Invalid transaction"
 	InvalidCurrency                string = "Invalid currency"
+	InvalidSchedule                string = "Invalid schedule"
+	ScheduleNotFound               string = "Schedule not found"
+	CallMethodUnsupported          string = "Call method unsupported"
+	TokenNotAssociated             string = "Token not associated with account"
+	InvalidBatchSize               string = "Invalid batch size"
+	ExchangeRateNotFound           string = "Exchange rate not found"
+	ServiceUnavailable             string = "Service unavailable"
+	InsufficientBalance            string = "Insufficient balance"
+	NftNotFound                    string = "NFT not found"
+	AddressBookNotFound            string = "Address book not found"
+	EntityIdChecksumMismatch       string = "Entity id checksum doesn't match the network"
+	TokenAssociationNotFound       string = "Token association not found"
+	TransactionUnmodeledFields     string = "Transaction has fields not modeled by its operations"
+	TokenRepeated                  string = "Token repeated in the operations"
+	Unauthorized                   string = "Unauthorized"
+	FeeScheduleNotFound            string = "Fee schedule not found"
+	TransactionTypeUnsupported     string = "Transaction type unsupported"
+	BlockHashAmbiguous             string = "Block hash prefix matches multiple blocks"
+	AmountOverflow                 string = "Amount overflows a 64-bit integer"
+	BlockAuditFailed               string = "Operations of the block don't add up to the expected net"
+	TransactionBodiesMismatch      string = "Transaction bodies for different nodes don't match"
+	WrongNetwork                   string = "Account doesn't exist on the network"
+	MultipleNodesUnsupported       string = "Transaction frozen for multiple nodes can't be combined"
 	InternalServerError            string = "Internal Server Error"
 )
 
@@ -100,12 +148,57 @@ var (
 	ErrTokenNotFound                  = newError(TokenNotFound, 132, false)
 	ErrInvalidTransaction             = newError(InvalidTransaction, 133, false)
 	ErrInvalidCurrency                = newError(InvalidCurrency, 134, false)
+	ErrInvalidSchedule                = newError(InvalidSchedule, 135, false)
+	ErrScheduleNotFound               = newError(ScheduleNotFound, 136, false)
+	ErrCallMethodUnsupported          = newError(CallMethodUnsupported, 137, false)
+	ErrTokenNotAssociated             = newError(TokenNotAssociated, 138, true)
+	ErrInvalidBatchSize               = newError(InvalidBatchSize, 139, false)
+	ErrExchangeRateNotFound           = newError(ExchangeRateNotFound, 140, true)
+	ErrServiceUnavailable             = newError(ServiceUnavailable, 141, true)
+	ErrInsufficientBalance            = newError(InsufficientBalance, 142, true)
+	ErrNftNotFound                    = newError(NftNotFound, 143, false)
+	ErrAddressBookNotFound            = newError(AddressBookNotFound, 144, true)
+	ErrEntityIdChecksumMismatch       = newError(EntityIdChecksumMismatch, 145, false)
+	ErrTokenAssociationNotFound       = newError(TokenAssociationNotFound, 146, true)
+	ErrTransactionUnmodeledFields     = newError(TransactionUnmodeledFields, 147, false)
+	ErrTokenRepeated                  = newError(TokenRepeated, 148, false)
+	ErrUnauthorized                   = newError(Unauthorized, 149, false)
+	ErrFeeScheduleNotFound            = newError(FeeScheduleNotFound, 150, true)
+	ErrTransactionTypeUnsupported     = newError(TransactionTypeUnsupported, 151, false)
+	ErrBlockHashAmbiguous             = newError(BlockHashAmbiguous, 152, false)
+	ErrAmountOverflow                 = newError(AmountOverflow, 153, false)
+	ErrBlockAuditFailed               = newError(BlockAuditFailed, 154, false)
+	ErrTransactionBodiesMismatch      = newError(TransactionBodiesMismatch, 155, false)
+	ErrWrongNetwork                   = newError(WrongNetwork, 156, false)
+	ErrMultipleNodesUnsupported       = newError(MultipleNodesUnsupported, 157, false)
 	ErrInternalServerError            = newError(InternalServerError, 500, true)
 
+	// Errors is the catalogue of all errors, each with a stable code. It's enumerated by /network/options
 	Errors = make([]*types.Error, 0)
+
+	codes = make(map[int32]bool)
 )
 
+// AddErrorDetails returns a copy of err with key set to value in its details, so the error in the catalogue is never
+// modified
+func AddErrorDetails(err *types.Error, key string, value interface{}) *types.Error {
+	details := make(map[string]interface{}, len(err.Details)+1)
+	for k, v := range err.Details {
+		details[k] = v
+	}
+	details[key] = value
+
+	detailed := *err
+	detailed.Details = details
+	return &detailed
+}
+
 func newError(message string, statusCode int32, retriable bool) *types.Error {
+	if codes[statusCode] {
+		panic(fmt.Sprintf("Duplicate error code %d", statusCode))
+	}
+	codes[statusCode] = true
+
 	err := &types.Error{
 		Message:   message,
 		Code:      statusCode,
@@ -0,0 +1,56 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package errors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorsHaveUniqueCodes(t *testing.T) {
+	codes := make(map[int32]string, len(Errors))
+	for _, err := range Errors {
+		assert.NotContains(t, codes, err.Code, "%s and %s share the same code", codes[err.Code], err.Message)
+		assert.NotEmpty(t, err.Message)
+		assert.Nil(t, err.Details)
+		codes[err.Code] = err.Message
+	}
+}
+
+func TestAddErrorDetails(t *testing.T) {
+	// when
+	actual := AddErrorDetails(ErrInvalidArgument, DetailField, "limit")
+	actual = AddErrorDetails(actual, DetailReason, "must be positive")
+
+	// then
+	assert.Equal(t, ErrInvalidArgument.Code, actual.Code)
+	assert.Equal(t, ErrInvalidArgument.Message, actual.Message)
+	assert.Equal(t, ErrInvalidArgument.Retriable, actual.Retriable)
+	assert.Equal(t, map[string]interface{}{DetailField: "limit", DetailReason: "must be positive"}, actual.Details)
+	assert.Nil(t, ErrInvalidArgument.Details)
+}
+
+func TestNewErrorPanicsOnDuplicateCode(t *testing.T) {
+	assert.Panics(t, func() {
+		newError("Duplicate", ErrInvalidArgument.Code, false)
+	})
+}
@@ -0,0 +1,244 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+// Package grpcapi serves the data endpoints of the Rosetta API over gRPC for the internal consumers, e.g., to stream a
+// range of blocks over one call. The services are the same as the HTTP endpoints', see rosetta_data.proto for the
+// RosettaData service
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coinbase/rosetta-sdk-go/asserter"
+	"github.com/coinbase/rosetta-sdk-go/server"
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/segmentio/encoding/json"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const serviceName = "com.hedera.mirror.rosetta.RosettaData"
+
+// BlocksRequest is the request of the Blocks stream, the blocks from StartIndex to EndIndex inclusive are streamed
+type BlocksRequest struct {
+	NetworkIdentifier *rTypes.NetworkIdentifier `json:"network_identifier"`
+	StartIndex        int64                     `json:"start_index"`
+	EndIndex          int64                     `json:"end_index"`
+}
+
+// rosettaDataService is the handler type of the RosettaData service, the request and the response are the JSON
+// encodings of the Rosetta types
+type rosettaDataService interface {
+	accountBalance(ctx context.Context, body []byte) (interface{}, error)
+	block(ctx context.Context, body []byte) (interface{}, error)
+	blocks(body []byte, stream grpc.ServerStream) error
+}
+
+// rosettaDataServer serves the RosettaData service with the Rosetta API services
+type rosettaDataServer struct {
+	accountService server.AccountAPIServicer
+	asserter       *asserter.Asserter
+	blockService   server.BlockAPIServicer
+}
+
+// rosettaDataServiceDesc is the service descriptor protoc-gen-go-grpc generates from rosetta_data.proto, the messages
+// are the well-known BytesValue so there is no generated message code
+var rosettaDataServiceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*rosettaDataService)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Block", Handler: unaryHandler("Block", rosettaDataService.block)},
+		{MethodName: "AccountBalance", Handler: unaryHandler("AccountBalance", rosettaDataService.accountBalance)},
+	},
+	Streams: []grpc.StreamDesc{
+		{StreamName: "Blocks", Handler: blocksHandler, ServerStreams: true},
+	},
+	Metadata: "rosetta_data.proto",
+}
+
+// Register registers the RosettaData service on grpcServer. The requests are validated by asserter the same way as
+// the HTTP requests
+func Register(
+	grpcServer *grpc.Server,
+	asserter *asserter.Asserter,
+	accountService server.AccountAPIServicer,
+	blockService server.BlockAPIServicer,
+) {
+	grpcServer.RegisterService(&rosettaDataServiceDesc, &rosettaDataServer{
+		accountService: accountService,
+		asserter:       asserter,
+		blockService:   blockService,
+	})
+}
+
+func (s *rosettaDataServer) block(ctx context.Context, body []byte) (interface{}, error) {
+	request := &rTypes.BlockRequest{}
+	if err := json.Unmarshal(body, request); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.asserter.BlockRequest(request); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	response, rErr := s.blockService.Block(ctx, request)
+	if rErr != nil {
+		return nil, toStatusError(rErr)
+	}
+
+	return response, nil
+}
+
+func (s *rosettaDataServer) accountBalance(ctx context.Context, body []byte) (interface{}, error) {
+	request := &rTypes.AccountBalanceRequest{}
+	if err := json.Unmarshal(body, request); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.asserter.AccountBalanceRequest(request); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	response, rErr := s.accountService.AccountBalance(ctx, request)
+	if rErr != nil {
+		return nil, toStatusError(rErr)
+	}
+
+	return response, nil
+}
+
+// blocks sends the /block response of each block in the range in order. It stops at the first error, e.g., a block
+// not found, or when the client cancels the stream
+func (s *rosettaDataServer) blocks(body []byte, stream grpc.ServerStream) error {
+	request := &BlocksRequest{}
+	if err := json.Unmarshal(body, request); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if request.StartIndex < 0 || request.EndIndex < request.StartIndex {
+		return status.Errorf(codes.InvalidArgument, "invalid block range [%d, %d]", request.StartIndex,
+			request.EndIndex)
+	}
+
+	ctx := stream.Context()
+	for index := request.StartIndex; index <= request.EndIndex; index++ {
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+
+		blockIndex := index
+		blockRequest := &rTypes.BlockRequest{
+			NetworkIdentifier: request.NetworkIdentifier,
+			BlockIdentifier:   &rTypes.PartialBlockIdentifier{Index: &blockIndex},
+		}
+		if err := s.asserter.BlockRequest(blockRequest); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		response, rErr := s.blockService.Block(ctx, blockRequest)
+		if rErr != nil {
+			return toStatusError(rErr)
+		}
+
+		message, err := toMessage(response)
+		if err != nil {
+			return err
+		}
+
+		if err = stream.SendMsg(message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unaryHandler returns the handler of the unary method, which decodes the request message and encodes the response
+func unaryHandler(
+	methodName string,
+	method func(rosettaDataService, context.Context, []byte) (interface{}, error),
+) func(interface{}, context.Context, func(interface{}) error, grpc.UnaryServerInterceptor) (interface{}, error) {
+	fullMethod := fmt.Sprintf("/%s/%s", serviceName, methodName)
+	return func(
+		srv interface{},
+		ctx context.Context,
+		decode func(interface{}) error,
+		interceptor grpc.UnaryServerInterceptor,
+	) (interface{}, error) {
+		in := &wrapperspb.BytesValue{}
+		if err := decode(in); err != nil {
+			return nil, err
+		}
+
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			response, err := method(srv.(rosettaDataService), ctx, req.(*wrapperspb.BytesValue).GetValue())
+			if err != nil {
+				return nil, err
+			}
+			return toMessage(response)
+		}
+		if interceptor == nil {
+			return handler(ctx, in)
+		}
+
+		info := &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethod}
+		return interceptor(ctx, in, info, handler)
+	}
+}
+
+func blocksHandler(srv interface{}, stream grpc.ServerStream) error {
+	in := &wrapperspb.BytesValue{}
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+
+	return srv.(rosettaDataService).blocks(in.GetValue(), stream)
+}
+
+// toMessage returns the BytesValue message of the JSON encoding of v
+func toMessage(v interface{}) (*wrapperspb.BytesValue, error) {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return wrapperspb.Bytes(value), nil
+}
+
+// toStatusError returns the status error of the Rosetta error, with the JSON encoding of the Rosetta error as the
+// detail. A retriable error is unavailable, the others are failed preconditions, e.g., a block not found
+func toStatusError(rErr *rTypes.Error) error {
+	code := codes.FailedPrecondition
+	if rErr.Retriable {
+		code = codes.Unavailable
+	}
+
+	st := status.New(code, rErr.Message)
+	if detail, err := toMessage(rErr); err == nil {
+		if withDetails, err := st.WithDetails(detail); err == nil {
+			st = withDetails
+		}
+	}
+
+	return st.Err()
+}
@@ -0,0 +1,309 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/asserter"
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var networkIdentifier = &rTypes.NetworkIdentifier{Blockchain: "Hedera", Network: "testnet"}
+
+type stubAccountAPIService struct{}
+
+func (s *stubAccountAPIService) AccountBalance(
+	_ context.Context,
+	request *rTypes.AccountBalanceRequest,
+) (*rTypes.AccountBalanceResponse, *rTypes.Error) {
+	return &rTypes.AccountBalanceResponse{
+		BlockIdentifier: &rTypes.BlockIdentifier{Index: 10, Hash: "0x0a"},
+		Balances: []*rTypes.Amount{
+			{Value: "100", Currency: &rTypes.Currency{Symbol: "HBAR", Decimals: 8}},
+		},
+		Metadata: map[string]interface{}{"account": request.AccountIdentifier.Address},
+	}, nil
+}
+
+func (s *stubAccountAPIService) AccountCoins(
+	context.Context,
+	*rTypes.AccountCoinsRequest,
+) (*rTypes.AccountCoinsResponse, *rTypes.Error) {
+	return nil, errors.ErrNotImplemented
+}
+
+type stubBlockAPIService struct {
+	notFoundIndex int64
+}
+
+func (s *stubBlockAPIService) Block(
+	_ context.Context,
+	request *rTypes.BlockRequest,
+) (*rTypes.BlockResponse, *rTypes.Error) {
+	index := *request.BlockIdentifier.Index
+	if index == s.notFoundIndex {
+		return nil, errors.ErrBlockNotFound
+	}
+
+	return &rTypes.BlockResponse{Block: &rTypes.Block{
+		BlockIdentifier:       &rTypes.BlockIdentifier{Index: index, Hash: "0x0a"},
+		ParentBlockIdentifier: &rTypes.BlockIdentifier{Index: index, Hash: "0x0a"},
+		Timestamp:             1000,
+	}}, nil
+}
+
+func (s *stubBlockAPIService) BlockTransaction(
+	context.Context,
+	*rTypes.BlockTransactionRequest,
+) (*rTypes.BlockTransactionResponse, *rTypes.Error) {
+	return nil, errors.ErrNotImplemented
+}
+
+func TestBlock(t *testing.T) {
+	// given
+	conn := newClientConn(t, -1)
+	index := int64(5)
+	request := &rTypes.BlockRequest{
+		NetworkIdentifier: networkIdentifier,
+		BlockIdentifier:   &rTypes.PartialBlockIdentifier{Index: &index},
+	}
+	response := &rTypes.BlockResponse{}
+
+	// when
+	err := invoke(conn, "Block", request, response)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), response.Block.BlockIdentifier.Index)
+}
+
+func TestBlockNotFound(t *testing.T) {
+	// given
+	conn := newClientConn(t, 5)
+	index := int64(5)
+	request := &rTypes.BlockRequest{
+		NetworkIdentifier: networkIdentifier,
+		BlockIdentifier:   &rTypes.PartialBlockIdentifier{Index: &index},
+	}
+
+	// when
+	err := invoke(conn, "Block", request, &rTypes.BlockResponse{})
+
+	// then
+	st := status.Convert(err)
+	assert.Equal(t, codes.Unavailable, st.Code())
+	assert.Equal(t, errors.ErrBlockNotFound.Message, st.Message())
+	require.Len(t, st.Details(), 1)
+	rErr := &rTypes.Error{}
+	assert.NoError(t, json.Unmarshal(st.Details()[0].(*wrapperspb.BytesValue).GetValue(), rErr))
+	assert.Equal(t, errors.ErrBlockNotFound.Code, rErr.Code)
+}
+
+func TestBlockInvalidRequest(t *testing.T) {
+	var tests = []struct {
+		name    string
+		request interface{}
+	}{
+		{name: "InvalidJson", request: json.RawMessage(`"block"`)},
+		{name: "OtherNetwork", request: &rTypes.BlockRequest{
+			NetworkIdentifier: &rTypes.NetworkIdentifier{Blockchain: "Hedera", Network: "mainnet"},
+			BlockIdentifier:   &rTypes.PartialBlockIdentifier{},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			conn := newClientConn(t, -1)
+
+			// when
+			err := invoke(conn, "Block", tt.request, &rTypes.BlockResponse{})
+
+			// then
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestAccountBalance(t *testing.T) {
+	// given
+	conn := newClientConn(t, -1)
+	request := &rTypes.AccountBalanceRequest{
+		NetworkIdentifier: networkIdentifier,
+		AccountIdentifier: &rTypes.AccountIdentifier{Address: "0.0.100"},
+	}
+	response := &rTypes.AccountBalanceResponse{}
+
+	// when
+	err := invoke(conn, "AccountBalance", request, response)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "100", response.Balances[0].Value)
+	assert.Equal(t, map[string]interface{}{"account": "0.0.100"}, response.Metadata)
+}
+
+func TestBlocks(t *testing.T) {
+	// given
+	conn := newClientConn(t, -1)
+	request := &BlocksRequest{NetworkIdentifier: networkIdentifier, StartIndex: 3, EndIndex: 5}
+
+	// when
+	indexes, err := receiveBlocks(conn, request)
+
+	// then
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, []int64{3, 4, 5}, indexes)
+}
+
+func TestBlocksNotFound(t *testing.T) {
+	// given
+	conn := newClientConn(t, 4)
+	request := &BlocksRequest{NetworkIdentifier: networkIdentifier, StartIndex: 3, EndIndex: 5}
+
+	// when
+	indexes, err := receiveBlocks(conn, request)
+
+	// then
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, []int64{3}, indexes)
+}
+
+func TestBlocksInvalidRange(t *testing.T) {
+	var tests = []struct {
+		name       string
+		startIndex int64
+		endIndex   int64
+	}{
+		{name: "NegativeStart", startIndex: -1, endIndex: 5},
+		{name: "EndBeforeStart", startIndex: 5, endIndex: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			conn := newClientConn(t, -1)
+			request := &BlocksRequest{
+				NetworkIdentifier: networkIdentifier,
+				StartIndex:        tt.startIndex,
+				EndIndex:          tt.endIndex,
+			}
+
+			// when
+			indexes, err := receiveBlocks(conn, request)
+
+			// then
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Empty(t, indexes)
+		})
+	}
+}
+
+func TestToStatusError(t *testing.T) {
+	assert.Equal(t, codes.Unavailable, status.Code(toStatusError(errors.ErrDatabaseError)))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(toStatusError(errors.ErrInvalidAccount)))
+}
+
+// newClientConn serves the RosettaData service on an in-memory listener and returns the client connection to it, the
+// block at notFoundIndex isn't found
+func newClientConn(t *testing.T, notFoundIndex int64) *grpc.ClientConn {
+	serverAsserter, err := asserter.NewServer(
+		[]string{"CRYPTOTRANSFER"},
+		true,
+		[]*rTypes.NetworkIdentifier{networkIdentifier},
+		nil,
+		false,
+	)
+	require.NoError(t, err)
+
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	Register(grpcServer, serverAsserter, &stubAccountAPIService{}, &stubBlockAPIService{notFoundIndex: notFoundIndex})
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithInsecure(),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func invoke(conn *grpc.ClientConn, method string, request interface{}, response interface{}) error {
+	body, _ := json.Marshal(request)
+	out := &wrapperspb.BytesValue{}
+	if err := conn.Invoke(context.Background(), "/"+serviceName+"/"+method, wrapperspb.Bytes(body), out); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(out.GetValue(), response)
+}
+
+// receiveBlocks returns the indexes of the blocks received from the Blocks stream and the error ending the stream
+func receiveBlocks(conn *grpc.ClientConn, request *BlocksRequest) ([]int64, error) {
+	stream, err := conn.NewStream(
+		context.Background(),
+		&grpc.StreamDesc{ServerStreams: true},
+		"/"+serviceName+"/Blocks",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	body, _ := json.Marshal(request)
+	if err = stream.SendMsg(wrapperspb.Bytes(body)); err != nil {
+		return nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, err
+	}
+
+	indexes := make([]int64, 0)
+	for {
+		out := &wrapperspb.BytesValue{}
+		if err = stream.RecvMsg(out); err != nil {
+			return indexes, err
+		}
+
+		response := &rTypes.BlockResponse{}
+		if err = json.Unmarshal(out.GetValue(), response); err != nil {
+			return indexes, err
+		}
+		indexes = append(indexes, response.Block.BlockIdentifier.Index)
+	}
+}
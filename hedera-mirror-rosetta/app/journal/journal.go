@@ -0,0 +1,305 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	OutcomeFailed    = "FAILED"
+	OutcomePending   = "PENDING"
+	OutcomeSubmitted = "SUBMITTED"
+)
+
+// Entry is the journal record of a submitted transaction. An entry still pending after a restart belongs to a
+// submission interrupted by a crash, its signed transaction can be resubmitted as is before the transaction expires
+type Entry struct {
+	Hash              string `json:"hash"`
+	NodeAccountId     string `json:"node_account_id"`
+	Outcome           string `json:"outcome"`
+	PayerAccountId    string `json:"payer_account_id"`
+	SignedTransaction string `json:"signed_transaction"`
+	Status            string `json:"status,omitempty"`
+	Timestamp         int64  `json:"timestamp"`
+	TransactionId     string `json:"transaction_id"`
+}
+
+// ToMetadata returns the entry as a map to be used in rosetta metadata
+func (e *Entry) ToMetadata(timestampStrings bool) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"hash":               e.Hash,
+		"node_account_id":    e.NodeAccountId,
+		"outcome":            e.Outcome,
+		"payer_account_id":   e.PayerAccountId,
+		"signed_transaction": e.SignedTransaction,
+		"transaction_id":     e.TransactionId,
+	}
+	types.AddTimestampMetadata(metadata, "timestamp", e.Timestamp, timestampStrings)
+	if e.Status != "" {
+		metadata["status"] = e.Status
+	}
+
+	return metadata
+}
+
+// Filter selects the journal entries to list, an empty field matches any value
+type Filter struct {
+	Hash           string
+	Outcome        string
+	PayerAccountId string
+}
+
+func (f Filter) matches(entry *Entry) bool {
+	return (f.Hash == "" || f.Hash == entry.Hash) &&
+		(f.Outcome == "" || f.Outcome == entry.Outcome) &&
+		(f.PayerAccountId == "" || f.PayerAccountId == entry.PayerAccountId)
+}
+
+// Journal is an append-only file of json lines recording every submission before and after it's sent to a consensus
+// node. Each line is synced to disk before returning, and the file is replayed on open so the last record of each hash
+// wins. Only the latest maxEntries submissions are kept, the file is compacted to them on open and whenever it has
+// twice as many records. A nil Journal records nothing
+type Journal struct {
+	entries    map[string]*Entry
+	file       *os.File
+	maxEntries int
+	mutex      sync.Mutex
+	path       string
+	records    int
+}
+
+// Open opens the journal file at path, creating it if it doesn't exist, recovers the latest maxEntries entries recorded
+// in it, and compacts the file to them
+func Open(path string, maxEntries int) (*Journal, error) {
+	if maxEntries <= 0 {
+		return nil, fmt.Errorf("invalid max entries %d, it must be positive", maxEntries)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	entries := make(map[string]*Entry)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		entry := &Entry{}
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			// the last line may be partially written if the process crashed in the middle of a record
+			log.Warnf("Skipped malformed submission journal record: %s", err)
+			continue
+		}
+		entries[entry.Hash] = entry
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	j := &Journal{entries: entries, maxEntries: maxEntries, path: path}
+	j.evict()
+	if err := j.compact(); err != nil {
+		return nil, err
+	}
+	log.Infof("Opened submission journal %s with %d submissions", path, len(j.entries))
+
+	return j, nil
+}
+
+// Record appends the entry to the journal file and replaces the previous entry with the same hash. The oldest entries
+// beyond the max entries are dropped
+func (j *Journal) Record(entry Entry) error {
+	if j == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(&entry)
+	if err != nil {
+		return err
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if _, err = j.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+
+	if err = j.file.Sync(); err != nil {
+		return err
+	}
+
+	j.entries[entry.Hash] = &entry
+	j.records++
+	j.evict()
+	if j.records >= 2*j.maxEntries {
+		return j.compact()
+	}
+
+	return nil
+}
+
+// ResolvePending resolves the entries still pending, i.e., the submissions interrupted by a crash, with find, which
+// returns the result of the transaction with the hash if the mirror node has recorded it. A recorded transaction gets
+// the submitted outcome with its result as the status. The others are reported one by one since they have to be
+// resubmitted before they expire
+func (j *Journal) ResolvePending(find func(hash string) (string, bool)) error {
+	for _, entry := range j.List(Filter{Outcome: OutcomePending}, 0) {
+		result, found := find(entry.Hash)
+		if !found {
+			log.Warnf("Submission %s of transaction %s paid by %s is pending and not recorded by the mirror node",
+				entry.Hash, entry.TransactionId, entry.PayerAccountId)
+			continue
+		}
+
+		entry.Outcome = OutcomeSubmitted
+		entry.Status = result
+		if err := j.Record(*entry); err != nil {
+			return err
+		}
+		log.Infof("Resolved pending submission %s with result %s", entry.Hash, result)
+	}
+
+	return nil
+}
+
+// List returns at most limit entries matching the filter, the latest first. A limit of 0 means no limit
+func (j *Journal) List(filter Filter, limit int) []*Entry {
+	if j == nil {
+		return nil
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	entries := make([]*Entry, 0)
+	for _, entry := range j.sortedEntries() {
+		if limit > 0 && len(entries) == limit {
+			break
+		}
+
+		if filter.matches(entry) {
+			copied := *entry
+			entries = append(entries, &copied)
+		}
+	}
+
+	return entries
+}
+
+// evict drops the oldest entries beyond the max entries
+func (j *Journal) evict() {
+	if len(j.entries) <= j.maxEntries {
+		return
+	}
+
+	entries := j.sortedEntries()
+	for _, entry := range entries[j.maxEntries:] {
+		delete(j.entries, entry.Hash)
+	}
+}
+
+// compact rewrites the journal file with only the entries kept, the oldest first, and reopens it for appending. The
+// file is replaced with a rename so a crash leaves either the old or the compacted file
+func (j *Journal) compact() error {
+	tmpPath := j.path + ".tmp"
+	tmpFile, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(tmpFile)
+	entries := j.sortedEntries()
+	for i := len(entries) - 1; i >= 0; i-- {
+		data, err := json.Marshal(entries[i])
+		if err != nil {
+			_ = tmpFile.Close()
+			return err
+		}
+		if _, err = writer.Write(append(data, '\n')); err != nil {
+			_ = tmpFile.Close()
+			return err
+		}
+	}
+
+	if err = writer.Flush(); err == nil {
+		err = tmpFile.Sync()
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if j.file != nil {
+		_ = j.file.Close()
+		j.file = nil
+	}
+	if err = os.Rename(tmpPath, j.path); err != nil {
+		return err
+	}
+
+	if j.file, err = os.OpenFile(j.path, os.O_APPEND|os.O_WRONLY, 0600); err != nil {
+		return err
+	}
+	j.records = len(entries)
+	return nil
+}
+
+// sortedEntries returns the entries, the latest first
+func (j *Journal) sortedEntries() []*Entry {
+	entries := make([]*Entry, 0, len(j.entries))
+	for _, entry := range j.entries {
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, k int) bool {
+		if entries[i].Timestamp != entries[k].Timestamp {
+			return entries[i].Timestamp > entries[k].Timestamp
+		}
+		return entries[i].Hash < entries[k].Hash
+	})
+
+	return entries
+}
+
+// Close closes the journal file
+func (j *Journal) Close() error {
+	if j == nil {
+		return nil
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	return j.file.Close()
+}
@@ -0,0 +1,264 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func pendingEntry(hash string, payer string, timestamp int64) Entry {
+	return Entry{
+		Hash:              hash,
+		NodeAccountId:     "0.0.3",
+		Outcome:           OutcomePending,
+		PayerAccountId:    payer,
+		SignedTransaction: "0x0a0b",
+		Timestamp:         timestamp,
+		TransactionId:     payer + "-1623101500-000000123",
+	}
+}
+
+func countLines(t *testing.T, path string) int {
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	return strings.Count(string(data), "\n")
+}
+
+func withOutcome(entry Entry, outcome, status string) Entry {
+	entry.Outcome = outcome
+	entry.Status = status
+	return entry
+}
+
+func TestJournalRecordAndList(t *testing.T) {
+	// given
+	j, err := Open(filepath.Join(t.TempDir(), "journal.jsonl"), 10)
+	assert.NoError(t, err)
+	defer j.Close()
+	entry1 := pendingEntry("0x01", "0.0.1001", 100)
+	entry2 := pendingEntry("0x02", "0.0.1002", 200)
+	entry3 := pendingEntry("0x03", "0.0.1001", 300)
+
+	// when
+	assert.NoError(t, j.Record(entry1))
+	assert.NoError(t, j.Record(entry2))
+	assert.NoError(t, j.Record(entry3))
+	assert.NoError(t, j.Record(withOutcome(entry1, OutcomeSubmitted, "")))
+	assert.NoError(t, j.Record(withOutcome(entry2, OutcomeFailed, "INSUFFICIENT_PAYER_BALANCE")))
+
+	// then
+	submitted := withOutcome(entry1, OutcomeSubmitted, "")
+	failed := withOutcome(entry2, OutcomeFailed, "INSUFFICIENT_PAYER_BALANCE")
+	assert.Equal(t, []*Entry{&entry3, &failed, &submitted}, j.List(Filter{}, 0))
+	assert.Equal(t, []*Entry{&entry3}, j.List(Filter{}, 1))
+	assert.Equal(t, []*Entry{&entry3, &submitted}, j.List(Filter{PayerAccountId: "0.0.1001"}, 0))
+	assert.Equal(t, []*Entry{&failed}, j.List(Filter{Outcome: OutcomeFailed}, 0))
+	assert.Equal(t, []*Entry{&submitted}, j.List(Filter{Hash: "0x01"}, 0))
+	assert.Empty(t, j.List(Filter{Hash: "0x04"}, 0))
+}
+
+func TestJournalRecoversAfterRestart(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	j, err := Open(path, 10)
+	assert.NoError(t, err)
+	entry1 := pendingEntry("0x01", "0.0.1001", 100)
+	entry2 := pendingEntry("0x02", "0.0.1002", 200)
+	assert.NoError(t, j.Record(entry1))
+	assert.NoError(t, j.Record(withOutcome(entry1, OutcomeSubmitted, "")))
+	assert.NoError(t, j.Record(entry2))
+	assert.NoError(t, j.Close())
+
+	// simulate a crash in the middle of writing a record
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	assert.NoError(t, err)
+	_, err = file.WriteString(`{"hash":"0x03","outc`)
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+
+	// when
+	j, err = Open(path, 10)
+	assert.NoError(t, err)
+	defer j.Close()
+
+	// then
+	submitted := withOutcome(entry1, OutcomeSubmitted, "")
+	assert.Equal(t, []*Entry{&entry2, &submitted}, j.List(Filter{}, 0))
+	assert.Equal(t, []*Entry{&entry2}, j.List(Filter{Outcome: OutcomePending}, 0))
+}
+
+func TestJournalListReturnsCopies(t *testing.T) {
+	// given
+	j, err := Open(filepath.Join(t.TempDir(), "journal.jsonl"), 10)
+	assert.NoError(t, err)
+	defer j.Close()
+	assert.NoError(t, j.Record(pendingEntry("0x01", "0.0.1001", 100)))
+
+	// when
+	j.List(Filter{}, 0)[0].Outcome = OutcomeSubmitted
+
+	// then
+	assert.Equal(t, OutcomePending, j.List(Filter{}, 0)[0].Outcome)
+}
+
+func TestJournalKeepsLatestEntries(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	j, err := Open(path, 2)
+	assert.NoError(t, err)
+	entry1 := pendingEntry("0x01", "0.0.1001", 100)
+	entry2 := pendingEntry("0x02", "0.0.1002", 200)
+	entry3 := pendingEntry("0x03", "0.0.1003", 300)
+
+	// when
+	assert.NoError(t, j.Record(entry1))
+	assert.NoError(t, j.Record(entry2))
+	assert.NoError(t, j.Record(entry3))
+	assert.NoError(t, j.Record(withOutcome(entry3, OutcomeSubmitted, "")))
+
+	// then
+	submitted := withOutcome(entry3, OutcomeSubmitted, "")
+	assert.Equal(t, []*Entry{&submitted, &entry2}, j.List(Filter{}, 0))
+	assert.Equal(t, 2, countLines(t, path))
+
+	assert.NoError(t, j.Close())
+	j, err = Open(path, 2)
+	assert.NoError(t, err)
+	defer j.Close()
+	assert.Equal(t, []*Entry{&submitted, &entry2}, j.List(Filter{}, 0))
+}
+
+func TestJournalCompactsOnOpen(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	j, err := Open(path, 10)
+	assert.NoError(t, err)
+	entry1 := pendingEntry("0x01", "0.0.1001", 100)
+	entry2 := pendingEntry("0x02", "0.0.1002", 200)
+	entry3 := pendingEntry("0x03", "0.0.1003", 300)
+	assert.NoError(t, j.Record(entry1))
+	assert.NoError(t, j.Record(withOutcome(entry1, OutcomeSubmitted, "")))
+	assert.NoError(t, j.Record(entry2))
+	assert.NoError(t, j.Record(entry3))
+	assert.NoError(t, j.Close())
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	assert.NoError(t, err)
+	_, err = file.WriteString(`{"hash":"0x04","outc`)
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+
+	// when
+	j, err = Open(path, 2)
+	assert.NoError(t, err)
+	defer j.Close()
+
+	// then
+	assert.Equal(t, []*Entry{&entry3, &entry2}, j.List(Filter{}, 0))
+	assert.Equal(t, 2, countLines(t, path))
+	assert.NoFileExists(t, path+".tmp")
+}
+
+func TestJournalResolvePending(t *testing.T) {
+	// given
+	j, err := Open(filepath.Join(t.TempDir(), "journal.jsonl"), 10)
+	assert.NoError(t, err)
+	defer j.Close()
+	entry1 := pendingEntry("0x01", "0.0.1001", 100)
+	entry2 := pendingEntry("0x02", "0.0.1002", 200)
+	entry3 := withOutcome(pendingEntry("0x03", "0.0.1003", 300), OutcomeFailed, "INVALID_SIGNATURE")
+	assert.NoError(t, j.Record(entry1))
+	assert.NoError(t, j.Record(entry2))
+	assert.NoError(t, j.Record(entry3))
+	found := map[string]string{"0x01": "SUCCESS", "0x03": "SUCCESS"}
+
+	// when
+	err = j.ResolvePending(func(hash string) (string, bool) {
+		result, ok := found[hash]
+		return result, ok
+	})
+
+	// then
+	assert.NoError(t, err)
+	resolved := withOutcome(entry1, OutcomeSubmitted, "SUCCESS")
+	assert.Equal(t, []*Entry{&entry3, &entry2, &resolved}, j.List(Filter{}, 0))
+}
+
+func TestOpenFails(t *testing.T) {
+	var tests = []struct {
+		name       string
+		path       string
+		maxEntries int
+	}{
+		{name: "MissingDirectory", path: filepath.Join("missing", "journal.jsonl"), maxEntries: 10},
+		{name: "ZeroMaxEntries", path: "journal.jsonl", maxEntries: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			j, err := Open(filepath.Join(t.TempDir(), tt.path), tt.maxEntries)
+
+			// then
+			assert.Error(t, err)
+			assert.Nil(t, j)
+		})
+	}
+}
+
+func TestNilJournal(t *testing.T) {
+	// given
+	var j *Journal
+
+	// when
+	err := j.Record(pendingEntry("0x01", "0.0.1001", 100))
+
+	// then
+	assert.NoError(t, err)
+	assert.Nil(t, j.List(Filter{}, 0))
+	assert.NoError(t, j.Close())
+}
+
+func TestEntryToMetadata(t *testing.T) {
+	// given
+	entry := withOutcome(pendingEntry("0x01", "0.0.1001", 100), OutcomeFailed, "INVALID_SIGNATURE")
+
+	// when
+	actual := entry.ToMetadata(false)
+
+	// then
+	assert.Equal(t, map[string]interface{}{
+		"hash":               "0x01",
+		"node_account_id":    "0.0.3",
+		"outcome":            OutcomeFailed,
+		"payer_account_id":   "0.0.1001",
+		"signed_transaction": "0x0a0b",
+		"status":             "INVALID_SIGNATURE",
+		"timestamp":          int64(100),
+		"transaction_id":     "0.0.1001-1623101500-000000123",
+	}, actual)
+	pending := pendingEntry("0x01", "0.0.1001", 100)
+	assert.NotContains(t, pending.ToMetadata(false), "status")
+}
@@ -0,0 +1,132 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package metrics
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	// QueryNameKey is the gorm setting key to explicitly name a query, e.g., db.Set(QueryNameKey, "latest_block")
+	QueryNameKey = "metrics:query_name"
+
+	maxCallerDepth = 32
+	modulePrefix   = "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/"
+	startTimeKey   = "metrics:start_time"
+	unknownQuery   = "unknown"
+)
+
+var packagePath = reflect.TypeOf(Registry{}).PkgPath()
+
+// Instrument registers gorm callbacks on dbClient to record the duration of each query in registry and to log the
+// queries slower than slowQueryThreshold with their redacted parameters. A zero slowQueryThreshold disables the log
+func Instrument(dbClient *gorm.DB, registry *Registry, slowQueryThreshold time.Duration) error {
+	after := func(db *gorm.DB) {
+		observe(db, registry, slowQueryThreshold)
+	}
+
+	callback := dbClient.Callback()
+	if err := callback.Query().Before("gorm:query").Register("metrics:before_query", start); err != nil {
+		return err
+	}
+	if err := callback.Query().After("gorm:query").Register("metrics:after_query", after); err != nil {
+		return err
+	}
+	if err := callback.Row().Before("gorm:row").Register("metrics:before_row", start); err != nil {
+		return err
+	}
+	return callback.Row().After("gorm:row").Register("metrics:after_row", after)
+}
+
+func start(db *gorm.DB) {
+	db.InstanceSet(startTimeKey, time.Now())
+}
+
+func observe(db *gorm.DB, registry *Registry, slowQueryThreshold time.Duration) {
+	value, ok := db.InstanceGet(startTimeKey)
+	if !ok {
+		return
+	}
+
+	elapsed := time.Since(value.(time.Time))
+	name := getQueryName(db)
+	registry.Observe(name, elapsed)
+
+	if slowQueryThreshold > 0 && elapsed >= slowQueryThreshold {
+		log.Warnf(
+			"Slow query %s took %s: %s, parameters: %s",
+			name,
+			elapsed,
+			strings.Join(strings.Fields(db.Statement.SQL.String()), " "),
+			redact(db.Statement.Vars),
+		)
+	}
+}
+
+// getQueryName returns the explicit name set with QueryNameKey, otherwise the name of the first function in this
+// module outside of this package up the call stack, e.g., "block.(*blockRepository).FindByIndex"
+func getQueryName(db *gorm.DB) string {
+	if value, ok := db.Get(QueryNameKey); ok {
+		if name, ok := value.(string); ok && name != "" {
+			return name
+		}
+	}
+
+	pcs := make([]uintptr, maxCallerDepth)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if isCaller(frame.Function) {
+			return trimFunctionName(frame.Function)
+		}
+
+		if !more {
+			return unknownQuery
+		}
+	}
+}
+
+func isCaller(function string) bool {
+	return strings.HasPrefix(function, modulePrefix) && !strings.HasPrefix(function, packagePath+".")
+}
+
+// trimFunctionName strips the package path, e.g., "github.com/x/app/persistence/block.(*blockRepository).FindByIndex"
+// becomes "block.(*blockRepository).FindByIndex"
+func trimFunctionName(function string) string {
+	return function[strings.LastIndex(function, "/")+1:]
+}
+
+// redact returns the types of the query parameters in place of their values
+func redact(vars []interface{}) string {
+	redacted := make([]string, 0, len(vars))
+	for i, v := range vars {
+		redacted = append(redacted, fmt.Sprintf("$%d=%T", i+1, v))
+	}
+
+	return "[" + strings.Join(redacted, ", ") + "]"
+}
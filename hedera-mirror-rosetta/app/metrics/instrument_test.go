@@ -0,0 +1,92 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package metrics
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const query = "SELECT index FROM record_file WHERE index = @index"
+
+type recordFile struct {
+	Index int64
+}
+
+func TestInstrumentFirst(t *testing.T) {
+	// given
+	dbClient, mock := mocks.DatabaseMock(t)
+	registry := NewRegistry()
+	assert.NoError(t, Instrument(dbClient, registry, 0))
+	mock.ExpectQuery(query).WithArgs(10).WillReturnRows(sqlmock.NewRows([]string{"index"}).AddRow(10))
+	rf := &recordFile{}
+
+	// when
+	err := dbClient.Set(QueryNameKey, "record_file_by_index").Raw(query, sql.Named("index", 10)).First(rf).Error
+
+	// then
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, int64(10), rf.Index)
+	assert.Equal(t, uint64(1), registry.histograms["record_file_by_index"].count)
+}
+
+func TestInstrumentScan(t *testing.T) {
+	// given
+	dbClient, mock := mocks.DatabaseMock(t)
+	registry := NewRegistry()
+	assert.NoError(t, Instrument(dbClient, registry, time.Nanosecond))
+	mock.ExpectQuery(query).WithArgs(10).WillReturnRows(sqlmock.NewRows([]string{"index"}).AddRow(10))
+	var rfs []recordFile
+
+	// when
+	err := dbClient.Raw(query, sql.Named("index", 10)).Scan(&rfs).Error
+
+	// then
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []recordFile{{Index: 10}}, rfs)
+	assert.Equal(t, uint64(1), registry.histograms[unknownQuery].count)
+}
+
+func TestTrimFunctionName(t *testing.T) {
+	assert.Equal(
+		t,
+		"block.(*blockRepository).FindByIndex",
+		trimFunctionName(modulePrefix+"app/persistence/block.(*blockRepository).FindByIndex"),
+	)
+}
+
+func TestIsCaller(t *testing.T) {
+	assert.True(t, isCaller(modulePrefix+"app/persistence/block.(*blockRepository).FindByIndex"))
+	assert.False(t, isCaller(packagePath+".observe"))
+	assert.False(t, isCaller("gorm.io/gorm.(*DB).First"))
+}
+
+func TestRedact(t *testing.T) {
+	assert.Equal(t, "[]", redact(nil))
+	assert.Equal(t, "[$1=int64, $2=[]int64, $3=<nil>]", redact([]interface{}{int64(1), []int64{2}, nil}))
+}
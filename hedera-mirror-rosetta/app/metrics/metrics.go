@@ -0,0 +1,246 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	contentTypeText   = "text/plain; version=0.0.4"
+	queryDurationName = "hedera_mirror_rosetta_db_query_duration_seconds"
+)
+
+// defaultBuckets are the upper bounds in seconds of the query duration histogram buckets
+var defaultBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// histogram is a cumulative histogram of durations
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// counter is a family of monotonically increasing counts keyed by the formatted labels
+type counter struct {
+	help   string
+	values map[string]uint64
+}
+
+// gauge is a single value which can go up and down
+type gauge struct {
+	help  string
+	value float64
+}
+
+// Registry records the duration histogram of each named query, the named counters and the named gauges, and exposes
+// them in the Prometheus text format. A nil Registry records nothing
+type Registry struct {
+	buckets    []float64
+	counters   map[string]*counter
+	gauges     map[string]*gauge
+	histograms map[string]*histogram
+	mutex      sync.Mutex
+}
+
+// NewRegistry creates a new instance of a Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		buckets:    defaultBuckets,
+		counters:   make(map[string]*counter),
+		gauges:     make(map[string]*gauge),
+		histograms: make(map[string]*histogram),
+	}
+}
+
+// Increment increments the counter with the given name, creating it with the help text if it doesn't exist
+func (r *Registry) Increment(name, help string) {
+	r.IncrementWithLabels(name, help, nil)
+}
+
+// IncrementWithLabels increments the counter with the given name and labels, creating it with the help text if it
+// doesn't exist. The label values should come from a small set to keep the number of series bounded
+func (r *Registry) IncrementWithLabels(name, help string, labels map[string]string) {
+	if r == nil {
+		return
+	}
+
+	key := formatLabels(labels)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	c, ok := r.counters[name]
+	if !ok {
+		c = &counter{help: help, values: make(map[string]uint64)}
+		r.counters[name] = c
+	}
+	c.values[key]++
+}
+
+// Observe records the duration of the query with the given name
+func (r *Registry) Observe(name string, duration time.Duration) {
+	if r == nil {
+		return
+	}
+
+	seconds := duration.Seconds()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	h, ok := r.histograms[name]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(r.buckets))}
+		r.histograms[name] = h
+	}
+
+	for i, upperBound := range r.buckets {
+		if seconds <= upperBound {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// Set sets the gauge with the given name to the value, creating it with the help text if it doesn't exist
+func (r *Registry) Set(name, help string, value float64) {
+	if r == nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	g, ok := r.gauges[name]
+	if !ok {
+		g = &gauge{help: help}
+		r.gauges[name] = g
+	}
+	g.value = value
+}
+
+// ServeHTTP implements http.Handler and writes the metrics in the Prometheus text format
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", contentTypeText)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(r.String()))
+}
+
+// String returns the histograms ordered by the query name, followed by the counters and the gauges ordered by name, in
+// the Prometheus text format
+func (r *Registry) String() string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var builder strings.Builder
+	r.writeHistograms(&builder)
+	r.writeCounters(&builder)
+	r.writeGauges(&builder)
+	return builder.String()
+}
+
+func (r *Registry) writeCounters(builder *strings.Builder) {
+	names := make([]string, 0, len(r.counters))
+	for name := range r.counters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		c := r.counters[name]
+		builder.WriteString(fmt.Sprintf("# HELP %s %s\n", name, c.help))
+		builder.WriteString(fmt.Sprintf("# TYPE %s counter\n", name))
+
+		keys := make([]string, 0, len(c.values))
+		for key := range c.values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			builder.WriteString(fmt.Sprintf("%s%s %d\n", name, key, c.values[key]))
+		}
+	}
+}
+
+func (r *Registry) writeGauges(builder *strings.Builder) {
+	names := make([]string, 0, len(r.gauges))
+	for name := range r.gauges {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		g := r.gauges[name]
+		builder.WriteString(fmt.Sprintf("# HELP %s %s\n", name, g.help))
+		builder.WriteString(fmt.Sprintf("# TYPE %s gauge\n", name))
+		builder.WriteString(fmt.Sprintf("%s %g\n", name, g.value))
+	}
+}
+
+func (r *Registry) writeHistograms(builder *strings.Builder) {
+	names := make([]string, 0, len(r.histograms))
+	for name := range r.histograms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	builder.WriteString(fmt.Sprintf("# HELP %s Duration of the database queries\n", queryDurationName))
+	builder.WriteString(fmt.Sprintf("# TYPE %s histogram\n", queryDurationName))
+	for _, name := range names {
+		h := r.histograms[name]
+		for i, upperBound := range r.buckets {
+			builder.WriteString(fmt.Sprintf("%s_bucket{query=%q,le=\"%g\"} %d\n", queryDurationName, name, upperBound,
+				h.counts[i]))
+		}
+		builder.WriteString(fmt.Sprintf("%s_bucket{query=%q,le=\"+Inf\"} %d\n", queryDurationName, name, h.count))
+		builder.WriteString(fmt.Sprintf("%s_sum{query=%q} %g\n", queryDurationName, name, h.sum))
+		builder.WriteString(fmt.Sprintf("%s_count{query=%q} %d\n", queryDurationName, name, h.count))
+	}
+}
+
+// formatLabels formats the labels ordered by name in the Prometheus text format, e.g., {outcome="success",type="x"}. It
+// returns an empty string if there are no labels
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	formatted := make([]string, 0, len(names))
+	for _, name := range names {
+		formatted = append(formatted, fmt.Sprintf("%s=%q", name, labels[name]))
+	}
+
+	return "{" + strings.Join(formatted, ",") + "}"
+}
@@ -0,0 +1,137 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistryObserve(t *testing.T) {
+	// given
+	registry := NewRegistry()
+
+	// when
+	registry.Observe("latest", 3*time.Millisecond)
+	registry.Observe("latest", 200*time.Millisecond)
+	registry.Observe("latest", 20*time.Second)
+
+	// then
+	h := registry.histograms["latest"]
+	assert.Equal(t, []uint64{0, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2}, h.counts)
+	assert.Equal(t, uint64(3), h.count)
+	assert.InDelta(t, 20.203, h.sum, 1e-9)
+}
+
+func TestRegistryServeHTTP(t *testing.T) {
+	// given
+	registry := NewRegistry()
+	registry.Observe("latest", 3*time.Millisecond)
+	registry.Observe("genesis", time.Second)
+	recorder := httptest.NewRecorder()
+
+	// when
+	registry.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	// then
+	body := recorder.Body.String()
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, contentTypeText, recorder.Header().Get("Content-Type"))
+	assert.Contains(t, body, "# TYPE hedera_mirror_rosetta_db_query_duration_seconds histogram\n")
+	assert.Contains(t, body, "hedera_mirror_rosetta_db_query_duration_seconds_bucket{query=\"latest\",le=\"0.001\"} 0\n")
+	assert.Contains(t, body, "hedera_mirror_rosetta_db_query_duration_seconds_bucket{query=\"latest\",le=\"0.005\"} 1\n")
+	assert.Contains(t, body, "hedera_mirror_rosetta_db_query_duration_seconds_bucket{query=\"genesis\",le=\"+Inf\"} 1\n")
+	assert.Contains(t, body, "hedera_mirror_rosetta_db_query_duration_seconds_sum{query=\"genesis\"} 1\n")
+	assert.Contains(t, body, "hedera_mirror_rosetta_db_query_duration_seconds_count{query=\"latest\"} 1\n")
+	assert.Less(t, strings.Index(body, "query=\"genesis\""), strings.Index(body, "query=\"latest\""))
+}
+
+func TestRegistryServeHTTPEmpty(t *testing.T) {
+	// given
+	recorder := httptest.NewRecorder()
+
+	// when
+	NewRegistry().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	// then
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.NotContains(t, recorder.Body.String(), "_bucket")
+}
+
+func TestRegistryIncrement(t *testing.T) {
+	// given
+	registry := NewRegistry()
+
+	// when
+	registry.Increment("panics_total", "The number of panics")
+	registry.Increment("panics_total", "The number of panics")
+
+	// then
+	body := registry.String()
+	assert.Contains(t, body, "# HELP panics_total The number of panics\n# TYPE panics_total counter\npanics_total 2\n")
+}
+
+func TestRegistryIncrementWithLabels(t *testing.T) {
+	// given
+	registry := NewRegistry()
+	help := "The number of transactions"
+	success := map[string]string{"type": "CRYPTOTRANSFER", "outcome": "success"}
+
+	// when
+	registry.IncrementWithLabels("transactions_total", help, success)
+	registry.IncrementWithLabels("transactions_total", help, map[string]string{"type": "TOKENMINT", "outcome": "failure"})
+	registry.IncrementWithLabels("transactions_total", help, success)
+
+	// then
+	body := registry.String()
+	assert.Contains(t, body, "# HELP transactions_total The number of transactions\n"+
+		"# TYPE transactions_total counter\n"+
+		"transactions_total{outcome=\"failure\",type=\"TOKENMINT\"} 1\n"+
+		"transactions_total{outcome=\"success\",type=\"CRYPTOTRANSFER\"} 2\n")
+}
+
+func TestRegistrySet(t *testing.T) {
+	// given
+	registry := NewRegistry()
+
+	// when
+	registry.Set("latency_seconds", "The latency", 1.5)
+	registry.Set("latency_seconds", "The latency", 0.25)
+
+	// then
+	body := registry.String()
+	assert.Contains(t, body, "# HELP latency_seconds The latency\n# TYPE latency_seconds gauge\nlatency_seconds 0.25\n")
+}
+
+func TestNilRegistry(t *testing.T) {
+	var registry *Registry
+	assert.NotPanics(t, func() {
+		registry.Increment("panics_total", "The number of panics")
+		registry.IncrementWithLabels("transactions_total", "The number of transactions", map[string]string{"a": "b"})
+		registry.Observe("latest", time.Second)
+		registry.Set("latency_seconds", "The latency", 1)
+	})
+}
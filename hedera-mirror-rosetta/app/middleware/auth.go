@@ -0,0 +1,129 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package middleware
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// ApiKeyHeader is the header carrying the api key of a construction request
+	ApiKeyHeader = "X-Api-Key"
+
+	callPath               = "/call"
+	constructionPathPrefix = "/construction/"
+)
+
+// protectedCallMethods are the /call methods authenticated like the construction requests, since they check signed
+// transactions against the network or expose the submitted ones
+var protectedCallMethods = map[string]bool{
+	config.CallMethodPrecheck:    true,
+	config.CallMethodSubmissions: true,
+}
+
+// AuthMiddleware authenticates the construction requests and the /call requests of the protected methods to next, and
+// leaves the data requests public. Such a request is authenticated with one of the apiKeys in the X-Api-Key header or,
+// if clientCertificates is true, with a client certificate verified in the TLS handshake. An unauthenticated request is
+// rejected with ErrUnauthorized
+func AuthMiddleware(next http.Handler, apiKeys []string, clientCertificates bool) (http.Handler, error) {
+	if len(apiKeys) == 0 && !clientCertificates {
+		return nil, fmt.Errorf("no api keys nor client certificates to authenticate with")
+	}
+
+	keys := make([][]byte, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		if apiKey == "" {
+			return nil, fmt.Errorf("empty api key")
+		}
+		keys = append(keys, []byte(apiKey))
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isProtected(r) || isAuthenticated(r, keys, clientCertificates) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		log.Warnf("Rejected unauthenticated request %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+		server.EncodeJSONResponse(errors.ErrUnauthorized, http.StatusUnauthorized, w)
+	}), nil
+}
+
+// isProtected returns true if the request is a construction request or a /call request of a protected method. The body
+// of a /call request is decoded the same way as by the /call endpoint to get the method and then restored for next. A
+// /call request which can't be decoded is protected, so a method can't be hidden from the check
+func isProtected(r *http.Request) bool {
+	if strings.HasPrefix(r.URL.Path, constructionPathPrefix) {
+		return true
+	}
+
+	if r.URL.Path != callPath || r.Body == nil {
+		return false
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return true
+	}
+
+	request := struct {
+		Method string `json:"method"`
+	}{}
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(&request); err != nil {
+		return true
+	}
+
+	return protectedCallMethods[request.Method]
+}
+
+// isAuthenticated returns true if the request carries one of the api keys or a verified client certificate. The api
+// keys are compared in constant time so the comparison doesn't leak how much of a key matches
+func isAuthenticated(r *http.Request, apiKeys [][]byte, clientCertificates bool) bool {
+	if clientCertificates && r.TLS != nil && len(r.TLS.VerifiedChains) != 0 {
+		return true
+	}
+
+	apiKey := []byte(r.Header.Get(ApiKeyHeader))
+	if len(apiKey) == 0 {
+		return false
+	}
+
+	authenticated := false
+	for _, key := range apiKeys {
+		if subtle.ConstantTimeCompare(apiKey, key) == 1 {
+			authenticated = true
+		}
+	}
+
+	return authenticated
+}
@@ -0,0 +1,139 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package middleware
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func okHandler(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	var tests = []struct {
+		name               string
+		path               string
+		body               string
+		apiKey             string
+		clientCertificates bool
+		verified           bool
+		expected           int
+	}{
+		{name: "DataEndpoint", path: "/block", expected: http.StatusOK},
+		{name: "ValidApiKey", path: "/construction/submit", apiKey: "key2", expected: http.StatusOK},
+		{name: "NoApiKey", path: "/construction/submit", expected: http.StatusUnauthorized},
+		{name: "WrongApiKey", path: "/construction/payloads", apiKey: "key", expected: http.StatusUnauthorized},
+		{name: "PublicCallMethod", path: "/call", body: `{"method":"tokeninfo"}`, expected: http.StatusOK},
+		{name: "PrecheckNoApiKey", path: "/call", body: `{"method":"precheck"}`, expected: http.StatusUnauthorized},
+		{
+			name:     "SubmissionsNoApiKey",
+			path:     "/call",
+			body:     `{"method":"submissions"}`,
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:     "SubmissionsValidApiKey",
+			path:     "/call",
+			body:     `{"method":"submissions"}`,
+			apiKey:   "key1",
+			expected: http.StatusOK,
+		},
+		{name: "MalformedCallNoApiKey", path: "/call", body: `{"method":`, expected: http.StatusUnauthorized},
+		{
+			name:               "VerifiedClientCertificate",
+			path:               "/construction/submit",
+			clientCertificates: true,
+			verified:           true,
+			expected:           http.StatusOK,
+		},
+		{
+			name:               "NoClientCertificate",
+			path:               "/construction/submit",
+			clientCertificates: true,
+			expected:           http.StatusUnauthorized,
+		},
+		{
+			name:     "ClientCertificateNotAccepted",
+			path:     "/construction/submit",
+			verified: true,
+			expected: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			var body string
+			handler, err := AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				data, _ := ioutil.ReadAll(r.Body)
+				body = string(data)
+				okHandler(w, r)
+			}), []string{"key1", "key2"}, tt.clientCertificates)
+			assert.NoError(t, err)
+			request := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			if tt.apiKey != "" {
+				request.Header.Set(ApiKeyHeader, tt.apiKey)
+			}
+			if tt.verified {
+				request.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{}}}}
+			}
+			recorder := httptest.NewRecorder()
+
+			// when
+			handler.ServeHTTP(recorder, request)
+
+			// then
+			assert.Equal(t, tt.expected, recorder.Code)
+			if tt.expected == http.StatusUnauthorized {
+				actual := &rTypes.Error{}
+				assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), actual))
+				assert.Equal(t, errors.ErrUnauthorized.Code, actual.Code)
+			} else {
+				assert.Equal(t, tt.body, body)
+			}
+		})
+	}
+}
+
+func TestAuthMiddlewareInvalidArguments(t *testing.T) {
+	for name, apiKeys := range map[string][]string{"NoApiKeys": nil, "EmptyApiKey": {"key", ""}} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			handler, err := AuthMiddleware(http.HandlerFunc(okHandler), apiKeys, false)
+
+			// then
+			assert.Error(t, err)
+			assert.Nil(t, handler)
+		})
+	}
+}
@@ -0,0 +1,204 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	acceptEncodingHeader  = "Accept-Encoding"
+	contentEncodingHeader = "Content-Encoding"
+	contentLengthHeader   = "Content-Length"
+	gzipEncoding          = "gzip"
+	varyHeader            = "Vary"
+)
+
+// CompressionMiddleware compresses the responses of next with gzip at level when the client accepts it in the
+// Accept-Encoding header. A response is buffered until it reaches minSize bytes, and smaller responses are sent as is
+// since compressing them doesn't pay off
+func CompressionMiddleware(next http.Handler, level int, minSize int) (http.Handler, error) {
+	if level < gzip.HuffmanOnly || level > gzip.BestCompression {
+		return nil, fmt.Errorf("invalid compression level %d", level)
+	}
+
+	if minSize < 0 {
+		return nil, fmt.Errorf("invalid compression minimum size %d", minSize)
+	}
+
+	pool := &sync.Pool{New: func() interface{} {
+		writer, _ := gzip.NewWriterLevel(io.Discard, level)
+		return writer
+	}}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add(varyHeader, acceptEncodingHeader)
+		if !acceptsGzip(r.Header.Get(acceptEncodingHeader)) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, minSize: minSize, pool: pool, status: http.StatusOK}
+		defer cw.close()
+		next.ServeHTTP(cw, r)
+	}), nil
+}
+
+// compressWriter buffers the response until it decides whether to compress it, i.e., when the response reaches the
+// minimum size, it's flushed, or it ends
+type compressWriter struct {
+	http.ResponseWriter
+	buffer      bytes.Buffer
+	decided     bool
+	gzipWriter  *gzip.Writer
+	minSize     int
+	pool        *sync.Pool
+	status      int
+	wroteHeader bool
+}
+
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.wroteHeader {
+		return
+	}
+
+	cw.status = status
+	cw.wroteHeader = true
+}
+
+func (cw *compressWriter) Write(data []byte) (int, error) {
+	cw.wroteHeader = true
+	if cw.decided {
+		if cw.gzipWriter != nil {
+			return cw.gzipWriter.Write(data)
+		}
+		return cw.ResponseWriter.Write(data)
+	}
+
+	cw.buffer.Write(data)
+	if cw.buffer.Len() >= cw.minSize {
+		if err := cw.decide(cw.ResponseWriter.Header().Get(contentEncodingHeader) == ""); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(data), nil
+}
+
+// Flush sends the buffered response uncompressed if it's not decided yet, so a streamed response isn't delayed
+func (cw *compressWriter) Flush() {
+	if !cw.decided {
+		if err := cw.decide(false); err != nil {
+			return
+		}
+	}
+
+	if cw.gzipWriter != nil {
+		if err := cw.gzipWriter.Flush(); err != nil {
+			return
+		}
+	}
+
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (cw *compressWriter) close() {
+	if !cw.decided {
+		if !cw.wroteHeader {
+			// nothing is written, let the server send the default response
+			return
+		}
+
+		cw.decide(false)
+	}
+
+	if cw.gzipWriter != nil {
+		cw.gzipWriter.Close()
+		cw.pool.Put(cw.gzipWriter)
+		cw.gzipWriter = nil
+	}
+}
+
+// decide writes the header and the buffered response, compressed with gzip if compress is true
+func (cw *compressWriter) decide(compress bool) error {
+	cw.decided = true
+	header := cw.ResponseWriter.Header()
+	if compress {
+		header.Set(contentEncodingHeader, gzipEncoding)
+		header.Del(contentLengthHeader)
+		cw.gzipWriter = cw.pool.Get().(*gzip.Writer)
+		cw.gzipWriter.Reset(cw.ResponseWriter)
+	} else if header.Get(contentLengthHeader) == "" && header.Get(contentEncodingHeader) == "" && cw.buffer.Len() > 0 {
+		header.Set(contentLengthHeader, strconv.Itoa(cw.buffer.Len()))
+	}
+	cw.ResponseWriter.WriteHeader(cw.status)
+
+	if cw.buffer.Len() == 0 {
+		return nil
+	}
+
+	var err error
+	if cw.gzipWriter != nil {
+		_, err = cw.gzipWriter.Write(cw.buffer.Bytes())
+	} else {
+		_, err = cw.ResponseWriter.Write(cw.buffer.Bytes())
+	}
+	cw.buffer.Reset()
+	return err
+}
+
+// acceptsGzip returns true if the Accept-Encoding header value accepts gzip with a non-zero quality, either explicitly
+// or with the wildcard
+func acceptsGzip(acceptEncoding string) bool {
+	wildcard := false
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		fields := strings.Split(part, ";")
+		coding := strings.ToLower(strings.TrimSpace(fields[0]))
+		if coding != gzipEncoding && coding != "*" {
+			continue
+		}
+
+		accepted := true
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				quality, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64)
+				accepted = err == nil && quality > 0
+			}
+		}
+
+		if coding == gzipEncoding {
+			return accepted
+		}
+		wildcard = accepted
+	}
+
+	return wildcard
+}
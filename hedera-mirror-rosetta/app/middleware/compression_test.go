@@ -0,0 +1,160 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const minSize = 16
+
+func bodyHandler(body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		for _, chunk := range strings.SplitAfter(body, ",") {
+			w.Write([]byte(chunk))
+		}
+	})
+}
+
+func TestCompressionMiddleware(t *testing.T) {
+	large := strings.Repeat(`{"index":1},`, 100)
+	var tests = []struct {
+		name           string
+		acceptEncoding string
+		body           string
+		compressed     bool
+	}{
+		{name: "Gzip", acceptEncoding: "gzip", body: large, compressed: true},
+		{name: "GzipWithQuality", acceptEncoding: "br;q=1.0, gzip;q=0.5", body: large, compressed: true},
+		{name: "Wildcard", acceptEncoding: "*", body: large, compressed: true},
+		{name: "GzipRejected", acceptEncoding: "gzip;q=0, *", body: large},
+		{name: "NoAcceptEncoding", body: large},
+		{name: "UnsupportedEncoding", acceptEncoding: "br", body: large},
+		{name: "BelowMinSize", acceptEncoding: "gzip", body: `{"a":1}`},
+		{name: "EmptyBody", acceptEncoding: "gzip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			handler, err := CompressionMiddleware(bodyHandler(tt.body), gzip.DefaultCompression, minSize)
+			assert.NoError(t, err)
+			request := httptest.NewRequest(http.MethodPost, "/block", nil)
+			if tt.acceptEncoding != "" {
+				request.Header.Set(acceptEncodingHeader, tt.acceptEncoding)
+			}
+			recorder := httptest.NewRecorder()
+
+			// when
+			handler.ServeHTTP(recorder, request)
+
+			// then
+			assert.Equal(t, http.StatusAccepted, recorder.Code)
+			assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			assert.Equal(t, acceptEncodingHeader, recorder.Header().Get(varyHeader))
+			body := recorder.Body.Bytes()
+			if tt.compressed {
+				assert.Equal(t, gzipEncoding, recorder.Header().Get(contentEncodingHeader))
+				assert.Less(t, len(body), len(tt.body))
+				reader, err := gzip.NewReader(bytes.NewReader(body))
+				assert.NoError(t, err)
+				body, err = io.ReadAll(reader)
+				assert.NoError(t, err)
+			} else {
+				assert.Empty(t, recorder.Header().Get(contentEncodingHeader))
+			}
+			assert.Equal(t, tt.body, string(body))
+		})
+	}
+}
+
+func TestCompressionMiddlewareAlreadyEncoded(t *testing.T) {
+	// given
+	body := strings.Repeat("a", minSize*2)
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(contentEncodingHeader, "br")
+		w.Write([]byte(body))
+	})
+	handler, _ := CompressionMiddleware(next, gzip.BestSpeed, minSize)
+	request := httptest.NewRequest(http.MethodPost, "/block", nil)
+	request.Header.Set(acceptEncodingHeader, "gzip")
+	recorder := httptest.NewRecorder()
+
+	// when
+	handler.ServeHTTP(recorder, request)
+
+	// then
+	assert.Equal(t, "br", recorder.Header().Get(contentEncodingHeader))
+	assert.Equal(t, body, recorder.Body.String())
+}
+
+func TestCompressionMiddlewareFlush(t *testing.T) {
+	// given
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("data: 1\n\n"))
+		w.(http.Flusher).Flush()
+	})
+	handler, _ := CompressionMiddleware(next, gzip.BestSpeed, minSize)
+	request := httptest.NewRequest(http.MethodGet, "/events", nil)
+	request.Header.Set(acceptEncodingHeader, "gzip")
+	recorder := httptest.NewRecorder()
+
+	// when
+	handler.ServeHTTP(recorder, request)
+
+	// then
+	assert.True(t, recorder.Flushed)
+	assert.Empty(t, recorder.Header().Get(contentEncodingHeader))
+	assert.Equal(t, "data: 1\n\n", recorder.Body.String())
+}
+
+func TestCompressionMiddlewareInvalidConfig(t *testing.T) {
+	var tests = []struct {
+		name    string
+		level   int
+		minSize int
+	}{
+		{name: "LevelTooLow", level: gzip.HuffmanOnly - 1},
+		{name: "LevelTooHigh", level: gzip.BestCompression + 1},
+		{name: "NegativeMinSize", level: gzip.BestSpeed, minSize: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			handler, err := CompressionMiddleware(http.NotFoundHandler(), tt.level, tt.minSize)
+
+			// then
+			assert.Error(t, err)
+			assert.Nil(t, handler)
+		})
+	}
+}
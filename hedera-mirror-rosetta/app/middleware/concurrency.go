@@ -0,0 +1,141 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const retryAfterHeader = "Retry-After"
+
+// ConcurrencyMiddleware caps the concurrent requests to next of each path in limits, so an aggressive client of one
+// endpoint can't take all the database connections from the others. A request over the limit waits in a queue of
+// maxQueued requests of the same path for at most queueTimeout, and it's rejected with ErrServiceUnavailable when the
+// queue is full or the timeout expires. The requests of the other paths and of the paths with a zero limit aren't
+// limited
+func ConcurrencyMiddleware(
+	next http.Handler,
+	limits map[string]int,
+	maxQueued int,
+	queueTimeout time.Duration,
+) (http.Handler, error) {
+	if maxQueued < 0 {
+		return nil, fmt.Errorf("invalid maximum queued requests %d", maxQueued)
+	}
+
+	if queueTimeout < 0 {
+		return nil, fmt.Errorf("invalid queue timeout %s", queueTimeout)
+	}
+
+	limiters := make(map[string]*concurrencyLimiter, len(limits))
+	for path, limit := range limits {
+		if limit < 0 {
+			return nil, fmt.Errorf("invalid concurrency limit %d of %s", limit, path)
+		}
+
+		if limit != 0 {
+			limiters[path] = &concurrencyLimiter{maxQueued: int32(maxQueued), slots: make(chan struct{}, limit)}
+		}
+	}
+
+	// ask the rejected clients to retry after the queue timeout, at least a second
+	retryAfter := strconv.Itoa(int(math.Max(math.Ceil(queueTimeout.Seconds()), 1)))
+
+	return &concurrencyHandler{
+		limiters:     limiters,
+		next:         next,
+		queueTimeout: queueTimeout,
+		retryAfter:   retryAfter,
+	}, nil
+}
+
+type concurrencyHandler struct {
+	limiters     map[string]*concurrencyLimiter
+	next         http.Handler
+	queueTimeout time.Duration
+	retryAfter   string
+}
+
+func (h *concurrencyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	limiter, ok := h.limiters[r.URL.Path]
+	if !ok {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+
+	if !limiter.acquire(r, h.queueTimeout) {
+		log.Warnf("Rejected request %s %s from %s over the concurrency limit", r.Method, r.URL.Path, r.RemoteAddr)
+		w.Header().Set(retryAfterHeader, h.retryAfter)
+		server.EncodeJSONResponse(errors.ErrServiceUnavailable, http.StatusServiceUnavailable, w)
+		return
+	}
+
+	defer limiter.release()
+	h.next.ServeHTTP(w, r)
+}
+
+// concurrencyLimiter holds a slot for each request being served and counts the requests waiting for one
+type concurrencyLimiter struct {
+	maxQueued int32
+	queued    int32
+	slots     chan struct{}
+}
+
+// acquire returns true once the request gets a slot, or false if the queue is full, the timeout expires or the
+// request is canceled while waiting
+func (c *concurrencyLimiter) acquire(r *http.Request, timeout time.Duration) bool {
+	select {
+	case c.slots <- struct{}{}:
+		return true
+	default:
+	}
+
+	if atomic.AddInt32(&c.queued, 1) > c.maxQueued {
+		atomic.AddInt32(&c.queued, -1)
+		return false
+	}
+	defer atomic.AddInt32(&c.queued, -1)
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case c.slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-r.Context().Done():
+		return false
+	}
+}
+
+func (c *concurrencyLimiter) release() {
+	<-c.slots
+}
@@ -0,0 +1,229 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	accountBalancePath = "/account/balance"
+	blockPath          = "/block"
+)
+
+// blockingHandler signals started when a request is being served and blocks it until release is closed
+func blockingHandler(started chan<- struct{}, release <-chan struct{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		started <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func serveAsync(handler http.Handler, path string) <-chan *httptest.ResponseRecorder {
+	done := make(chan *httptest.ResponseRecorder, 1)
+	go func() {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, path, nil))
+		done <- recorder
+	}()
+	return done
+}
+
+func assertServiceUnavailable(t *testing.T, recorder *httptest.ResponseRecorder, retryAfter string) {
+	var actual rTypes.Error
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &actual))
+	assert.Equal(t, *errors.ErrServiceUnavailable, actual)
+	assert.Equal(t, retryAfter, recorder.Header().Get(retryAfterHeader))
+}
+
+func TestConcurrencyMiddlewareRejectsOverQueue(t *testing.T) {
+	// given
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	handler, err := ConcurrencyMiddleware(
+		blockingHandler(started, release),
+		map[string]int{blockPath: 1},
+		1,
+		time.Minute,
+	)
+	assert.NoError(t, err)
+	first := serveAsync(handler, blockPath)
+	<-started
+	queued := serveAsync(handler, blockPath)
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&handler.(*concurrencyHandler).limiters[blockPath].queued) == 1
+	}, time.Second, time.Millisecond)
+
+	// when
+	rejected := httptest.NewRecorder()
+	handler.ServeHTTP(rejected, httptest.NewRequest(http.MethodPost, blockPath, nil))
+
+	// then
+	assertServiceUnavailable(t, rejected, "60")
+	close(release)
+	assert.Equal(t, http.StatusOK, (<-first).Code)
+	<-started
+	assert.Equal(t, http.StatusOK, (<-queued).Code)
+}
+
+func TestConcurrencyMiddlewareQueueTimeout(t *testing.T) {
+	// given
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	handler, err := ConcurrencyMiddleware(
+		blockingHandler(started, release),
+		map[string]int{blockPath: 1},
+		1,
+		10*time.Millisecond,
+	)
+	assert.NoError(t, err)
+	first := serveAsync(handler, blockPath)
+	<-started
+
+	// when
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, blockPath, nil))
+
+	// then
+	assertServiceUnavailable(t, recorder, "1")
+	close(release)
+	assert.Equal(t, http.StatusOK, (<-first).Code)
+}
+
+func TestConcurrencyMiddlewareCanceledWhileQueued(t *testing.T) {
+	// given
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	defer close(release)
+	handler, err := ConcurrencyMiddleware(
+		blockingHandler(started, release),
+		map[string]int{blockPath: 1},
+		1,
+		time.Minute,
+	)
+	assert.NoError(t, err)
+	serveAsync(handler, blockPath)
+	<-started
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, blockPath, nil).WithContext(ctx))
+
+	// then
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+}
+
+func TestConcurrencyMiddlewarePathsLimitedSeparately(t *testing.T) {
+	// given
+	started := make(chan struct{}, 4)
+	release := make(chan struct{})
+	handler, err := ConcurrencyMiddleware(
+		blockingHandler(started, release),
+		map[string]int{accountBalancePath: 1, blockPath: 1},
+		0,
+		0,
+	)
+	assert.NoError(t, err)
+	responses := []<-chan *httptest.ResponseRecorder{serveAsync(handler, blockPath)}
+	<-started
+
+	// when
+	for _, path := range []string{accountBalancePath, "/construction/submit", "/network/status"} {
+		responses = append(responses, serveAsync(handler, path))
+		<-started
+	}
+
+	// then
+	rejected := httptest.NewRecorder()
+	handler.ServeHTTP(rejected, httptest.NewRequest(http.MethodPost, accountBalancePath, nil))
+	assertServiceUnavailable(t, rejected, "1")
+	close(release)
+	for _, response := range responses {
+		assert.Equal(t, http.StatusOK, (<-response).Code)
+	}
+}
+
+func TestConcurrencyMiddlewareZeroLimit(t *testing.T) {
+	// given
+	const count = 8
+	started := make(chan struct{}, count)
+	release := make(chan struct{})
+	handler, err := ConcurrencyMiddleware(blockingHandler(started, release), map[string]int{blockPath: 0}, 0, 0)
+	assert.NoError(t, err)
+
+	// when
+	wg := sync.WaitGroup{}
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, blockPath, nil))
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		}()
+	}
+
+	// then
+	for i := 0; i < count; i++ {
+		<-started
+	}
+	close(release)
+	wg.Wait()
+}
+
+func TestConcurrencyMiddlewareInvalid(t *testing.T) {
+	var tests = []struct {
+		name         string
+		limits       map[string]int
+		maxQueued    int
+		queueTimeout time.Duration
+	}{
+		{name: "NegativeLimit", limits: map[string]int{blockPath: -1}},
+		{name: "NegativeMaxQueued", maxQueued: -1},
+		{name: "NegativeQueueTimeout", queueTimeout: -time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			handler, err := ConcurrencyMiddleware(bodyHandler(""), tt.limits, tt.maxQueued, tt.queueTimeout)
+
+			// then
+			assert.Error(t, err)
+			assert.Nil(t, handler)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	allowHeadersHeader  = "Access-Control-Allow-Headers"
+	allowMethodsHeader  = "Access-Control-Allow-Methods"
+	allowOriginHeader   = "Access-Control-Allow-Origin"
+	allowedMethods      = "GET, POST, OPTIONS"
+	anyOrigin           = "*"
+	maxAgeHeader        = "Access-Control-Max-Age"
+	originHeader        = "Origin"
+	requestMethodHeader = "Access-Control-Request-Method"
+)
+
+// CorsMiddleware lets the browsers of allowedOrigins call next cross-origin. An origin of "*" allows any origin, a
+// preflight request is answered with the allowedHeaders and cached by the browser for maxAge seconds, and the
+// cross-origin requests of other origins get no CORS headers so the browser blocks them
+func CorsMiddleware(next http.Handler, allowedOrigins []string, allowedHeaders []string, maxAge int) (
+	http.Handler,
+	error,
+) {
+	if maxAge < 0 {
+		return nil, fmt.Errorf("invalid CORS max age %d", maxAge)
+	}
+
+	anyAllowed := false
+	origins := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		if origin == "" {
+			return nil, fmt.Errorf("empty CORS allowed origin")
+		}
+
+		if origin == anyOrigin {
+			anyAllowed = true
+		}
+		origins[strings.ToLower(origin)] = true
+	}
+
+	headers := strings.Join(allowedHeaders, ", ")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get(originHeader)
+		allowed := origin != "" && (anyAllowed || origins[strings.ToLower(origin)])
+		if allowed {
+			if anyAllowed {
+				w.Header().Set(allowOriginHeader, anyOrigin)
+			} else {
+				// the response depends on the origin so caches must not serve it to other origins
+				w.Header().Set(allowOriginHeader, origin)
+				w.Header().Add(varyHeader, originHeader)
+			}
+		}
+
+		if r.Method != http.MethodOptions || r.Header.Get(requestMethodHeader) == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if allowed {
+			w.Header().Set(allowMethodsHeader, allowedMethods)
+			w.Header().Set(allowHeadersHeader, headers)
+			if maxAge > 0 {
+				w.Header().Set(maxAgeHeader, strconv.Itoa(maxAge))
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}), nil
+}
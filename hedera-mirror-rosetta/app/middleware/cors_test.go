@@ -0,0 +1,165 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorsMiddleware(t *testing.T) {
+	var tests = []struct {
+		name           string
+		allowedOrigins []string
+		origin         string
+		preflight      bool
+		expectedOrigin string
+		expectedVary   string
+		expectedStatus int
+	}{
+		{
+			name:           "AnyOrigin",
+			allowedOrigins: []string{"*"},
+			origin:         "https://wallet.example.com",
+			expectedOrigin: "*",
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "AllowedOrigin",
+			allowedOrigins: []string{"https://wallet.example.com"},
+			origin:         "https://Wallet.example.com",
+			expectedOrigin: "https://Wallet.example.com",
+			expectedVary:   originHeader,
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "DisallowedOrigin",
+			allowedOrigins: []string{"https://wallet.example.com"},
+			origin:         "https://evil.example.com",
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "NoOrigin",
+			allowedOrigins: []string{"*"},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "Preflight",
+			allowedOrigins: []string{"*"},
+			origin:         "https://wallet.example.com",
+			preflight:      true,
+			expectedOrigin: "*",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "PreflightDisallowedOrigin",
+			allowedOrigins: []string{"https://wallet.example.com"},
+			origin:         "https://evil.example.com",
+			preflight:      true,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+			})
+			handler, err := CorsMiddleware(next, tt.allowedOrigins, []string{"Content-Type", ApiKeyHeader}, 600)
+			assert.NoError(t, err)
+
+			method := http.MethodPost
+			if tt.preflight {
+				method = http.MethodOptions
+			}
+			request := httptest.NewRequest(method, "/block", nil)
+			if tt.origin != "" {
+				request.Header.Set(originHeader, tt.origin)
+			}
+			if tt.preflight {
+				request.Header.Set(requestMethodHeader, http.MethodPost)
+			}
+			recorder := httptest.NewRecorder()
+
+			// when
+			handler.ServeHTTP(recorder, request)
+
+			// then
+			header := recorder.Header()
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.Equal(t, tt.expectedOrigin, header.Get(allowOriginHeader))
+			assert.Equal(t, tt.expectedVary, header.Get(varyHeader))
+			if tt.preflight && tt.expectedOrigin != "" {
+				assert.Equal(t, allowedMethods, header.Get(allowMethodsHeader))
+				assert.Equal(t, "Content-Type, X-Api-Key", header.Get(allowHeadersHeader))
+				assert.Equal(t, "600", header.Get(maxAgeHeader))
+			} else {
+				assert.Empty(t, header.Get(allowMethodsHeader))
+				assert.Empty(t, header.Get(allowHeadersHeader))
+				assert.Empty(t, header.Get(maxAgeHeader))
+			}
+		})
+	}
+}
+
+func TestCorsMiddlewareNoMaxAge(t *testing.T) {
+	// given
+	handler, err := CorsMiddleware(http.NotFoundHandler(), []string{"*"}, []string{"Content-Type"}, 0)
+	assert.NoError(t, err)
+	request := httptest.NewRequest(http.MethodOptions, "/block", nil)
+	request.Header.Set(originHeader, "https://wallet.example.com")
+	request.Header.Set(requestMethodHeader, http.MethodPost)
+	recorder := httptest.NewRecorder()
+
+	// when
+	handler.ServeHTTP(recorder, request)
+
+	// then
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "Content-Type", recorder.Header().Get(allowHeadersHeader))
+	assert.Empty(t, recorder.Header().Get(maxAgeHeader))
+}
+
+func TestCorsMiddlewareInvalidArguments(t *testing.T) {
+	var tests = []struct {
+		name           string
+		allowedOrigins []string
+		maxAge         int
+	}{
+		{name: "NegativeMaxAge", allowedOrigins: []string{"*"}, maxAge: -1},
+		{name: "EmptyOrigin", allowedOrigins: []string{"https://wallet.example.com", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			handler, err := CorsMiddleware(http.NotFoundHandler(), tt.allowedOrigins, nil, tt.maxAge)
+
+			// then
+			assert.Error(t, err)
+			assert.Nil(t, handler)
+		})
+	}
+}
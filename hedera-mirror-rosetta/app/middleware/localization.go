@@ -0,0 +1,207 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+)
+
+const (
+	acceptLanguageHeader  = "Accept-Language"
+	contentLanguageHeader = "Content-Language"
+)
+
+// LocalizationMiddleware translates the messages of the error responses of next to the locale the client prefers in
+// the Accept-Language header, if the catalog has it. English is the default, and the error codes, the retriable flags
+// and the details are never changed, so clients which match on the codes aren't affected
+func LocalizationMiddleware(next http.Handler, catalog errors.Catalog) (http.Handler, error) {
+	if len(catalog) == 0 {
+		return nil, fmt.Errorf("empty error message catalog")
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add(varyHeader, acceptLanguageHeader)
+		locale := negotiateLocale(catalog, r.Header.Get(acceptLanguageHeader))
+		if locale == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		lw := &localizeWriter{ResponseWriter: w, catalog: catalog, locale: locale}
+		defer lw.close()
+		next.ServeHTTP(lw, r)
+	}), nil
+}
+
+// localizeWriter buffers the error responses so their messages can be translated when they end. Successful responses
+// are written through as is
+type localizeWriter struct {
+	http.ResponseWriter
+	buffer      bytes.Buffer
+	buffering   bool
+	catalog     errors.Catalog
+	locale      string
+	status      int
+	wroteHeader bool
+}
+
+func (lw *localizeWriter) WriteHeader(status int) {
+	if lw.wroteHeader {
+		return
+	}
+
+	lw.status = status
+	lw.wroteHeader = true
+	lw.buffering = status >= http.StatusBadRequest
+	if !lw.buffering {
+		lw.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (lw *localizeWriter) Write(data []byte) (int, error) {
+	if !lw.wroteHeader {
+		lw.WriteHeader(http.StatusOK)
+	}
+
+	if lw.buffering {
+		return lw.buffer.Write(data)
+	}
+
+	return lw.ResponseWriter.Write(data)
+}
+
+// Flush flushes a successful response. An error response is small and always sent as a whole when it ends
+func (lw *localizeWriter) Flush() {
+	if lw.buffering {
+		return
+	}
+
+	if flusher, ok := lw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (lw *localizeWriter) close() {
+	if !lw.buffering {
+		return
+	}
+
+	body := lw.buffer.Bytes()
+	if localized := lw.localize(body); localized != nil {
+		body = localized
+		header := lw.ResponseWriter.Header()
+		header.Set(contentLanguageHeader, lw.locale)
+		header.Del(contentLengthHeader)
+	}
+
+	lw.ResponseWriter.WriteHeader(lw.status)
+	lw.ResponseWriter.Write(body)
+}
+
+// localize returns the body with the message translated if it's a rosetta error in the catalog, otherwise nil
+func (lw *localizeWriter) localize(body []byte) []byte {
+	var rosettaErr rTypes.Error
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	// keep the numbers in the details as is
+	decoder.UseNumber()
+	if err := decoder.Decode(&rosettaErr); err != nil {
+		return nil
+	}
+
+	localized := lw.catalog.Localize(&rosettaErr, lw.locale)
+	if localized == &rosettaErr {
+		return nil
+	}
+
+	var buffer bytes.Buffer
+	if err := json.NewEncoder(&buffer).Encode(localized); err != nil {
+		return nil
+	}
+
+	return buffer.Bytes()
+}
+
+// negotiateLocale returns the locale in the catalog the Accept-Language header value prefers, or an empty string if
+// the default locale is preferred or none is acceptable. A language range matches the locale with the same tag first,
+// then the locale of its primary language, e.g., de-CH matches de
+func negotiateLocale(catalog errors.Catalog, acceptLanguage string) string {
+	type languageRange struct {
+		tag     string
+		quality float64
+	}
+
+	ranges := make([]languageRange, 0)
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				var err error
+				if quality, err = strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err != nil {
+					quality = 0
+				}
+			}
+		}
+
+		if quality > 0 {
+			ranges = append(ranges, languageRange{tag: tag, quality: quality})
+		}
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].quality > ranges[j].quality })
+
+	for _, languageRange := range ranges {
+		tag := languageRange.tag
+		if tag == "*" || tag == errors.DefaultLocale {
+			return ""
+		}
+
+		if catalog.HasLocale(tag) {
+			return tag
+		}
+
+		primary := strings.SplitN(tag, "-", 2)[0]
+		if primary == errors.DefaultLocale {
+			return ""
+		}
+
+		if catalog.HasLocale(primary) {
+			return primary
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,148 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const translatedMessage = "Ungültiges Argument"
+
+func errorHandler(rosettaErr *rTypes.Error, status int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(rosettaErr)
+	})
+}
+
+func newTestCatalog(t *testing.T) errors.Catalog {
+	catalog, err := errors.NewCatalog(map[string]map[int32]string{
+		"de":    {errors.ErrInvalidArgument.Code: translatedMessage},
+		"pt-br": {errors.ErrInvalidArgument.Code: "Argumento inválido"},
+	})
+	assert.NoError(t, err)
+	return catalog
+}
+
+func TestLocalizationMiddleware(t *testing.T) {
+	var tests = []struct {
+		name           string
+		acceptLanguage string
+		locale         string
+		message        string
+	}{
+		{name: "ExactMatch", acceptLanguage: "de", locale: "de", message: translatedMessage},
+		{name: "CaseInsensitive", acceptLanguage: "PT-BR", locale: "pt-br", message: "Argumento inválido"},
+		{name: "PrimaryLanguage", acceptLanguage: "de-CH", locale: "de", message: translatedMessage},
+		{name: "Quality", acceptLanguage: "fr;q=0.9, en;q=0.5, de;q=0.8", locale: "de", message: translatedMessage},
+		{name: "EnglishPreferred", acceptLanguage: "en-US, de;q=0.9", message: errors.InvalidArgument},
+		{name: "Rejected", acceptLanguage: "de;q=0", message: errors.InvalidArgument},
+		{name: "Unsupported", acceptLanguage: "fr, it", message: errors.InvalidArgument},
+		{name: "Wildcard", acceptLanguage: "*, de;q=0.5", message: errors.InvalidArgument},
+		{name: "NoAcceptLanguage", message: errors.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			detailed := errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField, "limit")
+			handler, err := LocalizationMiddleware(
+				errorHandler(detailed, http.StatusInternalServerError),
+				newTestCatalog(t),
+			)
+			assert.NoError(t, err)
+			request := httptest.NewRequest(http.MethodPost, "/block", nil)
+			if tt.acceptLanguage != "" {
+				request.Header.Set(acceptLanguageHeader, tt.acceptLanguage)
+			}
+			recorder := httptest.NewRecorder()
+
+			// when
+			handler.ServeHTTP(recorder, request)
+
+			// then
+			var actual rTypes.Error
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &actual))
+			assert.Equal(t, rTypes.Error{
+				Code:      detailed.Code,
+				Message:   tt.message,
+				Retriable: detailed.Retriable,
+				Details:   detailed.Details,
+			}, actual)
+			assert.Equal(t, tt.locale, recorder.Header().Get(contentLanguageHeader))
+			assert.Equal(t, acceptLanguageHeader, recorder.Header().Get(varyHeader))
+		})
+	}
+}
+
+func TestLocalizationMiddlewarePassThrough(t *testing.T) {
+	var tests = []struct {
+		name    string
+		handler http.Handler
+	}{
+		{name: "Success", handler: bodyHandler(`{"code":124,"message":"Invalid argument","retriable":false}`)},
+		{name: "UntranslatedError", handler: errorHandler(errors.ErrBlockNotFound, http.StatusInternalServerError)},
+		{
+			name:    "AsserterError",
+			handler: errorHandler(&rTypes.Error{Message: "NetworkIdentifier is nil"}, http.StatusInternalServerError),
+		},
+		{name: "NotJson", handler: errorHandler(nil, http.StatusNotFound)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			expected := httptest.NewRecorder()
+			tt.handler.ServeHTTP(expected, httptest.NewRequest(http.MethodPost, "/block", nil))
+			handler, err := LocalizationMiddleware(tt.handler, newTestCatalog(t))
+			assert.NoError(t, err)
+			request := httptest.NewRequest(http.MethodPost, "/block", nil)
+			request.Header.Set(acceptLanguageHeader, "de")
+			recorder := httptest.NewRecorder()
+
+			// when
+			handler.ServeHTTP(recorder, request)
+
+			// then
+			assert.Equal(t, expected.Code, recorder.Code)
+			assert.Equal(t, expected.Body.String(), recorder.Body.String())
+			assert.Empty(t, recorder.Header().Get(contentLanguageHeader))
+		})
+	}
+}
+
+func TestLocalizationMiddlewareEmptyCatalog(t *testing.T) {
+	// when
+	handler, err := LocalizationMiddleware(bodyHandler(""), errors.Catalog{})
+
+	// then
+	assert.Error(t, err)
+	assert.Nil(t, handler)
+}
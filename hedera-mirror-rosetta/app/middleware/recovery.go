@@ -0,0 +1,93 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/metrics"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// CorrelationIdHeader is the header carrying the id to correlate a failed request with its logs and error reports.
+	// The id in the request is used if present, otherwise a random one is generated
+	CorrelationIdHeader = "X-Correlation-Id"
+
+	panicsHelp   = "The number of requests recovered from a panic"
+	panicsMetric = "hedera_mirror_rosetta_http_panics_total"
+)
+
+// ErrorReporter reports the panics recovered from, e.g., a Sentry adapter capturing recovered with
+// sentry.CurrentHub().RecoverWithContext(request.Context(), recovered) and tagging the event with correlationId
+type ErrorReporter interface {
+	ReportPanic(request *http.Request, recovered interface{}, stack []byte, correlationId string)
+}
+
+// RecoveryMiddleware recovers from a panic in next and responds with ErrInternalServerError carrying the correlation
+// id in its details. The panic is logged with its stack, counted in registry, and reported to the optional reporter
+func RecoveryMiddleware(next http.Handler, registry *metrics.Registry, reporter ErrorReporter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			// the server aborts the response without logging it
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			stack := debug.Stack()
+			correlationId := getCorrelationId(r)
+			log.Errorf("Recovered from panic in %s %s with correlation id %s: %v\n%s", r.Method, r.URL.Path,
+				correlationId, recovered, stack)
+			registry.Increment(panicsMetric, panicsHelp)
+			if reporter != nil {
+				reporter.ReportPanic(r, recovered, stack, correlationId)
+			}
+
+			w.Header().Set(CorrelationIdHeader, correlationId)
+			rErr := errors.AddErrorDetails(errors.ErrInternalServerError, errors.DetailCorrelationId, correlationId)
+			server.EncodeJSONResponse(rErr, http.StatusInternalServerError, w)
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func getCorrelationId(r *http.Request) string {
+	if correlationId := r.Header.Get(CorrelationIdHeader); correlationId != "" {
+		return correlationId
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		log.Errorf("Failed to generate correlation id: %s", err)
+	}
+	return hex.EncodeToString(id)
+}
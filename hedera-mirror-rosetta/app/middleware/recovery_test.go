@@ -0,0 +1,143 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockErrorReporter struct {
+	correlationId string
+	recovered     interface{}
+	stack         []byte
+}
+
+func (m *mockErrorReporter) ReportPanic(_ *http.Request, recovered interface{}, stack []byte, correlationId string) {
+	m.correlationId = correlationId
+	m.recovered = recovered
+	m.stack = stack
+}
+
+func panicHandler(http.ResponseWriter, *http.Request) {
+	panic("boom")
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	var tests = []struct {
+		name          string
+		correlationId string
+	}{
+		{name: "GeneratedCorrelationId"},
+		{name: "RequestCorrelationId", correlationId: "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			registry := metrics.NewRegistry()
+			reporter := &mockErrorReporter{}
+			handler := RecoveryMiddleware(http.HandlerFunc(panicHandler), registry, reporter)
+			request := httptest.NewRequest(http.MethodPost, "/network/status", nil)
+			if tt.correlationId != "" {
+				request.Header.Set(CorrelationIdHeader, tt.correlationId)
+			}
+			recorder := httptest.NewRecorder()
+
+			// when
+			handler.ServeHTTP(recorder, request)
+
+			// then
+			actual := &rTypes.Error{}
+			correlationId := recorder.Header().Get(CorrelationIdHeader)
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), actual))
+			assert.Equal(t, errors.ErrInternalServerError.Code, actual.Code)
+			assert.Equal(t, map[string]interface{}{errors.DetailCorrelationId: correlationId}, actual.Details)
+			assert.NotEmpty(t, correlationId)
+			if tt.correlationId != "" {
+				assert.Equal(t, tt.correlationId, correlationId)
+			}
+			assert.Equal(t, correlationId, reporter.correlationId)
+			assert.Equal(t, "boom", reporter.recovered)
+			assert.NotEmpty(t, reporter.stack)
+			assert.Contains(t, registry.String(), panicsMetric+" 1\n")
+		})
+	}
+}
+
+func TestRecoveryMiddlewareWithoutPanic(t *testing.T) {
+	// given
+	registry := metrics.NewRegistry()
+	reporter := &mockErrorReporter{}
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	recorder := httptest.NewRecorder()
+
+	// when
+	RecoveryMiddleware(next, registry, reporter).ServeHTTP(
+		recorder,
+		httptest.NewRequest(http.MethodPost, "/network/status", nil),
+	)
+
+	// then
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Empty(t, recorder.Header().Get(CorrelationIdHeader))
+	assert.Nil(t, reporter.recovered)
+	assert.NotContains(t, registry.String(), panicsMetric)
+}
+
+func TestRecoveryMiddlewareWithoutReporter(t *testing.T) {
+	// given
+	recorder := httptest.NewRecorder()
+
+	// when
+	RecoveryMiddleware(http.HandlerFunc(panicHandler), nil, nil).ServeHTTP(
+		recorder,
+		httptest.NewRequest(http.MethodPost, "/network/status", nil),
+	)
+
+	// then
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
+
+func TestRecoveryMiddlewareRepanicsAbortHandler(t *testing.T) {
+	// given
+	next := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	})
+
+	// when
+	handler := RecoveryMiddleware(next, nil, nil)
+
+	// then
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/network/status", nil))
+	})
+}
@@ -0,0 +1,176 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package nodehealth
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashgraph/hedera-sdk-go/v2"
+)
+
+const (
+	// decay is the weight of the latest submit outcome in the moving averages, so a node recovers or degrades after a
+	// handful of submits instead of carrying its whole history
+	decay = 0.2
+	// minWeight keeps unhealthy nodes selected once in a while, so they are probed and can recover their score
+	minWeight = 0.05
+)
+
+type stats struct {
+	attempts    uint64
+	failures    uint64
+	latency     float64
+	successRate float64
+}
+
+// Score is the health of a consensus node derived from the outcomes of the submits to it
+type Score struct {
+	Attempts       uint64
+	AverageLatency time.Duration
+	Failures       uint64
+	NodeAccountId  hedera.AccountID
+	Score          float64
+	SuccessRate    float64
+}
+
+// ToMetadata returns the score as a map to be used in rosetta metadata
+func (s *Score) ToMetadata() map[string]interface{} {
+	return map[string]interface{}{
+		"attempts":           s.Attempts,
+		"average_latency_ms": s.AverageLatency.Milliseconds(),
+		"failures":           s.Failures,
+		"node_account_id":    s.NodeAccountId.String(),
+		"score":              s.Score,
+		"success_rate":       s.SuccessRate,
+	}
+}
+
+// Tracker keeps the success rate and latency moving averages of the submits per node. A node without submits has
+// the perfect score 1, a node always failing or slow tends to 0
+type Tracker struct {
+	mutex sync.RWMutex
+	stats map[hedera.AccountID]*stats
+}
+
+// NewTracker creates a Tracker without any submit recorded
+func NewTracker() *Tracker {
+	return &Tracker{stats: make(map[hedera.AccountID]*stats)}
+}
+
+// Record records the outcome and the latency of a submit to the node
+func (t *Tracker) Record(nodeAccountId hedera.AccountID, success bool, latency time.Duration) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	s, ok := t.stats[nodeAccountId]
+	outcome := 0.0
+	if success {
+		outcome = 1.0
+	}
+
+	if !ok {
+		s = &stats{latency: latency.Seconds(), successRate: outcome}
+		t.stats[nodeAccountId] = s
+	} else {
+		s.latency += decay * (latency.Seconds() - s.latency)
+		s.successRate += decay * (outcome - s.successRate)
+	}
+
+	s.attempts++
+	if !success {
+		s.failures++
+	}
+}
+
+// Scores returns the scores of the nodes with submits recorded ordered by node account id
+func (t *Tracker) Scores() []Score {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	scores := make([]Score, 0, len(t.stats))
+	for nodeAccountId, s := range t.stats {
+		scores = append(scores, Score{
+			Attempts:       s.attempts,
+			AverageLatency: time.Duration(s.latency * float64(time.Second)),
+			Failures:       s.failures,
+			NodeAccountId:  nodeAccountId,
+			Score:          s.score(),
+			SuccessRate:    s.successRate,
+		})
+	}
+
+	sort.Slice(scores, func(i, j int) bool {
+		return compare(scores[i].NodeAccountId, scores[j].NodeAccountId) < 0
+	})
+	return scores
+}
+
+// Select selects one of the candidate nodes with a probability proportional to its score, random is a number in [0, 1)
+// and the candidates must not be empty
+func (t *Tracker) Select(candidates []hedera.AccountID, random float64) hedera.AccountID {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	weights := make([]float64, 0, len(candidates))
+	total := 0.0
+	for _, candidate := range candidates {
+		weight := 1.0
+		if s, ok := t.stats[candidate]; ok {
+			weight = s.score()
+		}
+		if weight < minWeight {
+			weight = minWeight
+		}
+
+		weights = append(weights, weight)
+		total += weight
+	}
+
+	target := random * total
+	for i, weight := range weights {
+		if target < weight {
+			return candidates[i]
+		}
+		target -= weight
+	}
+
+	return candidates[len(candidates)-1]
+}
+
+// score is the success rate discounted by the latency, a node taking one second per submit scores half of an equally
+// successful node responding instantly
+func (s *stats) score() float64 {
+	return s.successRate / (1 + s.latency)
+}
+
+func compare(a, b hedera.AccountID) int {
+	for _, pair := range [][2]uint64{{a.Shard, b.Shard}, {a.Realm, b.Realm}, {a.Account, b.Account}} {
+		if pair[0] < pair[1] {
+			return -1
+		} else if pair[0] > pair[1] {
+			return 1
+		}
+	}
+
+	return 0
+}
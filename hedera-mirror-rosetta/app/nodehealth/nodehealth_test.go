@@ -0,0 +1,137 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package nodehealth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	node3 = hedera.AccountID{Account: 3}
+	node4 = hedera.AccountID{Account: 4}
+	node5 = hedera.AccountID{Account: 5}
+)
+
+func TestTrackerScores(t *testing.T) {
+	// given
+	tracker := NewTracker()
+
+	// when
+	tracker.Record(node4, false, time.Second)
+	tracker.Record(node3, true, 0)
+	tracker.Record(node3, true, time.Second)
+
+	// then
+	assert.Equal(t, []Score{
+		{
+			Attempts:       2,
+			AverageLatency: 200 * time.Millisecond,
+			NodeAccountId:  node3,
+			Score:          1 / 1.2,
+			SuccessRate:    1,
+		},
+		{
+			Attempts:       1,
+			AverageLatency: time.Second,
+			Failures:       1,
+			NodeAccountId:  node4,
+			Score:          0,
+			SuccessRate:    0,
+		},
+	}, tracker.Scores())
+}
+
+func TestTrackerScoresRecovery(t *testing.T) {
+	// given
+	tracker := NewTracker()
+	tracker.Record(node3, false, 0)
+
+	// when
+	for i := 0; i < 10; i++ {
+		tracker.Record(node3, true, 0)
+	}
+
+	// then
+	scores := tracker.Scores()
+	assert.Len(t, scores, 1)
+	assert.Equal(t, uint64(1), scores[0].Failures)
+	assert.InDelta(t, 0.89, scores[0].Score, 0.01)
+}
+
+func TestTrackerScoresEmpty(t *testing.T) {
+	assert.Empty(t, NewTracker().Scores())
+}
+
+func TestTrackerSelect(t *testing.T) {
+	// given
+	tracker := NewTracker()
+	tracker.Record(node4, false, 0)
+	candidates := []hedera.AccountID{node3, node4, node5}
+
+	var tests = []struct {
+		random   float64
+		expected hedera.AccountID
+	}{
+		// weights are 1, 0.05, and 1
+		{random: 0, expected: node3},
+		{random: 0.48, expected: node3},
+		{random: 0.49, expected: node4},
+		{random: 0.52, expected: node5},
+		{random: 0.9999, expected: node5},
+	}
+
+	for _, tt := range tests {
+		// when
+		actual := tracker.Select(candidates, tt.random)
+
+		// then
+		assert.Equal(t, tt.expected, actual, "random %f", tt.random)
+	}
+}
+
+func TestScoreToMetadata(t *testing.T) {
+	// given
+	score := Score{
+		Attempts:       3,
+		AverageLatency: 1500 * time.Millisecond,
+		Failures:       1,
+		NodeAccountId:  node3,
+		Score:          0.4,
+		SuccessRate:    0.6,
+	}
+
+	// when
+	actual := score.ToMetadata()
+
+	// then
+	assert.Equal(t, map[string]interface{}{
+		"attempts":           uint64(3),
+		"average_latency_ms": int64(1500),
+		"failures":           uint64(1),
+		"node_account_id":    "0.0.3",
+		"score":              0.4,
+		"success_rate":       0.6,
+	}, actual)
+}
@@ -22,20 +22,34 @@ package account
 
 import (
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 
 	rTypes "github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
 	hErrors "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/safemath"
+	"github.com/hashgraph/hedera-sdk-go/v2/proto"
+	log "github.com/sirupsen/logrus"
+	protobuf "google.golang.org/protobuf/proto"
 	"gorm.io/gorm"
 )
 
+const accountEntityType = 1
+
 const (
 	balanceChangeBetween string = `select
                                     coalesce((
                                       select sum(amount::bigint) from crypto_transfer
                                       where
+                                        @hbar_change and
                                         consensus_timestamp > @start and
                                         consensus_timestamp <= @end and
                                         entity_id = @account_id
@@ -54,8 +68,11 @@ const (
                                         where
                                           consensus_timestamp > @start and
                                           consensus_timestamp <= @end and
-                                          account_id = @account_id
+                                          account_id = @account_id and
+                                          tt.token_id > @after_token_id%s
                                         group by tt.account_id, tt.token_id, t.decimals
+                                        order by tt.token_id
+                                        limit @limit
                                       ) token_change
                                     ), '[]') as token_values`
 
@@ -69,19 +86,63 @@ const (
                                              coalesce((
                                                select json_agg(json_build_object(
                                                  'token_id', tb.token_id,
-                                                 'decimals', t.decimals,
+                                                 'decimals', tb.decimals,
                                                  'value', tb.balance
                                                ))
-                                               from token_balance tb
-                                               join token t
-                                                 on t.token_id = tb.token_id
-                                               where tb.consensus_timestamp = abm.max and tb.account_id = @account_id
+                                               from (
+                                                 select tb.token_id, t.decimals, tb.balance
+                                                 from token_balance tb
+                                                 join token t
+                                                   on t.token_id = tb.token_id
+                                                 where
+                                                   tb.consensus_timestamp = abm.max and
+                                                   tb.account_id = @account_id and
+                                                   tb.token_id > @after_token_id%s
+                                                 order by tb.token_id
+                                                 limit @limit
+                                               ) tb
                                              ), '[]') token_balances
                                            from abm
                                            left join account_balance ab
                                              on ab.consensus_timestamp = abm.max and ab.account_id = @account_id`
+
+	// tokenTransferFilter and tokenBalanceFilter limit the token changes and balances to the selected tokens
+	tokenTransferFilter = " and tt.token_id in @token_ids"
+	tokenBalanceFilter  = " and tb.token_id in @token_ids"
+
+	// selectAccountsByPublicKey selects the ids of the non-deleted accounts whose key is the simple ed25519 key. Only
+	// the lowercase hex public_key column the importer sets for such keys is matched, since it's indexed
+	selectAccountsByPublicKey string = `select id
+                                        from entity
+                                        where type = @type and
+                                          deleted is false and
+                                          public_key = @public_key
+                                        order by id`
+
+	// selectAccountExpiry selects the auto renew period and the expiration timestamp of the account, either is 0 if
+	// it's not set. A deleted account is never modified again, so its modified timestamp is when it was deleted
+	selectAccountExpiry string = `select
+                                    coalesce(auto_renew_period, 0) auto_renew_period,
+                                    case
+                                      when deleted is true then coalesce(modified_timestamp, 0)
+                                      else 0
+                                    end deleted_timestamp,
+                                    coalesce(expiration_timestamp, 0) expiration_timestamp
+                                  from entity
+                                  where id = @id and type = @type`
+
+	// selectAccountKey selects the key of the non-deleted account, older rows may only have the simple ed25519 key in
+	// the lowercase hex public_key column
+	selectAccountKey string = `select key, public_key
+                               from entity
+                               where id = @id and type = @type and deleted is not true`
 )
 
+type accountKey struct {
+	Key       []byte
+	PublicKey string
+}
+
 type combinedAccountBalance struct {
 	ConsensusTimestamp int64
 	Balance            int64
@@ -95,40 +156,114 @@ type accountBalanceChange struct {
 
 // accountRepository struct that has connection to the Database
 type accountRepository struct {
-	dbClient *gorm.DB
+	balanceWorkers int
+	dbClient       *gorm.DB
+	rawDb          *sql.DB
+	rollingBalance bool
 }
 
-// NewAccountRepository creates an instance of a accountRepository struct
-func NewAccountRepository(dbClient *gorm.DB) repositories.AccountRepository {
-	return &accountRepository{
-		dbClient: dbClient,
+// NewAccountRepository creates an instance of a accountRepository struct. With rawQueries, the balance queries are run
+// with hand-written SQL on the underlying sql.DB instead of through the ORM. With more than one balanceWorkers, the
+// balance change since the last balance snapshot is computed with that many concurrent queries, each over an equal
+// part of the time range. With rollingBalance, the hbar balance is read from the rolling balances maintained by the
+// RollingBalanceUpdater when they cover the block
+func NewAccountRepository(
+	dbClient *gorm.DB,
+	rawQueries bool,
+	balanceWorkers int,
+	rollingBalance bool,
+) repositories.AccountRepository {
+	ar := &accountRepository{balanceWorkers: balanceWorkers, dbClient: dbClient, rollingBalance: rollingBalance}
+	if rawQueries {
+		rawDb, err := dbClient.DB()
+		if err != nil {
+			log.Warnf("Failed to get the sql.DB for raw balance queries, fall back to the ORM: %s", err)
+		}
+		ar.rawDb = rawDb
 	}
+
+	return ar
 }
 
 // RetrieveBalanceAtBlock returns the hbar balance and token balances of the account at a given block (
-// provided by consensusEnd timestamp).
+// provided by consensusEnd timestamp). The token balances are ordered by token id and only those of the tokens with
+// id greater than afterTokenId are returned. When tokenIds is not nil, only the balances of the tokens with the
+// encoded ids are returned, and when limit is positive, at most limit token balances are returned.
 // balance = balanceAtLatestBalanceSnapshot + balanceChangeBetweenSnapshotAndBlock
 func (ar *accountRepository) RetrieveBalanceAtBlock(
 	addressStr string,
 	consensusEnd int64,
+	tokenIds []int64,
+	afterTokenId int64,
+	limit int,
 ) ([]types.Amount, *rTypes.Error) {
 	accountId, err := types.AccountFromString(addressStr)
 	if err != nil {
 		return nil, err
 	}
 
-	snapshotTimestamp, hbarAmount, tokenAmountMap, err := ar.getLatestBalanceSnapshot(accountId.EncodedId, consensusEnd)
+	amounts, err := ar.retrieveBalanceAtBlock(accountId, consensusEnd, tokenIds, afterTokenId, limit)
+	if err == hErrors.ErrAmountOverflow {
+		log.Errorf("%s: balance of account %s at %d", err.Message, addressStr, consensusEnd)
+		return nil, hErrors.AddErrorDetails(err, hErrors.DetailAccount, addressStr)
+	}
+
+	return amounts, err
+}
+
+func (ar *accountRepository) retrieveBalanceAtBlock(
+	accountId types.Account,
+	consensusEnd int64,
+	tokenIds []int64,
+	afterTokenId int64,
+	limit int,
+) ([]types.Amount, *rTypes.Error) {
+	filter := tokenFilter{afterTokenId: afterTokenId, limit: limit, tokenIds: tokenIds}
+	var rollingHbarAmount *types.HbarAmount
+	if ar.rollingBalance {
+		value, covered, err := getRollingBalance(ar.dbClient, accountId.EncodedId, consensusEnd)
+		if err != nil {
+			log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, err)
+			return nil, hErrors.ErrDatabaseError
+		}
+
+		if covered {
+			rollingHbarAmount = &types.HbarAmount{Value: value}
+			if tokenIds != nil && len(tokenIds) == 0 {
+				return []types.Amount{rollingHbarAmount}, nil
+			}
+			filter.skipHbarChange = true
+		}
+	}
+
+	snapshotTimestamp, hbarAmount, tokenAmountMap, err := ar.getLatestBalanceSnapshot(
+		accountId.EncodedId,
+		consensusEnd,
+		filter,
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	hbarValue, tokenValues, err := ar.getBalanceChange(accountId.EncodedId, snapshotTimestamp, consensusEnd)
+	hbarValue, tokenValues, err := ar.getBalanceChange(accountId.EncodedId, snapshotTimestamp, consensusEnd, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	hbarAmount.Value += hbarValue
-	tokenAmounts := ar.getUpdatedTokenAmounts(tokenAmountMap, tokenValues)
+	if rollingHbarAmount != nil {
+		hbarAmount = rollingHbarAmount
+	} else if hbarAmount.Value, err = addAmounts(hbarAmount.Value, hbarValue); err != nil {
+		return nil, err
+	}
+	// both queries return at most limit token balances after afterTokenId, so the first limit of the merged token
+	// balances are the correct ones
+	tokenAmounts, err := ar.getUpdatedTokenAmounts(tokenAmountMap, tokenValues)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(tokenAmounts) > limit {
+		tokenAmounts = tokenAmounts[:limit]
+	}
 
 	amounts := make([]types.Amount, 0, 1+len(tokenAmounts))
 	amounts = append(amounts, hbarAmount)
@@ -137,7 +272,103 @@ func (ar *accountRepository) RetrieveBalanceAtBlock(
 	return amounts, nil
 }
 
-func (ar *accountRepository) getLatestBalanceSnapshot(accountId, consensusEnd int64) (
+// FindByPublicKey returns the existing accounts whose key is the ed25519 public key provided as raw bytes, ordered by
+// account id
+func (ar *accountRepository) FindByPublicKey(publicKey []byte) ([]types.Account, *rTypes.Error) {
+	if len(publicKey) == 0 {
+		return nil, hErrors.ErrInvalidPublicKey
+	}
+
+	var ids []int64
+	if err := ar.dbClient.Raw(
+		selectAccountsByPublicKey,
+		sql.Named("type", accountEntityType),
+		sql.Named("public_key", hex.EncodeToString(publicKey)),
+	).Scan(&ids).Error; err != nil {
+		log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, err)
+		return nil, hErrors.ErrDatabaseError
+	}
+
+	accounts := make([]types.Account, 0, len(ids))
+	for _, id := range ids {
+		account, err := types.NewAccountFromEncodedID(id)
+		if err != nil {
+			log.Errorf(hErrors.CreateAccountDbIdFailed, id)
+			return nil, hErrors.ErrInternalServerError
+		}
+		accounts = append(accounts, account)
+	}
+
+	return accounts, nil
+}
+
+// FindExpiry returns the expiry and the deletion of the account, or nil if the account isn't found
+func (ar *accountRepository) FindExpiry(addressStr string) (*types.AccountExpiry, *rTypes.Error) {
+	account, rErr := types.AccountFromString(addressStr)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	expiry := &types.AccountExpiry{}
+	result := ar.dbClient.Raw(
+		selectAccountExpiry,
+		sql.Named("id", account.EncodedId),
+		sql.Named("type", accountEntityType),
+	).Scan(expiry)
+	if result.Error != nil {
+		log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, result.Error)
+		return nil, hErrors.ErrDatabaseError
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return expiry, nil
+}
+
+// FindKey returns the serialized protobuf key of the account, which is empty if the account has no key, or nil if the
+// account isn't found or is deleted
+func (ar *accountRepository) FindKey(addressStr string) ([]byte, *rTypes.Error) {
+	account, rErr := types.AccountFromString(addressStr)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	key := &accountKey{}
+	result := ar.dbClient.Raw(
+		selectAccountKey,
+		sql.Named("id", account.EncodedId),
+		sql.Named("type", accountEntityType),
+	).Scan(key)
+	if result.Error != nil {
+		log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, result.Error)
+		return nil, hErrors.ErrDatabaseError
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	if len(key.Key) != 0 || key.PublicKey == "" {
+		return append([]byte{}, key.Key...), nil
+	}
+
+	publicKey, err := hex.DecodeString(key.PublicKey)
+	if err != nil {
+		log.Errorf("Failed to decode public key of account %s: %s", addressStr, err)
+		return nil, hErrors.ErrInternalServerError
+	}
+
+	serialized, err := protobuf.Marshal(&proto.Key{Key: &proto.Key_Ed25519{Ed25519: publicKey}})
+	if err != nil {
+		return nil, hErrors.ErrInternalServerError
+	}
+
+	return serialized, nil
+}
+
+func (ar *accountRepository) getLatestBalanceSnapshot(accountId, consensusEnd int64, filter tokenFilter) (
 	int64,
 	*types.HbarAmount,
 	map[int64]*types.TokenAmount,
@@ -145,21 +376,30 @@ func (ar *accountRepository) getLatestBalanceSnapshot(accountId, consensusEnd in
 ) {
 	// gets the most recent balance at or before consensusEnd
 	cb := &combinedAccountBalance{}
-	result := ar.dbClient.Raw(
-		latestBalanceBeforeConsensus,
-		sql.Named("account_id", accountId),
-		sql.Named("timestamp", consensusEnd),
-	).
-		First(cb)
-	if result.Error != nil {
+	var err error
+	if ar.rawDb != nil {
+		cb, err = queryLatestBalanceSnapshotRaw(ar.rawDb, accountId, consensusEnd, filter)
+	} else {
+		err = ar.dbClient.Raw(
+			filter.apply(latestBalanceBeforeConsensus, tokenBalanceFilter),
+			sql.Named("account_id", accountId),
+			sql.Named("after_token_id", filter.afterTokenId),
+			sql.Named("limit", filter.getLimit()),
+			sql.Named("timestamp", consensusEnd),
+			sql.Named("token_ids", filter.tokenIds),
+		).
+			First(cb).
+			Error
+	}
+	if err != nil {
 		return 0, nil, nil, hErrors.ErrDatabaseError
 	}
 
 	hbarAmount := types.HbarAmount{Value: cb.Balance}
 
-	var tokenAmounts []*types.TokenAmount
-	if err := json.Unmarshal([]byte(cb.TokenBalances), &tokenAmounts); err != nil {
-		return 0, nil, nil, hErrors.ErrInvalidToken
+	tokenAmounts, rErr := unmarshalTokenAmounts(cb.TokenBalances)
+	if rErr != nil {
+		return 0, nil, nil, rErr
 	}
 
 	tokenAmountMap := make(map[int64]*types.TokenAmount, len(tokenAmounts))
@@ -170,27 +410,116 @@ func (ar *accountRepository) getLatestBalanceSnapshot(accountId, consensusEnd in
 	return cb.ConsensusTimestamp, &hbarAmount, tokenAmountMap, nil
 }
 
-func (ar *accountRepository) getBalanceChange(accountId, consensusStart, consensusEnd int64) (
+// getBalanceChange returns the hbar balance change and the token balance changes of the account in the time range
+// (consensusStart, consensusEnd]. The range is split among the balance workers and the partial changes are summed up
+func (ar *accountRepository) getBalanceChange(accountId, consensusStart, consensusEnd int64, filter tokenFilter) (
 	int64,
 	[]*types.TokenAmount,
 	*rTypes.Error,
 ) {
+	timestampRanges := splitTimestampRange(consensusStart, consensusEnd, ar.balanceWorkers)
+	if len(timestampRanges) == 1 {
+		return ar.getBalanceChangeBetween(accountId, consensusStart, consensusEnd, filter)
+	}
+
+	type partialChange struct {
+		value       int64
+		tokenValues []*types.TokenAmount
+		err         *rTypes.Error
+	}
+
+	changes := make([]partialChange, len(timestampRanges))
+	wg := sync.WaitGroup{}
+	for i, timestampRange := range timestampRanges {
+		wg.Add(1)
+		go func(change *partialChange, start, end int64) {
+			defer wg.Done()
+			change.value, change.tokenValues, change.err = ar.getBalanceChangeBetween(accountId, start, end, filter)
+		}(&changes[i], timestampRange[0], timestampRange[1])
+	}
+	wg.Wait()
+
+	// the partial changes are summed up with arbitrary precision, only the total change has to fit in int64
+	var valueSum safemath.Sum
+	tokenValueMap := make(map[int64]*types.TokenAmount)
+	tokenValueSums := make(map[int64]*safemath.Sum)
+	for _, change := range changes {
+		if change.err != nil {
+			return 0, nil, change.err
+		}
+
+		valueSum.Add(change.value)
+		for _, tokenValue := range change.tokenValues {
+			encodedId := tokenValue.TokenId.EncodedId
+			if _, ok := tokenValueMap[encodedId]; !ok {
+				tokenValueMap[encodedId] = tokenValue
+				tokenValueSums[encodedId] = &safemath.Sum{}
+			}
+			tokenValueSums[encodedId].Add(tokenValue.Value)
+		}
+	}
+
+	value, ok := valueSum.Int64()
+	if !ok {
+		return 0, nil, hErrors.ErrAmountOverflow
+	}
+
+	tokenValues := make([]*types.TokenAmount, 0, len(tokenValueMap))
+	for encodedId, tokenValue := range tokenValueMap {
+		if tokenValue.Value, ok = tokenValueSums[encodedId].Int64(); !ok {
+			return 0, nil, hErrors.ErrAmountOverflow
+		}
+		tokenValues = append(tokenValues, tokenValue)
+	}
+	sort.Slice(tokenValues, func(i, j int) bool {
+		return tokenValues[i].TokenId.EncodedId < tokenValues[j].TokenId.EncodedId
+	})
+
+	// a token among the first limit ones of all the ranges is also among the first limit ones of every range it has
+	// transfers in, so its change is complete
+	if filter.limit > 0 && len(tokenValues) > filter.limit {
+		tokenValues = tokenValues[:filter.limit]
+	}
+
+	return value, tokenValues, nil
+}
+
+func (ar *accountRepository) getBalanceChangeBetween(
+	accountId int64,
+	consensusStart int64,
+	consensusEnd int64,
+	filter tokenFilter,
+) (int64, []*types.TokenAmount, *rTypes.Error) {
 	change := &accountBalanceChange{}
+	var err error
 	// gets the balance change from the Balance snapshot until the target block
-	result := ar.dbClient.Raw(
-		balanceChangeBetween,
-		sql.Named("account_id", accountId),
-		sql.Named("start", consensusStart),
-		sql.Named("end", consensusEnd),
-	).
-		First(change)
-	if result.Error != nil {
+	if ar.rawDb != nil {
+		change, err = queryBalanceChangeRaw(ar.rawDb, accountId, consensusStart, consensusEnd, filter)
+	} else {
+		err = ar.dbClient.Raw(
+			filter.apply(balanceChangeBetween, tokenTransferFilter),
+			sql.Named("account_id", accountId),
+			sql.Named("after_token_id", filter.afterTokenId),
+			sql.Named("end", consensusEnd),
+			sql.Named("hbar_change", !filter.skipHbarChange),
+			sql.Named("limit", filter.getLimit()),
+			sql.Named("start", consensusStart),
+			sql.Named("token_ids", filter.tokenIds),
+		).
+			First(change).
+			Error
+	}
+	if err != nil {
+		if isOutOfRangeError(err) {
+			return 0, nil, hErrors.ErrAmountOverflow
+		}
+		log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, err)
 		return 0, nil, hErrors.ErrDatabaseError
 	}
 
-	var tokenValues []*types.TokenAmount
-	if err := json.Unmarshal([]byte(change.TokenValues), &tokenValues); err != nil {
-		return 0, nil, hErrors.ErrInvalidToken
+	tokenValues, rErr := unmarshalTokenAmounts(change.TokenValues)
+	if rErr != nil {
+		return 0, nil, rErr
 	}
 
 	return change.Value, tokenValues, nil
@@ -199,20 +528,114 @@ func (ar *accountRepository) getBalanceChange(accountId, consensusStart, consens
 func (ar *accountRepository) getUpdatedTokenAmounts(
 	tokenAmountMap map[int64]*types.TokenAmount,
 	tokenValues []*types.TokenAmount,
-) []types.Amount {
+) ([]types.Amount, *rTypes.Error) {
 	for _, tokenValue := range tokenValues {
 		encodedId := tokenValue.TokenId.EncodedId
-		if _, ok := tokenAmountMap[encodedId]; ok {
-			tokenAmountMap[encodedId].Value += tokenValue.Value
+		if tokenAmount, ok := tokenAmountMap[encodedId]; ok {
+			var err *rTypes.Error
+			if tokenAmount.Value, err = addAmounts(tokenAmount.Value, tokenValue.Value); err != nil {
+				return nil, err
+			}
 		} else {
 			tokenAmountMap[encodedId] = tokenValue
 		}
 	}
 
-	amounts := make([]types.Amount, 0, len(tokenAmountMap))
+	tokenAmounts := make([]*types.TokenAmount, 0, len(tokenAmountMap))
 	for _, tokenAmount := range tokenAmountMap {
+		tokenAmounts = append(tokenAmounts, tokenAmount)
+	}
+	sort.Slice(tokenAmounts, func(i, j int) bool {
+		return tokenAmounts[i].TokenId.EncodedId < tokenAmounts[j].TokenId.EncodedId
+	})
+
+	amounts := make([]types.Amount, 0, len(tokenAmounts))
+	for _, tokenAmount := range tokenAmounts {
 		amounts = append(amounts, tokenAmount)
 	}
 
-	return amounts
+	return amounts, nil
+}
+
+// isOutOfRangeError tells if err is the failure to scan a sum which is numeric in the database into an int64 field
+func isOutOfRangeError(err error) bool {
+	return errors.Is(err, strconv.ErrRange) || strings.Contains(err.Error(), strconv.ErrRange.Error())
+}
+
+// addAmounts returns the sum of the balance and the change, ErrAmountOverflow is returned if it overflows int64
+func addAmounts(balance, change int64) (int64, *rTypes.Error) {
+	sum, ok := safemath.AddInt64(balance, change)
+	if !ok {
+		return 0, hErrors.ErrAmountOverflow
+	}
+
+	return sum, nil
+}
+
+// unmarshalTokenAmounts unmarshals the token amounts aggregated as json by the balance queries. The sums are numeric
+// in the database, so a value which doesn't fit in int64 fails with ErrAmountOverflow instead of wrapping around
+func unmarshalTokenAmounts(data string) ([]*types.TokenAmount, *rTypes.Error) {
+	var tokenAmounts []*types.TokenAmount
+	if err := json.Unmarshal([]byte(data), &tokenAmounts); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		// the field is the path from the array, e.g., 0.value, in newer go versions
+		if errors.As(err, &typeErr) && (typeErr.Field == "value" || strings.HasSuffix(typeErr.Field, ".value")) {
+			return nil, hErrors.ErrAmountOverflow
+		}
+		return nil, hErrors.ErrInvalidToken
+	}
+
+	return tokenAmounts, nil
+}
+
+// splitTimestampRange splits the time range (start, end] into at most count consecutive ranges of equal length, each
+// as [2]int64{start, end} with the same exclusive start and inclusive end
+func splitTimestampRange(start, end int64, count int) [][2]int64 {
+	if count <= 1 || end-start < int64(count) {
+		return [][2]int64{{start, end}}
+	}
+
+	size := (end - start) / int64(count)
+	ranges := make([][2]int64, 0, count)
+	for i := 0; i < count; i++ {
+		rangeEnd := start + size
+		if i == count-1 {
+			rangeEnd = end
+		}
+		ranges = append(ranges, [2]int64{start, rangeEnd})
+		start = rangeEnd
+	}
+
+	return ranges
+}
+
+// tokenFilter selects the token balances and changes in the balance queries. skipHbarChange leaves the hbar balance
+// change out when the hbar balance comes from the rolling balances
+type tokenFilter struct {
+	afterTokenId   int64
+	limit          int
+	skipHbarChange bool
+	tokenIds       []int64
+}
+
+// apply adds the token id filter to the query if tokenIds is not nil. An empty tokenIds selects no tokens
+func (f tokenFilter) apply(query, tokenIdFilter string) string {
+	if f.tokenIds == nil {
+		return fmt.Sprintf(query, "")
+	}
+
+	if len(f.tokenIds) == 0 {
+		return fmt.Sprintf(query, " and false")
+	}
+
+	return fmt.Sprintf(query, tokenIdFilter)
+}
+
+// getLimit returns the sql limit, nil means no limit
+func (f tokenFilter) getLimit() interface{} {
+	if f.limit <= 0 {
+		return nil
+	}
+
+	return f.limit
 }
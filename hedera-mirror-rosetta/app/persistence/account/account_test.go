@@ -21,14 +21,25 @@
 package account
 
 import (
+	"encoding/hex"
+	"math"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
 	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	hErrors "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
 	dbTypes "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/types"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/db"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/domain"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/mocks"
+	"github.com/hashgraph/hedera-sdk-go/v2/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"github.com/thanhpk/randstr"
+	protobuf "google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
 )
 
 var (
@@ -67,7 +78,7 @@ var (
 	}
 	token1TransferAmounts      = []int64{10, -5}
 	token2TransferAmounts      = []int64{20, -7}
-	snapshotAccountBalanceFile = &accountBalanceFile{
+	snapshotAccountBalanceFile = &domain.AccountBalanceFile{
 		ConsensusTimestamp: snapshotTimestamp,
 		Count:              100,
 		LoadStart:          1600,
@@ -76,7 +87,7 @@ var (
 		Name:               "account balance file 1",
 		NodeAccountId:      3,
 	}
-	initialTokenBalances = []*tokenBalance{
+	initialTokenBalances = []*domain.TokenBalance{
 		{
 			AccountId:          account,
 			ConsensusTimestamp: snapshotTimestamp,
@@ -90,7 +101,7 @@ var (
 			TokenId:            token2.TokenId,
 		},
 	}
-	initialAccountBalance = &accountBalance{
+	initialAccountBalance = &domain.AccountBalance{
 		ConsensusTimestamp: snapshotTimestamp,
 		Balance:            12345,
 		AccountId:          account,
@@ -127,7 +138,7 @@ var (
 		},
 	}
 	// the last transfer of each token is after consensusEnd
-	tokenTransfers = []*tokenTransfer{
+	tokenTransfers = []*dbTypes.TokenTransfer{
 		{
 			AccountId:          account,
 			Amount:             token1TransferAmounts[0],
@@ -166,7 +177,7 @@ var (
 		},
 	}
 	// token transfers at or before snapshot timestamp
-	tokenTransfersLTESnapshot = []*tokenTransfer{
+	tokenTransfersLTESnapshot = []*dbTypes.TokenTransfer{
 		{
 			AccountId:          account,
 			Amount:             17,
@@ -194,53 +205,6 @@ var (
 	}
 )
 
-type accountBalance struct {
-	AccountId          int64 `gorm:"primaryKey"`
-	Balance            int64
-	ConsensusTimestamp int64 `gorm:"primaryKey"`
-}
-
-func (accountBalance) TableName() string {
-	return "account_balance"
-}
-
-type accountBalanceFile struct {
-	ConsensusTimestamp int64 `gorm:"primaryKey"`
-	Count              int64 `gorm:"not null"`
-	LoadStart          int64 `gorm:"not null"`
-	LoadEnd            int64 `gorm:"not null"`
-	FileHash           string
-	Name               string `gorm:"not null"`
-	NodeAccountId      int64  `gorm:"not null"`
-	Bytes              []byte
-}
-
-func (accountBalanceFile) TableName() string {
-	return "account_balance_file"
-}
-
-type tokenBalance struct {
-	AccountId          int64
-	Balance            int64
-	ConsensusTimestamp int64
-	TokenId            int64
-}
-
-func (tokenBalance) TableName() string {
-	return "token_balance"
-}
-
-type tokenTransfer struct {
-	AccountId          int64
-	Amount             int64
-	ConsensusTimestamp int64
-	TokenId            int64
-}
-
-func (tokenTransfer) TableName() string {
-	return "token_transfer"
-}
-
 // run the suite
 func TestAccountRepositorySuite(t *testing.T) {
 	suite.Run(t, new(accountRepositorySuite))
@@ -274,7 +238,7 @@ func (suite *accountRepositorySuite) TestRetrieveBalanceAtBlock() {
 	suite.createDbRecords(cryptoTransfers, tokenTransfers)
 
 	dbClient := suite.dbResource.GetGormDb()
-	repo := NewAccountRepository(dbClient)
+	repo := NewAccountRepository(dbClient, false, 1, false)
 
 	hbarAmount := &types.HbarAmount{Value: initialAccountBalance.Balance + sum(cryptoTransferAmounts)}
 	token1Amount := &types.TokenAmount{
@@ -291,13 +255,190 @@ func (suite *accountRepositorySuite) TestRetrieveBalanceAtBlock() {
 	expected := []types.Amount{hbarAmount, token1Amount, token2Amount}
 
 	// when
-	actual, err := repo.RetrieveBalanceAtBlock(accountString, consensusEnd)
+	actual, err := repo.RetrieveBalanceAtBlock(accountString, consensusEnd, nil, 0, 0)
 
 	// then
 	assert.Nil(suite.T(), err)
 	assert.ElementsMatch(suite.T(), expected, actual)
 }
 
+func (suite *accountRepositorySuite) TestRetrieveBalanceAtBlockWithTokenFilter() {
+	// given
+	suite.createDbRecords(token1, token2)
+	suite.createDbRecords(initialAccountBalance, initialTokenBalances)
+	suite.createDbRecords(cryptoTransfers, tokenTransfers)
+
+	dbClient := suite.dbResource.GetGormDb()
+	repo := NewAccountRepository(dbClient, false, 1, false)
+
+	hbarAmount := &types.HbarAmount{Value: initialAccountBalance.Balance + sum(cryptoTransferAmounts)}
+	token2Amount := &types.TokenAmount{
+		TokenId:  token2EntityId,
+		Decimals: token2.Decimals,
+		Value:    initialTokenBalances[1].Balance + sum(token2TransferAmounts),
+	}
+
+	var tests = []struct {
+		name     string
+		tokenIds []int64
+		expected []types.Amount
+	}{
+		{
+			name:     "SelectedToken",
+			tokenIds: []int64{token2.TokenId},
+			expected: []types.Amount{hbarAmount, token2Amount},
+		},
+		{
+			name:     "NoToken",
+			tokenIds: []int64{},
+			expected: []types.Amount{hbarAmount},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := repo.RetrieveBalanceAtBlock(accountString, consensusEnd, tt.tokenIds, 0, 0)
+
+			// then
+			assert.Nil(t, err)
+			assert.ElementsMatch(t, tt.expected, actual)
+		})
+	}
+}
+
+func (suite *accountRepositorySuite) TestRetrieveBalanceAtBlockWithRawQueries() {
+	// given
+	suite.createDbRecords(token1, token2)
+	suite.createDbRecords(initialAccountBalance, initialTokenBalances)
+	suite.createDbRecords(cryptoTransfersLTESnapshot, tokenTransfersLTESnapshot)
+	suite.createDbRecords(cryptoTransfers, tokenTransfers)
+
+	dbClient := suite.dbResource.GetGormDb()
+	ormRepo := NewAccountRepository(dbClient, false, 1, false)
+	rawRepo := NewAccountRepository(dbClient, true, 1, false)
+
+	var tests = []struct {
+		name     string
+		tokenIds []int64
+	}{
+		{name: "AllTokens"},
+		{name: "SelectedToken", tokenIds: []int64{token2.TokenId}},
+		{name: "NoToken", tokenIds: []int64{}},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			expected, err := ormRepo.RetrieveBalanceAtBlock(accountString, consensusEnd, tt.tokenIds, 0, 0)
+			assert.Nil(t, err)
+
+			// when
+			actual, err := rawRepo.RetrieveBalanceAtBlock(accountString, consensusEnd, tt.tokenIds, 0, 0)
+
+			// then
+			assert.Nil(t, err)
+			assert.ElementsMatch(t, expected, actual)
+		})
+	}
+}
+
+func (suite *accountRepositorySuite) TestRetrieveBalanceAtBlockWithBalanceWorkers() {
+	// given
+	suite.createDbRecords(token1, token2)
+	suite.createDbRecords(initialAccountBalance, initialTokenBalances)
+	suite.createDbRecords(cryptoTransfersLTESnapshot, tokenTransfersLTESnapshot)
+	suite.createDbRecords(cryptoTransfers, tokenTransfers)
+
+	dbClient := suite.dbResource.GetGormDb()
+	serialRepo := NewAccountRepository(dbClient, false, 1, false)
+
+	var tests = []struct {
+		name         string
+		rawQueries   bool
+		afterTokenId int64
+		limit        int
+	}{
+		{name: "AllTokens"},
+		{name: "AllTokensWithRawQueries", rawQueries: true},
+		{name: "FirstPage", limit: 1},
+		{name: "SecondPage", afterTokenId: token1.TokenId, limit: 1},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			expected, err := serialRepo.RetrieveBalanceAtBlock(accountString, consensusEnd, nil, tt.afterTokenId,
+				tt.limit)
+			assert.Nil(t, err)
+			repo := NewAccountRepository(dbClient, tt.rawQueries, 4, false)
+
+			// when
+			actual, err := repo.RetrieveBalanceAtBlock(accountString, consensusEnd, nil, tt.afterTokenId, tt.limit)
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func (suite *accountRepositorySuite) TestRetrieveBalanceAtBlockWithPaging() {
+	// given
+	suite.createDbRecords(token1, token2)
+	suite.createDbRecords(initialAccountBalance, initialTokenBalances)
+	suite.createDbRecords(cryptoTransfers, tokenTransfers)
+
+	dbClient := suite.dbResource.GetGormDb()
+	repo := NewAccountRepository(dbClient, false, 1, false)
+
+	hbarAmount := &types.HbarAmount{Value: initialAccountBalance.Balance + sum(cryptoTransferAmounts)}
+	token1Amount := &types.TokenAmount{
+		TokenId:  token1EntityId,
+		Decimals: token1.Decimals,
+		Value:    initialTokenBalances[0].Balance + sum(token1TransferAmounts),
+	}
+	token2Amount := &types.TokenAmount{
+		TokenId:  token2EntityId,
+		Decimals: token2.Decimals,
+		Value:    initialTokenBalances[1].Balance + sum(token2TransferAmounts),
+	}
+
+	var tests = []struct {
+		name         string
+		afterTokenId int64
+		limit        int
+		expected     []types.Amount
+	}{
+		{
+			name:     "FirstPage",
+			limit:    1,
+			expected: []types.Amount{hbarAmount, token1Amount},
+		},
+		{
+			name:         "SecondPage",
+			afterTokenId: token1.TokenId,
+			limit:        1,
+			expected:     []types.Amount{hbarAmount, token2Amount},
+		},
+		{
+			name:         "LastPage",
+			afterTokenId: token2.TokenId,
+			limit:        1,
+			expected:     []types.Amount{hbarAmount},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := repo.RetrieveBalanceAtBlock(accountString, consensusEnd, nil, tt.afterTokenId, tt.limit)
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
 func (suite *accountRepositorySuite) TestRetrieveBalanceAtBlockNoTokenEntity() {
 	// given
 	suite.createDbRecords(initialAccountBalance, initialTokenBalances)
@@ -306,14 +447,14 @@ func (suite *accountRepositorySuite) TestRetrieveBalanceAtBlockNoTokenEntity() {
 	suite.createDbRecords(cryptoTransfers, tokenTransfers)
 
 	dbClient := suite.dbResource.GetGormDb()
-	repo := NewAccountRepository(dbClient)
+	repo := NewAccountRepository(dbClient, false, 1, false)
 
 	// no token entities, so only hbar balance
 	hbarAmount := &types.HbarAmount{Value: initialAccountBalance.Balance + sum(cryptoTransferAmounts)}
 	expected := []types.Amount{hbarAmount}
 
 	// when
-	actual, err := repo.RetrieveBalanceAtBlock(accountString, consensusEnd)
+	actual, err := repo.RetrieveBalanceAtBlock(accountString, consensusEnd, nil, 0, 0)
 
 	// then
 	assert.Nil(suite.T(), err)
@@ -326,7 +467,7 @@ func (suite *accountRepositorySuite) TestRetrieveBalanceAtBlockNoInitialBalance(
 	suite.createDbRecords(cryptoTransfers, tokenTransfers)
 
 	dbClient := suite.dbResource.GetGormDb()
-	repo := NewAccountRepository(dbClient)
+	repo := NewAccountRepository(dbClient, false, 1, false)
 
 	hbarAmount := &types.HbarAmount{Value: sum(cryptoTransferAmounts)}
 	token1Amount := &types.TokenAmount{
@@ -342,7 +483,7 @@ func (suite *accountRepositorySuite) TestRetrieveBalanceAtBlockNoInitialBalance(
 	expected := []types.Amount{hbarAmount, token1Amount, token2Amount}
 
 	// when
-	actual, err := repo.RetrieveBalanceAtBlock(accountString, consensusEnd)
+	actual, err := repo.RetrieveBalanceAtBlock(accountString, consensusEnd, nil, 0, 0)
 
 	// then
 	assert.Nil(suite.T(), err)
@@ -352,16 +493,289 @@ func (suite *accountRepositorySuite) TestRetrieveBalanceAtBlockNoInitialBalance(
 func (suite *accountRepositorySuite) TestRetrieveBalanceAtBlockInvalidAccountIdStr() {
 	// given
 	dbClient := suite.dbResource.GetGormDb()
-	repo := NewAccountRepository(dbClient)
+	repo := NewAccountRepository(dbClient, false, 1, false)
 
 	// when
-	actual, err := repo.RetrieveBalanceAtBlock("a", consensusEnd)
+	actual, err := repo.RetrieveBalanceAtBlock("a", consensusEnd, nil, 0, 0)
 
 	// then
 	assert.NotNil(suite.T(), err)
 	assert.Nil(suite.T(), actual)
 }
 
+func (suite *accountRepositorySuite) TestFindByPublicKey() {
+	// given
+	publicKey := randstr.Bytes(32)
+	publicKeyHex := hex.EncodeToString(publicKey)
+	key, _ := protobuf.Marshal(&proto.Key{Key: &proto.Key_Ed25519{Ed25519: publicKey}})
+	suite.createDbRecords(
+		&dbTypes.Entity{Id: 9003, Num: 9003, Key: key, PublicKey: publicKeyHex, Type: 1},
+		&dbTypes.Entity{Id: account, Num: account, PublicKey: publicKeyHex, Type: 1},
+		&dbTypes.Entity{Id: 9005, Num: 9005, Deleted: true, Key: key, PublicKey: publicKeyHex, Type: 1},
+		&dbTypes.Entity{Id: 9006, Num: 9006, Key: randstr.Bytes(34), Type: 1},
+		&dbTypes.Entity{Id: 9007, Num: 9007, Key: key, PublicKey: publicKeyHex, Type: 4},
+		// only the indexed public_key column is queried
+		&dbTypes.Entity{Id: 9008, Num: 9008, Key: key, Type: 1},
+	)
+
+	dbClient := suite.dbResource.GetGormDb()
+	repo := NewAccountRepository(dbClient, false, 1, false)
+
+	expected := []types.Account{
+		{EntityId: entityid.EntityId{EntityNum: 9000, EncodedId: 9000}},
+		{EntityId: entityid.EntityId{EntityNum: 9003, EncodedId: 9003}},
+	}
+
+	// when
+	actual, err := repo.FindByPublicKey(publicKey)
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expected, actual)
+}
+
+func (suite *accountRepositorySuite) TestFindByPublicKeyNoMatch() {
+	// given
+	dbClient := suite.dbResource.GetGormDb()
+	repo := NewAccountRepository(dbClient, false, 1, false)
+
+	// when
+	actual, err := repo.FindByPublicKey(randstr.Bytes(32))
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), actual)
+}
+
+func (suite *accountRepositorySuite) TestFindByPublicKeyEmpty() {
+	// given
+	dbClient := suite.dbResource.GetGormDb()
+	repo := NewAccountRepository(dbClient, false, 1, false)
+
+	// when
+	actual, err := repo.FindByPublicKey([]byte{})
+
+	// then
+	assert.Equal(suite.T(), hErrors.ErrInvalidPublicKey, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *accountRepositorySuite) TestFindExpiry() {
+	// given
+	suite.createDbRecords(
+		&dbTypes.Entity{Id: account, Num: account, AutoRenewPeriod: 7776000, ExpirationTimestamp: 1000, Type: 1},
+		&dbTypes.Entity{Id: 9005, Num: 9005, AutoRenewPeriod: 7776000, ExpirationTimestamp: 2000, Type: 4},
+		&dbTypes.Entity{Id: 9007, Num: 9007, Deleted: true, ExpirationTimestamp: 3000, ModifiedTimestamp: 2500,
+			Type: 1},
+	)
+	repo := NewAccountRepository(suite.dbResource.GetGormDb(), false, 1, false)
+
+	var tests = []struct {
+		name     string
+		address  string
+		expected *types.AccountExpiry
+	}{
+		{
+			name:     "Account",
+			address:  "0.0.9000",
+			expected: &types.AccountExpiry{AutoRenewPeriod: 7776000, ExpirationTimestamp: 1000},
+		},
+		{
+			name:     "Deleted",
+			address:  "0.0.9007",
+			expected: &types.AccountExpiry{DeletedTimestamp: 2500, ExpirationTimestamp: 3000},
+		},
+		{name: "NotAccount", address: "0.0.9005"},
+		{name: "NotFound", address: "0.0.9006"},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := repo.FindExpiry(tt.address)
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func (suite *accountRepositorySuite) TestFindExpiryInvalidAccount() {
+	// given
+	repo := NewAccountRepository(suite.dbResource.GetGormDb(), false, 1, false)
+
+	// when
+	actual, err := repo.FindExpiry("a")
+
+	// then
+	assert.Equal(suite.T(), hErrors.ErrInvalidAccount, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *accountRepositorySuite) TestFindKey() {
+	// given
+	publicKey := randstr.Bytes(32)
+	key, _ := protobuf.Marshal(&proto.Key{Key: &proto.Key_Ed25519{Ed25519: publicKey}})
+	suite.createDbRecords(
+		&dbTypes.Entity{Id: account, Num: account, Key: key, Type: 1},
+		&dbTypes.Entity{Id: 9003, Num: 9003, PublicKey: hex.EncodeToString(publicKey), Type: 1},
+		&dbTypes.Entity{Id: 9004, Num: 9004, Type: 1},
+		&dbTypes.Entity{Id: 9005, Num: 9005, Deleted: true, Key: key, Type: 1},
+		&dbTypes.Entity{Id: 9007, Num: 9007, Key: key, Type: 4},
+	)
+	repo := NewAccountRepository(suite.dbResource.GetGormDb(), false, 1, false)
+
+	var tests = []struct {
+		name     string
+		address  string
+		expected []byte
+	}{
+		{name: "Key", address: "0.0.9000", expected: key},
+		{name: "PublicKey", address: "0.0.9003", expected: key},
+		{name: "NoKey", address: "0.0.9004", expected: []byte{}},
+		{name: "Deleted", address: "0.0.9005"},
+		{name: "NotAccount", address: "0.0.9007"},
+		{name: "NotFound", address: "0.0.9008"},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := repo.FindKey(tt.address)
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func (suite *accountRepositorySuite) TestFindKeyInvalidAccount() {
+	// given
+	repo := NewAccountRepository(suite.dbResource.GetGormDb(), false, 1, false)
+
+	// when
+	actual, err := repo.FindKey("a")
+
+	// then
+	assert.Equal(suite.T(), hErrors.ErrInvalidAccount, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func TestSplitTimestampRange(t *testing.T) {
+	var tests = []struct {
+		name     string
+		start    int64
+		end      int64
+		count    int
+		expected [][2]int64
+	}{
+		{name: "single", start: 100, end: 200, count: 1, expected: [][2]int64{{100, 200}}},
+		{name: "zero", start: 100, end: 200, expected: [][2]int64{{100, 200}}},
+		{name: "short range", start: 100, end: 102, count: 4, expected: [][2]int64{{100, 102}}},
+		{name: "even", start: 100, end: 200, count: 4, expected: [][2]int64{{100, 125}, {125, 150}, {150, 175},
+			{175, 200}}},
+		{name: "uneven", start: 0, end: 10, count: 3, expected: [][2]int64{{0, 3}, {3, 6}, {6, 10}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, splitTimestampRange(tt.start, tt.end, tt.count))
+		})
+	}
+}
+
+func TestRetrieveBalanceAtBlockBalanceChangeOverflow(t *testing.T) {
+	// given
+	dbClient, mock := mocks.DatabaseMock(t)
+	mock.ExpectQuery("from account_balance_file").
+		WillReturnRows(sqlmock.NewRows([]string{"consensus_timestamp", "balance", "token_balances"}).
+			AddRow(snapshotTimestamp, 100, "[]"))
+	mock.ExpectQuery("from crypto_transfer").
+		WillReturnRows(sqlmock.NewRows([]string{"value", "token_values"}).AddRow("9223372036854775808", "[]"))
+	repo := NewAccountRepository(dbClient, false, 1, false)
+	expected := hErrors.AddErrorDetails(hErrors.ErrAmountOverflow, hErrors.DetailAccount, accountString)
+
+	// when
+	actual, err := repo.RetrieveBalanceAtBlock(accountString, consensusEnd, nil, 0, 0)
+
+	// then
+	assert.Equal(t, expected, err)
+	assert.Nil(t, actual)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveBalanceAtBlockBalanceChangeDbError(t *testing.T) {
+	// given
+	dbClient, mock := mocks.DatabaseMock(t)
+	mock.ExpectQuery("from account_balance_file").
+		WillReturnRows(sqlmock.NewRows([]string{"consensus_timestamp", "balance", "token_balances"}).
+			AddRow(snapshotTimestamp, 100, "[]"))
+	mock.ExpectQuery("from crypto_transfer").WillReturnError(gorm.ErrInvalidTransaction)
+	repo := NewAccountRepository(dbClient, false, 1, false)
+
+	// when
+	actual, err := repo.RetrieveBalanceAtBlock(accountString, consensusEnd, nil, 0, 0)
+
+	// then
+	assert.Equal(t, hErrors.ErrDatabaseError, err)
+	assert.Nil(t, actual)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddAmounts(t *testing.T) {
+	// when
+	sum, err := addAmounts(math.MaxInt64-10, 10)
+	_, overflowErr := addAmounts(math.MaxInt64, 1)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(math.MaxInt64), sum)
+	assert.Equal(t, hErrors.ErrAmountOverflow, overflowErr)
+}
+
+func TestUnmarshalTokenAmounts(t *testing.T) {
+	var tests = []struct {
+		name     string
+		data     string
+		expected []*types.TokenAmount
+		err      *rTypes.Error
+	}{
+		{name: "empty", data: "[]", expected: []*types.TokenAmount{}},
+		{
+			name: "max int64",
+			data: `[{"token_id": 1001, "decimals": 0, "value": 9223372036854775807}]`,
+			expected: []*types.TokenAmount{{
+				TokenId: entityid.EntityId{EntityNum: 1001, EncodedId: 1001},
+				Value:   math.MaxInt64,
+			}},
+		},
+		{
+			name: "overflow",
+			data: `[{"token_id": 1001, "decimals": 0, "value": 9223372036854775808}]`,
+			err:  hErrors.ErrAmountOverflow,
+		},
+		{
+			name: "underflow",
+			data: `[{"token_id": 1001, "decimals": 0, "value": -9223372036854775809}]`,
+			err:  hErrors.ErrAmountOverflow,
+		},
+		{name: "invalid json", data: "{", err: hErrors.ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := unmarshalTokenAmounts(tt.data)
+
+			// then
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
 func (suite *accountRepositorySuite) createDbRecords(records ...interface{}) {
 	dbClient := suite.dbResource.GetGormDb()
 
@@ -378,3 +792,48 @@ func sum(amounts []int64) int64 {
 
 	return value
 }
+
+// benchmarkTransfers is the number of hbar and token transfers of the account seeded after the balance snapshot
+const benchmarkTransfers = 10000
+
+// BenchmarkRetrieveBalanceAtBlock measures the balance computation of an account with many transfers since the last
+// balance snapshot against a seeded database. Run it with
+// go test -run '^$' -bench RetrieveBalance ./app/persistence/account/
+func BenchmarkRetrieveBalanceAtBlock(b *testing.B) {
+	dbResource := db.SetupDb()
+	defer db.TeardownDb(dbResource)
+	dbClient := dbResource.GetGormDb()
+
+	for _, record := range []interface{}{snapshotAccountBalanceFile, token1, token2, initialAccountBalance,
+		initialTokenBalances} {
+		dbClient.Create(record)
+	}
+
+	cryptoTransfers := make([]*dbTypes.CryptoTransfer, 0, benchmarkTransfers)
+	tokenTransfers := make([]*dbTypes.TokenTransfer, 0, benchmarkTransfers)
+	for i := int64(0); i < benchmarkTransfers; i++ {
+		consensusTimestamp := snapshotTimestamp + 1 + i
+		cryptoTransfers = append(cryptoTransfers, &dbTypes.CryptoTransfer{
+			Amount:             1 - 2*(i%2),
+			ConsensusTimestamp: consensusTimestamp,
+			EntityId:           account,
+		})
+		tokenTransfers = append(tokenTransfers, &dbTypes.TokenTransfer{
+			AccountId:          account,
+			Amount:             1 - 2*(i%2),
+			ConsensusTimestamp: consensusTimestamp,
+			TokenId:            []int64{token1.TokenId, token2.TokenId}[i%2],
+		})
+	}
+	dbClient.CreateInBatches(cryptoTransfers, 1000)
+	dbClient.CreateInBatches(tokenTransfers, 1000)
+	repo := NewAccountRepository(dbClient, false, 1, false)
+	blockConsensusEnd := snapshotTimestamp + benchmarkTransfers
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.RetrieveBalanceAtBlock(accountString, blockConsensusEnd, nil, 0, 0); err != nil {
+			b.Fatalf("Failed to retrieve the balance: %s", err.Message)
+		}
+	}
+}
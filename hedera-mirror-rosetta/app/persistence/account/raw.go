@@ -0,0 +1,134 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package account
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// the raw balance queries take the token ids as an array, null selects all tokens and an empty array none, so the
+	// statements are static. The hints only take effect with the pg_hint_plan extension and are plain comments
+	// otherwise
+	balanceChangeBetweenRaw = `/*+ IndexScan(crypto_transfer crypto_transfer__entity_id_consensus_timestamp)
+                                   IndexScan(tt token_transfer__account_timestamp) */
+                               select
+                                 coalesce((
+                                   select sum(amount::bigint) from crypto_transfer
+                                   where
+                                     $7::boolean and
+                                     entity_id = $1 and
+                                     consensus_timestamp > $2 and
+                                     consensus_timestamp <= $3
+                                 ), 0) as value,
+                                 coalesce((
+                                   select json_agg(change)
+                                   from (
+                                     select json_build_object(
+                                         'token_id', tt.token_id,
+                                         'decimals', t.decimals,
+                                         'value', sum(tt.amount::bigint)
+                                     ) change
+                                     from token_transfer tt
+                                     join token t
+                                       on t.token_id = tt.token_id
+                                     where
+                                       tt.account_id = $1 and
+                                       tt.consensus_timestamp > $2 and
+                                       tt.consensus_timestamp <= $3 and
+                                       tt.token_id > $4 and
+                                       ($6::bigint[] is null or tt.token_id = any($6))
+                                     group by tt.account_id, tt.token_id, t.decimals
+                                     order by tt.token_id
+                                     limit $5
+                                   ) token_change
+                                 ), '[]') as token_values`
+
+	latestBalanceBeforeConsensusRaw = `/*+ IndexScan(ab account_balance__pk) IndexScan(tb token_balance__pk) */
+                                       with abm as (
+                                         select max(consensus_timestamp)
+                                         from account_balance_file where consensus_timestamp <= $2
+                                       )
+                                       select
+                                         coalesce(abm.max, 0) consensus_timestamp,
+                                         coalesce(ab.balance, 0) balance,
+                                         coalesce((
+                                           select json_agg(json_build_object(
+                                             'token_id', tb.token_id,
+                                             'decimals', tb.decimals,
+                                             'value', tb.balance
+                                           ))
+                                           from (
+                                             select tb.token_id, t.decimals, tb.balance
+                                             from token_balance tb
+                                             join token t
+                                               on t.token_id = tb.token_id
+                                             where
+                                               tb.consensus_timestamp = abm.max and
+                                               tb.account_id = $1 and
+                                               tb.token_id > $3 and
+                                               ($5::bigint[] is null or tb.token_id = any($5))
+                                             order by tb.token_id
+                                             limit $4
+                                           ) tb
+                                         ), '[]') token_balances
+                                       from abm
+                                       left join account_balance ab
+                                         on ab.consensus_timestamp = abm.max and ab.account_id = $1`
+)
+
+// queryLatestBalanceSnapshotRaw runs the latest balance snapshot query with hand-written SQL on the sql.DB
+func queryLatestBalanceSnapshotRaw(db *sql.DB, accountId, consensusEnd int64, filter tokenFilter) (
+	*combinedAccountBalance,
+	error,
+) {
+	cb := &combinedAccountBalance{}
+	err := db.QueryRow(
+		latestBalanceBeforeConsensusRaw,
+		accountId,
+		consensusEnd,
+		filter.afterTokenId,
+		filter.getLimit(),
+		pq.Array(filter.tokenIds),
+	).Scan(&cb.ConsensusTimestamp, &cb.Balance, &cb.TokenBalances)
+	return cb, err
+}
+
+// queryBalanceChangeRaw runs the balance change query with hand-written SQL on the sql.DB
+func queryBalanceChangeRaw(db *sql.DB, accountId, consensusStart, consensusEnd int64, filter tokenFilter) (
+	*accountBalanceChange,
+	error,
+) {
+	change := &accountBalanceChange{}
+	err := db.QueryRow(
+		balanceChangeBetweenRaw,
+		accountId,
+		consensusStart,
+		consensusEnd,
+		filter.afterTokenId,
+		filter.getLimit(),
+		pq.Array(filter.tokenIds),
+		!filter.skipHbarChange,
+	).Scan(&change.Value, &change.TokenValues)
+	return change, err
+}
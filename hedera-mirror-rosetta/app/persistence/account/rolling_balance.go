@@ -0,0 +1,223 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+package account
+
+import (
+	"database/sql"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	// createRollingBalanceTables creates the rolling balance tables owned by rosetta. The progress table has a single
+	// row with the time range (first_timestamp, last_timestamp] the rolling balances cover
+	createRollingBalanceTables = `create table if not exists rosetta_rolling_balance (
+                                    account_id          bigint not null,
+                                    consensus_timestamp bigint not null,
+                                    balance             bigint not null,
+                                    primary key (account_id, consensus_timestamp)
+                                  );
+                                  create table if not exists rosetta_rolling_balance_progress (
+                                    id              smallint primary key default 1 check (id = 1),
+                                    first_timestamp bigint not null,
+                                    last_timestamp  bigint not null
+                                  )`
+
+	selectRollingBalanceProgressForUpdate = `select first_timestamp, last_timestamp
+                                             from rosetta_rolling_balance_progress
+                                             for update`
+
+	// seedRollingBalances seeds the rolling balances with the latest account balance snapshot
+	seedRollingBalances = `insert into rosetta_rolling_balance (account_id, consensus_timestamp, balance)
+                           select account_id, consensus_timestamp, balance
+                           from account_balance
+                           where consensus_timestamp = @timestamp
+                           on conflict do nothing`
+
+	insertRollingBalanceProgress = `insert into rosetta_rolling_balance_progress (first_timestamp, last_timestamp)
+                                    values (@timestamp, @timestamp)
+                                    on conflict do nothing`
+
+	selectLatestBalanceSnapshotTimestamp = `select max(consensus_timestamp) from account_balance_file`
+
+	selectRecordFileEndsAfter = `select consensus_end
+                                 from record_file
+                                 where consensus_end > @timestamp
+                                 order by consensus_end
+                                 limit @limit`
+
+	// insertRollingBalances adds the balances at @end of the accounts with crypto transfers in (@start, @end], each
+	// the account's previous rolling balance plus its transfers
+	insertRollingBalances = `insert into rosetta_rolling_balance (account_id, consensus_timestamp, balance)
+                             select
+                               ct.entity_id,
+                               @end,
+                               sum(ct.amount)::bigint + coalesce((
+                                 select rb.balance
+                                 from rosetta_rolling_balance rb
+                                 where rb.account_id = ct.entity_id
+                                 order by rb.consensus_timestamp desc
+                                 limit 1
+                               ), 0)
+                             from crypto_transfer ct
+                             where ct.consensus_timestamp > @start and ct.consensus_timestamp <= @end
+                             group by ct.entity_id`
+
+	updateRollingBalanceProgress = `update rosetta_rolling_balance_progress set last_timestamp = @timestamp`
+
+	// selectRollingBalance selects the hbar balance of the account at the timestamp, and whether the rolling balances
+	// cover the timestamp. Since the rolling balances are kept at the end of each record file, a timestamp in the
+	// middle of a record file, e.g., the end of a time window block, isn't covered
+	selectRollingBalance = `select
+                              coalesce((
+                                select balance
+                                from rosetta_rolling_balance
+                                where account_id = @account_id and consensus_timestamp <= @timestamp
+                                order by consensus_timestamp desc
+                                limit 1
+                              ), 0) balance,
+                              first_timestamp <= @timestamp and last_timestamp >= @timestamp and exists(
+                                select 1 from record_file where consensus_end = @timestamp
+                              ) covered
+                            from rosetta_rolling_balance_progress`
+)
+
+type rollingBalanceProgress struct {
+	FirstTimestamp int64
+	LastTimestamp  int64
+}
+
+type rollingBalance struct {
+	Balance int64
+	Covered bool
+}
+
+// RollingBalanceUpdater maintains the hbar balance of every account at the end of each record file with crypto
+// transfers of the account, starting from the latest account balance snapshot when it first runs. The account
+// repository consults the rolling balances instead of summing up the crypto transfers since the last snapshot
+type RollingBalanceUpdater struct {
+	batchSize int
+	dbClient  *gorm.DB
+}
+
+// NewRollingBalanceUpdater creates a RollingBalanceUpdater and the rolling balance tables if they don't exist. Each
+// update processes at most batchSize record files
+func NewRollingBalanceUpdater(dbClient *gorm.DB, batchSize int) (*RollingBalanceUpdater, error) {
+	if err := dbClient.Exec(createRollingBalanceTables).Error; err != nil {
+		return nil, err
+	}
+
+	return &RollingBalanceUpdater{batchSize: batchSize, dbClient: dbClient}, nil
+}
+
+// Update adds the rolling balances of the next batch of record files in a single database transaction. Concurrent
+// updates, e.g. from multiple rosetta instances, are serialized by locking the progress row
+func (u *RollingBalanceUpdater) Update() {
+	var count int
+	var last int64
+	err := u.dbClient.Transaction(func(tx *gorm.DB) error {
+		progress := &rollingBalanceProgress{}
+		result := tx.Raw(selectRollingBalanceProgressForUpdate).Scan(progress)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return seed(tx)
+		}
+
+		var consensusEnds []int64
+		if err := tx.Raw(
+			selectRecordFileEndsAfter,
+			sql.Named("limit", u.batchSize),
+			sql.Named("timestamp", progress.LastTimestamp),
+		).Scan(&consensusEnds).Error; err != nil {
+			return err
+		}
+
+		last = progress.LastTimestamp
+		for _, consensusEnd := range consensusEnds {
+			if err := tx.Exec(
+				insertRollingBalances,
+				sql.Named("end", consensusEnd),
+				sql.Named("start", last),
+			).Error; err != nil {
+				return err
+			}
+			last = consensusEnd
+		}
+
+		count = len(consensusEnds)
+		if count == 0 {
+			return nil
+		}
+
+		return tx.Exec(updateRollingBalanceProgress, sql.Named("timestamp", last)).Error
+	})
+	if err != nil {
+		log.Errorf("Failed to update the rolling balances: %s", err)
+		return
+	}
+
+	if count != 0 {
+		log.Debugf("Updated the rolling balances of %d record files up to %d", count, last)
+	}
+}
+
+// seed seeds the rolling balances with the latest account balance snapshot, if there is one
+func seed(tx *gorm.DB) error {
+	var snapshotTimestamp sql.NullInt64
+	if err := tx.Raw(selectLatestBalanceSnapshotTimestamp).Scan(&snapshotTimestamp).Error; err != nil {
+		return err
+	}
+
+	if !snapshotTimestamp.Valid {
+		return nil
+	}
+
+	timestamp := sql.Named("timestamp", snapshotTimestamp.Int64)
+	if err := tx.Exec(seedRollingBalances, timestamp).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Exec(insertRollingBalanceProgress, timestamp).Error; err != nil {
+		return err
+	}
+
+	log.Infof("Seeded the rolling balances with the account balance snapshot at %d", snapshotTimestamp.Int64)
+	return nil
+}
+
+// getRollingBalance returns the hbar balance of the account at consensusEnd from the rolling balances, and false if
+// the rolling balances don't cover consensusEnd. consensusEnd must be at a block boundary
+func getRollingBalance(dbClient *gorm.DB, accountId, consensusEnd int64) (int64, bool, error) {
+	rb := &rollingBalance{}
+	result := dbClient.Raw(
+		selectRollingBalance,
+		sql.Named("account_id", accountId),
+		sql.Named("timestamp", consensusEnd),
+	).Scan(rb)
+	if result.Error != nil {
+		return 0, false, result.Error
+	}
+
+	return rb.Balance, result.RowsAffected != 0 && rb.Covered, nil
+}
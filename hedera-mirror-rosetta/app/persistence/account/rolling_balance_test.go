@@ -0,0 +1,140 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+package account
+
+import (
+	"testing"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/db"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestRollingBalanceSuite(t *testing.T) {
+	suite.Run(t, new(rollingBalanceSuite))
+}
+
+type rollingBalanceSuite struct {
+	suite.Suite
+	dbResource db.DbResource
+}
+
+func (suite *rollingBalanceSuite) SetupSuite() {
+	suite.dbResource = db.SetupDb()
+}
+
+func (suite *rollingBalanceSuite) TearDownSuite() {
+	db.TeardownDb(suite.dbResource)
+}
+
+func (suite *rollingBalanceSuite) SetupTest() {
+	dbClient := suite.dbResource.GetGormDb()
+	db.CleanupDb(suite.dbResource.GetDb())
+	dbClient.Exec("drop table if exists rosetta_rolling_balance, rosetta_rolling_balance_progress")
+
+	for _, record := range []interface{}{snapshotAccountBalanceFile, token1, token2, initialAccountBalance,
+		initialTokenBalances, cryptoTransfersLTESnapshot, tokenTransfersLTESnapshot, cryptoTransfers,
+		tokenTransfers} {
+		dbClient.Create(record)
+	}
+	domain.NewRecordFileBuilder(dbClient, 1, snapshotTimestamp+1, 150).Persist()
+	domain.NewRecordFileBuilder(dbClient, 2, 151, consensusEnd).Persist()
+	domain.NewRecordFileBuilder(dbClient, 3, consensusEnd+1, 250).Persist()
+}
+
+func (suite *rollingBalanceSuite) TestUpdate() {
+	// given
+	dbClient := suite.dbResource.GetGormDb()
+	updater, err := NewRollingBalanceUpdater(dbClient, 10)
+	assert.Nil(suite.T(), err)
+	serialRepo := NewAccountRepository(dbClient, false, 1, false)
+	rollingRepo := NewAccountRepository(dbClient, false, 1, true)
+
+	// when
+	updater.Update()
+	updater.Update()
+
+	// then
+	for _, timestamp := range []int64{snapshotTimestamp, 150, consensusEnd, 250} {
+		expected, rErr := serialRepo.RetrieveBalanceAtBlock(accountString, timestamp, nil, 0, 0)
+		assert.Nil(suite.T(), rErr)
+		actual, rErr := rollingRepo.RetrieveBalanceAtBlock(accountString, timestamp, nil, 0, 0)
+		assert.Nil(suite.T(), rErr)
+		assert.Equal(suite.T(), expected, actual)
+
+		balance, covered, err := getRollingBalance(dbClient, account, timestamp)
+		assert.Nil(suite.T(), err)
+		assert.True(suite.T(), covered)
+		assert.Equal(suite.T(), expected[0].(*types.HbarAmount).Value, balance)
+	}
+
+	hbarOnly, rErr := rollingRepo.RetrieveBalanceAtBlock(accountString, consensusEnd, []int64{}, 0, 0)
+	assert.Nil(suite.T(), rErr)
+	assert.Equal(suite.T(), []types.Amount{&types.HbarAmount{
+		Value: initialAccountBalance.Balance + sum(cryptoTransferAmounts),
+	}}, hbarOnly)
+}
+
+func (suite *rollingBalanceSuite) TestUpdateBatch() {
+	// given
+	dbClient := suite.dbResource.GetGormDb()
+	updater, err := NewRollingBalanceUpdater(dbClient, 1)
+	assert.Nil(suite.T(), err)
+
+	// when
+	updater.Update()
+	updater.Update()
+
+	// then
+	_, covered, err := getRollingBalance(dbClient, account, 150)
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), covered)
+
+	_, covered, err = getRollingBalance(dbClient, account, consensusEnd)
+	assert.Nil(suite.T(), err)
+	assert.False(suite.T(), covered)
+}
+
+func (suite *rollingBalanceSuite) TestGetRollingBalanceNotCovered() {
+	// given
+	dbClient := suite.dbResource.GetGormDb()
+	updater, err := NewRollingBalanceUpdater(dbClient, 10)
+	assert.Nil(suite.T(), err)
+
+	// when
+	_, notSeeded, err := getRollingBalance(dbClient, account, snapshotTimestamp)
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.False(suite.T(), notSeeded)
+
+	// when
+	updater.Update()
+	_, beforeSnapshot, err := getRollingBalance(dbClient, account, snapshotTimestamp-1)
+	assert.Nil(suite.T(), err)
+	_, afterLastRecordFile, err := getRollingBalance(dbClient, account, 150)
+	assert.Nil(suite.T(), err)
+
+	// then
+	assert.False(suite.T(), beforeSnapshot)
+	assert.False(suite.T(), afterLastRecordFile)
+}
@@ -0,0 +1,139 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package addressbook
+
+import (
+	"database/sql"
+	"errors"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	hErrors "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	dbTypes "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/types"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// nodeAddressBookFileId is the file 0.0.102 which has the full details of the nodes
+const nodeAddressBookFileId int64 = 102
+
+const (
+	// selectLatestAddressBook selects the current address book of the nodes
+	selectLatestAddressBook = `select *
+                               from address_book
+                               where file_id = @file_id and end_consensus_timestamp is null
+                               order by start_consensus_timestamp desc
+                               limit 1`
+
+	// selectAddressBookEntries selects the nodes in the address book ordered by node id
+	selectAddressBookEntries = `select *
+                                from address_book_entry
+                                where consensus_timestamp = @timestamp
+                                order by node_id`
+
+	// selectAddressBookServiceEndpoints selects the service endpoints of the nodes in the address book
+	selectAddressBookServiceEndpoints = `select *
+                                         from address_book_service_endpoint
+                                         where consensus_timestamp = @timestamp
+                                         order by node_id, ip_address_v4, port`
+)
+
+// addressBookRepository struct that has connection to the Database
+type addressBookRepository struct {
+	dbClient *gorm.DB
+}
+
+// NewAddressBookRepository creates an instance of a addressBookRepository struct
+func NewAddressBookRepository(dbClient *gorm.DB) repositories.AddressBookRepository {
+	return &addressBookRepository{dbClient: dbClient}
+}
+
+// FindLatest returns the current address book of the nodes with their service endpoints
+func (ar *addressBookRepository) FindLatest() (*types.AddressBook, *rTypes.Error) {
+	addressBook := &dbTypes.AddressBook{}
+	if err := ar.dbClient.Raw(selectLatestAddressBook, sql.Named("file_id", nodeAddressBookFileId)).
+		First(addressBook).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, hErrors.ErrAddressBookNotFound
+		}
+
+		log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, err)
+		return nil, hErrors.ErrDatabaseError
+	}
+
+	timestamp := sql.Named("timestamp", addressBook.StartConsensusTimestamp)
+	var entries []dbTypes.AddressBookEntry
+	if err := ar.dbClient.Raw(selectAddressBookEntries, timestamp).Scan(&entries).Error; err != nil {
+		log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, err)
+		return nil, hErrors.ErrDatabaseError
+	}
+
+	var serviceEndpoints []dbTypes.AddressBookServiceEndpoint
+	if err := ar.dbClient.Raw(selectAddressBookServiceEndpoints, timestamp).Scan(&serviceEndpoints).Error; err != nil {
+		log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, err)
+		return nil, hErrors.ErrDatabaseError
+	}
+
+	endpointsByNode := make(map[int64][]types.ServiceEndpoint)
+	for _, serviceEndpoint := range serviceEndpoints {
+		endpointsByNode[serviceEndpoint.NodeId] = append(endpointsByNode[serviceEndpoint.NodeId], types.ServiceEndpoint{
+			IpAddressV4: serviceEndpoint.IpAddressV4,
+			Port:        serviceEndpoint.Port,
+		})
+	}
+
+	nodes := make([]*types.AddressBookNode, 0, len(entries))
+	for _, entry := range entries {
+		nodeAccountId, err := types.NewAccountFromEncodedID(entry.NodeAccountId)
+		if err != nil {
+			log.Errorf(hErrors.CreateAccountDbIdFailed, entry.NodeAccountId)
+			return nil, hErrors.ErrInternalServerError
+		}
+
+		var stake int64
+		if entry.Stake != nil {
+			stake = *entry.Stake
+		}
+
+		nodes = append(nodes, &types.AddressBookNode{
+			Description:      entry.Description,
+			NodeAccountId:    nodeAccountId,
+			NodeCertHash:     string(entry.NodeCertHash),
+			NodeId:           entry.NodeId,
+			PublicKey:        entry.PublicKey,
+			ServiceEndpoints: endpointsByNode[entry.NodeId],
+			Stake:            stake,
+		})
+	}
+
+	fileId, err := entityid.Decode(addressBook.FileId)
+	if err != nil {
+		return nil, hErrors.ErrInternalServerError
+	}
+
+	return &types.AddressBook{
+		ConsensusTimestamp: addressBook.StartConsensusTimestamp,
+		FileId:             fileId,
+		Nodes:              nodes,
+	}, nil
+}
@@ -0,0 +1,145 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package addressbook
+
+import (
+	"testing"
+
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	dbTypes "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+var endTimestamp int64 = 199
+
+// run the suite
+func TestAddressBookRepositorySuite(t *testing.T) {
+	suite.Run(t, new(addressBookRepositorySuite))
+}
+
+type addressBookRepositorySuite struct {
+	suite.Suite
+	dbResource db.DbResource
+}
+
+func (suite *addressBookRepositorySuite) SetupSuite() {
+	suite.dbResource = db.SetupDb()
+}
+
+func (suite *addressBookRepositorySuite) TearDownSuite() {
+	db.TeardownDb(suite.dbResource)
+}
+
+func (suite *addressBookRepositorySuite) SetupTest() {
+	db.CleanupDb(suite.dbResource.GetDb())
+}
+
+func (suite *addressBookRepositorySuite) TestFindLatest() {
+	// given
+	dbClient := suite.dbResource.GetGormDb()
+	stake := int64(100)
+	// a superseded address book of file 0.0.102
+	suite.addAddressBook(100, &endTimestamp, 102, 0)
+	// the current address book of file 0.0.101
+	suite.addAddressBook(150, nil, 101, 0)
+	suite.addAddressBook(200, nil, 102, 1)
+	dbClient.Create(&[]dbTypes.AddressBookEntry{
+		{ConsensusTimestamp: 200, NodeAccountId: 4, NodeCertHash: []byte("0b0c"), NodeId: 1, PublicKey: "308201a3"},
+		{
+			ConsensusTimestamp: 200,
+			Description:        "node 0",
+			NodeAccountId:      3,
+			NodeCertHash:       []byte("0a0b"),
+			NodeId:             0,
+			PublicKey:          "308201a2",
+			Stake:              &stake,
+		},
+	})
+	dbClient.Create(&[]dbTypes.AddressBookServiceEndpoint{
+		{ConsensusTimestamp: 200, IpAddressV4: "10.0.0.1", NodeId: 0, Port: 50212},
+		{ConsensusTimestamp: 200, IpAddressV4: "10.0.0.1", NodeId: 0, Port: 50211},
+	})
+	repo := NewAddressBookRepository(dbClient)
+
+	expected := &types.AddressBook{
+		ConsensusTimestamp: 200,
+		FileId:             entityid.EntityId{EntityNum: 102, EncodedId: 102},
+		Nodes: []*types.AddressBookNode{
+			{
+				Description:   "node 0",
+				NodeAccountId: types.Account{EntityId: entityid.EntityId{EntityNum: 3, EncodedId: 3}},
+				NodeCertHash:  "0a0b",
+				NodeId:        0,
+				PublicKey:     "308201a2",
+				ServiceEndpoints: []types.ServiceEndpoint{
+					{IpAddressV4: "10.0.0.1", Port: 50211},
+					{IpAddressV4: "10.0.0.1", Port: 50212},
+				},
+				Stake: stake,
+			},
+			{
+				NodeAccountId: types.Account{EntityId: entityid.EntityId{EntityNum: 4, EncodedId: 4}},
+				NodeCertHash:  "0b0c",
+				NodeId:        1,
+				PublicKey:     "308201a3",
+			},
+		},
+	}
+
+	// when
+	actual, err := repo.FindLatest()
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expected, actual)
+}
+
+func (suite *addressBookRepositorySuite) TestFindLatestNotFound() {
+	// given
+	suite.addAddressBook(100, &endTimestamp, 102, 0)
+	repo := NewAddressBookRepository(suite.dbResource.GetGormDb())
+
+	// when
+	actual, err := repo.FindLatest()
+
+	// then
+	assert.Equal(suite.T(), errors.ErrAddressBookNotFound, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *addressBookRepositorySuite) addAddressBook(
+	startTimestamp int64,
+	endTimestamp *int64,
+	fileId int64,
+	nodeCount int32,
+) {
+	suite.dbResource.GetGormDb().Create(&dbTypes.AddressBook{
+		StartConsensusTimestamp: startTimestamp,
+		EndConsensusTimestamp:   endTimestamp,
+		FileData:                []byte{0x1},
+		FileId:                  fileId,
+		NodeCount:               nodeCount,
+	})
+}
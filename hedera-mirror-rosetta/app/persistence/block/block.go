@@ -23,7 +23,9 @@ package block
 import (
 	"database/sql"
 	"errors"
+	"strings"
 	"sync"
+	"time"
 
 	rTypes "github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
@@ -34,6 +36,9 @@ import (
 
 const (
 	tableNameRecordFile = "record_file"
+
+	// recordFileHashLength is the length of the hex encoded SHA-384 hash of a record file
+	recordFileHashLength = 96
 )
 
 const (
@@ -56,6 +61,18 @@ const (
                                     FROM record_file
                                     WHERE hash = @hash`
 
+	// selectByHashPrefix - Selects at most two rows whose hash starts with the prefix, two are enough to tell a unique
+	// prefix from an ambiguous one
+	selectByHashPrefix string = `SELECT consensus_start,
+                                        consensus_end,
+                                        hash,
+                                        index,
+                                        prev_hash
+                                 FROM record_file
+                                 WHERE hash LIKE @prefix
+                                 ORDER BY consensus_end
+                                 LIMIT 2`
+
 	// selectGenesis - Selects the first block whose consensus_end is after the genesis account balance
 	// timestamp. Return the record file with adjusted consensus start
 	selectGenesis string = `SELECT
@@ -73,6 +90,28 @@ const (
                             ORDER BY consensus_end
                             LIMIT 1`
 
+	// selectRecordFilesByIndexRange - Selects the record_files with index in the range [@start, @end], ordered by index
+	selectRecordFilesByIndexRange string = `SELECT consensus_start,
+                                                   consensus_end,
+                                                   hash,
+                                                   index,
+                                                   prev_hash
+                                            FROM record_file
+                                            WHERE index >= @start AND index <= @end
+                                            ORDER BY index`
+
+	// selectRecordFileByConsensusTimestamp - Selects the first record_file whose consensus_end is at or after the
+	// timestamp
+	selectRecordFileByConsensusTimestamp string = `SELECT consensus_start,
+                                                          consensus_end,
+                                                          hash,
+                                                          index,
+                                                          prev_hash
+                                                   FROM record_file
+                                                   WHERE consensus_end >= @timestamp
+                                                   ORDER BY consensus_end
+                                                   LIMIT 1`
+
 	// selectRecordFileByIndex - Selects the record_file by its index
 	selectRecordFileByIndex string = `SELECT consensus_start,
                                              consensus_end,
@@ -131,14 +170,36 @@ func (rf *recordFile) ToBlock(genesisIndex int64) *types.Block {
 // blockRepository struct that has connection to the Database
 type blockRepository struct {
 	once                   sync.Once
-	dbClient               *gorm.DB
+	queries                recordFileQueries
 	genesisRecordFile      *recordFile
 	genesisRecordFileIndex int64
+	hashPrefixMinLength    int
+	latestCacheTtl         time.Duration
+	latestExpiresAt        time.Time
+	latestMutex            sync.RWMutex
+	latestRecordFile       *recordFile
 }
 
-// NewBlockRepository creates an instance of a blockRepository struct
-func NewBlockRepository(dbClient *gorm.DB) *blockRepository {
-	return &blockRepository{dbClient: dbClient}
+// NewBlockRepository creates an instance of a blockRepository struct. The latest record file is cached for
+// latestCacheTtl, 0 disables the cache. With rawQueries, the record files are queried with hand-written SQL on the
+// underlying sql.DB instead of through the ORM. A truncated block hash of at least hashPrefixMinLength characters is
+// matched as a prefix, 0 disables the prefix lookups
+func NewBlockRepository(
+	dbClient *gorm.DB,
+	latestCacheTtl time.Duration,
+	rawQueries bool,
+	hashPrefixMinLength int,
+) *blockRepository {
+	var queries recordFileQueries = &gormRecordFileQueries{dbClient: dbClient}
+	if rawQueries {
+		queries = newSqlRecordFileQueries(dbClient)
+	}
+
+	return &blockRepository{
+		queries:             queries,
+		hashPrefixMinLength: hashPrefixMinLength,
+		latestCacheTtl:      latestCacheTtl,
+	}
 }
 
 // FindByIndex retrieves a block by given Index
@@ -151,14 +212,68 @@ func (br *blockRepository) FindByIndex(index int64) (*types.Block, *rTypes.Error
 		return nil, err
 	}
 
-	rf := &recordFile{}
+	rf := br.genesisRecordFile
 	index += br.genesisRecordFileIndex
-	if index == br.genesisRecordFileIndex {
-		rf = br.genesisRecordFile
-	} else if err := br.dbClient.Raw(selectRecordFileByIndex, sql.Named("index", index)).First(rf).Error; err != nil {
+	if index != br.genesisRecordFileIndex {
+		var err error
+		if rf, err = br.queries.findByIndex(index); err != nil {
+			return nil, handleDatabaseError(err, hErrors.ErrBlockNotFound)
+		}
+	}
+
+	return rf.ToBlock(br.genesisRecordFileIndex), nil
+}
+
+// FindBetweenIndexes retrieves the blocks with index between start and end inclusively, ordered by index
+func (br *blockRepository) FindBetweenIndexes(start int64, end int64) ([]*types.Block, *rTypes.Error) {
+	if start < 0 || start > end {
+		return nil, hErrors.ErrInvalidArgument
+	}
+
+	if _, err := br.getGenesisRecordFile(); err != nil {
+		return nil, err
+	}
+
+	rfs, err := br.queries.findBetweenIndexes(start+br.genesisRecordFileIndex, end+br.genesisRecordFileIndex)
+	if err != nil {
 		return nil, handleDatabaseError(err, hErrors.ErrBlockNotFound)
 	}
 
+	blocks := make([]*types.Block, 0, len(rfs))
+	for _, rf := range rfs {
+		blocks = append(blocks, rf.ToBlock(br.genesisRecordFileIndex))
+	}
+
+	return blocks, nil
+}
+
+// FindByConsensusTimestamp retrieves the block whose consensus timestamp range contains the timestamp
+func (br *blockRepository) FindByConsensusTimestamp(timestamp int64) (*types.Block, *rTypes.Error) {
+	if timestamp < 0 {
+		return nil, hErrors.ErrInvalidArgument
+	}
+
+	if _, err := br.getGenesisRecordFile(); err != nil {
+		return nil, err
+	}
+
+	rf := br.genesisRecordFile
+	if timestamp < rf.ConsensusStart {
+		return nil, hErrors.ErrBlockNotFound
+	}
+
+	if timestamp > rf.ConsensusEnd {
+		var err error
+		if rf, err = br.queries.findByConsensusTimestamp(timestamp); err != nil {
+			return nil, handleDatabaseError(err, hErrors.ErrBlockNotFound)
+		}
+
+		// the timestamp falls in the gap between two record files
+		if timestamp < rf.ConsensusStart {
+			return nil, hErrors.ErrBlockNotFound
+		}
+	}
+
 	return rf.ToBlock(br.genesisRecordFileIndex), nil
 }
 
@@ -212,32 +327,113 @@ func (br *blockRepository) RetrieveLatest() (*types.Block, *rTypes.Error) {
 		return nil, err
 	}
 
-	rf := &recordFile{}
-	if err := br.dbClient.Raw(selectLatestWithIndex).First(rf).Error; err != nil {
-		return nil, handleDatabaseError(err, hErrors.ErrBlockNotFound)
+	rf, err := br.getLatestRecordFile()
+	if err != nil {
+		return nil, err
+	}
+
+	return rf.ToBlock(br.genesisRecordFileIndex), nil
+}
+
+// RefreshLatest retrieves the latest block from the database bypassing the cache, and caches it
+func (br *blockRepository) RefreshLatest() (*types.Block, *rTypes.Error) {
+	if _, err := br.getGenesisRecordFile(); err != nil {
+		return nil, err
+	}
+
+	rf, err := br.queryLatestRecordFile()
+	if err != nil {
+		return nil, err
 	}
 
 	return rf.ToBlock(br.genesisRecordFileIndex), nil
 }
 
 func (br *blockRepository) findBlockByHash(hash string) (*types.Block, *rTypes.Error) {
-	rf := &recordFile{}
-	if hash == br.genesisRecordFile.Hash {
-		rf = br.genesisRecordFile
-	} else if err := br.dbClient.Raw(selectByHashWithIndex, sql.Named("hash", hash)).First(rf).Error; err != nil {
-		return nil, handleDatabaseError(err, hErrors.ErrBlockNotFound)
+	if br.hashPrefixMinLength > 0 && len(hash) >= br.hashPrefixMinLength && len(hash) < recordFileHashLength {
+		return br.findBlockByHashPrefix(hash)
+	}
+
+	rf := br.genesisRecordFile
+	if hash != br.genesisRecordFile.Hash {
+		var err error
+		if rf, err = br.queries.findByHash(hash); err != nil {
+			return nil, handleDatabaseError(err, hErrors.ErrBlockNotFound)
+		}
 	}
 
 	return rf.ToBlock(br.genesisRecordFileIndex), nil
 }
 
+// findBlockByHashPrefix finds the only block whose hash starts with the prefix, ErrBlockHashAmbiguous is returned if
+// more blocks match it
+func (br *blockRepository) findBlockByHashPrefix(prefix string) (*types.Block, *rTypes.Error) {
+	prefix = strings.ToLower(prefix)
+	if !isHex(prefix) {
+		// also keeps the LIKE wildcards out of the prefix
+		return nil, hErrors.ErrBlockNotFound
+	}
+
+	rfs, err := br.queries.findByHashPrefix(prefix)
+	if err != nil {
+		return nil, handleDatabaseError(err, hErrors.ErrBlockNotFound)
+	}
+
+	switch len(rfs) {
+	case 0:
+		return nil, hErrors.ErrBlockNotFound
+	case 1:
+		return rfs[0].ToBlock(br.genesisRecordFileIndex), nil
+	default:
+		return nil, hErrors.ErrBlockHashAmbiguous
+	}
+}
+
+// getLatestRecordFile returns the cached latest record file if it hasn't expired, otherwise queries the latest record
+// file and caches it
+func (br *blockRepository) getLatestRecordFile() (*recordFile, *rTypes.Error) {
+	if br.latestCacheTtl > 0 {
+		br.latestMutex.RLock()
+		rf := br.latestRecordFile
+		expiresAt := br.latestExpiresAt
+		br.latestMutex.RUnlock()
+
+		if rf != nil && time.Now().Before(expiresAt) {
+			return rf, nil
+		}
+	}
+
+	return br.queryLatestRecordFile()
+}
+
+// queryLatestRecordFile queries the latest record file and caches it
+func (br *blockRepository) queryLatestRecordFile() (*recordFile, *rTypes.Error) {
+	rf, err := br.queries.findLatest()
+	if err != nil {
+		return nil, handleDatabaseError(err, hErrors.ErrBlockNotFound)
+	}
+
+	if br.latestCacheTtl > 0 {
+		br.latestMutex.Lock()
+		// a concurrent query may have cached a newer record file
+		if br.latestRecordFile == nil || rf.ConsensusEnd >= br.latestRecordFile.ConsensusEnd {
+			br.latestRecordFile = rf
+			br.latestExpiresAt = time.Now().Add(br.latestCacheTtl)
+		}
+		rf = br.latestRecordFile
+		br.latestMutex.Unlock()
+	}
+
+	return rf, nil
+}
+
 func (br *blockRepository) getGenesisRecordFile() (*recordFile, *rTypes.Error) {
 	if br.genesisRecordFile != nil {
 		return br.genesisRecordFile, nil
 	}
 
-	rf := &recordFile{}
-	if err := br.dbClient.Raw(selectGenesis).First(rf).Error; err != nil {
+	rf, err := br.queries.findGenesis()
+	if err != nil {
 		return nil, handleDatabaseError(err, hErrors.ErrNodeIsStarting)
 	}
 
@@ -250,6 +446,74 @@ func (br *blockRepository) getGenesisRecordFile() (*recordFile, *rTypes.Error) {
 	return br.genesisRecordFile, nil
 }
 
+// recordFileQueries runs the record file queries of the repository
+type recordFileQueries interface {
+	findBetweenIndexes(start, end int64) ([]*recordFile, error)
+	findByConsensusTimestamp(timestamp int64) (*recordFile, error)
+	findByHash(hash string) (*recordFile, error)
+	findByHashPrefix(prefix string) ([]*recordFile, error)
+	findByIndex(index int64) (*recordFile, error)
+	findGenesis() (*recordFile, error)
+	findLatest() (*recordFile, error)
+}
+
+// gormRecordFileQueries runs the record file queries through the ORM
+type gormRecordFileQueries struct {
+	dbClient *gorm.DB
+}
+
+func (q *gormRecordFileQueries) findBetweenIndexes(start, end int64) ([]*recordFile, error) {
+	var rfs []*recordFile
+	err := q.dbClient.Raw(selectRecordFilesByIndexRange, sql.Named("start", start), sql.Named("end", end)).
+		Scan(&rfs).
+		Error
+	return rfs, err
+}
+
+func (q *gormRecordFileQueries) findByConsensusTimestamp(timestamp int64) (*recordFile, error) {
+	return q.first(selectRecordFileByConsensusTimestamp, sql.Named("timestamp", timestamp))
+}
+
+func (q *gormRecordFileQueries) findByHash(hash string) (*recordFile, error) {
+	return q.first(selectByHashWithIndex, sql.Named("hash", hash))
+}
+
+func (q *gormRecordFileQueries) findByHashPrefix(prefix string) ([]*recordFile, error) {
+	var rfs []*recordFile
+	err := q.dbClient.Raw(selectByHashPrefix, sql.Named("prefix", prefix+"%")).Scan(&rfs).Error
+	return rfs, err
+}
+
+func (q *gormRecordFileQueries) findByIndex(index int64) (*recordFile, error) {
+	return q.first(selectRecordFileByIndex, sql.Named("index", index))
+}
+
+func (q *gormRecordFileQueries) findGenesis() (*recordFile, error) {
+	return q.first(selectGenesis)
+}
+
+func (q *gormRecordFileQueries) findLatest() (*recordFile, error) {
+	return q.first(selectLatestWithIndex)
+}
+
+func (q *gormRecordFileQueries) first(query string, values ...interface{}) (*recordFile, error) {
+	rf := &recordFile{}
+	if err := q.dbClient.Raw(query, values...).First(rf).Error; err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+func isHex(s string) bool {
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+
+	return true
+}
+
 func handleDatabaseError(err error, recordNotFoundErr *rTypes.Error) *rTypes.Error {
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return recordNotFoundErr
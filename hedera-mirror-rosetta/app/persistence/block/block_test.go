@@ -23,6 +23,7 @@ package block
 import (
 	"database/sql/driver"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	rTypes "github.com/coinbase/rosetta-sdk-go/types"
@@ -203,6 +204,93 @@ func TestShouldFailFindByIndexNoGenesisRecordFile(t *testing.T) {
 	}
 }
 
+func TestShouldSuccessFindByConsensusTimestamp(t *testing.T) {
+	var tests = []struct {
+		name      string
+		timestamp int64
+		expected  *types.Block
+	}{
+		{
+			name:      "GenesisBlock",
+			timestamp: dbGenesis.ConsensusStart,
+			expected:  expectedGenesisBlock,
+		},
+		{
+			name:      "SecondBlock",
+			timestamp: dbRecordFile.ConsensusStart + 1,
+			expected:  expectedBlock,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			br, mock := setupRepositoryWithGenesisRecordFile(t, dbGenesis)
+			if tt.timestamp > dbGenesis.ConsensusEnd {
+				mock.ExpectQuery(selectRecordFileByConsensusTimestamp).
+					WithArgs(tt.timestamp).
+					WillReturnRows(sqlmock.NewRows(recordFileColumns).
+						AddRow(mocks.GetFieldsValuesAsDriverValue(dbRecordFile)...))
+			}
+
+			// when
+			result, err := br.FindByConsensusTimestamp(tt.timestamp)
+
+			// then
+			assert.NoError(t, mock.ExpectationsWereMet())
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestShouldFailFindByConsensusTimestamp(t *testing.T) {
+	var tests = []struct {
+		name       string
+		timestamp  int64
+		recordFile *recordFile
+		expected   *rTypes.Error
+	}{
+		{name: "Negative", timestamp: -1, expected: errors.ErrInvalidArgument},
+		{name: "BeforeGenesis", timestamp: dbGenesis.ConsensusStart - 1, expected: errors.ErrBlockNotFound},
+		{name: "AfterLatest", timestamp: dbRecordFile.ConsensusEnd + 1, expected: errors.ErrBlockNotFound},
+		{
+			name:      "BetweenRecordFiles",
+			timestamp: dbGenesis.ConsensusEnd + 1,
+			recordFile: &recordFile{
+				ConsensusStart: dbGenesis.ConsensusEnd + 10,
+				ConsensusEnd:   dbGenesis.ConsensusEnd + 20,
+				Hash:           "0x300300",
+				Index:          dbGenesis.Index + 1,
+				PrevHash:       dbGenesis.Hash,
+			},
+			expected: errors.ErrBlockNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			br, mock := setupRepositoryWithGenesisRecordFile(t, dbGenesis)
+			if tt.timestamp > dbGenesis.ConsensusEnd {
+				rows := sqlmock.NewRows(recordFileColumns)
+				if tt.recordFile != nil {
+					rows.AddRow(mocks.GetFieldsValuesAsDriverValue(tt.recordFile)...)
+				}
+				mock.ExpectQuery(selectRecordFileByConsensusTimestamp).WithArgs(tt.timestamp).WillReturnRows(rows)
+			}
+
+			// when
+			result, err := br.FindByConsensusTimestamp(tt.timestamp)
+
+			// then
+			assert.NoError(t, mock.ExpectationsWereMet())
+			assert.Equal(t, tt.expected, err)
+			assert.Nil(t, result)
+		})
+	}
+}
+
 func TestShouldSuccessFindByHash(t *testing.T) {
 	var tests = []struct {
 		name     string
@@ -295,6 +383,101 @@ func TestShouldFailFindByHashNoGenesisRecordFile(t *testing.T) {
 	}
 }
 
+func TestFindByHashPrefix(t *testing.T) {
+	var tests = []struct {
+		name     string
+		hash     string
+		query    string
+		args     string
+		rows     []*recordFile
+		expected *types.Block
+		err      *rTypes.Error
+	}{
+		{
+			name:     "UniquePrefix",
+			hash:     "2002",
+			query:    selectByHashPrefix,
+			args:     "2002%",
+			rows:     []*recordFile{dbRecordFile},
+			expected: expectedBlock,
+		},
+		{
+			name:     "UpperCasePrefix",
+			hash:     "AB2002",
+			query:    selectByHashPrefix,
+			args:     "ab2002%",
+			rows:     []*recordFile{dbRecordFile},
+			expected: expectedBlock,
+		},
+		{
+			name:  "AmbiguousPrefix",
+			hash:  "2002",
+			query: selectByHashPrefix,
+			args:  "2002%",
+			rows:  []*recordFile{dbGenesis, dbRecordFile},
+			err:   errors.ErrBlockHashAmbiguous,
+		},
+		{
+			name:  "NoMatch",
+			hash:  "2002",
+			query: selectByHashPrefix,
+			args:  "2002%",
+			err:   errors.ErrBlockNotFound,
+		},
+		{
+			name: "NotHex",
+			hash: "20%_",
+			err:  errors.ErrBlockNotFound,
+		},
+		{
+			name:     "BelowMinLength",
+			hash:     "200",
+			query:    selectByHashWithIndex,
+			args:     "200",
+			rows:     []*recordFile{dbRecordFile},
+			expected: expectedBlock,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			br, mock := setupRepositoryWithGenesisRecordFile(t, dbGenesis)
+			br.hashPrefixMinLength = 4
+			if tt.query != "" {
+				rows := sqlmock.NewRows(recordFileColumns)
+				for _, rf := range tt.rows {
+					rows.AddRow(mocks.GetFieldsValuesAsDriverValue(rf)...)
+				}
+				mock.ExpectQuery(tt.query).WithArgs(tt.args).WillReturnRows(rows)
+			}
+
+			// when
+			result, err := br.FindByHash(tt.hash)
+
+			// then
+			assert.NoError(t, mock.ExpectationsWereMet())
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestFindByHashPrefixDbError(t *testing.T) {
+	// given
+	br, mock := setupRepositoryWithGenesisRecordFile(t, dbGenesis)
+	br.hashPrefixMinLength = 4
+	mock.ExpectQuery(selectByHashPrefix).WillReturnError(gorm.ErrInvalidTransaction)
+
+	// when
+	result, err := br.FindByHash("2002")
+
+	// then
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Nil(t, result)
+	assert.Equal(t, errors.ErrDatabaseError, err)
+}
+
 func TestShouldSuccessFindByIdentifier(t *testing.T) {
 	var tests = []struct {
 		name     string
@@ -520,6 +703,134 @@ func TestShouldSuccessRetrieveLatest(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestShouldSuccessRetrieveLatestFromCache(t *testing.T) {
+	// given
+	dbSelectRecordFile := []driver.Value{
+		dbRecordFile.Hash,
+		dbRecordFile.ConsensusStart,
+		dbRecordFile.ConsensusEnd,
+		dbRecordFile.Index,
+		dbRecordFile.PrevHash,
+	}
+	br, mock := setupRepositoryWithLatestCacheTtl(t, dbGenesis, time.Minute)
+
+	mock.ExpectQuery(selectLatestWithIndex).
+		WillReturnRows(sqlmock.NewRows(selectRecordFileColumns).AddRow(dbSelectRecordFile...))
+
+	// when
+	first, firstErr := br.RetrieveLatest()
+	second, secondErr := br.RetrieveLatest()
+
+	// then
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, expectedBlock, first)
+	assert.Nil(t, firstErr)
+	assert.Equal(t, expectedBlock, second)
+	assert.Nil(t, secondErr)
+}
+
+func TestShouldSuccessRetrieveLatestCacheExpired(t *testing.T) {
+	// given
+	dbSelectRecordFile := []driver.Value{
+		dbRecordFile.Hash,
+		dbRecordFile.ConsensusStart,
+		dbRecordFile.ConsensusEnd,
+		dbRecordFile.Index,
+		dbRecordFile.PrevHash,
+	}
+	br, mock := setupRepositoryWithLatestCacheTtl(t, dbGenesis, time.Minute)
+	br.latestRecordFile = dbGenesis
+	br.latestExpiresAt = time.Now().Add(-time.Second)
+
+	mock.ExpectQuery(selectLatestWithIndex).
+		WillReturnRows(sqlmock.NewRows(selectRecordFileColumns).AddRow(dbSelectRecordFile...))
+
+	// when
+	result, err := br.RetrieveLatest()
+
+	// then
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, expectedBlock, result)
+	assert.Nil(t, err)
+	assert.True(t, br.latestExpiresAt.After(time.Now()))
+}
+
+func TestShouldSuccessRefreshLatest(t *testing.T) {
+	// given
+	dbSelectRecordFile := []driver.Value{
+		dbRecordFile.Hash,
+		dbRecordFile.ConsensusStart,
+		dbRecordFile.ConsensusEnd,
+		dbRecordFile.Index,
+		dbRecordFile.PrevHash,
+	}
+	br, mock := setupRepositoryWithLatestCacheTtl(t, dbGenesis, time.Minute)
+	br.latestRecordFile = dbGenesis
+	br.latestExpiresAt = time.Now().Add(time.Minute)
+
+	mock.ExpectQuery(selectLatestWithIndex).
+		WillReturnRows(sqlmock.NewRows(selectRecordFileColumns).AddRow(dbSelectRecordFile...))
+
+	// when
+	result, err := br.RefreshLatest()
+
+	// then
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, expectedBlock, result)
+	assert.Nil(t, err)
+	assert.Equal(t, dbRecordFile.ConsensusEnd, br.latestRecordFile.ConsensusEnd)
+}
+
+func TestShouldSuccessFindBetweenIndexes(t *testing.T) {
+	// given
+	dbSelectRecordFile := []driver.Value{
+		dbRecordFile.Hash,
+		dbRecordFile.ConsensusStart,
+		dbRecordFile.ConsensusEnd,
+		dbRecordFile.Index,
+		dbRecordFile.PrevHash,
+	}
+	br, mock := setupRepositoryWithGenesisRecordFile(t, dbGenesis)
+
+	mock.ExpectQuery(selectRecordFilesByIndexRange).
+		WithArgs(dbGenesis.Index+1, dbGenesis.Index+2).
+		WillReturnRows(sqlmock.NewRows(selectRecordFileColumns).AddRow(dbSelectRecordFile...))
+
+	// when
+	result, err := br.FindBetweenIndexes(1, 2)
+
+	// then
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []*types.Block{expectedBlock}, result)
+	assert.Nil(t, err)
+}
+
+func TestShouldFailFindBetweenIndexesInvalidArgument(t *testing.T) {
+	var tests = []struct {
+		name  string
+		start int64
+		end   int64
+	}{
+		{name: "NegativeStart", start: -1, end: 2},
+		{name: "StartAfterEnd", start: 3, end: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			br, mock := setupRepository(t)
+
+			// when
+			result, err := br.FindBetweenIndexes(tt.start, tt.end)
+
+			// then
+			assert.NoError(t, mock.ExpectationsWereMet())
+			assert.Nil(t, result)
+			assert.Equal(t, errors.ErrInvalidArgument, err)
+		})
+	}
+}
+
 func TestShouldFailRetrieveLatestRecordFileNotFound(t *testing.T) {
 	var tests = []struct {
 		name     string
@@ -776,12 +1087,24 @@ func TestShouldSuccessReturnRepository(t *testing.T) {
 	gormDbClient, _ := mocks.DatabaseMock(t)
 
 	// when
-	result := NewBlockRepository(gormDbClient)
+	result := NewBlockRepository(gormDbClient, time.Second, false, 0)
 
 	// then
 	assert.NotNil(t, result)
 	assert.Implements(t, (*repositories.BlockRepository)(nil), result)
-	assert.Equal(t, result.dbClient, gormDbClient)
+	assert.Equal(t, &gormRecordFileQueries{dbClient: gormDbClient}, result.queries)
+	assert.Equal(t, time.Second, result.latestCacheTtl)
+}
+
+func TestShouldSuccessReturnRepositoryWithRawQueries(t *testing.T) {
+	// given
+	gormDbClient, _ := mocks.DatabaseMock(t)
+
+	// when
+	result := NewBlockRepository(gormDbClient, time.Second, true, 0)
+
+	// then
+	assert.IsType(t, &sqlRecordFileQueries{}, result.queries)
 }
 
 func setupRepository(t *testing.T) (*blockRepository, sqlmock.Sqlmock) {
@@ -791,10 +1114,18 @@ func setupRepository(t *testing.T) (*blockRepository, sqlmock.Sqlmock) {
 func setupRepositoryWithGenesisRecordFile(
 	t *testing.T,
 	genesisRecordFile *recordFile,
+) (*blockRepository, sqlmock.Sqlmock) {
+	return setupRepositoryWithLatestCacheTtl(t, genesisRecordFile, 0)
+}
+
+func setupRepositoryWithLatestCacheTtl(
+	t *testing.T,
+	genesisRecordFile *recordFile,
+	latestCacheTtl time.Duration,
 ) (*blockRepository, sqlmock.Sqlmock) {
 	gormDbClient, mock := mocks.DatabaseMock(t)
 
-	aber := NewBlockRepository(gormDbClient)
+	aber := NewBlockRepository(gormDbClient, latestCacheTtl, false, 0)
 	if genesisRecordFile != nil {
 		aber.genesisRecordFile = genesisRecordFile
 		aber.genesisRecordFileIndex = genesisRecordFile.Index
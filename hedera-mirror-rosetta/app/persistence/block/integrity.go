@@ -0,0 +1,119 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+package block
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// selectRecordFileSample - Selects the latest @limit record files with the hash of the record file preceding each and
+// the number of transactions in its consensus timestamp range
+const selectRecordFileSample = `select
+                                  s.count,
+                                  s.hash,
+                                  s.index,
+                                  s.prev_hash,
+                                  (select hash from record_file where index = s.index - 1) as previous_hash,
+                                  (
+                                    select count(*)
+                                    from transaction
+                                    where consensus_ns >= s.consensus_start and consensus_ns <= s.consensus_end
+                                  ) as transaction_count
+                                from (
+                                  select consensus_start, consensus_end, count, hash, index, prev_hash
+                                  from record_file
+                                  order by consensus_end desc
+                                  limit @limit
+                                ) as s
+                                order by s.index`
+
+type recordFileSample struct {
+	Count            int64
+	Hash             string
+	Index            int64
+	PrevHash         string
+	PreviousHash     *string
+	TransactionCount int64
+}
+
+// inconsistencies returns the descriptions of how the record file disagrees with the record file preceding it and the
+// transaction table
+func (r recordFileSample) inconsistencies() []string {
+	var inconsistencies []string
+	if len(r.Hash) != recordFileHashLength {
+		inconsistencies = append(inconsistencies, fmt.Sprintf("record file %d has malformed hash %q", r.Index, r.Hash))
+	}
+
+	// the previous record file is missing for the first record file of a partial mirror node
+	if r.PreviousHash != nil && *r.PreviousHash != r.PrevHash {
+		inconsistencies = append(inconsistencies, fmt.Sprintf(
+			"record file %d links to previous hash %s but record file %d has hash %s",
+			r.Index,
+			r.PrevHash,
+			r.Index-1,
+			*r.PreviousHash,
+		))
+	}
+
+	if r.Count != r.TransactionCount {
+		inconsistencies = append(inconsistencies, fmt.Sprintf(
+			"record file %d has %d transactions but %d are in the transaction table",
+			r.Index,
+			r.Count,
+			r.TransactionCount,
+		))
+	}
+
+	return inconsistencies
+}
+
+// CheckIntegrity samples the latest sampleSize record files and returns an error if one isn't linked to the record
+// file preceding it by hash, or its transaction count disagrees with the transaction table, e.g., when the database is
+// partially restored. Since the record files themselves aren't stored, the hashes are checked for their links only
+func CheckIntegrity(dbClient *gorm.DB, sampleSize int) error {
+	if sampleSize <= 0 {
+		return fmt.Errorf("invalid integrity check sample size %d", sampleSize)
+	}
+
+	var samples []recordFileSample
+	if err := dbClient.Raw(selectRecordFileSample, sql.Named("limit", sampleSize)).Scan(&samples).Error; err != nil {
+		return fmt.Errorf("failed to sample the record files: %w", err)
+	}
+
+	var inconsistencies []string
+	for _, sample := range samples {
+		inconsistencies = append(inconsistencies, sample.inconsistencies()...)
+	}
+
+	if len(inconsistencies) != 0 {
+		return fmt.Errorf(
+			"found %d inconsistencies in the latest %d record files: %s",
+			len(inconsistencies),
+			len(samples),
+			strings.Join(inconsistencies, "; "),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+package block
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+var integrityHashes = []string{
+	strings.Repeat("a", recordFileHashLength),
+	strings.Repeat("b", recordFileHashLength),
+	strings.Repeat("c", recordFileHashLength),
+}
+
+func TestCheckIntegrity(t *testing.T) {
+	var tests = []struct {
+		name          string
+		rows          [][]driver.Value
+		expectedError string
+	}{
+		{
+			name: "Consistent",
+			rows: [][]driver.Value{
+				{int64(5), integrityHashes[1], int64(1), integrityHashes[0], nil, int64(5)},
+				{int64(0), integrityHashes[2], int64(2), integrityHashes[1], integrityHashes[1], int64(0)},
+			},
+		},
+		{name: "Empty"},
+		{
+			name: "BrokenLink",
+			rows: [][]driver.Value{
+				{int64(5), integrityHashes[2], int64(2), integrityHashes[0], integrityHashes[1], int64(5)},
+			},
+			expectedError: "record file 2 links to previous hash " + integrityHashes[0],
+		},
+		{
+			name: "TransactionCountMismatch",
+			rows: [][]driver.Value{
+				{int64(5), integrityHashes[2], int64(2), integrityHashes[1], integrityHashes[1], int64(3)},
+			},
+			expectedError: "record file 2 has 5 transactions but 3 are in the transaction table",
+		},
+		{
+			name:          "MalformedHash",
+			rows:          [][]driver.Value{{int64(5), "0x1234", int64(2), integrityHashes[1], nil, int64(5)}},
+			expectedError: "record file 2 has malformed hash",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			dbClient, mock := mocks.DatabaseMock(t)
+			rows := sqlmock.NewRows([]string{"count", "hash", "index", "prev_hash", "previous_hash",
+				"transaction_count"})
+			for _, row := range tt.rows {
+				rows.AddRow(row...)
+			}
+			mock.ExpectQuery(selectRecordFileSample).WithArgs(10).WillReturnRows(rows)
+
+			// when
+			err := CheckIntegrity(dbClient, 10)
+
+			// then
+			assert.NoError(t, mock.ExpectationsWereMet())
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestCheckIntegrityDbError(t *testing.T) {
+	// given
+	dbClient, mock := mocks.DatabaseMock(t)
+	mock.ExpectQuery(selectRecordFileSample).WillReturnError(errors.New("connection refused"))
+
+	// when
+	err := CheckIntegrity(dbClient, 10)
+
+	// then
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func TestCheckIntegrityInvalidSampleSize(t *testing.T) {
+	// when
+	err := CheckIntegrity(nil, 0)
+
+	// then
+	assert.Error(t, err)
+}
@@ -0,0 +1,150 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package block
+
+import (
+	"database/sql"
+	"errors"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	recordFileColumnsRaw = "consensus_start, consensus_end, hash, index, prev_hash"
+
+	// the hints only take effect with the pg_hint_plan extension and are plain comments otherwise
+	selectByConsensusTimestampRaw = "/*+ IndexScan(record_file record_file__consensus_end) */ select " +
+		recordFileColumnsRaw + " from record_file where consensus_end >= $1 order by consensus_end limit 1"
+	selectByHashRaw = "/*+ IndexScan(record_file) */ select " + recordFileColumnsRaw +
+		" from record_file where hash = $1"
+	selectByHashPrefixRaw = "/*+ IndexScan(record_file) */ select " + recordFileColumnsRaw +
+		" from record_file where hash like $1 order by consensus_end limit 2"
+	selectByIndexRaw = "/*+ IndexScan(record_file record_file__index) */ select " + recordFileColumnsRaw +
+		" from record_file where index = $1"
+	selectByIndexRangeRaw = "/*+ IndexScan(record_file record_file__index) */ select " + recordFileColumnsRaw +
+		" from record_file where index >= $1 and index <= $2 order by index"
+	selectGenesisRaw = `/*+ IndexScan(rf record_file__consensus_end) */
+                        select consensus_start, consensus_end, hash, index, prev_hash
+                        from (
+                          select
+                            case
+                              when genesis.min >= rf.consensus_start then genesis.min + 1
+                              else rf.consensus_start
+                            end as consensus_start,
+                            rf.consensus_end, rf.hash, rf.index, rf.prev_hash
+                          from record_file rf
+                          join (select min(consensus_timestamp) from account_balance_file) as genesis
+                            on rf.consensus_end > genesis.min
+                          order by rf.consensus_end
+                          limit 1
+                        ) genesis_record_file`
+	selectLatestRaw = "/*+ IndexScan(record_file) */ select " + recordFileColumnsRaw +
+		" from record_file order by consensus_end desc limit 1"
+)
+
+// sqlRecordFileQueries runs the record file queries with hand-written SQL on the sql.DB, skipping the ORM statement
+// building and reflection. The queries aren't instrumented nor protected by the database circuit breaker
+type sqlRecordFileQueries struct {
+	db *sql.DB
+}
+
+// newSqlRecordFileQueries returns the raw queries on the sql.DB of dbClient, or the ORM queries if dbClient isn't
+// backed by a sql.DB
+func newSqlRecordFileQueries(dbClient *gorm.DB) recordFileQueries {
+	db, err := dbClient.DB()
+	if err != nil {
+		log.Warnf("Failed to get the sql.DB for raw record file queries, fall back to the ORM: %s", err)
+		return &gormRecordFileQueries{dbClient: dbClient}
+	}
+
+	return &sqlRecordFileQueries{db: db}
+}
+
+func (q *sqlRecordFileQueries) findBetweenIndexes(start, end int64) ([]*recordFile, error) {
+	return q.all(selectByIndexRangeRaw, start, end)
+}
+
+func (q *sqlRecordFileQueries) findByConsensusTimestamp(timestamp int64) (*recordFile, error) {
+	return q.first(selectByConsensusTimestampRaw, timestamp)
+}
+
+func (q *sqlRecordFileQueries) findByHash(hash string) (*recordFile, error) {
+	return q.first(selectByHashRaw, hash)
+}
+
+func (q *sqlRecordFileQueries) findByHashPrefix(prefix string) ([]*recordFile, error) {
+	return q.all(selectByHashPrefixRaw, prefix+"%")
+}
+
+func (q *sqlRecordFileQueries) findByIndex(index int64) (*recordFile, error) {
+	return q.first(selectByIndexRaw, index)
+}
+
+func (q *sqlRecordFileQueries) findGenesis() (*recordFile, error) {
+	return q.first(selectGenesisRaw)
+}
+
+func (q *sqlRecordFileQueries) findLatest() (*recordFile, error) {
+	return q.first(selectLatestRaw)
+}
+
+// all returns the record files of the query in the order of the rows
+func (q *sqlRecordFileQueries) all(query string, args ...interface{}) ([]*recordFile, error) {
+	rows, err := q.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rfs := make([]*recordFile, 0)
+	for rows.Next() {
+		rf, err := scanRecordFile(rows)
+		if err != nil {
+			return nil, err
+		}
+		rfs = append(rfs, rf)
+	}
+
+	return rfs, rows.Err()
+}
+
+// first returns the first record file of the query, gorm.ErrRecordNotFound is returned if there is none so the
+// repository handles both query implementations the same way
+func (q *sqlRecordFileQueries) first(query string, args ...interface{}) (*recordFile, error) {
+	rf, err := scanRecordFile(q.db.QueryRow(query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return rf, err
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRecordFile(row scanner) (*recordFile, error) {
+	rf := &recordFile{}
+	if err := row.Scan(&rf.ConsensusStart, &rf.ConsensusEnd, &rf.Hash, &rf.Index, &rf.PrevHash); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
@@ -0,0 +1,176 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package block
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	hErrors "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+var rawRecordFileColumns = []string{"consensus_start", "consensus_end", "hash", "index", "prev_hash"}
+
+func TestRawFindByIndex(t *testing.T) {
+	// given
+	br, mock := setupRawRepository(t)
+	mock.ExpectQuery(selectByIndexRaw).
+		WithArgs(dbRecordFile.Index).
+		WillReturnRows(newRawRecordFileRows(dbRecordFile))
+
+	// when
+	result, err := br.FindByIndex(index)
+
+	// then
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Nil(t, err)
+	assert.Equal(t, expectedBlock, result)
+}
+
+func TestRawFindByIndexNotFound(t *testing.T) {
+	// given
+	br, mock := setupRawRepository(t)
+	mock.ExpectQuery(selectByIndexRaw).
+		WithArgs(dbRecordFile.Index).
+		WillReturnRows(sqlmock.NewRows(rawRecordFileColumns))
+
+	// when
+	result, err := br.FindByIndex(index)
+
+	// then
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, hErrors.ErrBlockNotFound, err)
+	assert.Nil(t, result)
+}
+
+func TestRawFindBetweenIndexes(t *testing.T) {
+	// given
+	br, mock := setupRawRepository(t)
+	mock.ExpectQuery(selectByIndexRangeRaw).
+		WithArgs(dbGenesis.Index, dbRecordFile.Index).
+		WillReturnRows(newRawRecordFileRows(dbGenesis, dbRecordFile))
+
+	// when
+	result, err := br.FindBetweenIndexes(0, index)
+
+	// then
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Nil(t, err)
+	assert.Equal(t, []*types.Block{expectedGenesisBlock, expectedBlock}, result)
+}
+
+func TestRawFindByConsensusTimestamp(t *testing.T) {
+	// given
+	br, mock := setupRawRepository(t)
+	mock.ExpectQuery(selectByConsensusTimestampRaw).
+		WithArgs(dbRecordFile.ConsensusStart).
+		WillReturnRows(newRawRecordFileRows(dbRecordFile))
+
+	// when
+	result, err := br.FindByConsensusTimestamp(dbRecordFile.ConsensusStart)
+
+	// then
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Nil(t, err)
+	assert.Equal(t, expectedBlock, result)
+}
+
+func TestRawFindByHash(t *testing.T) {
+	// given
+	br, mock := setupRawRepository(t)
+	mock.ExpectQuery(selectByHashRaw).
+		WithArgs(dbRecordFile.Hash).
+		WillReturnRows(newRawRecordFileRows(dbRecordFile))
+
+	// when
+	result, err := br.FindByHash(dbRecordFile.Hash)
+
+	// then
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Nil(t, err)
+	assert.Equal(t, expectedBlock, result)
+}
+
+func TestRawFindByHashPrefix(t *testing.T) {
+	// given
+	br, mock := setupRawRepository(t)
+	br.hashPrefixMinLength = 4
+	mock.ExpectQuery(selectByHashPrefixRaw).
+		WithArgs("2002%").
+		WillReturnRows(newRawRecordFileRows(dbRecordFile))
+
+	// when
+	result, err := br.FindByHash("2002")
+
+	// then
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Nil(t, err)
+	assert.Equal(t, expectedBlock, result)
+}
+
+func TestRawRetrieveGenesis(t *testing.T) {
+	// given
+	gormDbClient, mock := mocks.DatabaseMock(t)
+	br := NewBlockRepository(gormDbClient, 0, true, 0)
+	mock.ExpectQuery(selectGenesisRaw).WillReturnRows(newRawRecordFileRows(dbGenesis))
+
+	// when
+	result, err := br.RetrieveGenesis()
+
+	// then
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Nil(t, err)
+	assert.Equal(t, expectedGenesisBlock, result)
+}
+
+func TestRawRetrieveLatest(t *testing.T) {
+	// given
+	br, mock := setupRawRepository(t)
+	mock.ExpectQuery(selectLatestRaw).WillReturnRows(newRawRecordFileRows(dbRecordFile))
+
+	// when
+	result, err := br.RetrieveLatest()
+
+	// then
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Nil(t, err)
+	assert.Equal(t, expectedBlock, result)
+}
+
+func newRawRecordFileRows(rfs ...*recordFile) *sqlmock.Rows {
+	rows := sqlmock.NewRows(rawRecordFileColumns)
+	for _, rf := range rfs {
+		rows.AddRow(rf.ConsensusStart, rf.ConsensusEnd, rf.Hash, rf.Index, rf.PrevHash)
+	}
+	return rows
+}
+
+func setupRawRepository(t *testing.T) (*blockRepository, sqlmock.Sqlmock) {
+	gormDbClient, mock := mocks.DatabaseMock(t)
+	br := NewBlockRepository(gormDbClient, time.Duration(0), true, 0)
+	br.genesisRecordFile = dbGenesis
+	br.genesisRecordFileIndex = dbGenesis.Index
+	return br, mock
+}
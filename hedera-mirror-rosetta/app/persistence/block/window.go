@@ -0,0 +1,236 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package block
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	hErrors "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"gorm.io/gorm"
+)
+
+// windowHashLength is the length of the hash of a window block, the start of the window as 16 hex digits
+const windowHashLength = 16
+
+// windowBlockRepository groups the transactions into blocks of fixed time windows instead of one block per record file,
+// so the block cadence stays the same when the record file cadence changes over the history of the network. Block i
+// covers the consensus timestamps [genesis + i*window, genesis + (i+1)*window), where genesis is the consensus start
+// of the genesis record file. The index of a block is derived from a timestamp alone, and the hash of a block is the
+// start of its window encoded as hex. A window is only a block once the record files cover it completely
+type windowBlockRepository struct {
+	*blockRepository
+	window int64
+}
+
+// NewWindowBlockRepository creates a repositories.BlockRepository whose blocks are fixed time windows of the window
+// duration. The latest record file is cached for latestCacheTtl, and rawQueries is as in NewBlockRepository
+func NewWindowBlockRepository(
+	dbClient *gorm.DB,
+	latestCacheTtl time.Duration,
+	rawQueries bool,
+	window time.Duration,
+) repositories.BlockRepository {
+	return &windowBlockRepository{
+		blockRepository: NewBlockRepository(dbClient, latestCacheTtl, rawQueries, 0),
+		window:          window.Nanoseconds(),
+	}
+}
+
+// FindByIndex retrieves a block by given Index
+func (wr *windowBlockRepository) FindByIndex(index int64) (*types.Block, *rTypes.Error) {
+	if index < 0 {
+		return nil, hErrors.ErrInvalidArgument
+	}
+
+	latestIndex, err := wr.getLatestIndex(false)
+	if err != nil {
+		return nil, err
+	}
+
+	if index > latestIndex {
+		return nil, hErrors.ErrBlockNotFound
+	}
+
+	return wr.toBlock(index), nil
+}
+
+// FindBetweenIndexes retrieves the blocks with index between start and end inclusively, ordered by index
+func (wr *windowBlockRepository) FindBetweenIndexes(start int64, end int64) ([]*types.Block, *rTypes.Error) {
+	if start < 0 || start > end {
+		return nil, hErrors.ErrInvalidArgument
+	}
+
+	latestIndex, err := wr.getLatestIndex(false)
+	if err != nil {
+		return nil, err
+	}
+
+	if end > latestIndex {
+		end = latestIndex
+	}
+
+	blocks := make([]*types.Block, 0)
+	for index := start; index <= end; index++ {
+		blocks = append(blocks, wr.toBlock(index))
+	}
+
+	return blocks, nil
+}
+
+// FindByConsensusTimestamp retrieves the block whose consensus timestamp range contains the timestamp
+func (wr *windowBlockRepository) FindByConsensusTimestamp(timestamp int64) (*types.Block, *rTypes.Error) {
+	if timestamp < 0 {
+		return nil, hErrors.ErrInvalidArgument
+	}
+
+	genesis, err := wr.getGenesisRecordFile()
+	if err != nil {
+		return nil, err
+	}
+
+	if timestamp < genesis.ConsensusStart {
+		return nil, hErrors.ErrBlockNotFound
+	}
+
+	return wr.FindByIndex((timestamp - genesis.ConsensusStart) / wr.window)
+}
+
+// FindByHash retrieves a block by a given Hash
+func (wr *windowBlockRepository) FindByHash(hash string) (*types.Block, *rTypes.Error) {
+	if hash == "" {
+		return nil, hErrors.ErrInvalidArgument
+	}
+
+	genesis, err := wr.getGenesisRecordFile()
+	if err != nil {
+		return nil, err
+	}
+
+	start, parseErr := strconv.ParseInt(hash, 16, 64)
+	if parseErr != nil || len(hash) != windowHashLength || start < genesis.ConsensusStart ||
+		(start-genesis.ConsensusStart)%wr.window != 0 {
+		return nil, hErrors.ErrBlockNotFound
+	}
+
+	return wr.FindByIndex((start - genesis.ConsensusStart) / wr.window)
+}
+
+// FindByIdentifier retrieves a block by Index && Hash
+func (wr *windowBlockRepository) FindByIdentifier(index int64, hash string) (*types.Block, *rTypes.Error) {
+	if index < 0 || hash == "" {
+		return nil, hErrors.ErrInvalidArgument
+	}
+
+	block, err := wr.FindByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.Index != index {
+		return nil, hErrors.ErrBlockNotFound
+	}
+
+	return block, nil
+}
+
+// RetrieveGenesis retrieves the genesis block
+func (wr *windowBlockRepository) RetrieveGenesis() (*types.Block, *rTypes.Error) {
+	if _, err := wr.getGenesisRecordFile(); err != nil {
+		return nil, err
+	}
+
+	return wr.toBlock(0), nil
+}
+
+// RetrieveLatest retrieves the latest block
+func (wr *windowBlockRepository) RetrieveLatest() (*types.Block, *rTypes.Error) {
+	latestIndex, err := wr.getLatestIndex(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return wr.toBlock(latestIndex), nil
+}
+
+// RefreshLatest retrieves the latest block from the database bypassing the cache, and caches it
+func (wr *windowBlockRepository) RefreshLatest() (*types.Block, *rTypes.Error) {
+	latestIndex, err := wr.getLatestIndex(true)
+	if err != nil {
+		return nil, err
+	}
+
+	return wr.toBlock(latestIndex), nil
+}
+
+// getLatestIndex returns the index of the latest window the record files cover completely. The latest record file is
+// queried bypassing the cache if refresh is true
+func (wr *windowBlockRepository) getLatestIndex(refresh bool) (int64, *rTypes.Error) {
+	genesis, err := wr.getGenesisRecordFile()
+	if err != nil {
+		return 0, err
+	}
+
+	var latest *recordFile
+	if refresh {
+		latest, err = wr.queryLatestRecordFile()
+	} else {
+		latest, err = wr.getLatestRecordFile()
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	latestIndex := (latest.ConsensusEnd-genesis.ConsensusStart+1)/wr.window - 1
+	if latestIndex < 0 {
+		return 0, hErrors.ErrBlockNotFound
+	}
+
+	return latestIndex, nil
+}
+
+// toBlock returns the block of the window with the index. The parent of the genesis block is itself
+func (wr *windowBlockRepository) toBlock(index int64) *types.Block {
+	start := wr.genesisRecordFile.ConsensusStart + index*wr.window
+	parentIndex := index - 1
+	parentStart := start - wr.window
+	if index == 0 {
+		parentIndex = 0
+		parentStart = start
+	}
+
+	return &types.Block{
+		Index:               index,
+		Hash:                toWindowHash(start),
+		ConsensusStartNanos: start,
+		ConsensusEndNanos:   start + wr.window - 1,
+		ParentIndex:         parentIndex,
+		ParentHash:          toWindowHash(parentStart),
+	}
+}
+
+func toWindowHash(start int64) string {
+	return fmt.Sprintf("%016x", start)
+}
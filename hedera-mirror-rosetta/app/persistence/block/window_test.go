@@ -0,0 +1,250 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package block
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+const (
+	windowGenesisStart = int64(1000)
+	testWindow         = int64(100)
+)
+
+type stubRecordFileQueries struct {
+	recordFileQueries
+	latest *recordFile
+}
+
+func (q *stubRecordFileQueries) findGenesis() (*recordFile, error) {
+	return &recordFile{ConsensusStart: windowGenesisStart, ConsensusEnd: windowGenesisStart + 49, Index: 5}, nil
+}
+
+func (q *stubRecordFileQueries) findLatest() (*recordFile, error) {
+	if q.latest == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return q.latest, nil
+}
+
+// setupWindowRepository creates a window block repository whose latest record file ends at latestEnd
+func setupWindowRepository(latestEnd int64) *windowBlockRepository {
+	repo := NewWindowBlockRepository(nil, 0, false, time.Duration(testWindow)).(*windowBlockRepository)
+	repo.queries = &stubRecordFileQueries{latest: &recordFile{ConsensusEnd: latestEnd, Index: 10}}
+	return repo
+}
+
+func windowBlock(index int64) *types.Block {
+	start := windowGenesisStart + index*testWindow
+	parentIndex := index - 1
+	parentHash := toWindowHash(start - testWindow)
+	if index == 0 {
+		parentIndex = 0
+		parentHash = toWindowHash(start)
+	}
+
+	return &types.Block{
+		Index:               index,
+		Hash:                toWindowHash(start),
+		ConsensusStartNanos: start,
+		ConsensusEndNanos:   start + testWindow - 1,
+		ParentIndex:         parentIndex,
+		ParentHash:          parentHash,
+	}
+}
+
+func TestWindowRetrieveGenesis(t *testing.T) {
+	// given
+	repo := setupWindowRepository(windowGenesisStart + 549)
+
+	// when
+	actual, err := repo.RetrieveGenesis()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, windowBlock(0), actual)
+	assert.Equal(t, "00000000000003e8", actual.Hash)
+}
+
+func TestWindowRetrieveLatest(t *testing.T) {
+	var tests = []struct {
+		name          string
+		latestEnd     int64
+		expectedIndex int64
+		expectedErr   bool
+	}{
+		{name: "WindowComplete", latestEnd: windowGenesisStart + 499, expectedIndex: 4},
+		{name: "WindowIncomplete", latestEnd: windowGenesisStart + 549, expectedIndex: 4},
+		{name: "FirstWindowIncomplete", latestEnd: windowGenesisStart + 49, expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			repo := setupWindowRepository(tt.latestEnd)
+
+			// when
+			latest, err := repo.RetrieveLatest()
+			refreshed, refreshErr := repo.RefreshLatest()
+
+			// then
+			if tt.expectedErr {
+				assert.Equal(t, errors.ErrBlockNotFound, err)
+				assert.Equal(t, errors.ErrBlockNotFound, refreshErr)
+				assert.Nil(t, latest)
+				assert.Nil(t, refreshed)
+			} else {
+				assert.Nil(t, err)
+				assert.Nil(t, refreshErr)
+				assert.Equal(t, windowBlock(tt.expectedIndex), latest)
+				assert.Equal(t, windowBlock(tt.expectedIndex), refreshed)
+			}
+		})
+	}
+}
+
+func TestWindowFindByIndex(t *testing.T) {
+	// given
+	repo := setupWindowRepository(windowGenesisStart + 549)
+
+	// when
+	actual, err := repo.FindByIndex(3)
+	notFound, notFoundErr := repo.FindByIndex(5)
+	invalid, invalidErr := repo.FindByIndex(-1)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, windowBlock(3), actual)
+	assert.Equal(t, errors.ErrBlockNotFound, notFoundErr)
+	assert.Nil(t, notFound)
+	assert.Equal(t, errors.ErrInvalidArgument, invalidErr)
+	assert.Nil(t, invalid)
+}
+
+func TestWindowFindBetweenIndexes(t *testing.T) {
+	// given
+	repo := setupWindowRepository(windowGenesisStart + 549)
+
+	// when
+	actual, err := repo.FindBetweenIndexes(2, 10)
+	empty, emptyErr := repo.FindBetweenIndexes(6, 10)
+	invalid, invalidErr := repo.FindBetweenIndexes(3, 2)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, []*types.Block{windowBlock(2), windowBlock(3), windowBlock(4)}, actual)
+	assert.Nil(t, emptyErr)
+	assert.Empty(t, empty)
+	assert.Equal(t, errors.ErrInvalidArgument, invalidErr)
+	assert.Nil(t, invalid)
+}
+
+func TestWindowFindByConsensusTimestamp(t *testing.T) {
+	var tests = []struct {
+		timestamp     int64
+		expectedIndex int64
+		notFound      bool
+	}{
+		{timestamp: windowGenesisStart, expectedIndex: 0},
+		{timestamp: windowGenesisStart + 99, expectedIndex: 0},
+		{timestamp: windowGenesisStart + 100, expectedIndex: 1},
+		{timestamp: windowGenesisStart + 499, expectedIndex: 4},
+		{timestamp: windowGenesisStart + 500, notFound: true},
+		{timestamp: windowGenesisStart - 1, notFound: true},
+	}
+
+	for _, tt := range tests {
+		// given
+		repo := setupWindowRepository(windowGenesisStart + 549)
+
+		// when
+		actual, err := repo.FindByConsensusTimestamp(tt.timestamp)
+
+		// then
+		if tt.notFound {
+			assert.Equal(t, errors.ErrBlockNotFound, err)
+			assert.Nil(t, actual)
+		} else {
+			assert.Nil(t, err)
+			assert.Equal(t, windowBlock(tt.expectedIndex), actual)
+		}
+	}
+}
+
+func TestWindowFindByHash(t *testing.T) {
+	var tests = []struct {
+		name     string
+		hash     string
+		expected *types.Block
+	}{
+		{name: "Genesis", hash: toWindowHash(windowGenesisStart), expected: windowBlock(0)},
+		{name: "Latest", hash: toWindowHash(windowGenesisStart + 4*testWindow), expected: windowBlock(4)},
+		{name: "NotCovered", hash: toWindowHash(windowGenesisStart + 5*testWindow)},
+		{name: "NotAligned", hash: toWindowHash(windowGenesisStart + 1)},
+		{name: "BeforeGenesis", hash: toWindowHash(windowGenesisStart - testWindow)},
+		{name: "WrongLength", hash: "3e8"},
+		{name: "NotHex", hash: "00000000000003eg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			repo := setupWindowRepository(windowGenesisStart + 549)
+
+			// when
+			actual, err := repo.FindByHash(tt.hash)
+
+			// then
+			if tt.expected != nil {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expected, actual)
+			} else {
+				assert.Equal(t, errors.ErrBlockNotFound, err)
+				assert.Nil(t, actual)
+			}
+		})
+	}
+}
+
+func TestWindowFindByIdentifier(t *testing.T) {
+	// given
+	repo := setupWindowRepository(windowGenesisStart + 549)
+	hash := toWindowHash(windowGenesisStart + 2*testWindow)
+
+	// when
+	actual, err := repo.FindByIdentifier(2, hash)
+	mismatch, mismatchErr := repo.FindByIdentifier(3, hash)
+	invalid, invalidErr := repo.FindByIdentifier(2, "")
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, windowBlock(2), actual)
+	assert.Equal(t, errors.ErrBlockNotFound, mismatchErr)
+	assert.Nil(t, mismatch)
+	assert.Equal(t, errors.ErrInvalidArgument, invalidErr)
+	assert.Nil(t, invalid)
+}
@@ -0,0 +1,77 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package exchangerate
+
+import (
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	hErrors "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/filedata"
+	"github.com/hashgraph/hedera-sdk-go/v2/proto"
+	log "github.com/sirupsen/logrus"
+	protobuf "google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+)
+
+const exchangeRateFileId int64 = 112
+
+// exchangeRateRepository struct that has connection to the Database
+type exchangeRateRepository struct {
+	dbClient *gorm.DB
+}
+
+// NewExchangeRateRepository creates an instance of a exchangeRateRepository struct
+func NewExchangeRateRepository(dbClient *gorm.DB) repositories.ExchangeRateRepository {
+	return &exchangeRateRepository{dbClient: dbClient}
+}
+
+// FindAt returns the exchange rate set effective at the consensus timestamp
+func (er *exchangeRateRepository) FindAt(consensusTimestamp int64) (*types.ExchangeRateSet, *rTypes.Error) {
+	content, timestamp, rErr := filedata.FindContent(er.dbClient, exchangeRateFileId, consensusTimestamp)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	if content == nil {
+		return nil, hErrors.ErrExchangeRateNotFound
+	}
+
+	exchangeRateSet := &proto.ExchangeRateSet{}
+	if err := protobuf.Unmarshal(content, exchangeRateSet); err != nil {
+		log.Errorf("Failed to unmarshal exchange rate file: %s", err)
+		return nil, hErrors.ErrInternalServerError
+	}
+
+	return &types.ExchangeRateSet{
+		ConsensusTimestamp: timestamp,
+		CurrentRate:        toExchangeRate(exchangeRateSet.GetCurrentRate()),
+		NextRate:           toExchangeRate(exchangeRateSet.GetNextRate()),
+	}, nil
+}
+
+func toExchangeRate(exchangeRate *proto.ExchangeRate) types.ExchangeRate {
+	return types.ExchangeRate{
+		CentEquiv:      exchangeRate.GetCentEquiv(),
+		ExpirationTime: exchangeRate.GetExpirationTime().GetSeconds(),
+		HbarEquiv:      exchangeRate.GetHbarEquiv(),
+	}
+}
@@ -0,0 +1,145 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package exchangerate
+
+import (
+	"testing"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/filedata"
+	dbTypes "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/db"
+	"github.com/hashgraph/hedera-sdk-go/v2/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// run the suite
+func TestExchangeRateRepositorySuite(t *testing.T) {
+	suite.Run(t, new(exchangeRateRepositorySuite))
+}
+
+type exchangeRateRepositorySuite struct {
+	suite.Suite
+	dbResource db.DbResource
+}
+
+func (suite *exchangeRateRepositorySuite) SetupSuite() {
+	suite.dbResource = db.SetupDb()
+}
+
+func (suite *exchangeRateRepositorySuite) TearDownSuite() {
+	db.TeardownDb(suite.dbResource)
+}
+
+func (suite *exchangeRateRepositorySuite) SetupTest() {
+	db.CleanupDb(suite.dbResource.GetDb())
+}
+
+func (suite *exchangeRateRepositorySuite) TestFindAt() {
+	// given
+	first := exchangeRateFile(12, 15)
+	second := exchangeRateFile(20, 25)
+	suite.createFileData(100, filedata.TransactionTypeFileCreate, first)
+	suite.createFileData(200, filedata.TransactionTypeFileUpdate, second[:5])
+	suite.createFileData(201, filedata.TransactionTypeFileAppend, second[5:])
+	repo := NewExchangeRateRepository(suite.dbResource.GetGormDb())
+
+	var tests = []struct {
+		name               string
+		consensusTimestamp int64
+		expected           *types.ExchangeRateSet
+	}{
+		{
+			name:               "FileCreate",
+			consensusTimestamp: 150,
+			expected:           expectedExchangeRateSet(100, 12, 15),
+		},
+		{
+			name:               "FileUpdateWithAppend",
+			consensusTimestamp: 300,
+			expected:           expectedExchangeRateSet(201, 20, 25),
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := repo.FindAt(tt.consensusTimestamp)
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func (suite *exchangeRateRepositorySuite) TestFindAtNotFound() {
+	// given
+	suite.createFileData(100, filedata.TransactionTypeFileCreate, exchangeRateFile(12, 15))
+	repo := NewExchangeRateRepository(suite.dbResource.GetGormDb())
+
+	// when
+	actual, err := repo.FindAt(99)
+
+	// then
+	assert.Equal(suite.T(), errors.ErrExchangeRateNotFound, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *exchangeRateRepositorySuite) createFileData(
+	consensusTimestamp int64,
+	transactionType int16,
+	data []byte,
+) {
+	suite.dbResource.GetGormDb().Create(&dbTypes.FileData{
+		ConsensusTimestamp: consensusTimestamp,
+		EntityId:           exchangeRateFileId,
+		FileData:           data,
+		TransactionType:    transactionType,
+	})
+}
+
+func exchangeRateFile(currentCentEquiv, nextCentEquiv int32) []byte {
+	data, _ := protobuf.Marshal(&proto.ExchangeRateSet{
+		CurrentRate: &proto.ExchangeRate{
+			HbarEquiv:      30000,
+			CentEquiv:      currentCentEquiv,
+			ExpirationTime: &proto.TimestampSeconds{Seconds: 1000},
+		},
+		NextRate: &proto.ExchangeRate{
+			HbarEquiv:      30000,
+			CentEquiv:      nextCentEquiv,
+			ExpirationTime: &proto.TimestampSeconds{Seconds: 2000},
+		},
+	})
+	return data
+}
+
+func expectedExchangeRateSet(consensusTimestamp int64, currentCentEquiv, nextCentEquiv int32) *types.ExchangeRateSet {
+	return &types.ExchangeRateSet{
+		ConsensusTimestamp: consensusTimestamp,
+		CurrentRate:        types.ExchangeRate{CentEquiv: currentCentEquiv, ExpirationTime: 1000, HbarEquiv: 30000},
+		NextRate:           types.ExchangeRate{CentEquiv: nextCentEquiv, ExpirationTime: 2000, HbarEquiv: 30000},
+	}
+}
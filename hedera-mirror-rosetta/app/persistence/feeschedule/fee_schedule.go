@@ -0,0 +1,116 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package feeschedule
+
+import (
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	hErrors "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/filedata"
+	"github.com/hashgraph/hedera-sdk-go/v2/proto"
+	log "github.com/sirupsen/logrus"
+	protobuf "google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+)
+
+const feeScheduleFileId int64 = 111
+
+// feeScheduleRepository struct that has connection to the Database
+type feeScheduleRepository struct {
+	dbClient *gorm.DB
+}
+
+// NewFeeScheduleRepository creates an instance of a feeScheduleRepository struct
+func NewFeeScheduleRepository(dbClient *gorm.DB) repositories.FeeScheduleRepository {
+	return &feeScheduleRepository{dbClient: dbClient}
+}
+
+// FindAt returns the fee schedule set effective at the consensus timestamp
+func (fr *feeScheduleRepository) FindAt(consensusTimestamp int64) (*types.FeeScheduleSet, *rTypes.Error) {
+	content, timestamp, rErr := filedata.FindContent(fr.dbClient, feeScheduleFileId, consensusTimestamp)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	if content == nil {
+		return nil, hErrors.ErrFeeScheduleNotFound
+	}
+
+	feeScheduleSet := &proto.CurrentAndNextFeeSchedule{}
+	if err := protobuf.Unmarshal(content, feeScheduleSet); err != nil {
+		log.Errorf("Failed to unmarshal fee schedule file: %s", err)
+		return nil, hErrors.ErrInternalServerError
+	}
+
+	return &types.FeeScheduleSet{
+		ConsensusTimestamp: timestamp,
+		CurrentSchedule:    toFeeSchedule(feeScheduleSet.GetCurrentFeeSchedule()),
+		NextSchedule:       toFeeSchedule(feeScheduleSet.GetNextFeeSchedule()),
+	}, nil
+}
+
+func toFeeSchedule(feeSchedule *proto.FeeSchedule) types.FeeSchedule {
+	transactionFeeSchedules := make([]types.TransactionFeeSchedule, 0, len(feeSchedule.GetTransactionFeeSchedule()))
+	for _, transactionFeeSchedule := range feeSchedule.GetTransactionFeeSchedule() {
+		feeDataList := transactionFeeSchedule.GetFees()
+		if len(feeDataList) == 0 && transactionFeeSchedule.GetFeeData() != nil {
+			// fee schedules written before the sub types were introduced only have the deprecated single fee data
+			feeDataList = []*proto.FeeData{transactionFeeSchedule.GetFeeData()}
+		}
+
+		fees := make([]types.FeeData, 0, len(feeDataList))
+		for _, feeData := range feeDataList {
+			fees = append(fees, types.FeeData{
+				Network: toFeeComponents(feeData.GetNetworkdata()),
+				Node:    toFeeComponents(feeData.GetNodedata()),
+				Service: toFeeComponents(feeData.GetServicedata()),
+				SubType: feeData.GetSubType().String(),
+			})
+		}
+
+		transactionFeeSchedules = append(transactionFeeSchedules, types.TransactionFeeSchedule{
+			Fees:          fees,
+			Functionality: transactionFeeSchedule.GetHederaFunctionality().String(),
+		})
+	}
+
+	return types.FeeSchedule{
+		ExpiryTime:              feeSchedule.GetExpiryTime().GetSeconds(),
+		TransactionFeeSchedules: transactionFeeSchedules,
+	}
+}
+
+func toFeeComponents(feeComponents *proto.FeeComponents) types.FeeComponents {
+	return types.FeeComponents{
+		Bpr:      feeComponents.GetBpr(),
+		Bpt:      feeComponents.GetBpt(),
+		Constant: feeComponents.GetConstant(),
+		Gas:      feeComponents.GetGas(),
+		Max:      feeComponents.GetMax(),
+		Min:      feeComponents.GetMin(),
+		Rbh:      feeComponents.GetRbh(),
+		Sbh:      feeComponents.GetSbh(),
+		Sbpr:     feeComponents.GetSbpr(),
+		Tv:       feeComponents.GetTv(),
+		Vpt:      feeComponents.GetVpt(),
+	}
+}
@@ -0,0 +1,171 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package feeschedule
+
+import (
+	"testing"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/filedata"
+	dbTypes "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/db"
+	"github.com/hashgraph/hedera-sdk-go/v2/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// run the suite
+func TestFeeScheduleRepositorySuite(t *testing.T) {
+	suite.Run(t, new(feeScheduleRepositorySuite))
+}
+
+type feeScheduleRepositorySuite struct {
+	suite.Suite
+	dbResource db.DbResource
+}
+
+func (suite *feeScheduleRepositorySuite) SetupSuite() {
+	suite.dbResource = db.SetupDb()
+}
+
+func (suite *feeScheduleRepositorySuite) TearDownSuite() {
+	db.TeardownDb(suite.dbResource)
+}
+
+func (suite *feeScheduleRepositorySuite) SetupTest() {
+	db.CleanupDb(suite.dbResource.GetDb())
+}
+
+func (suite *feeScheduleRepositorySuite) TestFindAt() {
+	// given
+	first := feeScheduleFile(100000, false)
+	second := feeScheduleFile(200000, true)
+	suite.createFileData(100, filedata.TransactionTypeFileCreate, first)
+	suite.createFileData(200, filedata.TransactionTypeFileUpdate, second[:5])
+	suite.createFileData(201, filedata.TransactionTypeFileAppend, second[5:])
+	repo := NewFeeScheduleRepository(suite.dbResource.GetGormDb())
+
+	var tests = []struct {
+		name               string
+		consensusTimestamp int64
+		expected           *types.FeeScheduleSet
+	}{
+		{
+			name:               "FileCreate",
+			consensusTimestamp: 150,
+			expected:           expectedFeeScheduleSet(100, 100000),
+		},
+		{
+			name:               "FileUpdateWithAppend",
+			consensusTimestamp: 300,
+			expected:           expectedFeeScheduleSet(201, 200000),
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := repo.FindAt(tt.consensusTimestamp)
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func (suite *feeScheduleRepositorySuite) TestFindAtNotFound() {
+	// given
+	suite.createFileData(100, filedata.TransactionTypeFileCreate, feeScheduleFile(100000, true))
+	repo := NewFeeScheduleRepository(suite.dbResource.GetGormDb())
+
+	// when
+	actual, err := repo.FindAt(99)
+
+	// then
+	assert.Equal(suite.T(), errors.ErrFeeScheduleNotFound, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *feeScheduleRepositorySuite) createFileData(
+	consensusTimestamp int64,
+	transactionType int16,
+	data []byte,
+) {
+	suite.dbResource.GetGormDb().Create(&dbTypes.FileData{
+		ConsensusTimestamp: consensusTimestamp,
+		EntityId:           feeScheduleFileId,
+		FileData:           data,
+		TransactionType:    transactionType,
+	})
+}
+
+// feeScheduleFile returns the serialized fee schedule with a crypto transfer price of the constant, in the fees list or
+// the deprecated fee data
+func feeScheduleFile(constant int64, subTypes bool) []byte {
+	feeData := &proto.FeeData{
+		Nodedata:    &proto.FeeComponents{Constant: constant, Max: 1000000000, Vpt: 10},
+		Networkdata: &proto.FeeComponents{Constant: constant, Max: 1000000000},
+		Servicedata: &proto.FeeComponents{Constant: constant, Max: 1000000000},
+	}
+	transactionFeeSchedule := &proto.TransactionFeeSchedule{HederaFunctionality: proto.HederaFunctionality_CryptoTransfer}
+	if subTypes {
+		transactionFeeSchedule.Fees = []*proto.FeeData{feeData}
+	} else {
+		transactionFeeSchedule.FeeData = feeData
+	}
+
+	data, _ := protobuf.Marshal(&proto.CurrentAndNextFeeSchedule{
+		CurrentFeeSchedule: &proto.FeeSchedule{
+			TransactionFeeSchedule: []*proto.TransactionFeeSchedule{transactionFeeSchedule},
+			ExpiryTime:             &proto.TimestampSeconds{Seconds: 1000},
+		},
+		NextFeeSchedule: &proto.FeeSchedule{
+			TransactionFeeSchedule: []*proto.TransactionFeeSchedule{transactionFeeSchedule},
+			ExpiryTime:             &proto.TimestampSeconds{Seconds: 2000},
+		},
+	})
+	return data
+}
+
+func expectedFeeScheduleSet(consensusTimestamp int64, constant int64) *types.FeeScheduleSet {
+	transactionFeeSchedules := []types.TransactionFeeSchedule{
+		{
+			Fees: []types.FeeData{
+				{
+					Network: types.FeeComponents{Constant: constant, Max: 1000000000},
+					Node:    types.FeeComponents{Constant: constant, Max: 1000000000, Vpt: 10},
+					Service: types.FeeComponents{Constant: constant, Max: 1000000000},
+					SubType: "DEFAULT",
+				},
+			},
+			Functionality: "CryptoTransfer",
+		},
+	}
+
+	return &types.FeeScheduleSet{
+		ConsensusTimestamp: consensusTimestamp,
+		CurrentSchedule:    types.FeeSchedule{ExpiryTime: 1000, TransactionFeeSchedules: transactionFeeSchedules},
+		NextSchedule:       types.FeeSchedule{ExpiryTime: 2000, TransactionFeeSchedules: transactionFeeSchedules},
+	}
+}
@@ -0,0 +1,92 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package filedata
+
+import (
+	"database/sql"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	hErrors "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// TransactionType* are the protobuf ids of the transaction types writing a file
+const (
+	TransactionTypeFileAppend int16 = 16
+	TransactionTypeFileCreate int16 = 17
+	TransactionTypeFileUpdate int16 = 19
+)
+
+const (
+	// selectFileData selects the file data of the latest full content of the file at the timestamp, that is the last
+	// file create / update and the file appends after it, if any
+	selectFileData = `with latest as (
+                        select consensus_timestamp
+                        from file_data
+                        where entity_id = @entity_id and
+                          transaction_type in (@file_create, @file_update) and
+                          consensus_timestamp <= @consensus_timestamp
+                        order by consensus_timestamp desc
+                        limit 1
+                      )
+                      select fd.consensus_timestamp, fd.file_data
+                      from file_data fd, latest
+                      where fd.entity_id = @entity_id and
+                        fd.consensus_timestamp >= latest.consensus_timestamp and
+                        fd.consensus_timestamp <= @consensus_timestamp and
+                        (fd.consensus_timestamp = latest.consensus_timestamp or
+                          fd.transaction_type = @file_append)
+                      order by fd.consensus_timestamp`
+)
+
+type fileData struct {
+	ConsensusTimestamp int64
+	FileData           []byte
+}
+
+// FindContent returns the full content of the file with the encoded fileId at the consensus timestamp, and the
+// consensus timestamp of its last create, update, or append. The content is nil if the file doesn't exist yet
+func FindContent(dbClient *gorm.DB, fileId int64, consensusTimestamp int64) ([]byte, int64, *rTypes.Error) {
+	var fileDataList []fileData
+	if err := dbClient.Raw(
+		selectFileData,
+		sql.Named("consensus_timestamp", consensusTimestamp),
+		sql.Named("entity_id", fileId),
+		sql.Named("file_append", TransactionTypeFileAppend),
+		sql.Named("file_create", TransactionTypeFileCreate),
+		sql.Named("file_update", TransactionTypeFileUpdate),
+	).Scan(&fileDataList).Error; err != nil {
+		log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, err)
+		return nil, 0, hErrors.ErrDatabaseError
+	}
+
+	if len(fileDataList) == 0 {
+		return nil, 0, nil
+	}
+
+	content := make([]byte, 0)
+	for _, data := range fileDataList {
+		content = append(content, data.FileData...)
+	}
+
+	return content, fileDataList[len(fileDataList)-1].ConsensusTimestamp, nil
+}
@@ -0,0 +1,535 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package memory
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"strings"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	hErrors "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	hexUtils "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/hex"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/maphelper"
+	"github.com/hashgraph/hedera-sdk-go/v2/proto"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+const (
+	addressBookFileId      = 102
+	nodeEndpointIp         = "127.0.0.1"
+	nodeEndpointPort       = 50211
+	unknownTransactionType = "UNKNOWN"
+)
+
+type accountRepository struct {
+	store *Store
+}
+
+// NewAccountRepository creates the account repository of the store
+func NewAccountRepository(store *Store) repositories.AccountRepository {
+	return &accountRepository{store: store}
+}
+
+// FindByPublicKey returns the demo accounts whose key is the ed25519 public key, ordered by account id
+func (ar *accountRepository) FindByPublicKey(publicKey []byte) ([]types.Account, *rTypes.Error) {
+	if len(publicKey) == 0 {
+		return nil, hErrors.ErrInvalidPublicKey
+	}
+
+	accounts := make([]types.Account, 0)
+	for _, account := range ar.store.accounts {
+		if bytes.Equal(account.PrivateKey.Public().(ed25519.PublicKey), publicKey) {
+			accounts = append(accounts, account.Account)
+		}
+	}
+
+	return accounts, nil
+}
+
+// FindExpiry returns an empty expiry for the treasury and the demo accounts since they never expire nor get deleted,
+// or nil if the account isn't in the demo data, so the construction existence check passes for the demo accounts
+func (ar *accountRepository) FindExpiry(addressStr string) (*types.AccountExpiry, *rTypes.Error) {
+	account, rErr := types.AccountFromString(addressStr)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	if !ar.store.hasAccount(account.EncodedId) {
+		return nil, nil
+	}
+
+	return &types.AccountExpiry{}, nil
+}
+
+// FindKey returns the serialized ed25519 key of the demo account, or nil if it isn't a demo account
+func (ar *accountRepository) FindKey(addressStr string) ([]byte, *rTypes.Error) {
+	account, rErr := types.AccountFromString(addressStr)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	for _, demoAccount := range ar.store.accounts {
+		if demoAccount.Account.EncodedId == account.EncodedId {
+			publicKey := demoAccount.PrivateKey.Public().(ed25519.PublicKey)
+			key, err := protobuf.Marshal(&proto.Key{Key: &proto.Key_Ed25519{Ed25519: publicKey}})
+			if err != nil {
+				return nil, hErrors.ErrInternalServerError
+			}
+			return key, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// RetrieveBalanceAtBlock returns the hbar balance of the account at the block's consensus end, the demo data has no
+// token balances
+func (ar *accountRepository) RetrieveBalanceAtBlock(
+	addressStr string,
+	consensusEnd int64,
+	_ []int64,
+	_ int64,
+	_ int,
+) ([]types.Amount, *rTypes.Error) {
+	account, err := types.AccountFromString(addressStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return []types.Amount{&types.HbarAmount{Value: ar.store.getBalance(account.EncodedId, consensusEnd)}}, nil
+}
+
+type addressBookRepository struct {
+	store *Store
+}
+
+// NewAddressBookRepository creates the address book repository of the store
+func NewAddressBookRepository(store *Store) repositories.AddressBookRepository {
+	return &addressBookRepository{store: store}
+}
+
+// FindLatest returns the address book with the single demo node
+func (abr *addressBookRepository) FindLatest() (*types.AddressBook, *rTypes.Error) {
+	fileId, _ := entityid.Decode(addressBookFileId)
+	return &types.AddressBook{
+		ConsensusTimestamp: abr.store.blocks[0].ConsensusStartNanos,
+		FileId:             fileId,
+		Nodes: []*types.AddressBookNode{{
+			Description:      "demo node",
+			NodeAccountId:    newAccount(nodeAccount),
+			ServiceEndpoints: []types.ServiceEndpoint{{IpAddressV4: nodeEndpointIp, Port: nodeEndpointPort}},
+		}},
+	}, nil
+}
+
+type addressBookEntryRepository struct{}
+
+// NewAddressBookEntryRepository creates the address book entry repository of the store
+func NewAddressBookEntryRepository(*Store) repositories.AddressBookEntryRepository {
+	return &addressBookEntryRepository{}
+}
+
+// Entries returns the entry of the single demo node
+func (aber *addressBookEntryRepository) Entries() (*types.AddressBookEntries, *rTypes.Error) {
+	return &types.AddressBookEntries{
+		Entries: []*types.AddressBookEntry{{
+			PeerId:   newAccount(nodeAccount),
+			Metadata: map[string]interface{}{"ip": nodeEndpointIp, "port": int32(nodeEndpointPort)},
+		}},
+	}, nil
+}
+
+type blockRepository struct {
+	store *Store
+}
+
+// NewBlockRepository creates the block repository of the store
+func NewBlockRepository(store *Store) repositories.BlockRepository {
+	return &blockRepository{store: store}
+}
+
+// FindBetweenIndexes returns the blocks with index between start and end inclusively, ordered by index
+func (br *blockRepository) FindBetweenIndexes(start int64, end int64) ([]*types.Block, *rTypes.Error) {
+	if start < 0 || start > end {
+		return nil, hErrors.ErrInvalidArgument
+	}
+
+	blocks := make([]*types.Block, 0)
+	for index := start; index <= end && index < int64(len(br.store.blocks)); index++ {
+		blocks = append(blocks, copyBlock(br.store.blocks[index]))
+	}
+
+	return blocks, nil
+}
+
+// FindByConsensusTimestamp returns the block whose consensus timestamp range contains the timestamp
+func (br *blockRepository) FindByConsensusTimestamp(timestamp int64) (*types.Block, *rTypes.Error) {
+	if timestamp < 0 {
+		return nil, hErrors.ErrInvalidArgument
+	}
+
+	for _, block := range br.store.blocks {
+		if block.ConsensusStartNanos <= timestamp && timestamp <= block.ConsensusEndNanos {
+			return copyBlock(block), nil
+		}
+	}
+
+	return nil, hErrors.ErrBlockNotFound
+}
+
+// FindByIndex returns the block with the index
+func (br *blockRepository) FindByIndex(index int64) (*types.Block, *rTypes.Error) {
+	if index < 0 {
+		return nil, hErrors.ErrInvalidArgument
+	}
+
+	if index >= int64(len(br.store.blocks)) {
+		return nil, hErrors.ErrBlockNotFound
+	}
+
+	return copyBlock(br.store.blocks[index]), nil
+}
+
+// FindByHash returns the block with the hash
+func (br *blockRepository) FindByHash(hash string) (*types.Block, *rTypes.Error) {
+	if hash == "" {
+		return nil, hErrors.ErrInvalidArgument
+	}
+
+	for _, block := range br.store.blocks {
+		if block.Hash == hash {
+			return copyBlock(block), nil
+		}
+	}
+
+	return nil, hErrors.ErrBlockNotFound
+}
+
+// FindByIdentifier returns the block with the index and the hash
+func (br *blockRepository) FindByIdentifier(index int64, hash string) (*types.Block, *rTypes.Error) {
+	if index < 0 || hash == "" {
+		return nil, hErrors.ErrInvalidArgument
+	}
+
+	block, err := br.FindByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.Index != index {
+		return nil, hErrors.ErrBlockNotFound
+	}
+
+	return block, nil
+}
+
+// RefreshLatest returns the latest block, the demo data doesn't change so there is nothing to refresh
+func (br *blockRepository) RefreshLatest() (*types.Block, *rTypes.Error) {
+	return br.RetrieveLatest()
+}
+
+// RetrieveGenesis returns the genesis block
+func (br *blockRepository) RetrieveGenesis() (*types.Block, *rTypes.Error) {
+	return copyBlock(br.store.blocks[0]), nil
+}
+
+// RetrieveLatest returns the latest block
+func (br *blockRepository) RetrieveLatest() (*types.Block, *rTypes.Error) {
+	return copyBlock(br.store.blocks[len(br.store.blocks)-1]), nil
+}
+
+type exchangeRateRepository struct {
+	store *Store
+}
+
+// NewExchangeRateRepository creates the exchange rate repository of the store
+func NewExchangeRateRepository(store *Store) repositories.ExchangeRateRepository {
+	return &exchangeRateRepository{store: store}
+}
+
+// FindAt returns the fixed demo exchange rate of 12 cents per hbar, which is in effect from the genesis block on
+func (er *exchangeRateRepository) FindAt(consensusTimestamp int64) (*types.ExchangeRateSet, *rTypes.Error) {
+	genesisTimestamp := er.store.blocks[0].ConsensusStartNanos
+	if consensusTimestamp < genesisTimestamp {
+		return nil, hErrors.ErrExchangeRateNotFound
+	}
+
+	// the rate never expires in practice, the expiration time is in seconds
+	rate := types.ExchangeRate{CentEquiv: 12, ExpirationTime: 4102444800, HbarEquiv: 1}
+	return &types.ExchangeRateSet{ConsensusTimestamp: genesisTimestamp, CurrentRate: rate, NextRate: rate}, nil
+}
+
+type feeScheduleRepository struct{}
+
+// NewFeeScheduleRepository creates the fee schedule repository of the store
+func NewFeeScheduleRepository(*Store) repositories.FeeScheduleRepository {
+	return &feeScheduleRepository{}
+}
+
+// FindAt returns ErrFeeScheduleNotFound since the demo data has no fee schedule
+func (fr *feeScheduleRepository) FindAt(int64) (*types.FeeScheduleSet, *rTypes.Error) {
+	return nil, hErrors.ErrFeeScheduleNotFound
+}
+
+type networkVersionRepository struct{}
+
+// NewNetworkVersionRepository creates the network version repository of the store
+func NewNetworkVersionRepository(*Store) repositories.NetworkVersionRepository {
+	return &networkVersionRepository{}
+}
+
+// Find returns the versions the demo data is modeled after
+func (nvr *networkVersionRepository) Find() (*types.NetworkVersion, *rTypes.Error) {
+	return &types.NetworkVersion{HapiVersion: "0.21.0", MirrorSchemaVersion: "1.43.2"}, nil
+}
+
+type nftRepository struct{}
+
+// NewNftRepository creates the nft repository of the store
+func NewNftRepository(*Store) repositories.NftRepository {
+	return &nftRepository{}
+}
+
+// FindTransfers returns ErrNftNotFound since the demo data has no nfts
+func (nr *nftRepository) FindTransfers(tokenIdStr string, serialNumber int64) ([]*types.NftTransfer, *rTypes.Error) {
+	if _, err := entityid.FromString(tokenIdStr); err != nil {
+		return nil, hErrors.ErrInvalidToken
+	}
+
+	if serialNumber <= 0 {
+		return nil, hErrors.ErrInvalidArgument
+	}
+
+	return nil, hErrors.ErrNftNotFound
+}
+
+type scheduleRepository struct{}
+
+// NewScheduleRepository creates the schedule repository of the store
+func NewScheduleRepository(*Store) repositories.ScheduleRepository {
+	return &scheduleRepository{}
+}
+
+// FindById returns ErrScheduleNotFound since the demo data has no schedules
+func (sr *scheduleRepository) FindById(scheduleIdStr string) (*types.Schedule, *rTypes.Error) {
+	if _, err := entityid.FromString(scheduleIdStr); err != nil {
+		return nil, hErrors.ErrInvalidSchedule
+	}
+
+	return nil, hErrors.ErrScheduleNotFound
+}
+
+type tokenRepository struct{}
+
+// NewTokenRepository creates the token repository of the store
+func NewTokenRepository(*Store) repositories.TokenRepository {
+	return &tokenRepository{}
+}
+
+// Find returns ErrTokenNotFound since the demo data has no tokens
+func (tr *tokenRepository) Find(tokenIdStr string) (*types.Token, *rTypes.Error) {
+	if _, err := entityid.FromString(tokenIdStr); err != nil {
+		return nil, hErrors.ErrInvalidToken
+	}
+
+	return nil, hErrors.ErrTokenNotFound
+}
+
+// FindAt returns ErrTokenNotFound since the demo data has no tokens
+func (tr *tokenRepository) FindAt(tokenIdStr string, _ int64) (*types.Token, *rTypes.Error) {
+	return tr.Find(tokenIdStr)
+}
+
+// FindInfoAt returns ErrTokenNotFound since the demo data has no tokens
+func (tr *tokenRepository) FindInfoAt(tokenIdStr string, _ int64) (*types.TokenInfo, *rTypes.Error) {
+	_, err := tr.Find(tokenIdStr)
+	return nil, err
+}
+
+type tokenAssociationRepository struct{}
+
+// NewTokenAssociationRepository creates the token association repository of the store
+func NewTokenAssociationRepository(*Store) repositories.TokenAssociationRepository {
+	return &tokenAssociationRepository{}
+}
+
+// Find returns ErrTokenAssociationNotFound since the demo data has no tokens
+func (tr *tokenAssociationRepository) Find(accountIdStr string, tokenIdStr string) (
+	*types.TokenAssociation,
+	*rTypes.Error,
+) {
+	if _, err := entityid.FromString(accountIdStr); err != nil {
+		return nil, hErrors.ErrInvalidAccount
+	}
+
+	if _, err := entityid.FromString(tokenIdStr); err != nil {
+		return nil, hErrors.ErrInvalidToken
+	}
+
+	return nil, hErrors.ErrTokenAssociationNotFound
+}
+
+// FindByAccount returns no associations since the demo data has no tokens
+func (tr *tokenAssociationRepository) FindByAccount(accountIdStr string, _ int64, _ int) (
+	[]*types.TokenAssociation,
+	*rTypes.Error,
+) {
+	if _, err := entityid.FromString(accountIdStr); err != nil {
+		return nil, hErrors.ErrInvalidAccount
+	}
+
+	return []*types.TokenAssociation{}, nil
+}
+
+type transactionRepository struct {
+	store            *Store
+	timestampStrings bool
+}
+
+// NewTransactionRepository creates the transaction repository of the store, timestampStrings controls if the
+// timestamps in the transaction metadata have their RFC3339 strings
+func NewTransactionRepository(store *Store, timestampStrings bool) repositories.TransactionRepository {
+	return &transactionRepository{store: store, timestampStrings: timestampStrings}
+}
+
+// FindByHashInBlock returns the transaction with the hash in the consensus timestamp range
+func (tr *transactionRepository) FindByHashInBlock(
+	identifier string,
+	consensusStart int64,
+	consensusEnd int64,
+) (*types.Transaction, *rTypes.Error) {
+	hash := strings.ToLower(hexUtils.SafeRemoveHexPrefix(identifier))
+	if _, err := hex.DecodeString(hash); err != nil {
+		return nil, hErrors.ErrInvalidTransactionIdentifier
+	}
+
+	for _, transaction := range tr.store.transactions {
+		if hexUtils.SafeRemoveHexPrefix(transaction.hash) == hash &&
+			consensusStart <= transaction.consensusTimestamp && transaction.consensusTimestamp <= consensusEnd {
+			return transaction.toTransaction(tr.timestampStrings), nil
+		}
+	}
+
+	return nil, hErrors.ErrTransactionNotFound
+}
+
+// FindBetween returns the transactions in the consensus timestamp range, ordered by consensus timestamp
+func (tr *transactionRepository) FindBetween(start int64, end int64) ([]*types.Transaction, *rTypes.Error) {
+	if start > end {
+		return nil, hErrors.ErrStartMustNotBeAfterEnd
+	}
+
+	transactions := make([]*types.Transaction, 0)
+	for _, transaction := range tr.store.transactions {
+		if start <= transaction.consensusTimestamp && transaction.consensusTimestamp <= end {
+			transactions = append(transactions, transaction.toTransaction(tr.timestampStrings))
+		}
+	}
+
+	return transactions, nil
+}
+
+// FindBetweenWithMaxOperations returns the transactions in the consensus timestamp range as long as their operations
+// add up to at most maxOperations, and the hashes of the rest
+func (tr *transactionRepository) FindBetweenWithMaxOperations(start int64, end int64, maxOperations int) (
+	[]*types.Transaction,
+	[]string,
+	*rTypes.Error,
+) {
+	transactions, err := tr.FindBetween(start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transactions, otherHashes := types.SplitTransactions(transactions, maxOperations)
+	return transactions, otherHashes, nil
+}
+
+// FindByTransactionId returns the transactions with the transaction id, the demo transactions are never scheduled
+func (tr *transactionRepository) FindByTransactionId(transactionId types.TransactionId) (
+	[]*types.Transaction,
+	*rTypes.Error,
+) {
+	if transactionId.Nonce != 0 || transactionId.Scheduled {
+		return nil, hErrors.ErrTransactionNotFound
+	}
+
+	transactions := make([]*types.Transaction, 0)
+	for _, transaction := range tr.store.transactions {
+		id := transaction.transactionId
+		if id.Payer.EncodedId == transactionId.Payer.EncodedId && id.ValidStartNs == transactionId.ValidStartNs {
+			result := transaction.toTransaction(tr.timestampStrings)
+			result.Metadata["scheduled"] = false
+			transactions = append(transactions, result)
+		}
+	}
+
+	if len(transactions) == 0 {
+		return nil, hErrors.ErrTransactionNotFound
+	}
+
+	return transactions, nil
+}
+
+// FindRawByHash returns the raw transaction with the hash. The demo transactions are never submitted, so there are no
+// transaction bytes
+func (tr *transactionRepository) FindRawByHash(hash string) ([]*types.RawTransaction, *rTypes.Error) {
+	hash = strings.ToLower(hexUtils.SafeRemoveHexPrefix(hash))
+	if _, err := hex.DecodeString(hash); err != nil {
+		return nil, hErrors.ErrInvalidTransactionIdentifier
+	}
+
+	for _, transaction := range tr.store.transactions {
+		if hexUtils.SafeRemoveHexPrefix(transaction.hash) == hash {
+			return []*types.RawTransaction{
+				{ConsensusTimestamp: transaction.consensusTimestamp, Result: transaction.record.Result},
+			}, nil
+		}
+	}
+
+	return nil, hErrors.ErrTransactionNotFound
+}
+
+// Results returns the transaction results of the demo data
+func (tr *transactionRepository) Results() (map[int]string, *rTypes.Error) {
+	return transactionResults, nil
+}
+
+// Types returns the transaction types of the demo data
+func (tr *transactionRepository) Types() (map[int]string, *rTypes.Error) {
+	return transactionTypes, nil
+}
+
+// TypesAsArray returns the transaction type names, including the type of unknown transactions
+func (tr *transactionRepository) TypesAsArray() ([]string, *rTypes.Error) {
+	return append(maphelper.GetStringValuesFromIntStringMap(transactionTypes), unknownTransactionType), nil
+}
+
+// copyBlock returns a copy of the block, so the caller can set its transactions
+func copyBlock(block *types.Block) *types.Block {
+	copied := *block
+	return &copied
+}
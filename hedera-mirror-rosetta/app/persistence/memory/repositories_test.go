@@ -0,0 +1,316 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package memory
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	hErrors "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-sdk-go/v2/proto"
+	"github.com/stretchr/testify/assert"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+func TestAccountRepositoryFindByPublicKey(t *testing.T) {
+	// given
+	store := NewDemoStore(1)
+	demoAccount := store.Accounts()[1]
+	repo := NewAccountRepository(store)
+
+	// when
+	accounts, err := repo.FindByPublicKey(demoAccount.PrivateKey.Public().(ed25519.PublicKey))
+	unknown, unknownErr := repo.FindByPublicKey([]byte{1, 2, 3})
+	_, invalidErr := repo.FindByPublicKey(nil)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, []types.Account{demoAccount.Account}, accounts)
+	assert.Nil(t, unknownErr)
+	assert.Empty(t, unknown)
+	assert.Equal(t, hErrors.ErrInvalidPublicKey, invalidErr)
+}
+
+func TestAccountRepositoryFindExpiry(t *testing.T) {
+	// given
+	store := NewDemoStore(1)
+	repo := NewAccountRepository(store)
+
+	// when
+	expiry, err := repo.FindExpiry(store.Accounts()[0].Account.String())
+	treasury, treasuryErr := repo.FindExpiry("0.0.2")
+	unknown, unknownErr := repo.FindExpiry("0.0.9999")
+	_, invalidErr := repo.FindExpiry("a.b.c")
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, &types.AccountExpiry{}, expiry)
+	assert.Nil(t, treasuryErr)
+	assert.Equal(t, &types.AccountExpiry{}, treasury)
+	assert.Nil(t, unknownErr)
+	assert.Nil(t, unknown)
+	assert.NotNil(t, invalidErr)
+}
+
+func TestAccountRepositoryFindKey(t *testing.T) {
+	// given
+	store := NewDemoStore(1)
+	demoAccount := store.Accounts()[1]
+	repo := NewAccountRepository(store)
+	publicKey := demoAccount.PrivateKey.Public().(ed25519.PublicKey)
+	expected, _ := protobuf.Marshal(&proto.Key{Key: &proto.Key_Ed25519{Ed25519: publicKey}})
+
+	// when
+	key, err := repo.FindKey(demoAccount.Account.String())
+	unknown, unknownErr := repo.FindKey("0.0.9999")
+	_, invalidErr := repo.FindKey("a.b.c")
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, expected, key)
+	assert.Nil(t, unknownErr)
+	assert.Nil(t, unknown)
+	assert.NotNil(t, invalidErr)
+}
+
+func TestAccountRepositoryRetrieveBalanceAtBlock(t *testing.T) {
+	// given
+	store := NewDemoStore(1)
+	repo := NewAccountRepository(store)
+
+	// when
+	amounts, err := repo.RetrieveBalanceAtBlock("0.0.1002", store.blocks[1].ConsensusEndNanos, nil, 0, 0)
+	_, invalidErr := repo.RetrieveBalanceAtBlock("a.b.c", store.blocks[1].ConsensusEndNanos, nil, 0, 0)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, []types.Amount{&types.HbarAmount{Value: demoAccountBalance + 100_000_000}}, amounts)
+	assert.NotNil(t, invalidErr)
+}
+
+func TestBlockRepository(t *testing.T) {
+	// given
+	store := NewDemoStore(3)
+	repo := NewBlockRepository(store)
+	second := store.blocks[2]
+
+	// when
+	byIndex, byIndexErr := repo.FindByIndex(2)
+	byHash, byHashErr := repo.FindByHash(second.Hash)
+	byIdentifier, byIdentifierErr := repo.FindByIdentifier(2, second.Hash)
+	byTimestamp, byTimestampErr := repo.FindByConsensusTimestamp(second.ConsensusEndNanos)
+	between, betweenErr := repo.FindBetweenIndexes(1, 10)
+	genesis, genesisErr := repo.RetrieveGenesis()
+	latest, latestErr := repo.RetrieveLatest()
+
+	// then
+	for _, err := range []interface{}{byIndexErr, byHashErr, byIdentifierErr, byTimestampErr, betweenErr, genesisErr,
+		latestErr} {
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, second, byIndex)
+	assert.Equal(t, second, byHash)
+	assert.Equal(t, second, byIdentifier)
+	assert.Equal(t, second, byTimestamp)
+	assert.Equal(t, store.blocks[1:], between)
+	assert.Equal(t, store.blocks[0], genesis)
+	assert.Equal(t, store.blocks[3], latest)
+
+	byIndex.Transactions = []*types.Transaction{{}}
+	assert.Nil(t, second.Transactions)
+}
+
+func TestBlockRepositoryErrors(t *testing.T) {
+	// given
+	store := NewDemoStore(3)
+	repo := NewBlockRepository(store)
+
+	// when
+	_, invalidIndexErr := repo.FindByIndex(-1)
+	_, notFoundIndexErr := repo.FindByIndex(4)
+	_, invalidHashErr := repo.FindByHash("")
+	_, notFoundHashErr := repo.FindByHash("0a")
+	_, mismatchErr := repo.FindByIdentifier(1, store.blocks[2].Hash)
+	_, gapErr := repo.FindByConsensusTimestamp(store.blocks[1].ConsensusEndNanos + 1)
+	_, invalidRangeErr := repo.FindBetweenIndexes(2, 1)
+
+	// then
+	assert.Equal(t, hErrors.ErrInvalidArgument, invalidIndexErr)
+	assert.Equal(t, hErrors.ErrBlockNotFound, notFoundIndexErr)
+	assert.Equal(t, hErrors.ErrInvalidArgument, invalidHashErr)
+	assert.Equal(t, hErrors.ErrBlockNotFound, notFoundHashErr)
+	assert.Equal(t, hErrors.ErrBlockNotFound, mismatchErr)
+	assert.Equal(t, hErrors.ErrBlockNotFound, gapErr)
+	assert.Equal(t, hErrors.ErrInvalidArgument, invalidRangeErr)
+}
+
+func TestTransactionRepository(t *testing.T) {
+	// given
+	store := NewDemoStore(3)
+	repo := NewTransactionRepository(store, false)
+	block := store.blocks[2]
+	stored := store.transactions[1]
+
+	// when
+	between, betweenErr := repo.FindBetween(block.ConsensusStartNanos, block.ConsensusEndNanos)
+	byHash, byHashErr := repo.FindByHashInBlock(stored.hash, block.ConsensusStartNanos, block.ConsensusEndNanos)
+	byId, byIdErr := repo.FindByTransactionId(stored.transactionId)
+	raw, rawErr := repo.FindRawByHash(stored.hash)
+
+	// then
+	assert.Nil(t, betweenErr)
+	assert.Nil(t, byHashErr)
+	assert.Nil(t, byIdErr)
+	assert.Nil(t, rawErr)
+	assert.Equal(t, []*types.RawTransaction{{ConsensusTimestamp: stored.consensusTimestamp, Result: "SUCCESS"}}, raw)
+	assert.Equal(t, []*types.Transaction{stored.toTransaction(false)}, between)
+	assert.Equal(t, stored.toTransaction(false), byHash)
+	assert.Len(t, byId, 1)
+	assert.Equal(t, false, byId[0].Metadata["scheduled"])
+}
+
+func TestTransactionRepositoryFindBetweenWithMaxOperations(t *testing.T) {
+	// given
+	store := NewDemoStore(3)
+	repo := NewTransactionRepository(store, false)
+	block := store.blocks[2]
+	stored := store.transactions[1].toTransaction(false)
+
+	// when
+	fit, fitHashes, fitErr := repo.FindBetweenWithMaxOperations(block.ConsensusStartNanos, block.ConsensusEndNanos,
+		len(stored.Operations))
+	split, splitHashes, splitErr := repo.FindBetweenWithMaxOperations(block.ConsensusStartNanos,
+		block.ConsensusEndNanos, len(stored.Operations)-1)
+	_, _, betweenErr := repo.FindBetweenWithMaxOperations(2, 1, 1)
+
+	// then
+	assert.Nil(t, fitErr)
+	assert.Nil(t, splitErr)
+	assert.Equal(t, []*types.Transaction{stored}, fit)
+	assert.Empty(t, fitHashes)
+	assert.Empty(t, split)
+	assert.Equal(t, []string{stored.Hash}, splitHashes)
+	assert.Equal(t, hErrors.ErrStartMustNotBeAfterEnd, betweenErr)
+}
+
+func TestTransactionRepositoryTimestampStrings(t *testing.T) {
+	// given
+	store := NewDemoStore(3)
+	stored := store.transactions[1]
+	block := store.blocks[2]
+
+	// when
+	withStrings, withStringsErr := NewTransactionRepository(store, true).
+		FindByHashInBlock(stored.hash, block.ConsensusStartNanos, block.ConsensusEndNanos)
+	withoutStrings, withoutStringsErr := NewTransactionRepository(store, false).
+		FindByHashInBlock(stored.hash, block.ConsensusStartNanos, block.ConsensusEndNanos)
+
+	// then
+	assert.Nil(t, withStringsErr)
+	assert.Nil(t, withoutStringsErr)
+	assert.Equal(t, stored.consensusTimestamp, withStrings.Metadata["consensus_timestamp"])
+	assert.Equal(
+		t,
+		types.FormatTimestamp(stored.consensusTimestamp),
+		withStrings.Metadata["consensus_timestamp_rfc3339"],
+	)
+	assert.NotContains(t, withoutStrings.Metadata, "consensus_timestamp_rfc3339")
+}
+
+func TestTransactionRepositoryErrors(t *testing.T) {
+	// given
+	store := NewDemoStore(3)
+	repo := NewTransactionRepository(store, false)
+	stored := store.transactions[1]
+	block := store.blocks[1]
+
+	// when
+	_, betweenErr := repo.FindBetween(2, 1)
+	_, invalidHashErr := repo.FindByHashInBlock("0xzz", block.ConsensusStartNanos, block.ConsensusEndNanos)
+	_, otherBlockErr := repo.FindByHashInBlock(stored.hash, block.ConsensusStartNanos, block.ConsensusEndNanos)
+	scheduledId := stored.transactionId
+	scheduledId.Scheduled = true
+	_, scheduledErr := repo.FindByTransactionId(scheduledId)
+	_, invalidRawHashErr := repo.FindRawByHash("0xzz")
+	_, rawNotFoundErr := repo.FindRawByHash("0x0102")
+
+	// then
+	assert.Equal(t, hErrors.ErrStartMustNotBeAfterEnd, betweenErr)
+	assert.Equal(t, hErrors.ErrInvalidTransactionIdentifier, invalidHashErr)
+	assert.Equal(t, hErrors.ErrTransactionNotFound, otherBlockErr)
+	assert.Equal(t, hErrors.ErrTransactionNotFound, scheduledErr)
+	assert.Equal(t, hErrors.ErrInvalidTransactionIdentifier, invalidRawHashErr)
+	assert.Equal(t, hErrors.ErrTransactionNotFound, rawNotFoundErr)
+}
+
+func TestTransactionRepositoryTypes(t *testing.T) {
+	// given
+	repo := NewTransactionRepository(NewDemoStore(1), false)
+
+	// when
+	typesArray, err := repo.TypesAsArray()
+
+	// then
+	assert.Nil(t, err)
+	assert.Len(t, typesArray, len(transactionTypes)+1)
+	assert.Contains(t, typesArray, "CRYPTOTRANSFER")
+	assert.Contains(t, typesArray, unknownTransactionType)
+}
+
+func TestExchangeRateRepositoryFindAt(t *testing.T) {
+	// given
+	store := NewDemoStore(1)
+	repo := NewExchangeRateRepository(store)
+
+	// when
+	exchangeRate, err := repo.FindAt(store.blocks[1].ConsensusEndNanos)
+	_, notFoundErr := repo.FindAt(store.blocks[0].ConsensusStartNanos - 1)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, int32(12), exchangeRate.CurrentRate.CentEquiv)
+	assert.Equal(t, hErrors.ErrExchangeRateNotFound, notFoundErr)
+}
+
+func TestNotFoundRepositories(t *testing.T) {
+	// given
+	store := NewDemoStore(1)
+
+	// when
+	_, feeScheduleErr := NewFeeScheduleRepository(store).FindAt(0)
+	_, nftErr := NewNftRepository(store).FindTransfers("0.0.2000", 1)
+	_, scheduleErr := NewScheduleRepository(store).FindById("0.0.2000")
+	_, tokenErr := NewTokenRepository(store).FindAt("0.0.2000", 0)
+	_, tokenInfoErr := NewTokenRepository(store).FindInfoAt("0.0.2000", 0)
+	_, tokenAssociationErr := NewTokenAssociationRepository(store).Find("0.0.1001", "0.0.2000")
+	tokenAssociations, tokenAssociationsErr := NewTokenAssociationRepository(store).FindByAccount("0.0.1001", 0, 0)
+
+	// then
+	assert.Equal(t, hErrors.ErrFeeScheduleNotFound, feeScheduleErr)
+	assert.Equal(t, hErrors.ErrNftNotFound, nftErr)
+	assert.Equal(t, hErrors.ErrScheduleNotFound, scheduleErr)
+	assert.Equal(t, hErrors.ErrTokenNotFound, tokenErr)
+	assert.Equal(t, hErrors.ErrTokenNotFound, tokenInfoErr)
+	assert.Equal(t, hErrors.ErrTokenAssociationNotFound, tokenAssociationErr)
+	assert.Nil(t, tokenAssociationsErr)
+	assert.Empty(t, tokenAssociations)
+}
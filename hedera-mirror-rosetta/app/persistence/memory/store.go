@@ -0,0 +1,241 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+// Package memory provides the repositories of an in-memory store seeded with demo data, so the online mode can be
+// served without the mirror node database and the importer, e.g., to integrate a wallet during local development
+package memory
+
+import (
+	"crypto/ed25519"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/mapper"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	hexUtils "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/hex"
+)
+
+const (
+	blockInterval         = 2 * int64(time.Second)
+	blockSpan             = int64(time.Second)
+	chargedFee            = 100_000
+	demoAccountCount      = 3
+	demoAccountBalance    = 1_000 * 100_000_000
+	feeCollectorAccount   = 98
+	firstDemoAccount      = 1001
+	genesisTimestamp      = 1640995200 * int64(time.Second) // 2022-01-01T00:00:00Z
+	nodeAccount           = 3
+	nodeFee               = 10_000
+	resultSuccess         = "SUCCESS"
+	transactionTypeCrypto = 14
+	treasuryAccount       = 2
+	treasuryBalance       = 50_000_000_000 * 100_000_000
+)
+
+var (
+	transactionResults = map[int]string{
+		7:  "INVALID_SIGNATURE",
+		9:  "INSUFFICIENT_TX_FEE",
+		10: "INSUFFICIENT_PAYER_BALANCE",
+		11: "DUPLICATE_TRANSACTION",
+		22: resultSuccess,
+		28: "INSUFFICIENT_ACCOUNT_BALANCE",
+	}
+	transactionTypes = map[int]string{
+		11: "CRYPTOCREATEACCOUNT",
+		14: "CRYPTOTRANSFER",
+		29: "TOKENCREATION",
+		31: "TOKENFREEZE",
+		32: "TOKENUNFREEZE",
+		33: "TOKENGRANTKYC",
+		34: "TOKENREVOKEKYC",
+		35: "TOKENDELETION",
+		36: "TOKENUPDATE",
+		37: "TOKENMINT",
+		38: "TOKENBURN",
+		39: "TOKENWIPE",
+		40: "TOKENASSOCIATE",
+		41: "TOKENDISSOCIATE",
+		44: "SCHEDULESIGN",
+	}
+)
+
+// DemoAccount is an account of the demo data funded with hbar, the private key can sign the account's transactions
+type DemoAccount struct {
+	Account    types.Account
+	PrivateKey ed25519.PrivateKey
+}
+
+// storedTransaction is a transaction of the demo data with the fields it's looked up by
+type storedTransaction struct {
+	consensusTimestamp int64
+	hash               string
+	record             *types.TransactionRecord
+	transactionId      types.TransactionId
+}
+
+// Store holds the demo data shared by the in-memory repositories. It's read-only once created, so it's safe for
+// concurrent use
+type Store struct {
+	accounts        []DemoAccount
+	blocks          []*types.Block
+	initialBalances map[int64]int64
+	transactions    []*storedTransaction
+}
+
+// NewDemoStore creates the store with the genesis block and blockCount blocks following it. The treasury and the demo
+// accounts are funded in the genesis balances, and each block after the genesis block has a crypto transfer between
+// two demo accounts
+func NewDemoStore(blockCount int) *Store {
+	store := &Store{
+		accounts: make([]DemoAccount, 0, demoAccountCount),
+		blocks:   make([]*types.Block, 0, blockCount+1),
+		initialBalances: map[int64]int64{
+			treasuryAccount: treasuryBalance,
+		},
+	}
+
+	for i := 0; i < demoAccountCount; i++ {
+		seed := sha512.Sum512_256([]byte(fmt.Sprintf("demo account %d", i)))
+		accountId := int64(firstDemoAccount + i)
+		store.accounts = append(store.accounts, DemoAccount{
+			Account:    newAccount(accountId),
+			PrivateKey: ed25519.NewKeyFromSeed(seed[:]),
+		})
+		store.initialBalances[accountId] = demoAccountBalance
+	}
+
+	for index := int64(0); index <= int64(blockCount); index++ {
+		consensusStart := genesisTimestamp + index*blockInterval
+		block := &types.Block{
+			Index:               index,
+			Hash:                newHash("block", index),
+			ConsensusStartNanos: consensusStart,
+			ConsensusEndNanos:   consensusStart + blockSpan,
+		}
+
+		if index == 0 {
+			block.ParentHash = block.Hash
+		} else {
+			parent := store.blocks[index-1]
+			block.ParentIndex = parent.Index
+			block.ParentHash = parent.Hash
+			store.transactions = append(store.transactions, store.newTransfer(index, consensusStart))
+		}
+
+		store.blocks = append(store.blocks, block)
+	}
+
+	return store
+}
+
+// Accounts returns the demo accounts
+func (s *Store) Accounts() []DemoAccount {
+	return s.accounts
+}
+
+// newTransfer creates the crypto transfer in the block with the index, sent by one demo account to the next one
+func (s *Store) newTransfer(index int64, consensusTimestamp int64) *storedTransaction {
+	payer := s.accounts[int(index-1)%len(s.accounts)].Account
+	recipient := s.accounts[int(index)%len(s.accounts)].Account
+	amount := index * 100_000_000
+	hash := hexUtils.SafeAddHexPrefix(newHash("transaction", index))
+
+	record := &types.TransactionRecord{
+		CryptoTransfers: []types.Transfer{
+			{Account: payer, Amount: &types.HbarAmount{Value: -amount - chargedFee}},
+			{Account: recipient, Amount: &types.HbarAmount{Value: amount}},
+			{Account: newAccount(nodeAccount), Amount: &types.HbarAmount{Value: nodeFee}},
+			{Account: newAccount(feeCollectorAccount), Amount: &types.HbarAmount{Value: chargedFee - nodeFee}},
+		},
+		Hash: hash,
+		Metadata: map[string]interface{}{
+			"charged_fee":         int64(chargedFee),
+			"consensus_timestamp": consensusTimestamp,
+			"memo":                fmt.Sprintf("demo transfer %d", index),
+		},
+		NonFeeTransfers: []types.Transfer{
+			{Account: payer, Amount: &types.HbarAmount{Value: -amount}},
+			{Account: recipient, Amount: &types.HbarAmount{Value: amount}},
+		},
+		Payer:    payer,
+		Result:   resultSuccess,
+		Type:     transactionTypeCrypto,
+		TypeName: transactionTypes[transactionTypeCrypto],
+	}
+	return &storedTransaction{
+		consensusTimestamp: consensusTimestamp,
+		hash:               hash,
+		record:             record,
+		transactionId:      types.TransactionId{Payer: payer, ValidStartNs: consensusTimestamp - int64(time.Second)},
+	}
+}
+
+// hasAccount returns true if the account is funded in the genesis balances, i.e., the treasury or a demo account
+func (s *Store) hasAccount(encodedId int64) bool {
+	_, ok := s.initialBalances[encodedId]
+	return ok
+}
+
+// getBalance returns the hbar balance of the account at the consensus timestamp
+func (s *Store) getBalance(encodedId int64, consensusTimestamp int64) int64 {
+	balance := s.initialBalances[encodedId]
+	for _, transaction := range s.transactions {
+		if transaction.consensusTimestamp > consensusTimestamp {
+			break
+		}
+
+		for _, transfer := range transaction.record.CryptoTransfers {
+			if transfer.Account.EncodedId == encodedId {
+				balance += transfer.Amount.(*types.HbarAmount).Value
+			}
+		}
+	}
+
+	return balance
+}
+
+// toTransaction assembles the domain transaction of the stored transaction, the metadata is copied so the caller can
+// change it. The consensus timestamp in the metadata has its RFC3339 string if timestampStrings is set
+func (t *storedTransaction) toTransaction(timestampStrings bool) *types.Transaction {
+	transaction := mapper.ToTransaction([]*types.TransactionRecord{t.record}, resultSuccess,
+		mapper.FailedAmountsIntended, timestampStrings)
+	metadata := make(map[string]interface{}, len(transaction.Metadata)+1)
+	for key, value := range transaction.Metadata {
+		metadata[key] = value
+	}
+	types.AddTimestampMetadata(metadata, "consensus_timestamp", t.consensusTimestamp, timestampStrings)
+	transaction.Metadata = metadata
+	return transaction
+}
+
+// newAccount returns the account with the entity num in shard 0 realm 0
+func newAccount(num int64) types.Account {
+	account, _ := types.NewAccountFromEncodedID(num)
+	return account
+}
+
+// newHash returns the hex encoded deterministic 48-byte hash of the entity with the index
+func newHash(entity string, index int64) string {
+	hash := sha512.Sum384([]byte(fmt.Sprintf("demo %s %d", entity, index)))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,115 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package memory
+
+import (
+	"testing"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDemoStore(t *testing.T) {
+	// when
+	store := NewDemoStore(5)
+
+	// then
+	assert.Len(t, store.Accounts(), demoAccountCount)
+	assert.Len(t, store.blocks, 6)
+	assert.Len(t, store.transactions, 5)
+
+	genesis := store.blocks[0]
+	assert.Equal(t, int64(0), genesis.ParentIndex)
+	assert.Equal(t, genesis.Hash, genesis.ParentHash)
+	for i, block := range store.blocks[1:] {
+		parent := store.blocks[i]
+		assert.Equal(t, parent.Index, block.ParentIndex)
+		assert.Equal(t, parent.Hash, block.ParentHash)
+		assert.Greater(t, block.ConsensusStartNanos, parent.ConsensusEndNanos)
+
+		transaction := store.transactions[i]
+		assert.GreaterOrEqual(t, transaction.consensusTimestamp, block.ConsensusStartNanos)
+		assert.LessOrEqual(t, transaction.consensusTimestamp, block.ConsensusEndNanos)
+	}
+}
+
+func TestNewDemoStoreIsDeterministic(t *testing.T) {
+	assert.Equal(t, NewDemoStore(3), NewDemoStore(3))
+}
+
+func TestStoreGetBalance(t *testing.T) {
+	// given
+	store := NewDemoStore(3)
+	payer := store.Accounts()[0].Account.EncodedId
+	recipient := store.Accounts()[1].Account.EncodedId
+	firstBlock := store.blocks[1]
+
+	// when
+	payerBefore := store.getBalance(payer, firstBlock.ConsensusStartNanos-1)
+	payerAfter := store.getBalance(payer, firstBlock.ConsensusEndNanos)
+	recipientAfter := store.getBalance(recipient, firstBlock.ConsensusEndNanos)
+
+	// then
+	assert.Equal(t, int64(demoAccountBalance), payerBefore)
+	assert.Equal(t, int64(demoAccountBalance-100_000_000-chargedFee), payerAfter)
+	assert.Equal(t, int64(demoAccountBalance+100_000_000), recipientAfter)
+}
+
+func TestStoreBalancesAreConserved(t *testing.T) {
+	// given
+	store := NewDemoStore(10)
+	latest := store.blocks[len(store.blocks)-1].ConsensusEndNanos
+	accounts := []int64{treasuryAccount, nodeAccount, feeCollectorAccount}
+	for _, account := range store.Accounts() {
+		accounts = append(accounts, account.Account.EncodedId)
+	}
+
+	// when
+	var initial, final int64
+	for _, account := range accounts {
+		initial += store.initialBalances[account]
+		final += store.getBalance(account, latest)
+	}
+
+	// then
+	assert.Equal(t, initial, final)
+}
+
+func TestStoredTransactionToTransaction(t *testing.T) {
+	// given
+	store := NewDemoStore(1)
+	stored := store.transactions[0]
+
+	// when
+	transaction := stored.toTransaction(false)
+	transaction.Metadata["scheduled"] = false
+
+	// then
+	assert.Equal(t, stored.hash, transaction.Hash)
+	assert.Len(t, transaction.Operations, 5)
+	var sum int64
+	for _, operation := range transaction.Operations {
+		assert.Equal(t, resultSuccess, operation.Status)
+		sum += operation.Amount.(*types.HbarAmount).Value
+	}
+	assert.Zero(t, sum)
+	assert.NotContains(t, stored.record.Metadata, "scheduled")
+}
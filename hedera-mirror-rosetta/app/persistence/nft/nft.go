@@ -0,0 +1,145 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package nft
+
+import (
+	"database/sql"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	hErrors "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	// selectNftTransfers selects the transfers of the nft serial in chronological order, with the type of the
+	// transaction to tell a wipe from a burn
+	selectNftTransfers = `select
+                            nt.consensus_timestamp,
+                            nt.receiver_account_id,
+                            nt.sender_account_id,
+                            coalesce(t.type, 0) as transaction_type
+                          from nft_transfer nt
+                          left join transaction t on t.consensus_ns = nt.consensus_timestamp
+                          where nt.token_id = @token_id and nt.serial_number = @serial_number
+                          order by nt.consensus_timestamp`
+)
+
+type nftTransfer struct {
+	ConsensusTimestamp int64
+	ReceiverAccountId  *int64
+	SenderAccountId    *int64
+	TransactionType    int16
+}
+
+func (n nftTransfer) toDomainNftTransfer() (*types.NftTransfer, *rTypes.Error) {
+	receiver, rErr := toAccount(n.ReceiverAccountId)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	sender, rErr := toAccount(n.SenderAccountId)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	transferType := types.NftTransferTypeTransfer
+	if sender == nil {
+		transferType = types.NftTransferTypeMint
+	} else if receiver == nil {
+		transferType = types.NftTransferTypeBurn
+		if n.TransactionType == types.TransactionTypeTokenWipe {
+			transferType = types.NftTransferTypeWipe
+		}
+	}
+
+	return &types.NftTransfer{
+		ConsensusTimestamp: n.ConsensusTimestamp,
+		Receiver:           receiver,
+		Sender:             sender,
+		Type:               transferType,
+	}, nil
+}
+
+// nftRepository struct that has connection to the Database
+type nftRepository struct {
+	dbClient *gorm.DB
+}
+
+// NewNftRepository creates an instance of a nftRepository struct
+func NewNftRepository(dbClient *gorm.DB) repositories.NftRepository {
+	return &nftRepository{dbClient: dbClient}
+}
+
+// FindTransfers returns the ownership history of the nft serial from its mint, in chronological order
+func (nr *nftRepository) FindTransfers(tokenIdStr string, serialNumber int64) ([]*types.NftTransfer, *rTypes.Error) {
+	tokenId, err := entityid.FromString(tokenIdStr)
+	if err != nil {
+		return nil, hErrors.ErrInvalidToken
+	}
+
+	if serialNumber <= 0 {
+		return nil, hErrors.ErrInvalidArgument
+	}
+
+	var transfers []nftTransfer
+	if err := nr.dbClient.Raw(
+		selectNftTransfers,
+		sql.Named("token_id", tokenId.EncodedId),
+		sql.Named("serial_number", serialNumber),
+	).Scan(&transfers).Error; err != nil {
+		log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, err)
+		return nil, hErrors.ErrDatabaseError
+	}
+
+	if len(transfers) == 0 {
+		return nil, hErrors.ErrNftNotFound
+	}
+
+	nftTransfers := make([]*types.NftTransfer, 0, len(transfers))
+	for _, transfer := range transfers {
+		nftTransfer, rErr := transfer.toDomainNftTransfer()
+		if rErr != nil {
+			return nil, rErr
+		}
+		nftTransfers = append(nftTransfers, nftTransfer)
+	}
+
+	return nftTransfers, nil
+}
+
+// toAccount returns nil for a nil encoded account id
+func toAccount(encodedId *int64) (*types.Account, *rTypes.Error) {
+	if encodedId == nil {
+		return nil, nil
+	}
+
+	account, err := types.NewAccountFromEncodedID(*encodedId)
+	if err != nil {
+		log.Errorf(hErrors.CreateAccountDbIdFailed, *encodedId)
+		return nil, hErrors.ErrInternalServerError
+	}
+
+	return &account, nil
+}
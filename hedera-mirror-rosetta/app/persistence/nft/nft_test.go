@@ -0,0 +1,165 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package nft
+
+import (
+	"testing"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	dbTypes "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/db"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	nodeAccountId int64 = 3
+	tokenId       int64 = 2001
+	tokenIdStr          = "0.0.2001"
+)
+
+var (
+	firstAccountId  int64 = 1001
+	secondAccountId int64 = 1002
+)
+
+// run the suite
+func TestNftRepositorySuite(t *testing.T) {
+	suite.Run(t, new(nftRepositorySuite))
+}
+
+type nftRepositorySuite struct {
+	suite.Suite
+	dbResource db.DbResource
+}
+
+func (suite *nftRepositorySuite) SetupSuite() {
+	suite.dbResource = db.SetupDb()
+}
+
+func (suite *nftRepositorySuite) TearDownSuite() {
+	db.TeardownDb(suite.dbResource)
+}
+
+func (suite *nftRepositorySuite) SetupTest() {
+	db.CleanupDb(suite.dbResource.GetDb())
+}
+
+func (suite *nftRepositorySuite) TestFindTransfers() {
+	// given
+	dbClient := suite.dbResource.GetGormDb()
+	suite.addNftTransfer(100, 37, &firstAccountId, nil, 1)
+	suite.addNftTransfer(101, 14, &secondAccountId, &firstAccountId, 1)
+	suite.addNftTransfer(102, types.TransactionTypeTokenWipe, nil, &secondAccountId, 1)
+	// a different serial
+	suite.addNftTransfer(103, 37, &firstAccountId, nil, 2)
+	repo := NewNftRepository(dbClient)
+
+	first, _ := types.NewAccountFromEncodedID(firstAccountId)
+	second, _ := types.NewAccountFromEncodedID(secondAccountId)
+	expected := []*types.NftTransfer{
+		{ConsensusTimestamp: 100, Receiver: &first, Type: types.NftTransferTypeMint},
+		{ConsensusTimestamp: 101, Receiver: &second, Sender: &first, Type: types.NftTransferTypeTransfer},
+		{ConsensusTimestamp: 102, Sender: &second, Type: types.NftTransferTypeWipe},
+	}
+
+	// when
+	actual, err := repo.FindTransfers(tokenIdStr, 1)
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expected, actual)
+}
+
+func (suite *nftRepositorySuite) TestFindTransfersBurn() {
+	// given
+	dbClient := suite.dbResource.GetGormDb()
+	suite.addNftTransfer(100, 37, &firstAccountId, nil, 1)
+	suite.addNftTransfer(101, 38, nil, &firstAccountId, 1)
+	repo := NewNftRepository(dbClient)
+
+	// when
+	actual, err := repo.FindTransfers(tokenIdStr, 1)
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.Len(suite.T(), actual, 2)
+	assert.Equal(suite.T(), types.NftTransferTypeBurn, actual[1].Type)
+}
+
+func (suite *nftRepositorySuite) TestFindTransfersNotFound() {
+	// given
+	repo := NewNftRepository(suite.dbResource.GetGormDb())
+
+	// when
+	actual, err := repo.FindTransfers(tokenIdStr, 1)
+
+	// then
+	assert.Equal(suite.T(), errors.ErrNftNotFound, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *nftRepositorySuite) TestFindTransfersInvalidArguments() {
+	// given
+	repo := NewNftRepository(suite.dbResource.GetGormDb())
+
+	var tests = []struct {
+		name         string
+		tokenId      string
+		serialNumber int64
+		expected     interface{}
+	}{
+		{name: "InvalidTokenId", tokenId: "abc", serialNumber: 1, expected: errors.ErrInvalidToken},
+		{name: "ZeroSerialNumber", tokenId: tokenIdStr, serialNumber: 0, expected: errors.ErrInvalidArgument},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := repo.FindTransfers(tt.tokenId, tt.serialNumber)
+
+			// then
+			assert.Equal(t, tt.expected, err)
+			assert.Nil(t, actual)
+		})
+	}
+}
+
+func (suite *nftRepositorySuite) addNftTransfer(
+	consensusTimestamp int64,
+	transactionType int16,
+	receiver *int64,
+	sender *int64,
+	serialNumber int64,
+) {
+	dbClient := suite.dbResource.GetGormDb()
+	domain.AddTransaction(dbClient, consensusTimestamp, tokenId, nodeAccountId, firstAccountId, 22,
+		[]byte{0x1, byte(consensusTimestamp)}, transactionType, consensusTimestamp-10, nil, nil, nil)
+	dbClient.Create(&dbTypes.NftTransfer{
+		ConsensusTimestamp: consensusTimestamp,
+		ReceiverAccountId:  receiver,
+		SenderAccountId:    sender,
+		SerialNumber:       serialNumber,
+		TokenId:            tokenId,
+	})
+}
@@ -0,0 +1,106 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package notification
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	minReconnectInterval = time.Second
+	maxReconnectInterval = time.Minute
+)
+
+// RecordFileListener calls onRecordFile when notified of a new record file on the PostgreSQL notification channel. It
+// also calls onRecordFile every pollInterval as the fallback in case the notification isn't sent or is lost
+type RecordFileListener struct {
+	channel      string
+	dsn          string
+	onRecordFile func()
+	pollInterval time.Duration
+	stop         chan struct{}
+}
+
+// NewRecordFileListener creates a new instance of a RecordFileListener. An empty channel disables the notification
+// listening, so it only polls
+func NewRecordFileListener(
+	dsn string,
+	channel string,
+	pollInterval time.Duration,
+	onRecordFile func(),
+) *RecordFileListener {
+	return &RecordFileListener{
+		channel:      channel,
+		dsn:          dsn,
+		onRecordFile: onRecordFile,
+		pollInterval: pollInterval,
+		stop:         make(chan struct{}),
+	}
+}
+
+// Start starts listening in a new goroutine
+func (l *RecordFileListener) Start() {
+	go l.run()
+}
+
+// Stop stops listening
+func (l *RecordFileListener) Stop() {
+	close(l.stop)
+}
+
+func (l *RecordFileListener) run() {
+	var notify <-chan *pq.Notification
+	if l.channel != "" {
+		listener := pq.NewListener(l.dsn, minReconnectInterval, maxReconnectInterval, l.logEvent)
+		defer listener.Close()
+
+		if err := listener.Listen(l.channel); err != nil {
+			log.Errorf("Failed to listen on channel %s, fall back to polling: %s", l.channel, err)
+		} else {
+			log.Infof("Listening on channel %s", l.channel)
+			notify = listener.Notify
+		}
+	}
+
+	ticker := time.NewTicker(l.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-notify:
+			// a nil notification is sent after reconnecting, a record file may be missed during the downtime
+			l.onRecordFile()
+		case <-ticker.C:
+			l.onRecordFile()
+		}
+	}
+}
+
+func (l *RecordFileListener) logEvent(event pq.ListenerEventType, err error) {
+	if err != nil {
+		log.Warnf("Listener event %d on channel %s: %s", event, l.channel, err)
+	}
+}
@@ -0,0 +1,134 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package schedule
+
+import (
+	"database/sql"
+	"errors"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	hErrors "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	// selectScheduleById selects the schedule by its id, the deleted flag comes from the schedule entity
+	selectScheduleById = `select
+                            s.consensus_timestamp,
+                            s.creator_account_id,
+                            coalesce(e.deleted, false) as deleted,
+                            s.executed_timestamp,
+                            s.payer_account_id,
+                            s.schedule_id,
+                            s.transaction_body
+                          from schedule s
+                          left join entity e on e.id = s.schedule_id
+                          where s.schedule_id = @schedule_id`
+
+	// selectSignaturesByScheduleId selects the public key prefixes of the signatures collected by the schedule, in
+	// chronological order
+	selectSignaturesByScheduleId = `select public_key_prefix
+                                    from transaction_signature
+                                    where entity_id = @schedule_id
+                                    order by consensus_timestamp, public_key_prefix`
+)
+
+type schedule struct {
+	ConsensusTimestamp int64
+	CreatorAccountId   int64
+	Deleted            bool
+	ExecutedTimestamp  *int64
+	PayerAccountId     int64
+	ScheduleId         int64
+	TransactionBody    []byte
+}
+
+func (s schedule) toDomainSchedule(signatures [][]byte) (*types.Schedule, *rTypes.Error) {
+	creator, err := types.NewAccountFromEncodedID(s.CreatorAccountId)
+	if err != nil {
+		log.Errorf(hErrors.CreateAccountDbIdFailed, s.CreatorAccountId)
+		return nil, hErrors.ErrInternalServerError
+	}
+
+	payer, err := types.NewAccountFromEncodedID(s.PayerAccountId)
+	if err != nil {
+		log.Errorf(hErrors.CreateAccountDbIdFailed, s.PayerAccountId)
+		return nil, hErrors.ErrInternalServerError
+	}
+
+	scheduleId, err := entityid.Decode(s.ScheduleId)
+	if err != nil {
+		return nil, hErrors.ErrInvalidSchedule
+	}
+
+	return &types.Schedule{
+		ConsensusTimestamp: s.ConsensusTimestamp,
+		CreatorAccountId:   creator,
+		Deleted:            s.Deleted,
+		ExecutedTimestamp:  s.ExecutedTimestamp,
+		PayerAccountId:     payer,
+		ScheduleId:         scheduleId,
+		Signatures:         signatures,
+		TransactionBody:    s.TransactionBody,
+	}, nil
+}
+
+// scheduleRepository struct that has connection to the Database
+type scheduleRepository struct {
+	dbClient *gorm.DB
+}
+
+// NewScheduleRepository creates an instance of a scheduleRepository struct
+func NewScheduleRepository(dbClient *gorm.DB) repositories.ScheduleRepository {
+	return &scheduleRepository{dbClient: dbClient}
+}
+
+// FindById returns the schedule with its execution status and the signatures collected so far
+func (sr *scheduleRepository) FindById(scheduleIdStr string) (*types.Schedule, *rTypes.Error) {
+	scheduleId, err := entityid.FromString(scheduleIdStr)
+	if err != nil {
+		return nil, hErrors.ErrInvalidSchedule
+	}
+
+	s := &schedule{}
+	if err := sr.dbClient.Raw(selectScheduleById, sql.Named("schedule_id", scheduleId.EncodedId)).
+		First(s).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, hErrors.ErrScheduleNotFound
+		}
+
+		log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, err)
+		return nil, hErrors.ErrDatabaseError
+	}
+
+	var signatures [][]byte
+	if err := sr.dbClient.Raw(selectSignaturesByScheduleId, sql.Named("schedule_id", scheduleId.EncodedId)).
+		Scan(&signatures).Error; err != nil {
+		log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, err)
+		return nil, hErrors.ErrDatabaseError
+	}
+
+	return s.toDomainSchedule(signatures)
+}
@@ -0,0 +1,183 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package schedule
+
+import (
+	"testing"
+
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	dbTypes "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	creatorAccountId int64 = 1001
+	payerAccountId   int64 = 1002
+	scheduleId       int64 = 1500
+)
+
+// run the suite
+func TestScheduleRepositorySuite(t *testing.T) {
+	suite.Run(t, new(scheduleRepositorySuite))
+}
+
+type scheduleRepositorySuite struct {
+	suite.Suite
+	dbResource db.DbResource
+}
+
+func (suite *scheduleRepositorySuite) SetupSuite() {
+	suite.dbResource = db.SetupDb()
+}
+
+func (suite *scheduleRepositorySuite) TearDownSuite() {
+	db.TeardownDb(suite.dbResource)
+}
+
+func (suite *scheduleRepositorySuite) SetupTest() {
+	db.CleanupDb(suite.dbResource.GetDb())
+}
+
+func (suite *scheduleRepositorySuite) TestFindByIdPending() {
+	// given
+	dbClient := suite.dbResource.GetGormDb()
+	suite.createSchedule(nil, false)
+	entityId := scheduleId
+	dbClient.Create(&dbTypes.TransactionSignature{
+		ConsensusTimestamp: 100,
+		EntityId:           &entityId,
+		PublicKeyPrefix:    []byte{0x1, 0x2},
+		Signature:          []byte{0x3},
+	})
+	dbClient.Create(&dbTypes.TransactionSignature{
+		ConsensusTimestamp: 101,
+		EntityId:           &entityId,
+		PublicKeyPrefix:    []byte{0x4, 0x5},
+		Signature:          []byte{0x6},
+	})
+
+	expected := suite.expectedSchedule(nil, false, [][]byte{{0x1, 0x2}, {0x4, 0x5}})
+	repo := NewScheduleRepository(dbClient)
+
+	// when
+	actual, err := repo.FindById("0.0.1500")
+
+	// then
+	assert.Equal(suite.T(), expected, actual)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), types.ScheduleStatusPending, actual.Status())
+}
+
+func (suite *scheduleRepositorySuite) TestFindByIdExecuted() {
+	// given
+	dbClient := suite.dbResource.GetGormDb()
+	executedTimestamp := int64(200)
+	suite.createSchedule(&executedTimestamp, false)
+
+	expected := suite.expectedSchedule(&executedTimestamp, false, nil)
+	repo := NewScheduleRepository(dbClient)
+
+	// when
+	actual, err := repo.FindById("0.0.1500")
+
+	// then
+	assert.Equal(suite.T(), expected, actual)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), types.ScheduleStatusExecuted, actual.Status())
+}
+
+func (suite *scheduleRepositorySuite) TestFindByIdDeleted() {
+	// given
+	dbClient := suite.dbResource.GetGormDb()
+	suite.createSchedule(nil, true)
+	repo := NewScheduleRepository(dbClient)
+
+	// when
+	actual, err := repo.FindById("0.0.1500")
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), types.ScheduleStatusDeleted, actual.Status())
+}
+
+func (suite *scheduleRepositorySuite) TestFindByIdNotFound() {
+	// given
+	repo := NewScheduleRepository(suite.dbResource.GetGormDb())
+
+	// when
+	actual, err := repo.FindById("0.0.1500")
+
+	// then
+	assert.Equal(suite.T(), errors.ErrScheduleNotFound, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *scheduleRepositorySuite) TestFindByIdInvalidScheduleId() {
+	// given
+	repo := NewScheduleRepository(suite.dbResource.GetGormDb())
+
+	// when
+	actual, err := repo.FindById("0.0.-1")
+
+	// then
+	assert.Equal(suite.T(), errors.ErrInvalidSchedule, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *scheduleRepositorySuite) createSchedule(executedTimestamp *int64, deleted bool) {
+	dbClient := suite.dbResource.GetGormDb()
+	dbClient.Create(&dbTypes.Entity{
+		CreatedTimestamp: 10,
+		Deleted:          deleted,
+		Id:               scheduleId,
+		Num:              scheduleId,
+		Type:             6,
+	})
+	dbClient.Create(&dbTypes.Schedule{
+		ConsensusTimestamp: 10,
+		CreatorAccountId:   creatorAccountId,
+		ExecutedTimestamp:  executedTimestamp,
+		PayerAccountId:     payerAccountId,
+		ScheduleId:         scheduleId,
+		TransactionBody:    []byte{0x10, 0x11},
+	})
+}
+
+func (suite *scheduleRepositorySuite) expectedSchedule(
+	executedTimestamp *int64,
+	deleted bool,
+	signatures [][]byte,
+) *types.Schedule {
+	return &types.Schedule{
+		ConsensusTimestamp: 10,
+		CreatorAccountId:   types.Account{EntityId: entityid.EntityId{EntityNum: creatorAccountId, EncodedId: creatorAccountId}},
+		Deleted:            deleted,
+		ExecutedTimestamp:  executedTimestamp,
+		PayerAccountId:     types.Account{EntityId: entityid.EntityId{EntityNum: payerAccountId, EncodedId: payerAccountId}},
+		ScheduleId:         entityid.EntityId{EntityNum: scheduleId, EncodedId: scheduleId},
+		Signatures:         signatures,
+		TransactionBody:    []byte{0x10, 0x11},
+	}
+}
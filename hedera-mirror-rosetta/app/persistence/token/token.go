@@ -21,6 +21,7 @@
 package token
 
 import (
+	"database/sql"
 	"errors"
 
 	rTypes "github.com/coinbase/rosetta-sdk-go/types"
@@ -33,6 +34,139 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	// selectTokenAt - Selects the token as of @timestamp. Only the current state of a token is stored, which also
+	// represents the token at any time after its creation since the decimals are immutable
+	selectTokenAt string = `select *
+                           from token
+                           where token_id = @token_id and created_timestamp <= @timestamp`
+	// selectTokenInfoAt - Selects the token info as of @timestamp. The total supply is summed from the token transfers,
+	// since mints, burns, and wipes are the only transfers which don't net to zero, or from the mints and the burns or
+	// wipes of the serials for an nft. The admin key is the key of the token entity
+	selectTokenInfoAt string = `select
+                                 t.token_id,
+                                 t.decimals,
+                                 t.fee_schedule_key,
+                                 t.freeze_default,
+                                 t.freeze_key,
+                                 t.initial_supply,
+                                 t.kyc_key,
+                                 t.max_supply,
+                                 t.name,
+                                 t.supply_key,
+                                 t.supply_type::text,
+                                 t.symbol,
+                                 t.treasury_account_id,
+                                 t.type::text,
+                                 t.wipe_key,
+                                 e.key as admin_key,
+                                 case
+                                   when t.type = 'NON_FUNGIBLE_UNIQUE' then (
+                                     select count(*) filter (where sender_account_id is null) -
+                                       count(*) filter (where receiver_account_id is null)
+                                     from nft_transfer
+                                     where token_id = @token_id and consensus_timestamp <= @timestamp
+                                   )
+                                   else (
+                                     select coalesce(sum(amount), 0)
+                                     from token_transfer
+                                     where token_id = @token_id and consensus_timestamp <= @timestamp
+                                   )
+                                 end as total_supply
+                               from token t
+                               left join entity e on e.id = t.token_id
+                               where t.token_id = @token_id and t.created_timestamp <= @timestamp`
+	// selectCustomFeesAt - Selects the custom fee schedule of the token effective at @timestamp. An empty fee schedule
+	// is stored as a single row without a collector
+	selectCustomFeesAt string = `select
+                                  amount,
+                                  amount_denominator,
+                                  collector_account_id,
+                                  denominating_token_id,
+                                  maximum_amount,
+                                  minimum_amount,
+                                  net_of_transfers,
+                                  royalty_denominator,
+                                  royalty_numerator
+                                from custom_fee
+                                where token_id = @token_id and
+                                  collector_account_id is not null and
+                                  created_timestamp = (
+                                    select max(created_timestamp)
+                                    from custom_fee
+                                    where token_id = @token_id and created_timestamp <= @timestamp
+                                  )
+                                order by collector_account_id, denominating_token_id nulls first, amount`
+)
+
+type tokenInfo struct {
+	AdminKey          []byte
+	Decimals          int64
+	FeeScheduleKey    []byte
+	FreezeDefault     bool
+	FreezeKey         []byte
+	InitialSupply     int64
+	KycKey            []byte
+	MaxSupply         int64
+	Name              string
+	SupplyKey         []byte
+	SupplyType        string
+	Symbol            string
+	TokenId           int64
+	TotalSupply       int64
+	TreasuryAccountId int64
+	Type              string
+	WipeKey           []byte
+}
+
+type customFee struct {
+	Amount              *int64
+	AmountDenominator   *int64
+	CollectorAccountId  int64
+	DenominatingTokenId *int64
+	MaximumAmount       *int64
+	MinimumAmount       int64
+	NetOfTransfers      *bool
+	RoyaltyDenominator  *int64
+	RoyaltyNumerator    *int64
+}
+
+func (c customFee) toDomainCustomFee() (*types.CustomFee, *rTypes.Error) {
+	collector, err := entityid.Decode(c.CollectorAccountId)
+	if err != nil {
+		log.Errorf("Failed to decode collector account id %d: %s", c.CollectorAccountId, err)
+		return nil, hErrors.ErrInternalServerError
+	}
+
+	fee := &types.CustomFee{
+		Amount:             valueOf(c.Amount),
+		AmountDenominator:  valueOf(c.AmountDenominator),
+		CollectorAccountId: collector,
+		MaximumAmount:      valueOf(c.MaximumAmount),
+		MinimumAmount:      c.MinimumAmount,
+		NetOfTransfers:     c.NetOfTransfers != nil && *c.NetOfTransfers,
+		RoyaltyDenominator: valueOf(c.RoyaltyDenominator),
+		RoyaltyNumerator:   valueOf(c.RoyaltyNumerator),
+		Type:               types.CustomFeeTypeFixed,
+	}
+	if c.RoyaltyDenominator != nil {
+		fee.Type = types.CustomFeeTypeRoyalty
+	} else if c.AmountDenominator != nil {
+		fee.Type = types.CustomFeeTypeFractional
+	}
+
+	if c.DenominatingTokenId != nil {
+		denominatingTokenId, err := entityid.Decode(*c.DenominatingTokenId)
+		if err != nil {
+			log.Errorf("Failed to decode denominating token id %d: %s", *c.DenominatingTokenId, err)
+			return nil, hErrors.ErrInternalServerError
+		}
+		fee.DenominatingTokenId = &denominatingTokenId
+	}
+
+	return fee, nil
+}
+
 // tokenRepository struct that has connection to the Database
 type tokenRepository struct {
 	dbClient *gorm.DB
@@ -61,3 +195,106 @@ func (tr *tokenRepository) Find(tokenIdStr string) (*types.Token, *rTypes.Error)
 
 	return token.ToDomainToken()
 }
+
+// FindAt returns the token as of the consensus timestamp, e.g., to resolve the currency decimals when replaying a
+// historical block. ErrTokenNotFound is returned if the token didn't exist at the timestamp
+func (tr *tokenRepository) FindAt(tokenIdStr string, consensusTimestamp int64) (*types.Token, *rTypes.Error) {
+	entityId, err := entityid.FromString(tokenIdStr)
+	if err != nil {
+		return nil, hErrors.ErrInvalidToken
+	}
+
+	token := &dbTypes.Token{}
+	if err := tr.dbClient.Raw(
+		selectTokenAt,
+		sql.Named("timestamp", consensusTimestamp),
+		sql.Named("token_id", entityId.EncodedId),
+	).First(token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, hErrors.ErrTokenNotFound
+		}
+
+		log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, err)
+		return nil, hErrors.ErrDatabaseError
+	}
+
+	return token.ToDomainToken()
+}
+
+// FindInfoAt returns the token info as of the consensus timestamp with the total supply and the custom fee schedule
+// at the timestamp. ErrTokenNotFound is returned if the token didn't exist at the timestamp
+func (tr *tokenRepository) FindInfoAt(tokenIdStr string, consensusTimestamp int64) (*types.TokenInfo, *rTypes.Error) {
+	entityId, err := entityid.FromString(tokenIdStr)
+	if err != nil {
+		return nil, hErrors.ErrInvalidToken
+	}
+
+	info := &tokenInfo{}
+	if err := tr.dbClient.Raw(
+		selectTokenInfoAt,
+		sql.Named("timestamp", consensusTimestamp),
+		sql.Named("token_id", entityId.EncodedId),
+	).First(info).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, hErrors.ErrTokenNotFound
+		}
+
+		log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, err)
+		return nil, hErrors.ErrDatabaseError
+	}
+
+	var customFees []customFee
+	if err := tr.dbClient.Raw(
+		selectCustomFeesAt,
+		sql.Named("timestamp", consensusTimestamp),
+		sql.Named("token_id", entityId.EncodedId),
+	).Scan(&customFees).Error; err != nil {
+		log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, err)
+		return nil, hErrors.ErrDatabaseError
+	}
+
+	treasury, err := types.NewAccountFromEncodedID(info.TreasuryAccountId)
+	if err != nil {
+		log.Errorf("Failed to decode treasury account id %d: %s", info.TreasuryAccountId, err)
+		return nil, hErrors.ErrInternalServerError
+	}
+
+	tokenInfo := &types.TokenInfo{
+		Token: types.Token{
+			TokenId:       entityId,
+			Decimals:      uint32(info.Decimals),
+			FreezeDefault: info.FreezeDefault,
+			InitialSupply: info.InitialSupply,
+			Name:          info.Name,
+			Symbol:        info.Symbol,
+		},
+		AdminKey:          info.AdminKey,
+		CustomFees:        make([]types.CustomFee, 0, len(customFees)),
+		FeeScheduleKey:    info.FeeScheduleKey,
+		FreezeKey:         info.FreezeKey,
+		KycKey:            info.KycKey,
+		MaxSupply:         info.MaxSupply,
+		SupplyKey:         info.SupplyKey,
+		SupplyType:        info.SupplyType,
+		TotalSupply:       info.TotalSupply,
+		TreasuryAccountId: treasury,
+		Type:              info.Type,
+		WipeKey:           info.WipeKey,
+	}
+	for _, fee := range customFees {
+		customFee, rErr := fee.toDomainCustomFee()
+		if rErr != nil {
+			return nil, rErr
+		}
+		tokenInfo.CustomFees = append(tokenInfo.CustomFees, *customFee)
+	}
+
+	return tokenInfo, nil
+}
+
+func valueOf(value *int64) int64 {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
@@ -86,9 +86,11 @@ func (suite *tokenRepositorySuite) TestFindShouldSucceed() {
 			EntityNum: 1200,
 			EncodedId: 1200,
 		},
-		Decimals: 9,
-		Name:     token.Name,
-		Symbol:   token.Symbol,
+		Decimals:      9,
+		FreezeDefault: true,
+		InitialSupply: 120,
+		Name:          token.Name,
+		Symbol:        token.Symbol,
 	}
 
 	repo := NewTokenRepository(dbClient)
@@ -113,3 +115,205 @@ func (suite *tokenRepositorySuite) TestFindTokenNotFound() {
 	assert.Equal(suite.T(), errors.ErrTokenNotFound, err)
 	assert.Nil(suite.T(), actual)
 }
+
+func (suite *tokenRepositorySuite) TestFindAt() {
+	// given
+	dbClient := suite.dbResource.GetGormDb()
+	token := &dbTypes.Token{
+		TokenId:           1200,
+		CreatedTimestamp:  10001,
+		Decimals:          9,
+		ModifiedTimestamp: 10001,
+		Name:              randstr.Hex(6),
+		Symbol:            randstr.Hex(4),
+		TreasuryAccountId: 1100,
+	}
+	dbClient.Create(token)
+	expected := &types.Token{
+		TokenId:  entityid.EntityId{EntityNum: 1200, EncodedId: 1200},
+		Decimals: 9,
+		Name:     token.Name,
+		Symbol:   token.Symbol,
+	}
+	repo := NewTokenRepository(dbClient)
+
+	var tests = []struct {
+		name          string
+		timestamp     int64
+		expected      *types.Token
+		expectedError bool
+	}{
+		{name: "AtCreation", timestamp: 10001, expected: expected},
+		{name: "AfterCreation", timestamp: 20000, expected: expected},
+		{name: "BeforeCreation", timestamp: 10000, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := repo.FindAt("0.0.1200", tt.timestamp)
+
+			// then
+			assert.Equal(t, tt.expected, actual)
+			if tt.expectedError {
+				assert.Equal(t, errors.ErrTokenNotFound, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func (suite *tokenRepositorySuite) TestFindAtInvalidTokenId() {
+	// given
+	repo := NewTokenRepository(suite.dbResource.GetGormDb())
+
+	// when
+	actual, err := repo.FindAt("abc", 10001)
+
+	// then
+	assert.Equal(suite.T(), errors.ErrInvalidToken, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *tokenRepositorySuite) TestFindInfoAt() {
+	// given
+	dbClient := suite.dbResource.GetGormDb()
+	adminKey := randstr.Bytes(10)
+	supplyKey := randstr.Bytes(12)
+	dbClient.Create(&dbTypes.Entity{Id: 1200, Key: adminKey, Num: 1200, Type: 5})
+	token := &dbTypes.Token{
+		TokenId:           1200,
+		CreatedTimestamp:  10001,
+		Decimals:          9,
+		InitialSupply:     120,
+		ModifiedTimestamp: 10001,
+		Name:              randstr.Hex(6),
+		SupplyKey:         supplyKey,
+		Symbol:            randstr.Hex(4),
+		TotalSupply:       200,
+		TreasuryAccountId: 1100,
+	}
+	dbClient.Create(token)
+	dbClient.Create([]*dbTypes.TokenTransfer{
+		{AccountId: 1100, Amount: 120, ConsensusTimestamp: 10001, TokenId: 1200},
+		{AccountId: 1100, Amount: -20, ConsensusTimestamp: 10005, TokenId: 1200},
+		{AccountId: 1101, Amount: 20, ConsensusTimestamp: 10005, TokenId: 1200},
+		{AccountId: 1100, Amount: 80, ConsensusTimestamp: 10010, TokenId: 1200},
+		{AccountId: 1100, Amount: 50, ConsensusTimestamp: 10010, TokenId: 1300},
+	})
+	dbClient.Exec("insert into custom_fee (created_timestamp, token_id) values (10001, 1200)")
+	dbClient.Exec(`insert into custom_fee (amount, amount_denominator, collector_account_id, created_timestamp,
+        maximum_amount, minimum_amount, net_of_transfers, token_id) values (1, 10, 1100, 10008, 5, 1, true, 1200)`)
+
+	expected := &types.TokenInfo{
+		Token: types.Token{
+			TokenId:       entityid.EntityId{EntityNum: 1200, EncodedId: 1200},
+			Decimals:      9,
+			InitialSupply: 120,
+			Name:          token.Name,
+			Symbol:        token.Symbol,
+		},
+		AdminKey:          adminKey,
+		CustomFees:        []types.CustomFee{},
+		MaxSupply:         9223372036854775807,
+		SupplyKey:         supplyKey,
+		SupplyType:        "INFINITE",
+		TotalSupply:       120,
+		TreasuryAccountId: types.Account{EntityId: entityid.EntityId{EntityNum: 1100, EncodedId: 1100}},
+		Type:              "FUNGIBLE_COMMON",
+	}
+	expectedLatest := *expected
+	expectedLatest.CustomFees = []types.CustomFee{
+		{
+			Amount:             1,
+			AmountDenominator:  10,
+			CollectorAccountId: entityid.EntityId{EntityNum: 1100, EncodedId: 1100},
+			MaximumAmount:      5,
+			MinimumAmount:      1,
+			NetOfTransfers:     true,
+			Type:               types.CustomFeeTypeFractional,
+		},
+	}
+	expectedLatest.TotalSupply = 200
+	repo := NewTokenRepository(dbClient)
+
+	var tests = []struct {
+		name      string
+		timestamp int64
+		expected  *types.TokenInfo
+	}{
+		{name: "AtCreation", timestamp: 10001, expected: expected},
+		{name: "AfterMint", timestamp: 20000, expected: &expectedLatest},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := repo.FindInfoAt("0.0.1200", tt.timestamp)
+
+			// then
+			assert.Equal(t, tt.expected, actual)
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func (suite *tokenRepositorySuite) TestFindInfoAtNft() {
+	// given
+	dbClient := suite.dbResource.GetGormDb()
+	dbClient.Create(&dbTypes.Token{
+		TokenId:           1200,
+		CreatedTimestamp:  10001,
+		ModifiedTimestamp: 10001,
+		Name:              randstr.Hex(6),
+		Symbol:            randstr.Hex(4),
+		TreasuryAccountId: 1100,
+	})
+	dbClient.Exec("update token set type = 'NON_FUNGIBLE_UNIQUE' where token_id = 1200")
+	treasury := int64(1100)
+	receiver := int64(1101)
+	dbClient.Create([]*dbTypes.NftTransfer{
+		{ConsensusTimestamp: 10002, ReceiverAccountId: &treasury, SerialNumber: 1, TokenId: 1200},
+		{ConsensusTimestamp: 10002, ReceiverAccountId: &treasury, SerialNumber: 2, TokenId: 1200},
+		{ConsensusTimestamp: 10003, ReceiverAccountId: &receiver, SenderAccountId: &treasury, SerialNumber: 1,
+			TokenId: 1200},
+		{ConsensusTimestamp: 10004, SenderAccountId: &treasury, SerialNumber: 2, TokenId: 1200},
+	})
+	repo := NewTokenRepository(dbClient)
+
+	// when
+	beforeBurn, beforeBurnErr := repo.FindInfoAt("0.0.1200", 10003)
+	afterBurn, afterBurnErr := repo.FindInfoAt("0.0.1200", 10004)
+
+	// then
+	assert.Nil(suite.T(), beforeBurnErr)
+	assert.Equal(suite.T(), int64(2), beforeBurn.TotalSupply)
+	assert.Equal(suite.T(), "NON_FUNGIBLE_UNIQUE", beforeBurn.Type)
+	assert.Nil(suite.T(), afterBurnErr)
+	assert.Equal(suite.T(), int64(1), afterBurn.TotalSupply)
+}
+
+func (suite *tokenRepositorySuite) TestFindInfoAtTokenNotFound() {
+	// given
+	repo := NewTokenRepository(suite.dbResource.GetGormDb())
+
+	// when
+	actual, err := repo.FindInfoAt("0.0.1200", 10001)
+
+	// then
+	assert.Equal(suite.T(), errors.ErrTokenNotFound, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *tokenRepositorySuite) TestFindInfoAtInvalidTokenId() {
+	// given
+	repo := NewTokenRepository(suite.dbResource.GetGormDb())
+
+	// when
+	actual, err := repo.FindInfoAt("abc", 10001)
+
+	// then
+	assert.Equal(suite.T(), errors.ErrInvalidToken, err)
+	assert.Nil(suite.T(), actual)
+}
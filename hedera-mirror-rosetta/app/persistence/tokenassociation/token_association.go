@@ -0,0 +1,125 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package tokenassociation
+
+import (
+	"database/sql"
+	"errors"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	hErrors "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	dbTypes "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/types"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	// selectTokenAssociationsByAccount selects the token associations of the account ordered by token id, starting
+	// after @after_token_id. A @limit of null means no limit
+	selectTokenAssociationsByAccount = `select *
+                                        from token_account
+                                        where account_id = @account_id and token_id > @after_token_id
+                                        order by token_id
+                                        limit @limit`
+)
+
+// tokenAssociationRepository struct that has connection to the Database
+type tokenAssociationRepository struct {
+	dbClient *gorm.DB
+}
+
+// NewTokenAssociationRepository creates an instance of a tokenAssociationRepository struct
+func NewTokenAssociationRepository(dbClient *gorm.DB) repositories.TokenAssociationRepository {
+	return &tokenAssociationRepository{dbClient: dbClient}
+}
+
+// Find returns the association of the account with the token. ErrTokenAssociationNotFound is returned if the account
+// has never been associated with the token. Auto association slots aren't tracked by the mirror node
+func (tr *tokenAssociationRepository) Find(accountIdStr string, tokenIdStr string) (
+	*types.TokenAssociation,
+	*rTypes.Error,
+) {
+	accountId, err := entityid.FromString(accountIdStr)
+	if err != nil {
+		return nil, hErrors.ErrInvalidAccount
+	}
+
+	tokenId, err := entityid.FromString(tokenIdStr)
+	if err != nil {
+		return nil, hErrors.ErrInvalidToken
+	}
+
+	tokenAccount := &dbTypes.TokenAccount{}
+	if err := tr.dbClient.
+		Where(&dbTypes.TokenAccount{AccountId: accountId.EncodedId, TokenId: tokenId.EncodedId}).
+		First(tokenAccount).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, hErrors.ErrTokenAssociationNotFound
+		}
+
+		log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, err)
+		return nil, hErrors.ErrDatabaseError
+	}
+
+	return tokenAccount.ToDomainTokenAssociation()
+}
+
+// FindByAccount returns at most limit associations of the account, including dissociated ones, ordered by token id
+// and starting after the encoded afterTokenId. A limit of 0 means no limit
+func (tr *tokenAssociationRepository) FindByAccount(accountIdStr string, afterTokenId int64, limit int) (
+	[]*types.TokenAssociation,
+	*rTypes.Error,
+) {
+	accountId, err := entityid.FromString(accountIdStr)
+	if err != nil {
+		return nil, hErrors.ErrInvalidAccount
+	}
+
+	var queryLimit interface{}
+	if limit > 0 {
+		queryLimit = limit
+	}
+
+	var tokenAccounts []dbTypes.TokenAccount
+	if err := tr.dbClient.Raw(
+		selectTokenAssociationsByAccount,
+		sql.Named("account_id", accountId.EncodedId),
+		sql.Named("after_token_id", afterTokenId),
+		sql.Named("limit", queryLimit),
+	).Scan(&tokenAccounts).Error; err != nil {
+		log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, err)
+		return nil, hErrors.ErrDatabaseError
+	}
+
+	tokenAssociations := make([]*types.TokenAssociation, 0, len(tokenAccounts))
+	for _, tokenAccount := range tokenAccounts {
+		tokenAssociation, rErr := tokenAccount.ToDomainTokenAssociation()
+		if rErr != nil {
+			return nil, rErr
+		}
+		tokenAssociations = append(tokenAssociations, tokenAssociation)
+	}
+
+	return tokenAssociations, nil
+}
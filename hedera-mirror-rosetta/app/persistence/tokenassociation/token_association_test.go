@@ -0,0 +1,198 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package tokenassociation
+
+import (
+	"testing"
+
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	dbTypes "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	accountId    int64 = 1100
+	accountIdStr       = "0.0.1100"
+)
+
+// run the suite
+func TestTokenAssociationRepositorySuite(t *testing.T) {
+	suite.Run(t, new(tokenAssociationRepositorySuite))
+}
+
+type tokenAssociationRepositorySuite struct {
+	suite.Suite
+	dbResource db.DbResource
+}
+
+func (suite *tokenAssociationRepositorySuite) SetupSuite() {
+	suite.dbResource = db.SetupDb()
+}
+
+func (suite *tokenAssociationRepositorySuite) TearDownSuite() {
+	db.TeardownDb(suite.dbResource)
+}
+
+func (suite *tokenAssociationRepositorySuite) SetupTest() {
+	db.CleanupDb(suite.dbResource.GetDb())
+}
+
+func (suite *tokenAssociationRepositorySuite) TestFind() {
+	// given
+	dbClient := suite.dbResource.GetGormDb()
+	suite.addTokenAccount(1200, true, 1, 2)
+	repo := NewTokenAssociationRepository(dbClient)
+	expected := &types.TokenAssociation{
+		AccountId:         entityid.EntityId{EntityNum: 1100, EncodedId: 1100},
+		Associated:        true,
+		CreatedTimestamp:  10001,
+		FreezeStatus:      types.TokenFreezeStatusFrozen,
+		KycStatus:         types.TokenKycStatusRevoked,
+		ModifiedTimestamp: 10002,
+		TokenId:           entityid.EntityId{EntityNum: 1200, EncodedId: 1200},
+	}
+
+	// when
+	actual, err := repo.Find(accountIdStr, "0.0.1200")
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expected, actual)
+}
+
+func (suite *tokenAssociationRepositorySuite) TestFindDissociated() {
+	// given
+	suite.addTokenAccount(1200, false, 0, 0)
+	repo := NewTokenAssociationRepository(suite.dbResource.GetGormDb())
+
+	// when
+	actual, err := repo.Find(accountIdStr, "0.0.1200")
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.False(suite.T(), actual.Associated)
+}
+
+func (suite *tokenAssociationRepositorySuite) TestFindNotFound() {
+	// given
+	suite.addTokenAccount(1201, true, 0, 0)
+	repo := NewTokenAssociationRepository(suite.dbResource.GetGormDb())
+
+	// when
+	actual, err := repo.Find(accountIdStr, "0.0.1200")
+
+	// then
+	assert.Equal(suite.T(), errors.ErrTokenAssociationNotFound, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *tokenAssociationRepositorySuite) TestFindInvalidId() {
+	// given
+	repo := NewTokenAssociationRepository(suite.dbResource.GetGormDb())
+
+	// when
+	_, accountErr := repo.Find("x.y.z", "0.0.1200")
+	_, tokenErr := repo.Find(accountIdStr, "x.y.z")
+
+	// then
+	assert.Equal(suite.T(), errors.ErrInvalidAccount, accountErr)
+	assert.Equal(suite.T(), errors.ErrInvalidToken, tokenErr)
+}
+
+func (suite *tokenAssociationRepositorySuite) TestFindByAccount() {
+	// given
+	suite.addTokenAccount(1202, true, 0, 0)
+	suite.addTokenAccount(1200, true, 0, 0)
+	suite.addTokenAccount(1201, false, 0, 0)
+	suite.addTokenAccount(1203, true, 0, 0)
+	// a different account
+	dbClient := suite.dbResource.GetGormDb()
+	dbClient.Create(&dbTypes.TokenAccount{
+		AccountId:         1101,
+		Associated:        true,
+		CreatedTimestamp:  10001,
+		ModifiedTimestamp: 10001,
+		TokenId:           1200,
+	})
+	repo := NewTokenAssociationRepository(dbClient)
+
+	var tests = []struct {
+		name         string
+		afterTokenId int64
+		limit        int
+		expected     []int64
+	}{
+		{name: "All", expected: []int64{1200, 1201, 1202, 1203}},
+		{name: "AfterTokenId", afterTokenId: 1201, expected: []int64{1202, 1203}},
+		{name: "Limit", limit: 2, expected: []int64{1200, 1201}},
+		{name: "AfterTokenIdAndLimit", afterTokenId: 1200, limit: 2, expected: []int64{1201, 1202}},
+		{name: "Empty", afterTokenId: 1203, expected: []int64{}},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := repo.FindByAccount(accountIdStr, tt.afterTokenId, tt.limit)
+
+			// then
+			assert.Nil(t, err)
+			actualTokenIds := make([]int64, 0, len(actual))
+			for _, tokenAssociation := range actual {
+				assert.Equal(t, accountId, tokenAssociation.AccountId.EncodedId)
+				actualTokenIds = append(actualTokenIds, tokenAssociation.TokenId.EncodedId)
+			}
+			assert.Equal(t, tt.expected, actualTokenIds)
+		})
+	}
+}
+
+func (suite *tokenAssociationRepositorySuite) TestFindByAccountInvalidId() {
+	// given
+	repo := NewTokenAssociationRepository(suite.dbResource.GetGormDb())
+
+	// when
+	actual, err := repo.FindByAccount("x.y.z", 0, 0)
+
+	// then
+	assert.Equal(suite.T(), errors.ErrInvalidAccount, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *tokenAssociationRepositorySuite) addTokenAccount(
+	tokenId int64,
+	associated bool,
+	freezeStatus int16,
+	kycStatus int16,
+) {
+	suite.dbResource.GetGormDb().Create(&dbTypes.TokenAccount{
+		AccountId:         accountId,
+		Associated:        associated,
+		CreatedTimestamp:  10001,
+		FreezeStatus:      freezeStatus,
+		KycStatus:         kycStatus,
+		ModifiedTimestamp: 10002,
+		TokenId:           tokenId,
+	})
+}
@@ -22,16 +22,19 @@ package transaction
 
 import (
 	"database/sql"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"sync"
+	"unicode/utf8"
 
 	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/mapper"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
 	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
 	hErrors "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
-	dbTypes "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/types"
 	hexUtils "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/hex"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/maphelper"
 	log "github.com/sirupsen/logrus"
@@ -40,9 +43,11 @@ import (
 
 const (
 	batchSize                   = 2000
+	memoEncodingBase64          = "base64"
 	tableNameTransactionResults = "t_transaction_results"
 	tableNameTransactionTypes   = "t_transaction_types"
 	transactionResultSuccess    = 22
+	unknownTransactionType      = "UNKNOWN"
 )
 
 const (
@@ -50,15 +55,20 @@ const (
 	orderByConsensusNs       = " order by consensus_ns"
 	selectTransactionResults = "select * from " + tableNameTransactionResults
 	selectTransactionTypes   = "select * from " + tableNameTransactionTypes
-	// selectTransactionsInTimestampRange selects the transactions with its crypto transfers in json, non-fee transfers
-	// in json, token transfers in json, and optionally the token information when the transaction is token create,
-	// token delete, or token update. Note the three token transactions are the ones the entity_id in the transaction
-	// table is its related token id and require an extra rosetta operation
-	selectTransactionsInTimestampRange = `select
+	// selectTransactions selects the transactions with its crypto transfers in json, non-fee transfers in json, token
+	// transfers in json, optionally the token information when the transaction is token create, token delete, or
+	// token update, and optionally the chunk info of the topic message when the transaction is consensus submit
+	// message. Note the three token transactions are the ones the entity_id in the transaction table is its related
+	// token id and require an extra rosetta operation
+	selectTransactions = `select
                                             t.consensus_ns,
+                                            t.charged_tx_fee,
+                                            coalesce(t.entity_id, 0) as entity_id,
+                                            t.memo,
                                             t.payer_account_id,
                                             t.transaction_hash as hash,
                                             t.result,
+                                            t.scheduled,
                                             t.type,
                                             coalesce((
                                               select json_agg(json_build_object(
@@ -100,11 +110,47 @@ const (
                                                   where token_id = t.entity_id
                                                 ), '{}')
                                               else '{}'
-                                            end as token
-                                          from transaction t
-                                          where consensus_ns >= @start and consensus_ns <= @end`
-	selectTransactionsByHashInTimestampRange  = selectTransactionsInTimestampRange + andTransactionHashFilter
-	selectTransactionsInTimestampRangeOrdered = selectTransactionsInTimestampRange + orderByConsensusNs
+                                            end as token,
+                                            case
+                                              when t.type = 27 then coalesce((
+                                                  select json_build_object(
+                                                    'chunk_num', chunk_num,
+                                                    'chunk_total', chunk_total,
+                                                    'payer_account_id', payer_account_id,
+                                                    'valid_start_timestamp', valid_start_timestamp
+                                                  )
+                                                  from topic_message
+                                                  where consensus_timestamp = t.consensus_ns
+                                                ), '{}')
+                                              else '{}'
+                                            end as topic_message
+                                          from transaction t`
+	selectTransactionsInTimestampRange = selectTransactions +
+		" where consensus_ns >= @start and consensus_ns <= @end"
+	// selectTransactionsByTransactionId selects the transactions with the payer and the valid start of the
+	// transaction id, optionally only the scheduled one
+	selectTransactionsByTransactionId = selectTransactions +
+		" where payer_account_id = @payer and valid_start_ns = @valid_start and (@scheduled = false or scheduled)" +
+		orderByConsensusNs
+	// selectRawTransactionsByHash selects the transaction bytes of the transactions with the hash, e.g., a transaction
+	// and its duplicates
+	selectRawTransactionsByHash = `select consensus_ns, result, transaction_bytes
+                                   from transaction
+                                   where transaction_hash = @hash` + orderByConsensusNs
+	selectTransactionsByHashInTimestampRange = selectTransactionsInTimestampRange + andTransactionHashFilter
+	// selectTransactionsPageInTimestampRange selects a page of the transactions in the timestamp range. The next page
+	// starts after the consensus timestamp of the last transaction, so neither an offset nor a count is needed
+	selectTransactionsPageInTimestampRange = selectTransactionsInTimestampRange + orderByConsensusNs + " limit @limit"
+	// selectTransactionsByHashesInTimestampRange selects the transactions in the timestamp range with any of the hashes
+	selectTransactionsByHashesInTimestampRange = selectTransactionsInTimestampRange +
+		" and transaction_hash in @hashes" + orderByConsensusNs
+	// selectTransactionHashesInTimestampRange selects the hashes of the transactions in the timestamp range, in the
+	// order of the first transaction of each hash
+	selectTransactionHashesInTimestampRange = `select transaction_hash as hash
+                                               from transaction
+                                               where consensus_ns >= @start and consensus_ns <= @end
+                                               group by transaction_hash
+                                               order by min(consensus_ns)`
 )
 
 type transactionType struct {
@@ -128,26 +174,157 @@ func (transactionResult) TableName() string {
 }
 
 // transaction maps to the transaction query which returns the required transaction fields, CryptoTransfers json string,
-// NonFeeTransfers json string, TokenTransfers json string, and Token definition json string
+// NonFeeTransfers json string, TokenTransfers json string, Token definition json string, and TopicMessage chunk info
+// json string
 type transaction struct {
 	ConsensusNs     int64
+	ChargedTxFee    int64
+	EntityId        int64
 	Hash            []byte
+	Memo            []byte
 	PayerAccountId  int64
 	Result          int16
+	Scheduled       bool
 	Type            int16
 	CryptoTransfers string
 	NonFeeTransfers string
 	TokenTransfers  string
 	Token           string
+	TopicMessage    string
 }
 
 func (t transaction) getHashString() string {
 	return hexUtils.SafeAddHexPrefix(hex.EncodeToString(t.Hash))
 }
 
-type transfer interface {
-	getAccount() types.Account
-	getAmount() types.Amount
+// toRecord maps the transaction row and the transfers aggregated into it to the domain transaction record
+func (t transaction) toRecord(transactionTypes, transactionResults map[int]string) (
+	*types.TransactionRecord,
+	*rTypes.Error,
+) {
+	payer, err := constructAccount(t.PayerAccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	cryptoTransfers := make([]hbarTransfer, 0)
+	if err := json.Unmarshal([]byte(t.CryptoTransfers), &cryptoTransfers); err != nil {
+		return nil, hErrors.ErrInternalServerError
+	}
+
+	nonFeeTransfers := make([]hbarTransfer, 0)
+	if err := json.Unmarshal([]byte(t.NonFeeTransfers), &nonFeeTransfers); err != nil {
+		return nil, hErrors.ErrInternalServerError
+	}
+
+	tokenTransfers := make([]tokenTransfer, 0)
+	if err := json.Unmarshal([]byte(t.TokenTransfers), &tokenTransfers); err != nil {
+		return nil, hErrors.ErrInternalServerError
+	}
+
+	token := &token{}
+	if err := json.Unmarshal([]byte(t.Token), token); err != nil {
+		return nil, hErrors.ErrInternalServerError
+	}
+
+	record := &types.TransactionRecord{
+		CryptoTransfers: make([]types.Transfer, 0, len(cryptoTransfers)),
+		NonFeeTransfers: make([]types.Transfer, 0, len(nonFeeTransfers)),
+		Payer:           payer,
+		Result:          transactionResults[int(t.Result)],
+		Token:           token.toDomain(),
+		TokenTransfers:  make([]types.Transfer, 0, len(tokenTransfers)),
+		Type:            t.Type,
+		TypeName:        getTransactionType(transactionTypes, t.Type),
+	}
+
+	for _, transfer := range cryptoTransfers {
+		record.CryptoTransfers = append(record.CryptoTransfers, transfer.toDomain())
+	}
+
+	for _, transfer := range nonFeeTransfers {
+		record.NonFeeTransfers = append(record.NonFeeTransfers, transfer.toDomain())
+	}
+
+	for _, transfer := range tokenTransfers {
+		record.TokenTransfers = append(record.TokenTransfers, transfer.toDomain())
+	}
+
+	return record, nil
+}
+
+// getMetadata returns the basic context of the transaction. The memo is returned as is if it's valid UTF-8, otherwise
+// it's base64 encoded and memo_encoding is set to base64
+func (t transaction) getMetadata(timestampStrings bool) (map[string]interface{}, *rTypes.Error) {
+	metadata := map[string]interface{}{"charged_fee": t.ChargedTxFee}
+	types.AddTimestampMetadata(metadata, "consensus_timestamp", t.ConsensusNs, timestampStrings)
+
+	if utf8.Valid(t.Memo) {
+		metadata["memo"] = string(t.Memo)
+	} else {
+		metadata["memo"] = base64.StdEncoding.EncodeToString(t.Memo)
+		metadata["memo_encoding"] = memoEncodingBase64
+	}
+
+	if t.EntityId != 0 {
+		entityId, err := entityid.Decode(t.EntityId)
+		if err != nil {
+			log.Errorf(hErrors.CreateAccountDbIdFailed, t.EntityId)
+			return nil, hErrors.ErrInternalServerError
+		}
+		metadata["entity_id"] = entityId.String()
+	}
+
+	if t.Type == types.TransactionTypeConsensusSubmitMessage {
+		chunkInfo := &topicMessageChunkInfo{}
+		if err := json.Unmarshal([]byte(t.TopicMessage), chunkInfo); err != nil {
+			return nil, hErrors.ErrInternalServerError
+		}
+
+		for key, value := range chunkInfo.getMetadata() {
+			metadata[key] = value
+		}
+	}
+
+	return metadata, nil
+}
+
+// topicMessageChunkInfo is the chunk info of the message submitted by a consensus submit message transaction. The
+// fields are nil if the message isn't chunked
+type topicMessageChunkInfo struct {
+	ChunkNum            *int32             `json:"chunk_num"`
+	ChunkTotal          *int32             `json:"chunk_total"`
+	PayerAccountId      *entityid.EntityId `json:"payer_account_id"`
+	ValidStartTimestamp *int64             `json:"valid_start_timestamp"`
+}
+
+// getMetadata returns the chunk number, the chunk total, and the id of the transaction which submitted the first chunk
+// in the format of shard.realm.num-seconds-nanos, so the chunks of a message can be reassembled. Nothing is returned
+// if the message isn't chunked
+func (c topicMessageChunkInfo) getMetadata() map[string]interface{} {
+	if c.ChunkNum == nil || c.ChunkTotal == nil {
+		return nil
+	}
+
+	metadata := map[string]interface{}{
+		"chunk_number": *c.ChunkNum,
+		"chunk_total":  *c.ChunkTotal,
+	}
+
+	if c.PayerAccountId != nil && c.ValidStartTimestamp != nil {
+		validStart := *c.ValidStartTimestamp
+		metadata["initial_transaction_id"] = fmt.Sprintf("%s-%d-%09d", c.PayerAccountId, validStart/1e9,
+			validStart%1e9)
+	}
+
+	return metadata
+}
+
+// rawTransaction maps to the raw transaction query
+type rawTransaction struct {
+	ConsensusNs      int64
+	Result           int16
+	TransactionBytes []byte
 }
 
 type hbarTransfer struct {
@@ -155,12 +332,11 @@ type hbarTransfer struct {
 	Amount    int64             `json:"amount"`
 }
 
-func (t hbarTransfer) getAccount() types.Account {
-	return types.Account{EntityId: t.AccountId}
-}
-
-func (t hbarTransfer) getAmount() types.Amount {
-	return &types.HbarAmount{Value: t.Amount}
+func (t hbarTransfer) toDomain() types.Transfer {
+	return types.Transfer{
+		Account: types.Account{EntityId: t.AccountId},
+		Amount:  &types.HbarAmount{Value: t.Amount},
+	}
 }
 
 type tokenTransfer struct {
@@ -170,15 +346,14 @@ type tokenTransfer struct {
 	TokenId   entityid.EntityId `json:"token_id"`
 }
 
-func (t tokenTransfer) getAccount() types.Account {
-	return types.Account{EntityId: t.AccountId}
-}
-
-func (t tokenTransfer) getAmount() types.Amount {
-	return &types.TokenAmount{
-		Decimals: t.Decimals,
-		TokenId:  t.TokenId,
-		Value:    t.Amount,
+func (t tokenTransfer) toDomain() types.Transfer {
+	return types.Transfer{
+		Account: types.Account{EntityId: t.AccountId},
+		Amount: &types.TokenAmount{
+			Decimals: t.Decimals,
+			TokenId:  t.TokenId,
+			Value:    t.Amount,
+		},
 	}
 }
 
@@ -189,25 +364,45 @@ type token struct {
 	TokenId       entityid.EntityId `json:"token_id"`
 }
 
-func (t token) getAmount() types.Amount {
-	return &types.TokenAmount{
-		TokenId:  t.TokenId,
-		Decimals: t.Decimals,
-		Value:    0,
+// toDomain returns the domain token, or nil if the transaction doesn't touch a token definition
+func (t token) toDomain() *types.Token {
+	if t.TokenId.IsZero() {
+		return nil
+	}
+
+	return &types.Token{
+		TokenId:       t.TokenId,
+		Decimals:      uint32(t.Decimals),
+		FreezeDefault: t.FreezeDefault,
+		InitialSupply: t.InitialSupply,
 	}
 }
 
 // transactionRepository struct that has connection to the Database
 type transactionRepository struct {
-	once     sync.Once
-	dbClient *gorm.DB
-	results  map[int]string
-	types    map[int]string
-}
-
-// NewTransactionRepository creates an instance of a TransactionRepository struct
-func NewTransactionRepository(dbClient *gorm.DB) repositories.TransactionRepository {
-	return &transactionRepository{dbClient: dbClient}
+	batchSize        int
+	once             sync.Once
+	dbClient         *gorm.DB
+	failedAmounts    string
+	results          map[int]string
+	timestampStrings bool
+	types            map[int]string
+}
+
+// NewTransactionRepository creates an instance of a TransactionRepository struct. failedAmounts is the mapper mode of
+// the amounts in the operations of a failed transaction, and timestampStrings controls if the timestamps in the
+// transaction metadata have their RFC3339 strings
+func NewTransactionRepository(
+	dbClient *gorm.DB,
+	failedAmounts string,
+	timestampStrings bool,
+) repositories.TransactionRepository {
+	return &transactionRepository{
+		batchSize:        batchSize,
+		dbClient:         dbClient,
+		failedAmounts:    failedAmounts,
+		timestampStrings: timestampStrings,
+	}
 }
 
 // Types returns map of all transaction types
@@ -232,38 +427,157 @@ func (tr *transactionRepository) Results() (map[int]string, *rTypes.Error) {
 	return tr.results, nil
 }
 
-// TypesAsArray returns all Transaction type names as an array
+// TypesAsArray returns all Transaction type names as an array, including the type of unknown transactions
 func (tr *transactionRepository) TypesAsArray() ([]string, *rTypes.Error) {
 	transactionTypes, err := tr.Types()
 	if err != nil {
 		return nil, err
 	}
-	return maphelper.GetStringValuesFromIntStringMap(transactionTypes), nil
+	return append(maphelper.GetStringValuesFromIntStringMap(transactionTypes), unknownTransactionType), nil
 }
 
-// FindBetween retrieves all Transactions between the provided start and end timestamp
+// FindBetween retrieves all Transactions between the provided start and end timestamp. They are queried in pages of
+// batchSize transactions keyed by the consensus timestamp
 func (tr *transactionRepository) FindBetween(start, end int64) ([]*types.Transaction, *rTypes.Error) {
 	if start > end {
 		return nil, hErrors.ErrStartMustNotBeAfterEnd
 	}
 
 	transactions := make([]*transaction, 0)
-
 	for start <= end {
-		transactionsBatch := make([]*transaction, 0)
-		tr.dbClient.
-			Raw(selectTransactionsInTimestampRangeOrdered, sql.Named("start", start), sql.Named("end", end)).
-			Limit(batchSize).
-			Find(&transactionsBatch)
+		transactionsBatch, err := tr.findPageBetween(start, end)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, transactionsBatch...)
+
+		if len(transactionsBatch) < tr.batchSize {
+			break
+		}
+
+		start = transactionsBatch[len(transactionsBatch)-1].ConsensusNs + 1
+	}
+
+	return tr.constructTransactions(transactions)
+}
+
+// FindBetweenWithMaxOperations retrieves the Transactions between the provided start and end timestamp in order as long
+// as their operations add up to at most maxOperations, and the hashes of the rest. The pages of batchSize transactions
+// are only queried until the operations exceed maxOperations, then the later records sharing a hash with the queried
+// ones, e.g., the duplicates, are added, and only the hashes of the later transactions are queried. This way an
+// oversized block is never fully loaded
+func (tr *transactionRepository) FindBetweenWithMaxOperations(start, end int64, maxOperations int) (
+	[]*types.Transaction,
+	[]string,
+	*rTypes.Error,
+) {
+	if start > end {
+		return nil, nil, hErrors.ErrStartMustNotBeAfterEnd
+	}
+
+	transactions := make([]*transaction, 0)
+	operationCount := 0
+	for start <= end && operationCount <= maxOperations {
+		transactionsBatch, err := tr.findPageBetween(start, end)
+		if err != nil {
+			return nil, nil, err
+		}
 		transactions = append(transactions, transactionsBatch...)
 
-		if len(transactionsBatch) < batchSize {
+		// the operations of the transactions sharing a hash add up to the operations of the records
+		for _, t := range transactionsBatch {
+			record, err := tr.constructTransaction([]*transaction{t})
+			if err != nil {
+				return nil, nil, err
+			}
+			operationCount += len(record.Operations)
+		}
+
+		if len(transactionsBatch) < tr.batchSize {
+			start = end + 1
 			break
 		}
 
 		start = transactionsBatch[len(transactionsBatch)-1].ConsensusNs + 1
 	}
 
+	if start <= end && len(transactions) != 0 {
+		hashes := make([][]byte, 0, len(transactions))
+		for _, t := range transactions {
+			hashes = append(hashes, t.Hash)
+		}
+
+		var sameHashTransactions []*transaction
+		if err := tr.dbClient.
+			Raw(
+				selectTransactionsByHashesInTimestampRange,
+				sql.Named("end", end),
+				sql.Named("hashes", hashes),
+				sql.Named("start", start),
+			).
+			Find(&sameHashTransactions).
+			Error; err != nil {
+			log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, err)
+			return nil, nil, hErrors.ErrDatabaseError
+		}
+		transactions = append(transactions, sameHashTransactions...)
+	}
+
+	res, rErr := tr.constructTransactions(transactions)
+	if rErr != nil {
+		return nil, nil, rErr
+	}
+	res, otherHashes := types.SplitTransactions(res, maxOperations)
+
+	if start <= end {
+		var laterTransactions []*transaction
+		if err := tr.dbClient.
+			Raw(selectTransactionHashesInTimestampRange, sql.Named("end", end), sql.Named("start", start)).
+			Find(&laterTransactions).
+			Error; err != nil {
+			log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, err)
+			return nil, nil, hErrors.ErrDatabaseError
+		}
+
+		queried := make(map[string]bool, len(transactions))
+		for _, t := range transactions {
+			queried[t.getHashString()] = true
+		}
+		for _, t := range laterTransactions {
+			if hash := t.getHashString(); !queried[hash] {
+				otherHashes = append(otherHashes, hash)
+			}
+		}
+	}
+
+	return res, otherHashes, nil
+}
+
+// findPageBetween retrieves the page of at most batchSize transactions from the start timestamp to the end timestamp
+func (tr *transactionRepository) findPageBetween(start, end int64) ([]*transaction, *rTypes.Error) {
+	transactions := make([]*transaction, 0)
+	if err := tr.dbClient.
+		Raw(
+			selectTransactionsPageInTimestampRange,
+			sql.Named("end", end),
+			sql.Named("limit", tr.batchSize),
+			sql.Named("start", start),
+		).
+		Find(&transactions).
+		Error; err != nil {
+		log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, err)
+		return nil, hErrors.ErrDatabaseError
+	}
+
+	return transactions, nil
+}
+
+// constructTransactions groups the transactions by hash and constructs one Transaction per hash, in the order of the
+// first transaction of each hash
+func (tr *transactionRepository) constructTransactions(transactions []*transaction) (
+	[]*types.Transaction,
+	*rTypes.Error,
+) {
 	hashes := make([]string, 0)
 	sameHashMap := make(map[string][]*transaction)
 	for _, t := range transactions {
@@ -320,6 +634,89 @@ func (tr *transactionRepository) FindByHashInBlock(
 	return transaction, nil
 }
 
+// FindByTransactionId retrieves all transactions with the payer and the valid start of the transaction id, ordered by
+// consensus timestamp. Unlike the transactions in a block, the ones sharing a hash, e.g., the duplicates and the failed
+// attempts, are returned separately, and so is the transaction executed by a schedule unless the transaction id is
+// scheduled, in which case only the scheduled one is returned. The transaction table predates child transactions and
+// doesn't record the nonce, so a transaction id with a positive nonce is never found
+func (tr *transactionRepository) FindByTransactionId(transactionId types.TransactionId) (
+	[]*types.Transaction,
+	*rTypes.Error,
+) {
+	if transactionId.Nonce != 0 {
+		return nil, hErrors.ErrTransactionNotFound
+	}
+
+	var transactions []*transaction
+	if err := tr.dbClient.
+		Raw(
+			selectTransactionsByTransactionId,
+			sql.Named("payer", transactionId.Payer.EncodedId),
+			sql.Named("scheduled", transactionId.Scheduled),
+			sql.Named("valid_start", transactionId.ValidStartNs),
+		).
+		Find(&transactions).
+		Error; err != nil {
+		log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, err)
+		return nil, hErrors.ErrDatabaseError
+	}
+
+	if len(transactions) == 0 {
+		return nil, hErrors.ErrTransactionNotFound
+	}
+
+	res := make([]*types.Transaction, 0, len(transactions))
+	for _, t := range transactions {
+		transaction, err := tr.constructTransaction([]*transaction{t})
+		if err != nil {
+			return nil, err
+		}
+
+		transaction.Metadata["scheduled"] = t.Scheduled
+		res = append(res, transaction)
+	}
+
+	return res, nil
+}
+
+// FindRawByHash retrieves the transaction bytes of all transactions with the hash, ordered by consensus timestamp, so
+// they can be decoded without database access. The bytes are nil unless the importer persists them
+func (tr *transactionRepository) FindRawByHash(hashStr string) ([]*types.RawTransaction, *rTypes.Error) {
+	transactionHash, err := hex.DecodeString(hexUtils.SafeRemoveHexPrefix(hashStr))
+	if err != nil {
+		return nil, hErrors.ErrInvalidTransactionIdentifier
+	}
+
+	transactionResults, rErr := tr.Results()
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	var rawTransactions []rawTransaction
+	if err := tr.dbClient.
+		Raw(selectRawTransactionsByHash, sql.Named("hash", transactionHash)).
+		Find(&rawTransactions).
+		Error; err != nil {
+		log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, err)
+		return nil, hErrors.ErrDatabaseError
+	}
+
+	if len(rawTransactions) == 0 {
+		return nil, hErrors.ErrTransactionNotFound
+	}
+
+	res := make([]*types.RawTransaction, 0, len(rawTransactions))
+	for _, raw := range rawTransactions {
+		res = append(res, &types.RawTransaction{
+			ConsensusTimestamp: raw.ConsensusNs,
+			Result:             transactionResults[int(raw.Result)],
+			TransactionBytes:   raw.TransactionBytes,
+		})
+	}
+
+	return res, nil
+}
+
 func (tr *transactionRepository) retrieveTransactionTypes() []transactionType {
 	var transactionTypes []transactionType
 	tr.dbClient.Raw(selectTransactionTypes).Find(&transactionTypes)
@@ -346,99 +743,24 @@ func (tr *transactionRepository) constructTransaction(sameHashTransactions []*tr
 		return nil, err
 	}
 
-	tResult := &types.Transaction{Hash: sameHashTransactions[0].getHashString()}
-	operations := make([]*types.Operation, 0)
-	success := transactionResults[transactionResultSuccess]
-
+	hash := sameHashTransactions[0].getHashString()
+	records := make([]*types.TransactionRecord, 0, len(sameHashTransactions))
 	for _, transaction := range sameHashTransactions {
-		cryptoTransfers := make([]hbarTransfer, 0)
-		if err := json.Unmarshal([]byte(transaction.CryptoTransfers), &cryptoTransfers); err != nil {
-			return nil, hErrors.ErrInternalServerError
-		}
-
-		nonFeeTransfers := make([]hbarTransfer, 0)
-		if err := json.Unmarshal([]byte(transaction.NonFeeTransfers), &nonFeeTransfers); err != nil {
-			return nil, hErrors.ErrInternalServerError
-		}
-
-		tokenTransfers := make([]tokenTransfer, 0)
-		if err := json.Unmarshal([]byte(transaction.TokenTransfers), &tokenTransfers); err != nil {
-			return nil, hErrors.ErrInternalServerError
-		}
-
-		token := &token{}
-		if err := json.Unmarshal([]byte(transaction.Token), token); err != nil {
-			return nil, hErrors.ErrInternalServerError
+		record, err := transaction.toRecord(transactionTypes, transactionResults)
+		if err != nil {
+			return nil, err
 		}
 
-		transactionResult := transactionResults[int(transaction.Result)]
-		transactionType := transactionTypes[int(transaction.Type)]
-
-		nonFeeTransferMap := aggregateNonFeeTransfers(nonFeeTransfers)
-		adjustedCryptoTransfers := adjustCryptoTransfers(cryptoTransfers, nonFeeTransferMap)
-
-		operations = tr.appendHbarTransferOperations(transactionResult, transactionType, nonFeeTransfers, operations)
-		// crypto transfers are always successful regardless of the transaction result
-		operations = tr.appendHbarTransferOperations(success, transactionType, adjustedCryptoTransfers, operations)
-		operations = tr.appendTokenTransferOperations(transactionResult, transactionType, tokenTransfers, operations)
-
-		if !token.TokenId.IsZero() {
-			operation, err := getTokenOperation(len(operations), token, transaction, transactionResult, transactionType)
-			if err != nil {
-				return nil, err
-			}
-			operations = append(operations, operation)
+		if record.Metadata, err = transaction.getMetadata(tr.timestampStrings); err != nil {
+			return nil, err
 		}
-	}
-
-	tResult.Operations = operations
-	return tResult, nil
-}
-
-func (tr *transactionRepository) appendHbarTransferOperations(
-	transactionResult string,
-	transactionType string,
-	hbarTransfers []hbarTransfer,
-	operations []*types.Operation,
-) []*types.Operation {
-	transfers := make([]transfer, 0, len(hbarTransfers))
-	for _, hbarTransfer := range hbarTransfers {
-		transfers = append(transfers, hbarTransfer)
-	}
-
-	return tr.appendTransferOperations(transactionResult, transactionType, transfers, operations)
-}
 
-func (tr *transactionRepository) appendTokenTransferOperations(
-	transactionResult string,
-	transactionType string,
-	tokenTransfers []tokenTransfer,
-	operations []*types.Operation,
-) []*types.Operation {
-	transfers := make([]transfer, 0, len(tokenTransfers))
-	for _, tokenTransfer := range tokenTransfers {
-		transfers = append(transfers, tokenTransfer)
+		record.Hash = hash
+		records = append(records, record)
 	}
 
-	return tr.appendTransferOperations(transactionResult, transactionType, transfers, operations)
-}
-
-func (tr *transactionRepository) appendTransferOperations(
-	transactionResult string,
-	transactionType string,
-	transfers []transfer,
-	operations []*types.Operation,
-) []*types.Operation {
-	for _, transfer := range transfers {
-		operations = append(operations, &types.Operation{
-			Index:   int64(len(operations)),
-			Type:    transactionType,
-			Status:  transactionResult,
-			Account: transfer.getAccount(),
-			Amount:  transfer.getAmount(),
-		})
-	}
-	return operations
+	success := transactionResults[transactionResultSuccess]
+	return mapper.ToTransaction(records, success, tr.failedAmounts, tr.timestampStrings), nil
 }
 
 func (tr *transactionRepository) retrieveTransactionTypesAndResults() *rTypes.Error {
@@ -470,6 +792,16 @@ func (tr *transactionRepository) retrieveTransactionTypesAndResults() *rTypes.Er
 	return nil
 }
 
+// getTransactionType returns the name of the transaction type, or unknownTransactionType if the type doesn't exist,
+// e.g., a transaction type introduced after the reference data of the importer is updated
+func getTransactionType(transactionTypes map[int]string, transactionType int16) string {
+	if name, ok := transactionTypes[int(transactionType)]; ok {
+		return name
+	}
+
+	return unknownTransactionType
+}
+
 func IsTransactionResultSuccessful(result int) bool {
 	return result == transactionResultSuccess
 }
@@ -482,77 +814,3 @@ func constructAccount(encodedId int64) (types.Account, *rTypes.Error) {
 	}
 	return account, nil
 }
-
-func adjustCryptoTransfers(
-	cryptoTransfers []hbarTransfer,
-	nonFeeTransferMap map[int64]int64,
-) []hbarTransfer {
-	cryptoTransferMap := make(map[int64]hbarTransfer)
-	for _, transfer := range cryptoTransfers {
-		key := transfer.AccountId.EncodedId
-		cryptoTransferMap[key] = hbarTransfer{
-			AccountId: transfer.AccountId,
-			Amount:    transfer.Amount + cryptoTransferMap[key].Amount,
-		}
-	}
-
-	adjusted := make([]hbarTransfer, 0, len(cryptoTransfers))
-	for key, aggregated := range cryptoTransferMap {
-		amount := aggregated.Amount - nonFeeTransferMap[key]
-		if amount != 0 {
-			adjusted = append(adjusted, hbarTransfer{
-				AccountId: aggregated.AccountId,
-				Amount:    amount,
-			})
-		}
-	}
-
-	return adjusted
-}
-
-func aggregateNonFeeTransfers(nonFeeTransfers []hbarTransfer) map[int64]int64 {
-	nonFeeTransferMap := make(map[int64]int64)
-
-	// the original transfer list from the transaction body
-	for _, transfer := range nonFeeTransfers {
-		// the original transfer list may have multiple entries for one entity, so accumulate it
-		nonFeeTransferMap[transfer.AccountId.EncodedId] += transfer.Amount
-	}
-
-	return nonFeeTransferMap
-}
-
-func getTokenOperation(
-	index int,
-	token *token,
-	transaction *transaction,
-	transactionResult string,
-	transactionType string,
-) (*types.Operation, *rTypes.Error) {
-	payerId, rErr := constructAccount(transaction.PayerAccountId)
-	if rErr != nil {
-		return nil, rErr
-	}
-
-	operation := &types.Operation{
-		Index:   int64(index),
-		Type:    transactionType,
-		Status:  transactionResult,
-		Account: payerId,
-		Amount:  token.getAmount(),
-	}
-
-	if transaction.Type == dbTypes.TransactionTypeTokenCreation {
-		// token creation shouldn't have Amount
-		operation.Amount = nil
-		metadata := make(map[string]interface{})
-		operation.Metadata = metadata
-
-		// best effort for immutable fields
-		metadata["decimals"] = token.Decimals
-		metadata["freeze_default"] = token.FreezeDefault
-		metadata["initial_supply"] = token.InitialSupply
-	}
-
-	return operation, nil
-}
@@ -23,14 +23,17 @@ package transaction
 import (
 	"testing"
 
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/mapper"
 	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
 	dbTypes "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/types"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/db"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/domain"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
 )
 
 const (
@@ -63,31 +66,247 @@ func TestTransactionGetHashString(t *testing.T) {
 	assert.Equal(t, "0x010203aaff", tx.getHashString())
 }
 
-func TestHbarTransferGetAccount(t *testing.T) {
-	hbarTransfer := hbarTransfer{AccountId: entityid.EntityId{EntityNum: 1, EncodedId: 1}}
-	assert.Equal(t, types.Account{EntityId: entityid.EntityId{EntityNum: 1, EncodedId: 1}}, hbarTransfer.getAccount())
-}
+func TestTransactionGetMetadata(t *testing.T) {
+	var tests = []struct {
+		name             string
+		tx               transaction
+		timestampStrings bool
+		expected         map[string]interface{}
+		wantErr          bool
+	}{
+		{
+			name: "Utf8Memo",
+			tx:   transaction{ChargedTxFee: 17, ConsensusNs: 100, EntityId: tokenId1.EncodedId, Memo: []byte("transfer")},
+			expected: map[string]interface{}{
+				"charged_fee":         int64(17),
+				"consensus_timestamp": int64(100),
+				"entity_id":           "0.0.25636",
+				"memo":                "transfer",
+			},
+		},
+		{
+			name: "NonUtf8Memo",
+			tx:   transaction{ChargedTxFee: 17, ConsensusNs: 100, Memo: []byte{0xff, 0xfe}},
+			expected: map[string]interface{}{
+				"charged_fee":         int64(17),
+				"consensus_timestamp": int64(100),
+				"memo":                "//4=",
+				"memo_encoding":       "base64",
+			},
+		},
+		{
+			name:             "TimestampStrings",
+			tx:               transaction{ChargedTxFee: 17, ConsensusNs: 100, Memo: []byte("transfer")},
+			timestampStrings: true,
+			expected: map[string]interface{}{
+				"charged_fee":                 int64(17),
+				"consensus_timestamp":         int64(100),
+				"consensus_timestamp_rfc3339": "1970-01-01T00:00:00.0000001Z",
+				"memo":                        "transfer",
+			},
+		},
+		{
+			name: "ChunkedTopicMessage",
+			tx: transaction{
+				ChargedTxFee: 17,
+				ConsensusNs:  100,
+				Memo:         []byte("message"),
+				Type:         types.TransactionTypeConsensusSubmitMessage,
+				TopicMessage: `{"chunk_num": 2, "chunk_total": 3, "payer_account_id": 1001,
+					"valid_start_timestamp": 1623101500000000123}`,
+			},
+			expected: map[string]interface{}{
+				"charged_fee":            int64(17),
+				"chunk_number":           int32(2),
+				"chunk_total":            int32(3),
+				"consensus_timestamp":    int64(100),
+				"initial_transaction_id": "0.0.1001-1623101500-000000123",
+				"memo":                   "message",
+			},
+		},
+		{
+			name: "ChunkedTopicMessageWithoutInitialTransactionId",
+			tx: transaction{
+				ChargedTxFee: 17,
+				ConsensusNs:  100,
+				Type:         types.TransactionTypeConsensusSubmitMessage,
+				TopicMessage: `{"chunk_num": 1, "chunk_total": 1, "payer_account_id": null,
+					"valid_start_timestamp": null}`,
+			},
+			expected: map[string]interface{}{
+				"charged_fee":         int64(17),
+				"chunk_number":        int32(1),
+				"chunk_total":         int32(1),
+				"consensus_timestamp": int64(100),
+				"memo":                "",
+			},
+		},
+		{
+			name: "UnchunkedTopicMessage",
+			tx: transaction{
+				ChargedTxFee: 17,
+				ConsensusNs:  100,
+				Type:         types.TransactionTypeConsensusSubmitMessage,
+				TopicMessage: `{"chunk_num": null, "chunk_total": null, "payer_account_id": null,
+					"valid_start_timestamp": null}`,
+			},
+			expected: map[string]interface{}{
+				"charged_fee":         int64(17),
+				"consensus_timestamp": int64(100),
+				"memo":                "",
+			},
+		},
+		{
+			name:    "InvalidEntityId",
+			tx:      transaction{EntityId: -1},
+			wantErr: true,
+		},
+		{
+			name:    "InvalidTopicMessage",
+			tx:      transaction{Type: types.TransactionTypeConsensusSubmitMessage, TopicMessage: "chunk"},
+			wantErr: true,
+		},
+	}
 
-func TestHbarTransferGetAmount(t *testing.T) {
-	hbarTransfer := hbarTransfer{Amount: 10}
-	assert.Equal(t, &types.HbarAmount{Value: 10}, hbarTransfer.getAmount())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := tt.tx.getMetadata(tt.timestampStrings)
+
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				assert.Nil(t, actual)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expected, actual)
+			}
+		})
+	}
 }
 
-func TestTokenTransferGetAccount(t *testing.T) {
-	tokenTransfer := tokenTransfer{AccountId: entityid.EntityId{EntityNum: 1, EncodedId: 1}}
-	assert.Equal(t, types.Account{EntityId: entityid.EntityId{EntityNum: 1, EncodedId: 1}}, tokenTransfer.getAccount())
+func TestHbarTransferToDomain(t *testing.T) {
+	hbarTransfer := hbarTransfer{AccountId: entityid.EntityId{EntityNum: 1, EncodedId: 1}, Amount: 10}
+	expected := types.Transfer{
+		Account: types.Account{EntityId: entityid.EntityId{EntityNum: 1, EncodedId: 1}},
+		Amount:  &types.HbarAmount{Value: 10},
+	}
+	assert.Equal(t, expected, hbarTransfer.toDomain())
 }
 
-func TestTokenTransferGetAmount(t *testing.T) {
+func TestTokenTransferToDomain(t *testing.T) {
 	tokenId := entityid.EntityId{EntityNum: 123, EncodedId: 123}
-	tokenTransfer := tokenTransfer{Amount: 10, Decimals: 3, TokenId: tokenId}
-	assert.Equal(t, &types.TokenAmount{Decimals: 3, Value: 10, TokenId: tokenId}, tokenTransfer.getAmount())
+	tokenTransfer := tokenTransfer{
+		AccountId: entityid.EntityId{EntityNum: 1, EncodedId: 1},
+		Amount:    10,
+		Decimals:  3,
+		TokenId:   tokenId,
+	}
+	expected := types.Transfer{
+		Account: types.Account{EntityId: entityid.EntityId{EntityNum: 1, EncodedId: 1}},
+		Amount:  &types.TokenAmount{Decimals: 3, Value: 10, TokenId: tokenId},
+	}
+	assert.Equal(t, expected, tokenTransfer.toDomain())
 }
 
-func TestTokenGetAmount(t *testing.T) {
+func TestTokenToDomain(t *testing.T) {
 	tokenId := entityid.EntityId{EntityNum: 123, EncodedId: 123}
-	token := token{Decimals: 5, TokenId: tokenId}
-	assert.Equal(t, &types.TokenAmount{Decimals: 5, TokenId: tokenId}, token.getAmount())
+	token := token{Decimals: 5, FreezeDefault: true, InitialSupply: 100, TokenId: tokenId}
+	expected := &types.Token{TokenId: tokenId, Decimals: 5, FreezeDefault: true, InitialSupply: 100}
+	assert.Equal(t, expected, token.toDomain())
+}
+
+func TestTokenToDomainWithoutToken(t *testing.T) {
+	assert.Nil(t, token{}.toDomain())
+}
+
+func TestTransactionToRecord(t *testing.T) {
+	// given
+	tx := transaction{
+		CryptoTransfers: `[{"account_id": 98, "amount": 5}, {"account_id": 58, "amount": -5}]`,
+		NonFeeTransfers: `[]`,
+		PayerAccountId:  58,
+		Result:          22,
+		Token:           `{}`,
+		TokenTransfers:  `[]`,
+		Type:            14,
+	}
+	payer := types.Account{EntityId: entityid.EntityId{EntityNum: 58, EncodedId: 58}}
+	expected := &types.TransactionRecord{
+		CryptoTransfers: []types.Transfer{
+			{
+				Account: types.Account{EntityId: entityid.EntityId{EntityNum: 98, EncodedId: 98}},
+				Amount:  &types.HbarAmount{Value: 5},
+			},
+			{Account: payer, Amount: &types.HbarAmount{Value: -5}},
+		},
+		NonFeeTransfers: []types.Transfer{},
+		Payer:           payer,
+		Result:          resultSuccess,
+		TokenTransfers:  []types.Transfer{},
+		Type:            14,
+		TypeName:        "CRYPTOTRANSFER",
+	}
+
+	// when
+	actual, err := tx.toRecord(map[int]string{14: "CRYPTOTRANSFER"}, map[int]string{22: resultSuccess})
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestTransactionToRecordThrows(t *testing.T) {
+	var tests = []struct {
+		name string
+		tx   transaction
+	}{
+		{name: "InvalidPayer", tx: transaction{PayerAccountId: -1}},
+		{name: "InvalidCryptoTransfers", tx: transaction{CryptoTransfers: "transfers"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := tt.tx.toRecord(map[int]string{}, map[int]string{})
+
+			// then
+			assert.Equal(t, errors.ErrInternalServerError, err)
+			assert.Nil(t, actual)
+		})
+	}
+}
+
+func TestGetTransactionType(t *testing.T) {
+	transactionTypes := map[int]string{14: "CRYPTOTRANSFER"}
+
+	var tests = []struct {
+		name            string
+		transactionType int16
+		expected        string
+	}{
+		{name: "Known", transactionType: 14, expected: "CRYPTOTRANSFER"},
+		{name: "Unknown", transactionType: 1000, expected: "UNKNOWN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getTransactionType(transactionTypes, tt.transactionType))
+		})
+	}
+}
+
+func TestFindByTransactionIdThrowsDbError(t *testing.T) {
+	// given
+	dbClient, mock := mocks.DatabaseMock(t)
+	mock.ExpectQuery(selectTransactionsByTransactionId).WillReturnError(gorm.ErrInvalidTransaction)
+	repo := NewTransactionRepository(dbClient, mapper.FailedAmountsIntended, false)
+
+	// when
+	actual, err := repo.FindByTransactionId(types.TransactionId{Payer: firstAccount, ValidStartNs: consensusStart})
+
+	// then
+	assert.Equal(t, errors.ErrDatabaseError, err)
+	assert.Nil(t, actual)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestShouldFailConstructAccount(t *testing.T) {
@@ -158,6 +377,7 @@ func assertTransactions(t *testing.T, expected, actual []*types.Transaction) {
 		assert.Contains(t, expectedTransactionMap, txHash)
 		expectedTx := expectedTransactionMap[txHash]
 		assert.ElementsMatch(t, actualTx.Operations, expectedTx.Operations)
+		assert.Equal(t, expectedTx.Metadata, actualTx.Metadata)
 	}
 }
 
@@ -183,35 +403,49 @@ func (suite *transactionRepositorySuite) SetupTest() {
 }
 
 func (suite *transactionRepositorySuite) TestNewTransactionRepository() {
-	t := NewTransactionRepository(suite.dbResource.GetGormDb())
+	t := NewTransactionRepository(suite.dbResource.GetGormDb(), mapper.FailedAmountsIntended, false)
 	assert.NotNil(suite.T(), t)
 }
 
 func (suite *transactionRepositorySuite) TestTypes() {
-	t := NewTransactionRepository(suite.dbResource.GetGormDb())
+	t := NewTransactionRepository(suite.dbResource.GetGormDb(), mapper.FailedAmountsIntended, false)
 	actual, err := t.Types()
 	assert.Nil(suite.T(), err)
 	assert.NotEmpty(suite.T(), actual)
 }
 
 func (suite *transactionRepositorySuite) TestResults() {
-	t := NewTransactionRepository(suite.dbResource.GetGormDb())
+	t := NewTransactionRepository(suite.dbResource.GetGormDb(), mapper.FailedAmountsIntended, false)
 	actual, err := t.Results()
 	assert.Nil(suite.T(), err)
 	assert.NotEmpty(suite.T(), actual)
 }
 
 func (suite *transactionRepositorySuite) TestTypesAsArray() {
-	t := NewTransactionRepository(suite.dbResource.GetGormDb())
+	t := NewTransactionRepository(suite.dbResource.GetGormDb(), mapper.FailedAmountsIntended, false)
 	actual, err := t.TypesAsArray()
 	assert.Nil(suite.T(), err)
 	assert.NotEmpty(suite.T(), actual)
+	assert.Contains(suite.T(), actual, "UNKNOWN")
 }
 
 func (suite *transactionRepositorySuite) TestFindBetween() {
 	// given
 	expected := suite.setupDb(true)
-	t := NewTransactionRepository(suite.dbResource.GetGormDb())
+	t := NewTransactionRepository(suite.dbResource.GetGormDb(), mapper.FailedAmountsIntended, false)
+
+	// when
+	actual, err := t.FindBetween(consensusStart, consensusEnd)
+
+	// then
+	assert.Nil(suite.T(), err)
+	assertTransactions(suite.T(), expected, actual)
+}
+
+func (suite *transactionRepositorySuite) TestFindBetweenInPages() {
+	// given
+	expected := suite.setupDb(true)
+	t := &transactionRepository{batchSize: 1, dbClient: suite.dbResource.GetGormDb()}
 
 	// when
 	actual, err := t.FindBetween(consensusStart, consensusEnd)
@@ -221,10 +455,48 @@ func (suite *transactionRepositorySuite) TestFindBetween() {
 	assertTransactions(suite.T(), expected, actual)
 }
 
+func (suite *transactionRepositorySuite) TestFindBetweenWithMaxOperations() {
+	// given
+	suite.setupDb(true)
+	t := &transactionRepository{batchSize: 1, dbClient: suite.dbResource.GetGormDb()}
+	all, err := t.FindBetween(consensusStart, consensusEnd)
+	assert.Nil(suite.T(), err)
+	otherHashes := make([]string, 0)
+	for _, transaction := range all[1:] {
+		otherHashes = append(otherHashes, transaction.Hash)
+	}
+
+	// when
+	actual, actualOtherHashes, err := t.FindBetweenWithMaxOperations(consensusStart, consensusEnd,
+		len(all[0].Operations))
+	actualAll, actualAllOtherHashes, allErr := t.FindBetweenWithMaxOperations(consensusStart, consensusEnd, 10000)
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), all[:1], actual)
+	assert.Equal(suite.T(), otherHashes, actualOtherHashes)
+	assert.Nil(suite.T(), allErr)
+	assert.Equal(suite.T(), all, actualAll)
+	assert.Empty(suite.T(), actualAllOtherHashes)
+}
+
+func (suite *transactionRepositorySuite) TestFindBetweenWithMaxOperationsThrowsWhenStartAfterEnd() {
+	// given
+	t := NewTransactionRepository(suite.dbResource.GetGormDb(), mapper.FailedAmountsIntended, false)
+
+	// when
+	actual, otherHashes, err := t.FindBetweenWithMaxOperations(consensusStart, consensusStart-1, 10)
+
+	// then
+	assert.Equal(suite.T(), errors.ErrStartMustNotBeAfterEnd, err)
+	assert.Nil(suite.T(), actual)
+	assert.Nil(suite.T(), otherHashes)
+}
+
 func (suite *transactionRepositorySuite) TestFindBetweenNoTokenEntity() {
 	// given
 	expected := suite.setupDb(false)
-	t := NewTransactionRepository(suite.dbResource.GetGormDb())
+	t := NewTransactionRepository(suite.dbResource.GetGormDb(), mapper.FailedAmountsIntended, false)
 
 	// when
 	actual, err := t.FindBetween(consensusStart, consensusEnd)
@@ -236,7 +508,7 @@ func (suite *transactionRepositorySuite) TestFindBetweenNoTokenEntity() {
 
 func (suite *transactionRepositorySuite) TestFindBetweenThrowsWhenStartAfterEnd() {
 	// given
-	t := NewTransactionRepository(suite.dbResource.GetGormDb())
+	t := NewTransactionRepository(suite.dbResource.GetGormDb(), mapper.FailedAmountsIntended, false)
 
 	// when
 	actual, err := t.FindBetween(consensusStart, consensusStart-1)
@@ -249,7 +521,7 @@ func (suite *transactionRepositorySuite) TestFindBetweenThrowsWhenStartAfterEnd(
 func (suite *transactionRepositorySuite) TestFindByHashInBlock() {
 	// given
 	expected := suite.setupDb(true)
-	t := NewTransactionRepository(suite.dbResource.GetGormDb())
+	t := NewTransactionRepository(suite.dbResource.GetGormDb(), mapper.FailedAmountsIntended, false)
 
 	// when
 	actual, err := t.FindByHashInBlock(expected[0].Hash, consensusStart, consensusEnd)
@@ -262,7 +534,7 @@ func (suite *transactionRepositorySuite) TestFindByHashInBlock() {
 func (suite *transactionRepositorySuite) TestFindByHashInBlockNoTokenEntity() {
 	// given
 	expected := suite.setupDb(false)
-	t := NewTransactionRepository(suite.dbResource.GetGormDb())
+	t := NewTransactionRepository(suite.dbResource.GetGormDb(), mapper.FailedAmountsIntended, false)
 
 	// when
 	actual, err := t.FindByHashInBlock(expected[1].Hash, consensusStart, consensusEnd)
@@ -274,7 +546,7 @@ func (suite *transactionRepositorySuite) TestFindByHashInBlockNoTokenEntity() {
 
 func (suite *transactionRepositorySuite) TestFindByHashThrowsInvalidHash() {
 	// given
-	t := NewTransactionRepository(suite.dbResource.GetGormDb())
+	t := NewTransactionRepository(suite.dbResource.GetGormDb(), mapper.FailedAmountsIntended, false)
 
 	// when
 	actual, err := t.FindByHashInBlock("invalid hash", consensusStart, consensusEnd)
@@ -286,7 +558,7 @@ func (suite *transactionRepositorySuite) TestFindByHashThrowsInvalidHash() {
 
 func (suite *transactionRepositorySuite) TestFindByHashThrowsNotFound() {
 	// given
-	t := NewTransactionRepository(suite.dbResource.GetGormDb())
+	t := NewTransactionRepository(suite.dbResource.GetGormDb(), mapper.FailedAmountsIntended, false)
 
 	// when
 	actual, err := t.FindByHashInBlock("0x123456", consensusStart, consensusEnd)
@@ -296,6 +568,95 @@ func (suite *transactionRepositorySuite) TestFindByHashThrowsNotFound() {
 	assert.Nil(suite.T(), actual)
 }
 
+func (suite *transactionRepositorySuite) TestFindByTransactionId() {
+	// given
+	suite.setupDb(true)
+	t := NewTransactionRepository(suite.dbResource.GetGormDb(), mapper.FailedAmountsIntended, false)
+	transactionId := types.TransactionId{Payer: firstAccount, ValidStartNs: consensusStart - 10}
+
+	// when
+	actual, err := t.FindByTransactionId(transactionId)
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.Len(suite.T(), actual, 2)
+	for i, transaction := range actual {
+		assert.Equal(suite.T(), "0x010203", transaction.Hash)
+		assert.Equal(suite.T(), consensusStart+1+int64(i), transaction.Metadata["consensus_timestamp"])
+		assert.Equal(suite.T(), false, transaction.Metadata["scheduled"])
+	}
+	assert.Equal(suite.T(), resultSuccess, actual[0].Operations[0].Status)
+	assert.Equal(suite.T(), "DUPLICATE_TRANSACTION", actual[1].Operations[0].Status)
+}
+
+func (suite *transactionRepositorySuite) TestFindRawByHash() {
+	// given
+	suite.setupDb(true)
+	dbClient := suite.dbResource.GetGormDb()
+	dbClient.Exec("update transaction set transaction_bytes = ? where consensus_ns = ?", []byte{0x1, 0x2},
+		consensusStart+1)
+	repo := NewTransactionRepository(dbClient, mapper.FailedAmountsIntended, false)
+	expected := []*types.RawTransaction{
+		{ConsensusTimestamp: consensusStart + 1, Result: resultSuccess, TransactionBytes: []byte{0x1, 0x2}},
+		{ConsensusTimestamp: consensusStart + 2, Result: "DUPLICATE_TRANSACTION"},
+	}
+
+	// when
+	actual, err := repo.FindRawByHash("0x010203")
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expected, actual)
+}
+
+func (suite *transactionRepositorySuite) TestFindRawByHashThrows() {
+	// given
+	suite.setupDb(true)
+	repo := NewTransactionRepository(suite.dbResource.GetGormDb(), mapper.FailedAmountsIntended, false)
+
+	// when
+	_, invalidErr := repo.FindRawByHash("0xzz")
+	_, notFoundErr := repo.FindRawByHash("0x0102")
+
+	// then
+	assert.Equal(suite.T(), errors.ErrInvalidTransactionIdentifier, invalidErr)
+	assert.Equal(suite.T(), errors.ErrTransactionNotFound, notFoundErr)
+}
+
+func (suite *transactionRepositorySuite) TestFindByTransactionIdThrowsNotFound() {
+	// given
+	suite.setupDb(true)
+	repo := NewTransactionRepository(suite.dbResource.GetGormDb(), mapper.FailedAmountsIntended, false)
+
+	var tests = []struct {
+		name          string
+		transactionId types.TransactionId
+	}{
+		{name: "Scheduled", transactionId: types.TransactionId{
+			Payer:        firstAccount,
+			Scheduled:    true,
+			ValidStartNs: consensusStart - 10,
+		}},
+		{name: "Nonce", transactionId: types.TransactionId{
+			Nonce:        1,
+			Payer:        firstAccount,
+			ValidStartNs: consensusStart - 10,
+		}},
+		{name: "OtherPayer", transactionId: types.TransactionId{Payer: secondAccount, ValidStartNs: consensusStart - 10}},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := repo.FindByTransactionId(tt.transactionId)
+
+			// then
+			assert.Equal(t, errors.ErrTransactionNotFound, err)
+			assert.Nil(t, actual)
+		})
+	}
+}
+
 func (suite *transactionRepositorySuite) setupDb(createTokenEntity bool) []*types.Transaction {
 	dbClient := suite.dbResource.GetGormDb()
 
@@ -334,7 +695,15 @@ func (suite *transactionRepositorySuite) setupDb(createTokenEntity bool) []*type
 		{Account: nodeAccount, Amount: &types.HbarAmount{Value: 5}, Type: "CRYPTOTRANSFER", Status: resultSuccess},
 		{Account: treasuryAccount, Amount: &types.HbarAmount{Value: 10}, Type: "CRYPTOTRANSFER", Status: resultSuccess},
 	}
-	expectedTransaction1 := &types.Transaction{Hash: "0x010203", Operations: operations1}
+	metadata1 := expectedMetadata(consensusStart+1, 0)
+	metadata1["attempts"] = []map[string]interface{}{
+		{"charged_fee": int64(17), "consensus_timestamp": consensusTimestamp, "result": "DUPLICATE_TRANSACTION"},
+	}
+	expectedTransaction1 := &types.Transaction{
+		Hash:       "0x010203",
+		Metadata:   metadata1,
+		Operations: operations1,
+	}
 
 	// a successful crypto transfer + token transfer transaction
 	consensusTimestamp += 1
@@ -384,7 +753,11 @@ func (suite *transactionRepositorySuite) setupDb(createTokenEntity bool) []*type
 			},
 		)
 	}
-	expectedTransaction2 := &types.Transaction{Hash: "0x0a0b0c", Operations: operations2}
+	expectedTransaction2 := &types.Transaction{
+		Hash:       "0x0a0b0c",
+		Metadata:   expectedMetadata(consensusTimestamp, 0),
+		Operations: operations2,
+	}
 
 	// token create transaction
 	domain.AddToken(dbClient, tokenId2.EncodedId, tokenDecimals, false, tokenInitialSupply, firstAccount.EncodedId)
@@ -400,14 +773,15 @@ func (suite *transactionRepositorySuite) setupDb(createTokenEntity bool) []*type
 		{AccountId: firstAccount.EncodedId, Amount: tokenInitialSupply, ConsensusTimestamp: consensusTimestamp, TokenId: tokenId2.EncodedId},
 	}
 	domain.AddTransaction(dbClient, consensusTimestamp, tokenId2.EncodedId, nodeAccount.EncodedId, firstAccount.EncodedId, 22,
-		[]byte{0xaa, 0xcc, 0xdd}, dbTypes.TransactionTypeTokenCreation, validStartNs, cryptoTransfers, nil, tokenTransfers)
+		[]byte{0xaa, 0xcc, 0xdd}, types.TransactionTypeTokenCreation, validStartNs, cryptoTransfers, nil, tokenTransfers)
 	metadata := map[string]interface{}{
 		"decimals":       tokenDecimals,
 		"freeze_default": false,
 		"initial_supply": tokenInitialSupply,
 	}
 	expectedTransaction3 := &types.Transaction{
-		Hash: "0xaaccdd",
+		Hash:     "0xaaccdd",
+		Metadata: expectedMetadata(consensusTimestamp, tokenId2.EncodedId),
 		Operations: []*types.Operation{
 			{Account: firstAccount, Amount: &types.HbarAmount{Value: -15}, Type: "TOKENCREATION", Status: resultSuccess},
 			{Account: nodeAccount, Amount: &types.HbarAmount{Value: 5}, Type: "TOKENCREATION", Status: resultSuccess},
@@ -424,3 +798,51 @@ func (suite *transactionRepositorySuite) setupDb(createTokenEntity bool) []*type
 
 	return []*types.Transaction{expectedTransaction1, expectedTransaction2, expectedTransaction3}
 }
+
+func expectedMetadata(consensusTimestamp, entityId int64) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"charged_fee":         int64(17),
+		"consensus_timestamp": consensusTimestamp,
+		"memo":                "",
+	}
+
+	if entityId != 0 {
+		entity, _ := entityid.Decode(entityId)
+		metadata["entity_id"] = entity.String()
+	}
+
+	return metadata
+}
+
+// benchmarkBlockSize is the number of crypto transfer transactions seeded in the benchmarked block
+const benchmarkBlockSize = 1000
+
+// BenchmarkFindBetween measures getting the transactions of a block, the bulk of the block assembly, against a
+// seeded database. Run it with go test -run '^$' -bench FindBetween ./app/persistence/transaction/
+func BenchmarkFindBetween(b *testing.B) {
+	dbResource := db.SetupDb()
+	defer db.TeardownDb(dbResource)
+	dbClient := dbResource.GetGormDb()
+
+	start := consensusEnd + 1
+	for i := int64(0); i < benchmarkBlockSize; i++ {
+		consensusTimestamp := start + i
+		cryptoTransfers := []dbTypes.CryptoTransfer{
+			{Amount: -150, ConsensusTimestamp: consensusTimestamp, EntityId: firstAccount.EncodedId},
+			{Amount: 135, ConsensusTimestamp: consensusTimestamp, EntityId: secondAccount.EncodedId},
+			{Amount: 5, ConsensusTimestamp: consensusTimestamp, EntityId: nodeAccount.EncodedId},
+			{Amount: 10, ConsensusTimestamp: consensusTimestamp, EntityId: treasuryAccount.EncodedId},
+		}
+		domain.AddTransaction(dbClient, consensusTimestamp, 0, nodeAccount.EncodedId, firstAccount.EncodedId, 22,
+			[]byte{byte(i >> 8), byte(i)}, 14, consensusTimestamp-10, cryptoTransfers, nil, nil)
+	}
+	repo := NewTransactionRepository(dbClient, mapper.FailedAmountsIntended, false)
+	end := start + benchmarkBlockSize - 1
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.FindBetween(start, end); err != nil {
+			b.Fatalf("Failed to find the transactions: %s", err.Message)
+		}
+	}
+}
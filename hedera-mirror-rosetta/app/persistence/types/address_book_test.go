@@ -0,0 +1,39 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddressBookTableName(t *testing.T) {
+	assert.Equal(t, "address_book", AddressBook{}.TableName())
+}
+
+func TestAddressBookEntryTableName(t *testing.T) {
+	assert.Equal(t, "address_book_entry", AddressBookEntry{}.TableName())
+}
+
+func TestAddressBookServiceEndpointTableName(t *testing.T) {
+	assert.Equal(t, "address_book_service_endpoint", AddressBookServiceEndpoint{}.TableName())
+}
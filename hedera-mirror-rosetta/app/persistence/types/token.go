@@ -63,9 +63,11 @@ func (t Token) ToDomainToken() (*types.Token, *rTypes.Error) {
 	}
 
 	return &types.Token{
-		TokenId:  tokenId,
-		Decimals: uint32(t.Decimals),
-		Name:     t.Name,
-		Symbol:   t.Symbol,
+		TokenId:       tokenId,
+		Decimals:      uint32(t.Decimals),
+		FreezeDefault: t.FreezeDefault,
+		InitialSupply: t.InitialSupply,
+		Name:          t.Name,
+		Symbol:        t.Symbol,
 	}, nil
 }
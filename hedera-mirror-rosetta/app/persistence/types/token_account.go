@@ -0,0 +1,94 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package types
+
+import (
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	hErrors "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+)
+
+const tableNameTokenAccount = "token_account"
+
+var (
+	// freezeStatuses maps the freeze status stored in the token_account table to the domain freeze status
+	freezeStatuses = map[int16]string{
+		0: types.TokenFreezeStatusNotApplicable,
+		1: types.TokenFreezeStatusFrozen,
+		2: types.TokenFreezeStatusUnfrozen,
+	}
+
+	// kycStatuses maps the kyc status stored in the token_account table to the domain kyc status
+	kycStatuses = map[int16]string{
+		0: types.TokenKycStatusNotApplicable,
+		1: types.TokenKycStatusGranted,
+		2: types.TokenKycStatusRevoked,
+	}
+)
+
+type TokenAccount struct {
+	AccountId         int64 `gorm:"primaryKey"`
+	Associated        bool
+	CreatedTimestamp  int64
+	FreezeStatus      int16
+	KycStatus         int16
+	ModifiedTimestamp int64
+	TokenId           int64 `gorm:"primaryKey"`
+}
+
+// TableName returns token_account table name
+func (TokenAccount) TableName() string {
+	return tableNameTokenAccount
+}
+
+// ToDomainTokenAssociation returns the domain TokenAssociation of the token account
+func (t TokenAccount) ToDomainTokenAssociation() (*types.TokenAssociation, *rTypes.Error) {
+	accountId, err := entityid.Decode(t.AccountId)
+	if err != nil {
+		return nil, hErrors.ErrInvalidAccount
+	}
+
+	tokenId, err := entityid.Decode(t.TokenId)
+	if err != nil {
+		return nil, hErrors.ErrInvalidToken
+	}
+
+	freezeStatus, ok := freezeStatuses[t.FreezeStatus]
+	if !ok {
+		return nil, hErrors.ErrInternalServerError
+	}
+
+	kycStatus, ok := kycStatuses[t.KycStatus]
+	if !ok {
+		return nil, hErrors.ErrInternalServerError
+	}
+
+	return &types.TokenAssociation{
+		AccountId:         accountId,
+		Associated:        t.Associated,
+		CreatedTimestamp:  t.CreatedTimestamp,
+		FreezeStatus:      freezeStatus,
+		KycStatus:         kycStatus,
+		ModifiedTimestamp: t.ModifiedTimestamp,
+		TokenId:           tokenId,
+	}, nil
+}
@@ -0,0 +1,102 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package types
+
+import (
+	"testing"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	hErrors "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenAccountTableName(t *testing.T) {
+	assert.Equal(t, "token_account", TokenAccount{}.TableName())
+}
+
+func TestTokenAccountToDomainTokenAssociation(t *testing.T) {
+	// given
+	tokenAccount := TokenAccount{
+		AccountId:         1001,
+		Associated:        true,
+		CreatedTimestamp:  100,
+		FreezeStatus:      1,
+		KycStatus:         2,
+		ModifiedTimestamp: 101,
+		TokenId:           2001,
+	}
+	expected := &types.TokenAssociation{
+		AccountId:         entityid.EntityId{EntityNum: 1001, EncodedId: 1001},
+		Associated:        true,
+		CreatedTimestamp:  100,
+		FreezeStatus:      types.TokenFreezeStatusFrozen,
+		KycStatus:         types.TokenKycStatusRevoked,
+		ModifiedTimestamp: 101,
+		TokenId:           entityid.EntityId{EntityNum: 2001, EncodedId: 2001},
+	}
+
+	// when
+	actual, err := tokenAccount.ToDomainTokenAssociation()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestTokenAccountToDomainTokenAssociationThrows(t *testing.T) {
+	var tests = []struct {
+		name         string
+		tokenAccount TokenAccount
+		expected     *rTypes.Error
+	}{
+		{
+			name:         "InvalidAccountId",
+			tokenAccount: TokenAccount{AccountId: -1, TokenId: 2001},
+			expected:     hErrors.ErrInvalidAccount,
+		},
+		{
+			name:         "InvalidTokenId",
+			tokenAccount: TokenAccount{AccountId: 1001, TokenId: -1},
+			expected:     hErrors.ErrInvalidToken,
+		},
+		{
+			name:         "InvalidFreezeStatus",
+			tokenAccount: TokenAccount{AccountId: 1001, FreezeStatus: 3, TokenId: 2001},
+			expected:     hErrors.ErrInternalServerError,
+		},
+		{
+			name:         "InvalidKycStatus",
+			tokenAccount: TokenAccount{AccountId: 1001, KycStatus: 3, TokenId: 2001},
+			expected:     hErrors.ErrInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := tt.tokenAccount.ToDomainTokenAssociation()
+
+			assert.Equal(t, tt.expected, err)
+			assert.Nil(t, actual)
+		})
+	}
+}
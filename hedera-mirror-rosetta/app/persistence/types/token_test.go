@@ -47,16 +47,20 @@ func TestTokenToDomainToken(t *testing.T) {
 		{
 			name: "Success",
 			token: Token{
-				TokenId:  1001,
-				Decimals: 10,
-				Name:     tokenName,
-				Symbol:   tokenSymbol,
+				TokenId:       1001,
+				Decimals:      10,
+				FreezeDefault: true,
+				InitialSupply: 100,
+				Name:          tokenName,
+				Symbol:        tokenSymbol,
 			},
 			expected: &types.Token{
-				TokenId:  entityid.EntityId{EntityNum: 1001, EncodedId: 1001},
-				Decimals: 10,
-				Name:     tokenName,
-				Symbol:   tokenSymbol,
+				TokenId:       entityid.EntityId{EntityNum: 1001, EncodedId: 1001},
+				Decimals:      10,
+				FreezeDefault: true,
+				InitialSupply: 100,
+				Name:          tokenName,
+				Symbol:        tokenSymbol,
 			},
 		},
 		{
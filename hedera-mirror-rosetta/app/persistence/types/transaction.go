@@ -20,13 +20,7 @@
 
 package types
 
-const (
-	TransactionTypeTokenCreation int16 = 29
-	TransactionTypeTokenDeletion int16 = 35
-	TransactionTypeTokenUpdate   int16 = 36
-
-	transactionTableName = "transaction"
-)
+const transactionTableName = "transaction"
 
 type Transaction struct {
 	ConsensusNs          int64 `gorm:"primaryKey"`
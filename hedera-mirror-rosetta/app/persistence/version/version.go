@@ -0,0 +1,92 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package version
+
+import (
+	"fmt"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	hErrors "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	// selectLatestHapiVersion - Selects the HAPI version of the latest record file which has it
+	selectLatestHapiVersion = `select hapi_version_major, hapi_version_minor, hapi_version_patch
+                               from record_file
+                               where hapi_version_major is not null
+                               order by consensus_end desc
+                               limit 1`
+
+	// selectLatestSchemaVersion - Selects the version of the latest successful versioned migration
+	selectLatestSchemaVersion = `select version
+                                 from flyway_schema_history
+                                 where success is true and version is not null
+                                 order by installed_rank desc
+                                 limit 1`
+)
+
+type hapiVersion struct {
+	HapiVersionMajor int
+	HapiVersionMinor int
+	HapiVersionPatch int
+}
+
+// networkVersionRepository struct that has connection to the Database
+type networkVersionRepository struct {
+	dbClient *gorm.DB
+}
+
+// NewNetworkVersionRepository creates an instance of a networkVersionRepository struct
+func NewNetworkVersionRepository(dbClient *gorm.DB) repositories.NetworkVersionRepository {
+	return &networkVersionRepository{dbClient: dbClient}
+}
+
+// Find returns the HAPI version of the latest record file and the mirror node schema version. A version is empty if
+// it's not available yet
+func (nvr *networkVersionRepository) Find() (*types.NetworkVersion, *rTypes.Error) {
+	var hapiVersions []hapiVersion
+	if err := nvr.dbClient.Raw(selectLatestHapiVersion).Scan(&hapiVersions).Error; err != nil {
+		log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, err)
+		return nil, hErrors.ErrDatabaseError
+	}
+
+	var schemaVersions []string
+	if err := nvr.dbClient.Raw(selectLatestSchemaVersion).Scan(&schemaVersions).Error; err != nil {
+		log.Errorf("%s: %s", hErrors.ErrDatabaseError.Message, err)
+		return nil, hErrors.ErrDatabaseError
+	}
+
+	networkVersion := &types.NetworkVersion{}
+	if len(hapiVersions) != 0 {
+		v := hapiVersions[0]
+		networkVersion.HapiVersion = fmt.Sprintf("%d.%d.%d", v.HapiVersionMajor, v.HapiVersionMinor, v.HapiVersionPatch)
+	}
+
+	if len(schemaVersions) != 0 {
+		networkVersion.MirrorSchemaVersion = schemaVersions[0]
+	}
+
+	return networkVersion, nil
+}
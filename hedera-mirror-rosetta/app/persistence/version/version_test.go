@@ -0,0 +1,86 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package version
+
+import (
+	"testing"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/db"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// run the suite
+func TestNetworkVersionRepositorySuite(t *testing.T) {
+	suite.Run(t, new(networkVersionRepositorySuite))
+}
+
+type networkVersionRepositorySuite struct {
+	suite.Suite
+	dbResource db.DbResource
+}
+
+func (suite *networkVersionRepositorySuite) SetupSuite() {
+	suite.dbResource = db.SetupDb()
+}
+
+func (suite *networkVersionRepositorySuite) TearDownSuite() {
+	db.TeardownDb(suite.dbResource)
+}
+
+func (suite *networkVersionRepositorySuite) SetupTest() {
+	db.CleanupDb(suite.dbResource.GetDb())
+}
+
+func (suite *networkVersionRepositorySuite) TestFind() {
+	// given
+	suite.createRecordFile(1, 0, 15, 2)
+	suite.createRecordFile(2, 0, 16, 0)
+	repo := NewNetworkVersionRepository(suite.dbResource.GetGormDb())
+
+	// when
+	actual, err := repo.Find()
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "0.16.0", actual.HapiVersion)
+	assert.NotEmpty(suite.T(), actual.MirrorSchemaVersion)
+}
+
+func (suite *networkVersionRepositorySuite) TestFindWithoutRecordFile() {
+	// given
+	repo := NewNetworkVersionRepository(suite.dbResource.GetGormDb())
+
+	// when
+	actual, err := repo.Find()
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), actual.HapiVersion)
+	assert.NotEmpty(suite.T(), actual.MirrorSchemaVersion)
+}
+
+func (suite *networkVersionRepositorySuite) createRecordFile(index int64, major, minor, patch int) {
+	domain.NewRecordFileBuilder(suite.dbResource.GetGormDb(), index, index*10, index*10+1).
+		HapiVersion(major, minor, patch).
+		Persist()
+}
@@ -25,27 +25,45 @@ import (
 
 	rTypes "github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/base"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
 	hexUtils "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/hex"
 )
 
 // AccountAPIService implements the server.AccountAPIServicer interface.
 type AccountAPIService struct {
 	base.BaseService
-	accountRepo repositories.AccountRepository
+	accountRepo      repositories.AccountRepository
+	maxTokenBalances int
+	settings         *config.Settings
+	tokenRepo        repositories.TokenRepository
 }
 
-// NewAccountAPIService creates a new instance of a AccountAPIService.
-func NewAccountAPIService(base base.BaseService, accountRepo repositories.AccountRepository) *AccountAPIService {
+// NewAccountAPIService creates a new instance of a AccountAPIService. At most maxTokenBalances token balances are
+// returned unless it's 0.
+func NewAccountAPIService(
+	base base.BaseService,
+	accountRepo repositories.AccountRepository,
+	tokenRepo repositories.TokenRepository,
+	maxTokenBalances int,
+	settings *config.Settings,
+) *AccountAPIService {
 	return &AccountAPIService{
-		BaseService: base,
-		accountRepo: accountRepo,
+		BaseService:      base,
+		accountRepo:      accountRepo,
+		maxTokenBalances: maxTokenBalances,
+		settings:         settings,
+		tokenRepo:        tokenRepo,
 	}
 }
 
-// AccountBalance implements the /account/balance endpoint.
+// AccountBalance implements the /account/balance endpoint. The metadata has the current expiry of the account, with
+// whether it's pending removal at the block, if the account is found. An account deleted at the block has zero balances
+// with the deletion timestamp and the block of the deleting transaction in the metadata, so the balances before the
+// deletion still reconcile
 func (a *AccountAPIService) AccountBalance(
 	ctx context.Context,
 	request *rTypes.AccountBalanceRequest,
@@ -53,6 +71,18 @@ func (a *AccountAPIService) AccountBalance(
 	var block *types.Block
 	var err *rTypes.Error
 
+	// the address can have the checksum of the network, the repositories take the address without it
+	account, err := types.AccountFromStringWithLedgerId(request.AccountIdentifier.Address, a.settings.LedgerId)
+	if err != nil {
+		return nil, err
+	}
+	address := account.String()
+
+	filter, err := newCurrencyFilter(request.Currencies, a.settings.CurrencyHbar)
+	if err != nil {
+		return nil, err
+	}
+
 	if request.BlockIdentifier != nil {
 		block, err = a.RetrieveBlock(request.BlockIdentifier)
 	} else {
@@ -62,29 +92,233 @@ func (a *AccountAPIService) AccountBalance(
 		return nil, err
 	}
 
-	balances, err := a.accountRepo.RetrieveBalanceAtBlock(request.AccountIdentifier.Address, block.ConsensusEndNanos)
+	if a.settings.TokenSubAccounts {
+		subAccount := request.AccountIdentifier.SubAccount
+		if filter, err = a.toSubAccountFilter(subAccount, filter, block.ConsensusEndNanos); err != nil {
+			return nil, err
+		}
+	}
+
+	// the requested tokens are always returned in full, otherwise query one more than the max to detect truncation
+	tokenIds := filter.getTokenIds()
+	limit := 0
+	if tokenIds == nil && a.maxTokenBalances > 0 {
+		limit = a.maxTokenBalances + 1
+	}
+
+	balances, err := a.accountRepo.RetrieveBalanceAtBlock(
+		address,
+		block.ConsensusEndNanos,
+		tokenIds,
+		0,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	balances = filter.apply(balances)
+
+	expiry, err := a.accountRepo.FindExpiry(address)
 	if err != nil {
 		return nil, err
 	}
 
+	var metadata map[string]interface{}
+	if expiry != nil {
+		metadata = expiry.ToMetadata(block.ConsensusEndNanos, a.settings.TimestampStrings)
+
+		if expiry.IsDeletedAt(block.ConsensusEndNanos) {
+			deletedBlock, err := a.FindByConsensusTimestamp(expiry.DeletedTimestamp)
+			if err != nil {
+				return nil, err
+			}
+
+			metadata["deleted_block_identifier"] = &rTypes.BlockIdentifier{
+				Index: deletedBlock.Index,
+				Hash:  hexUtils.SafeAddHexPrefix(deletedBlock.Hash),
+			}
+			balances = toZeroBalances(balances)
+		}
+	}
+
+	// the hbar balance is always the first
+	if limit != 0 && len(balances) > limit {
+		balances = balances[:limit]
+		lastTokenAmount := balances[len(balances)-1].(*types.TokenAmount)
+		if metadata == nil {
+			metadata = make(map[string]interface{})
+		}
+		metadata["last_token_id"] = lastTokenAmount.TokenId.String()
+		metadata["token_balances_truncated"] = true
+	}
+
 	return &rTypes.AccountBalanceResponse{
 		BlockIdentifier: &rTypes.BlockIdentifier{
 			Index: block.Index,
 			Hash:  hexUtils.SafeAddHexPrefix(block.Hash),
 		},
 		Balances: a.toRosettaBalances(balances),
+		Metadata: metadata,
 	}, nil
 }
 
+// toSubAccountFilter narrows the currency filter to the currencies held by the (sub-)account in the token sub-account
+// mode. The account itself only holds hbar, and a sub-account only holds the token with its address as the id
+func (a *AccountAPIService) toSubAccountFilter(
+	subAccount *rTypes.SubAccountIdentifier,
+	filter *currencyFilter,
+	consensusEnd int64,
+) (*currencyFilter, *rTypes.Error) {
+	if subAccount == nil {
+		if filter != nil && len(filter.tokenIds) != 0 {
+			return nil, errors.ErrInvalidCurrency
+		}
+		return &currencyFilter{hbar: true, tokenIds: []int64{}}, nil
+	}
+
+	tokenId, err := entityid.FromStringWithLedgerId(subAccount.Address, a.settings.LedgerId)
+	if err != nil {
+		return nil, errors.AddErrorDetails(errors.ErrInvalidAccount, errors.DetailField, "sub_account")
+	}
+
+	if filter != nil {
+		if filter.hbar || len(filter.tokenIds) != 1 || filter.tokenIds[0] != tokenId.EncodedId {
+			return nil, errors.ErrInvalidCurrency
+		}
+		return filter, nil
+	}
+
+	// the decimals as of the block are needed for the zero balance if the account has no balance of the token
+	token, rErr := a.tokenRepo.FindAt(tokenId.String(), consensusEnd)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	return &currencyFilter{
+		tokenIds: []int64{tokenId.EncodedId},
+		tokens:   map[int64]*rTypes.Currency{tokenId.EncodedId: token.ToRosettaCurrency()},
+	}, nil
+}
+
+// toZeroBalances returns the balances with zero values, since a deleted account holds no hbar or tokens
+func toZeroBalances(balances []types.Amount) []types.Amount {
+	zeroBalances := make([]types.Amount, 0, len(balances))
+	for _, balance := range balances {
+		switch amount := balance.(type) {
+		case *types.HbarAmount:
+			zeroBalances = append(zeroBalances, &types.HbarAmount{})
+		case *types.TokenAmount:
+			zeroBalances = append(zeroBalances, &types.TokenAmount{Decimals: amount.Decimals, TokenId: amount.TokenId})
+		}
+	}
+
+	return zeroBalances
+}
+
 func (a *AccountAPIService) toRosettaBalances(balances []types.Amount) []*rTypes.Amount {
 	rosettaBalances := make([]*rTypes.Amount, 0, len(balances))
 	for _, balance := range balances {
-		rosettaBalances = append(rosettaBalances, balance.ToRosetta())
+		rosettaBalances = append(rosettaBalances, balance.ToRosetta(a.settings))
 	}
 
 	return rosettaBalances
 }
 
+// currencyFilter selects the balances of the currencies in the /account/balance request. The token filter is pushed
+// down to the account repository, the hbar filter is applied on the returned balances
+type currencyFilter struct {
+	hbar     bool
+	tokenIds []int64
+	// tokens maps the encoded token id to its requested currency
+	tokens map[int64]*rTypes.Currency
+}
+
+// newCurrencyFilter creates a currencyFilter from the requested currencies, with currencyHbar as the native currency.
+// A nil filter is returned when no currencies are requested, so all balances are returned
+func newCurrencyFilter(
+	currencies []*rTypes.Currency,
+	currencyHbar *rTypes.Currency,
+) (*currencyFilter, *rTypes.Error) {
+	if len(currencies) == 0 {
+		return nil, nil
+	}
+
+	filter := &currencyFilter{tokenIds: make([]int64, 0, len(currencies)), tokens: make(map[int64]*rTypes.Currency)}
+	for _, currency := range currencies {
+		if currency == nil {
+			return nil, errors.ErrInvalidCurrency
+		}
+
+		if currency.Symbol == currencyHbar.Symbol {
+			if currency.Decimals != currencyHbar.Decimals {
+				return nil, errors.ErrInvalidCurrency
+			}
+
+			filter.hbar = true
+			continue
+		}
+
+		tokenId, err := entityid.FromString(currency.Symbol)
+		if err != nil {
+			return nil, errors.ErrInvalidCurrency
+		}
+
+		if _, ok := filter.tokens[tokenId.EncodedId]; !ok {
+			filter.tokenIds = append(filter.tokenIds, tokenId.EncodedId)
+			filter.tokens[tokenId.EncodedId] = currency
+		}
+	}
+
+	return filter, nil
+}
+
+// getTokenIds returns the encoded ids of the requested tokens, nil if there is no filter
+func (f *currencyFilter) getTokenIds() []int64 {
+	if f == nil {
+		return nil
+	}
+
+	return f.tokenIds
+}
+
+// apply returns the balances of the requested currencies. A requested token the account has no balance for gets a
+// zero balance with the requested decimals
+func (f *currencyFilter) apply(balances []types.Amount) []types.Amount {
+	if f == nil {
+		return balances
+	}
+
+	filtered := make([]types.Amount, 0, len(f.tokenIds)+1)
+	found := make(map[int64]bool)
+	for _, balance := range balances {
+		switch amount := balance.(type) {
+		case *types.HbarAmount:
+			if f.hbar {
+				filtered = append(filtered, amount)
+			}
+		case *types.TokenAmount:
+			if _, ok := f.tokens[amount.TokenId.EncodedId]; ok {
+				filtered = append(filtered, amount)
+				found[amount.TokenId.EncodedId] = true
+			}
+		}
+	}
+
+	for _, encodedId := range f.tokenIds {
+		if found[encodedId] {
+			continue
+		}
+
+		tokenId, _ := entityid.Decode(encodedId)
+		filtered = append(filtered, &types.TokenAmount{
+			Decimals: int64(f.tokens[encodedId].Decimals),
+			TokenId:  tokenId,
+		})
+	}
+
+	return filtered
+}
+
 func (a *AccountAPIService) AccountCoins(
 	ctx context.Context,
 	request *rTypes.AccountCoinsRequest,
@@ -25,15 +25,19 @@ import (
 
 	"github.com/coinbase/rosetta-sdk-go/server"
 	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/base"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/mocks/repository"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
+const maxTokenBalances = 2
+
 func block() *types.Block {
 	return &types.Block{
 		Index:               1,
@@ -89,23 +93,36 @@ type accountServiceSuite struct {
 	accountService      server.AccountAPIServicer
 	mockAccountRepo     *repository.MockAccountRepository
 	mockBlockRepo       *repository.MockBlockRepository
+	mockTokenRepo       *repository.MockTokenRepository
 	mockTransactionRepo *repository.MockTransactionRepository
+	settings            *config.Settings
 }
 
 func (suite *accountServiceSuite) SetupTest() {
 	suite.mockAccountRepo = &repository.MockAccountRepository{}
 	suite.mockBlockRepo = &repository.MockBlockRepository{}
+	suite.mockTokenRepo = &repository.MockTokenRepository{}
 	suite.mockTransactionRepo = &repository.MockTransactionRepository{}
+	suite.settings = config.NewSettings()
 
 	baseService := base.NewBaseService(suite.mockBlockRepo, suite.mockTransactionRepo)
-	suite.accountService = NewAccountAPIService(baseService, suite.mockAccountRepo)
+	suite.accountService = NewAccountAPIService(
+		baseService,
+		suite.mockAccountRepo,
+		suite.mockTokenRepo,
+		maxTokenBalances,
+		suite.settings,
+	)
 }
 
 func (suite *accountServiceSuite) TestAccountBalance() {
 	// given:
 
 	suite.mockBlockRepo.On("RetrieveLatest").Return(block(), repository.NilError)
-	suite.mockAccountRepo.On("RetrieveBalanceAtBlock").Return(amount(), repository.NilError)
+	suite.mockAccountRepo.
+		On("RetrieveBalanceAtBlock", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(amount(), repository.NilError)
+	suite.mockAccountRepo.On("FindExpiry", "0.0.1").Return(repository.NilAccountExpiry, repository.NilError)
 
 	// when:
 	actualResult, e := suite.accountService.AccountBalance(nil, request(false))
@@ -120,7 +137,10 @@ func (suite *accountServiceSuite) TestAccountBalance() {
 func (suite *accountServiceSuite) TestAccountBalanceWithBlockIdentifier() {
 	// given:
 	suite.mockBlockRepo.On("FindByIdentifier").Return(block(), repository.NilError)
-	suite.mockAccountRepo.On("RetrieveBalanceAtBlock").Return(amount(), repository.NilError)
+	suite.mockAccountRepo.
+		On("RetrieveBalanceAtBlock", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(amount(), repository.NilError)
+	suite.mockAccountRepo.On("FindExpiry", "0.0.1").Return(repository.NilAccountExpiry, repository.NilError)
 
 	// when:
 	actualResult, e := suite.accountService.AccountBalance(nil, request(true))
@@ -131,6 +151,326 @@ func (suite *accountServiceSuite) TestAccountBalanceWithBlockIdentifier() {
 	suite.mockBlockRepo.AssertNotCalled(suite.T(), "RetrieveLatest")
 }
 
+func (suite *accountServiceSuite) TestAccountBalanceWithChecksum() {
+	// given:
+	suite.settings.ConfigureLedgerId("mainnet")
+	accountId := entityid.EntityId{EntityNum: 1, EncodedId: 1}
+	suite.mockBlockRepo.On("RetrieveLatest").Return(block(), repository.NilError)
+	suite.mockAccountRepo.
+		On("RetrieveBalanceAtBlock", "0.0.1", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(amount(), repository.NilError)
+	suite.mockAccountRepo.On("FindExpiry", "0.0.1").Return(repository.NilAccountExpiry, repository.NilError)
+	request := request(false)
+	request.AccountIdentifier.Address = accountId.StringWithChecksum(suite.settings.LedgerId)
+
+	// when:
+	actualResult, e := suite.accountService.AccountBalance(nil, request)
+
+	// then:
+	assert.Equal(suite.T(), expectedAccountBalanceResponse(), actualResult)
+	assert.Nil(suite.T(), e)
+	suite.mockAccountRepo.AssertExpectations(suite.T())
+}
+
+func (suite *accountServiceSuite) TestAccountBalanceThrowsWhenChecksumMismatch() {
+	// given:
+	suite.settings.ConfigureLedgerId("testnet")
+	request := request(false)
+	request.AccountIdentifier.Address = "0.0.123-vfmkw"
+
+	// when:
+	actualResult, e := suite.accountService.AccountBalance(nil, request)
+
+	// then:
+	assert.Nil(suite.T(), actualResult)
+	assert.Equal(suite.T(), errors.ErrEntityIdChecksumMismatch, e)
+	suite.mockAccountRepo.AssertNotCalled(suite.T(), "RetrieveBalanceAtBlock")
+}
+
+func (suite *accountServiceSuite) TestAccountBalanceWithCustomCurrency() {
+	// given:
+	suite.settings.ConfigureCurrencyHbar("ℏ", nil)
+	suite.mockBlockRepo.On("RetrieveLatest").Return(block(), repository.NilError)
+	suite.mockAccountRepo.
+		On("RetrieveBalanceAtBlock", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(amount(), repository.NilError)
+	suite.mockAccountRepo.On("FindExpiry", "0.0.1").Return(repository.NilAccountExpiry, repository.NilError)
+	request := request(false)
+	request.Currencies = []*rTypes.Currency{suite.settings.CurrencyHbar}
+
+	// when:
+	actualResult, e := suite.accountService.AccountBalance(nil, request)
+
+	// then:
+	assert.Nil(suite.T(), e)
+	assert.Equal(suite.T(), []*rTypes.Amount{{Value: "1000", Currency: suite.settings.CurrencyHbar}},
+		actualResult.Balances)
+	assert.Equal(suite.T(), "ℏ", actualResult.Balances[0].Currency.Symbol)
+}
+
+func (suite *accountServiceSuite) TestAccountBalanceWithCurrencies() {
+	// given:
+	tokenId := entityid.EntityId{EntityNum: 1001, EncodedId: 1001}
+	token1Currency := &rTypes.Currency{Symbol: "0.0.1001", Decimals: 6}
+	token2Currency := &rTypes.Currency{Symbol: "0.0.1002", Decimals: 8}
+
+	var tests = []struct {
+		name             string
+		currencies       []*rTypes.Currency
+		expectedTokenIds []int64
+		expected         []*rTypes.Amount
+	}{
+		{
+			name:             "Hbar",
+			currencies:       []*rTypes.Currency{config.CurrencyHbar},
+			expectedTokenIds: []int64{},
+			expected:         []*rTypes.Amount{{Value: "1000", Currency: config.CurrencyHbar}},
+		},
+		{
+			name:             "Token",
+			currencies:       []*rTypes.Currency{token1Currency},
+			expectedTokenIds: []int64{1001},
+			expected:         []*rTypes.Amount{{Value: "10", Currency: token1Currency}},
+		},
+		{
+			name:             "HbarAndTokenWithoutBalance",
+			currencies:       []*rTypes.Currency{token2Currency, config.CurrencyHbar, token1Currency},
+			expectedTokenIds: []int64{1002, 1001},
+			expected: []*rTypes.Amount{
+				{Value: "1000", Currency: config.CurrencyHbar},
+				{Value: "10", Currency: token1Currency},
+				{Value: "0", Currency: token2Currency},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			suite.SetupTest()
+			balances := []types.Amount{&types.HbarAmount{Value: 1000}}
+			if len(tt.expectedTokenIds) != 0 {
+				balances = append(balances, &types.TokenAmount{Decimals: 6, TokenId: tokenId, Value: 10})
+			}
+			suite.mockBlockRepo.On("RetrieveLatest").Return(block(), repository.NilError)
+			suite.mockAccountRepo.
+				On("RetrieveBalanceAtBlock", "0.0.1", block().ConsensusEndNanos, tt.expectedTokenIds, int64(0), 0).
+				Return(balances, repository.NilError)
+			suite.mockAccountRepo.On("FindExpiry", "0.0.1").Return(repository.NilAccountExpiry, repository.NilError)
+			request := request(false)
+			request.Currencies = tt.currencies
+
+			// when:
+			actual, err := suite.accountService.AccountBalance(nil, request)
+
+			// then:
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, actual.Balances)
+			suite.mockAccountRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func (suite *accountServiceSuite) TestAccountBalanceTokenBalancesTruncated() {
+	// given:
+	tokenAmount := func(num int64) *types.TokenAmount {
+		return &types.TokenAmount{Decimals: 6, TokenId: entityid.EntityId{EntityNum: num, EncodedId: num}, Value: 10}
+	}
+	balances := []types.Amount{&types.HbarAmount{Value: 1000}, tokenAmount(2001), tokenAmount(2002), tokenAmount(2003)}
+	expected := &rTypes.AccountBalanceResponse{
+		BlockIdentifier: &rTypes.BlockIdentifier{Index: 1, Hash: "0x123jsjs"},
+		Balances: []*rTypes.Amount{
+			{Value: "1000", Currency: config.CurrencyHbar},
+			tokenAmount(2001).ToRosetta(suite.settings),
+			tokenAmount(2002).ToRosetta(suite.settings),
+		},
+		Metadata: map[string]interface{}{
+			"last_token_id":            "0.0.2002",
+			"token_balances_truncated": true,
+		},
+	}
+	suite.mockBlockRepo.On("RetrieveLatest").Return(block(), repository.NilError)
+	suite.mockAccountRepo.
+		On("RetrieveBalanceAtBlock", "0.0.1", block().ConsensusEndNanos, []int64(nil), int64(0), maxTokenBalances+1).
+		Return(balances, repository.NilError)
+	suite.mockAccountRepo.On("FindExpiry", "0.0.1").Return(repository.NilAccountExpiry, repository.NilError)
+
+	// when:
+	actual, err := suite.accountService.AccountBalance(nil, request(false))
+
+	// then:
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expected, actual)
+	suite.mockAccountRepo.AssertExpectations(suite.T())
+}
+
+func (suite *accountServiceSuite) TestAccountBalanceWithTokenSubAccounts() {
+	// given:
+	tokenId := entityid.EntityId{EntityNum: 1001, EncodedId: 1001}
+	tokenCurrency := &rTypes.Currency{Symbol: "0.0.1001", Decimals: 6}
+
+	var tests = []struct {
+		name             string
+		subAccount       *rTypes.SubAccountIdentifier
+		currencies       []*rTypes.Currency
+		balances         []types.Amount
+		expectedTokenIds []int64
+		expected         []*rTypes.Amount
+	}{
+		{
+			name:             "Account",
+			balances:         []types.Amount{&types.HbarAmount{Value: 1000}},
+			expectedTokenIds: []int64{},
+			expected:         []*rTypes.Amount{{Value: "1000", Currency: config.CurrencyHbar}},
+		},
+		{
+			name:             "SubAccount",
+			subAccount:       &rTypes.SubAccountIdentifier{Address: "0.0.1001"},
+			balances:         []types.Amount{&types.HbarAmount{Value: 1000}},
+			expectedTokenIds: []int64{1001},
+			expected:         []*rTypes.Amount{{Value: "0", Currency: tokenCurrency}},
+		},
+		{
+			name:       "SubAccountWithCurrency",
+			subAccount: &rTypes.SubAccountIdentifier{Address: "0.0.1001"},
+			currencies: []*rTypes.Currency{tokenCurrency},
+			balances: []types.Amount{
+				&types.HbarAmount{Value: 1000},
+				&types.TokenAmount{Decimals: 6, TokenId: tokenId, Value: 10},
+			},
+			expectedTokenIds: []int64{1001},
+			expected:         []*rTypes.Amount{{Value: "10", Currency: tokenCurrency}},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			suite.SetupTest()
+			suite.settings.TokenSubAccounts = true
+			suite.mockBlockRepo.On("RetrieveLatest").Return(block(), repository.NilError)
+			suite.mockAccountRepo.
+				On("RetrieveBalanceAtBlock", "0.0.1", block().ConsensusEndNanos, tt.expectedTokenIds, int64(0), 0).
+				Return(tt.balances, repository.NilError)
+			suite.mockAccountRepo.On("FindExpiry", "0.0.1").Return(repository.NilAccountExpiry, repository.NilError)
+			suite.mockTokenRepo.
+				On("FindAt", "0.0.1001", block().ConsensusEndNanos).
+				Return(&types.Token{TokenId: tokenId, Decimals: 6}, repository.NilError)
+			request := request(false)
+			request.AccountIdentifier.SubAccount = tt.subAccount
+			request.Currencies = tt.currencies
+
+			// when:
+			actual, err := suite.accountService.AccountBalance(nil, request)
+
+			// then:
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, actual.Balances)
+			assert.Nil(t, actual.Metadata)
+			suite.mockAccountRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func (suite *accountServiceSuite) TestAccountBalanceWithTokenSubAccountsThrows() {
+	tokenCurrency := &rTypes.Currency{Symbol: "0.0.1001", Decimals: 6}
+
+	var tests = []struct {
+		name       string
+		subAccount *rTypes.SubAccountIdentifier
+		currencies []*rTypes.Currency
+		expected   *rTypes.Error
+	}{
+		{
+			name:       "AccountWithTokenCurrency",
+			currencies: []*rTypes.Currency{tokenCurrency},
+			expected:   errors.ErrInvalidCurrency,
+		},
+		{
+			name:       "InvalidSubAccount",
+			subAccount: &rTypes.SubAccountIdentifier{Address: "abc"},
+			expected:   errors.AddErrorDetails(errors.ErrInvalidAccount, errors.DetailField, "sub_account"),
+		},
+		{
+			name:       "SubAccountWithHbarCurrency",
+			subAccount: &rTypes.SubAccountIdentifier{Address: "0.0.1001"},
+			currencies: []*rTypes.Currency{config.CurrencyHbar},
+			expected:   errors.ErrInvalidCurrency,
+		},
+		{
+			name:       "SubAccountWithOtherTokenCurrency",
+			subAccount: &rTypes.SubAccountIdentifier{Address: "0.0.1002"},
+			currencies: []*rTypes.Currency{tokenCurrency},
+			expected:   errors.ErrInvalidCurrency,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// given:
+			suite.SetupTest()
+			suite.settings.TokenSubAccounts = true
+			suite.mockBlockRepo.On("RetrieveLatest").Return(block(), repository.NilError)
+			request := request(false)
+			request.AccountIdentifier.SubAccount = tt.subAccount
+			request.Currencies = tt.currencies
+
+			// when:
+			actual, err := suite.accountService.AccountBalance(nil, request)
+
+			// then:
+			assert.Equal(t, tt.expected, err)
+			assert.Nil(t, actual)
+			suite.mockAccountRepo.AssertNotCalled(t, "RetrieveBalanceAtBlock")
+		})
+	}
+}
+
+func (suite *accountServiceSuite) TestAccountBalanceWithTokenSubAccountsThrowsWhenTokenNotFound() {
+	// given:
+	suite.settings.TokenSubAccounts = true
+	suite.mockBlockRepo.On("RetrieveLatest").Return(block(), repository.NilError)
+	suite.mockTokenRepo.
+		On("FindAt", "0.0.1001", block().ConsensusEndNanos).
+		Return(repository.NilToken, errors.ErrTokenNotFound)
+	request := request(false)
+	request.AccountIdentifier.SubAccount = &rTypes.SubAccountIdentifier{Address: "0.0.1001"}
+
+	// when:
+	actual, err := suite.accountService.AccountBalance(nil, request)
+
+	// then:
+	assert.Equal(suite.T(), errors.ErrTokenNotFound, err)
+	assert.Nil(suite.T(), actual)
+	suite.mockAccountRepo.AssertNotCalled(suite.T(), "RetrieveBalanceAtBlock")
+}
+
+func (suite *accountServiceSuite) TestAccountBalanceThrowsWhenInvalidCurrency() {
+	var tests = []struct {
+		name     string
+		currency *rTypes.Currency
+	}{
+		{name: "Nil"},
+		{name: "InvalidHbarDecimals", currency: &rTypes.Currency{Symbol: config.CurrencyHbar.Symbol, Decimals: 6}},
+		{name: "InvalidTokenId", currency: &rTypes.Currency{Symbol: "abc", Decimals: 6}},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			suite.SetupTest()
+			request := request(false)
+			request.Currencies = []*rTypes.Currency{tt.currency}
+
+			// when:
+			actual, err := suite.accountService.AccountBalance(nil, request)
+
+			// then:
+			assert.Equal(t, errors.ErrInvalidCurrency, err)
+			assert.Nil(t, actual)
+			suite.mockBlockRepo.AssertNotCalled(t, "RetrieveLatest")
+			suite.mockAccountRepo.AssertNotCalled(t, "RetrieveBalanceAtBlock")
+		})
+	}
+}
+
 func (suite *accountServiceSuite) TestAccountBalanceThrowsWhenRetrieveLatestFails() {
 	// given:
 	suite.mockBlockRepo.On("RetrieveLatest").Return(repository.NilBlock, &rTypes.Error{})
@@ -161,7 +501,9 @@ func (suite *accountServiceSuite) TestAccountBalanceThrowsWhenRetrieveBlockFails
 func (suite *accountServiceSuite) TestAccountBalanceThrowsWhenRetrieveBalanceAtBlockFails() {
 	// given:
 	suite.mockBlockRepo.On("FindByIdentifier").Return(block(), repository.NilError)
-	suite.mockAccountRepo.On("RetrieveBalanceAtBlock").Return([]types.Amount{}, &rTypes.Error{})
+	suite.mockAccountRepo.
+		On("RetrieveBalanceAtBlock", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]types.Amount{}, &rTypes.Error{})
 
 	// when:
 	actualResult, e := suite.accountService.AccountBalance(nil, request(true))
@@ -171,6 +513,147 @@ func (suite *accountServiceSuite) TestAccountBalanceThrowsWhenRetrieveBalanceAtB
 	assert.NotNil(suite.T(), e)
 }
 
+func (suite *accountServiceSuite) TestAccountBalanceWithExpiry() {
+	var tests = []struct {
+		name     string
+		expiry   *types.AccountExpiry
+		expected map[string]interface{}
+	}{
+		{
+			name:   "NotExpired",
+			expiry: &types.AccountExpiry{AutoRenewPeriod: 7776000, ExpirationTimestamp: 20000001},
+			expected: map[string]interface{}{
+				"auto_renew_period":    int64(7776000),
+				"expiration_timestamp": int64(20000001),
+				"pending_removal":      false,
+			},
+		},
+		{
+			name:   "PendingRemoval",
+			expiry: &types.AccountExpiry{AutoRenewPeriod: 7776000, ExpirationTimestamp: 20000000},
+			expected: map[string]interface{}{
+				"auto_renew_period":    int64(7776000),
+				"expiration_timestamp": int64(20000000),
+				"pending_removal":      true,
+			},
+		},
+		{
+			name:     "NoExpirationTimestamp",
+			expiry:   &types.AccountExpiry{},
+			expected: map[string]interface{}{"pending_removal": false},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// given:
+			suite.SetupTest()
+			suite.mockBlockRepo.On("RetrieveLatest").Return(block(), repository.NilError)
+			suite.mockAccountRepo.
+				On("RetrieveBalanceAtBlock", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(amount(), repository.NilError)
+			suite.mockAccountRepo.On("FindExpiry", "0.0.1").Return(tt.expiry, repository.NilError)
+
+			// when:
+			actual, err := suite.accountService.AccountBalance(nil, request(false))
+
+			// then:
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, actual.Metadata)
+		})
+	}
+}
+
+func (suite *accountServiceSuite) TestAccountBalanceDeleted() {
+	// given:
+	deletedBlock := &types.Block{Index: 1, Hash: "0a0b", ConsensusStartNanos: 1000000, ConsensusEndNanos: 1999999}
+	tokenId := entityid.EntityId{EntityNum: 2001, EncodedId: 2001}
+	suite.mockBlockRepo.On("RetrieveLatest").Return(block(), repository.NilError)
+	suite.mockBlockRepo.On("FindByConsensusTimestamp", int64(1500000)).Return(deletedBlock, repository.NilError)
+	suite.mockAccountRepo.
+		On("RetrieveBalanceAtBlock", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]types.Amount{
+			&types.HbarAmount{Value: 10},
+			&types.TokenAmount{Decimals: 2, TokenId: tokenId, Value: 5},
+		}, repository.NilError)
+	suite.mockAccountRepo.On("FindExpiry", "0.0.1").
+		Return(&types.AccountExpiry{DeletedTimestamp: 1500000}, repository.NilError)
+	expected := &rTypes.AccountBalanceResponse{
+		BlockIdentifier: &rTypes.BlockIdentifier{Index: 1, Hash: "0x123jsjs"},
+		Balances: []*rTypes.Amount{
+			(&types.HbarAmount{}).ToRosetta(suite.settings),
+			(&types.TokenAmount{Decimals: 2, TokenId: tokenId}).ToRosetta(suite.settings),
+		},
+		Metadata: map[string]interface{}{
+			"deleted":                  true,
+			"deleted_block_identifier": &rTypes.BlockIdentifier{Index: 1, Hash: "0x0a0b"},
+			"deleted_timestamp":        int64(1500000),
+			"pending_removal":          false,
+		},
+	}
+
+	// when:
+	actual, err := suite.accountService.AccountBalance(nil, request(false))
+
+	// then:
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expected, actual)
+}
+
+func (suite *accountServiceSuite) TestAccountBalanceDeletedAfterBlock() {
+	// given:
+	suite.mockBlockRepo.On("RetrieveLatest").Return(block(), repository.NilError)
+	suite.mockAccountRepo.
+		On("RetrieveBalanceAtBlock", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(amount(), repository.NilError)
+	suite.mockAccountRepo.On("FindExpiry", "0.0.1").
+		Return(&types.AccountExpiry{DeletedTimestamp: 20000001}, repository.NilError)
+
+	// when:
+	actual, err := suite.accountService.AccountBalance(nil, request(false))
+
+	// then:
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []*rTypes.Amount{amount()[0].ToRosetta(suite.settings)}, actual.Balances)
+	assert.Equal(suite.T(), map[string]interface{}{"pending_removal": false}, actual.Metadata)
+	suite.mockBlockRepo.AssertNotCalled(suite.T(), "FindByConsensusTimestamp", mock.Anything)
+}
+
+func (suite *accountServiceSuite) TestAccountBalanceThrowsWhenDeletedBlockNotFound() {
+	// given:
+	suite.mockBlockRepo.On("RetrieveLatest").Return(block(), repository.NilError)
+	suite.mockBlockRepo.On("FindByConsensusTimestamp", int64(1500000)).
+		Return(repository.NilBlock, errors.ErrBlockNotFound)
+	suite.mockAccountRepo.
+		On("RetrieveBalanceAtBlock", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(amount(), repository.NilError)
+	suite.mockAccountRepo.On("FindExpiry", "0.0.1").
+		Return(&types.AccountExpiry{DeletedTimestamp: 1500000}, repository.NilError)
+
+	// when:
+	actual, err := suite.accountService.AccountBalance(nil, request(false))
+
+	// then:
+	assert.Nil(suite.T(), actual)
+	assert.Equal(suite.T(), errors.ErrBlockNotFound, err)
+}
+
+func (suite *accountServiceSuite) TestAccountBalanceThrowsWhenFindExpiryFails() {
+	// given:
+	suite.mockBlockRepo.On("RetrieveLatest").Return(block(), repository.NilError)
+	suite.mockAccountRepo.
+		On("RetrieveBalanceAtBlock", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(amount(), repository.NilError)
+	suite.mockAccountRepo.On("FindExpiry", "0.0.1").Return(repository.NilAccountExpiry, errors.ErrDatabaseError)
+
+	// when:
+	actual, err := suite.accountService.AccountBalance(nil, request(false))
+
+	// then:
+	assert.Nil(suite.T(), actual)
+	assert.Equal(suite.T(), errors.ErrDatabaseError, err)
+}
+
 func (suite *accountServiceSuite) TestAccountCoins() {
 	// when:
 	result, err := suite.accountService.AccountCoins(nil, &rTypes.AccountCoinsRequest{})
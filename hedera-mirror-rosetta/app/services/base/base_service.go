@@ -74,6 +74,14 @@ func (c *BaseService) RetrieveGenesis() (*types.Block, *rTypes.Error) {
 	return c.blockRepo.RetrieveGenesis()
 }
 
+func (c *BaseService) FindBetweenIndexes(start int64, end int64) ([]*types.Block, *rTypes.Error) {
+	return c.blockRepo.FindBetweenIndexes(start, end)
+}
+
+func (c *BaseService) FindByConsensusTimestamp(timestamp int64) (*types.Block, *rTypes.Error) {
+	return c.blockRepo.FindByConsensusTimestamp(timestamp)
+}
+
 func (c *BaseService) FindByIdentifier(index int64, hash string) (*types.Block, *rTypes.Error) {
 	return c.blockRepo.FindByIdentifier(index, hash)
 }
@@ -90,6 +98,22 @@ func (c *BaseService) FindBetween(start int64, end int64) ([]*types.Transaction,
 	return c.transactionRepo.FindBetween(start, end)
 }
 
+func (c *BaseService) FindBetweenWithMaxOperations(start int64, end int64, maxOperations int) (
+	[]*types.Transaction,
+	[]string,
+	*rTypes.Error,
+) {
+	return c.transactionRepo.FindBetweenWithMaxOperations(start, end, maxOperations)
+}
+
+func (c *BaseService) FindByTransactionId(transactionId types.TransactionId) ([]*types.Transaction, *rTypes.Error) {
+	return c.transactionRepo.FindByTransactionId(transactionId)
+}
+
+func (c *BaseService) FindRawByHash(hash string) ([]*types.RawTransaction, *rTypes.Error) {
+	return c.transactionRepo.FindRawByHash(hash)
+}
+
 func (c *BaseService) Results() (map[int]string, *rTypes.Error) {
 	return c.transactionRepo.Results()
 }
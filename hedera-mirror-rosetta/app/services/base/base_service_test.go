@@ -224,6 +224,21 @@ func (suite *baseServiceSuite) TestRetrieveLatestThrows() {
 	assert.NotNil(suite.T(), e)
 }
 
+func (suite *baseServiceSuite) TestFindBetweenIndexes() {
+	// given:
+	suite.mockBlockRepo.On("FindBetweenIndexes", int64(1), int64(2)).Return(
+		[]*types.Block{block()},
+		repository.NilError,
+	)
+
+	// when:
+	res, e := suite.baseService.FindBetweenIndexes(1, 2)
+
+	// then:
+	assert.Nil(suite.T(), e)
+	assert.Equal(suite.T(), []*types.Block{block()}, res)
+}
+
 func (suite *baseServiceSuite) TestRetrieveGenesis() {
 	// given:
 	suite.mockBlockRepo.On("RetrieveGenesis").Return(
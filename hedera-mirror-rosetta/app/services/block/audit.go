@@ -0,0 +1,91 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package block
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+)
+
+const (
+	// AuditModeFail rejects a block with an operation mismatch
+	AuditModeFail = "fail"
+	// AuditModeLog logs the operation mismatches of a block and returns it as is
+	AuditModeLog = "log"
+	// AuditModeOff returns a block without auditing its operations
+	AuditModeOff = "off"
+)
+
+// auditTransaction returns the mismatches between the operations of the transaction and the expected net. The hbar
+// amounts of the operations with the same status must sum to zero, and the hbar debited by the fee transfers must equal
+// the fees charged for the transaction and its attempts. The token amounts aren't audited since a mint, burn, or wipe
+// changes the supply
+func auditTransaction(transaction *types.Transaction) []string {
+	mismatches := make([]string, 0)
+
+	nets := make(map[string]int64)
+	for _, operation := range transaction.Operations {
+		if amount, ok := operation.Amount.(*types.HbarAmount); ok {
+			nets[operation.Status] += amount.Value
+		}
+	}
+
+	statuses := make([]string, 0, len(nets))
+	for status := range nets {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+
+	for _, status := range statuses {
+		if net := nets[status]; net != 0 {
+			mismatches = append(mismatches, fmt.Sprintf("hbar operations with status %s net to %d", status, net))
+		}
+	}
+
+	if chargedFee, ok := getChargedFee(transaction.Metadata); ok && chargedFee != transaction.FeeDebited {
+		mismatches = append(mismatches, fmt.Sprintf("fee transfers debit %d but the charged fee is %d",
+			transaction.FeeDebited, chargedFee))
+	}
+
+	return mismatches
+}
+
+// getChargedFee returns the total of the charged fee of the transaction and the charged fees of its attempts, or false
+// if the charged fee isn't in the metadata
+func getChargedFee(metadata map[string]interface{}) (int64, bool) {
+	chargedFee, ok := metadata["charged_fee"].(int64)
+	if !ok {
+		return 0, false
+	}
+
+	attempts, _ := metadata["attempts"].([]map[string]interface{})
+	for _, attempt := range attempts {
+		attemptChargedFee, ok := attempt["charged_fee"].(int64)
+		if !ok {
+			return 0, false
+		}
+		chargedFee += attemptChargedFee
+	}
+
+	return chargedFee, true
+}
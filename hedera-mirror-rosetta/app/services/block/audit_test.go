@@ -0,0 +1,109 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package block
+
+import (
+	"testing"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newHbarOperation(status string, value int64) *types.Operation {
+	return &types.Operation{Type: "CRYPTOTRANSFER", Status: status, Amount: &types.HbarAmount{Value: value}}
+}
+
+func TestAuditTransaction(t *testing.T) {
+	tokenOperation := &types.Operation{Type: "TOKENMINT", Status: "SUCCESS", Amount: &types.TokenAmount{Value: 100}}
+	var tests = []struct {
+		name        string
+		transaction *types.Transaction
+		expected    []string
+	}{
+		{
+			name: "Balanced",
+			transaction: &types.Transaction{
+				Metadata: map[string]interface{}{"charged_fee": int64(5)},
+				Operations: []*types.Operation{
+					newHbarOperation("SUCCESS", -5),
+					newHbarOperation("SUCCESS", 5),
+					newHbarOperation("INSUFFICIENT_ACCOUNT_BALANCE", -10),
+					newHbarOperation("INSUFFICIENT_ACCOUNT_BALANCE", 10),
+					tokenOperation,
+				},
+				FeeDebited: 5,
+			},
+			expected: []string{},
+		},
+		{
+			name: "BalancedWithAttempts",
+			transaction: &types.Transaction{
+				Metadata: map[string]interface{}{
+					"attempts":    []map[string]interface{}{{"charged_fee": int64(4)}},
+					"charged_fee": int64(5),
+				},
+				FeeDebited: 9,
+			},
+			expected: []string{},
+		},
+		{
+			name: "NoChargedFee",
+			transaction: &types.Transaction{
+				Metadata:   map[string]interface{}{"memo": "transfer"},
+				FeeDebited: 5,
+			},
+			expected: []string{},
+		},
+		{
+			name: "Unbalanced",
+			transaction: &types.Transaction{
+				Metadata: map[string]interface{}{"charged_fee": int64(5)},
+				Operations: []*types.Operation{
+					newHbarOperation("SUCCESS", -5),
+					newHbarOperation("SUCCESS", 4),
+					newHbarOperation("INSUFFICIENT_ACCOUNT_BALANCE", 10),
+				},
+				FeeDebited: 5,
+			},
+			expected: []string{
+				"hbar operations with status INSUFFICIENT_ACCOUNT_BALANCE net to 10",
+				"hbar operations with status SUCCESS net to -1",
+			},
+		},
+		{
+			name: "FeeMismatch",
+			transaction: &types.Transaction{
+				Metadata: map[string]interface{}{
+					"attempts":    []map[string]interface{}{{"charged_fee": int64(4)}},
+					"charged_fee": int64(5),
+				},
+				FeeDebited: 5,
+			},
+			expected: []string{"fee transfers debit 5 but the charged fee is 9"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, auditTransaction(tt.transaction))
+		})
+	}
+}
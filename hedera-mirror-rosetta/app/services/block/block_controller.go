@@ -0,0 +1,141 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package block
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/coinbase/rosetta-sdk-go/asserter"
+	"github.com/coinbase/rosetta-sdk-go/server"
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/encoder"
+)
+
+type rosettaAllocatorKey struct{}
+
+// blockAPIController implements the server.Router interface for the block endpoints. It's the rosetta-sdk-go
+// server.BlockAPIController with the responses, which can be several MBs for a busy block, written by a JSONEncoder.
+// The Rosetta structs of a response are handed out by a pooled types.RosettaAllocator passed to the service in the
+// request context, and the allocator is released once the response is written
+type blockAPIController struct {
+	asserter *asserter.Asserter
+	encoder  encoder.JSONEncoder
+	service  server.BlockAPIServicer
+}
+
+// Routes returns the block routes of the blockAPIController
+func (c *blockAPIController) Routes() server.Routes {
+	return server.Routes{
+		{
+			Name:        "Block",
+			Method:      http.MethodPost,
+			Pattern:     "/block",
+			HandlerFunc: c.Block,
+		},
+		{
+			Name:        "BlockTransaction",
+			Method:      http.MethodPost,
+			Pattern:     "/block/transaction",
+			HandlerFunc: c.BlockTransaction,
+		},
+	}
+}
+
+// Block implements the /block endpoint.
+func (c *blockAPIController) Block(w http.ResponseWriter, r *http.Request) {
+	request := &rTypes.BlockRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		c.encodeResponse(&rTypes.Error{Message: err.Error()}, http.StatusInternalServerError, w)
+		return
+	}
+
+	if err := c.asserter.BlockRequest(request); err != nil {
+		c.encodeResponse(&rTypes.Error{Message: err.Error()}, http.StatusInternalServerError, w)
+		return
+	}
+
+	allocator := types.GetRosettaAllocator()
+	defer allocator.Release()
+
+	response, rErr := c.service.Block(withRosettaAllocator(r.Context(), allocator), request)
+	if rErr != nil {
+		c.encodeResponse(rErr, http.StatusInternalServerError, w)
+		return
+	}
+
+	c.encodeResponse(response, http.StatusOK, w)
+}
+
+// BlockTransaction implements the /block/transaction endpoint.
+func (c *blockAPIController) BlockTransaction(w http.ResponseWriter, r *http.Request) {
+	request := &rTypes.BlockTransactionRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		c.encodeResponse(&rTypes.Error{Message: err.Error()}, http.StatusInternalServerError, w)
+		return
+	}
+
+	if err := c.asserter.BlockTransactionRequest(request); err != nil {
+		c.encodeResponse(&rTypes.Error{Message: err.Error()}, http.StatusInternalServerError, w)
+		return
+	}
+
+	allocator := types.GetRosettaAllocator()
+	defer allocator.Release()
+
+	response, rErr := c.service.BlockTransaction(withRosettaAllocator(r.Context(), allocator), request)
+	if rErr != nil {
+		c.encodeResponse(rErr, http.StatusInternalServerError, w)
+		return
+	}
+
+	c.encodeResponse(response, http.StatusOK, w)
+}
+
+func (c *blockAPIController) encodeResponse(v interface{}, status int, w http.ResponseWriter) {
+	encoder.EncodeJSONResponse(c.encoder, v, status, w)
+}
+
+// withRosettaAllocator returns a copy of ctx carrying allocator
+func withRosettaAllocator(ctx context.Context, allocator *types.RosettaAllocator) context.Context {
+	return context.WithValue(ctx, rosettaAllocatorKey{}, allocator)
+}
+
+// getRosettaAllocator returns the allocator carried by ctx, or nil if there isn't one
+func getRosettaAllocator(ctx context.Context) *types.RosettaAllocator {
+	if ctx == nil {
+		return nil
+	}
+
+	allocator, _ := ctx.Value(rosettaAllocatorKey{}).(*types.RosettaAllocator)
+	return allocator
+}
+
+// NewBlockAPIController creates a server.Router serving the block endpoints with the responses written by encoder
+func NewBlockAPIController(
+	service server.BlockAPIServicer,
+	asserter *asserter.Asserter,
+	encoder encoder.JSONEncoder,
+) server.Router {
+	return &blockAPIController{asserter: asserter, encoder: encoder, service: service}
+}
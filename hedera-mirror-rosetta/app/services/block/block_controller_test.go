@@ -0,0 +1,140 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package block
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/asserter"
+	"github.com/coinbase/rosetta-sdk-go/server"
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/encoder"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubBlockAPIService struct {
+	rErr *rTypes.Error
+}
+
+func (s *stubBlockAPIService) Block(context.Context, *rTypes.BlockRequest) (*rTypes.BlockResponse, *rTypes.Error) {
+	if s.rErr != nil {
+		return nil, s.rErr
+	}
+
+	return exampleBlockResponse(), nil
+}
+
+func (s *stubBlockAPIService) BlockTransaction(
+	context.Context,
+	*rTypes.BlockTransactionRequest,
+) (*rTypes.BlockTransactionResponse, *rTypes.Error) {
+	if s.rErr != nil {
+		return nil, s.rErr
+	}
+
+	return &rTypes.BlockTransactionResponse{Transaction: exampleBlockResponse().Block.Transactions[0]}, nil
+}
+
+func TestBlockAPIController(t *testing.T) {
+	blockRequest, _ := json.Marshal(exampleBlockRequest())
+	blockTransactionRequest := transactionRequest()
+	blockTransactionRequest.NetworkIdentifier = exampleBlockRequest().NetworkIdentifier
+	transactionBody, _ := json.Marshal(blockTransactionRequest)
+	otherNetworkRequest := transactionRequest()
+	otherNetworkRequest.NetworkIdentifier.SubNetworkIdentifier = nil
+	otherNetworkBody, _ := json.Marshal(otherNetworkRequest)
+
+	var tests = []struct {
+		name           string
+		pattern        string
+		body           []byte
+		rErr           *rTypes.Error
+		expectedStatus int
+	}{
+		{name: "Block", pattern: "/block", body: blockRequest, expectedStatus: http.StatusOK},
+		{
+			name:           "BlockServiceError",
+			pattern:        "/block",
+			body:           blockRequest,
+			rErr:           errors.ErrBlockNotFound,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "BlockInvalidBody",
+			pattern:        "/block",
+			body:           []byte("{"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "BlockTransaction",
+			pattern:        "/block/transaction",
+			body:           transactionBody,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "BlockTransactionOtherNetwork",
+			pattern:        "/block/transaction",
+			body:           otherNetworkBody,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			serverAsserter, _ := asserter.NewServer(
+				[]string{"CRYPTOTRANSFER"},
+				true,
+				[]*rTypes.NetworkIdentifier{exampleBlockRequest().NetworkIdentifier},
+				nil,
+				false,
+			)
+			service := &stubBlockAPIService{rErr: tt.rErr}
+			expected := httptest.NewRecorder()
+			serveRoute(server.NewBlockAPIController(service, serverAsserter), tt.pattern, expected, tt.body)
+			recorder := httptest.NewRecorder()
+			controller := NewBlockAPIController(service, serverAsserter, encoder.NewJSONEncoder())
+
+			// when
+			serveRoute(controller, tt.pattern, recorder, tt.body)
+
+			// then
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.Equal(t, expected.Code, recorder.Code)
+			assert.Equal(t, expected.Header().Get("Content-Type"), recorder.Header().Get("Content-Type"))
+			assert.Equal(t, expected.Body.String(), recorder.Body.String())
+		})
+	}
+}
+
+func serveRoute(router server.Router, pattern string, w http.ResponseWriter, body []byte) {
+	for _, route := range router.Routes() {
+		if route.Pattern == pattern {
+			route.HandlerFunc(w, httptest.NewRequest(http.MethodPost, pattern, bytes.NewReader(body)))
+		}
+	}
+}
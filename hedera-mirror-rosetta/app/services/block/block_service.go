@@ -22,23 +22,59 @@ package block
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/coinbase/rosetta-sdk-go/server"
 	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/base"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/hex"
+	log "github.com/sirupsen/logrus"
 )
 
 // BlockAPIService implements the server.BlockAPIServicer interface.
 type BlockAPIService struct {
 	base.BaseService
+	auditMode        string
+	exchangeRateRepo repositories.ExchangeRateRepository
+	maxOperations    int
+	omitZeroAmounts  bool
+	settings         *config.Settings
 }
 
-// NewBlockAPIService creates a new instance of a BlockAPIService.
-func NewBlockAPIService(base base.BaseService) server.BlockAPIServicer {
-	return &BlockAPIService{
-		BaseService: base,
+// NewBlockAPIService creates a new instance of a BlockAPIService. The exchange rate is added to the block metadata
+// when exchangeRateRepo isn't nil, and the operations with a zero amount are left out of the transactions when
+// omitZeroAmounts is true. When maxOperations is positive, a block response carries at most maxOperations operations
+// and the transactions which don't fit are listed in other_transactions instead, without being loaded. Unless
+// auditMode is off, the operations of each transaction are audited before it's returned. The blocks are presented with
+// the settings
+func NewBlockAPIService(
+	base base.BaseService,
+	exchangeRateRepo repositories.ExchangeRateRepository,
+	omitZeroAmounts bool,
+	maxOperations int,
+	auditMode string,
+	settings *config.Settings,
+) (server.BlockAPIServicer, error) {
+	switch auditMode {
+	case "":
+		auditMode = AuditModeOff
+	case AuditModeFail, AuditModeLog, AuditModeOff:
+	default:
+		return nil, fmt.Errorf("unsupported audit mode %s", auditMode)
 	}
+
+	return &BlockAPIService{
+		BaseService:      base,
+		auditMode:        auditMode,
+		exchangeRateRepo: exchangeRateRepo,
+		maxOperations:    maxOperations,
+		omitZeroAmounts:  omitZeroAmounts,
+		settings:         settings,
+	}, nil
 }
 
 // Block implements the /block endpoint.
@@ -51,15 +87,51 @@ func (s *BlockAPIService) Block(
 		return nil, err
 	}
 
-	transactions, err := s.FindBetween(block.ConsensusStartNanos, block.ConsensusEndNanos)
+	var transactions []*types.Transaction
+	var otherHashes []string
+	if s.maxOperations > 0 {
+		transactions, otherHashes, err = s.FindBetweenWithMaxOperations(block.ConsensusStartNanos,
+			block.ConsensusEndNanos, s.maxOperations)
+	} else {
+		transactions, err = s.FindBetween(block.ConsensusStartNanos, block.ConsensusEndNanos)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	if err = s.audit(block.Index, transactions...); err != nil {
+		return nil, err
+	}
+
+	if s.omitZeroAmounts {
+		for _, transaction := range transactions {
+			transaction.OmitZeroAmounts()
+		}
+	}
+
+	var otherTransactions []*rTypes.TransactionIdentifier
+	for _, hash := range otherHashes {
+		otherTransactions = append(otherTransactions, &rTypes.TransactionIdentifier{Hash: hash})
+	}
+
 	block.Transactions = transactions
-	rBlock := block.ToRosetta()
+	rBlock := block.ToRosettaWithAllocator(s.settings, getRosettaAllocator(ctx))
+
+	if s.exchangeRateRepo != nil {
+		exchangeRate, err := s.exchangeRateRepo.FindAt(block.ConsensusEndNanos)
+		if err != nil && err != errors.ErrExchangeRateNotFound {
+			return nil, err
+		}
+
+		if exchangeRate != nil {
+			exchangeRateMetadata := exchangeRate.ToMetadata(s.settings.TimestampStrings)
+			rBlock.Metadata = map[string]interface{}{"exchange_rate": exchangeRateMetadata}
+		}
+	}
+
 	return &rTypes.BlockResponse{
-		Block: rBlock,
+		Block:             rBlock,
+		OtherTransactions: otherTransactions,
 	}, nil
 }
 
@@ -82,8 +154,39 @@ func (s *BlockAPIService) BlockTransaction(
 	if err != nil {
 		return nil, err
 	}
-	rTransaction := transaction.ToRosetta()
+
+	if err = s.audit(block.Index, transaction); err != nil {
+		return nil, err
+	}
+
+	if s.omitZeroAmounts {
+		transaction.OmitZeroAmounts()
+	}
+
+	rTransaction := transaction.ToRosettaWithAllocator(s.settings, getRosettaAllocator(ctx))
 	return &rTypes.BlockTransactionResponse{
 		Transaction: rTransaction,
 	}, nil
 }
+
+// audit logs the operation mismatches of the transactions in the block, and fails with ErrBlockAuditFailed if there
+// is any in fail mode. A mismatch is a data mapping bug, so it's better caught here than by the clients
+func (s *BlockAPIService) audit(blockIndex int64, transactions ...*types.Transaction) *rTypes.Error {
+	if s.auditMode == AuditModeOff {
+		return nil
+	}
+
+	failed := false
+	for _, transaction := range transactions {
+		for _, mismatch := range auditTransaction(transaction) {
+			log.Errorf("Transaction %s in block %d failed the audit: %s", transaction.Hash, blockIndex, mismatch)
+			failed = true
+		}
+	}
+
+	if failed && s.auditMode == AuditModeFail {
+		return errors.ErrBlockAuditFailed
+	}
+
+	return nil
+}
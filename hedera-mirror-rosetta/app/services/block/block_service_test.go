@@ -21,12 +21,15 @@
 package block
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/coinbase/rosetta-sdk-go/server"
 	rTypes "github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/base"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/mocks/repository"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -97,6 +100,37 @@ func dummyTransaction(hash string) *types.Transaction {
 	}
 }
 
+func zeroAmountTransaction() *types.Transaction {
+	return &types.Transaction{
+		Hash: "zero",
+		Operations: []*types.Operation{
+			{Index: 0, Type: "CRYPTOTRANSFER", Status: "SUCCESS", Amount: &types.HbarAmount{}},
+			{Index: 1, Type: "CRYPTOTRANSFER", Status: "SUCCESS", Amount: &types.HbarAmount{Value: 5}},
+		},
+	}
+}
+
+func unbalancedTransaction() *types.Transaction {
+	return &types.Transaction{
+		Hash: "unbalanced",
+		Operations: []*types.Operation{
+			{Index: 0, Type: "CRYPTOTRANSFER", Status: "SUCCESS", Amount: &types.HbarAmount{Value: -10}},
+			{Index: 1, Type: "CRYPTOTRANSFER", Status: "SUCCESS", Amount: &types.HbarAmount{Value: 5}},
+		},
+	}
+}
+
+func assertZeroAmountsOmitted(t *testing.T, omitZeroAmounts bool, operations []*rTypes.Operation) {
+	if omitZeroAmounts {
+		assert.Len(t, operations, 1)
+		assert.Equal(t, int64(0), operations[0].OperationIdentifier.Index)
+		assert.Equal(t, "5", operations[0].Amount.Value)
+	} else {
+		assert.Len(t, operations, 2)
+		assert.Equal(t, "0", operations[0].Amount.Value)
+	}
+}
+
 func transactionRequest() *rTypes.BlockTransactionRequest {
 	return &rTypes.BlockTransactionRequest{
 		NetworkIdentifier: &rTypes.NetworkIdentifier{
@@ -131,16 +165,25 @@ func (suite *blockServiceSuite) SetupTest() {
 	suite.mockTransactionRepo = &repository.MockTransactionRepository{}
 
 	baseService := base.NewBaseService(suite.mockBlockRepo, suite.mockTransactionRepo)
-	suite.blockService = NewBlockAPIService(baseService)
+	suite.blockService, _ = NewBlockAPIService(baseService, nil, false, 0, AuditModeOff, config.NewSettings())
 }
 
 func (suite *blockServiceSuite) TestNewBlockAPIService() {
 	baseService := base.NewBaseService(suite.mockBlockRepo, suite.mockTransactionRepo)
-	blockService := NewBlockAPIService(baseService)
+	blockService, err := NewBlockAPIService(baseService, nil, false, 0, AuditModeOff, config.NewSettings())
 
+	assert.Nil(suite.T(), err)
 	assert.IsType(suite.T(), &BlockAPIService{}, blockService)
 }
 
+func (suite *blockServiceSuite) TestNewBlockAPIServiceInvalidAuditMode() {
+	baseService := base.NewBaseService(suite.mockBlockRepo, suite.mockTransactionRepo)
+	blockService, err := NewBlockAPIService(baseService, nil, false, 0, "strict", config.NewSettings())
+
+	assert.NotNil(suite.T(), err)
+	assert.Nil(suite.T(), blockService)
+}
+
 func (suite *blockServiceSuite) TestBlock() {
 	// given:
 	exampleTransactions := []*types.Transaction{
@@ -159,6 +202,210 @@ func (suite *blockServiceSuite) TestBlock() {
 	assert.Equal(suite.T(), exampleBlockResponse(), res)
 }
 
+func (suite *blockServiceSuite) TestBlockWithExchangeRate() {
+	var tests = []struct {
+		name             string
+		exchangeRate     *types.ExchangeRateSet
+		exchangeRateErr  *rTypes.Error
+		expectedMetadata map[string]interface{}
+		expectError      bool
+	}{
+		{
+			name: "Found",
+			exchangeRate: &types.ExchangeRateSet{
+				ConsensusTimestamp: 100,
+				CurrentRate:        types.ExchangeRate{CentEquiv: 12, ExpirationTime: 1000, HbarEquiv: 1},
+				NextRate:           types.ExchangeRate{CentEquiv: 15, ExpirationTime: 2000, HbarEquiv: 1},
+			},
+			exchangeRateErr: repository.NilError,
+			expectedMetadata: map[string]interface{}{
+				"exchange_rate": map[string]interface{}{
+					"consensus_timestamp": int64(100),
+					"current_rate": map[string]interface{}{
+						"cent_equivalent": int32(12),
+						"expiration_time": int64(1000),
+						"hbar_equivalent": int32(1),
+					},
+					"next_rate": map[string]interface{}{
+						"cent_equivalent": int32(15),
+						"expiration_time": int64(2000),
+						"hbar_equivalent": int32(1),
+					},
+				},
+			},
+		},
+		{
+			name:            "NotFound",
+			exchangeRate:    repository.NilExchangeRate,
+			exchangeRateErr: errors.ErrExchangeRateNotFound,
+		},
+		{
+			name:            "DatabaseError",
+			exchangeRate:    repository.NilExchangeRate,
+			exchangeRateErr: errors.ErrDatabaseError,
+			expectError:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// given
+			mockBlockRepo := &repository.MockBlockRepository{}
+			mockExchangeRateRepo := &repository.MockExchangeRateRepository{}
+			mockTransactionRepo := &repository.MockTransactionRepository{}
+			mockBlockRepo.On("FindByIdentifier").Return(block(), repository.NilError)
+			mockExchangeRateRepo.On("FindAt", block().ConsensusEndNanos).Return(tt.exchangeRate, tt.exchangeRateErr)
+			mockTransactionRepo.On("FindBetween").Return([]*types.Transaction{}, repository.NilError)
+			blockService, _ := NewBlockAPIService(
+				base.NewBaseService(mockBlockRepo, mockTransactionRepo),
+				mockExchangeRateRepo,
+				false,
+				0,
+				AuditModeOff,
+				config.NewSettings(),
+			)
+
+			// when
+			res, e := blockService.Block(nil, exampleBlockRequest())
+
+			// then
+			if tt.expectError {
+				assert.Equal(t, tt.exchangeRateErr, e)
+				assert.Nil(t, res)
+			} else {
+				assert.Nil(t, e)
+				assert.Equal(t, tt.expectedMetadata, res.Block.Metadata)
+			}
+			mockExchangeRateRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func (suite *blockServiceSuite) TestBlockOmitZeroAmounts() {
+	for _, omitZeroAmounts := range []bool{false, true} {
+		suite.T().Run(fmt.Sprintf("%t", omitZeroAmounts), func(t *testing.T) {
+			// given
+			mockBlockRepo := &repository.MockBlockRepository{}
+			mockTransactionRepo := &repository.MockTransactionRepository{}
+			mockBlockRepo.On("FindByIdentifier").Return(block(), repository.NilError)
+			transactions := []*types.Transaction{zeroAmountTransaction()}
+			mockTransactionRepo.On("FindBetween").Return(transactions, repository.NilError)
+			blockService, _ := NewBlockAPIService(
+				base.NewBaseService(mockBlockRepo, mockTransactionRepo),
+				nil,
+				omitZeroAmounts,
+				0,
+				AuditModeOff,
+				config.NewSettings(),
+			)
+
+			// when
+			res, e := blockService.Block(nil, exampleBlockRequest())
+
+			// then
+			assert.Nil(t, e)
+			assertZeroAmountsOmitted(t, omitZeroAmounts, res.Block.Transactions[0].Operations)
+		})
+	}
+}
+
+func (suite *blockServiceSuite) TestBlockMaxOperations() {
+	// given
+	mockBlockRepo := &repository.MockBlockRepository{}
+	mockTransactionRepo := &repository.MockTransactionRepository{}
+	mockBlockRepo.On("FindByIdentifier").Return(block(), repository.NilError)
+	transactions := []*types.Transaction{dummyTransaction("a")}
+	mockTransactionRepo.On("FindBetweenWithMaxOperations", 3).
+		Return(transactions, []string{"b", "c"}, repository.NilError)
+	blockService, _ := NewBlockAPIService(
+		base.NewBaseService(mockBlockRepo, mockTransactionRepo),
+		nil,
+		false,
+		3,
+		AuditModeOff,
+		config.NewSettings(),
+	)
+
+	// when
+	res, e := blockService.Block(nil, exampleBlockRequest())
+
+	// then
+	assert.Nil(suite.T(), e)
+	assert.Len(suite.T(), res.Block.Transactions, 1)
+	assert.Equal(suite.T(), "a", res.Block.Transactions[0].TransactionIdentifier.Hash)
+	assert.Equal(
+		suite.T(),
+		[]*rTypes.TransactionIdentifier{{Hash: "b"}, {Hash: "c"}},
+		res.OtherTransactions,
+	)
+	mockTransactionRepo.AssertNotCalled(suite.T(), "FindBetween")
+}
+
+func (suite *blockServiceSuite) TestBlockThrowsWhenFindBetweenWithMaxOperationsFails() {
+	// given
+	mockBlockRepo := &repository.MockBlockRepository{}
+	mockTransactionRepo := &repository.MockTransactionRepository{}
+	mockBlockRepo.On("FindByIdentifier").Return(block(), repository.NilError)
+	mockTransactionRepo.On("FindBetweenWithMaxOperations", 3).
+		Return([]*types.Transaction{}, []string{}, errors.ErrDatabaseError)
+	blockService, _ := NewBlockAPIService(
+		base.NewBaseService(mockBlockRepo, mockTransactionRepo),
+		nil,
+		false,
+		3,
+		AuditModeOff,
+		config.NewSettings(),
+	)
+
+	// when
+	res, e := blockService.Block(nil, exampleBlockRequest())
+
+	// then
+	assert.Equal(suite.T(), errors.ErrDatabaseError, e)
+	assert.Nil(suite.T(), res)
+}
+
+func (suite *blockServiceSuite) TestBlockAudit() {
+	var tests = []struct {
+		auditMode   string
+		expectedErr *rTypes.Error
+	}{
+		{auditMode: AuditModeFail, expectedErr: errors.ErrBlockAuditFailed},
+		{auditMode: AuditModeLog},
+		{auditMode: AuditModeOff},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.auditMode, func(t *testing.T) {
+			// given
+			mockBlockRepo := &repository.MockBlockRepository{}
+			mockTransactionRepo := &repository.MockTransactionRepository{}
+			mockBlockRepo.On("FindByIdentifier").Return(block(), repository.NilError)
+			transactions := []*types.Transaction{dummyTransaction("123"), unbalancedTransaction()}
+			mockTransactionRepo.On("FindBetween").Return(transactions, repository.NilError)
+			blockService, _ := NewBlockAPIService(
+				base.NewBaseService(mockBlockRepo, mockTransactionRepo),
+				nil,
+				false,
+				0,
+				tt.auditMode,
+				config.NewSettings(),
+			)
+
+			// when
+			res, e := blockService.Block(nil, exampleBlockRequest())
+
+			// then
+			assert.Equal(t, tt.expectedErr, e)
+			if tt.expectedErr != nil {
+				assert.Nil(t, res)
+			} else {
+				assert.Len(t, res.Block.Transactions, 2)
+			}
+		})
+	}
+}
+
 func (suite *blockServiceSuite) TestBlockThrowsWhenFindByIdentifierFails() {
 	// given:
 	suite.mockBlockRepo.On("FindByIdentifier").Return(
@@ -211,6 +458,54 @@ func (suite *blockServiceSuite) TestBlockTransaction() {
 	assert.Nil(suite.T(), e)
 }
 
+func (suite *blockServiceSuite) TestBlockTransactionOmitZeroAmounts() {
+	for _, omitZeroAmounts := range []bool{false, true} {
+		suite.T().Run(fmt.Sprintf("%t", omitZeroAmounts), func(t *testing.T) {
+			// given
+			mockBlockRepo := &repository.MockBlockRepository{}
+			mockTransactionRepo := &repository.MockTransactionRepository{}
+			mockBlockRepo.On("FindByIdentifier").Return(block(), repository.NilError)
+			mockTransactionRepo.On("FindByHashInBlock").Return(zeroAmountTransaction(), repository.NilError)
+			blockService, _ := NewBlockAPIService(
+				base.NewBaseService(mockBlockRepo, mockTransactionRepo),
+				nil,
+				omitZeroAmounts,
+				0,
+				AuditModeOff,
+				config.NewSettings(),
+			)
+
+			// when
+			res, e := blockService.BlockTransaction(nil, transactionRequest())
+
+			// then
+			assert.Nil(t, e)
+			assertZeroAmountsOmitted(t, omitZeroAmounts, res.Transaction.Operations)
+		})
+	}
+}
+
+func (suite *blockServiceSuite) TestBlockTransactionAudit() {
+	// given
+	suite.mockBlockRepo.On("FindByIdentifier").Return(block(), repository.NilError)
+	suite.mockTransactionRepo.On("FindByHashInBlock").Return(unbalancedTransaction(), repository.NilError)
+	blockService, _ := NewBlockAPIService(
+		base.NewBaseService(suite.mockBlockRepo, suite.mockTransactionRepo),
+		nil,
+		false,
+		0,
+		AuditModeFail,
+		config.NewSettings(),
+	)
+
+	// when
+	res, e := blockService.BlockTransaction(nil, transactionRequest())
+
+	// then
+	assert.Equal(suite.T(), errors.ErrBlockAuditFailed, e)
+	assert.Nil(suite.T(), res)
+}
+
 func (suite *blockServiceSuite) TestBlockTransactionThrowsWhenFindByIdentifierFails() {
 	// given:
 	suite.mockBlockRepo.On("FindByIdentifier").Return(repository.NilBlock, &rTypes.Error{})
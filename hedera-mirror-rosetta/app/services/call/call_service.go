@@ -0,0 +1,802 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package call
+
+import (
+	"context"
+	"math"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/journal"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/nodehealth"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/transaction"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/base"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/construction"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/hex"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/parse"
+)
+
+const (
+	// maxReconcileBlocks is the max number of blocks a reconcile call walks through
+	maxReconcileBlocks = 1000
+	// maxSubmissions is the max number of journaled submissions a submissions call returns
+	maxSubmissions = 100
+	// maxTokenRelationships is the max number of token relationships a tokenrelationships call returns
+	maxTokenRelationships = 100
+)
+
+// CallMethod handles a /call method. It returns the result and whether the result is idempotent
+type CallMethod func(parameters map[string]interface{}) (map[string]interface{}, bool, *rTypes.Error)
+
+// CallAPIService implements the server.CallAPIServicer interface.
+type CallAPIService struct {
+	base.BaseService
+	accountRepo          repositories.AccountRepository
+	addressBookRepo      repositories.AddressBookRepository
+	exchangeRateRepo     repositories.ExchangeRateRepository
+	feeScheduleRepo      repositories.FeeScheduleRepository
+	handlers             map[string]CallMethod
+	maxTokenBalances     int
+	nftRepo              repositories.NftRepository
+	nodeHealth           *nodehealth.Tracker
+	prechecker           construction.TransactionPrechecker
+	scheduleRepo         repositories.ScheduleRepository
+	settings             *config.Settings
+	submissionJournal    *journal.Journal
+	tokenAssociationRepo repositories.TokenAssociationRepository
+	tokenRepo            repositories.TokenRepository
+}
+
+// CallAPIServiceOptions are the dependencies and the settings of a CallAPIService
+type CallAPIServiceOptions struct {
+	AccountRepo     repositories.AccountRepository
+	AddressBookRepo repositories.AddressBookRepository
+	// CallMethods are the extra methods served along with the builtin ones, which take precedence
+	CallMethods      map[string]CallMethod
+	ExchangeRateRepo repositories.ExchangeRateRepository
+	FeeScheduleRepo  repositories.FeeScheduleRepository
+	// MaxTokenBalances is the max number of token balances a token_balances call returns unless it's 0
+	MaxTokenBalances int
+	NftRepo          repositories.NftRepository
+	NodeHealth       *nodehealth.Tracker
+	Prechecker       construction.TransactionPrechecker
+	ScheduleRepo     repositories.ScheduleRepository
+	// Settings are the settings the results are presented with
+	Settings             *config.Settings
+	SubmissionJournal    *journal.Journal
+	TokenAssociationRepo repositories.TokenAssociationRepository
+	TokenRepo            repositories.TokenRepository
+}
+
+// NewCallAPIService creates a new instance of a CallAPIService with the options
+func NewCallAPIService(base base.BaseService, options CallAPIServiceOptions) *CallAPIService {
+	c := &CallAPIService{
+		BaseService:          base,
+		accountRepo:          options.AccountRepo,
+		addressBookRepo:      options.AddressBookRepo,
+		exchangeRateRepo:     options.ExchangeRateRepo,
+		feeScheduleRepo:      options.FeeScheduleRepo,
+		maxTokenBalances:     options.MaxTokenBalances,
+		nftRepo:              options.NftRepo,
+		nodeHealth:           options.NodeHealth,
+		prechecker:           options.Prechecker,
+		scheduleRepo:         options.ScheduleRepo,
+		settings:             options.Settings,
+		submissionJournal:    options.SubmissionJournal,
+		tokenAssociationRepo: options.TokenAssociationRepo,
+		tokenRepo:            options.TokenRepo,
+	}
+	c.handlers = map[string]CallMethod{
+		config.CallMethodAddressBook:        c.addressBook,
+		config.CallMethodBlockTimestamp:     c.blockTimestamp,
+		config.CallMethodExchangeRate:       c.exchangeRate,
+		config.CallMethodFeeSchedule:        c.feeSchedule,
+		config.CallMethodNfts:               c.nfts,
+		config.CallMethodNodeHealth:         c.nodeHealthScores,
+		config.CallMethodPrecheck:           c.precheck,
+		config.CallMethodRawTransaction:     c.rawTransaction,
+		config.CallMethodReconcile:          c.reconcile,
+		config.CallMethodScheduleInfo:       c.scheduleInfo,
+		config.CallMethodSubmissions:        c.submissions,
+		config.CallMethodTokenBalances:      c.tokenBalances,
+		config.CallMethodTokenInfo:          c.tokenInfo,
+		config.CallMethodTokenRelationships: c.tokenRelationships,
+		config.CallMethodTransaction:        c.transaction,
+	}
+	for name, method := range options.CallMethods {
+		if _, ok := c.handlers[name]; !ok {
+			c.handlers[name] = method
+		}
+	}
+	return c
+}
+
+// Call implements the /call endpoint.
+func (c *CallAPIService) Call(
+	ctx context.Context,
+	request *rTypes.CallRequest,
+) (*rTypes.CallResponse, *rTypes.Error) {
+	handler, ok := c.handlers[request.Method]
+	if !ok {
+		return nil, errors.ErrCallMethodUnsupported
+	}
+
+	result, idempotent, err := handler(request.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rTypes.CallResponse{Result: result, Idempotent: idempotent}, nil
+}
+
+// addressBook returns the current address book of the nodes with their service endpoints. The result isn't idempotent
+// since the address book can be updated
+func (c *CallAPIService) addressBook(_ map[string]interface{}) (map[string]interface{}, bool, *rTypes.Error) {
+	addressBook, err := c.addressBookRepo.FindLatest()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return addressBook.ToMetadata(c.settings.TimestampStrings), false, nil
+}
+
+// blockTimestamp maps between a block and the consensus timestamps it covers. With the consensus_timestamp parameter,
+// it returns the block containing the timestamp, and with the block_index parameter, the block's consensus timestamp
+// range. The result is idempotent since the blocks never change once found
+func (c *CallAPIService) blockTimestamp(parameters map[string]interface{}) (map[string]interface{}, bool, *rTypes.Error) {
+	consensusTimestamp, hasConsensusTimestamp, err := getConsensusTimestamp(parameters)
+	if err != nil {
+		return nil, false, err
+	}
+
+	value, hasBlockIndex := parameters["block_index"]
+	if hasConsensusTimestamp == hasBlockIndex {
+		return nil, false, invalidParameter("consensus_timestamp")
+	}
+
+	var block *types.Block
+	if hasConsensusTimestamp {
+		block, err = c.FindByConsensusTimestamp(consensusTimestamp)
+	} else {
+		index, ok := value.(float64)
+		if !ok || index < 0 || index != math.Trunc(index) {
+			return nil, false, invalidParameter("block_index")
+		}
+
+		blockIndex := int64(index)
+		block, err = c.RetrieveBlock(&rTypes.PartialBlockIdentifier{Index: &blockIndex})
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	result := map[string]interface{}{
+		"block_identifier": &rTypes.BlockIdentifier{
+			Index: block.Index,
+			Hash:  hex.SafeAddHexPrefix(block.Hash),
+		},
+	}
+	types.AddTimestampMetadata(result, "consensus_end", block.ConsensusEndNanos, c.settings.TimestampStrings)
+	types.AddTimestampMetadata(result, "consensus_start", block.ConsensusStartNanos, c.settings.TimestampStrings)
+
+	return result, true, nil
+}
+
+// exchangeRate returns the current and next exchange rates effective at the optional consensus_timestamp parameter,
+// or the latest if it's not present
+func (c *CallAPIService) exchangeRate(parameters map[string]interface{}) (map[string]interface{}, bool, *rTypes.Error) {
+	consensusTimestamp, ok, err := getConsensusTimestamp(parameters)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !ok {
+		consensusTimestamp = math.MaxInt64
+	}
+
+	exchangeRate, err := c.exchangeRateRepo.FindAt(consensusTimestamp)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return exchangeRate.ToMetadata(c.settings.TimestampStrings), false, nil
+}
+
+// feeSchedule returns the current and next fee schedules effective at the optional consensus_timestamp parameter, or
+// the latest if it's not present. With the optional hedera_functionality parameter, e.g. CryptoTransfer, only the
+// prices of the functionality are returned
+func (c *CallAPIService) feeSchedule(parameters map[string]interface{}) (map[string]interface{}, bool, *rTypes.Error) {
+	consensusTimestamp, ok, err := getConsensusTimestamp(parameters)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !ok {
+		consensusTimestamp = math.MaxInt64
+	}
+
+	var functionality string
+	if value, ok := parameters["hedera_functionality"]; ok {
+		if functionality, ok = value.(string); !ok || functionality == "" {
+			return nil, false, invalidParameter("hedera_functionality")
+		}
+	}
+
+	feeSchedule, err := c.feeScheduleRepo.FindAt(consensusTimestamp)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return feeSchedule.ToMetadata(functionality, c.settings.TimestampStrings), false, nil
+}
+
+// nfts returns the ownership history of the nft with the token_id and serial_number parameters, from its mint to the
+// latest transfer, burn, or wipe. The result isn't idempotent since the nft can be transferred again
+func (c *CallAPIService) nfts(parameters map[string]interface{}) (map[string]interface{}, bool, *rTypes.Error) {
+	tokenIdStr, ok := parameters["token_id"].(string)
+	if !ok {
+		return nil, false, invalidParameter("token_id")
+	}
+
+	tokenId, err := entityid.FromString(tokenIdStr)
+	if err != nil {
+		return nil, false, invalidParameter("token_id")
+	}
+
+	number, ok := parameters["serial_number"].(float64)
+	if !ok || number < 1 || number != math.Trunc(number) {
+		return nil, false, invalidParameter("serial_number")
+	}
+	serialNumber := int64(number)
+
+	transfers, rErr := c.nftRepo.FindTransfers(tokenId.String(), serialNumber)
+	if rErr != nil {
+		return nil, false, rErr
+	}
+
+	history := make([]map[string]interface{}, 0, len(transfers))
+	for _, transfer := range transfers {
+		history = append(history, transfer.ToMetadata(c.settings.TimestampStrings))
+	}
+
+	return map[string]interface{}{
+		"history":       history,
+		"serial_number": serialNumber,
+		"token_id":      tokenId.String(),
+	}, false, nil
+}
+
+// precheck returns the precheck code of the signed_transaction parameter without submitting it, so no fee is charged.
+// The result isn't idempotent since the validity of a transaction depends on time and the payer's balance
+func (c *CallAPIService) precheck(parameters map[string]interface{}) (map[string]interface{}, bool, *rTypes.Error) {
+	signedTransaction, ok := parameters["signed_transaction"].(string)
+	if !ok || signedTransaction == "" {
+		return nil, false, invalidParameter("signed_transaction")
+	}
+
+	status, err := c.prechecker.Precheck(signedTransaction)
+	if err != nil {
+		return nil, false, errors.AddErrorDetails(err, errors.DetailField, "signed_transaction")
+	}
+
+	return map[string]interface{}{"precheck_code": status.String()}, false, nil
+}
+
+// rawTransaction returns the hex encoded bytes of the transactions with the transaction_hash parameter, e.g., a
+// transaction and its duplicates ordered by consensus timestamp, so auditors can decode them independently, e.g., with
+// protoc, without database access. The transaction bytes are null unless the importer persists them, and the record
+// bytes aren't available since the records are only kept in the record files. The result isn't idempotent since a
+// duplicate can still reach consensus
+func (c *CallAPIService) rawTransaction(parameters map[string]interface{}) (
+	map[string]interface{},
+	bool,
+	*rTypes.Error,
+) {
+	hash, ok := parameters["transaction_hash"].(string)
+	if !ok || hash == "" {
+		return nil, false, invalidParameter("transaction_hash")
+	}
+
+	rawTransactions, err := c.FindRawByHash(hash)
+	if err != nil {
+		return nil, false, err
+	}
+
+	transactions := make([]map[string]interface{}, 0, len(rawTransactions))
+	for _, rawTransaction := range rawTransactions {
+		transactions = append(transactions, rawTransaction.ToMetadata(c.settings.TimestampStrings))
+	}
+
+	return map[string]interface{}{"transactions": transactions}, false, nil
+}
+
+// reconcile walks through the blocks from the start_index to the end_index parameter, at most maxReconcileBlocks of
+// them, and compares the hbar balance of the account parameter computed from the successful operations with the
+// balance derived from the balance snapshots. It stops at the first block where they diverge. The result is idempotent
+// since the blocks and the balances at them never change once found
+func (c *CallAPIService) reconcile(parameters map[string]interface{}) (map[string]interface{}, bool, *rTypes.Error) {
+	accountStr, ok := parameters["account"].(string)
+	if !ok || accountStr == "" {
+		return nil, false, invalidParameter("account")
+	}
+
+	account, err := types.AccountFromString(accountStr)
+	if err != nil {
+		return nil, false, invalidParameter("account")
+	}
+
+	startIndex, err := getBlockIndex(parameters, "start_index")
+	if err != nil {
+		return nil, false, err
+	}
+
+	endIndex, err := getBlockIndex(parameters, "end_index")
+	if err != nil {
+		return nil, false, err
+	}
+
+	if endIndex < startIndex || endIndex-startIndex >= maxReconcileBlocks {
+		return nil, false, invalidParameter("end_index")
+	}
+
+	results, err := c.Results()
+	if err != nil {
+		return nil, false, err
+	}
+
+	successfulStatuses := make(map[string]bool)
+	for value, name := range results {
+		if transaction.IsTransactionResultSuccessful(value) {
+			successfulStatuses[name] = true
+		}
+	}
+
+	blocks, err := c.FindBetweenIndexes(startIndex, endIndex)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(blocks) == 0 {
+		return nil, false, errors.ErrBlockNotFound
+	}
+
+	initialBalance, err := c.getHbarBalance(account.String(), blocks[0].ConsensusStartNanos-1)
+	if err != nil {
+		return nil, false, err
+	}
+
+	result := map[string]interface{}{
+		"account":                account.String(),
+		"initial_balance":        (&types.HbarAmount{Value: initialBalance}).ToRosetta(c.settings),
+		"start_block_identifier": toBlockIdentifier(blocks[0]),
+	}
+
+	computedBalance := initialBalance
+	for _, block := range blocks {
+		transactions, err := c.FindBetween(block.ConsensusStartNanos, block.ConsensusEndNanos)
+		if err != nil {
+			return nil, false, err
+		}
+
+		for _, transaction := range transactions {
+			for _, operation := range transaction.Operations {
+				amount, ok := operation.Amount.(*types.HbarAmount)
+				if ok && operation.Account.EncodedId == account.EncodedId && successfulStatuses[operation.Status] {
+					computedBalance += amount.Value
+				}
+			}
+		}
+
+		snapshotBalance, err := c.getHbarBalance(account.String(), block.ConsensusEndNanos)
+		if err != nil {
+			return nil, false, err
+		}
+
+		result["computed_delta"] = (&types.HbarAmount{Value: computedBalance - initialBalance}).ToRosetta(c.settings)
+		result["end_block_identifier"] = toBlockIdentifier(block)
+		result["snapshot_delta"] = (&types.HbarAmount{Value: snapshotBalance - initialBalance}).ToRosetta(c.settings)
+
+		if computedBalance != snapshotBalance {
+			result["divergent_block"] = map[string]interface{}{
+				"block_identifier": toBlockIdentifier(block),
+				"computed_balance": (&types.HbarAmount{Value: computedBalance}).ToRosetta(c.settings),
+				"snapshot_balance": (&types.HbarAmount{Value: snapshotBalance}).ToRosetta(c.settings),
+			}
+			break
+		}
+	}
+
+	result["reconciled"] = result["divergent_block"] == nil
+	return result, true, nil
+}
+
+// getHbarBalance returns the hbar balance of the account at the consensus timestamp
+func (c *CallAPIService) getHbarBalance(account string, consensusTimestamp int64) (int64, *rTypes.Error) {
+	amounts, err := c.accountRepo.RetrieveBalanceAtBlock(account, consensusTimestamp, []int64{}, 0, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, amount := range amounts {
+		if hbarAmount, ok := amount.(*types.HbarAmount); ok {
+			return hbarAmount.Value, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// scheduleInfo returns the schedule info, the result isn't idempotent since a pending schedule can collect more
+// signatures, get executed, or get deleted
+func (c *CallAPIService) scheduleInfo(parameters map[string]interface{}) (map[string]interface{}, bool, *rTypes.Error) {
+	scheduleId, ok := parameters["schedule_id"].(string)
+	if !ok || scheduleId == "" {
+		return nil, false, invalidParameter("schedule_id")
+	}
+
+	schedule, err := c.scheduleRepo.FindById(scheduleId)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return schedule.ToMetadata(c.settings.TimestampStrings), false, nil
+}
+
+// nodeHealthScores returns the health scores of the consensus nodes derived from the outcomes of the submits to them,
+// ordered by node account id. The result isn't idempotent since the scores change with every submit
+func (c *CallAPIService) nodeHealthScores(map[string]interface{}) (map[string]interface{}, bool, *rTypes.Error) {
+	if c.nodeHealth == nil {
+		return nil, false, errors.ErrNotImplemented
+	}
+
+	scores := c.nodeHealth.Scores()
+	nodes := make([]map[string]interface{}, 0, len(scores))
+	for _, score := range scores {
+		nodes = append(nodes, score.ToMetadata())
+	}
+
+	return map[string]interface{}{"nodes": nodes}, false, nil
+}
+
+// submissions returns the journaled submissions matching the optional hash, payer_account_id, and outcome parameters,
+// the latest first and at most limit of them. A pending submission wasn't confirmed to reach a consensus node before a
+// crash and can be replayed with its signed transaction. The result isn't idempotent since submissions keep coming
+func (c *CallAPIService) submissions(parameters map[string]interface{}) (map[string]interface{}, bool, *rTypes.Error) {
+	if c.submissionJournal == nil {
+		return nil, false, errors.ErrNotImplemented
+	}
+
+	filter := journal.Filter{}
+	for name, field := range map[string]*string{
+		"hash":             &filter.Hash,
+		"outcome":          &filter.Outcome,
+		"payer_account_id": &filter.PayerAccountId,
+	} {
+		if value, ok := parameters[name]; ok {
+			str, ok := value.(string)
+			if !ok || str == "" {
+				return nil, false, invalidParameter(name)
+			}
+			*field = str
+		}
+	}
+
+	limit, err := getLimit(parameters, maxSubmissions)
+	if err != nil {
+		return nil, false, err
+	}
+
+	entries := c.submissionJournal.List(filter, limit)
+	submissions := make([]map[string]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		submissions = append(submissions, entry.ToMetadata(c.settings.TimestampStrings))
+	}
+
+	return map[string]interface{}{"submissions": submissions}, false, nil
+}
+
+// tokenBalances pages through the token balances of the account parameter ordered by token id, at the block with the
+// optional block_index parameter or the latest block. The page starts after the optional after_token_id parameter and
+// has at most limit token balances. The result is idempotent only if the block is specified
+func (c *CallAPIService) tokenBalances(parameters map[string]interface{}) (map[string]interface{}, bool, *rTypes.Error) {
+	account, ok := parameters["account"].(string)
+	if !ok || account == "" {
+		return nil, false, invalidParameter("account")
+	}
+
+	afterTokenId, err := getAfterTokenId(parameters)
+	if err != nil {
+		return nil, false, err
+	}
+
+	limit, err := getLimit(parameters, c.maxTokenBalances)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var block *types.Block
+	value, idempotent := parameters["block_index"]
+	if idempotent {
+		index, ok := value.(float64)
+		if !ok || index < 0 {
+			return nil, false, invalidParameter("block_index")
+		}
+
+		blockIndex := int64(index)
+		block, err = c.RetrieveBlock(&rTypes.PartialBlockIdentifier{Index: &blockIndex})
+	} else {
+		block, err = c.RetrieveLatest()
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	// query one more than the limit to detect if there are more token balances
+	queryLimit := 0
+	if limit > 0 {
+		queryLimit = limit + 1
+	}
+	balances, err := c.accountRepo.RetrieveBalanceAtBlock(account, block.ConsensusEndNanos, nil, afterTokenId, queryLimit)
+	if err != nil {
+		return nil, false, err
+	}
+
+	tokenBalances := make([]*rTypes.Amount, 0, len(balances))
+	var lastTokenAmount *types.TokenAmount
+	for _, balance := range balances {
+		if tokenAmount, ok := balance.(*types.TokenAmount); ok {
+			if limit > 0 && len(tokenBalances) == limit {
+				break
+			}
+
+			tokenBalances = append(tokenBalances, tokenAmount.ToRosetta(c.settings))
+			lastTokenAmount = tokenAmount
+		}
+	}
+
+	result := map[string]interface{}{
+		"block_identifier": &rTypes.BlockIdentifier{
+			Index: block.Index,
+			Hash:  hex.SafeAddHexPrefix(block.Hash),
+		},
+		"token_balances": tokenBalances,
+		"truncated":      limit > 0 && len(balances)-1 > limit,
+	}
+	if lastTokenAmount != nil {
+		result["last_token_id"] = lastTokenAmount.TokenId.String()
+	}
+
+	return result, idempotent, nil
+}
+
+// tokenInfo returns the info of the token with the token_id parameter at the block with the optional block_index
+// parameter or the latest block, a superset of the currency metadata. The total supply and the custom fee schedule are
+// as of the block, while the treasury, the keys, and the freeze default are the latest since only the current state of
+// a token is stored. The result isn't idempotent for the same reason
+func (c *CallAPIService) tokenInfo(parameters map[string]interface{}) (map[string]interface{}, bool, *rTypes.Error) {
+	tokenIdStr, ok := parameters["token_id"].(string)
+	if !ok {
+		return nil, false, invalidParameter("token_id")
+	}
+
+	tokenId, err := entityid.FromString(tokenIdStr)
+	if err != nil {
+		return nil, false, invalidParameter("token_id")
+	}
+
+	var block *types.Block
+	var rErr *rTypes.Error
+	if value, ok := parameters["block_index"]; ok {
+		index, ok := value.(float64)
+		if !ok || index < 0 || index != math.Trunc(index) {
+			return nil, false, invalidParameter("block_index")
+		}
+
+		blockIndex := int64(index)
+		block, rErr = c.RetrieveBlock(&rTypes.PartialBlockIdentifier{Index: &blockIndex})
+	} else {
+		block, rErr = c.RetrieveLatest()
+	}
+	if rErr != nil {
+		return nil, false, rErr
+	}
+
+	tokenInfo, rErr := c.tokenRepo.FindInfoAt(tokenId.String(), block.ConsensusEndNanos)
+	if rErr != nil {
+		return nil, false, rErr
+	}
+
+	result := tokenInfo.ToMetadata()
+	result["block_identifier"] = &rTypes.BlockIdentifier{
+		Index: block.Index,
+		Hash:  hex.SafeAddHexPrefix(block.Hash),
+	}
+
+	return result, false, nil
+}
+
+// tokenRelationships pages through the token associations of the account parameter ordered by token id, including the
+// dissociated ones. The page starts after the optional after_token_id parameter and has at most limit associations. The
+// result isn't idempotent since the account can be associated, dissociated, frozen, or granted kyc at any time
+func (c *CallAPIService) tokenRelationships(parameters map[string]interface{}) (
+	map[string]interface{},
+	bool,
+	*rTypes.Error,
+) {
+	account, ok := parameters["account"].(string)
+	if !ok || account == "" {
+		return nil, false, invalidParameter("account")
+	}
+
+	afterTokenId, err := getAfterTokenId(parameters)
+	if err != nil {
+		return nil, false, err
+	}
+
+	limit, err := getLimit(parameters, maxTokenRelationships)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// query one more than the limit to detect if there are more token associations
+	tokenAssociations, err := c.tokenAssociationRepo.FindByAccount(account, afterTokenId, limit+1)
+	if err != nil {
+		return nil, false, err
+	}
+
+	truncated := len(tokenAssociations) > limit
+	if truncated {
+		tokenAssociations = tokenAssociations[:limit]
+	}
+
+	tokenRelationships := make([]map[string]interface{}, 0, len(tokenAssociations))
+	for _, tokenAssociation := range tokenAssociations {
+		tokenRelationships = append(tokenRelationships, tokenAssociation.ToMetadata(c.settings.TimestampStrings))
+	}
+
+	result := map[string]interface{}{
+		"account":             account,
+		"token_relationships": tokenRelationships,
+		"truncated":           truncated,
+	}
+	if len(tokenAssociations) != 0 {
+		result["last_token_id"] = tokenAssociations[len(tokenAssociations)-1].TokenId.String()
+	}
+
+	return result, false, nil
+}
+
+// transaction returns all transactions with the transaction_id parameter in the format of
+// shard.realm.num@seconds.nanos with the optional ?scheduled and /nonce suffixes, so a submission can be looked up
+// without its hash. The duplicates and the failed attempts are returned as separate transactions ordered by consensus
+// timestamp. The result isn't idempotent since a duplicate or a scheduled execution can still reach consensus
+func (c *CallAPIService) transaction(parameters map[string]interface{}) (map[string]interface{}, bool, *rTypes.Error) {
+	transactionIdStr, ok := parameters["transaction_id"].(string)
+	if !ok || transactionIdStr == "" {
+		return nil, false, invalidParameter("transaction_id")
+	}
+
+	transactionId, err := types.NewTransactionIdFromString(transactionIdStr)
+	if err != nil {
+		return nil, false, invalidParameter("transaction_id")
+	}
+
+	transactions, rErr := c.FindByTransactionId(transactionId)
+	if rErr != nil {
+		return nil, false, rErr
+	}
+
+	rosettaTransactions := make([]*rTypes.Transaction, 0, len(transactions))
+	for _, transaction := range transactions {
+		rosettaTransactions = append(rosettaTransactions, transaction.ToRosetta(c.settings))
+	}
+
+	return map[string]interface{}{"transactions": rosettaTransactions}, false, nil
+}
+
+// getConsensusTimestamp returns the optional non-negative consensus_timestamp parameter and whether it's present. Since
+// a nanosecond timestamp can't be precisely represented as a json number, it's also accepted as a string
+func getConsensusTimestamp(parameters map[string]interface{}) (int64, bool, *rTypes.Error) {
+	value, ok := parameters["consensus_timestamp"]
+	if !ok {
+		return 0, false, nil
+	}
+
+	var consensusTimestamp int64
+	switch timestamp := value.(type) {
+	case float64:
+		consensusTimestamp = int64(timestamp)
+	case string:
+		var err error
+		if consensusTimestamp, err = parse.ToInt64(timestamp); err != nil {
+			return 0, false, invalidParameter("consensus_timestamp")
+		}
+	default:
+		return 0, false, invalidParameter("consensus_timestamp")
+	}
+
+	if consensusTimestamp < 0 {
+		return 0, false, invalidParameter("consensus_timestamp")
+	}
+
+	return consensusTimestamp, true, nil
+}
+
+// getAfterTokenId returns the encoded id of the optional after_token_id parameter, or 0 if it's not present
+func getAfterTokenId(parameters map[string]interface{}) (int64, *rTypes.Error) {
+	value, ok := parameters["after_token_id"]
+	if !ok {
+		return 0, nil
+	}
+
+	tokenIdStr, ok := value.(string)
+	if !ok {
+		return 0, invalidParameter("after_token_id")
+	}
+
+	tokenId, err := entityid.FromString(tokenIdStr)
+	if err != nil {
+		return 0, invalidParameter("after_token_id")
+	}
+
+	return tokenId.EncodedId, nil
+}
+
+// getBlockIndex returns the block index parameter with the name, which must be a non-negative integer
+func getBlockIndex(parameters map[string]interface{}, name string) (int64, *rTypes.Error) {
+	index, ok := parameters[name].(float64)
+	if !ok || index < 0 || index != math.Trunc(index) {
+		return 0, invalidParameter(name)
+	}
+
+	return int64(index), nil
+}
+
+// getLimit returns the optional limit parameter which must be a positive integer no larger than maxLimit, or maxLimit
+// if it's not present. A maxLimit of 0 means no limit
+func getLimit(parameters map[string]interface{}, maxLimit int) (int, *rTypes.Error) {
+	value, ok := parameters["limit"]
+	if !ok {
+		return maxLimit, nil
+	}
+
+	number, ok := value.(float64)
+	if !ok || number < 1 || number != math.Trunc(number) || (maxLimit > 0 && int(number) > maxLimit) {
+		return 0, invalidParameter("limit")
+	}
+
+	return int(number), nil
+}
+
+// toBlockIdentifier returns the rosetta block identifier of the block
+func toBlockIdentifier(block *types.Block) *rTypes.BlockIdentifier {
+	return &rTypes.BlockIdentifier{Index: block.Index, Hash: hex.SafeAddHexPrefix(block.Hash)}
+}
+
+// invalidParameter returns ErrInvalidArgument with the name of the offending call parameter in its details
+func invalidParameter(name string) *rTypes.Error {
+	return errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField, name)
+}
@@ -0,0 +1,1623 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package call
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/journal"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/nodehealth"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/base"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/mocks/repository"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	accountIdStr     = "0.0.1001"
+	maxTokenBalances = 2
+	scheduleIdStr    = "0.0.1500"
+	signedTxStr      = "0x0a0b"
+	tokenIdStr       = "0.0.2001"
+)
+
+type mockTransactionPrechecker struct {
+	mock.Mock
+}
+
+func (m *mockTransactionPrechecker) Precheck(signedTransaction string) (hedera.Status, *rTypes.Error) {
+	args := m.Called(signedTransaction)
+	return args.Get(0).(hedera.Status), args.Get(1).(*rTypes.Error)
+}
+
+func TestCallServiceSuite(t *testing.T) {
+	suite.Run(t, new(callServiceSuite))
+}
+
+type callServiceSuite struct {
+	suite.Suite
+	callService              *CallAPIService
+	mockAccountRepo          *repository.MockAccountRepository
+	mockAddressBookRepo      *repository.MockAddressBookRepository
+	mockBlockRepo            *repository.MockBlockRepository
+	mockExchangeRateRepo     *repository.MockExchangeRateRepository
+	mockFeeScheduleRepo      *repository.MockFeeScheduleRepository
+	mockNftRepo              *repository.MockNftRepository
+	mockPrechecker           *mockTransactionPrechecker
+	mockScheduleRepo         *repository.MockScheduleRepository
+	mockTokenAssociationRepo *repository.MockTokenAssociationRepository
+	mockTokenRepo            *repository.MockTokenRepository
+	mockTransactionRepo      *repository.MockTransactionRepository
+	nodeHealth               *nodehealth.Tracker
+	settings                 *config.Settings
+	submissionJournal        *journal.Journal
+}
+
+func (suite *callServiceSuite) SetupTest() {
+	suite.mockAccountRepo = &repository.MockAccountRepository{}
+	suite.mockAddressBookRepo = &repository.MockAddressBookRepository{}
+	suite.mockBlockRepo = &repository.MockBlockRepository{}
+	suite.mockExchangeRateRepo = &repository.MockExchangeRateRepository{}
+	suite.mockFeeScheduleRepo = &repository.MockFeeScheduleRepository{}
+	suite.mockNftRepo = &repository.MockNftRepository{}
+	suite.mockPrechecker = &mockTransactionPrechecker{}
+	suite.mockScheduleRepo = &repository.MockScheduleRepository{}
+	suite.mockTokenAssociationRepo = &repository.MockTokenAssociationRepository{}
+	suite.mockTokenRepo = &repository.MockTokenRepository{}
+	suite.mockTransactionRepo = &repository.MockTransactionRepository{}
+	suite.nodeHealth = nodehealth.NewTracker()
+	suite.settings = config.NewSettings()
+	suite.submissionJournal, _ = journal.Open(filepath.Join(suite.T().TempDir(), "submissions.jsonl"), 10)
+	suite.callService = suite.newCallAPIService(suite.mockExchangeRateRepo)
+}
+
+func (suite *callServiceSuite) newCallAPIService(
+	exchangeRateRepo *repository.MockExchangeRateRepository,
+) *CallAPIService {
+	baseService := base.NewBaseService(suite.mockBlockRepo, suite.mockTransactionRepo)
+	return NewCallAPIService(baseService, CallAPIServiceOptions{
+		AccountRepo:          suite.mockAccountRepo,
+		AddressBookRepo:      suite.mockAddressBookRepo,
+		ExchangeRateRepo:     exchangeRateRepo,
+		FeeScheduleRepo:      suite.mockFeeScheduleRepo,
+		NftRepo:              suite.mockNftRepo,
+		ScheduleRepo:         suite.mockScheduleRepo,
+		Settings:             suite.settings,
+		TokenAssociationRepo: suite.mockTokenAssociationRepo,
+		TokenRepo:            suite.mockTokenRepo,
+		Prechecker:           suite.mockPrechecker,
+		SubmissionJournal:    suite.submissionJournal,
+		NodeHealth:           suite.nodeHealth,
+		MaxTokenBalances:     maxTokenBalances,
+		CallMethods:          map[string]CallMethod{"addressbook": suite.customCallMethod, "custom": suite.customCallMethod},
+	})
+}
+
+func (suite *callServiceSuite) TestAddressBook() {
+	// given
+	addressBook := &types.AddressBook{
+		ConsensusTimestamp: 100,
+		FileId:             entityid.EntityId{EntityNum: 102, EncodedId: 102},
+		Nodes: []*types.AddressBookNode{
+			{
+				NodeAccountId:    types.Account{EntityId: entityid.EntityId{EntityNum: 3, EncodedId: 3}},
+				NodeId:           0,
+				PublicKey:        "308201a2",
+				ServiceEndpoints: []types.ServiceEndpoint{{IpAddressV4: "10.0.0.1", Port: 50211}},
+			},
+		},
+	}
+	suite.mockAddressBookRepo.On("FindLatest").Return(addressBook, repository.NilError)
+
+	// when
+	actual, err := suite.callService.Call(nil, &rTypes.CallRequest{Method: "addressbook"})
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), &rTypes.CallResponse{Result: addressBook.ToMetadata(false)}, actual)
+	suite.mockAddressBookRepo.AssertExpectations(suite.T())
+}
+
+func (suite *callServiceSuite) TestAddressBookNotFound() {
+	// given
+	suite.mockAddressBookRepo.On("FindLatest").Return(repository.NilAddressBook, errors.ErrAddressBookNotFound)
+
+	// when
+	actual, err := suite.callService.Call(nil, &rTypes.CallRequest{Method: "addressbook"})
+
+	// then
+	assert.Equal(suite.T(), errors.ErrAddressBookNotFound, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *callServiceSuite) TestBlockTimestamp() {
+	block := &types.Block{
+		ConsensusStartNanos: 1623101500000000000,
+		ConsensusEndNanos:   1623101501999999999,
+		Hash:                "123abc",
+		Index:               5,
+	}
+	expected := &rTypes.CallResponse{
+		Result: map[string]interface{}{
+			"block_identifier": &rTypes.BlockIdentifier{Index: 5, Hash: "0x123abc"},
+			"consensus_end":    block.ConsensusEndNanos,
+			"consensus_start":  block.ConsensusStartNanos,
+		},
+		Idempotent: true,
+	}
+
+	var tests = []struct {
+		name       string
+		parameters map[string]interface{}
+		setup      func(mockBlockRepo *repository.MockBlockRepository)
+	}{
+		{
+			name:       "NumberTimestamp",
+			parameters: map[string]interface{}{"consensus_timestamp": float64(1623101501000000000)},
+			setup: func(mockBlockRepo *repository.MockBlockRepository) {
+				mockBlockRepo.On("FindByConsensusTimestamp", int64(1623101501000000000)).
+					Return(block, repository.NilError)
+			},
+		},
+		{
+			name:       "StringTimestamp",
+			parameters: map[string]interface{}{"consensus_timestamp": "1623101501000000001"},
+			setup: func(mockBlockRepo *repository.MockBlockRepository) {
+				mockBlockRepo.On("FindByConsensusTimestamp", int64(1623101501000000001)).
+					Return(block, repository.NilError)
+			},
+		},
+		{
+			name:       "BlockIndex",
+			parameters: map[string]interface{}{"block_index": float64(5)},
+			setup: func(mockBlockRepo *repository.MockBlockRepository) {
+				mockBlockRepo.On("FindByIndex").Return(block, repository.NilError)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// given
+			suite.SetupTest()
+			tt.setup(suite.mockBlockRepo)
+
+			// when
+			actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+				Method:     "block_timestamp",
+				Parameters: tt.parameters,
+			})
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, expected, actual)
+			suite.mockBlockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func (suite *callServiceSuite) TestBlockTimestampNotFound() {
+	// given
+	suite.mockBlockRepo.On("FindByConsensusTimestamp", int64(100)).
+		Return(repository.NilBlock, errors.ErrBlockNotFound)
+
+	// when
+	actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+		Method:     "block_timestamp",
+		Parameters: map[string]interface{}{"consensus_timestamp": float64(100)},
+	})
+
+	// then
+	assert.Equal(suite.T(), errors.ErrBlockNotFound, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *callServiceSuite) TestBlockTimestampInvalidParameters() {
+	var tests = []struct {
+		name       string
+		parameters map[string]interface{}
+		field      string
+	}{
+		{name: "NoParameters", field: "consensus_timestamp"},
+		{
+			name:       "BothParameters",
+			parameters: map[string]interface{}{"block_index": float64(1), "consensus_timestamp": float64(100)},
+			field:      "consensus_timestamp",
+		},
+		{
+			name:       "InvalidTimestamp",
+			parameters: map[string]interface{}{"consensus_timestamp": "abc"},
+			field:      "consensus_timestamp",
+		},
+		{
+			name:       "NegativeBlockIndex",
+			parameters: map[string]interface{}{"block_index": float64(-1)},
+			field:      "block_index",
+		},
+		{
+			name:       "FractionalBlockIndex",
+			parameters: map[string]interface{}{"block_index": 1.5},
+			field:      "block_index",
+		},
+		{
+			name:       "InvalidBlockIndexType",
+			parameters: map[string]interface{}{"block_index": "1"},
+			field:      "block_index",
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+				Method:     "block_timestamp",
+				Parameters: tt.parameters,
+			})
+
+			// then
+			assert.Equal(t, errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField, tt.field), err)
+			assert.Nil(t, actual)
+		})
+	}
+
+	suite.mockBlockRepo.AssertNotCalled(suite.T(), "FindByConsensusTimestamp", mock.Anything)
+	suite.mockBlockRepo.AssertNotCalled(suite.T(), "FindByIndex")
+}
+
+func (suite *callServiceSuite) TestExchangeRate() {
+	exchangeRate := &types.ExchangeRateSet{
+		ConsensusTimestamp: 100,
+		CurrentRate:        types.ExchangeRate{CentEquiv: 12, ExpirationTime: 1000, HbarEquiv: 1},
+		NextRate:           types.ExchangeRate{CentEquiv: 15, ExpirationTime: 2000, HbarEquiv: 1},
+	}
+
+	var tests = []struct {
+		name              string
+		parameters        map[string]interface{}
+		expectedTimestamp int64
+	}{
+		{name: "Latest", expectedTimestamp: math.MaxInt64},
+		{
+			name:              "NumberTimestamp",
+			parameters:        map[string]interface{}{"consensus_timestamp": float64(1500)},
+			expectedTimestamp: 1500,
+		},
+		{
+			name:              "StringTimestamp",
+			parameters:        map[string]interface{}{"consensus_timestamp": "1623101500123456789"},
+			expectedTimestamp: 1623101500123456789,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// given
+			mockExchangeRateRepo := &repository.MockExchangeRateRepository{}
+			mockExchangeRateRepo.On("FindAt", tt.expectedTimestamp).Return(exchangeRate, repository.NilError)
+			callService := suite.newCallAPIService(mockExchangeRateRepo)
+
+			// when
+			actual, err := callService.Call(nil, &rTypes.CallRequest{
+				Method:     "exchangerate",
+				Parameters: tt.parameters,
+			})
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, &rTypes.CallResponse{Result: exchangeRate.ToMetadata(false)}, actual)
+			mockExchangeRateRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func (suite *callServiceSuite) TestExchangeRateInvalidTimestamp() {
+	expected := errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField, "consensus_timestamp")
+	var tests = []struct {
+		name      string
+		timestamp interface{}
+	}{
+		{name: "InvalidString", timestamp: "abc"},
+		{name: "Negative", timestamp: float64(-1)},
+		{name: "InvalidType", timestamp: true},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+				Method:     "exchangerate",
+				Parameters: map[string]interface{}{"consensus_timestamp": tt.timestamp},
+			})
+
+			// then
+			assert.Equal(t, expected, err)
+			assert.Nil(t, actual)
+		})
+	}
+
+	suite.mockExchangeRateRepo.AssertNotCalled(suite.T(), "FindAt")
+}
+
+func (suite *callServiceSuite) TestExchangeRateNotFound() {
+	// given
+	suite.mockExchangeRateRepo.On("FindAt", int64(math.MaxInt64)).
+		Return(repository.NilExchangeRate, errors.ErrExchangeRateNotFound)
+
+	// when
+	actual, err := suite.callService.Call(nil, &rTypes.CallRequest{Method: "exchangerate"})
+
+	// then
+	assert.Equal(suite.T(), errors.ErrExchangeRateNotFound, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *callServiceSuite) TestFeeSchedule() {
+	feeSchedule := &types.FeeScheduleSet{
+		ConsensusTimestamp: 100,
+		CurrentSchedule: types.FeeSchedule{
+			ExpiryTime: 1000,
+			TransactionFeeSchedules: []types.TransactionFeeSchedule{
+				{Fees: []types.FeeData{{SubType: "DEFAULT"}}, Functionality: "CryptoTransfer"},
+				{Fees: []types.FeeData{{SubType: "DEFAULT"}}, Functionality: "TokenMint"},
+			},
+		},
+		NextSchedule: types.FeeSchedule{ExpiryTime: 2000},
+	}
+
+	var tests = []struct {
+		name              string
+		parameters        map[string]interface{}
+		expectedTimestamp int64
+		functionality     string
+	}{
+		{name: "Latest", expectedTimestamp: math.MaxInt64},
+		{
+			name:              "Timestamp",
+			parameters:        map[string]interface{}{"consensus_timestamp": "1623101500123456789"},
+			expectedTimestamp: 1623101500123456789,
+		},
+		{
+			name:              "Functionality",
+			parameters:        map[string]interface{}{"hedera_functionality": "TokenMint"},
+			expectedTimestamp: math.MaxInt64,
+			functionality:     "TokenMint",
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// given
+			suite.SetupTest()
+			suite.mockFeeScheduleRepo.On("FindAt", tt.expectedTimestamp).Return(feeSchedule, repository.NilError)
+
+			// when
+			actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+				Method:     "feeschedule",
+				Parameters: tt.parameters,
+			})
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, &rTypes.CallResponse{Result: feeSchedule.ToMetadata(tt.functionality, false)}, actual)
+			suite.mockFeeScheduleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func (suite *callServiceSuite) TestFeeScheduleInvalidParameters() {
+	var tests = []struct {
+		name       string
+		parameters map[string]interface{}
+		field      string
+	}{
+		{
+			name:       "InvalidTimestamp",
+			parameters: map[string]interface{}{"consensus_timestamp": "abc"},
+			field:      "consensus_timestamp",
+		},
+		{
+			name:       "EmptyFunctionality",
+			parameters: map[string]interface{}{"hedera_functionality": ""},
+			field:      "hedera_functionality",
+		},
+		{
+			name:       "InvalidFunctionalityType",
+			parameters: map[string]interface{}{"hedera_functionality": float64(1)},
+			field:      "hedera_functionality",
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+				Method:     "feeschedule",
+				Parameters: tt.parameters,
+			})
+
+			// then
+			assert.Equal(t, errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField, tt.field), err)
+			assert.Nil(t, actual)
+		})
+	}
+
+	suite.mockFeeScheduleRepo.AssertNotCalled(suite.T(), "FindAt")
+}
+
+func (suite *callServiceSuite) TestFeeScheduleNotFound() {
+	// given
+	suite.mockFeeScheduleRepo.On("FindAt", int64(math.MaxInt64)).
+		Return(repository.NilFeeSchedule, errors.ErrFeeScheduleNotFound)
+
+	// when
+	actual, err := suite.callService.Call(nil, &rTypes.CallRequest{Method: "feeschedule"})
+
+	// then
+	assert.Equal(suite.T(), errors.ErrFeeScheduleNotFound, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *callServiceSuite) TestNfts() {
+	// given
+	owner := types.Account{EntityId: entityid.EntityId{EntityNum: 1001, EncodedId: 1001}}
+	transfers := []*types.NftTransfer{{ConsensusTimestamp: 100, Receiver: &owner, Type: types.NftTransferTypeMint}}
+	suite.mockNftRepo.On("FindTransfers", tokenIdStr, int64(5)).Return(transfers, repository.NilError)
+	expected := &rTypes.CallResponse{
+		Result: map[string]interface{}{
+			"history": []map[string]interface{}{
+				{"consensus_timestamp": int64(100), "receiver_account_id": "0.0.1001", "type": "MINT"},
+			},
+			"serial_number": int64(5),
+			"token_id":      tokenIdStr,
+		},
+		Idempotent: false,
+	}
+
+	// when
+	actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+		Method:     "nfts",
+		Parameters: map[string]interface{}{"token_id": tokenIdStr, "serial_number": float64(5)},
+	})
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expected, actual)
+	suite.mockNftRepo.AssertExpectations(suite.T())
+}
+
+func (suite *callServiceSuite) TestNftsNotFound() {
+	// given
+	suite.mockNftRepo.On("FindTransfers", tokenIdStr, int64(5)).Return([]*types.NftTransfer{}, errors.ErrNftNotFound)
+
+	// when
+	actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+		Method:     "nfts",
+		Parameters: map[string]interface{}{"token_id": tokenIdStr, "serial_number": float64(5)},
+	})
+
+	// then
+	assert.Equal(suite.T(), errors.ErrNftNotFound, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *callServiceSuite) TestNftsInvalidParameters() {
+	var tests = []struct {
+		name       string
+		parameters map[string]interface{}
+		field      string
+	}{
+		{name: "nil parameters", field: "token_id"},
+		{name: "invalid token_id", parameters: map[string]interface{}{"token_id": "abc"}, field: "token_id"},
+		{name: "non-string token_id", parameters: map[string]interface{}{"token_id": 2001}, field: "token_id"},
+		{
+			name:       "missing serial_number",
+			parameters: map[string]interface{}{"token_id": tokenIdStr},
+			field:      "serial_number",
+		},
+		{
+			name:       "zero serial_number",
+			parameters: map[string]interface{}{"token_id": tokenIdStr, "serial_number": float64(0)},
+			field:      "serial_number",
+		},
+		{
+			name:       "fractional serial_number",
+			parameters: map[string]interface{}{"token_id": tokenIdStr, "serial_number": 1.5},
+			field:      "serial_number",
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := suite.callService.Call(nil, &rTypes.CallRequest{Method: "nfts", Parameters: tt.parameters})
+
+			// then
+			assert.Equal(t, errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField, tt.field), err)
+			assert.Nil(t, actual)
+		})
+	}
+
+	suite.mockNftRepo.AssertNotCalled(suite.T(), "FindTransfers")
+}
+
+func (suite *callServiceSuite) TestPrecheck() {
+	var tests = []struct {
+		name     string
+		status   hedera.Status
+		expected string
+	}{
+		{name: "Ok", status: hedera.StatusOk, expected: "OK"},
+		{name: "InvalidSignature", status: hedera.StatusInvalidSignature, expected: "INVALID_SIGNATURE"},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// given
+			suite.SetupTest()
+			suite.mockPrechecker.On("Precheck", signedTxStr).Return(tt.status, repository.NilError)
+
+			// when
+			actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+				Method:     "precheck",
+				Parameters: map[string]interface{}{"signed_transaction": signedTxStr},
+			})
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, &rTypes.CallResponse{
+				Result:     map[string]interface{}{"precheck_code": tt.expected},
+				Idempotent: false,
+			}, actual)
+			suite.mockPrechecker.AssertExpectations(t)
+		})
+	}
+}
+
+func (suite *callServiceSuite) TestPrecheckThrows() {
+	// given
+	suite.mockPrechecker.On("Precheck", signedTxStr).Return(hedera.Status(0), errors.ErrTransactionDecodeFailed)
+
+	// when
+	actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+		Method:     "precheck",
+		Parameters: map[string]interface{}{"signed_transaction": signedTxStr},
+	})
+
+	// then
+	expected := errors.AddErrorDetails(errors.ErrTransactionDecodeFailed, errors.DetailField, "signed_transaction")
+	assert.Equal(suite.T(), expected, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *callServiceSuite) TestPrecheckInvalidParameters() {
+	var tests = []struct {
+		name       string
+		parameters map[string]interface{}
+	}{
+		{name: "nil parameters"},
+		{name: "empty signed_transaction", parameters: map[string]interface{}{"signed_transaction": ""}},
+		{name: "non-string signed_transaction", parameters: map[string]interface{}{"signed_transaction": 1}},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+				Method:     "precheck",
+				Parameters: tt.parameters,
+			})
+
+			// then
+			expected := errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField, "signed_transaction")
+			assert.Equal(t, expected, err)
+			assert.Nil(t, actual)
+		})
+	}
+
+	suite.mockPrechecker.AssertNotCalled(suite.T(), "Precheck")
+}
+
+func (suite *callServiceSuite) TestRawTransaction() {
+	// given
+	rawTransactions := []*types.RawTransaction{
+		{ConsensusTimestamp: 20, Result: "SUCCESS", TransactionBytes: []byte{0x1, 0x2}},
+		{ConsensusTimestamp: 21, Result: "DUPLICATE_TRANSACTION"},
+	}
+	expected := &rTypes.CallResponse{
+		Result: map[string]interface{}{
+			"transactions": []map[string]interface{}{
+				{"consensus_timestamp": int64(20), "result": "SUCCESS", "transaction_bytes": "0x0102"},
+				{"consensus_timestamp": int64(21), "result": "DUPLICATE_TRANSACTION", "transaction_bytes": nil},
+			},
+		},
+		Idempotent: false,
+	}
+	suite.mockTransactionRepo.On("FindRawByHash", "0x0a0b").Return(rawTransactions, repository.NilError)
+
+	// when
+	actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+		Method:     "rawtransaction",
+		Parameters: map[string]interface{}{"transaction_hash": "0x0a0b"},
+	})
+
+	// then
+	assert.Equal(suite.T(), expected, actual)
+	assert.Nil(suite.T(), err)
+	suite.mockTransactionRepo.AssertExpectations(suite.T())
+}
+
+func (suite *callServiceSuite) TestRawTransactionNotFound() {
+	// given
+	suite.mockTransactionRepo.On("FindRawByHash", mock.Anything).
+		Return([]*types.RawTransaction(nil), errors.ErrTransactionNotFound)
+
+	// when
+	actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+		Method:     "rawtransaction",
+		Parameters: map[string]interface{}{"transaction_hash": "0x0a0b"},
+	})
+
+	// then
+	assert.Equal(suite.T(), errors.ErrTransactionNotFound, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *callServiceSuite) TestRawTransactionInvalidParameters() {
+	var tests = []struct {
+		name       string
+		parameters map[string]interface{}
+	}{
+		{name: "nil parameters"},
+		{name: "missing transaction_hash", parameters: map[string]interface{}{}},
+		{name: "empty transaction_hash", parameters: map[string]interface{}{"transaction_hash": ""}},
+		{name: "non-string transaction_hash", parameters: map[string]interface{}{"transaction_hash": 1001}},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+				Method:     "rawtransaction",
+				Parameters: tt.parameters,
+			})
+
+			// then
+			assert.Equal(t, errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField, "transaction_hash"),
+				err)
+			assert.Nil(t, actual)
+		})
+	}
+
+	suite.mockTransactionRepo.AssertNotCalled(suite.T(), "FindRawByHash")
+}
+
+func (suite *callServiceSuite) TestReconcile() {
+	// given
+	blocks := suite.reconcileBlocks()
+	suite.mockBlockRepo.On("FindBetweenIndexes", int64(1), int64(2)).Return(blocks, repository.NilError)
+	suite.mockTransactionRepo.On("Results").Return(map[int]string{22: "SUCCESS", 11: "DUPLICATE_TRANSACTION"},
+		repository.NilError)
+	suite.mockTransactionRepo.On("FindBetween").Return(reconcileTransactions(-50, "SUCCESS"), repository.NilError).
+		Once()
+	suite.mockTransactionRepo.On("FindBetween").Return(
+		reconcileTransactions(-30, "DUPLICATE_TRANSACTION"),
+		repository.NilError,
+	).Once()
+	suite.mockAccountRepo.On("RetrieveBalanceAtBlock", accountIdStr, int64(99), []int64{}, int64(0), 0).
+		Return([]types.Amount{&types.HbarAmount{Value: 1000}}, repository.NilError)
+	suite.mockAccountRepo.On("RetrieveBalanceAtBlock", accountIdStr, int64(199), []int64{}, int64(0), 0).
+		Return([]types.Amount{&types.HbarAmount{Value: 950}}, repository.NilError)
+	suite.mockAccountRepo.On("RetrieveBalanceAtBlock", accountIdStr, int64(299), []int64{}, int64(0), 0).
+		Return([]types.Amount{&types.HbarAmount{Value: 950}}, repository.NilError)
+
+	// when
+	actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+		Method:     "reconcile",
+		Parameters: map[string]interface{}{"account": accountIdStr, "start_index": 1.0, "end_index": 2.0},
+	})
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), &rTypes.CallResponse{
+		Result: map[string]interface{}{
+			"account":                accountIdStr,
+			"computed_delta":         (&types.HbarAmount{Value: -50}).ToRosetta(suite.settings),
+			"end_block_identifier":   &rTypes.BlockIdentifier{Index: 2, Hash: "0x0b02"},
+			"initial_balance":        (&types.HbarAmount{Value: 1000}).ToRosetta(suite.settings),
+			"reconciled":             true,
+			"snapshot_delta":         (&types.HbarAmount{Value: -50}).ToRosetta(suite.settings),
+			"start_block_identifier": &rTypes.BlockIdentifier{Index: 1, Hash: "0x0b01"},
+		},
+		Idempotent: true,
+	}, actual)
+	suite.mockAccountRepo.AssertExpectations(suite.T())
+	suite.mockBlockRepo.AssertExpectations(suite.T())
+	suite.mockTransactionRepo.AssertExpectations(suite.T())
+}
+
+func (suite *callServiceSuite) TestReconcileDivergent() {
+	// given
+	blocks := suite.reconcileBlocks()
+	suite.mockBlockRepo.On("FindBetweenIndexes", int64(1), int64(2)).Return(blocks, repository.NilError)
+	suite.mockTransactionRepo.On("Results").Return(map[int]string{22: "SUCCESS"}, repository.NilError)
+	suite.mockTransactionRepo.On("FindBetween").Return(reconcileTransactions(-50, "SUCCESS"), repository.NilError).
+		Once()
+	suite.mockAccountRepo.On("RetrieveBalanceAtBlock", accountIdStr, int64(99), []int64{}, int64(0), 0).
+		Return([]types.Amount{&types.HbarAmount{Value: 1000}}, repository.NilError)
+	suite.mockAccountRepo.On("RetrieveBalanceAtBlock", accountIdStr, int64(199), []int64{}, int64(0), 0).
+		Return([]types.Amount{&types.HbarAmount{Value: 900}}, repository.NilError)
+
+	// when
+	actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+		Method:     "reconcile",
+		Parameters: map[string]interface{}{"account": accountIdStr, "start_index": 1.0, "end_index": 2.0},
+	})
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), &rTypes.CallResponse{
+		Result: map[string]interface{}{
+			"account":        accountIdStr,
+			"computed_delta": (&types.HbarAmount{Value: -50}).ToRosetta(suite.settings),
+			"divergent_block": map[string]interface{}{
+				"block_identifier": &rTypes.BlockIdentifier{Index: 1, Hash: "0x0b01"},
+				"computed_balance": (&types.HbarAmount{Value: 950}).ToRosetta(suite.settings),
+				"snapshot_balance": (&types.HbarAmount{Value: 900}).ToRosetta(suite.settings),
+			},
+			"end_block_identifier":   &rTypes.BlockIdentifier{Index: 1, Hash: "0x0b01"},
+			"initial_balance":        (&types.HbarAmount{Value: 1000}).ToRosetta(suite.settings),
+			"reconciled":             false,
+			"snapshot_delta":         (&types.HbarAmount{Value: -100}).ToRosetta(suite.settings),
+			"start_block_identifier": &rTypes.BlockIdentifier{Index: 1, Hash: "0x0b01"},
+		},
+		Idempotent: true,
+	}, actual)
+	suite.mockAccountRepo.AssertExpectations(suite.T())
+	suite.mockTransactionRepo.AssertNumberOfCalls(suite.T(), "FindBetween", 1)
+}
+
+func (suite *callServiceSuite) TestReconcileBlockNotFound() {
+	// given
+	suite.mockTransactionRepo.On("Results").Return(map[int]string{22: "SUCCESS"}, repository.NilError)
+	suite.mockBlockRepo.On("FindBetweenIndexes", int64(1), int64(2)).Return([]*types.Block{}, repository.NilError)
+
+	// when
+	actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+		Method:     "reconcile",
+		Parameters: map[string]interface{}{"account": accountIdStr, "start_index": 1.0, "end_index": 2.0},
+	})
+
+	// then
+	assert.Equal(suite.T(), errors.ErrBlockNotFound, err)
+	assert.Nil(suite.T(), actual)
+	suite.mockAccountRepo.AssertNotCalled(suite.T(), "RetrieveBalanceAtBlock")
+}
+
+func (suite *callServiceSuite) TestReconcileInvalidParameters() {
+	var tests = []struct {
+		name       string
+		parameters map[string]interface{}
+		field      string
+	}{
+		{name: "nil parameters", field: "account"},
+		{
+			name:       "invalid account",
+			parameters: map[string]interface{}{"account": "a", "start_index": 1.0, "end_index": 2.0},
+			field:      "account",
+		},
+		{
+			name:       "missing start_index",
+			parameters: map[string]interface{}{"account": accountIdStr, "end_index": 2.0},
+			field:      "start_index",
+		},
+		{
+			name:       "negative start_index",
+			parameters: map[string]interface{}{"account": accountIdStr, "start_index": -1.0, "end_index": 2.0},
+			field:      "start_index",
+		},
+		{
+			name:       "fractional end_index",
+			parameters: map[string]interface{}{"account": accountIdStr, "start_index": 1.0, "end_index": 2.5},
+			field:      "end_index",
+		},
+		{
+			name:       "end_index before start_index",
+			parameters: map[string]interface{}{"account": accountIdStr, "start_index": 2.0, "end_index": 1.0},
+			field:      "end_index",
+		},
+		{
+			name: "range too large",
+			parameters: map[string]interface{}{
+				"account":     accountIdStr,
+				"start_index": 1.0,
+				"end_index":   float64(maxReconcileBlocks + 1),
+			},
+			field: "end_index",
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+				Method:     "reconcile",
+				Parameters: tt.parameters,
+			})
+
+			// then
+			expected := errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField, tt.field)
+			assert.Equal(t, expected, err)
+			assert.Nil(t, actual)
+		})
+	}
+
+	suite.mockBlockRepo.AssertNotCalled(suite.T(), "FindBetweenIndexes")
+}
+
+func (suite *callServiceSuite) reconcileBlocks() []*types.Block {
+	return []*types.Block{
+		{Index: 1, Hash: "0b01", ConsensusStartNanos: 100, ConsensusEndNanos: 199},
+		{Index: 2, Hash: "0b02", ConsensusStartNanos: 200, ConsensusEndNanos: 299},
+	}
+}
+
+func reconcileTransactions(amount int64, status string) []*types.Transaction {
+	account := types.Account{EntityId: entityid.EntityId{EntityNum: 1001, EncodedId: 1001}}
+	other := types.Account{EntityId: entityid.EntityId{EntityNum: 1002, EncodedId: 1002}}
+	return []*types.Transaction{
+		{
+			Hash: "0x1234",
+			Operations: []*types.Operation{
+				{Index: 0, Status: status, Account: account, Amount: &types.HbarAmount{Value: amount}},
+				{Index: 1, Status: status, Account: other, Amount: &types.HbarAmount{Value: -amount}},
+			},
+		},
+	}
+}
+
+func (suite *callServiceSuite) TestScheduleInfo() {
+	// given
+	schedule := &types.Schedule{
+		ConsensusTimestamp: 10,
+		CreatorAccountId:   types.Account{EntityId: entityid.EntityId{EntityNum: 1001, EncodedId: 1001}},
+		PayerAccountId:     types.Account{EntityId: entityid.EntityId{EntityNum: 1002, EncodedId: 1002}},
+		ScheduleId:         entityid.EntityId{EntityNum: 1500, EncodedId: 1500},
+		Signatures:         [][]byte{{0x1, 0x2}},
+		TransactionBody:    []byte{0x10},
+	}
+	expected := &rTypes.CallResponse{
+		Result: map[string]interface{}{
+			"consensus_timestamp": int64(10),
+			"creator_account_id":  "0.0.1001",
+			"payer_account_id":    "0.0.1002",
+			"schedule_id":         scheduleIdStr,
+			"signatures":          []string{"0102"},
+			"status":              types.ScheduleStatusPending,
+			"transaction_body":    "10",
+		},
+		Idempotent: false,
+	}
+	suite.mockScheduleRepo.On("FindById", scheduleIdStr).Return(schedule, repository.NilError)
+
+	// when
+	actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+		Method:     "schedule_info",
+		Parameters: map[string]interface{}{"schedule_id": scheduleIdStr},
+	})
+
+	// then
+	assert.Equal(suite.T(), expected, actual)
+	assert.Nil(suite.T(), err)
+	suite.mockScheduleRepo.AssertExpectations(suite.T())
+}
+
+func (suite *callServiceSuite) TestScheduleInfoNotFound() {
+	// given
+	suite.mockScheduleRepo.On("FindById", scheduleIdStr).Return(repository.NilSchedule, errors.ErrScheduleNotFound)
+
+	// when
+	actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+		Method:     "schedule_info",
+		Parameters: map[string]interface{}{"schedule_id": scheduleIdStr},
+	})
+
+	// then
+	assert.Equal(suite.T(), errors.ErrScheduleNotFound, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *callServiceSuite) TestScheduleInfoInvalidParameters() {
+	var tests = []struct {
+		name       string
+		parameters map[string]interface{}
+	}{
+		{name: "nil parameters"},
+		{name: "missing schedule_id", parameters: map[string]interface{}{}},
+		{name: "empty schedule_id", parameters: map[string]interface{}{"schedule_id": ""}},
+		{name: "non-string schedule_id", parameters: map[string]interface{}{"schedule_id": 1500}},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+				Method:     "schedule_info",
+				Parameters: tt.parameters,
+			})
+
+			// then
+			assert.Equal(t, errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField, "schedule_id"), err)
+			assert.Nil(t, actual)
+		})
+	}
+
+	suite.mockScheduleRepo.AssertNotCalled(suite.T(), "FindById")
+}
+
+func (suite *callServiceSuite) TestTransaction() {
+	// given
+	payer, _ := types.AccountFromString(accountIdStr)
+	transactions := []*types.Transaction{
+		{
+			Hash:     "0x0102",
+			Metadata: map[string]interface{}{"consensus_timestamp": int64(20), "scheduled": false},
+			Operations: []*types.Operation{
+				{Account: payer, Amount: &types.HbarAmount{Value: -10}, Status: "SUCCESS", Type: "CRYPTOTRANSFER"},
+			},
+		},
+		{
+			Hash:     "0x0102",
+			Metadata: map[string]interface{}{"consensus_timestamp": int64(21), "scheduled": false},
+			Operations: []*types.Operation{
+				{
+					Account: payer,
+					Amount:  &types.HbarAmount{Value: -5},
+					Status:  "DUPLICATE_TRANSACTION",
+					Type:    "CRYPTOTRANSFER",
+				},
+			},
+		},
+	}
+	expected := &rTypes.CallResponse{
+		Result: map[string]interface{}{
+			"transactions": []*rTypes.Transaction{
+				transactions[0].ToRosetta(suite.settings),
+				transactions[1].ToRosetta(suite.settings),
+			},
+		},
+		Idempotent: false,
+	}
+	transactionId := types.TransactionId{Payer: payer, ValidStartNs: 1623101500000000010}
+	suite.mockTransactionRepo.On("FindByTransactionId", transactionId).Return(transactions, repository.NilError)
+
+	// when
+	actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+		Method:     "transaction",
+		Parameters: map[string]interface{}{"transaction_id": "0.0.1001@1623101500.000000010"},
+	})
+
+	// then
+	assert.Equal(suite.T(), expected, actual)
+	assert.Nil(suite.T(), err)
+	suite.mockTransactionRepo.AssertExpectations(suite.T())
+}
+
+func (suite *callServiceSuite) TestTransactionNotFound() {
+	// given
+	suite.mockTransactionRepo.On("FindByTransactionId", mock.Anything).
+		Return([]*types.Transaction(nil), errors.ErrTransactionNotFound)
+
+	// when
+	actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+		Method:     "transaction",
+		Parameters: map[string]interface{}{"transaction_id": "0.0.1001@1623101500.000000010?scheduled"},
+	})
+
+	// then
+	assert.Equal(suite.T(), errors.ErrTransactionNotFound, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *callServiceSuite) TestTransactionInvalidParameters() {
+	var tests = []struct {
+		name       string
+		parameters map[string]interface{}
+	}{
+		{name: "nil parameters"},
+		{name: "missing transaction_id", parameters: map[string]interface{}{}},
+		{name: "empty transaction_id", parameters: map[string]interface{}{"transaction_id": ""}},
+		{name: "non-string transaction_id", parameters: map[string]interface{}{"transaction_id": 1001}},
+		{name: "invalid transaction_id", parameters: map[string]interface{}{"transaction_id": "0.0.1001@abc"}},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+				Method:     "transaction",
+				Parameters: tt.parameters,
+			})
+
+			// then
+			assert.Equal(t, errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField, "transaction_id"), err)
+			assert.Nil(t, actual)
+		})
+	}
+
+	suite.mockTransactionRepo.AssertNotCalled(suite.T(), "FindByTransactionId")
+}
+
+func (suite *callServiceSuite) TestNodeHealth() {
+	// given
+	node3 := hedera.AccountID{Account: 3}
+	node4 := hedera.AccountID{Account: 4}
+	suite.nodeHealth.Record(node4, false, time.Second)
+	suite.nodeHealth.Record(node3, true, 0)
+	expected := make([]map[string]interface{}, 0, 2)
+	for _, score := range suite.nodeHealth.Scores() {
+		expected = append(expected, score.ToMetadata())
+	}
+
+	// when
+	actual, err := suite.callService.Call(nil, &rTypes.CallRequest{Method: "nodehealth"})
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), &rTypes.CallResponse{Result: map[string]interface{}{"nodes": expected}}, actual)
+	assert.Equal(suite.T(), "0.0.3", expected[0]["node_account_id"])
+	assert.Equal(suite.T(), 1.0, expected[0]["score"])
+	assert.Equal(suite.T(), "0.0.4", expected[1]["node_account_id"])
+	assert.Equal(suite.T(), 0.0, expected[1]["score"])
+}
+
+func (suite *callServiceSuite) TestNodeHealthNotTracked() {
+	// given
+	suite.nodeHealth = nil
+	callService := suite.newCallAPIService(suite.mockExchangeRateRepo)
+
+	// when
+	actual, err := callService.Call(nil, &rTypes.CallRequest{Method: "nodehealth"})
+
+	// then
+	assert.Equal(suite.T(), errors.ErrNotImplemented, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *callServiceSuite) TestSubmissions() {
+	// given
+	entry := func(hash, outcome, payer string, timestamp int64) journal.Entry {
+		return journal.Entry{
+			Hash:              hash,
+			NodeAccountId:     "0.0.3",
+			Outcome:           outcome,
+			PayerAccountId:    payer,
+			SignedTransaction: signedTxStr,
+			Timestamp:         timestamp,
+			TransactionId:     payer + "-1623101500-000000123",
+		}
+	}
+	entry1 := entry("0x01", journal.OutcomeSubmitted, accountIdStr, 100)
+	entry2 := entry("0x02", journal.OutcomePending, "0.0.1002", 200)
+	entry3 := entry("0x03", journal.OutcomePending, accountIdStr, 300)
+	for _, e := range []journal.Entry{entry1, entry2, entry3} {
+		assert.NoError(suite.T(), suite.submissionJournal.Record(e))
+	}
+
+	var tests = []struct {
+		name       string
+		parameters map[string]interface{}
+		expected   []journal.Entry
+	}{
+		{name: "All", expected: []journal.Entry{entry3, entry2, entry1}},
+		{name: "Limit", parameters: map[string]interface{}{"limit": float64(1)}, expected: []journal.Entry{entry3}},
+		{name: "Hash", parameters: map[string]interface{}{"hash": "0x02"}, expected: []journal.Entry{entry2}},
+		{
+			name:       "OutcomeAndPayer",
+			parameters: map[string]interface{}{"outcome": journal.OutcomePending, "payer_account_id": accountIdStr},
+			expected:   []journal.Entry{entry3},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			expected := make([]map[string]interface{}, 0, len(tt.expected))
+			for _, e := range tt.expected {
+				expected = append(expected, e.ToMetadata(false))
+			}
+
+			// when
+			actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+				Method:     "submissions",
+				Parameters: tt.parameters,
+			})
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, &rTypes.CallResponse{Result: map[string]interface{}{"submissions": expected}}, actual)
+		})
+	}
+}
+
+func (suite *callServiceSuite) TestSubmissionsInvalidParameters() {
+	var tests = []struct {
+		name       string
+		parameters map[string]interface{}
+		field      string
+	}{
+		{name: "empty hash", parameters: map[string]interface{}{"hash": ""}, field: "hash"},
+		{name: "non-string outcome", parameters: map[string]interface{}{"outcome": 1}, field: "outcome"},
+		{name: "non-string payer", parameters: map[string]interface{}{"payer_account_id": 1001}, field: "payer_account_id"},
+		{name: "limit too large", parameters: map[string]interface{}{"limit": float64(maxSubmissions + 1)}, field: "limit"},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+				Method:     "submissions",
+				Parameters: tt.parameters,
+			})
+
+			// then
+			assert.Equal(t, errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField, tt.field), err)
+			assert.Nil(t, actual)
+		})
+	}
+}
+
+func (suite *callServiceSuite) TestSubmissionsJournalDisabled() {
+	// given
+	suite.submissionJournal = nil
+	callService := suite.newCallAPIService(suite.mockExchangeRateRepo)
+
+	// when
+	actual, err := callService.Call(nil, &rTypes.CallRequest{Method: "submissions"})
+
+	// then
+	assert.Equal(suite.T(), errors.ErrNotImplemented, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *callServiceSuite) TestTokenBalances() {
+	// given
+	block := &types.Block{Index: 5, Hash: "0a0b", ConsensusEndNanos: 100}
+	tokenAmount := func(num int64) *types.TokenAmount {
+		return &types.TokenAmount{Decimals: 6, TokenId: entityid.EntityId{EntityNum: num, EncodedId: num}, Value: 10}
+	}
+	expectedBlockIdentifier := &rTypes.BlockIdentifier{Index: 5, Hash: "0x0a0b"}
+
+	var tests = []struct {
+		name                 string
+		parameters           map[string]interface{}
+		balances             []types.Amount
+		expectedAfterTokenId int64
+		expectedLimit        int
+		expected             *rTypes.CallResponse
+	}{
+		{
+			name:          "Truncated",
+			parameters:    map[string]interface{}{"account": accountIdStr},
+			balances:      []types.Amount{&types.HbarAmount{}, tokenAmount(2001), tokenAmount(2002), tokenAmount(2003)},
+			expectedLimit: maxTokenBalances + 1,
+			expected: &rTypes.CallResponse{
+				Result: map[string]interface{}{
+					"block_identifier": expectedBlockIdentifier,
+					"last_token_id":    "0.0.2002",
+					"token_balances": []*rTypes.Amount{
+						tokenAmount(2001).ToRosetta(suite.settings),
+						tokenAmount(2002).ToRosetta(suite.settings),
+					},
+					"truncated": true,
+				},
+			},
+		},
+		{
+			name: "AfterTokenIdWithLimitAndBlockIndex",
+			parameters: map[string]interface{}{
+				"account":        accountIdStr,
+				"after_token_id": "0.0.2002",
+				"block_index":    float64(5),
+				"limit":          float64(1),
+			},
+			balances:             []types.Amount{&types.HbarAmount{}, tokenAmount(2003)},
+			expectedAfterTokenId: 2002,
+			expectedLimit:        2,
+			expected: &rTypes.CallResponse{
+				Result: map[string]interface{}{
+					"block_identifier": expectedBlockIdentifier,
+					"last_token_id":    "0.0.2003",
+					"token_balances":   []*rTypes.Amount{tokenAmount(2003).ToRosetta(suite.settings)},
+					"truncated":        false,
+				},
+				Idempotent: true,
+			},
+		},
+		{
+			name:          "NoTokenBalance",
+			parameters:    map[string]interface{}{"account": accountIdStr},
+			balances:      []types.Amount{&types.HbarAmount{}},
+			expectedLimit: maxTokenBalances + 1,
+			expected: &rTypes.CallResponse{
+				Result: map[string]interface{}{
+					"block_identifier": expectedBlockIdentifier,
+					"token_balances":   []*rTypes.Amount{},
+					"truncated":        false,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			suite.SetupTest()
+			suite.mockBlockRepo.On("FindByIndex").Return(block, repository.NilError)
+			suite.mockBlockRepo.On("RetrieveLatest").Return(block, repository.NilError)
+			suite.mockAccountRepo.On(
+				"RetrieveBalanceAtBlock",
+				accountIdStr,
+				block.ConsensusEndNanos,
+				[]int64(nil),
+				tt.expectedAfterTokenId,
+				tt.expectedLimit,
+			).Return(tt.balances, repository.NilError)
+
+			// when
+			actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+				Method:     "token_balances",
+				Parameters: tt.parameters,
+			})
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, actual)
+			suite.mockAccountRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func (suite *callServiceSuite) TestTokenBalancesInvalidParameters() {
+	var tests = []struct {
+		name       string
+		parameters map[string]interface{}
+		field      string
+	}{
+		{name: "nil parameters", field: "account"},
+		{name: "non-string account", parameters: map[string]interface{}{"account": 1001}, field: "account"},
+		{
+			name:       "invalid after_token_id",
+			parameters: map[string]interface{}{"account": accountIdStr, "after_token_id": "a"},
+			field:      "after_token_id",
+		},
+		{
+			name:       "zero limit",
+			parameters: map[string]interface{}{"account": accountIdStr, "limit": float64(0)},
+			field:      "limit",
+		},
+		{
+			name:       "limit too large",
+			parameters: map[string]interface{}{"account": accountIdStr, "limit": float64(maxTokenBalances + 1)},
+			field:      "limit",
+		},
+		{
+			name:       "negative block_index",
+			parameters: map[string]interface{}{"account": accountIdStr, "block_index": float64(-1)},
+			field:      "block_index",
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+				Method:     "token_balances",
+				Parameters: tt.parameters,
+			})
+
+			// then
+			assert.Equal(t, errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField, tt.field), err)
+			assert.Nil(t, actual)
+		})
+	}
+
+	suite.mockAccountRepo.AssertNotCalled(suite.T(), "RetrieveBalanceAtBlock")
+}
+
+func (suite *callServiceSuite) TestTokenInfo() {
+	// given
+	block := &types.Block{Index: 5, Hash: "0a0b", ConsensusEndNanos: 100}
+	tokenInfo := &types.TokenInfo{
+		Token: types.Token{
+			TokenId:  entityid.EntityId{EntityNum: 2001, EncodedId: 2001},
+			Decimals: 6,
+			Name:     "teebar",
+			Symbol:   "foobar",
+		},
+		MaxSupply:         100000,
+		SupplyType:        "FINITE",
+		TotalSupply:       500,
+		TreasuryAccountId: types.Account{EntityId: entityid.EntityId{EntityNum: 1001, EncodedId: 1001}},
+		Type:              "FUNGIBLE_COMMON",
+	}
+	expectedResult := tokenInfo.ToMetadata()
+	expectedResult["block_identifier"] = &rTypes.BlockIdentifier{Index: 5, Hash: "0x0a0b"}
+
+	var tests = []struct {
+		name       string
+		parameters map[string]interface{}
+	}{
+		{name: "Latest", parameters: map[string]interface{}{"token_id": tokenIdStr}},
+		{name: "BlockIndex", parameters: map[string]interface{}{"token_id": tokenIdStr, "block_index": float64(5)}},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			suite.SetupTest()
+			suite.mockBlockRepo.On("FindByIndex").Return(block, repository.NilError)
+			suite.mockBlockRepo.On("RetrieveLatest").Return(block, repository.NilError)
+			suite.mockTokenRepo.On("FindInfoAt", tokenIdStr, block.ConsensusEndNanos).
+				Return(tokenInfo, repository.NilError)
+
+			// when
+			actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+				Method:     "tokeninfo",
+				Parameters: tt.parameters,
+			})
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, &rTypes.CallResponse{Result: expectedResult}, actual)
+			suite.mockTokenRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func (suite *callServiceSuite) TestTokenInfoNotFound() {
+	// given
+	block := &types.Block{Index: 5, Hash: "0a0b", ConsensusEndNanos: 100}
+	suite.mockBlockRepo.On("RetrieveLatest").Return(block, repository.NilError)
+	suite.mockTokenRepo.On("FindInfoAt", tokenIdStr, block.ConsensusEndNanos).
+		Return((*types.TokenInfo)(nil), errors.ErrTokenNotFound)
+
+	// when
+	actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+		Method:     "tokeninfo",
+		Parameters: map[string]interface{}{"token_id": tokenIdStr},
+	})
+
+	// then
+	assert.Equal(suite.T(), errors.ErrTokenNotFound, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *callServiceSuite) TestTokenInfoInvalidParameters() {
+	var tests = []struct {
+		name       string
+		parameters map[string]interface{}
+		field      string
+	}{
+		{name: "nil parameters", field: "token_id"},
+		{name: "invalid token_id", parameters: map[string]interface{}{"token_id": "abc"}, field: "token_id"},
+		{name: "non-string token_id", parameters: map[string]interface{}{"token_id": 2001}, field: "token_id"},
+		{
+			name:       "negative block_index",
+			parameters: map[string]interface{}{"token_id": tokenIdStr, "block_index": float64(-1)},
+			field:      "block_index",
+		},
+		{
+			name:       "fractional block_index",
+			parameters: map[string]interface{}{"token_id": tokenIdStr, "block_index": 1.5},
+			field:      "block_index",
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := suite.callService.Call(nil, &rTypes.CallRequest{Method: "tokeninfo", Parameters: tt.parameters})
+
+			// then
+			assert.Equal(t, errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField, tt.field), err)
+			assert.Nil(t, actual)
+		})
+	}
+
+	suite.mockTokenRepo.AssertNotCalled(suite.T(), "FindInfoAt")
+}
+
+func (suite *callServiceSuite) TestTokenRelationships() {
+	// given
+	tokenAssociation := func(num int64) *types.TokenAssociation {
+		return &types.TokenAssociation{
+			AccountId:         entityid.EntityId{EntityNum: 1001, EncodedId: 1001},
+			Associated:        true,
+			CreatedTimestamp:  100,
+			FreezeStatus:      types.TokenFreezeStatusUnfrozen,
+			KycStatus:         types.TokenKycStatusNotApplicable,
+			ModifiedTimestamp: 100,
+			TokenId:           entityid.EntityId{EntityNum: num, EncodedId: num},
+		}
+	}
+
+	var tests = []struct {
+		name                 string
+		parameters           map[string]interface{}
+		tokenAssociations    []*types.TokenAssociation
+		expectedAfterTokenId int64
+		expectedLimit        int
+		expected             map[string]interface{}
+	}{
+		{
+			name:              "Truncated",
+			parameters:        map[string]interface{}{"account": accountIdStr, "limit": float64(2)},
+			tokenAssociations: []*types.TokenAssociation{tokenAssociation(2001), tokenAssociation(2002), tokenAssociation(2003)},
+			expectedLimit:     3,
+			expected: map[string]interface{}{
+				"account":       accountIdStr,
+				"last_token_id": "0.0.2002",
+				"token_relationships": []map[string]interface{}{
+					tokenAssociation(2001).ToMetadata(false),
+					tokenAssociation(2002).ToMetadata(false),
+				},
+				"truncated": true,
+			},
+		},
+		{
+			name:                 "AfterTokenId",
+			parameters:           map[string]interface{}{"account": accountIdStr, "after_token_id": "0.0.2002"},
+			tokenAssociations:    []*types.TokenAssociation{tokenAssociation(2003)},
+			expectedAfterTokenId: 2002,
+			expectedLimit:        maxTokenRelationships + 1,
+			expected: map[string]interface{}{
+				"account":             accountIdStr,
+				"last_token_id":       "0.0.2003",
+				"token_relationships": []map[string]interface{}{tokenAssociation(2003).ToMetadata(false)},
+				"truncated":           false,
+			},
+		},
+		{
+			name:          "NoTokenRelationship",
+			parameters:    map[string]interface{}{"account": accountIdStr},
+			expectedLimit: maxTokenRelationships + 1,
+			expected: map[string]interface{}{
+				"account":             accountIdStr,
+				"token_relationships": []map[string]interface{}{},
+				"truncated":           false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			suite.SetupTest()
+			suite.mockTokenAssociationRepo.
+				On("FindByAccount", accountIdStr, tt.expectedAfterTokenId, tt.expectedLimit).
+				Return(tt.tokenAssociations, repository.NilError)
+
+			// when
+			actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+				Method:     "tokenrelationships",
+				Parameters: tt.parameters,
+			})
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, &rTypes.CallResponse{Result: tt.expected}, actual)
+			suite.mockTokenAssociationRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func (suite *callServiceSuite) TestTokenRelationshipsThrows() {
+	// given
+	suite.mockTokenAssociationRepo.On("FindByAccount", accountIdStr, int64(0), maxTokenRelationships+1).
+		Return([]*types.TokenAssociation{}, errors.ErrDatabaseError)
+
+	// when
+	actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+		Method:     "tokenrelationships",
+		Parameters: map[string]interface{}{"account": accountIdStr},
+	})
+
+	// then
+	assert.Equal(suite.T(), errors.ErrDatabaseError, err)
+	assert.Nil(suite.T(), actual)
+}
+
+func (suite *callServiceSuite) TestTokenRelationshipsInvalidParameters() {
+	var tests = []struct {
+		name       string
+		parameters map[string]interface{}
+		field      string
+	}{
+		{name: "nil parameters", field: "account"},
+		{name: "empty account", parameters: map[string]interface{}{"account": ""}, field: "account"},
+		{
+			name:       "non-string after_token_id",
+			parameters: map[string]interface{}{"account": accountIdStr, "after_token_id": 2001},
+			field:      "after_token_id",
+		},
+		{
+			name:       "fractional limit",
+			parameters: map[string]interface{}{"account": accountIdStr, "limit": 1.5},
+			field:      "limit",
+		},
+		{
+			name:       "limit too large",
+			parameters: map[string]interface{}{"account": accountIdStr, "limit": float64(maxTokenRelationships + 1)},
+			field:      "limit",
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+				Method:     "tokenrelationships",
+				Parameters: tt.parameters,
+			})
+
+			// then
+			assert.Equal(t, errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField, tt.field), err)
+			assert.Nil(t, actual)
+		})
+	}
+
+	suite.mockTokenAssociationRepo.AssertNotCalled(suite.T(), "FindByAccount")
+}
+
+func (suite *callServiceSuite) customCallMethod(parameters map[string]interface{}) (
+	map[string]interface{},
+	bool,
+	*rTypes.Error,
+) {
+	return map[string]interface{}{"echo": parameters["value"]}, true, nil
+}
+
+func (suite *callServiceSuite) TestCustomCallMethod() {
+	// when
+	actual, err := suite.callService.Call(nil, &rTypes.CallRequest{
+		Method:     "custom",
+		Parameters: map[string]interface{}{"value": "abc"},
+	})
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), &rTypes.CallResponse{Result: map[string]interface{}{"echo": "abc"}, Idempotent: true}, actual)
+}
+
+func (suite *callServiceSuite) TestCallMethodUnsupported() {
+	// when
+	actual, err := suite.callService.Call(nil, &rTypes.CallRequest{Method: "unknown"})
+
+	// then
+	assert.Equal(suite.T(), errors.ErrCallMethodUnsupported, err)
+	assert.Nil(suite.T(), actual)
+}
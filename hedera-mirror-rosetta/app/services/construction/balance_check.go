@@ -0,0 +1,262 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package construction
+
+import (
+	"math"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/parse"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/safemath"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+)
+
+const (
+	optionCheckBalance = "check_balance"
+	optionEstimatedFee = "estimated_fee"
+)
+
+// defaultEstimatedFee is the default max transaction fee set by the SDK, so it's the most the payer can be charged
+var defaultEstimatedFee = hedera.NewHbar(1).AsTinybar()
+
+// balanceRequirement is the amount of hbar and the amount of each token an account needs to have
+type balanceRequirement struct {
+	address      string
+	hbar         int64
+	tokenIds     []int64
+	tokenSymbols map[int64]string
+	tokens       map[int64]int64
+}
+
+// addHbar adds the debited amount to the hbar requirement, ErrAmountOverflow is returned if the total overflows int64
+func (b *balanceRequirement) addHbar(amount int64) *rTypes.Error {
+	var ok bool
+	if b.hbar, ok = safemath.AddInt64(b.hbar, amount); !ok {
+		return errors.ErrAmountOverflow
+	}
+	return nil
+}
+
+// addToken adds the debited amount to the token requirement, ErrAmountOverflow is returned if the total overflows
+// int64
+func (b *balanceRequirement) addToken(tokenId entityid.EntityId, amount int64) *rTypes.Error {
+	if _, ok := b.tokens[tokenId.EncodedId]; !ok {
+		b.tokenIds = append(b.tokenIds, tokenId.EncodedId)
+		b.tokenSymbols[tokenId.EncodedId] = tokenId.String()
+	}
+
+	total, ok := safemath.AddInt64(b.tokens[tokenId.EncodedId], amount)
+	if !ok {
+		return errors.ErrAmountOverflow
+	}
+	b.tokens[tokenId.EncodedId] = total
+	return nil
+}
+
+// checkBalances checks the current balances of the accounts debited by the operations when the preprocess request
+// opts in with the check_balance option. The payer must also afford the fee, which is the estimated_fee option in
+// tinybars or defaultEstimatedFee. ErrInsufficientBalance is returned if any balance falls short
+func (c *constructionAPIService) checkBalances(
+	payer hedera.AccountID,
+	operations []*rTypes.Operation,
+	options map[string]interface{},
+) *rTypes.Error {
+	if value, ok := options[optionCheckBalance]; !ok {
+		return nil
+	} else if checkBalance, ok := value.(bool); !ok {
+		return errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField, optionCheckBalance)
+	} else if !checkBalance {
+		return nil
+	}
+
+	if c.accountRepo == nil {
+		return errors.AddErrorDetails(errors.ErrNotImplemented, errors.DetailReason, "Balance check requires online mode")
+	}
+
+	estimatedFee, rErr := getEstimatedFee(options)
+	if rErr != nil {
+		return rErr
+	}
+
+	requirements, rErr := getBalanceRequirements(payer, estimatedFee, operations, c.settings)
+	if rErr != nil {
+		return rErr
+	}
+
+	for _, requirement := range requirements {
+		// the balances are the current ones since the timestamp is after any existing block
+		balances, rErr := c.accountRepo.RetrieveBalanceAtBlock(
+			requirement.address,
+			math.MaxInt64,
+			requirement.tokenIds,
+			0,
+			0,
+		)
+		if rErr != nil {
+			return rErr
+		}
+
+		tokenBalances := make(map[int64]int64)
+		for _, balance := range balances {
+			switch amount := balance.(type) {
+			case *types.HbarAmount:
+				if amount.Value < requirement.hbar {
+					return insufficientBalance(requirement.address, c.settings.CurrencyHbar.Symbol)
+				}
+			case *types.TokenAmount:
+				tokenBalances[amount.TokenId.EncodedId] = amount.Value
+			}
+		}
+
+		// a token missing from the balances isn't associated with the account, thus the balance is 0
+		for _, tokenId := range requirement.tokenIds {
+			if tokenBalances[tokenId] < requirement.tokens[tokenId] {
+				return insufficientBalance(requirement.address, requirement.tokenSymbols[tokenId])
+			}
+		}
+	}
+
+	return nil
+}
+
+// getBalanceRequirements aggregates the debited amounts of each account in the order they first appear, with the
+// estimated fee charged to the payer. The accounts are keyed by their addresses without the checksum validated against
+// the ledger id of the settings
+func getBalanceRequirements(
+	payer hedera.AccountID,
+	estimatedFee int64,
+	operations []*rTypes.Operation,
+	settings *config.Settings,
+) ([]*balanceRequirement, *rTypes.Error) {
+	requirements := make([]*balanceRequirement, 0)
+	requirementMap := make(map[string]*balanceRequirement)
+	getRequirement := func(address string) *balanceRequirement {
+		requirement, ok := requirementMap[address]
+		if !ok {
+			requirement = &balanceRequirement{
+				address:      address,
+				tokenSymbols: make(map[int64]string),
+				tokens:       make(map[int64]int64),
+			}
+			requirementMap[address] = requirement
+			requirements = append(requirements, requirement)
+		}
+		return requirement
+	}
+
+	getRequirement(payer.String()).hbar = estimatedFee
+
+	for _, operation := range operations {
+		if operation.Account == nil || operation.Amount == nil || operation.Amount.Currency == nil {
+			continue
+		}
+
+		amount, err := parse.ToInt64(operation.Amount.Value)
+		if err != nil {
+			return nil, errors.ErrInvalidAmount
+		}
+
+		if amount >= 0 {
+			continue
+		}
+
+		if amount == math.MinInt64 {
+			// the debited amount -amount doesn't fit in int64
+			return nil, errors.ErrAmountOverflow
+		}
+
+		accountId, err := parseAccountId(operation.Account.Address, settings.LedgerId)
+		if err != nil {
+			return nil, invalidEntityIdError(err, errors.ErrInvalidAccount)
+		}
+
+		requirement := getRequirement(accountId.String())
+		currency := operation.Amount.Currency
+		if currency.Symbol == settings.CurrencyHbar.Symbol {
+			if rErr := requirement.addHbar(-amount); rErr != nil {
+				return nil, rErr
+			}
+			continue
+		}
+
+		tokenId, err := entityid.FromString(currency.Symbol)
+		if err != nil {
+			return nil, errors.ErrInvalidToken
+		}
+		if rErr := requirement.addToken(tokenId, -amount); rErr != nil {
+			return nil, rErr
+		}
+	}
+
+	return requirements, nil
+}
+
+// getEstimatedFee returns the estimated_fee option, accepted as a number or a string, or defaultEstimatedFee
+func getEstimatedFee(options map[string]interface{}) (int64, *rTypes.Error) {
+	fee, ok, rErr := getFeeOption(options, optionEstimatedFee)
+	if rErr != nil {
+		return 0, rErr
+	}
+
+	if !ok {
+		return defaultEstimatedFee, nil
+	}
+
+	return fee, nil
+}
+
+// getFeeOption returns the non-negative fee option in tinybars, accepted as a number or a string. ok is false if the
+// option isn't present
+func getFeeOption(options map[string]interface{}, option string) (int64, bool, *rTypes.Error) {
+	value, ok := options[option]
+	if !ok {
+		return 0, false, nil
+	}
+
+	var fee int64
+	switch optionFee := value.(type) {
+	case float64:
+		fee = int64(optionFee)
+	case string:
+		var err error
+		if fee, err = parse.ToInt64(optionFee); err != nil {
+			fee = -1
+		}
+	default:
+		fee = -1
+	}
+
+	if fee < 0 {
+		return 0, true, errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField, option)
+	}
+
+	return fee, true, nil
+}
+
+// insufficientBalance returns ErrInsufficientBalance with the account and the currency in its details
+func insufficientBalance(address, symbol string) *rTypes.Error {
+	rErr := errors.AddErrorDetails(errors.ErrInsufficientBalance, errors.DetailAccount, address)
+	return errors.AddErrorDetails(rErr, errors.DetailCurrency, symbol)
+}
@@ -0,0 +1,323 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package construction
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	domainTypes "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/mocks/repository"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const balanceCheckTokenId = "0.0.2001"
+
+func balanceCheckOperations() []*types.Operation {
+	tokenCurrency := &types.Currency{Symbol: balanceCheckTokenId, Decimals: 2}
+	return []*types.Operation{
+		dummyOperation(0, "CRYPTOTRANSFER", defaultCryptoAccountId1, defaultSendAmount),
+		dummyOperation(1, "CRYPTOTRANSFER", defaultCryptoAccountId2, defaultReceiveAmount),
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 2},
+			Type:                "CRYPTOTRANSFER",
+			Account:             &types.AccountIdentifier{Address: defaultCryptoAccountId1},
+			Amount:              &types.Amount{Value: "-30", Currency: tokenCurrency},
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 3},
+			Type:                "CRYPTOTRANSFER",
+			Account:             &types.AccountIdentifier{Address: defaultCryptoAccountId2},
+			Amount:              &types.Amount{Value: "30", Currency: tokenCurrency},
+		},
+	}
+}
+
+func balanceCheckPreprocessRequest(metadata map[string]interface{}) *types.ConstructionPreprocessRequest {
+	return &types.ConstructionPreprocessRequest{
+		NetworkIdentifier: networkIdentifier(),
+		Operations:        balanceCheckOperations(),
+		Metadata:          metadata,
+	}
+}
+
+func newBalanceCheckService(accountRepo repositories.AccountRepository) *constructionAPIService {
+	mockConstructor := &mockTransactionConstructor{}
+	mockConstructor.
+		On("Preprocess", mock.IsType([]*types.Operation{})).
+		Return([]hedera.AccountID{defaultAccountId1}, nilErr)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		AccountRepo:            accountRepo,
+		Network:                defaultNetwork,
+		Nodes:                  defaultNodes,
+		Broadcast:              defaultBroadcast,
+		Settings:               config.NewSettings(),
+		TransactionConstructor: mockConstructor,
+	})
+	return service.(*constructionAPIService)
+}
+
+func balanceCheckTokenAmount(value int64) *domainTypes.TokenAmount {
+	tokenId, _ := entityid.FromString(balanceCheckTokenId)
+	return &domainTypes.TokenAmount{Decimals: 2, TokenId: tokenId, Value: value}
+}
+
+func TestConstructionPreprocessCheckBalance(t *testing.T) {
+	tokenId, _ := entityid.FromString(balanceCheckTokenId)
+	fee := defaultEstimatedFee
+
+	var tests = []struct {
+		name     string
+		metadata map[string]interface{}
+		balances []domainTypes.Amount
+		expected *types.Error
+	}{
+		{
+			name:     "Sufficient",
+			metadata: map[string]interface{}{"check_balance": true},
+			balances: []domainTypes.Amount{&domainTypes.HbarAmount{Value: fee + 1000}, balanceCheckTokenAmount(30)},
+		},
+		{
+			name:     "SufficientWithEstimatedFee",
+			metadata: map[string]interface{}{"check_balance": true, "estimated_fee": "100"},
+			balances: []domainTypes.Amount{&domainTypes.HbarAmount{Value: 1100}, balanceCheckTokenAmount(30)},
+		},
+		{
+			name:     "InsufficientHbarForFee",
+			metadata: map[string]interface{}{"check_balance": true},
+			balances: []domainTypes.Amount{&domainTypes.HbarAmount{Value: fee + 999}, balanceCheckTokenAmount(30)},
+			expected: insufficientBalance(defaultCryptoAccountId1, "HBAR"),
+		},
+		{
+			name:     "InsufficientToken",
+			metadata: map[string]interface{}{"check_balance": true, "estimated_fee": float64(0)},
+			balances: []domainTypes.Amount{&domainTypes.HbarAmount{Value: 1000}, balanceCheckTokenAmount(29)},
+			expected: insufficientBalance(defaultCryptoAccountId1, balanceCheckTokenId),
+		},
+		{
+			name:     "TokenNotAssociated",
+			metadata: map[string]interface{}{"check_balance": true, "estimated_fee": float64(0)},
+			balances: []domainTypes.Amount{&domainTypes.HbarAmount{Value: 1000}},
+			expected: insufficientBalance(defaultCryptoAccountId1, balanceCheckTokenId),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			mockAccountRepo := &repository.MockAccountRepository{}
+			mockAccountRepo.
+				On("RetrieveBalanceAtBlock", defaultCryptoAccountId1, int64(math.MaxInt64), []int64{tokenId.EncodedId},
+					int64(0), 0).
+				Return(tt.balances, repository.NilError)
+			service := newBalanceCheckService(mockAccountRepo)
+
+			// when
+			actual, err := service.ConstructionPreprocess(nil, balanceCheckPreprocessRequest(tt.metadata))
+
+			// then
+			assert.Equal(t, tt.expected, err)
+			if tt.expected == nil {
+				assert.NotNil(t, actual)
+			} else {
+				assert.Nil(t, actual)
+			}
+			mockAccountRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestConstructionPreprocessSkipsBalanceCheck(t *testing.T) {
+	var tests = []struct {
+		name     string
+		metadata map[string]interface{}
+	}{
+		{name: "NoMetadata"},
+		{name: "CheckBalanceFalse", metadata: map[string]interface{}{"check_balance": false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			mockAccountRepo := &repository.MockAccountRepository{}
+			service := newBalanceCheckService(mockAccountRepo)
+
+			// when
+			actual, err := service.ConstructionPreprocess(nil, balanceCheckPreprocessRequest(tt.metadata))
+
+			// then
+			assert.Nil(t, err)
+			assert.NotNil(t, actual)
+			mockAccountRepo.AssertNotCalled(t, "RetrieveBalanceAtBlock")
+		})
+	}
+}
+
+func TestConstructionPreprocessCheckBalanceThrows(t *testing.T) {
+	var tests = []struct {
+		name     string
+		metadata map[string]interface{}
+		offline  bool
+		expected *types.Error
+	}{
+		{
+			name:     "InvalidCheckBalance",
+			metadata: map[string]interface{}{"check_balance": "yes"},
+			expected: errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField, "check_balance"),
+		},
+		{
+			name:     "InvalidEstimatedFee",
+			metadata: map[string]interface{}{"check_balance": true, "estimated_fee": "abc"},
+			expected: errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField, "estimated_fee"),
+		},
+		{
+			name:     "NegativeEstimatedFee",
+			metadata: map[string]interface{}{"check_balance": true, "estimated_fee": float64(-1)},
+			expected: errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField, "estimated_fee"),
+		},
+		{
+			name:     "OfflineMode",
+			metadata: map[string]interface{}{"check_balance": true},
+			offline:  true,
+			expected: errors.AddErrorDetails(
+				errors.ErrNotImplemented,
+				errors.DetailReason,
+				"Balance check requires online mode",
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			mockAccountRepo := &repository.MockAccountRepository{}
+			service := newBalanceCheckService(mockAccountRepo)
+			if tt.offline {
+				service = newBalanceCheckService(nil)
+			}
+
+			// when
+			actual, err := service.ConstructionPreprocess(nil, balanceCheckPreprocessRequest(tt.metadata))
+
+			// then
+			assert.Equal(t, tt.expected, err)
+			assert.Nil(t, actual)
+			mockAccountRepo.AssertNotCalled(t, "RetrieveBalanceAtBlock")
+		})
+	}
+}
+
+func TestConstructionPreprocessCheckBalanceThrowsDbError(t *testing.T) {
+	// given
+	mockAccountRepo := &repository.MockAccountRepository{}
+	mockAccountRepo.
+		On("RetrieveBalanceAtBlock", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]domainTypes.Amount{}, errors.ErrDatabaseError)
+	service := newBalanceCheckService(mockAccountRepo)
+	request := balanceCheckPreprocessRequest(map[string]interface{}{"check_balance": true})
+
+	// when
+	actual, err := service.ConstructionPreprocess(nil, request)
+
+	// then
+	assert.Equal(t, errors.ErrDatabaseError, err)
+	assert.Nil(t, actual)
+}
+
+func TestGetBalanceRequirements(t *testing.T) {
+	// given
+	tokenId, _ := entityid.FromString(balanceCheckTokenId)
+	operations := append(
+		balanceCheckOperations(),
+		dummyOperation(4, "CRYPTOTRANSFER", defaultCryptoAccountId2, defaultSendAmount),
+		dummyOperation(5, "CRYPTOTRANSFER", defaultCryptoAccountId1, defaultReceiveAmount),
+	)
+
+	// when
+	actual, err := getBalanceRequirements(defaultAccountId1, 10, operations, config.NewSettings())
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, []*balanceRequirement{
+		{
+			address:      defaultCryptoAccountId1,
+			hbar:         1010,
+			tokenIds:     []int64{tokenId.EncodedId},
+			tokenSymbols: map[int64]string{tokenId.EncodedId: balanceCheckTokenId},
+			tokens:       map[int64]int64{tokenId.EncodedId: 30},
+		},
+		{
+			address:      defaultCryptoAccountId2,
+			hbar:         1000,
+			tokenSymbols: map[int64]string{},
+			tokens:       map[int64]int64{},
+		},
+	}, actual)
+}
+
+func TestGetBalanceRequirementsOverflow(t *testing.T) {
+	tokenCurrency := &types.Currency{Symbol: balanceCheckTokenId, Decimals: 2}
+	debit := func(index int64, value string, currency *types.Currency) *types.Operation {
+		return &types.Operation{
+			OperationIdentifier: &types.OperationIdentifier{Index: index},
+			Type:                "CRYPTOTRANSFER",
+			Account:             &types.AccountIdentifier{Address: defaultCryptoAccountId2},
+			Amount:              &types.Amount{Value: value, Currency: currency},
+		}
+	}
+	maxDebit := strconv.FormatInt(-math.MaxInt64, 10)
+	var tests = []struct {
+		name       string
+		operations []*types.Operation
+	}{
+		{
+			name:       "MinInt64",
+			operations: []*types.Operation{debit(0, strconv.FormatInt(math.MinInt64, 10), config.CurrencyHbar)},
+		},
+		{
+			name:       "Hbar",
+			operations: []*types.Operation{debit(0, maxDebit, config.CurrencyHbar), debit(1, "-1", config.CurrencyHbar)},
+		},
+		{
+			name:       "Token",
+			operations: []*types.Operation{debit(0, maxDebit, tokenCurrency), debit(1, "-1", tokenCurrency)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := getBalanceRequirements(defaultAccountId1, 10, tt.operations, config.NewSettings())
+
+			// then
+			assert.Equal(t, errors.ErrAmountOverflow, err)
+			assert.Nil(t, actual)
+		})
+	}
+}
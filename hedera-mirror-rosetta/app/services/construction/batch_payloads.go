@@ -0,0 +1,136 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package construction
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/coinbase/rosetta-sdk-go/asserter"
+	"github.com/coinbase/rosetta-sdk-go/server"
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const maxBatchPayloadsSize = 100
+
+// ConstructionBatchPayloadsRequest is the request of the /construction/payloads/batch extension endpoint. Each entry
+// is an independent /construction/payloads request, an entry without network identifier inherits the batch's
+type ConstructionBatchPayloadsRequest struct {
+	NetworkIdentifier *rTypes.NetworkIdentifier             `json:"network_identifier"`
+	Requests          []*rTypes.ConstructionPayloadsRequest `json:"requests"`
+}
+
+// ConstructionBatchPayloadsResponse holds the /construction/payloads responses in the order of the requests
+type ConstructionBatchPayloadsResponse struct {
+	Responses []*rTypes.ConstructionPayloadsResponse `json:"responses"`
+}
+
+// constructionBatchAPIController implements the server.Router interface for the batch payloads extension endpoint
+type constructionBatchAPIController struct {
+	asserter *asserter.Asserter
+	service  server.ConstructionAPIServicer
+}
+
+// Routes returns the extension routes of the constructionBatchAPIController
+func (c *constructionBatchAPIController) Routes() server.Routes {
+	return server.Routes{
+		{
+			Name:        "ConstructionBatchPayloads",
+			Method:      http.MethodPost,
+			Pattern:     "/construction/payloads/batch",
+			HandlerFunc: c.ConstructionBatchPayloads,
+		},
+	}
+}
+
+// ConstructionBatchPayloads implements the /construction/payloads/batch endpoint.
+func (c *constructionBatchAPIController) ConstructionBatchPayloads(w http.ResponseWriter, r *http.Request) {
+	request := &ConstructionBatchPayloadsRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		rErr := errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailReason, err.Error())
+		server.EncodeJSONResponse(rErr, http.StatusInternalServerError, w)
+		return
+	}
+
+	if len(request.Requests) == 0 || len(request.Requests) > maxBatchPayloadsSize {
+		server.EncodeJSONResponse(errors.ErrInvalidBatchSize, http.StatusInternalServerError, w)
+		return
+	}
+
+	for index, payloadsRequest := range request.Requests {
+		if payloadsRequest == nil {
+			rErr := errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailIndex, index)
+			server.EncodeJSONResponse(rErr, http.StatusInternalServerError, w)
+			return
+		}
+
+		if payloadsRequest.NetworkIdentifier == nil {
+			payloadsRequest.NetworkIdentifier = request.NetworkIdentifier
+		}
+
+		if err := c.asserter.ConstructionPayloadsRequest(payloadsRequest); err != nil {
+			rErr := errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailIndex, index)
+			rErr = errors.AddErrorDetails(rErr, errors.DetailReason, err.Error())
+			server.EncodeJSONResponse(rErr, http.StatusInternalServerError, w)
+			return
+		}
+	}
+
+	response, rErr := constructBatchPayloads(r.Context(), c.service, request.Requests)
+	if rErr != nil {
+		server.EncodeJSONResponse(rErr, http.StatusInternalServerError, w)
+		return
+	}
+
+	server.EncodeJSONResponse(response, http.StatusOK, w)
+}
+
+// constructBatchPayloads constructs the transactions one by one and fails the batch on the first error. The error
+// details carry the index of the failed request
+func constructBatchPayloads(
+	ctx context.Context,
+	service server.ConstructionAPIServicer,
+	requests []*rTypes.ConstructionPayloadsRequest,
+) (*ConstructionBatchPayloadsResponse, *rTypes.Error) {
+	responses := make([]*rTypes.ConstructionPayloadsResponse, 0, len(requests))
+	for index, request := range requests {
+		response, rErr := service.ConstructionPayloads(ctx, request)
+		if rErr != nil {
+			log.Errorf("Failed to construct transaction %d of the batch: %s", index, rErr.Message)
+			return nil, errors.AddErrorDetails(rErr, errors.DetailIndex, index)
+		}
+
+		responses = append(responses, response)
+	}
+
+	return &ConstructionBatchPayloadsResponse{Responses: responses}, nil
+}
+
+// NewConstructionBatchAPIController creates a server.Router serving the /construction/payloads/batch endpoint
+func NewConstructionBatchAPIController(
+	service server.ConstructionAPIServicer,
+	asserter *asserter.Asserter,
+) server.Router {
+	return &constructionBatchAPIController{asserter: asserter, service: service}
+}
@@ -0,0 +1,182 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package construction
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/asserter"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func batchPayloadsOperations() []*types.Operation {
+	return []*types.Operation{
+		dummyOperation(0, "CRYPTOTRANSFER", defaultCryptoAccountId1, defaultSendAmount),
+		dummyOperation(1, "CRYPTOTRANSFER", defaultCryptoAccountId2, defaultReceiveAmount),
+	}
+}
+
+func newBatchPayloadsConstructor(rErr *types.Error) *mockTransactionConstructor {
+	transaction, _ := hedera.NewTransferTransaction().
+		SetNodeAccountIDs([]hedera.AccountID{nodeAccountId}).
+		SetTransactionID(hedera.TransactionIDGenerate(defaultAccountId1)).
+		Freeze()
+	mockConstructor := &mockTransactionConstructor{}
+	if rErr == nil {
+		mockConstructor.
+			On("Construct", mock.IsType(hedera.AccountID{}), mock.IsType([]*types.Operation{}), hedera.ZeroHbar).
+			Return(transaction, []hedera.AccountID{defaultAccountId1}, nilErr)
+	} else {
+		mockConstructor.
+			On("Construct", mock.IsType(hedera.AccountID{}), mock.IsType([]*types.Operation{}), hedera.ZeroHbar).
+			Return(nilTransaction, nilSigners, rErr)
+	}
+
+	return mockConstructor
+}
+
+func TestConstructBatchPayloads(t *testing.T) {
+	// given
+	mockConstructor := newBatchPayloadsConstructor(nil)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:                defaultNetwork,
+		Nodes:                  defaultNodes,
+		Broadcast:              defaultBroadcast,
+		Settings:               config.NewSettings(),
+		TransactionConstructor: mockConstructor,
+	})
+	requests := []*types.ConstructionPayloadsRequest{
+		dummyPayloadsRequest(batchPayloadsOperations()),
+		dummyPayloadsRequest(batchPayloadsOperations()),
+	}
+
+	// when
+	actual, err := constructBatchPayloads(nil, service, requests)
+
+	// then
+	assert.Nil(t, err)
+	assert.Len(t, actual.Responses, 2)
+	for _, response := range actual.Responses {
+		assert.NotEmpty(t, response.UnsignedTransaction)
+		assert.Len(t, response.Payloads, 1)
+	}
+	mockConstructor.AssertNumberOfCalls(t, "Construct", 2)
+}
+
+func TestConstructBatchPayloadsFail(t *testing.T) {
+	// given
+	mockConstructor := newBatchPayloadsConstructor(errors.ErrInvalidOperations)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:                defaultNetwork,
+		Nodes:                  defaultNodes,
+		Broadcast:              defaultBroadcast,
+		Settings:               config.NewSettings(),
+		TransactionConstructor: mockConstructor,
+	})
+	requests := []*types.ConstructionPayloadsRequest{dummyPayloadsRequest(batchPayloadsOperations())}
+
+	// when
+	actual, err := constructBatchPayloads(nil, service, requests)
+
+	// then
+	assert.Nil(t, actual)
+	assert.Equal(t, errors.ErrInvalidOperations.Code, err.Code)
+	assert.Equal(t, map[string]interface{}{"index": 0}, err.Details)
+	assert.Nil(t, errors.ErrInvalidOperations.Details)
+}
+
+func TestConstructionBatchPayloadsController(t *testing.T) {
+	tooManyRequests := make([]*types.ConstructionPayloadsRequest, 0, maxBatchPayloadsSize+1)
+	for i := 0; i <= maxBatchPayloadsSize; i++ {
+		tooManyRequests = append(tooManyRequests, dummyPayloadsRequest(batchPayloadsOperations()))
+	}
+
+	var tests = []struct {
+		name           string
+		requests       []*types.ConstructionPayloadsRequest
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			requests: []*types.ConstructionPayloadsRequest{
+				dummyPayloadsRequest(batchPayloadsOperations()),
+				dummyPayloadsRequest(batchPayloadsOperations()),
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "EmptyBatch",
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "BatchTooLarge",
+			requests:       tooManyRequests,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			serverAsserter, _ := asserter.NewServer(
+				[]string{"CRYPTOTRANSFER"},
+				true,
+				[]*types.NetworkIdentifier{networkIdentifier()},
+				nil,
+				false,
+			)
+			service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+				Network:                defaultNetwork,
+				Nodes:                  defaultNodes,
+				Broadcast:              defaultBroadcast,
+				Settings:               config.NewSettings(),
+				TransactionConstructor: newBatchPayloadsConstructor(nil),
+			})
+			router := NewConstructionBatchAPIController(service, serverAsserter)
+			body, _ := json.Marshal(&ConstructionBatchPayloadsRequest{
+				NetworkIdentifier: networkIdentifier(),
+				Requests:          tt.requests,
+			})
+			request := httptest.NewRequest(http.MethodPost, "/construction/payloads/batch", bytes.NewReader(body))
+			recorder := httptest.NewRecorder()
+
+			// when
+			router.Routes()[0].HandlerFunc(recorder, request)
+
+			// then
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			if tt.expectedStatus == http.StatusOK {
+				response := &ConstructionBatchPayloadsResponse{}
+				assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), response))
+				assert.Len(t, response.Responses, len(tt.requests))
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package construction
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	hexutils "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/hex"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/types"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+)
+
+const (
+	BroadcastTypeGrpc  = "grpc"
+	BroadcastTypeKafka = "kafka"
+	BroadcastTypeRelay = "relay"
+
+	contentTypeJson          = "application/json"
+	contentTypeKafkaBinaryV2 = "application/vnd.kafka.binary.v2+json"
+)
+
+// Broadcaster sends a signed transaction towards the consensus nodes
+type Broadcaster interface {
+	// Broadcast sends the signed transaction, an error means it may not have been sent
+	Broadcast(transaction ITransaction) error
+}
+
+// grpcBroadcaster sends the transaction directly to its node over gRPC
+type grpcBroadcaster struct {
+	hederaClient *hedera.Client
+}
+
+func (g *grpcBroadcaster) Broadcast(transaction ITransaction) error {
+	_, err := transaction.Execute(g.hederaClient)
+	return err
+}
+
+// httpBroadcaster posts the transaction to an HTTP endpoint which takes over sending it to the network, so Rosetta
+// doesn't need direct network access to the consensus nodes
+type httpBroadcaster struct {
+	client      *http.Client
+	contentType string
+	encode      func(hash string, transactionBytes []byte) interface{}
+	url         string
+}
+
+func (h *httpBroadcaster) Broadcast(transaction ITransaction) error {
+	hash, err := transaction.GetTransactionHash()
+	if err != nil {
+		return err
+	}
+
+	transactionBytes, err := transaction.ToBytes()
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(h.encode(hexutils.SafeAddHexPrefix(hex.EncodeToString(hash)), transactionBytes))
+	if err != nil {
+		return err
+	}
+
+	response, err := h.client.Post(h.url, h.contentType, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("broadcast to %s failed with http status %d", h.url, response.StatusCode)
+	}
+
+	return nil
+}
+
+// relayRequest is the json body posted to a relay endpoint
+type relayRequest struct {
+	Hash              string `json:"hash"`
+	SignedTransaction string `json:"signed_transaction"`
+}
+
+// kafkaRecord is a record of a Kafka REST proxy v2 produce request with the binary embedded format
+type kafkaRecord struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type kafkaProduceRequest struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+// newRelayBroadcaster creates a broadcaster posting the hash and the hex encoded signed transaction to the relay url
+func newRelayBroadcaster(client *http.Client, relayUrl string) Broadcaster {
+	return &httpBroadcaster{
+		client:      client,
+		contentType: contentTypeJson,
+		encode: func(hash string, transactionBytes []byte) interface{} {
+			return &relayRequest{
+				Hash:              hash,
+				SignedTransaction: hexutils.SafeAddHexPrefix(hex.EncodeToString(transactionBytes)),
+			}
+		},
+		url: relayUrl,
+	}
+}
+
+// newKafkaBroadcaster creates a broadcaster producing the signed transaction to the Kafka topic through the REST proxy
+// at proxyUrl. The record key is the transaction hash so retries of the same transaction go to the same partition
+func newKafkaBroadcaster(client *http.Client, proxyUrl string, topic string) Broadcaster {
+	return &httpBroadcaster{
+		client:      client,
+		contentType: contentTypeKafkaBinaryV2,
+		encode: func(hash string, transactionBytes []byte) interface{} {
+			return &kafkaProduceRequest{
+				Records: []kafkaRecord{{
+					Key:   base64.StdEncoding.EncodeToString([]byte(hash)),
+					Value: base64.StdEncoding.EncodeToString(transactionBytes),
+				}},
+			}
+		},
+		url: fmt.Sprintf("%s/topics/%s", proxyUrl, url.PathEscape(topic)),
+	}
+}
+
+// NewBroadcaster creates the Broadcaster of the configured type, the gRPC one sends through the hederaClient, or over
+// its own connection pool to the hederaClient network when the pool size is set. An empty type defaults to gRPC
+func NewBroadcaster(config types.Broadcast, hederaClient *hedera.Client) (Broadcaster, error) {
+	client := &http.Client{Timeout: time.Duration(config.Timeout) * time.Millisecond}
+
+	switch config.Type {
+	case "", BroadcastTypeGrpc:
+		if config.Grpc.PoolSize > 0 {
+			return newPooledGrpcBroadcaster(config, hederaClient.GetNetwork())
+		}
+		return &grpcBroadcaster{hederaClient: hederaClient}, nil
+	case BroadcastTypeKafka:
+		if config.Kafka.Url == "" || config.Kafka.Topic == "" {
+			return nil, fmt.Errorf("kafka broadcast requires the REST proxy url and the topic")
+		}
+		return newKafkaBroadcaster(client, config.Kafka.Url, config.Kafka.Topic), nil
+	case BroadcastTypeRelay:
+		if config.Relay.Url == "" {
+			return nil, fmt.Errorf("relay broadcast requires the relay url")
+		}
+		return newRelayBroadcaster(client, config.Relay.Url), nil
+	default:
+		return nil, fmt.Errorf("unsupported broadcast type %s", config.Type)
+	}
+}
@@ -0,0 +1,187 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package construction
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	hexutils "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/hex"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/types"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type capturedRequest struct {
+	body        []byte
+	contentType string
+	path        string
+}
+
+func newCapturingServer(statusCode int, captured *capturedRequest) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		captured.body, _ = ioutil.ReadAll(r.Body)
+		captured.contentType = r.Header.Get("Content-Type")
+		captured.path = r.URL.Path
+		w.WriteHeader(statusCode)
+	}))
+}
+
+func getSignedTransaction(t *testing.T) (ITransaction, string, []byte) {
+	transaction, rErr := unmarshallTransactionFromHexString(validSignedTransaction)
+	assert.Nil(t, rErr)
+	hash, err := transaction.GetTransactionHash()
+	assert.NoError(t, err)
+	transactionBytes, err := transaction.ToBytes()
+	assert.NoError(t, err)
+	return transaction, hexutils.SafeAddHexPrefix(hex.EncodeToString(hash)), transactionBytes
+}
+
+func TestNewBroadcaster(t *testing.T) {
+	var tests = []struct {
+		name         string
+		config       types.Broadcast
+		expectedType interface{}
+		expectError  bool
+	}{
+		{name: "Default", expectedType: &grpcBroadcaster{}},
+		{name: "Grpc", config: types.Broadcast{Type: BroadcastTypeGrpc}, expectedType: &grpcBroadcaster{}},
+		{
+			name: "Kafka",
+			config: types.Broadcast{
+				Kafka: types.KafkaBroadcast{Topic: "transactions", Url: "http://localhost:8082"},
+				Type:  BroadcastTypeKafka,
+			},
+			expectedType: &httpBroadcaster{},
+		},
+		{name: "KafkaWithoutUrl", config: types.Broadcast{Type: BroadcastTypeKafka}, expectError: true},
+		{
+			name: "Relay",
+			config: types.Broadcast{
+				Relay: types.RelayBroadcast{Url: "http://localhost:8080/submit"},
+				Type:  BroadcastTypeRelay,
+			},
+			expectedType: &httpBroadcaster{},
+		},
+		{name: "RelayWithoutUrl", config: types.Broadcast{Type: BroadcastTypeRelay}, expectError: true},
+		{name: "Unsupported", config: types.Broadcast{Type: "pigeon"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := NewBroadcaster(tt.config, hedera.ClientForTestnet())
+
+			// then
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, actual)
+			} else {
+				assert.NoError(t, err)
+				assert.IsType(t, tt.expectedType, actual)
+			}
+		})
+	}
+}
+
+func TestRelayBroadcast(t *testing.T) {
+	// given
+	captured := &capturedRequest{}
+	server := newCapturingServer(http.StatusAccepted, captured)
+	defer server.Close()
+	broadcaster, _ := NewBroadcaster(
+		types.Broadcast{Relay: types.RelayBroadcast{Url: server.URL + "/submit"}, Type: BroadcastTypeRelay},
+		nil,
+	)
+	transaction, hash, transactionBytes := getSignedTransaction(t)
+
+	// when
+	err := broadcaster.Broadcast(transaction)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, contentTypeJson, captured.contentType)
+	assert.Equal(t, "/submit", captured.path)
+	actual := &relayRequest{}
+	assert.NoError(t, json.Unmarshal(captured.body, actual))
+	assert.Equal(t, &relayRequest{
+		Hash:              hash,
+		SignedTransaction: hexutils.SafeAddHexPrefix(hex.EncodeToString(transactionBytes)),
+	}, actual)
+}
+
+func TestKafkaBroadcast(t *testing.T) {
+	// given
+	captured := &capturedRequest{}
+	server := newCapturingServer(http.StatusOK, captured)
+	defer server.Close()
+	broadcaster, _ := NewBroadcaster(
+		types.Broadcast{Kafka: types.KafkaBroadcast{Topic: "transactions", Url: server.URL}, Type: BroadcastTypeKafka},
+		nil,
+	)
+	transaction, hash, transactionBytes := getSignedTransaction(t)
+
+	// when
+	err := broadcaster.Broadcast(transaction)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, contentTypeKafkaBinaryV2, captured.contentType)
+	assert.Equal(t, "/topics/transactions", captured.path)
+	actual := &kafkaProduceRequest{}
+	assert.NoError(t, json.Unmarshal(captured.body, actual))
+	assert.Equal(t, &kafkaProduceRequest{
+		Records: []kafkaRecord{{
+			Key:   base64.StdEncoding.EncodeToString([]byte(hash)),
+			Value: base64.StdEncoding.EncodeToString(transactionBytes),
+		}},
+	}, actual)
+}
+
+func TestHttpBroadcastFails(t *testing.T) {
+	// given
+	server := newCapturingServer(http.StatusServiceUnavailable, &capturedRequest{})
+	defer server.Close()
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+	transaction, _, _ := getSignedTransaction(t)
+
+	for name, url := range map[string]string{"ErrorStatus": server.URL, "Unreachable": unreachable.URL} {
+		t.Run(name, func(t *testing.T) {
+			broadcaster, _ := NewBroadcaster(
+				types.Broadcast{Relay: types.RelayBroadcast{Url: url}, Timeout: 1000, Type: BroadcastTypeRelay},
+				nil,
+			)
+
+			// when
+			err := broadcaster.Broadcast(transaction)
+
+			// then
+			assert.Error(t, err)
+			assert.True(t, isSubmitFailure(err))
+		})
+	}
+}
@@ -22,11 +22,14 @@ package construction
 
 import (
 	"encoding/json"
+	goErrors "errors"
 	"reflect"
+	"time"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/go-playground/validator/v10"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
 	"github.com/hashgraph/hedera-sdk-go/v2"
 	log "github.com/sirupsen/logrus"
@@ -59,14 +62,94 @@ func isEmptyPublicKey(key hedera.Key) bool {
 	return len(pk.Bytes()) == 0
 }
 
+func isAccountIdLess(accountIdA hedera.AccountID, accountIdB hedera.AccountID) bool {
+	if accountIdA.Shard != accountIdB.Shard {
+		return accountIdA.Shard < accountIdB.Shard
+	}
+
+	if accountIdA.Realm != accountIdB.Realm {
+		return accountIdA.Realm < accountIdB.Realm
+	}
+
+	return accountIdA.Account < accountIdB.Account
+}
+
+func isTokenIdLess(tokenIdA hedera.TokenID, tokenIdB hedera.TokenID) bool {
+	if tokenIdA.Shard != tokenIdB.Shard {
+		return tokenIdA.Shard < tokenIdB.Shard
+	}
+
+	if tokenIdA.Realm != tokenIdB.Realm {
+		return tokenIdA.Realm < tokenIdB.Realm
+	}
+
+	return tokenIdA.Token < tokenIdB.Token
+}
+
 func isZeroAccountId(accountId hedera.AccountID) bool {
 	return accountId.Shard == 0 && accountId.Realm == 0 && accountId.Account == 0
 }
 
+func isZeroScheduleId(scheduleId hedera.ScheduleID) bool {
+	return scheduleId.Shard == 0 && scheduleId.Realm == 0 && scheduleId.Schedule == 0
+}
+
 func isZeroTokenId(tokenId hedera.TokenID) bool {
 	return tokenId.Shard == 0 && tokenId.Realm == 0 && tokenId.Token == 0
 }
 
+// newTransactionId generates the transaction id of a constructed transaction paid by payer, with the valid start
+// backdated by validStartBackdate, or by the SDK's random backdate if it's 0
+func newTransactionId(payer hedera.AccountID, validStartBackdate time.Duration) hedera.TransactionID {
+	if validStartBackdate == 0 {
+		return hedera.TransactionIDGenerate(payer)
+	}
+
+	return hedera.NewTransactionIDWithValidStart(payer, time.Now().Add(-validStartBackdate))
+}
+
+// parseAccountId parses the shard.realm.num address into a hedera.AccountID, the optional checksum is validated
+// against ledgerId. Unlike hedera.AccountIDFromString, an address which can't be encoded as an entity id in the
+// database is rejected
+func parseAccountId(address string, ledgerId []byte) (hedera.AccountID, error) {
+	entityId, err := entityid.FromStringWithLedgerId(address, ledgerId)
+	if err != nil {
+		return hedera.AccountID{}, err
+	}
+
+	return entityId.ToSdkAccountId(), nil
+}
+
+// parseScheduleId parses the shard.realm.num schedule id into a hedera.ScheduleID, the optional checksum is validated
+// against ledgerId
+func parseScheduleId(scheduleId string, ledgerId []byte) (hedera.ScheduleID, error) {
+	entityId, err := entityid.FromStringWithLedgerId(scheduleId, ledgerId)
+	if err != nil {
+		return hedera.ScheduleID{}, err
+	}
+
+	return entityId.ToSdkScheduleId(), nil
+}
+
+// parseTokenId parses the shard.realm.num token id into a hedera.TokenID
+func parseTokenId(tokenId string) (hedera.TokenID, error) {
+	entityId, err := entityid.FromString(tokenId)
+	if err != nil {
+		return hedera.TokenID{}, err
+	}
+
+	return entityId.ToSdkTokenId(), nil
+}
+
+// invalidEntityIdError returns ErrEntityIdChecksumMismatch if err is caused by a checksum mismatch, otherwise invalidErr
+func invalidEntityIdError(err error, invalidErr *types.Error) *types.Error {
+	if goErrors.Is(err, entityid.ErrChecksumMismatch) {
+		return errors.ErrEntityIdChecksumMismatch
+	}
+
+	return invalidErr
+}
+
 func parseOperationMetadata(
 	validate *validator.Validate,
 	out interface{},
@@ -87,7 +170,7 @@ func parseOperationMetadata(
 
 	if err := json.Unmarshal(data, out); err != nil {
 		log.Errorf("Failed to unmarshal operation metadata: %s", err)
-		return errors.ErrInvalidOperationMetadata
+		return invalidEntityIdError(err, errors.ErrInvalidOperationMetadata)
 	}
 
 	if validate != nil {
@@ -100,6 +183,20 @@ func parseOperationMetadata(
 	return nil
 }
 
+// resolveMetadataAccountIds resolves the account ids unmarshalled from the operation metadata with ledgerId.
+// ErrEntityIdChecksumMismatch is returned if a checksum doesn't match, otherwise ErrInvalidOperationMetadata if any
+// account id is invalid
+func resolveMetadataAccountIds(ledgerId []byte, accountIds ...*metadataAccountId) *types.Error {
+	for _, accountId := range accountIds {
+		if err := accountId.resolve(ledgerId); err != nil {
+			log.Errorf("Failed to parse the account id in the operation metadata: %s", err)
+			return invalidEntityIdError(err, errors.ErrInvalidOperationMetadata)
+		}
+	}
+
+	return nil
+}
+
 func validateOperations(operations []*types.Operation, size int, opType string, expectNilAmount bool) *types.Error {
 	if len(operations) == 0 {
 		return errors.ErrEmptyOperations
@@ -21,10 +21,14 @@
 package construction
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	rTypes "github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/go-playground/validator/v10"
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/mocks/repository"
 	"github.com/hashgraph/hedera-sdk-go/v2"
@@ -146,6 +150,92 @@ func TestIsEmptyPublicKey(t *testing.T) {
 	}
 }
 
+func TestIsAccountIdLess(t *testing.T) {
+	var tests = []struct {
+		name       string
+		accountIdA hedera.AccountID
+		accountIdB hedera.AccountID
+		expected   bool
+	}{
+		{
+			name:       "LessShard",
+			accountIdA: hedera.AccountID{Shard: 0, Realm: 1, Account: 3},
+			accountIdB: hedera.AccountID{Shard: 1, Realm: 0, Account: 2},
+			expected:   true,
+		},
+		{
+			name:       "LessRealm",
+			accountIdA: hedera.AccountID{Realm: 0, Account: 3},
+			accountIdB: hedera.AccountID{Realm: 1, Account: 2},
+			expected:   true,
+		},
+		{
+			name:       "LessAccount",
+			accountIdA: hedera.AccountID{Account: 2},
+			accountIdB: hedera.AccountID{Account: 3},
+			expected:   true,
+		},
+		{
+			name:       "Equal",
+			accountIdA: hedera.AccountID{Account: 2},
+			accountIdB: hedera.AccountID{Account: 2},
+			expected:   false,
+		},
+		{
+			name:       "Greater",
+			accountIdA: hedera.AccountID{Account: 3},
+			accountIdB: hedera.AccountID{Account: 2},
+			expected:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isAccountIdLess(tt.accountIdA, tt.accountIdB))
+		})
+	}
+}
+
+func TestIsTokenIdLess(t *testing.T) {
+	var tests = []struct {
+		name     string
+		tokenIdA hedera.TokenID
+		tokenIdB hedera.TokenID
+		expected bool
+	}{
+		{
+			name:     "LessShard",
+			tokenIdA: hedera.TokenID{Shard: 0, Realm: 1, Token: 3},
+			tokenIdB: hedera.TokenID{Shard: 1, Realm: 0, Token: 2},
+			expected: true,
+		},
+		{
+			name:     "LessRealm",
+			tokenIdA: hedera.TokenID{Realm: 0, Token: 3},
+			tokenIdB: hedera.TokenID{Realm: 1, Token: 2},
+			expected: true,
+		},
+		{
+			name:     "LessToken",
+			tokenIdA: hedera.TokenID{Token: 2},
+			tokenIdB: hedera.TokenID{Token: 3},
+			expected: true,
+		},
+		{
+			name:     "Equal",
+			tokenIdA: hedera.TokenID{Token: 2},
+			tokenIdB: hedera.TokenID{Token: 2},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isTokenIdLess(tt.tokenIdA, tt.tokenIdB))
+		})
+	}
+}
+
 func TestIsZeroAccountId(t *testing.T) {
 	var tests = []struct {
 		name      string
@@ -196,6 +286,47 @@ func TestIsZeroTokenId(t *testing.T) {
 	}
 }
 
+func TestParseEntityIds(t *testing.T) {
+	accountId, err := parseAccountId("1.2.3", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, hedera.AccountID{Shard: 1, Realm: 2, Account: 3}, accountId)
+
+	scheduleId, err := parseScheduleId("1.2.3", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, hedera.ScheduleID{Shard: 1, Realm: 2, Schedule: 3}, scheduleId)
+
+	tokenId, err := parseTokenId("1.2.3")
+	assert.NoError(t, err)
+	assert.Equal(t, hedera.TokenID{Shard: 1, Realm: 2, Token: 3}, tokenId)
+}
+
+func TestParseEntityIdsThrows(t *testing.T) {
+	var tests = []struct {
+		name  string
+		input string
+	}{
+		{name: "Empty"},
+		{name: "NotTriplet", input: "123"},
+		{name: "Negative", input: "0.0.-1"},
+		{name: "NumOverflow", input: "0.0.4294967296"},
+		{name: "RealmOverflow", input: "0.65536.1"},
+		{name: "ShardOverflow", input: "32768.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseAccountId(tt.input, nil)
+			assert.Error(t, err)
+
+			_, err = parseScheduleId(tt.input, nil)
+			assert.Error(t, err)
+
+			_, err = parseTokenId(tt.input)
+			assert.Error(t, err)
+		})
+	}
+}
+
 func TestParseOperationMetadataWithValidate(t *testing.T) {
 	type data struct {
 		Name  string `json:"name" validate:"required"`
@@ -271,6 +402,88 @@ func TestParseOperationMetadataWithValidate(t *testing.T) {
 	}
 }
 
+func TestParseEntityIdsWithChecksum(t *testing.T) {
+	// given
+	settings := config.NewSettings()
+	settings.ConfigureLedgerId("mainnet")
+
+	// when
+	accountId, accountErr := parseAccountId("0.0.123-vfmkw", settings.LedgerId)
+	_, mismatchErr := parseAccountId("0.0.123-vfmkw", nil)
+
+	// then
+	assert.NoError(t, accountErr)
+	assert.Equal(t, hedera.AccountID{Account: 123}, accountId)
+	assert.ErrorIs(t, mismatchErr, entityid.ErrChecksumMismatch)
+}
+
+func TestParseOperationMetadataWithAccountIdChecksum(t *testing.T) {
+	type data struct {
+		Account *metadataAccountId `json:"account" validate:"required"`
+	}
+
+	var tests = []struct {
+		name     string
+		account  string
+		expected *rTypes.Error
+	}{
+		{name: "NoChecksum", account: "0.0.123"},
+		{name: "ValidChecksum", account: "0.0.123-vfmkw"},
+		{name: "ChecksumMismatch", account: "0.0.123-esxsf", expected: errors.ErrEntityIdChecksumMismatch},
+		{name: "InvalidAccount", account: "0.0.a", expected: errors.ErrInvalidOperationMetadata},
+		{name: "EmptyAccount", account: "", expected: errors.ErrInvalidOperationMetadata},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			settings := config.NewSettings()
+			settings.ConfigureLedgerId("mainnet")
+			output := &data{}
+
+			// when
+			err := parseOperationMetadata(validator.New(), output, map[string]interface{}{"account": tt.account})
+			if err == nil {
+				err = resolveMetadataAccountIds(settings.LedgerId, output.Account)
+			}
+
+			// then
+			assert.Equal(t, tt.expected, err)
+			if tt.expected == nil {
+				assert.Equal(t, hedera.AccountID{Account: 123}, output.Account.AccountID)
+			}
+		})
+	}
+}
+
+func TestInvalidEntityIdError(t *testing.T) {
+	assert.Equal(
+		t,
+		errors.ErrEntityIdChecksumMismatch,
+		invalidEntityIdError(entityid.ErrChecksumMismatch, errors.ErrInvalidAccount),
+	)
+	assert.Equal(t, errors.ErrInvalidAccount, invalidEntityIdError(fmt.Errorf("invalid"), errors.ErrInvalidAccount))
+	assert.Equal(t, errors.ErrInvalidAccount, invalidEntityIdError(nil, errors.ErrInvalidAccount))
+}
+
+func TestNewTransactionId(t *testing.T) {
+	// given
+	payer := hedera.AccountID{Account: 123}
+
+	// when
+	before := time.Now()
+	generated := newTransactionId(payer, 0)
+	backdated := newTransactionId(payer, 10*time.Second)
+	after := time.Now()
+
+	// then
+	assert.Equal(t, payer, *generated.AccountID)
+	assert.True(t, generated.ValidStart.Before(before))
+	assert.Equal(t, payer, *backdated.AccountID)
+	assert.False(t, backdated.ValidStart.Before(before.Add(-10*time.Second)))
+	assert.False(t, backdated.ValidStart.After(after.Add(-10*time.Second)))
+}
+
 func TestParseOperationMetadataWithoutValidate(t *testing.T) {
 	type data struct {
 		Name  string `json:"name"`
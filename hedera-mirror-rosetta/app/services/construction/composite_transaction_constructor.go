@@ -26,10 +26,34 @@ import (
 	rTypes "github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
 	"github.com/hashgraph/hedera-sdk-go/v2"
 	log "github.com/sirupsen/logrus"
 )
 
+// defaultMaxTransactionFees are the max transaction fees the SDK defaults to for the transaction of each operation type
+var defaultMaxTransactionFees = map[string]hedera.Hbar{
+	config.OperationTypeCryptoTransfer:  hedera.NewHbar(1),
+	config.OperationTypeScheduleSign:    hedera.NewHbar(5),
+	config.OperationTypeTokenAssociate:  hedera.NewHbar(5),
+	config.OperationTypeTokenBurn:       hedera.NewHbar(2),
+	config.OperationTypeTokenCreate:     hedera.NewHbar(30),
+	config.OperationTypeTokenDelete:     hedera.NewHbar(30),
+	config.OperationTypeTokenDissociate: hedera.NewHbar(5),
+	config.OperationTypeTokenFreeze:     hedera.NewHbar(30),
+	config.OperationTypeTokenGrantKyc:   hedera.NewHbar(30),
+	config.OperationTypeTokenMint:       hedera.NewHbar(30),
+	config.OperationTypeTokenRevokeKyc:  hedera.NewHbar(30),
+	config.OperationTypeTokenUnfreeze:   hedera.NewHbar(30),
+	config.OperationTypeTokenUpdate:     hedera.NewHbar(30),
+	config.OperationTypeTokenWipe:       hedera.NewHbar(30),
+}
+
+// bodyDataTypeParser parses a decoded transaction with the constructor selected by the data type of its body
+type bodyDataTypeParser interface {
+	parseByBodyDataType(decoded *decodedTransaction) ([]*rTypes.Operation, []hedera.AccountID, *rTypes.Error)
+}
+
 type transactionConstructorWithType interface {
 	TransactionConstructor
 	GetOperationType() string
@@ -39,18 +63,26 @@ type transactionConstructorWithType interface {
 type compositeTransactionConstructor struct {
 	constructorsByOperationType   map[string]transactionConstructorWithType
 	constructorsByTransactionType map[string]transactionConstructorWithType
+	maxTransactionFees            map[string]hedera.Hbar
 }
 
+// Construct constructs the transaction with the max transaction fee. A zero max transaction fee is replaced with the
+// configured or the default max transaction fee of the operation type
 func (c *compositeTransactionConstructor) Construct(
 	nodeAccountId hedera.AccountID,
 	operations []*rTypes.Operation,
+	maxTransactionFee hedera.Hbar,
 ) (ITransaction, []hedera.AccountID, *rTypes.Error) {
 	h, err := c.validate(operations)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return h.Construct(nodeAccountId, operations)
+	if maxTransactionFee.AsTinybar() == 0 {
+		maxTransactionFee = c.maxTransactionFees[h.GetOperationType()]
+	}
+
+	return h.Construct(nodeAccountId, operations, maxTransactionFee)
 }
 
 func (c *compositeTransactionConstructor) Parse(transaction ITransaction) (
@@ -68,6 +100,23 @@ func (c *compositeTransactionConstructor) Parse(transaction ITransaction) (
 	return h.Parse(transaction)
 }
 
+// parseByBodyDataType parses the transaction with the constructor of the data type of its decoded body, so the
+// constructor is chosen from the transaction itself rather than the type of the sdk transaction built from it
+func (c *compositeTransactionConstructor) parseByBodyDataType(decoded *decodedTransaction) (
+	[]*rTypes.Operation,
+	[]hedera.AccountID,
+	*rTypes.Error,
+) {
+	dataType := decoded.getBodyDataType()
+	h, ok := c.constructorsByTransactionType[sdkTransactionTypesByBodyDataType[dataType]]
+	if !ok {
+		return nil, nil, errors.AddErrorDetails(errors.ErrTransactionTypeUnsupported, errors.DetailTransactionType,
+			string(dataType))
+	}
+
+	return h.Parse(decoded.transaction)
+}
+
 func (c *compositeTransactionConstructor) Preprocess(operations []*rTypes.Operation) ([]hedera.AccountID, *rTypes.Error) {
 	h, err := c.validate(operations)
 	if err != nil {
@@ -77,6 +126,14 @@ func (c *compositeTransactionConstructor) Preprocess(operations []*rTypes.Operat
 	return h.Preprocess(operations)
 }
 
+func (c *compositeTransactionConstructor) getOperationType(transaction ITransaction) string {
+	if h, ok := c.constructorsByTransactionType[reflect.TypeOf(transaction).Elem().Name()]; ok {
+		return h.GetOperationType()
+	}
+
+	return ""
+}
+
 func (c *compositeTransactionConstructor) addConstructor(constructor transactionConstructorWithType) {
 	c.constructorsByOperationType[constructor.GetOperationType()] = constructor
 	c.constructorsByTransactionType[constructor.GetSdkTransactionType()] = constructor
@@ -103,27 +160,59 @@ func (c *compositeTransactionConstructor) validate(operations []*rTypes.Operatio
 	return h, nil
 }
 
-func NewTransactionConstructor(tokenRepo repositories.TokenRepository) TransactionConstructor {
+// NewTransactionConstructor creates the TransactionConstructor of all supported operation types. maxTransactionFees
+// in tinybars override the default max transaction fees by operation type, a non-positive fee is ignored. The
+// autoRenewPeriod in seconds is the default of created entities, one outside of the HAPI bounds is ignored. The
+// transactions are constructed and parsed with the settings
+func NewTransactionConstructor(
+	tokenAssociationRepo repositories.TokenAssociationRepository,
+	tokenRepo repositories.TokenRepository,
+	maxTransactionFees map[string]int64,
+	autoRenewPeriod int64,
+	settings *config.Settings,
+) TransactionConstructor {
 	c := &compositeTransactionConstructor{
 		constructorsByOperationType:   make(map[string]transactionConstructorWithType),
 		constructorsByTransactionType: make(map[string]transactionConstructorWithType),
+		maxTransactionFees:            make(map[string]hedera.Hbar, len(defaultMaxTransactionFees)),
+	}
+
+	for operationType, maxTransactionFee := range defaultMaxTransactionFees {
+		c.maxTransactionFees[operationType] = maxTransactionFee
+	}
+
+	for operationType, maxTransactionFee := range maxTransactionFees {
+		if maxTransactionFee <= 0 {
+			log.Warnf("Ignore non-positive max transaction fee %d of operation type %s", maxTransactionFee,
+				operationType)
+			continue
+		}
+
+		c.maxTransactionFees[operationType] = hedera.HbarFromTinybar(maxTransactionFee)
+	}
+
+	if autoRenewPeriod != 0 && !isValidAutoRenewPeriod(autoRenewPeriod) {
+		log.Warnf("Ignore default auto renew period %d outside of [%d, %d]", autoRenewPeriod, minAutoRenewPeriod,
+			maxAutoRenewPeriod)
+		autoRenewPeriod = 0
 	}
 
-	c.addConstructor(newCryptoTransferTransactionConstructor(tokenRepo))
-	c.addConstructor(newTokenCreateTransactionConstructor())
+	c.addConstructor(newCryptoTransferTransactionConstructor(tokenAssociationRepo, tokenRepo, settings))
+	c.addConstructor(newScheduleSignTransactionConstructor(settings))
+	c.addConstructor(newTokenCreateTransactionConstructor(autoRenewPeriod, settings))
 
 	if tokenRepo != nil {
-		c.addConstructor(newTokenAssociateTransactionConstructor(tokenRepo))
-		c.addConstructor(newTokenBurnTransactionConstructor(tokenRepo))
-		c.addConstructor(newTokenDeleteTransactionConstructor(tokenRepo))
-		c.addConstructor(newTokenDissociateTransactionConstructor(tokenRepo))
-		c.addConstructor(newTokenFreezeTransactionConstructor(tokenRepo))
-		c.addConstructor(newTokenGrantKycTransactionConstructor(tokenRepo))
-		c.addConstructor(newTokenRevokeKycTransactionConstructor(tokenRepo))
-		c.addConstructor(newTokenMintTransactionConstructor(tokenRepo))
-		c.addConstructor(newTokenUnfreezeTransactionConstructor(tokenRepo))
-		c.addConstructor(newTokenUpdateTransactionConstructor(tokenRepo))
-		c.addConstructor(newTokenWipeTransactionConstructor(tokenRepo))
+		c.addConstructor(newTokenAssociateTransactionConstructor(tokenRepo, settings))
+		c.addConstructor(newTokenBurnTransactionConstructor(tokenRepo, settings))
+		c.addConstructor(newTokenDeleteTransactionConstructor(tokenRepo, settings))
+		c.addConstructor(newTokenDissociateTransactionConstructor(tokenRepo, settings))
+		c.addConstructor(newTokenFreezeTransactionConstructor(tokenRepo, settings))
+		c.addConstructor(newTokenGrantKycTransactionConstructor(tokenRepo, settings))
+		c.addConstructor(newTokenRevokeKycTransactionConstructor(tokenRepo, settings))
+		c.addConstructor(newTokenMintTransactionConstructor(tokenRepo, settings))
+		c.addConstructor(newTokenUnfreezeTransactionConstructor(tokenRepo, settings))
+		c.addConstructor(newTokenUpdateTransactionConstructor(tokenRepo, settings))
+		c.addConstructor(newTokenWipeTransactionConstructor(tokenRepo, settings))
 	}
 
 	return c
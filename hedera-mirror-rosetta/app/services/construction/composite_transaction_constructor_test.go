@@ -28,6 +28,7 @@ import (
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/mocks/repository"
 	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/hashgraph/hedera-sdk-go/v2/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -53,12 +54,12 @@ type mockTransactionConstructor struct {
 	mock.Mock
 }
 
-func (m *mockTransactionConstructor) Construct(nodeAccountId hedera.AccountID, operations []*types.Operation) (
-	ITransaction,
-	[]hedera.AccountID,
-	*types.Error,
-) {
-	args := m.Called(nodeAccountId, operations)
+func (m *mockTransactionConstructor) Construct(
+	nodeAccountId hedera.AccountID,
+	operations []*types.Operation,
+	maxTransactionFee hedera.Hbar,
+) (ITransaction, []hedera.AccountID, *types.Error) {
+	args := m.Called(nodeAccountId, operations, maxTransactionFee)
 	return args.Get(0).(ITransaction), args.Get(1).([]hedera.AccountID), args.Get(2).(*types.Error)
 }
 
@@ -99,6 +100,7 @@ func (suite *compositeTransactionConstructorSuite) SetupTest() {
 	constructor := &compositeTransactionConstructor{
 		constructorsByOperationType:   map[string]transactionConstructorWithType{},
 		constructorsByTransactionType: map[string]transactionConstructorWithType{},
+		maxTransactionFees:            map[string]hedera.Hbar{config.OperationTypeCryptoTransfer: hedera.NewHbar(2)},
 	}
 	constructor.addConstructor(mockConstructor)
 
@@ -107,23 +109,91 @@ func (suite *compositeTransactionConstructorSuite) SetupTest() {
 }
 
 func (suite *compositeTransactionConstructorSuite) TestNewTransactionConstructor() {
-	h := NewTransactionConstructor(&repository.MockTokenRepository{})
+	h := NewTransactionConstructor(
+		&repository.MockTokenAssociationRepository{},
+		&repository.MockTokenRepository{},
+		nil,
+		0,
+		config.NewSettings(),
+	)
 	assert.NotNil(suite.T(), h)
 }
 
 func (suite *compositeTransactionConstructorSuite) TestNewTransactionConstructorNilRepo() {
-	h := NewTransactionConstructor(nil)
+	h := NewTransactionConstructor(nil, nil, nil, 0, config.NewSettings())
 	assert.NotNil(suite.T(), h)
 }
 
+func (suite *compositeTransactionConstructorSuite) TestNewTransactionConstructorMaxTransactionFees() {
+	// given
+	maxTransactionFees := map[string]int64{
+		config.OperationTypeCryptoTransfer: 50000000,
+		config.OperationTypeTokenCreate:    -1,
+		config.OperationTypeTokenDelete:    0,
+	}
+	expected := map[string]hedera.Hbar{}
+	for operationType, maxTransactionFee := range defaultMaxTransactionFees {
+		expected[operationType] = maxTransactionFee
+	}
+	expected[config.OperationTypeCryptoTransfer] = hedera.HbarFromTinybar(50000000)
+
+	// when
+	h := NewTransactionConstructor(nil, nil, maxTransactionFees, 0, config.NewSettings())
+
+	// then
+	assert.Equal(suite.T(), expected, h.(*compositeTransactionConstructor).maxTransactionFees)
+}
+
+func (suite *compositeTransactionConstructorSuite) TestNewTransactionConstructorAutoRenewPeriod() {
+	var tests = []struct {
+		autoRenewPeriod int64
+		expected        int64
+	}{
+		{autoRenewPeriod: 0, expected: 0},
+		{autoRenewPeriod: 7776000, expected: 7776000},
+		{autoRenewPeriod: 3600, expected: 0},
+		{autoRenewPeriod: maxAutoRenewPeriod + 1, expected: 0},
+	}
+
+	for _, tt := range tests {
+		// when
+		h := NewTransactionConstructor(nil, nil, nil, tt.autoRenewPeriod, config.NewSettings())
+
+		// then
+		tokenCreate := h.(*compositeTransactionConstructor).
+			constructorsByOperationType[config.OperationTypeTokenCreate].(*tokenCreateTransactionConstructor)
+		assert.Equal(suite.T(), tt.expected, tokenCreate.autoRenewPeriod)
+	}
+}
+
 func (suite *compositeTransactionConstructorSuite) TestConstruct() {
 	// given
 	suite.mockConstructor.
-		On("Construct", nodeAccountId, cryptoTransferOperations).
+		On("Construct", nodeAccountId, cryptoTransferOperations, maxTransactionFee).
+		Return(cryptoTransferTransaction, signers, nilError)
+
+	// when
+	actualTx, actualSigners, err := suite.constructor.Construct(
+		nodeAccountId,
+		cryptoTransferOperations,
+		maxTransactionFee,
+	)
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), cryptoTransferTransaction, actualTx)
+	assert.Equal(suite.T(), signers, actualSigners)
+	suite.mockConstructor.AssertExpectations(suite.T())
+}
+
+func (suite *compositeTransactionConstructorSuite) TestConstructDefaultMaxTransactionFee() {
+	// given
+	suite.mockConstructor.
+		On("Construct", nodeAccountId, cryptoTransferOperations, hedera.NewHbar(2)).
 		Return(cryptoTransferTransaction, signers, nilError)
 
 	// when
-	actualTx, actualSigners, err := suite.constructor.Construct(nodeAccountId, cryptoTransferOperations)
+	actualTx, actualSigners, err := suite.constructor.Construct(nodeAccountId, cryptoTransferOperations, hedera.ZeroHbar)
 
 	// then
 	assert.Nil(suite.T(), err)
@@ -135,11 +205,15 @@ func (suite *compositeTransactionConstructorSuite) TestConstruct() {
 func (suite *compositeTransactionConstructorSuite) TestConstructFail() {
 	// given
 	suite.mockConstructor.
-		On("Construct", nodeAccountId, cryptoTransferOperations).
+		On("Construct", nodeAccountId, cryptoTransferOperations, maxTransactionFee).
 		Return(nilTransaction, nilSigners, errors.ErrInternalServerError)
 
 	// when
-	actualTx, actualSigners, err := suite.constructor.Construct(nodeAccountId, cryptoTransferOperations)
+	actualTx, actualSigners, err := suite.constructor.Construct(
+		nodeAccountId,
+		cryptoTransferOperations,
+		maxTransactionFee,
+	)
 
 	// then
 	assert.NotNil(suite.T(), err)
@@ -152,7 +226,7 @@ func (suite *compositeTransactionConstructorSuite) TestConstructEmptyOperations(
 	// given
 
 	// when
-	actualTx, actualSigners, err := suite.constructor.Construct(nodeAccountId, []*types.Operation{})
+	actualTx, actualSigners, err := suite.constructor.Construct(nodeAccountId, []*types.Operation{}, maxTransactionFee)
 
 	// then
 	assert.NotNil(suite.T(), err)
@@ -165,7 +239,7 @@ func (suite *compositeTransactionConstructorSuite) TestConstructUnsupportedOpera
 	// given
 
 	// when
-	actualTx, actualSigners, err := suite.constructor.Construct(nodeAccountId, unsupportedOperations)
+	actualTx, actualSigners, err := suite.constructor.Construct(nodeAccountId, unsupportedOperations, maxTransactionFee)
 
 	// then
 	assert.NotNil(suite.T(), err)
@@ -178,7 +252,7 @@ func (suite *compositeTransactionConstructorSuite) TestConstructMixedOperations(
 	// given
 
 	// when
-	actualTx, actualSigners, err := suite.constructor.Construct(nodeAccountId, mixedOperations)
+	actualTx, actualSigners, err := suite.constructor.Construct(nodeAccountId, mixedOperations, maxTransactionFee)
 
 	// then
 	assert.NotNil(suite.T(), err)
@@ -232,6 +306,66 @@ func (suite *compositeTransactionConstructorSuite) TestParseUnsupportedTransacti
 	suite.mockConstructor.AssertExpectations(suite.T())
 }
 
+func (suite *compositeTransactionConstructorSuite) TestParseByBodyDataType() {
+	// given
+	decoded := &decodedTransaction{
+		body:        &proto.TransactionBody{Data: &proto.TransactionBody_CryptoTransfer{}},
+		transaction: cryptoTransferTransaction,
+	}
+	suite.mockConstructor.
+		On("Parse", cryptoTransferTransaction).
+		Return(cryptoTransferOperations, signers, nilError)
+	parser := suite.constructor.(bodyDataTypeParser)
+
+	// when
+	actualOperations, actualSigner, err := parser.parseByBodyDataType(decoded)
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), cryptoTransferOperations, actualOperations)
+	assert.Equal(suite.T(), signers, actualSigner)
+	suite.mockConstructor.AssertExpectations(suite.T())
+}
+
+func (suite *compositeTransactionConstructorSuite) TestParseByBodyDataTypeUnsupported() {
+	var tests = []struct {
+		name     string
+		body     *proto.TransactionBody
+		dataType string
+	}{
+		{
+			name:     "ConstructorMissing",
+			body:     &proto.TransactionBody{Data: &proto.TransactionBody_TokenCreation{}},
+			dataType: "tokenCreation",
+		},
+		{
+			name:     "Unsupported",
+			body:     &proto.TransactionBody{Data: &proto.TransactionBody_ConsensusCreateTopic{}},
+			dataType: "consensusCreateTopic",
+		},
+		{name: "NoData", body: &proto.TransactionBody{}},
+	}
+
+	parser := suite.constructor.(bodyDataTypeParser)
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// when
+			actualOperations, actualSigner, err := parser.parseByBodyDataType(&decodedTransaction{
+				body:        tt.body,
+				transaction: tokenCreateTransaction,
+			})
+
+			// then
+			assert.Equal(t, errors.AddErrorDetails(errors.ErrTransactionTypeUnsupported,
+				errors.DetailTransactionType, tt.dataType), err)
+			assert.Nil(t, actualOperations)
+			assert.Nil(t, actualSigner)
+		})
+	}
+
+	suite.mockConstructor.AssertNotCalled(suite.T(), "Parse", mock.Anything)
+}
+
 func (suite *compositeTransactionConstructorSuite) TestPreprocess() {
 	// given
 	suite.mockConstructor.
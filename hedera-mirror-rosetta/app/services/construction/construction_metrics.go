@@ -0,0 +1,80 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package construction
+
+import (
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/iancoleman/strcase"
+)
+
+const (
+	endpointParse    = "parse"
+	endpointPayloads = "payloads"
+	endpointSubmit   = "submit"
+
+	outcomeSuccess       = "success"
+	transactionsHelp     = "The number of transactions constructed, parsed, and submitted by operation type and outcome"
+	transactionsMetric   = "hedera_mirror_rosetta_construction_transactions_total"
+	unknownOperationType = "UNKNOWN"
+)
+
+// operationTypeGetter gets the operation type of a transaction without parsing it
+type operationTypeGetter interface {
+	getOperationType(transaction ITransaction) string
+}
+
+// recordTransaction counts the transaction with operationType handled by the endpoint. The outcome is either success
+// or the error message in snake case, e.g., invalid_operations_amount
+func (c *constructionAPIService) recordTransaction(endpoint, operationType string, err *rTypes.Error) {
+	outcome := outcomeSuccess
+	if err != nil {
+		outcome = strcase.ToSnake(err.Message)
+	}
+
+	if operationType == "" {
+		operationType = unknownOperationType
+	}
+
+	c.registry.IncrementWithLabels(transactionsMetric, transactionsHelp, map[string]string{
+		"endpoint":       endpoint,
+		"operation_type": operationType,
+		"outcome":        outcome,
+	})
+}
+
+// getOperationType returns the operation type of the transaction, or an empty string if it's unknown
+func (c *constructionAPIService) getOperationType(transaction ITransaction) string {
+	getter, ok := c.transactionHandler.(operationTypeGetter)
+	if !ok || transaction == nil {
+		return ""
+	}
+
+	return getter.getOperationType(transaction)
+}
+
+// getOperationsType returns the type of the first operation, or an empty string if there is no operation
+func getOperationsType(operations []*rTypes.Operation) string {
+	if len(operations) == 0 || operations[0] == nil {
+		return ""
+	}
+
+	return operations[0].Type
+}
@@ -0,0 +1,120 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package construction
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/breaker"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/metrics"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	"github.com/iancoleman/strcase"
+	"github.com/stretchr/testify/assert"
+)
+
+func transactionsSample(endpoint, operationType, outcome string, count int) string {
+	return fmt.Sprintf("%s{endpoint=%q,operation_type=%q,outcome=%q} %d\n", transactionsMetric, endpoint,
+		operationType, outcome, count)
+}
+
+func TestConstructionPayloadsRecordsTransactions(t *testing.T) {
+	// given
+	registry := metrics.NewRegistry()
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:                defaultNetwork,
+		Nodes:                  defaultNodes,
+		Broadcast:              defaultBroadcast,
+		Settings:               config.NewSettings(),
+		TransactionConstructor: NewTransactionConstructor(nil, nil, nil, 0, config.NewSettings()),
+		Registry:               registry,
+	})
+	operations := []*types.Operation{
+		dummyOperation(0, "CRYPTOTRANSFER", defaultCryptoAccountId1, defaultSendAmount),
+		dummyOperation(1, "CRYPTOTRANSFER", defaultCryptoAccountId2, defaultReceiveAmount),
+	}
+	unbalanced := []*types.Operation{dummyOperation(0, "CRYPTOTRANSFER", defaultCryptoAccountId1, defaultSendAmount)}
+
+	// when
+	service.ConstructionPayloads(nil, dummyPayloadsRequest(operations))
+	service.ConstructionPayloads(nil, dummyPayloadsRequest(operations))
+	_, err := service.ConstructionPayloads(nil, dummyPayloadsRequest(unbalanced))
+	service.ConstructionPayloads(nil, dummyPayloadsRequest(nil))
+
+	// then
+	body := registry.String()
+	assert.Contains(t, body, fmt.Sprintf("# HELP %s %s\n", transactionsMetric, transactionsHelp))
+	assert.Contains(t, body, transactionsSample(endpointPayloads, "CRYPTOTRANSFER", outcomeSuccess, 2))
+	assert.NotNil(t, err)
+	assert.Contains(t, body, transactionsSample(endpointPayloads, "CRYPTOTRANSFER", strcase.ToSnake(err.Message), 1))
+	assert.Contains(t, body, transactionsSample(endpointPayloads, unknownOperationType, "empty_operations_provided", 1))
+}
+
+func TestConstructionParseRecordsTransactions(t *testing.T) {
+	// given
+	registry := metrics.NewRegistry()
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:                defaultNetwork,
+		Nodes:                  defaultNodes,
+		Broadcast:              defaultBroadcast,
+		Settings:               config.NewSettings(),
+		TransactionConstructor: NewTransactionConstructor(nil, nil, nil, 0, config.NewSettings()),
+		Registry:               registry,
+	})
+
+	// when
+	service.ConstructionParse(nil, dummyConstructionParseRequest(validSignedTransaction, false))
+	service.ConstructionParse(nil, dummyConstructionParseRequest(corruptedTransaction, false))
+
+	// then
+	body := registry.String()
+	assert.Contains(t, body, transactionsSample(endpointParse, "CRYPTOTRANSFER", outcomeSuccess, 1))
+	assert.Contains(t, body, transactionsSample(endpointParse, unknownOperationType,
+		"transaction_unmarshalling_failed", 1))
+}
+
+func TestConstructionSubmitRecordsTransactions(t *testing.T) {
+	// given
+	registry := metrics.NewRegistry()
+	submitBreaker := breaker.NewCircuitBreaker("consensus nodes", 1, time.Hour)
+	_ = submitBreaker.Execute(func() error { return fmt.Errorf("timeout") }, isSubmitFailure)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:                defaultNetwork,
+		Nodes:                  defaultNodes,
+		Broadcast:              defaultBroadcast,
+		Settings:               config.NewSettings(),
+		TransactionConstructor: NewTransactionConstructor(nil, nil, nil, 0, config.NewSettings()),
+		SubmitBreaker:          submitBreaker,
+		Registry:               registry,
+	})
+	request := &types.ConstructionSubmitRequest{
+		NetworkIdentifier: networkIdentifier(),
+		SignedTransaction: validSignedTransaction,
+	}
+
+	// when
+	service.ConstructionSubmit(nil, request)
+
+	// then
+	assert.Contains(t, registry.String(), transactionsSample(endpointSubmit, "CRYPTOTRANSFER", "service_unavailable", 1))
+}
@@ -21,32 +21,69 @@
 package construction
 
 import (
+	"bytes"
 	"context"
 	"crypto/ed25519"
 	"crypto/rand"
 	"encoding/hex"
+	goErrors "errors"
+	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/coinbase/rosetta-sdk-go/server"
 	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/breaker"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
+	domainTypes "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/journal"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/metrics"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/nodehealth"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
 	hexutils "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/hex"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/types"
 	"github.com/hashgraph/hedera-sdk-go/v2"
 	"github.com/hashgraph/hedera-sdk-go/v2/proto"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/encoding/prototext"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
+const (
+	metadataNodeAccountIds = "node_account_ids"
+
+	optionMaxTransactionFee = "max_transaction_fee"
+	optionScheduleId        = "schedule_id"
+)
+
+// randomPrecision is the number of distinct random fractions used for the weighted node selection
+var randomPrecision = big.NewInt(1 << 53)
+
 // constructionAPIService implements the server.ConstructionAPIServicer interface.
 type constructionAPIService struct {
-	hederaClient       *hedera.Client
+	accountRepo        repositories.AccountRepository
+	broadcaster        Broadcaster
+	defaultPayer       *hedera.AccountID
+	exchangeRateRepo   repositories.ExchangeRateRepository
+	existenceCheck     bool
+	feeScheduleRepo    repositories.FeeScheduleRepository
+	journal            *journal.Journal
 	nodeAccountIds     []hedera.AccountID
 	nodeAccountIdsLen  *big.Int
+	nodeHealth         *nodehealth.Tracker
+	parseMode          string
+	registry           *metrics.Registry
+	scheduleRepo       repositories.ScheduleRepository
+	settings           *config.Settings
+	submitBreaker      *breaker.CircuitBreaker
 	transactionHandler TransactionConstructor
 }
 
-// ConstructionCombine implements the /construction/combine endpoint.
+// ConstructionCombine implements the /construction/combine endpoint. A transaction frozen for multiple nodes is
+// rejected since each node's transaction body has its own node account id, so a signature of the first body is invalid
+// for the others. /construction/payloads always freezes the transaction for a single node
 func (c *constructionAPIService) ConstructionCombine(
 	ctx context.Context,
 	request *rTypes.ConstructionCombineRequest,
@@ -55,24 +92,22 @@ func (c *constructionAPIService) ConstructionCombine(
 		return nil, errors.ErrNoSignature
 	}
 
-	transaction, rErr := unmarshallTransactionFromHexString(request.UnsignedTransaction)
+	decoded, rErr := decodeTransaction(request.UnsignedTransaction)
 	if rErr != nil {
 		return nil, rErr
 	}
 
-	frozenBodyBytes, rErr := getFrozenTransactionBodyBytes(transaction)
-	if rErr != nil {
-		return nil, rErr
+	if len(decoded.nodeAccountIds) > 1 {
+		return nil, errors.ErrMultipleNodesUnsupported
 	}
 
-	for _, signature := range request.Signatures {
-		pubKey, err := hedera.PublicKeyFromBytes(signature.PublicKey.Bytes)
-		if err != nil {
-			return nil, errors.ErrInvalidPublicKey
-		}
+	transaction := decoded.transaction
+	frozenBodyBytes := decoded.frozenBodyBytes
 
-		if !ed25519.Verify(pubKey.Bytes(), frozenBodyBytes, signature.Bytes) {
-			return nil, errors.ErrInvalidSignatureVerification
+	for index, signature := range request.Signatures {
+		pubKey, rErr := verifySignature(signature, frozenBodyBytes)
+		if rErr != nil {
+			return nil, errors.AddErrorDetails(rErr, errors.DetailIndex, index)
 		}
 
 		if rErr := addSignature(transaction, pubKey, signature.Bytes); rErr != nil {
@@ -95,7 +130,33 @@ func (c *constructionAPIService) ConstructionDerive(
 	ctx context.Context,
 	request *rTypes.ConstructionDeriveRequest,
 ) (*rTypes.ConstructionDeriveResponse, *rTypes.Error) {
-	return nil, errors.ErrNotImplemented
+	if c.accountRepo == nil {
+		// offline mode, an account can't be derived from a public key without its creation record
+		return nil, errors.ErrNotImplemented
+	}
+
+	if request.PublicKey == nil {
+		return nil, errors.ErrInvalidPublicKey
+	}
+
+	pubKey, err := hedera.PublicKeyFromBytes(request.PublicKey.Bytes)
+	if err != nil {
+		return nil, errors.ErrInvalidPublicKey
+	}
+
+	accounts, rErr := c.accountRepo.FindByPublicKey(pubKey.Bytes())
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	if len(accounts) == 0 {
+		return nil, errors.ErrAccountNotFound
+	}
+
+	return &rTypes.ConstructionDeriveResponse{
+		AccountIdentifier: accounts[0].ToRosetta(),
+		Metadata:          map[string]interface{}{"accounts": toAddresses(accounts)},
+	}, nil
 }
 
 // ConstructionHash implements the /construction/hash endpoint.
@@ -121,13 +182,40 @@ func (c *constructionAPIService) ConstructionHash(
 	}, nil
 }
 
-// ConstructionMetadata implements the /construction/metadata endpoint.
+// ConstructionMetadata implements the /construction/metadata endpoint. The fee of the transaction is suggested when
+// the fee schedule and the exchange rate repositories are available
 func (c *constructionAPIService) ConstructionMetadata(
 	ctx context.Context,
 	request *rTypes.ConstructionMetadataRequest,
 ) (*rTypes.ConstructionMetadataResponse, *rTypes.Error) {
+	metadata := make(map[string]interface{})
+	var suggestedFee []*rTypes.Amount
+
+	if request != nil {
+		if scheduleId, ok := request.Options[optionScheduleId].(string); ok && c.scheduleRepo != nil {
+			scheduleMetadata, rErr := c.getScheduleMetadata(scheduleId, request.PublicKeys)
+			if rErr != nil {
+				return nil, rErr
+			}
+
+			for key, value := range scheduleMetadata {
+				metadata[key] = value
+			}
+		}
+
+		if maxTransactionFee, ok := request.Options[optionMaxTransactionFee]; ok {
+			metadata[optionMaxTransactionFee] = maxTransactionFee
+		}
+
+		var rErr *rTypes.Error
+		if suggestedFee, rErr = c.getSuggestedFee(request.Options, len(request.PublicKeys)); rErr != nil {
+			return nil, rErr
+		}
+	}
+
 	return &rTypes.ConstructionMetadataResponse{
-		Metadata: make(map[string]interface{}),
+		Metadata:     metadata,
+		SuggestedFee: suggestedFee,
 	}, nil
 }
 
@@ -136,27 +224,90 @@ func (c *constructionAPIService) ConstructionParse(
 	ctx context.Context,
 	request *rTypes.ConstructionParseRequest,
 ) (*rTypes.ConstructionParseResponse, *rTypes.Error) {
-	transaction, err := unmarshallTransactionFromHexString(request.Transaction)
+	response, transaction, err := c.constructionParse(request)
+	operationType := c.getOperationType(transaction)
+	if response != nil {
+		operationType = getOperationsType(response.Operations)
+	}
+	c.recordTransaction(endpointParse, operationType, err)
+	return response, err
+}
+
+func (c *constructionAPIService) constructionParse(request *rTypes.ConstructionParseRequest) (
+	*rTypes.ConstructionParseResponse,
+	ITransaction,
+	*rTypes.Error,
+) {
+	decoded, err := decodeTransaction(request.Transaction)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	operations, accounts, err := c.transactionHandler.Parse(transaction)
+	transaction := decoded.transaction
+	unmodeledFields, err := checkUnmodeledFields(decoded.body, c.parseMode)
 	if err != nil {
-		return nil, err
+		return nil, transaction, err
+	}
+
+	operations, accounts, err := c.parse(decoded)
+	if err != nil {
+		return nil, transaction, err
 	}
 
 	signers := make([]*rTypes.AccountIdentifier, 0, len(accounts))
+	var metadata map[string]interface{}
+	if len(unmodeledFields) != 0 {
+		metadata = map[string]interface{}{metadataUnmodeledFields: unmodeledFields}
+	}
+
+	if len(decoded.nodeAccountIds) > 1 {
+		if metadata == nil {
+			metadata = make(map[string]interface{})
+		}
+		nodeAccountIds := make([]string, 0, len(decoded.nodeAccountIds))
+		for _, nodeAccountId := range decoded.nodeAccountIds {
+			nodeAccountIds = append(nodeAccountIds, nodeAccountId.String())
+		}
+		metadata[metadataNodeAccountIds] = nodeAccountIds
+	}
+
 	if request.Signed {
 		for _, account := range accounts {
 			signers = append(signers, &rTypes.AccountIdentifier{Address: account.String()})
 		}
+
+		keyAccounts, err := c.getSignatureKeyAccounts(transaction)
+		if err != nil {
+			return nil, transaction, err
+		}
+
+		if keyAccounts != nil {
+			if metadata == nil {
+				metadata = make(map[string]interface{})
+			}
+			metadata["key_accounts"] = keyAccounts
+		}
 	}
 
 	return &rTypes.ConstructionParseResponse{
 		Operations:               operations,
 		AccountIdentifierSigners: signers,
-	}, nil
+		Metadata:                 metadata,
+	}, transaction, nil
+}
+
+// parse parses the decoded transaction with the constructor of the data type of its body if the transaction handler
+// dispatches on it, otherwise with the constructor of the sdk transaction type
+func (c *constructionAPIService) parse(decoded *decodedTransaction) (
+	[]*rTypes.Operation,
+	[]hedera.AccountID,
+	*rTypes.Error,
+) {
+	if parser, ok := c.transactionHandler.(bodyDataTypeParser); ok {
+		return parser.parseByBodyDataType(decoded)
+	}
+
+	return c.transactionHandler.Parse(decoded.transaction)
 }
 
 // ConstructionPayloads implements the /construction/payloads endpoint.
@@ -164,11 +315,34 @@ func (c *constructionAPIService) ConstructionPayloads(
 	ctx context.Context,
 	request *rTypes.ConstructionPayloadsRequest,
 ) (*rTypes.ConstructionPayloadsResponse, *rTypes.Error) {
-	transaction, signers, rErr := c.transactionHandler.Construct(c.getRandomNodeAccountId(), request.Operations)
+	response, err := c.constructionPayloads(request)
+	c.recordTransaction(endpointPayloads, getOperationsType(request.Operations), err)
+	return response, err
+}
+
+func (c *constructionAPIService) constructionPayloads(request *rTypes.ConstructionPayloadsRequest) (
+	*rTypes.ConstructionPayloadsResponse,
+	*rTypes.Error,
+) {
+	maxTransactionFee, rErr := getMaxTransactionFee(request.Metadata)
 	if rErr != nil {
 		return nil, rErr
 	}
 
+	transaction, signers, rErr := c.transactionHandler.Construct(
+		c.getRandomNodeAccountId(),
+		c.withDefaultPayer(request.Operations),
+		maxTransactionFee,
+	)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	// the single signing payload is only valid for a transaction frozen for a single node
+	if len(transaction.GetNodeAccountIDs()) > 1 {
+		return nil, errors.ErrMultipleNodesUnsupported
+	}
+
 	bytes, err := transaction.ToBytes()
 	if err != nil {
 		return nil, errors.ErrTransactionMarshallingFailed
@@ -194,23 +368,61 @@ func (c *constructionAPIService) ConstructionPayloads(
 	}, nil
 }
 
-// ConstructionPreprocess implements the /construction/preprocess endpoint.
+// ConstructionPreprocess implements the /construction/preprocess endpoint. An operation without an account is filled
+// with the default payer if it's configured, so the default payer is among the required public keys. The accounts of
+// the operations must exist on the network if the existence check is enabled. The balances of the accounts are
+// checked if the request metadata has the check_balance option set to true. The max_transaction_fee option in
+// tinybars is passed on to the payloads request as metadata
 func (c *constructionAPIService) ConstructionPreprocess(
 	ctx context.Context,
 	request *rTypes.ConstructionPreprocessRequest,
 ) (*rTypes.ConstructionPreprocessResponse, *rTypes.Error) {
-	signers, err := c.transactionHandler.Preprocess(request.Operations)
+	operations := c.withDefaultPayer(request.Operations)
+	signers, err := c.transactionHandler.Preprocess(operations)
 	if err != nil {
 		return nil, err
 	}
 
+	if err = c.checkAccountsExist(operations); err != nil {
+		return nil, err
+	}
+
+	// the first signer is always the payer
+	if len(signers) > 0 {
+		if err = c.checkBalances(signers[0], operations, request.Metadata); err != nil {
+			return nil, err
+		}
+	}
+
 	requiredPublicKeys := make([]*rTypes.AccountIdentifier, 0, len(signers))
 	for _, signer := range signers {
 		requiredPublicKeys = append(requiredPublicKeys, &rTypes.AccountIdentifier{Address: signer.String()})
 	}
 
+	options := make(map[string]interface{})
+	if operationType := getOperationsType(operations); operationType != "" {
+		options[optionOperationType] = operationType
+	}
+
+	if maxTransactionFee, ok := request.Metadata[optionMaxTransactionFee]; ok {
+		if _, err = getMaxTransactionFee(request.Metadata); err != nil {
+			return nil, err
+		}
+		options[optionMaxTransactionFee] = maxTransactionFee
+	}
+
+	for _, operation := range operations {
+		if operation.Type != config.OperationTypeScheduleSign {
+			continue
+		}
+
+		if scheduleId, ok := operation.Metadata[optionScheduleId]; ok {
+			options[optionScheduleId] = scheduleId
+		}
+	}
+
 	return &rTypes.ConstructionPreprocessResponse{
-		Options:            make(map[string]interface{}),
+		Options:            options,
 		RequiredPublicKeys: requiredPublicKeys,
 	}, nil
 }
@@ -220,31 +432,162 @@ func (c *constructionAPIService) ConstructionSubmit(
 	ctx context.Context,
 	request *rTypes.ConstructionSubmitRequest,
 ) (*rTypes.TransactionIdentifierResponse, *rTypes.Error) {
+	response, transaction, err := c.constructionSubmit(request)
+	c.recordTransaction(endpointSubmit, c.getOperationType(transaction), err)
+	return response, err
+}
+
+func (c *constructionAPIService) constructionSubmit(request *rTypes.ConstructionSubmitRequest) (
+	*rTypes.TransactionIdentifierResponse,
+	ITransaction,
+	*rTypes.Error,
+) {
 	transaction, rErr := unmarshallTransactionFromHexString(request.SignedTransaction)
 	if rErr != nil {
-		return nil, rErr
+		return nil, nil, rErr
 	}
 
 	hash, err := transaction.GetTransactionHash()
 	if err != nil {
-		return nil, errors.ErrTransactionHashFailed
+		return nil, transaction, errors.ErrTransactionHashFailed
 	}
+	hashStr := hexutils.SafeAddHexPrefix(hex.EncodeToString(hash[:]))
 
-	_, err = transaction.Execute(c.hederaClient)
+	// journal the submission before sending it, so it can be audited and replayed if the process crashes in between
+	entry := newJournalEntry(hashStr, request.SignedTransaction, transaction)
+	if err := c.journal.Record(entry); err != nil {
+		log.Errorf("Failed to journal the submission of transaction %s: %s", entry.TransactionId, err)
+		return nil, transaction, errors.ErrInternalServerError
+	}
+
+	start := time.Now()
+	err = c.submitBreaker.Execute(func() error {
+		return c.broadcaster.Broadcast(transaction)
+	}, isSubmitFailure)
+	c.recordNodeHealth(transaction, err, time.Since(start))
 	if err != nil {
 		log.Errorf("Failed to execute transaction %s: %s", transaction.GetTransactionID(), err)
-		return nil, errors.ErrTransactionSubmissionFailed
+		rErr := errors.ErrTransactionSubmissionFailed
+		if err == breaker.ErrOpenState {
+			rErr = errors.ErrServiceUnavailable
+		}
+
+		var precheckErr hedera.ErrHederaPreCheckStatus
+		if goErrors.As(err, &precheckErr) {
+			rErr = errors.AddErrorDetails(rErr, errors.DetailHederaStatus, precheckErr.Status.String())
+			c.recordJournalOutcome(entry, journal.OutcomeFailed, precheckErr.Status.String())
+		} else {
+			c.recordJournalOutcome(entry, journal.OutcomeFailed, err.Error())
+		}
+		return nil, transaction, rErr
 	}
 
+	c.recordJournalOutcome(entry, journal.OutcomeSubmitted, "")
 	return &rTypes.TransactionIdentifierResponse{
-		TransactionIdentifier: &rTypes.TransactionIdentifier{
-			Hash: hexutils.SafeAddHexPrefix(hex.EncodeToString(hash[:])),
-		},
-		Metadata: nil,
+		TransactionIdentifier: &rTypes.TransactionIdentifier{Hash: hashStr},
+		Metadata:              nil,
+	}, transaction, nil
+}
+
+// recordJournalOutcome records the outcome of the journaled submission. The transaction has been sent at this point, so
+// failing to record its outcome is only logged
+func (c *constructionAPIService) recordJournalOutcome(entry journal.Entry, outcome, status string) {
+	entry.Outcome = outcome
+	entry.Status = status
+	if err := c.journal.Record(entry); err != nil {
+		log.Errorf("Failed to journal the %s outcome of transaction %s: %s", outcome, entry.TransactionId, err)
+	}
+}
+
+// recordNodeHealth records the outcome of the submit to the node the transaction is sent to. A precheck status other
+// than busy is about the transaction itself, so the node is considered healthy. Submits short-circuited by the open
+// circuit breaker never reach the node and aren't recorded
+func (c *constructionAPIService) recordNodeHealth(transaction ITransaction, err error, latency time.Duration) {
+	nodeAccountIds := transaction.GetNodeAccountIDs()
+	if c.nodeHealth == nil || err == breaker.ErrOpenState || len(nodeAccountIds) == 0 {
+		return
+	}
+
+	success := err == nil || !isSubmitFailure(err)
+	var precheckErr hedera.ErrHederaPreCheckStatus
+	if goErrors.As(err, &precheckErr) && precheckErr.Status == hedera.StatusBusy {
+		success = false
+	}
+
+	c.nodeHealth.Record(nodeAccountIds[0], success, latency)
+}
+
+// getScheduleMetadata returns the status of the schedule and the public keys which haven't signed the schedule yet
+func (c *constructionAPIService) getScheduleMetadata(
+	scheduleId string,
+	publicKeys []*rTypes.PublicKey,
+) (map[string]interface{}, *rTypes.Error) {
+	schedule, rErr := c.scheduleRepo.FindById(scheduleId)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	remainingPublicKeys := make([]string, 0, len(publicKeys))
+	for _, publicKey := range publicKeys {
+		if publicKey == nil {
+			continue
+		}
+
+		if !schedule.IsSignedBy(publicKey.Bytes) {
+			remainingPublicKeys = append(remainingPublicKeys, hex.EncodeToString(publicKey.Bytes))
+		}
+	}
+
+	return map[string]interface{}{
+		optionScheduleId:        schedule.ScheduleId.String(),
+		"schedule_status":       schedule.Status(),
+		"remaining_public_keys": remainingPublicKeys,
 	}, nil
 }
 
+// getSignatureKeyAccounts resolves the existing accounts controlled by each public key which signed the transaction.
+// It returns nil in offline mode
+func (c *constructionAPIService) getSignatureKeyAccounts(transaction ITransaction) (map[string][]string, *rTypes.Error) {
+	if c.accountRepo == nil {
+		return nil, nil
+	}
+
+	signatures, err := transaction.GetSignatures()
+	if err != nil {
+		return nil, errors.ErrTransactionUnmarshallingFailed
+	}
+
+	keyAccounts := make(map[string][]string)
+	for _, signatureMap := range signatures {
+		for pubKey := range signatureMap {
+			key := pubKey.String()
+			if _, ok := keyAccounts[key]; ok {
+				continue
+			}
+
+			accounts, rErr := c.accountRepo.FindByPublicKey(pubKey.Bytes())
+			if rErr != nil {
+				return nil, rErr
+			}
+			keyAccounts[key] = toAddresses(accounts)
+		}
+	}
+
+	return keyAccounts, nil
+}
+
+// getRandomNodeAccountId selects a random node, healthier nodes are more likely to be selected when the node health is
+// tracked
 func (c *constructionAPIService) getRandomNodeAccountId() hedera.AccountID {
+	if c.nodeHealth != nil {
+		index, err := rand.Int(rand.Reader, randomPrecision)
+		if err != nil {
+			log.Errorf("Failed to get a random number, use 0 instead: %s", err)
+			return c.nodeHealth.Select(c.nodeAccountIds, 0)
+		}
+		return c.nodeHealth.Select(c.nodeAccountIds, float64(index.Int64())/float64(randomPrecision.Int64()))
+	}
+
 	index, err := rand.Int(rand.Reader, c.nodeAccountIdsLen)
 	if err != nil {
 		log.Errorf("Failed to get a random number, use 0 instead: %s", err)
@@ -254,22 +597,46 @@ func (c *constructionAPIService) getRandomNodeAccountId() hedera.AccountID {
 	return c.nodeAccountIds[index.Int64()]
 }
 
-// NewConstructionAPIService creates a new instance of a constructionAPIService.
-func NewConstructionAPIService(
-	network string,
-	nodes types.NodeMap,
-	transactionConstructor TransactionConstructor,
-) (server.ConstructionAPIServicer, error) {
+// ConstructionAPIServiceOptions are the dependencies and the settings of a constructionAPIService. The repositories are
+// nil in offline mode
+type ConstructionAPIServiceOptions struct {
+	AccountRepo repositories.AccountRepository
+	Broadcast   types.Broadcast
+	// DefaultPayer is the account id filled in as the account of the operations without one, empty to disable it
+	DefaultPayer string
+	// ExchangeRateRepo and FeeScheduleRepo are both required for /construction/metadata to suggest the fee
+	ExchangeRateRepo repositories.ExchangeRateRepository
+	ExistenceCheck   bool
+	FeeScheduleRepo  repositories.FeeScheduleRepository
+	Journal          *journal.Journal
+	Network          string
+	// NodeHealth optionally tracks the submit outcomes
+	NodeHealth *nodehealth.Tracker
+	Nodes      types.NodeMap
+	ParseMode  string
+	// Registry optionally counts the constructed, parsed, and submitted transactions
+	Registry     *metrics.Registry
+	ScheduleRepo repositories.ScheduleRepository
+	// Settings are the settings the operations are parsed and presented with, they must be the same as the settings of
+	// the TransactionConstructor
+	Settings               *config.Settings
+	SubmitBreaker          *breaker.CircuitBreaker
+	TransactionConstructor TransactionConstructor
+}
+
+// NewConstructionAPIService creates a new instance of a constructionAPIService with the options
+func NewConstructionAPIService(options ConstructionAPIServiceOptions) (server.ConstructionAPIServicer, error) {
 	var err error
 	var hederaClient *hedera.Client
 
 	// there is no live demo network, it's only used to run rosetta test, so replace it with testnet
+	network := options.Network
 	if network == "demo" {
 		log.Info("Use testnet instead of demo")
 		network = "testnet"
 	}
 
-	if len(nodes) > 0 {
+	if nodes := options.Nodes; len(nodes) > 0 {
 		hederaClient = hedera.ClientForNetwork(nodes)
 	} else if hederaClient, err = hedera.ClientForName(network); err != nil {
 		return nil, err
@@ -281,17 +648,115 @@ func NewConstructionAPIService(
 		nodeAccountIds = append(nodeAccountIds, nodeAccountId)
 	}
 
+	broadcaster, err := NewBroadcaster(options.Broadcast, hederaClient)
+	if err != nil {
+		return nil, err
+	}
+
+	parseMode := options.ParseMode
+	switch parseMode {
+	case "":
+		parseMode = ParseModeLenient
+	case ParseModeLenient, ParseModeStrict:
+	default:
+		return nil, fmt.Errorf("unsupported parse mode %s", parseMode)
+	}
+
+	var defaultPayerId *hedera.AccountID
+	if defaultPayer := options.DefaultPayer; defaultPayer != "" {
+		accountId, err := parseAccountId(defaultPayer, options.Settings.LedgerId)
+		if err != nil || isZeroAccountId(accountId) {
+			return nil, fmt.Errorf("invalid default payer account id %s", defaultPayer)
+		}
+		defaultPayerId = &accountId
+	}
+
 	return &constructionAPIService{
-		hederaClient:       hederaClient,
+		accountRepo:        options.AccountRepo,
+		broadcaster:        broadcaster,
+		defaultPayer:       defaultPayerId,
+		exchangeRateRepo:   options.ExchangeRateRepo,
+		existenceCheck:     options.ExistenceCheck,
+		feeScheduleRepo:    options.FeeScheduleRepo,
+		journal:            options.Journal,
 		nodeAccountIds:     nodeAccountIds,
 		nodeAccountIdsLen:  big.NewInt(int64(len(nodeAccountIds))),
-		transactionHandler: transactionConstructor,
+		nodeHealth:         options.NodeHealth,
+		parseMode:          parseMode,
+		registry:           options.Registry,
+		scheduleRepo:       options.ScheduleRepo,
+		settings:           options.Settings,
+		submitBreaker:      options.SubmitBreaker,
+		transactionHandler: options.TransactionConstructor,
 	}, nil
 }
 
+// isSubmitFailure returns true if err indicates the consensus nodes are failing. A precheck status error means the
+// node has processed the transaction, so it's not a failure
+func isSubmitFailure(err error) bool {
+	var precheckErr hedera.ErrHederaPreCheckStatus
+	return !goErrors.As(err, &precheckErr)
+}
+
+// newJournalEntry creates the pending journal entry of the transaction, the node account id is the first one since the
+// frozen transaction is always sent to it first
+func newJournalEntry(hash string, signedTransaction string, transaction ITransaction) journal.Entry {
+	entry := journal.Entry{
+		Hash:              hash,
+		Outcome:           journal.OutcomePending,
+		SignedTransaction: signedTransaction,
+		Timestamp:         time.Now().UnixNano(),
+		TransactionId:     transaction.GetTransactionID().String(),
+	}
+
+	if payer := transaction.GetTransactionID().AccountID; payer != nil {
+		entry.PayerAccountId = payer.String()
+	}
+
+	if nodeAccountIds := transaction.GetNodeAccountIDs(); len(nodeAccountIds) != 0 {
+		entry.NodeAccountId = nodeAccountIds[0].String()
+	}
+
+	return entry
+}
+
+// verifySignature verifies the signature is an ed25519 signature of the frozen transaction body bytes by the claimed
+// public key, and if the signing payload is provided, that it's the payload of the transaction. It returns the public
+// key parsed from the signature
+func verifySignature(signature *rTypes.Signature, frozenBodyBytes []byte) (hedera.PublicKey, *rTypes.Error) {
+	if signature.PublicKey == nil {
+		return hedera.PublicKey{}, errors.ErrInvalidPublicKey
+	}
+
+	pubKey, err := hedera.PublicKeyFromBytes(signature.PublicKey.Bytes)
+	if err != nil {
+		return hedera.PublicKey{}, errors.ErrInvalidPublicKey
+	}
+
+	if signature.SignatureType != "" && signature.SignatureType != rTypes.Ed25519 {
+		return hedera.PublicKey{}, errors.AddErrorDetails(errors.ErrInvalidSignatureVerification, errors.DetailReason,
+			fmt.Sprintf("unsupported signature type %s", signature.SignatureType))
+	}
+
+	if signature.SigningPayload != nil && len(signature.SigningPayload.Bytes) != 0 &&
+		!bytes.Equal(signature.SigningPayload.Bytes, frozenBodyBytes) {
+		return hedera.PublicKey{}, errors.AddErrorDetails(errors.ErrInvalidSignatureVerification, errors.DetailReason,
+			"signing payload isn't the payload of the unsigned transaction")
+	}
+
+	if !ed25519.Verify(pubKey.Bytes(), frozenBodyBytes, signature.Bytes) {
+		return hedera.PublicKey{}, errors.AddErrorDetails(errors.ErrInvalidSignatureVerification, errors.DetailReason,
+			"signature doesn't verify against the payload and the public key")
+	}
+
+	return pubKey, nil
+}
+
 func addSignature(transaction ITransaction, pubKey hedera.PublicKey, signature []byte) *rTypes.Error {
 	switch tx := transaction.(type) {
 	// these transaction types are what the construction service supports
+	case *hedera.ScheduleSignTransaction:
+		tx.AddSignature(pubKey, signature)
 	case *hedera.TokenAssociateTransaction:
 		tx.AddSignature(pubKey, signature)
 	case *hedera.TokenBurnTransaction:
@@ -325,6 +790,31 @@ func addSignature(transaction ITransaction, pubKey hedera.PublicKey, signature [
 	return nil
 }
 
+// getMaxTransactionFee returns the positive max_transaction_fee option in tinybars, or zero hbar if it's not present so
+// the default max transaction fee of the operation type applies
+func getMaxTransactionFee(options map[string]interface{}) (hedera.Hbar, *rTypes.Error) {
+	fee, ok, rErr := getFeeOption(options, optionMaxTransactionFee)
+	if rErr != nil {
+		return hedera.ZeroHbar, rErr
+	}
+
+	if ok && fee == 0 {
+		return hedera.ZeroHbar, errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField,
+			optionMaxTransactionFee)
+	}
+
+	return hedera.HbarFromTinybar(fee), nil
+}
+
+func toAddresses(accounts []domainTypes.Account) []string {
+	addresses := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		addresses = append(addresses, account.String())
+	}
+
+	return addresses
+}
+
 func getFrozenTransactionBodyBytes(transaction ITransaction) ([]byte, *rTypes.Error) {
 	signedTransaction := proto.SignedTransaction{}
 	if err := prototext.Unmarshal([]byte(transaction.String()), &signedTransaction); err != nil {
@@ -334,12 +824,158 @@ func getFrozenTransactionBodyBytes(transaction ITransaction) ([]byte, *rTypes.Er
 	return signedTransaction.BodyBytes, nil
 }
 
+// sdkTransactionTypesByBodyDataType are the sdk transaction types of the supported data types of the transaction body,
+// i.e., the names of the fields of its data oneof
+var sdkTransactionTypesByBodyDataType = map[protoreflect.Name]string{
+	"cryptoTransfer":  "TransferTransaction",
+	"scheduleSign":    "ScheduleSignTransaction",
+	"tokenAssociate":  "TokenAssociateTransaction",
+	"tokenBurn":       "TokenBurnTransaction",
+	"tokenCreation":   "TokenCreateTransaction",
+	"tokenDeletion":   "TokenDeleteTransaction",
+	"tokenDissociate": "TokenDissociateTransaction",
+	"tokenFreeze":     "TokenFreezeTransaction",
+	"tokenGrantKyc":   "TokenGrantKycTransaction",
+	"tokenMint":       "TokenMintTransaction",
+	"tokenRevokeKyc":  "TokenRevokeKycTransaction",
+	"tokenUnfreeze":   "TokenUnfreezeTransaction",
+	"tokenUpdate":     "TokenUpdateTransaction",
+	"tokenWipe":       "TokenWipeTransaction",
+}
+
+// decodedTransaction is a transaction decoded once from its hex string. It holds both the sdk transaction and the frozen
+// transaction body, so the body isn't recovered by converting the sdk transaction back. The node account ids are the
+// distinct nodes the transaction is frozen for in order, since the sdk only keeps the first one
+type decodedTransaction struct {
+	body            *proto.TransactionBody
+	frozenBodyBytes []byte
+	nodeAccountIds  []hedera.AccountID
+	transaction     ITransaction
+}
+
+// getBodyDataType returns the name of the field set in the data oneof of the transaction body, or an empty name if
+// none is set
+func (d *decodedTransaction) getBodyDataType() protoreflect.Name {
+	return getBodyDataType(d.body)
+}
+
+func getBodyDataType(body *proto.TransactionBody) protoreflect.Name {
+	message := body.ProtoReflect()
+	field := message.WhichOneof(message.Descriptor().Oneofs().ByName("data"))
+	if field == nil {
+		return ""
+	}
+
+	return field.Name()
+}
+
+// decodeTransaction decodes the hex string of the serialized transaction list into the sdk transaction and the
+// transaction body of its first transaction, which is the frozen body the signatures sign. A transaction whose body
+// has an unsupported data type is rejected with the data type in the error details. A transaction frozen for multiple
+// nodes, e.g., by another sdk, has a transaction per node in the list, and is rejected unless their bodies only differ
+// in the node account id, since the operations parsed from the first body must be what any of the nodes executes
+func decodeTransaction(transactionString string) (*decodedTransaction, *rTypes.Error) {
+	transactionBytes, err := hex.DecodeString(hexutils.SafeRemoveHexPrefix(transactionString))
+	if err != nil {
+		return nil, errors.ErrTransactionDecodeFailed
+	}
+
+	transactionList := &proto.TransactionList{}
+	if err := protobuf.Unmarshal(transactionBytes, transactionList); err != nil ||
+		len(transactionList.TransactionList) == 0 {
+		return nil, errors.ErrTransactionUnmarshallingFailed
+	}
+
+	signedTransaction := &proto.SignedTransaction{}
+	signedTransactionBytes := transactionList.TransactionList[0].SignedTransactionBytes
+	if err := protobuf.Unmarshal(signedTransactionBytes, signedTransaction); err != nil {
+		return nil, errors.ErrTransactionUnmarshallingFailed
+	}
+
+	body := &proto.TransactionBody{}
+	if err := protobuf.Unmarshal(signedTransaction.BodyBytes, body); err != nil {
+		return nil, errors.ErrTransactionUnmarshallingFailed
+	}
+
+	if dataType := getBodyDataType(body); sdkTransactionTypesByBodyDataType[dataType] == "" {
+		return nil, errors.AddErrorDetails(errors.ErrTransactionTypeUnsupported, errors.DetailTransactionType,
+			string(dataType))
+	}
+
+	nodeAccountIds, rErr := getFrozenNodeAccountIds(transactionList, body)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	transaction, rErr := unmarshallTransaction(transactionBytes)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	return &decodedTransaction{
+		body:            body,
+		frozenBodyBytes: signedTransaction.BodyBytes,
+		nodeAccountIds:  nodeAccountIds,
+		transaction:     transaction,
+	}, nil
+}
+
+// getFrozenNodeAccountIds returns the distinct node account ids of the transactions in the list in order, checking the body
+// of each transaction matches the first body except for the node account id
+func getFrozenNodeAccountIds(transactionList *proto.TransactionList, first *proto.TransactionBody) (
+	[]hedera.AccountID,
+	*rTypes.Error,
+) {
+	firstWithoutNode := protobuf.Clone(first).(*proto.TransactionBody)
+	firstWithoutNode.NodeAccountID = nil
+
+	nodeAccountIds := make([]hedera.AccountID, 0, len(transactionList.TransactionList))
+	seen := make(map[string]bool)
+	for _, transaction := range transactionList.TransactionList {
+		signedTransaction := &proto.SignedTransaction{}
+		if err := protobuf.Unmarshal(transaction.SignedTransactionBytes, signedTransaction); err != nil {
+			return nil, errors.ErrTransactionUnmarshallingFailed
+		}
+
+		body := &proto.TransactionBody{}
+		if err := protobuf.Unmarshal(signedTransaction.BodyBytes, body); err != nil {
+			return nil, errors.ErrTransactionUnmarshallingFailed
+		}
+
+		nodeAccountId := body.NodeAccountID
+		body.NodeAccountID = nil
+		if !protobuf.Equal(firstWithoutNode, body) {
+			return nil, errors.ErrTransactionBodiesMismatch
+		}
+
+		if nodeAccountId == nil {
+			continue
+		}
+
+		accountId := hedera.AccountID{
+			Shard:   uint64(nodeAccountId.GetShardNum()),
+			Realm:   uint64(nodeAccountId.GetRealmNum()),
+			Account: uint64(nodeAccountId.GetAccountNum()),
+		}
+		if address := accountId.String(); !seen[address] {
+			seen[address] = true
+			nodeAccountIds = append(nodeAccountIds, accountId)
+		}
+	}
+
+	return nodeAccountIds, nil
+}
+
 func unmarshallTransactionFromHexString(transactionString string) (ITransaction, *rTypes.Error) {
 	transactionBytes, err := hex.DecodeString(hexutils.SafeRemoveHexPrefix(transactionString))
 	if err != nil {
 		return nil, errors.ErrTransactionDecodeFailed
 	}
 
+	return unmarshallTransaction(transactionBytes)
+}
+
+func unmarshallTransaction(transactionBytes []byte) (ITransaction, *rTypes.Error) {
 	transaction, err := hedera.TransactionFromBytes(transactionBytes)
 	if err != nil {
 		return nil, errors.ErrTransactionUnmarshallingFailed
@@ -347,6 +983,8 @@ func unmarshallTransactionFromHexString(transactionString string) (ITransaction,
 
 	switch tx := transaction.(type) {
 	// these transaction types are what the construction service supports
+	case hedera.ScheduleSignTransaction:
+		return &tx, nil
 	case hedera.TokenAssociateTransaction:
 		return &tx, nil
 	case hedera.TokenBurnTransaction:
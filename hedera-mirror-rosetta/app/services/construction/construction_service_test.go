@@ -21,19 +21,32 @@
 package construction
 
 import (
+	"crypto/ed25519"
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"net/http"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/breaker"
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
+	domainTypes "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/journal"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/nodehealth"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/mocks/repository"
 	hexutils "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/hex"
 	types2 "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/types"
 	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/hashgraph/hedera-sdk-go/v2/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	protobuf "google.golang.org/protobuf/proto"
 )
 
 const (
@@ -46,6 +59,7 @@ const (
 
 var (
 	defaultAccountId1 = hedera.AccountID{Account: 123352}
+	defaultBroadcast  = types2.Broadcast{}
 	defaultNodes      = types2.NodeMap{
 		"10.0.0.1:50211": hedera.AccountID{Account: 3},
 		"10.0.0.2:50211": hedera.AccountID{Account: 4},
@@ -63,7 +77,7 @@ var (
 
 func dummyConstructionCombineRequest() *types.ConstructionCombineRequest {
 	unsignedTransaction := "0x0a432a410a3d0a140a0c08feafcb840610ae86c0db03120418d8c307120218041880c2d72f2202087872180a160a090a0418d8c30710cf0f0a090a0418fec40710d00f1200"
-	signingPayloadBytes := "967f26876ad492cc27b4c384dc962f443bcc9be33cbb7add3844bc864de047340e7a78c0fbaf40ab10948dc570bbc25edb505f112d0926dffb65c93199e6d507"
+	signingPayloadBytes := "0a140a0c08feafcb840610ae86c0db03120418d8c307120218041880c2d72f2202087872180a160a090a0418d8c30710cf0f0a090a0418fec40710d00f"
 	signatureBytes := "793de745bc19dd8fe8e817891f51b8fe1e259c2e6428bd7fa075b181585a2d40e3666a7c9a1873abb5433ffe1414502836d8d37082eaf94a648b530e9fa78108"
 
 	return dummyConstructionCombineRequestWith(
@@ -251,7 +265,13 @@ func TestNewConstructionAPIService(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			actual, err := NewConstructionAPIService(tt.network, tt.nodes, &mockTransactionConstructor{})
+			actual, err := NewConstructionAPIService(ConstructionAPIServiceOptions{
+				Network:                tt.network,
+				Nodes:                  tt.nodes,
+				Broadcast:              defaultBroadcast,
+				Settings:               config.NewSettings(),
+				TransactionConstructor: &mockTransactionConstructor{},
+			})
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -261,7 +281,8 @@ func TestNewConstructionAPIService(t *testing.T) {
 
 				service := actual.(*constructionAPIService)
 				expectedNodeAccountIds := getNodeAccountIds(tt.expectedHederaNetwork)
-				assert.EqualValues(t, tt.expectedHederaNetwork, service.hederaClient.GetNetwork())
+				hederaClient := service.broadcaster.(*grpcBroadcaster).hederaClient
+				assert.EqualValues(t, tt.expectedHederaNetwork, hederaClient.GetNetwork())
 				assert.ElementsMatch(t, expectedNodeAccountIds, service.nodeAccountIds)
 				assert.Equal(t, big.NewInt(int64(len(service.nodeAccountIds))), service.nodeAccountIdsLen)
 			}
@@ -269,12 +290,154 @@ func TestNewConstructionAPIService(t *testing.T) {
 	}
 }
 
+func TestNewConstructionAPIServiceUnsupportedBroadcastType(t *testing.T) {
+	// when
+	actual, err := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:   defaultNetwork,
+		Nodes:     defaultNodes,
+		Broadcast: types2.Broadcast{Type: "pigeon"},
+		Settings:  config.NewSettings(),
+	})
+
+	// then
+	assert.Error(t, err)
+	assert.Nil(t, actual)
+}
+
+func TestConstructionSubmitWithRelayBroadcast(t *testing.T) {
+	// given:
+	relay := newCapturingServer(http.StatusOK, &capturedRequest{})
+	defer relay.Close()
+	broadcast := types2.Broadcast{Relay: types2.RelayBroadcast{Url: relay.URL}, Type: BroadcastTypeRelay}
+	request := &types.ConstructionSubmitRequest{
+		NetworkIdentifier: networkIdentifier(),
+		SignedTransaction: validSignedTransaction,
+	}
+	transaction, _, _ := getSignedTransaction(t)
+	hash, _ := transaction.GetTransactionHash()
+
+	// when:
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:   defaultNetwork,
+		Nodes:     defaultNodes,
+		Broadcast: broadcast,
+		Settings:  config.NewSettings(),
+	})
+	res, e := service.ConstructionSubmit(nil, request)
+
+	// then:
+	assert.Nil(t, e)
+	assert.Equal(t, &types.TransactionIdentifierResponse{
+		TransactionIdentifier: &types.TransactionIdentifier{
+			Hash: hexutils.SafeAddHexPrefix(hex.EncodeToString(hash)),
+		},
+	}, res)
+}
+
+func TestConstructionSubmitRecordsNodeHealth(t *testing.T) {
+	var tests = []struct {
+		name            string
+		statusCode      int
+		expectedFailure uint64
+	}{
+		{name: "Success", statusCode: http.StatusOK},
+		{name: "Failure", statusCode: http.StatusServiceUnavailable, expectedFailure: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			relay := newCapturingServer(tt.statusCode, &capturedRequest{})
+			defer relay.Close()
+			broadcast := types2.Broadcast{Relay: types2.RelayBroadcast{Url: relay.URL}, Type: BroadcastTypeRelay}
+			nodeHealth := nodehealth.NewTracker()
+			request := &types.ConstructionSubmitRequest{
+				NetworkIdentifier: networkIdentifier(),
+				SignedTransaction: validSignedTransaction,
+			}
+			transaction, _, _ := getSignedTransaction(t)
+			service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+				Network:    defaultNetwork,
+				Nodes:      defaultNodes,
+				Broadcast:  broadcast,
+				NodeHealth: nodeHealth,
+				Settings:   config.NewSettings(),
+			})
+
+			// when
+			_, _ = service.ConstructionSubmit(nil, request)
+
+			// then
+			scores := nodeHealth.Scores()
+			assert.Len(t, scores, 1)
+			assert.Equal(t, transaction.GetNodeAccountIDs()[0], scores[0].NodeAccountId)
+			assert.Equal(t, uint64(1), scores[0].Attempts)
+			assert.Equal(t, tt.expectedFailure, scores[0].Failures)
+		})
+	}
+}
+
+func TestConstructionSubmitWhenCircuitBreakerOpenDoesNotRecordNodeHealth(t *testing.T) {
+	// given
+	submitBreaker := breaker.NewCircuitBreaker("consensus nodes", 1, time.Hour)
+	_ = submitBreaker.Execute(func() error { return fmt.Errorf("timeout") }, isSubmitFailure)
+	nodeHealth := nodehealth.NewTracker()
+	request := &types.ConstructionSubmitRequest{
+		NetworkIdentifier: networkIdentifier(),
+		SignedTransaction: validSignedTransaction,
+	}
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:       defaultNetwork,
+		Nodes:         defaultNodes,
+		Broadcast:     defaultBroadcast,
+		Settings:      config.NewSettings(),
+		SubmitBreaker: submitBreaker,
+		NodeHealth:    nodeHealth,
+	})
+
+	// when
+	_, e := service.ConstructionSubmit(nil, request)
+
+	// then
+	assert.Equal(t, errors.ErrServiceUnavailable, e)
+	assert.Empty(t, nodeHealth.Scores())
+}
+
+func TestGetRandomNodeAccountIdWithNodeHealth(t *testing.T) {
+	// given
+	nodeHealth := nodehealth.NewTracker()
+	for _, nodeAccountId := range defaultNodes {
+		nodeHealth.Record(nodeAccountId, false, 0)
+	}
+	servicer, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:    defaultNetwork,
+		Nodes:      defaultNodes,
+		Broadcast:  defaultBroadcast,
+		NodeHealth: nodeHealth,
+		Settings:   config.NewSettings(),
+	})
+	service := servicer.(*constructionAPIService)
+
+	for i := 0; i < 10; i++ {
+		// when
+		actual := service.getRandomNodeAccountId()
+
+		// then
+		assert.Contains(t, service.nodeAccountIds, actual)
+	}
+}
+
 func TestConstructionCombine(t *testing.T) {
 	// given:
 	expectedConstructionCombineResponse := &types.ConstructionCombineResponse{
 		SignedTransaction: validSignedTransaction,
 	}
-	service, _ := NewConstructionAPIService(defaultNetwork, defaultNodes, nil)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:   defaultNetwork,
+		Nodes:     defaultNodes,
+		Broadcast: defaultBroadcast,
+		Settings:  config.NewSettings(),
+	})
 
 	// when:
 	res, e := service.ConstructionCombine(nil, dummyConstructionCombineRequest())
@@ -284,11 +447,72 @@ func TestConstructionCombine(t *testing.T) {
 	assert.Nil(t, e)
 }
 
+func TestConstructionCombineSignaturesVerifyForEachBody(t *testing.T) {
+	// given
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:   defaultNetwork,
+		Nodes:     defaultNodes,
+		Broadcast: defaultBroadcast,
+		Settings:  config.NewSettings(),
+	})
+
+	// when
+	res, e := service.ConstructionCombine(nil, dummyConstructionCombineRequest())
+
+	// then
+	assert.Nil(t, e)
+	transactionBytes, _ := hex.DecodeString(hexutils.SafeRemoveHexPrefix(res.SignedTransaction))
+	transactionList := &proto.TransactionList{}
+	assert.NoError(t, protobuf.Unmarshal(transactionBytes, transactionList))
+	assert.Len(t, transactionList.TransactionList, 1)
+	for _, transaction := range transactionList.TransactionList {
+		signedTransaction := &proto.SignedTransaction{}
+		assert.NoError(t, protobuf.Unmarshal(transaction.SignedTransactionBytes, signedTransaction))
+		assert.NotEmpty(t, signedTransaction.SigMap.SigPair)
+		for _, sigPair := range signedTransaction.SigMap.SigPair {
+			assert.True(t, ed25519.Verify(sigPair.PubKeyPrefix, signedTransaction.BodyBytes, sigPair.GetEd25519()))
+		}
+	}
+}
+
+func TestConstructionCombineThrowsWithMultipleNodes(t *testing.T) {
+	// given
+	transactionBytes := newMultiNodeTransaction([]hedera.AccountID{nodeAccountId, {Account: 4}}, 5)
+	transactionList := &proto.TransactionList{}
+	_ = protobuf.Unmarshal(transactionBytes, transactionList)
+	signedTransaction := &proto.SignedTransaction{}
+	_ = protobuf.Unmarshal(transactionList.TransactionList[0].SignedTransactionBytes, signedTransaction)
+	request := dummyConstructionCombineRequestWith(
+		hexutils.SafeAddHexPrefix(hex.EncodeToString(transactionBytes)),
+		hex.EncodeToString(signedTransaction.BodyBytes),
+		hex.EncodeToString(privateKey.PublicKey().Bytes()),
+		hex.EncodeToString(privateKey.Sign(signedTransaction.BodyBytes)),
+	)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:   defaultNetwork,
+		Nodes:     defaultNodes,
+		Broadcast: defaultBroadcast,
+		Settings:  config.NewSettings(),
+	})
+
+	// when
+	res, e := service.ConstructionCombine(nil, request)
+
+	// then
+	assert.Nil(t, res)
+	assert.Equal(t, errors.ErrMultipleNodesUnsupported, e)
+}
+
 func TestConstructionCombineThrowsWithNoSignature(t *testing.T) {
 	// given
 	request := dummyConstructionCombineRequest()
 	request.Signatures = []*types.Signature{}
-	service, _ := NewConstructionAPIService(defaultNetwork, defaultNodes, nil)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:   defaultNetwork,
+		Nodes:     defaultNodes,
+		Broadcast: defaultBroadcast,
+		Settings:  config.NewSettings(),
+	})
 
 	// when
 	res, e := service.ConstructionCombine(nil, request)
@@ -305,7 +529,12 @@ func TestConstructionCombineThrowsWhenDecodeStringFails(t *testing.T) {
 	exampleCorruptedTxHexStrConstructionCombineRequest.UnsignedTransaction = invalidTransaction
 
 	// when:
-	service, _ := NewConstructionAPIService(defaultNetwork, defaultNodes, nil)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:   defaultNetwork,
+		Nodes:     defaultNodes,
+		Broadcast: defaultBroadcast,
+		Settings:  config.NewSettings(),
+	})
 	res, e := service.ConstructionCombine(nil, exampleCorruptedTxHexStrConstructionCombineRequest)
 
 	// then:
@@ -319,7 +548,12 @@ func TestConstructionCombineThrowsWhenUnmarshallFails(t *testing.T) {
 	exampleCorruptedTxHexStrConstructionCombineRequest.UnsignedTransaction = corruptedTransaction
 
 	// when:
-	service, _ := NewConstructionAPIService(defaultNetwork, defaultNodes, nil)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:   defaultNetwork,
+		Nodes:     defaultNodes,
+		Broadcast: defaultBroadcast,
+		Settings:  config.NewSettings(),
+	})
 	res, e := service.ConstructionCombine(nil, exampleCorruptedTxHexStrConstructionCombineRequest)
 
 	// then:
@@ -327,18 +561,118 @@ func TestConstructionCombineThrowsWhenUnmarshallFails(t *testing.T) {
 	assert.Equal(t, errors.ErrTransactionUnmarshallingFailed, e)
 }
 
+func TestConstructionSubmitThrowsWhenCircuitBreakerOpen(t *testing.T) {
+	// given:
+	submitBreaker := breaker.NewCircuitBreaker("consensus nodes", 1, time.Hour)
+	_ = submitBreaker.Execute(func() error { return fmt.Errorf("timeout") }, isSubmitFailure)
+	exampleConstructionSubmitRequest := &types.ConstructionSubmitRequest{
+		NetworkIdentifier: networkIdentifier(),
+		SignedTransaction: validSignedTransaction,
+	}
+
+	// when:
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:       defaultNetwork,
+		Nodes:         defaultNodes,
+		Broadcast:     defaultBroadcast,
+		Settings:      config.NewSettings(),
+		SubmitBreaker: submitBreaker,
+	})
+	res, e := service.ConstructionSubmit(nil, exampleConstructionSubmitRequest)
+
+	// then:
+	assert.Nil(t, res)
+	assert.Equal(t, errors.ErrServiceUnavailable, e)
+}
+
+func TestConstructionSubmitJournalsSubmission(t *testing.T) {
+	// given:
+	submissionJournal, err := journal.Open(filepath.Join(t.TempDir(), "submissions.jsonl"), 10)
+	assert.NoError(t, err)
+	defer submissionJournal.Close()
+	submitBreaker := breaker.NewCircuitBreaker("consensus nodes", 1, time.Hour)
+	_ = submitBreaker.Execute(func() error { return fmt.Errorf("timeout") }, isSubmitFailure)
+	request := &types.ConstructionSubmitRequest{
+		NetworkIdentifier: networkIdentifier(),
+		SignedTransaction: validSignedTransaction,
+	}
+	transaction, _ := unmarshallTransactionFromHexString(validSignedTransaction)
+	hash, _ := transaction.GetTransactionHash()
+	expectedHash := hexutils.SafeAddHexPrefix(hex.EncodeToString(hash[:]))
+
+	// when:
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:       defaultNetwork,
+		Nodes:         defaultNodes,
+		Broadcast:     defaultBroadcast,
+		Settings:      config.NewSettings(),
+		SubmitBreaker: submitBreaker,
+		Journal:       submissionJournal,
+	})
+	_, e := service.ConstructionSubmit(nil, request)
+
+	// then:
+	assert.Equal(t, errors.ErrServiceUnavailable, e)
+	entries := submissionJournal.List(journal.Filter{}, 0)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, expectedHash, entries[0].Hash)
+	assert.Equal(t, transaction.GetNodeAccountIDs()[0].String(), entries[0].NodeAccountId)
+	assert.Equal(t, journal.OutcomeFailed, entries[0].Outcome)
+	assert.Equal(t, transaction.GetTransactionID().AccountID.String(), entries[0].PayerAccountId)
+	assert.Equal(t, validSignedTransaction, entries[0].SignedTransaction)
+	assert.Equal(t, breaker.ErrOpenState.Error(), entries[0].Status)
+	assert.Equal(t, transaction.GetTransactionID().String(), entries[0].TransactionId)
+	assert.NotZero(t, entries[0].Timestamp)
+}
+
+func TestConstructionSubmitThrowsWhenJournalFails(t *testing.T) {
+	// given:
+	submissionJournal, err := journal.Open(filepath.Join(t.TempDir(), "submissions.jsonl"), 10)
+	assert.NoError(t, err)
+	assert.NoError(t, submissionJournal.Close())
+	request := &types.ConstructionSubmitRequest{
+		NetworkIdentifier: networkIdentifier(),
+		SignedTransaction: validSignedTransaction,
+	}
+
+	// when:
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:   defaultNetwork,
+		Nodes:     defaultNodes,
+		Broadcast: defaultBroadcast,
+		Journal:   submissionJournal,
+		Settings:  config.NewSettings(),
+	})
+	res, e := service.ConstructionSubmit(nil, request)
+
+	// then:
+	assert.Nil(t, res)
+	assert.Equal(t, errors.ErrInternalServerError, e)
+	assert.Empty(t, submissionJournal.List(journal.Filter{}, 0))
+}
+
+func TestIsSubmitFailure(t *testing.T) {
+	assert.True(t, isSubmitFailure(fmt.Errorf("timeout")))
+	assert.False(t, isSubmitFailure(hedera.ErrHederaPreCheckStatus{Status: hedera.StatusInvalidSignature}))
+}
+
 func TestConstructionCombineThrowsWithInvalidPublicKey(t *testing.T) {
 	// given:
 	exampleInvalidPublicKeyConstructionCombineRequest := dummyConstructionCombineRequest()
 	exampleInvalidPublicKeyConstructionCombineRequest.Signatures[0].PublicKey = &types.PublicKey{}
 
 	// when:
-	service, _ := NewConstructionAPIService(defaultNetwork, defaultNodes, nil)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:   defaultNetwork,
+		Nodes:     defaultNodes,
+		Broadcast: defaultBroadcast,
+		Settings:  config.NewSettings(),
+	})
 	res, e := service.ConstructionCombine(nil, exampleInvalidPublicKeyConstructionCombineRequest)
 
 	// then:
 	assert.Nil(t, res)
-	assert.Equal(t, errors.ErrInvalidPublicKey, e)
+	assert.Equal(t, errors.AddErrorDetails(errors.ErrInvalidPublicKey, errors.DetailIndex, 0), e)
 }
 
 func TestConstructionCombineThrowsWithInvalidSignature(t *testing.T) {
@@ -347,12 +681,90 @@ func TestConstructionCombineThrowsWithInvalidSignature(t *testing.T) {
 	exampleInvalidSigningPayloadConstructionCombineRequest.Signatures[0].Bytes = []byte("bad signature")
 
 	// when:
-	service, _ := NewConstructionAPIService(defaultNetwork, defaultNodes, nil)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:   defaultNetwork,
+		Nodes:     defaultNodes,
+		Broadcast: defaultBroadcast,
+		Settings:  config.NewSettings(),
+	})
 	res, e := service.ConstructionCombine(nil, exampleInvalidSigningPayloadConstructionCombineRequest)
 
 	// then:
+	expected := errors.AddErrorDetails(errors.ErrInvalidSignatureVerification, errors.DetailIndex, 0)
+	expected = errors.AddErrorDetails(expected, errors.DetailReason,
+		"signature doesn't verify against the payload and the public key")
 	assert.Nil(t, res)
-	assert.Equal(t, errors.ErrInvalidSignatureVerification, e)
+	assert.Equal(t, expected, e)
+}
+
+func TestConstructionCombineThrowsWithInvalidSignatureAtIndex(t *testing.T) {
+	var tests = []struct {
+		name           string
+		updateRequest  func(signature *types.Signature)
+		expectedError  *types.Error
+		expectedReason string
+	}{
+		{
+			name: "NilPublicKey",
+			updateRequest: func(signature *types.Signature) {
+				signature.PublicKey = nil
+			},
+			expectedError: errors.ErrInvalidPublicKey,
+		},
+		{
+			name: "UnsupportedSignatureType",
+			updateRequest: func(signature *types.Signature) {
+				signature.SignatureType = types.Ecdsa
+			},
+			expectedError:  errors.ErrInvalidSignatureVerification,
+			expectedReason: "unsupported signature type ecdsa",
+		},
+		{
+			name: "SigningPayloadMismatch",
+			updateRequest: func(signature *types.Signature) {
+				signature.SigningPayload.Bytes = []byte{0x1, 0x2}
+			},
+			expectedError:  errors.ErrInvalidSignatureVerification,
+			expectedReason: "signing payload isn't the payload of the unsigned transaction",
+		},
+		{
+			name: "SignatureOfOtherPayload",
+			updateRequest: func(signature *types.Signature) {
+				signature.Bytes = make([]byte, ed25519.SignatureSize)
+			},
+			expectedError:  errors.ErrInvalidSignatureVerification,
+			expectedReason: "signature doesn't verify against the payload and the public key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			request := dummyConstructionCombineRequest()
+			signature := *request.Signatures[0]
+			signingPayload := *signature.SigningPayload
+			signature.SigningPayload = &signingPayload
+			tt.updateRequest(&signature)
+			request.Signatures = append(request.Signatures, &signature)
+			service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+				Network:   defaultNetwork,
+				Nodes:     defaultNodes,
+				Broadcast: defaultBroadcast,
+				Settings:  config.NewSettings(),
+			})
+
+			// when
+			res, e := service.ConstructionCombine(nil, request)
+
+			// then
+			expected := errors.AddErrorDetails(tt.expectedError, errors.DetailIndex, 1)
+			if tt.expectedReason != "" {
+				expected = errors.AddErrorDetails(expected, errors.DetailReason, tt.expectedReason)
+			}
+			assert.Nil(t, res)
+			assert.Equal(t, expected, e)
+		})
+	}
 }
 
 func TestConstructionCombineThrowsWithInvalidTransactionType(t *testing.T) {
@@ -361,17 +773,28 @@ func TestConstructionCombineThrowsWithInvalidTransactionType(t *testing.T) {
 	exampleInvalidTransactionTypeConstructionCombineRequest.UnsignedTransaction = invalidTypeTransaction
 
 	// when:
-	service, _ := NewConstructionAPIService(defaultNetwork, defaultNodes, nil)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:   defaultNetwork,
+		Nodes:     defaultNodes,
+		Broadcast: defaultBroadcast,
+		Settings:  config.NewSettings(),
+	})
 	res, e := service.ConstructionCombine(nil, exampleInvalidTransactionTypeConstructionCombineRequest)
 
 	// then:
 	assert.Nil(t, res)
-	assert.Equal(t, errors.ErrTransactionInvalidType, e)
+	assert.Equal(t, errors.AddErrorDetails(errors.ErrTransactionTypeUnsupported, errors.DetailTransactionType,
+		"consensusCreateTopic"), e)
 }
 
 func TestConstructionDerive(t *testing.T) {
 	// given
-	service, _ := NewConstructionAPIService(defaultNetwork, defaultNodes, nil)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:   defaultNetwork,
+		Nodes:     defaultNodes,
+		Broadcast: defaultBroadcast,
+		Settings:  config.NewSettings(),
+	})
 
 	// when:
 	res, e := service.ConstructionDerive(nil, nil)
@@ -381,6 +804,77 @@ func TestConstructionDerive(t *testing.T) {
 	assert.Equal(t, errors.ErrNotImplemented, e)
 }
 
+func TestConstructionDeriveOnline(t *testing.T) {
+	account1, _ := domainTypes.AccountFromString(defaultCryptoAccountId1)
+	account2, _ := domainTypes.AccountFromString(defaultCryptoAccountId2)
+	publicKeyBytes, _ := hex.DecodeString(publicKeyStr)
+
+	var tests = []struct {
+		name      string
+		publicKey *types.PublicKey
+		accounts  []domainTypes.Account
+		repoErr   *types.Error
+		expected  *types.ConstructionDeriveResponse
+		expectErr *types.Error
+	}{
+		{
+			name:      "Success",
+			publicKey: &types.PublicKey{Bytes: publicKeyBytes, CurveType: types.Edwards25519},
+			accounts:  []domainTypes.Account{account1, account2},
+			expected: &types.ConstructionDeriveResponse{
+				AccountIdentifier: &types.AccountIdentifier{Address: defaultCryptoAccountId1},
+				Metadata: map[string]interface{}{
+					"accounts": []string{defaultCryptoAccountId1, defaultCryptoAccountId2},
+				},
+			},
+		},
+		{
+			name:      "AccountNotFound",
+			publicKey: &types.PublicKey{Bytes: publicKeyBytes, CurveType: types.Edwards25519},
+			accounts:  []domainTypes.Account{},
+			expectErr: errors.ErrAccountNotFound,
+		},
+		{
+			name:      "RepoError",
+			publicKey: &types.PublicKey{Bytes: publicKeyBytes, CurveType: types.Edwards25519},
+			accounts:  []domainTypes.Account{},
+			repoErr:   errors.ErrDatabaseError,
+			expectErr: errors.ErrDatabaseError,
+		},
+		{
+			name:      "InvalidPublicKey",
+			publicKey: &types.PublicKey{Bytes: []byte{0x1, 0x2}, CurveType: types.Edwards25519},
+			expectErr: errors.ErrInvalidPublicKey,
+		},
+		{
+			name:      "NilPublicKey",
+			expectErr: errors.ErrInvalidPublicKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			mockAccountRepo := &repository.MockAccountRepository{}
+			mockAccountRepo.On("FindByPublicKey").Return(tt.accounts, tt.repoErr)
+			service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+				AccountRepo: mockAccountRepo,
+				Network:     defaultNetwork,
+				Nodes:       defaultNodes,
+				Broadcast:   defaultBroadcast,
+				Settings:    config.NewSettings(),
+			})
+
+			// when
+			res, e := service.ConstructionDerive(nil, &types.ConstructionDeriveRequest{PublicKey: tt.publicKey})
+
+			// then
+			assert.Equal(t, tt.expected, res)
+			assert.Equal(t, tt.expectErr, e)
+		})
+	}
+}
+
 func TestConstructionHash(t *testing.T) {
 	// given:
 	expectedHash := "0xc371b00f25490004c01b7d50350aecacaf7569ca564955465aa2b48fafd68dda5f7f277465a5f1b862f438e630c30648"
@@ -390,7 +884,12 @@ func TestConstructionHash(t *testing.T) {
 	}
 
 	// when:
-	service, _ := NewConstructionAPIService(defaultNetwork, defaultNodes, nil)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:   defaultNetwork,
+		Nodes:     defaultNodes,
+		Broadcast: defaultBroadcast,
+		Settings:  config.NewSettings(),
+	})
 	res, e := service.ConstructionHash(nil, exampleConstructionHashRequest)
 
 	// then:
@@ -403,7 +902,12 @@ func TestConstructionHashThrowsWhenDecodeStringFails(t *testing.T) {
 	exampleConstructionHashRequest := dummyConstructionHashRequest(invalidTransaction)
 
 	// when:
-	service, _ := NewConstructionAPIService(defaultNetwork, defaultNodes, nil)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:   defaultNetwork,
+		Nodes:     defaultNodes,
+		Broadcast: defaultBroadcast,
+		Settings:  config.NewSettings(),
+	})
 	res, e := service.ConstructionHash(nil, exampleConstructionHashRequest)
 
 	// then:
@@ -418,7 +922,12 @@ func TestConstructionMetadata(t *testing.T) {
 	}
 
 	// when:
-	service, _ := NewConstructionAPIService(defaultNetwork, defaultNodes, nil)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:   defaultNetwork,
+		Nodes:     defaultNodes,
+		Broadcast: defaultBroadcast,
+		Settings:  config.NewSettings(),
+	})
 	res, e := service.ConstructionMetadata(nil, nil)
 
 	// then:
@@ -426,6 +935,94 @@ func TestConstructionMetadata(t *testing.T) {
 	assert.Nil(t, e)
 }
 
+func TestConstructionMetadataWithMaxTransactionFee(t *testing.T) {
+	// given:
+	expected := &types.ConstructionMetadataResponse{
+		Metadata: map[string]interface{}{"max_transaction_fee": "50000000"},
+	}
+	request := &types.ConstructionMetadataRequest{
+		NetworkIdentifier: networkIdentifier(),
+		Options:           map[string]interface{}{"max_transaction_fee": "50000000"},
+	}
+
+	// when:
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:   defaultNetwork,
+		Nodes:     defaultNodes,
+		Broadcast: defaultBroadcast,
+		Settings:  config.NewSettings(),
+	})
+	res, e := service.ConstructionMetadata(nil, request)
+
+	// then:
+	assert.Equal(t, expected, res)
+	assert.Nil(t, e)
+}
+
+func TestConstructionMetadataWithSchedule(t *testing.T) {
+	// given:
+	signedKey := []byte{0x1, 0x2, 0x3}
+	unsignedKey := []byte{0x4, 0x5, 0x6}
+	schedule := &domainTypes.Schedule{
+		ScheduleId: entityid.EntityId{EntityNum: 1500, EncodedId: 1500},
+		Signatures: [][]byte{{0x1, 0x2}},
+	}
+	expectedResponse := &types.ConstructionMetadataResponse{
+		Metadata: map[string]interface{}{
+			"schedule_id":           "0.0.1500",
+			"schedule_status":       domainTypes.ScheduleStatusPending,
+			"remaining_public_keys": []string{hex.EncodeToString(unsignedKey)},
+		},
+	}
+	mockScheduleRepo := &repository.MockScheduleRepository{}
+	mockScheduleRepo.On("FindById", "0.0.1500").Return(schedule, repository.NilError)
+	request := &types.ConstructionMetadataRequest{
+		Options: map[string]interface{}{"schedule_id": "0.0.1500"},
+		PublicKeys: []*types.PublicKey{
+			{Bytes: signedKey, CurveType: types.Edwards25519},
+			{Bytes: unsignedKey, CurveType: types.Edwards25519},
+		},
+	}
+
+	// when:
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		ScheduleRepo: mockScheduleRepo,
+		Network:      defaultNetwork,
+		Nodes:        defaultNodes,
+		Broadcast:    defaultBroadcast,
+		Settings:     config.NewSettings(),
+	})
+	res, e := service.ConstructionMetadata(nil, request)
+
+	// then:
+	assert.Equal(t, expectedResponse, res)
+	assert.Nil(t, e)
+	mockScheduleRepo.AssertExpectations(t)
+}
+
+func TestConstructionMetadataWithScheduleNotFound(t *testing.T) {
+	// given:
+	mockScheduleRepo := &repository.MockScheduleRepository{}
+	mockScheduleRepo.On("FindById", "0.0.1500").Return(repository.NilSchedule, errors.ErrScheduleNotFound)
+	request := &types.ConstructionMetadataRequest{
+		Options: map[string]interface{}{"schedule_id": "0.0.1500"},
+	}
+
+	// when:
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		ScheduleRepo: mockScheduleRepo,
+		Network:      defaultNetwork,
+		Nodes:        defaultNodes,
+		Broadcast:    defaultBroadcast,
+		Settings:     config.NewSettings(),
+	})
+	res, e := service.ConstructionMetadata(nil, request)
+
+	// then:
+	assert.Equal(t, errors.ErrScheduleNotFound, e)
+	assert.Nil(t, res)
+}
+
 func TestConstructionParse(t *testing.T) {
 	var tests = []struct {
 		name    string
@@ -459,7 +1056,13 @@ func TestConstructionParse(t *testing.T) {
 			mockConstructor.
 				On("Parse", mock.IsType(&hedera.TransferTransaction{})).
 				Return(operations, []hedera.AccountID{defaultAccountId1}, nilError)
-			service, _ := NewConstructionAPIService(defaultNetwork, defaultNodes, mockConstructor)
+			service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+				Network:                defaultNetwork,
+				Nodes:                  defaultNodes,
+				Broadcast:              defaultBroadcast,
+				Settings:               config.NewSettings(),
+				TransactionConstructor: mockConstructor,
+			})
 
 			// when:
 			res, e := service.ConstructionParse(nil, request)
@@ -472,13 +1075,59 @@ func TestConstructionParse(t *testing.T) {
 	}
 }
 
+func TestConstructionParseSignedWithKeyAccounts(t *testing.T) {
+	// given
+	account1, _ := domainTypes.AccountFromString(defaultCryptoAccountId1)
+	request := dummyConstructionParseRequest(validSignedTransaction, true)
+	operations := []*types.Operation{
+		dummyOperation(0, "CRYPTOTRANSFER", defaultCryptoAccountId1, defaultSendAmount),
+		dummyOperation(1, "CRYPTOTRANSFER", defaultCryptoAccountId2, defaultReceiveAmount),
+	}
+	publicKey, _ := hedera.PublicKeyFromString(publicKeyStr)
+	expected := &types.ConstructionParseResponse{
+		Operations:               operations,
+		AccountIdentifierSigners: []*types.AccountIdentifier{{Address: defaultCryptoAccountId1}},
+		Metadata: map[string]interface{}{
+			"key_accounts": map[string][]string{publicKey.String(): {defaultCryptoAccountId1}},
+		},
+	}
+	mockAccountRepo := &repository.MockAccountRepository{}
+	mockAccountRepo.On("FindByPublicKey").Return([]domainTypes.Account{account1}, nilError)
+	mockConstructor := &mockTransactionConstructor{}
+	mockConstructor.
+		On("Parse", mock.IsType(&hedera.TransferTransaction{})).
+		Return(operations, []hedera.AccountID{defaultAccountId1}, nilError)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		AccountRepo:            mockAccountRepo,
+		Network:                defaultNetwork,
+		Nodes:                  defaultNodes,
+		Broadcast:              defaultBroadcast,
+		Settings:               config.NewSettings(),
+		TransactionConstructor: mockConstructor,
+	})
+
+	// when
+	res, e := service.ConstructionParse(nil, request)
+
+	// then
+	assert.Equal(t, expected, res)
+	assert.Nil(t, e)
+	mockAccountRepo.AssertExpectations(t)
+}
+
 func TestConstructionParseThrowsWhenConstructorParseFails(t *testing.T) {
 	// given
 	mockConstructor := &mockTransactionConstructor{}
 	mockConstructor.
 		On("Parse", mock.IsType(&hedera.TransferTransaction{})).
 		Return(nilOperations, nilSigners, errors.ErrInternalServerError)
-	service, _ := NewConstructionAPIService(defaultNetwork, defaultNodes, mockConstructor)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:                defaultNetwork,
+		Nodes:                  defaultNodes,
+		Broadcast:              defaultBroadcast,
+		Settings:               config.NewSettings(),
+		TransactionConstructor: mockConstructor,
+	})
 
 	// when
 	res, e := service.ConstructionParse(nil, dummyConstructionParseRequest(validSignedTransaction, false))
@@ -492,7 +1141,13 @@ func TestConstructionParseThrowsWhenConstructorParseFails(t *testing.T) {
 func TestConstructionParseThrowsWhenDecodeStringFails(t *testing.T) {
 	// given
 	mockConstructor := &mockTransactionConstructor{}
-	service, _ := NewConstructionAPIService(defaultNetwork, defaultNodes, mockConstructor)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:                defaultNetwork,
+		Nodes:                  defaultNodes,
+		Broadcast:              defaultBroadcast,
+		Settings:               config.NewSettings(),
+		TransactionConstructor: mockConstructor,
+	})
 
 	// when
 	res, e := service.ConstructionParse(nil, dummyConstructionParseRequest(invalidTransaction, false))
@@ -505,7 +1160,13 @@ func TestConstructionParseThrowsWhenDecodeStringFails(t *testing.T) {
 func TestConstructionParseThrowsWhenUnmarshallFails(t *testing.T) {
 	// given
 	mockConstructor := &mockTransactionConstructor{}
-	service, _ := NewConstructionAPIService(defaultNetwork, defaultNodes, mockConstructor)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:                defaultNetwork,
+		Nodes:                  defaultNodes,
+		Broadcast:              defaultBroadcast,
+		Settings:               config.NewSettings(),
+		TransactionConstructor: mockConstructor,
+	})
 
 	// when
 	res, e := service.ConstructionParse(nil, dummyConstructionParseRequest(corruptedTransaction, false))
@@ -515,6 +1176,101 @@ func TestConstructionParseThrowsWhenUnmarshallFails(t *testing.T) {
 	assert.Equal(t, errors.ErrTransactionUnmarshallingFailed, e)
 }
 
+func TestConstructionParseThrowsWithUnsupportedTransactionType(t *testing.T) {
+	// given
+	mockConstructor := &mockTransactionConstructor{}
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:                defaultNetwork,
+		Nodes:                  defaultNodes,
+		Broadcast:              defaultBroadcast,
+		Settings:               config.NewSettings(),
+		TransactionConstructor: mockConstructor,
+	})
+
+	// when
+	res, e := service.ConstructionParse(nil, dummyConstructionParseRequest(invalidTypeTransaction, false))
+
+	// then
+	assert.Nil(t, res)
+	assert.Equal(t, errors.AddErrorDetails(errors.ErrTransactionTypeUnsupported, errors.DetailTransactionType,
+		"consensusCreateTopic"), e)
+	mockConstructor.AssertNotCalled(t, "Parse", mock.Anything)
+}
+
+func newMultiNodeTransaction(nodeAccountIds []hedera.AccountID, amount int64) []byte {
+	transaction, _ := hedera.NewTransferTransaction().
+		AddHbarTransfer(defaultAccountId1, hedera.HbarFromTinybar(-amount)).
+		AddHbarTransfer(payerId, hedera.HbarFromTinybar(amount)).
+		SetNodeAccountIDs(nodeAccountIds).
+		SetTransactionID(hedera.TransactionIDGenerate(payerId)).
+		Freeze()
+	transactionBytes, _ := transaction.ToBytes()
+	return transactionBytes
+}
+
+func TestConstructionParseMultipleNodes(t *testing.T) {
+	// given
+	otherNodeAccountId := hedera.AccountID{Account: 4}
+	transactionBytes := newMultiNodeTransaction([]hedera.AccountID{nodeAccountId, otherNodeAccountId}, 5)
+	request := dummyConstructionParseRequest(hexutils.SafeAddHexPrefix(hex.EncodeToString(transactionBytes)), false)
+	operations := []*types.Operation{
+		dummyOperation(0, "CRYPTOTRANSFER", defaultCryptoAccountId1, defaultSendAmount),
+		dummyOperation(1, "CRYPTOTRANSFER", defaultCryptoAccountId2, defaultReceiveAmount),
+	}
+	expected := &types.ConstructionParseResponse{
+		Operations:               operations,
+		AccountIdentifierSigners: []*types.AccountIdentifier{},
+		Metadata: map[string]interface{}{
+			"node_account_ids": []string{nodeAccountId.String(), otherNodeAccountId.String()},
+		},
+	}
+	mockConstructor := &mockTransactionConstructor{}
+	mockConstructor.
+		On("Parse", mock.IsType(&hedera.TransferTransaction{})).
+		Return(operations, []hedera.AccountID{defaultAccountId1}, nilError)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:                defaultNetwork,
+		Nodes:                  defaultNodes,
+		Broadcast:              defaultBroadcast,
+		Settings:               config.NewSettings(),
+		TransactionConstructor: mockConstructor,
+	})
+
+	// when
+	res, e := service.ConstructionParse(nil, request)
+
+	// then
+	assert.Nil(t, e)
+	assert.Equal(t, expected, res)
+}
+
+func TestConstructionParseThrowsWithMismatchedNodeTransactions(t *testing.T) {
+	// given
+	first := &proto.TransactionList{}
+	_ = protobuf.Unmarshal(newMultiNodeTransaction([]hedera.AccountID{nodeAccountId}, 5), first)
+	second := &proto.TransactionList{}
+	_ = protobuf.Unmarshal(newMultiNodeTransaction([]hedera.AccountID{{Account: 4}}, 50), second)
+	first.TransactionList = append(first.TransactionList, second.TransactionList...)
+	transactionBytes, _ := protobuf.Marshal(first)
+	request := dummyConstructionParseRequest(hexutils.SafeAddHexPrefix(hex.EncodeToString(transactionBytes)), false)
+	mockConstructor := &mockTransactionConstructor{}
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:                defaultNetwork,
+		Nodes:                  defaultNodes,
+		Broadcast:              defaultBroadcast,
+		Settings:               config.NewSettings(),
+		TransactionConstructor: mockConstructor,
+	})
+
+	// when
+	res, e := service.ConstructionParse(nil, request)
+
+	// then
+	assert.Nil(t, res)
+	assert.Equal(t, errors.ErrTransactionBodiesMismatch, e)
+	mockConstructor.AssertNotCalled(t, "Parse", mock.Anything)
+}
+
 func TestConstructionPayloads(t *testing.T) {
 	// given
 	operations := []*types.Operation{
@@ -540,9 +1296,15 @@ func TestConstructionPayloads(t *testing.T) {
 		Freeze()
 	mockConstructor := &mockTransactionConstructor{}
 	mockConstructor.
-		On("Construct", mock.IsType(hedera.AccountID{}), mock.IsType([]*types.Operation{})).
+		On("Construct", mock.IsType(hedera.AccountID{}), mock.IsType([]*types.Operation{}), hedera.ZeroHbar).
 		Return(transaction, []hedera.AccountID{defaultAccountId1}, nilErr)
-	service, _ := NewConstructionAPIService(defaultNetwork, defaultNodes, mockConstructor)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:                defaultNetwork,
+		Nodes:                  defaultNodes,
+		Broadcast:              defaultBroadcast,
+		Settings:               config.NewSettings(),
+		TransactionConstructor: mockConstructor,
+	})
 
 	// when
 	actual, e := service.ConstructionPayloads(nil, dummyPayloadsRequest(operations))
@@ -552,6 +1314,101 @@ func TestConstructionPayloads(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func TestConstructionPayloadsThrowsWithMultipleNodes(t *testing.T) {
+	// given
+	operations := []*types.Operation{
+		dummyOperation(0, "CRYPTOTRANSFER", defaultCryptoAccountId1, defaultSendAmount),
+		dummyOperation(1, "CRYPTOTRANSFER", defaultCryptoAccountId2, defaultReceiveAmount),
+	}
+	transaction, _ := hedera.NewTransferTransaction().
+		SetNodeAccountIDs([]hedera.AccountID{nodeAccountId, {Account: 4}}).
+		SetTransactionID(hedera.TransactionIDGenerate(defaultAccountId1)).
+		Freeze()
+	mockConstructor := &mockTransactionConstructor{}
+	mockConstructor.
+		On("Construct", mock.IsType(hedera.AccountID{}), mock.IsType([]*types.Operation{}), hedera.ZeroHbar).
+		Return(transaction, []hedera.AccountID{defaultAccountId1}, nilErr)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:                defaultNetwork,
+		Nodes:                  defaultNodes,
+		Broadcast:              defaultBroadcast,
+		Settings:               config.NewSettings(),
+		TransactionConstructor: mockConstructor,
+	})
+
+	// when
+	actual, e := service.ConstructionPayloads(nil, dummyPayloadsRequest(operations))
+
+	// then
+	assert.Nil(t, actual)
+	assert.Equal(t, errors.ErrMultipleNodesUnsupported, e)
+}
+
+func TestConstructionPayloadsWithMaxTransactionFee(t *testing.T) {
+	for _, maxTransactionFee := range []interface{}{"50000000", float64(50000000)} {
+		// given
+		operations := []*types.Operation{
+			dummyOperation(0, "CRYPTOTRANSFER", defaultCryptoAccountId1, defaultSendAmount),
+			dummyOperation(1, "CRYPTOTRANSFER", defaultCryptoAccountId2, defaultReceiveAmount),
+		}
+		request := dummyPayloadsRequest(operations)
+		request.Metadata = map[string]interface{}{"max_transaction_fee": maxTransactionFee}
+		transaction, _ := hedera.NewTransferTransaction().
+			SetMaxTransactionFee(hedera.HbarFromTinybar(50000000)).
+			SetNodeAccountIDs([]hedera.AccountID{nodeAccountId}).
+			SetTransactionID(hedera.TransactionIDGenerate(defaultAccountId1)).
+			Freeze()
+		mockConstructor := &mockTransactionConstructor{}
+		mockConstructor.
+			On("Construct", mock.IsType(hedera.AccountID{}), operations, hedera.HbarFromTinybar(50000000)).
+			Return(transaction, []hedera.AccountID{defaultAccountId1}, nilErr)
+		service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+			Network:                defaultNetwork,
+			Nodes:                  defaultNodes,
+			Broadcast:              defaultBroadcast,
+			Settings:               config.NewSettings(),
+			TransactionConstructor: mockConstructor,
+		})
+
+		// when
+		actual, e := service.ConstructionPayloads(nil, request)
+
+		// then
+		assert.Nil(t, e)
+		assert.NotNil(t, actual)
+		mockConstructor.AssertExpectations(t)
+	}
+}
+
+func TestConstructionPayloadsThrowsWithInvalidMaxTransactionFee(t *testing.T) {
+	for _, maxTransactionFee := range []interface{}{"0", float64(0), float64(-1), "abc", true} {
+		// given
+		operations := []*types.Operation{
+			dummyOperation(0, "CRYPTOTRANSFER", defaultCryptoAccountId1, defaultSendAmount),
+			dummyOperation(1, "CRYPTOTRANSFER", defaultCryptoAccountId2, defaultReceiveAmount),
+		}
+		request := dummyPayloadsRequest(operations)
+		request.Metadata = map[string]interface{}{"max_transaction_fee": maxTransactionFee}
+		mockConstructor := &mockTransactionConstructor{}
+		service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+			Network:                defaultNetwork,
+			Nodes:                  defaultNodes,
+			Broadcast:              defaultBroadcast,
+			Settings:               config.NewSettings(),
+			TransactionConstructor: mockConstructor,
+		})
+
+		// when
+		actual, e := service.ConstructionPayloads(nil, request)
+
+		// then
+		assert.Equal(t, errors.ErrInvalidArgument.Code, e.Code)
+		assert.Equal(t, "max_transaction_fee", e.Details[errors.DetailField])
+		assert.Nil(t, actual)
+		mockConstructor.AssertNotCalled(t, "Construct")
+	}
+}
+
 func TestConstructionPayloadsThrowsWithConstuctorConstructFailure(t *testing.T) {
 	// given
 	operations := []*types.Operation{
@@ -560,9 +1417,15 @@ func TestConstructionPayloadsThrowsWithConstuctorConstructFailure(t *testing.T)
 	}
 	mockConstructor := &mockTransactionConstructor{}
 	mockConstructor.
-		On("Construct", mock.IsType(hedera.AccountID{}), mock.IsType([]*types.Operation{})).
+		On("Construct", mock.IsType(hedera.AccountID{}), mock.IsType([]*types.Operation{}), hedera.ZeroHbar).
 		Return(nilTransaction, nilSigners, errors.ErrInternalServerError)
-	service, _ := NewConstructionAPIService(defaultNetwork, defaultNodes, mockConstructor)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:                defaultNetwork,
+		Nodes:                  defaultNodes,
+		Broadcast:              defaultBroadcast,
+		Settings:               config.NewSettings(),
+		TransactionConstructor: mockConstructor,
+	})
 
 	// when
 	actual, err := service.ConstructionPayloads(nil, dummyPayloadsRequest(operations))
@@ -580,7 +1443,12 @@ func TestConstructionSubmitThrowsWhenDecodeStringFails(t *testing.T) {
 	}
 
 	// when:
-	service, _ := NewConstructionAPIService(defaultNetwork, defaultNodes, nil)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:   defaultNetwork,
+		Nodes:     defaultNodes,
+		Broadcast: defaultBroadcast,
+		Settings:  config.NewSettings(),
+	})
 	res, e := service.ConstructionSubmit(nil, exampleConstructionSubmitRequest)
 
 	// then:
@@ -598,7 +1466,12 @@ func TestConstructionSubmitThrowsWhenUnmarshalBinaryFails(t *testing.T) {
 	}
 
 	// when:
-	service, _ := NewConstructionAPIService(defaultNetwork, defaultNodes, nil)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:   defaultNetwork,
+		Nodes:     defaultNodes,
+		Broadcast: defaultBroadcast,
+		Settings:  config.NewSettings(),
+	})
 	res, e := service.ConstructionSubmit(nil, exampleConstructionSubmitRequest)
 
 	// then:
@@ -609,14 +1482,20 @@ func TestConstructionSubmitThrowsWhenUnmarshalBinaryFails(t *testing.T) {
 func TestConstructionPreprocess(t *testing.T) {
 	// given:
 	expected := &types.ConstructionPreprocessResponse{
-		Options:            make(map[string]interface{}),
+		Options:            map[string]interface{}{"operation_type": config.OperationTypeCryptoTransfer},
 		RequiredPublicKeys: []*types.AccountIdentifier{{Address: defaultCryptoAccountId1}},
 	}
 	mockConstructor := &mockTransactionConstructor{}
 	mockConstructor.
 		On("Preprocess", mock.IsType([]*types.Operation{})).
 		Return([]hedera.AccountID{defaultAccountId1}, nilErr)
-	service, _ := NewConstructionAPIService(defaultNetwork, defaultNodes, mockConstructor)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:                defaultNetwork,
+		Nodes:                  defaultNodes,
+		Broadcast:              defaultBroadcast,
+		Settings:               config.NewSettings(),
+		TransactionConstructor: mockConstructor,
+	})
 
 	// when:
 	actual, e := service.ConstructionPreprocess(nil, dummyConstructionPreprocessRequest(true))
@@ -632,7 +1511,13 @@ func TestConstructionPreprocessThrowsWithConstructorPreprocessFailure(t *testing
 	mockConstructor.
 		On("Preprocess", mock.IsType([]*types.Operation{})).
 		Return(nilSigners, errors.ErrInternalServerError)
-	service, _ := NewConstructionAPIService(defaultNetwork, defaultNodes, mockConstructor)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:                defaultNetwork,
+		Nodes:                  defaultNodes,
+		Broadcast:              defaultBroadcast,
+		Settings:               config.NewSettings(),
+		TransactionConstructor: mockConstructor,
+	})
 
 	// when:
 	actual, e := service.ConstructionPreprocess(nil, dummyConstructionPreprocessRequest(false))
@@ -642,6 +1527,101 @@ func TestConstructionPreprocessThrowsWithConstructorPreprocessFailure(t *testing
 	assert.NotNil(t, e)
 }
 
+func TestConstructionPreprocessWithMaxTransactionFee(t *testing.T) {
+	// given:
+	expected := &types.ConstructionPreprocessResponse{
+		Options: map[string]interface{}{
+			"max_transaction_fee": "50000000",
+			"operation_type":      config.OperationTypeCryptoTransfer,
+		},
+		RequiredPublicKeys: []*types.AccountIdentifier{{Address: defaultCryptoAccountId1}},
+	}
+	request := dummyConstructionPreprocessRequest(true)
+	request.Metadata = map[string]interface{}{"max_transaction_fee": "50000000"}
+	mockConstructor := &mockTransactionConstructor{}
+	mockConstructor.
+		On("Preprocess", mock.IsType([]*types.Operation{})).
+		Return([]hedera.AccountID{defaultAccountId1}, nilErr)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:                defaultNetwork,
+		Nodes:                  defaultNodes,
+		Broadcast:              defaultBroadcast,
+		Settings:               config.NewSettings(),
+		TransactionConstructor: mockConstructor,
+	})
+
+	// when:
+	actual, e := service.ConstructionPreprocess(nil, request)
+
+	// then:
+	assert.Equal(t, expected, actual)
+	assert.Nil(t, e)
+}
+
+func TestConstructionPreprocessThrowsWithInvalidMaxTransactionFee(t *testing.T) {
+	// given:
+	request := dummyConstructionPreprocessRequest(true)
+	request.Metadata = map[string]interface{}{"max_transaction_fee": "-1"}
+	mockConstructor := &mockTransactionConstructor{}
+	mockConstructor.
+		On("Preprocess", mock.IsType([]*types.Operation{})).
+		Return([]hedera.AccountID{defaultAccountId1}, nilErr)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:                defaultNetwork,
+		Nodes:                  defaultNodes,
+		Broadcast:              defaultBroadcast,
+		Settings:               config.NewSettings(),
+		TransactionConstructor: mockConstructor,
+	})
+
+	// when:
+	actual, e := service.ConstructionPreprocess(nil, request)
+
+	// then:
+	assert.Nil(t, actual)
+	assert.Equal(t, errors.ErrInvalidArgument.Code, e.Code)
+}
+
+func TestConstructionPreprocessScheduleSign(t *testing.T) {
+	// given:
+	expected := &types.ConstructionPreprocessResponse{
+		Options: map[string]interface{}{
+			"operation_type": config.OperationTypeScheduleSign,
+			"schedule_id":    "0.0.1500",
+		},
+		RequiredPublicKeys: []*types.AccountIdentifier{{Address: defaultCryptoAccountId1}},
+	}
+	request := &types.ConstructionPreprocessRequest{
+		NetworkIdentifier: networkIdentifier(),
+		Operations: []*types.Operation{
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 0},
+				Type:                config.OperationTypeScheduleSign,
+				Account:             &types.AccountIdentifier{Address: defaultCryptoAccountId1},
+				Metadata:            map[string]interface{}{"schedule_id": "0.0.1500"},
+			},
+		},
+	}
+	mockConstructor := &mockTransactionConstructor{}
+	mockConstructor.
+		On("Preprocess", mock.IsType([]*types.Operation{})).
+		Return([]hedera.AccountID{defaultAccountId1}, nilErr)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:                defaultNetwork,
+		Nodes:                  defaultNodes,
+		Broadcast:              defaultBroadcast,
+		Settings:               config.NewSettings(),
+		TransactionConstructor: mockConstructor,
+	})
+
+	// when:
+	actual, e := service.ConstructionPreprocess(nil, request)
+
+	// then:
+	assert.Equal(t, expected, actual)
+	assert.Nil(t, e)
+}
+
 func freezeTransaction(transaction ITransaction) {
 	nodeAccountIds := []hedera.AccountID{nodeAccountId}
 	transactionId := hedera.TransactionIDGenerate(payerId)
@@ -876,6 +1856,54 @@ func TestUnmarshallTransactionFromHexStringThrowsWithUnsupportedTransactionType(
 	assert.Nil(t, actual)
 }
 
+func TestDecodeTransaction(t *testing.T) {
+	for _, signed := range []bool{false, true} {
+		t.Run(fmt.Sprintf("signed=%t", signed), func(t *testing.T) {
+			// given
+			transaction := hedera.NewTransferTransaction().SetTransactionMemo("memo")
+			txStr := createTransactionHexString(transaction, signed)
+			expected, _ := unmarshallTransactionFromHexString(txStr)
+			expectedBodyBytes, _ := getFrozenTransactionBodyBytes(expected)
+
+			// when
+			actual, err := decodeTransaction(txStr)
+
+			// then
+			assert.Nil(t, err)
+			assert.IsType(t, transaction, actual.transaction)
+			assert.Equal(t, expectedBodyBytes, actual.frozenBodyBytes)
+			assert.Equal(t, "memo", actual.body.Memo)
+			assert.NotNil(t, actual.body.GetCryptoTransfer())
+		})
+	}
+}
+
+func TestDecodeTransactionThrows(t *testing.T) {
+	var tests = []struct {
+		name        string
+		transaction string
+		expected    *types.Error
+	}{
+		{name: "InvalidHexString", transaction: "not a hex string", expected: errors.ErrTransactionDecodeFailed},
+		{
+			name:        "InvalidTransactionBytes",
+			transaction: "0xdeadbeaf",
+			expected:    errors.ErrTransactionUnmarshallingFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := decodeTransaction(tt.transaction)
+
+			// then
+			assert.Equal(t, tt.expected, err)
+			assert.Nil(t, actual)
+		})
+	}
+}
+
 func assertSignatureMap(
 	t *testing.T,
 	tx ITransaction,
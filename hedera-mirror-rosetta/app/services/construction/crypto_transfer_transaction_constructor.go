@@ -22,10 +22,12 @@ package construction
 
 import (
 	"reflect"
+	"sort"
 	"strconv"
 
 	rTypes "github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/parse"
@@ -33,9 +35,15 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// metadataRawAmount is the operation metadata flag telling the amount is in the token's smallest unit and the currency
+// decimals are unknown, so the server resolves them from the token
+const metadataRawAmount = "raw_amount"
+
 type cryptoTransferTransactionConstructor struct {
-	tokenRepo       repositories.TokenRepository
-	transactionType string
+	settings             *config.Settings
+	tokenAssociationRepo repositories.TokenAssociationRepository
+	tokenRepo            repositories.TokenRepository
+	transactionType      string
 }
 
 type transfer struct {
@@ -51,14 +59,17 @@ func (m senderMap) toSenders() []hedera.AccountID {
 	for sender := range m {
 		senders = append(senders, sender)
 	}
+	sort.Slice(senders, func(i, j int) bool {
+		return isAccountIdLess(senders[i], senders[j])
+	})
 	return senders
 }
 
-func (c *cryptoTransferTransactionConstructor) Construct(nodeAccountId hedera.AccountID, operations []*rTypes.Operation) (
-	ITransaction,
-	[]hedera.AccountID,
-	*rTypes.Error,
-) {
+func (c *cryptoTransferTransactionConstructor) Construct(
+	nodeAccountId hedera.AccountID,
+	operations []*rTypes.Operation,
+	maxTransactionFee hedera.Hbar,
+) (ITransaction, []hedera.AccountID, *rTypes.Error) {
 	transfers, senders, rErr := c.preprocess(operations)
 	if rErr != nil {
 		return nil, nil, rErr
@@ -76,8 +87,10 @@ func (c *cryptoTransferTransactionConstructor) Construct(nodeAccountId hedera.Ac
 
 	// set to a single node account ID, so later can add signature
 	_, err := transaction.
-		SetTransactionID(hedera.TransactionIDGenerate(senders[0])).
+		SetTransactionID(newTransactionId(senders[0], c.settings.TransactionValidStartBackdate)).
+		SetTransactionValidDuration(c.settings.TransactionValidDuration).
 		SetNodeAccountIDs([]hedera.AccountID{nodeAccountId}).
+		SetMaxTransactionFee(maxTransactionFee).
 		Freeze()
 	if err != nil {
 		return nil, nil, errors.ErrTransactionFreezeFailed
@@ -122,17 +135,36 @@ func (c *cryptoTransferTransactionConstructor) Parse(transaction ITransaction) (
 	operations := make([]*rTypes.Operation, 0, numOperations)
 	senderMap := senderMap{}
 
-	for accountId, hbarAmount := range hbarTransfers {
-		operations = c.addOperation(accountId, hbarAmount.AsTinybar(), config.CurrencyHbar, operations, senderMap)
+	// the sdk returns the transfers as maps, iterate over the sorted keys so the operations are in a stable order
+	accountIds := make([]hedera.AccountID, 0, len(hbarTransfers))
+	for accountId := range hbarTransfers {
+		accountIds = append(accountIds, accountId)
+	}
+	sort.Slice(accountIds, func(i, j int) bool {
+		return isAccountIdLess(accountIds[i], accountIds[j])
+	})
+
+	for _, accountId := range accountIds {
+		hbarAmount := hbarTransfers[accountId]
+		operations = c.addOperation(accountId, hbarAmount.AsTinybar(), c.settings.CurrencyHbar, operations, senderMap)
 	}
 
-	for token, sameTokenTransfers := range tokenTransfers {
+	tokenIds := make([]hedera.TokenID, 0, len(tokenTransfers))
+	for tokenId := range tokenTransfers {
+		tokenIds = append(tokenIds, tokenId)
+	}
+	sort.Slice(tokenIds, func(i, j int) bool {
+		return isTokenIdLess(tokenIds[i], tokenIds[j])
+	})
+
+	for _, token := range tokenIds {
 		dbToken, err := c.tokenRepo.Find(token.String())
 		if err != nil {
 			return nil, nil, err
 		}
 
 		currency := dbToken.ToRosettaCurrency()
+		sameTokenTransfers := tokenTransfers[token]
 		for _, tokenTransfer := range sameTokenTransfers {
 			operations = c.addOperation(tokenTransfer.AccountID, tokenTransfer.Amount, currency, operations, senderMap)
 		}
@@ -145,11 +177,15 @@ func (c *cryptoTransferTransactionConstructor) Preprocess(operations []*rTypes.O
 	[]hedera.AccountID,
 	*rTypes.Error,
 ) {
-	_, senders, err := c.preprocess(operations)
+	transfers, senders, err := c.preprocess(operations)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := c.validateTokenAssociations(transfers); err != nil {
+		return nil, err
+	}
+
 	return senders, nil
 }
 
@@ -163,7 +199,7 @@ func (c *cryptoTransferTransactionConstructor) addOperation(
 	operation := &rTypes.Operation{
 		OperationIdentifier: &rTypes.OperationIdentifier{Index: int64(len(operations))},
 		Type:                c.GetOperationType(),
-		Account:             &rTypes.AccountIdentifier{Address: accountId.String()},
+		Account:             types.NewAccountIdentifier(accountId.String(), currency, c.settings),
 		Amount: &rTypes.Amount{
 			Value:    strconv.FormatInt(amount, 10),
 			Currency: currency,
@@ -186,15 +222,15 @@ func (c *cryptoTransferTransactionConstructor) preprocess(operations []*rTypes.O
 		return nil, nil, rErr
 	}
 
-	currencies := map[string]rTypes.Currency{config.CurrencySymbol: *config.CurrencyHbar}
+	currencies := map[string]rTypes.Currency{c.settings.CurrencyHbar.Symbol: *c.settings.CurrencyHbar}
 	transfers := make([]transfer, 0, len(operations))
 	senderMap := senderMap{}
 	sums := make(map[string]int64)
 
 	for _, operation := range operations {
-		account, err := hedera.AccountIDFromString(operation.Account.Address)
+		account, err := parseAccountId(operation.Account.Address, c.settings.LedgerId)
 		if err != nil {
-			return nil, nil, errors.ErrInvalidAccount
+			return nil, nil, invalidEntityIdError(err, errors.ErrInvalidAccount)
 		}
 
 		amount, err := parse.ToInt64(operation.Amount.Value)
@@ -202,12 +238,30 @@ func (c *cryptoTransferTransactionConstructor) preprocess(operations []*rTypes.O
 			return nil, nil, errors.ErrInvalidAmount
 		}
 
+		rawAmount, rErr := isRawAmount(operation)
+		if rErr != nil {
+			return nil, nil, rErr
+		}
+
 		currency := operation.Amount.Currency
-		if !c.validateCurrency(currency, currencies) {
+		if rawAmount {
+			if currency = c.resolveCurrency(currency, currencies); currency == nil {
+				return nil, nil, errors.ErrInvalidCurrency
+			}
+			// normalize the operation so the currency with the actual decimals is what flows through
+			operation.Amount.Currency = currency
+		} else if !c.validateCurrency(currency, currencies) {
 			return nil, nil, errors.ErrInvalidCurrency
 		}
 
-		tokenId, _ := hedera.TokenIDFromString(currency.Symbol)
+		if c.settings.TokenSubAccounts && !isSameSubAccount(
+			operation.Account.SubAccount,
+			types.NewAccountIdentifier(operation.Account.Address, currency, c.settings).SubAccount,
+		) {
+			return nil, nil, errors.ErrInvalidAccount
+		}
+
+		tokenId, _ := parseTokenId(currency.Symbol)
 		transfers = append(transfers, transfer{
 			account: account,
 			amount:  amount,
@@ -231,6 +285,33 @@ func (c *cryptoTransferTransactionConstructor) preprocess(operations []*rTypes.O
 	return transfers, senderMap.toSenders(), nil
 }
 
+// validateTokenAssociations checks every token receiver is associated with the token, otherwise the transaction is
+// guaranteed to fail with TOKEN_NOT_ASSOCIATED_TO_ACCOUNT
+func (c *cryptoTransferTransactionConstructor) validateTokenAssociations(transfers []transfer) *rTypes.Error {
+	if c.tokenAssociationRepo == nil {
+		// offline mode
+		return nil
+	}
+
+	for _, transfer := range transfers {
+		if isZeroTokenId(transfer.token) || transfer.amount < 0 {
+			continue
+		}
+
+		tokenAssociation, err := c.tokenAssociationRepo.Find(transfer.account.String(), transfer.token.String())
+		if err != nil && err != errors.ErrTokenAssociationNotFound {
+			return err
+		}
+
+		if tokenAssociation == nil || !tokenAssociation.Associated {
+			log.Warnf("Account %s is not associated with token %s", transfer.account, transfer.token)
+			return errors.ErrTokenNotAssociated
+		}
+	}
+
+	return nil
+}
+
 func (c *cryptoTransferTransactionConstructor) validateCurrency(
 	currency *rTypes.Currency,
 	currencies map[string]rTypes.Currency,
@@ -246,7 +327,7 @@ func (c *cryptoTransferTransactionConstructor) validateCurrency(
 		return false
 	}
 
-	if _, err := hedera.TokenIDFromString(currency.Symbol); err != nil {
+	if _, err := parseTokenId(currency.Symbol); err != nil {
 		return false
 	}
 
@@ -258,10 +339,69 @@ func (c *cryptoTransferTransactionConstructor) validateCurrency(
 	return true
 }
 
-func newCryptoTransferTransactionConstructor(tokenRepo repositories.TokenRepository) transactionConstructorWithType {
+// resolveCurrency resolves the currency of a raw amount by its symbol regardless of the provided decimals, returns nil
+// if the symbol isn't hbar or a known token. Tokens can't be resolved in offline mode
+func (c *cryptoTransferTransactionConstructor) resolveCurrency(
+	currency *rTypes.Currency,
+	currencies map[string]rTypes.Currency,
+) *rTypes.Currency {
+	if cached, ok := currencies[currency.Symbol]; ok {
+		return &cached
+	}
+
+	if c.tokenRepo == nil {
+		// offline mode
+		return nil
+	}
+
+	if _, err := parseTokenId(currency.Symbol); err != nil {
+		return nil
+	}
+
+	token, err := c.tokenRepo.Find(currency.Symbol)
+	if err != nil {
+		return nil
+	}
+
+	resolved := token.ToRosettaCurrency()
+	currencies[currency.Symbol] = *resolved
+	return resolved
+}
+
+func newCryptoTransferTransactionConstructor(
+	tokenAssociationRepo repositories.TokenAssociationRepository,
+	tokenRepo repositories.TokenRepository,
+	settings *config.Settings,
+) transactionConstructorWithType {
 	transactionType := reflect.TypeOf(hedera.TransferTransaction{}).Name()
 	return &cryptoTransferTransactionConstructor{
-		tokenRepo:       tokenRepo,
-		transactionType: transactionType,
+		settings:             settings,
+		tokenAssociationRepo: tokenAssociationRepo,
+		tokenRepo:            tokenRepo,
+		transactionType:      transactionType,
+	}
+}
+
+// isRawAmount returns true if the operation metadata has the raw amount flag set, the flag must be a bool
+func isRawAmount(operation *rTypes.Operation) (bool, *rTypes.Error) {
+	value, ok := operation.Metadata[metadataRawAmount]
+	if !ok {
+		return false, nil
+	}
+
+	rawAmount, ok := value.(bool)
+	if !ok {
+		return false, errors.ErrInvalidOperationMetadata
 	}
+
+	return rawAmount, nil
+}
+
+// isSameSubAccount returns true if the two sub-accounts have the same address or are both nil
+func isSameSubAccount(first, second *rTypes.SubAccountIdentifier) bool {
+	if first == nil || second == nil {
+		return first == second
+	}
+
+	return first.Address == second.Address
 }
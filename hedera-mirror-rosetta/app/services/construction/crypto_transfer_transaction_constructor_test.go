@@ -26,10 +26,13 @@ import (
 	"testing"
 
 	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/mocks/repository"
 	"github.com/hashgraph/hedera-sdk-go/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -53,17 +56,29 @@ type cryptoTransferTransactionConstructorSuite struct {
 }
 
 func (suite *cryptoTransferTransactionConstructorSuite) TestNewTransactionConstructor() {
-	h := newCryptoTransferTransactionConstructor(&repository.MockTokenRepository{})
+	h := newCryptoTransferTransactionConstructor(
+		&repository.MockTokenAssociationRepository{},
+		&repository.MockTokenRepository{},
+		config.NewSettings(),
+	)
 	assert.NotNil(suite.T(), h)
 }
 
 func (suite *cryptoTransferTransactionConstructorSuite) TestGetOperationType() {
-	h := newCryptoTransferTransactionConstructor(&repository.MockTokenRepository{})
+	h := newCryptoTransferTransactionConstructor(
+		&repository.MockTokenAssociationRepository{},
+		&repository.MockTokenRepository{},
+		config.NewSettings(),
+	)
 	assert.Equal(suite.T(), config.OperationTypeCryptoTransfer, h.GetOperationType())
 }
 
 func (suite *cryptoTransferTransactionConstructorSuite) TestGetSdkTransactionType() {
-	h := newCryptoTransferTransactionConstructor(&repository.MockTokenRepository{})
+	h := newCryptoTransferTransactionConstructor(
+		&repository.MockTokenAssociationRepository{},
+		&repository.MockTokenRepository{},
+		config.NewSettings(),
+	)
 	assert.Equal(suite.T(), "TransferTransaction", h.GetSdkTransactionType())
 }
 
@@ -97,11 +112,11 @@ func (suite *cryptoTransferTransactionConstructorSuite) TestConstruct() {
 			// given
 			operations := suite.makeOperations(tt.transfers)
 			mockTokenRepo := &repository.MockTokenRepository{}
-			h := newCryptoTransferTransactionConstructor(mockTokenRepo)
+			h := newCryptoTransferTransactionConstructor(nil, mockTokenRepo, config.NewSettings())
 			configMockTokenRepo(mockTokenRepo, defaultMockTokenRepoConfigs...)
 
 			// when
-			tx, signers, err := h.Construct(nodeAccountId, operations)
+			tx, signers, err := h.Construct(nodeAccountId, operations, maxTransactionFee)
 
 			// then
 			if tt.expectError {
@@ -176,7 +191,7 @@ func (suite *cryptoTransferTransactionConstructorSuite) TestParse() {
 		suite.T().Run(tt.name, func(t *testing.T) {
 			// given
 			mockTokenRepo := &repository.MockTokenRepository{}
-			h := newCryptoTransferTransactionConstructor(mockTokenRepo)
+			h := newCryptoTransferTransactionConstructor(nil, mockTokenRepo, config.NewSettings())
 			tx := tt.getTransaction()
 
 			if tt.tokenRepoErr {
@@ -217,6 +232,8 @@ func (suite *cryptoTransferTransactionConstructorSuite) TestPreprocess() {
 		transfers       []transferOperation
 		operations      []*rTypes.Operation
 		tokenRepoErr    bool
+		notAssociated   bool
+		neverAssociated bool
 		expectError     bool
 		expectedSigners []hedera.AccountID
 	}{
@@ -316,6 +333,28 @@ func (suite *cryptoTransferTransactionConstructorSuite) TestPreprocess() {
 			tokenRepoErr: true,
 			expectError:  true,
 		},
+		{
+			name: "ReceiverNotAssociated",
+			transfers: []transferOperation{
+				{account: accountIdA.String(), amount: -15, currency: config.CurrencyHbar},
+				{account: accountIdB.String(), amount: 15, currency: config.CurrencyHbar},
+				{account: accountIdB.String(), amount: -25, currency: dbTokenA.ToRosettaCurrency()},
+				{account: accountIdA.String(), amount: 25, currency: dbTokenA.ToRosettaCurrency()},
+			},
+			notAssociated: true,
+			expectError:   true,
+		},
+		{
+			name: "ReceiverNeverAssociated",
+			transfers: []transferOperation{
+				{account: accountIdA.String(), amount: -15, currency: config.CurrencyHbar},
+				{account: accountIdB.String(), amount: 15, currency: config.CurrencyHbar},
+				{account: accountIdB.String(), amount: -25, currency: dbTokenA.ToRosettaCurrency()},
+				{account: accountIdA.String(), amount: 25, currency: dbTokenA.ToRosettaCurrency()},
+			},
+			neverAssociated: true,
+			expectError:     true,
+		},
 		{
 			name: "InvalidOperationType",
 			operations: []*rTypes.Operation{
@@ -338,14 +377,22 @@ func (suite *cryptoTransferTransactionConstructorSuite) TestPreprocess() {
 				operations = suite.makeOperations(tt.transfers)
 			}
 
+			mockTokenAssociationRepo := &repository.MockTokenAssociationRepository{}
 			mockTokenRepo := &repository.MockTokenRepository{}
-			h := newCryptoTransferTransactionConstructor(mockTokenRepo)
+			h := newCryptoTransferTransactionConstructor(mockTokenAssociationRepo, mockTokenRepo, config.NewSettings())
 
 			if !tt.tokenRepoErr {
 				configMockTokenRepo(mockTokenRepo, defaultMockTokenRepoConfigs...)
 			} else {
 				configMockTokenRepo(mockTokenRepo, mockTokenRepoNotFoundConfigs...)
 			}
+			if tt.neverAssociated {
+				mockTokenAssociationRepo.On("Find", mock.Anything, mock.Anything).
+					Return(repository.NilTokenAssociation, errors.ErrTokenAssociationNotFound)
+			} else {
+				mockTokenAssociationRepo.On("Find", mock.Anything, mock.Anything).
+					Return(&types.TokenAssociation{Associated: !tt.notAssociated}, repository.NilError)
+			}
 
 			// when
 			signers, err := h.Preprocess(operations)
@@ -357,8 +404,158 @@ func (suite *cryptoTransferTransactionConstructorSuite) TestPreprocess() {
 			} else {
 				assert.Nil(t, err)
 				assert.ElementsMatch(t, tt.expectedSigners, signers)
+				mockTokenAssociationRepo.AssertExpectations(t)
 				mockTokenRepo.AssertExpectations(t)
 			}
+			if tt.notAssociated || tt.neverAssociated {
+				assert.Equal(t, errors.ErrTokenNotAssociated, err)
+			}
+		})
+	}
+}
+
+func (suite *cryptoTransferTransactionConstructorSuite) TestPreprocessWithTokenSubAccounts() {
+	transfers := []transferOperation{
+		{account: accountIdA.String(), amount: -15, currency: config.CurrencyHbar},
+		{account: accountIdB.String(), amount: 15, currency: config.CurrencyHbar},
+		{account: accountIdB.String(), amount: -25, currency: dbTokenA.ToRosettaCurrency()},
+		{account: accountIdA.String(), amount: 25, currency: dbTokenA.ToRosettaCurrency()},
+	}
+	tokenSubAccount := &rTypes.SubAccountIdentifier{Address: dbTokenA.TokenId.String()}
+	otherSubAccount := &rTypes.SubAccountIdentifier{Address: dbTokenB.TokenId.String()}
+
+	var tests = []struct {
+		name        string
+		subAccounts []*rTypes.SubAccountIdentifier
+		expectError bool
+	}{
+		{name: "Success", subAccounts: []*rTypes.SubAccountIdentifier{nil, nil, tokenSubAccount, tokenSubAccount}},
+		{
+			name:        "HbarWithSubAccount",
+			subAccounts: []*rTypes.SubAccountIdentifier{tokenSubAccount, nil, tokenSubAccount, tokenSubAccount},
+			expectError: true,
+		},
+		{
+			name:        "TokenWithoutSubAccount",
+			subAccounts: []*rTypes.SubAccountIdentifier{nil, nil, nil, tokenSubAccount},
+			expectError: true,
+		},
+		{
+			name:        "TokenWithOtherSubAccount",
+			subAccounts: []*rTypes.SubAccountIdentifier{nil, nil, otherSubAccount, tokenSubAccount},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// given
+			settings := config.NewSettings()
+			settings.TokenSubAccounts = true
+			operations := suite.makeOperations(transfers)
+			for i, subAccount := range tt.subAccounts {
+				operations[i].Account.SubAccount = subAccount
+			}
+
+			mockTokenAssociationRepo := &repository.MockTokenAssociationRepository{}
+			mockTokenAssociationRepo.On("Find", mock.Anything, mock.Anything).
+				Return(&types.TokenAssociation{Associated: true}, repository.NilError)
+			mockTokenRepo := &repository.MockTokenRepository{}
+			configMockTokenRepo(mockTokenRepo, defaultMockTokenRepoConfigs...)
+			h := newCryptoTransferTransactionConstructor(mockTokenAssociationRepo, mockTokenRepo, settings)
+
+			// when
+			signers, err := h.Preprocess(operations)
+
+			// then
+			if tt.expectError {
+				assert.Equal(t, errors.ErrInvalidAccount, err)
+				assert.Nil(t, signers)
+			} else {
+				assert.Nil(t, err)
+				assert.ElementsMatch(t, []hedera.AccountID{accountIdA, accountIdB}, signers)
+			}
+		})
+	}
+}
+
+func (suite *cryptoTransferTransactionConstructorSuite) TestPreprocessWithRawAmount() {
+	decimalsLessA := &rTypes.Currency{Symbol: dbTokenA.TokenId.String()}
+	transfers := []transferOperation{
+		{account: accountIdA.String(), amount: -15, currency: config.CurrencyHbar},
+		{account: accountIdB.String(), amount: 15, currency: config.CurrencyHbar},
+		{account: accountIdB.String(), amount: -25, currency: decimalsLessA},
+		{account: accountIdA.String(), amount: 25, currency: dbTokenA.ToRosettaCurrency()},
+	}
+
+	var tests = []struct {
+		name         string
+		metadata     map[string]interface{}
+		offline      bool
+		tokenRepoErr bool
+		expectedErr  *rTypes.Error
+	}{
+		{name: "Success", metadata: map[string]interface{}{metadataRawAmount: true}},
+		{name: "WithoutFlag", expectedErr: errors.ErrInvalidCurrency},
+		{
+			name:        "FlagNotSet",
+			metadata:    map[string]interface{}{metadataRawAmount: false},
+			expectedErr: errors.ErrInvalidCurrency,
+		},
+		{
+			name:        "InvalidFlag",
+			metadata:    map[string]interface{}{metadataRawAmount: "true"},
+			expectedErr: errors.ErrInvalidOperationMetadata,
+		},
+		{
+			name:         "TokenNotFound",
+			metadata:     map[string]interface{}{metadataRawAmount: true},
+			tokenRepoErr: true,
+			expectedErr:  errors.ErrInvalidCurrency,
+		},
+		{
+			name:        "Offline",
+			metadata:    map[string]interface{}{metadataRawAmount: true},
+			offline:     true,
+			expectedErr: errors.ErrInvalidCurrency,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// given
+			operations := suite.makeOperations(transfers)
+			for _, operation := range operations {
+				operation.Metadata = tt.metadata
+			}
+
+			mockTokenAssociationRepo := &repository.MockTokenAssociationRepository{}
+			mockTokenAssociationRepo.On("Find", mock.Anything, mock.Anything).
+				Return(&types.TokenAssociation{Associated: true}, repository.NilError)
+			mockTokenRepo := &repository.MockTokenRepository{}
+			if tt.tokenRepoErr {
+				configMockTokenRepo(mockTokenRepo, mockTokenRepoNotFoundConfigs[0])
+			} else {
+				configMockTokenRepo(mockTokenRepo, defaultMockTokenRepoConfigs[0])
+			}
+			h := newCryptoTransferTransactionConstructor(mockTokenAssociationRepo, mockTokenRepo, config.NewSettings())
+			if tt.offline {
+				h = newCryptoTransferTransactionConstructor(nil, nil, config.NewSettings())
+			}
+
+			// when
+			signers, err := h.Preprocess(operations)
+
+			// then
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				assert.ElementsMatch(t, []hedera.AccountID{accountIdA, accountIdB}, signers)
+				assert.Equal(t, config.CurrencyHbar, operations[0].Amount.Currency)
+				assert.Equal(t, dbTokenA.ToRosettaCurrency(), operations[2].Amount.Currency)
+				assert.Equal(t, dbTokenA.ToRosettaCurrency(), operations[3].Amount.Currency)
+			} else {
+				assert.Nil(t, signers)
+			}
 		})
 	}
 }
@@ -419,6 +616,7 @@ func assertCryptoTransferTransaction(
 
 	assert.ElementsMatch(t, expectedTransfers, actualTransfers)
 	assert.ElementsMatch(t, []hedera.AccountID{nodeAccountId}, actual.GetNodeAccountIDs())
+	assert.Equal(t, maxTransactionFee, tx.GetMaxTransactionFee())
 }
 
 func operationTransferStringify(operation *rTypes.Operation) string {
@@ -0,0 +1,54 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package construction
+
+import (
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+)
+
+// withDefaultPayer returns the operations with the default payer as the account of each operation without one, so a
+// client of a custodial deployment only has to specify the counterparties. The operations with an account are kept
+// as is, and the request isn't modified. The operations are returned unchanged if there is no default payer
+func (c *constructionAPIService) withDefaultPayer(operations []*rTypes.Operation) []*rTypes.Operation {
+	if c.defaultPayer == nil {
+		return operations
+	}
+
+	filled := make([]*rTypes.Operation, 0, len(operations))
+	for _, operation := range operations {
+		if operation == nil || (operation.Account != nil && operation.Account.Address != "") {
+			filled = append(filled, operation)
+			continue
+		}
+
+		var currency *rTypes.Currency
+		if operation.Amount != nil {
+			currency = operation.Amount.Currency
+		}
+
+		withPayer := *operation
+		withPayer.Account = types.NewAccountIdentifier(c.defaultPayer.String(), currency, c.settings)
+		filled = append(filled, &withPayer)
+	}
+
+	return filled
+}
@@ -0,0 +1,133 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package construction
+
+import (
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// defaultPayerOperations returns the transfer operations with the debit leg missing its account
+func defaultPayerOperations() []*types.Operation {
+	debit := dummyOperation(0, "CRYPTOTRANSFER", "", defaultSendAmount)
+	debit.Account = nil
+	return []*types.Operation{
+		debit,
+		dummyOperation(1, "CRYPTOTRANSFER", defaultCryptoAccountId2, defaultReceiveAmount),
+	}
+}
+
+func newDefaultPayerService(defaultPayer string) *constructionAPIService {
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:                defaultNetwork,
+		Nodes:                  defaultNodes,
+		Broadcast:              defaultBroadcast,
+		DefaultPayer:           defaultPayer,
+		Settings:               config.NewSettings(),
+		TransactionConstructor: NewTransactionConstructor(nil, nil, nil, 0, config.NewSettings()),
+	})
+	return service.(*constructionAPIService)
+}
+
+func TestConstructionPreprocessDefaultPayer(t *testing.T) {
+	// given
+	service := newDefaultPayerService(defaultCryptoAccountId1)
+	operations := defaultPayerOperations()
+
+	// when
+	actual, err := service.ConstructionPreprocess(nil, &types.ConstructionPreprocessRequest{
+		NetworkIdentifier: networkIdentifier(),
+		Operations:        operations,
+	})
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, []*types.AccountIdentifier{{Address: defaultCryptoAccountId1}}, actual.RequiredPublicKeys)
+	assert.Nil(t, operations[0].Account)
+}
+
+func TestConstructionPayloadsDefaultPayer(t *testing.T) {
+	// given
+	service := newDefaultPayerService(defaultCryptoAccountId1)
+
+	// when
+	actual, err := service.ConstructionPayloads(nil, dummyPayloadsRequest(defaultPayerOperations()))
+
+	// then
+	assert.Nil(t, err)
+	assert.Len(t, actual.Payloads, 1)
+	assert.Equal(t, &types.AccountIdentifier{Address: defaultCryptoAccountId1}, actual.Payloads[0].AccountIdentifier)
+}
+
+func TestConstructionPreprocessWithoutDefaultPayer(t *testing.T) {
+	// given
+	service := newDefaultPayerService("")
+
+	// when
+	actual, err := service.ConstructionPreprocess(nil, &types.ConstructionPreprocessRequest{
+		NetworkIdentifier: networkIdentifier(),
+		Operations:        defaultPayerOperations(),
+	})
+
+	// then
+	assert.Equal(t, errors.ErrInvalidOperations, err)
+	assert.Nil(t, actual)
+}
+
+func TestWithDefaultPayerKeepsAccounts(t *testing.T) {
+	// given
+	service := newDefaultPayerService(defaultCryptoAccountId2)
+	operations := []*types.Operation{
+		dummyOperation(0, "CRYPTOTRANSFER", defaultCryptoAccountId1, defaultSendAmount),
+		dummyOperation(1, "CRYPTOTRANSFER", "", defaultReceiveAmount),
+	}
+
+	// when
+	actual := service.withDefaultPayer(operations)
+
+	// then
+	assert.Same(t, operations[0], actual[0])
+	assert.Equal(t, defaultCryptoAccountId2, actual[1].Account.Address)
+	assert.Equal(t, "", operations[1].Account.Address)
+}
+
+func TestNewConstructionAPIServiceInvalidDefaultPayer(t *testing.T) {
+	for _, defaultPayer := range []string{"0.0.a", "0.0.0"} {
+		t.Run(defaultPayer, func(t *testing.T) {
+			// when
+			actual, err := NewConstructionAPIService(ConstructionAPIServiceOptions{
+				Network:      defaultNetwork,
+				Nodes:        defaultNodes,
+				Broadcast:    defaultBroadcast,
+				DefaultPayer: defaultPayer,
+				Settings:     config.NewSettings(),
+			})
+
+			// then
+			assert.Error(t, err)
+			assert.Nil(t, actual)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package construction
+
+import (
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+)
+
+// checkAccountsExist checks each account referenced by the operations exists on the configured network when the
+// existence check is enabled, so a client pointed at the wrong environment, e.g., with mainnet account ids on a testnet
+// deployment, is stopped before it signs and pays for a transaction bound to fail. ErrWrongNetwork is returned with
+// the first account not found. The accounts are looked up by their addresses without the checksum
+func (c *constructionAPIService) checkAccountsExist(operations []*rTypes.Operation) *rTypes.Error {
+	if !c.existenceCheck || c.accountRepo == nil {
+		return nil
+	}
+
+	checked := make(map[string]bool)
+	for _, operation := range operations {
+		if operation.Account == nil {
+			continue
+		}
+
+		accountId, err := parseAccountId(operation.Account.Address, c.settings.LedgerId)
+		if err != nil {
+			return invalidEntityIdError(err, errors.ErrInvalidAccount)
+		}
+
+		address := accountId.String()
+		if checked[address] {
+			continue
+		}
+
+		checked[address] = true
+		expiry, rErr := c.accountRepo.FindExpiry(address)
+		if rErr != nil {
+			return rErr
+		}
+
+		if expiry == nil {
+			return errors.AddErrorDetails(errors.ErrWrongNetwork, errors.DetailAccount, address)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package construction
+
+import (
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
+	domainTypes "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/mocks/repository"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func existenceCheckPreprocessRequest() *types.ConstructionPreprocessRequest {
+	return &types.ConstructionPreprocessRequest{
+		NetworkIdentifier: networkIdentifier(),
+		Operations: []*types.Operation{
+			dummyOperation(0, "CRYPTOTRANSFER", defaultCryptoAccountId1, defaultSendAmount),
+			dummyOperation(1, "CRYPTOTRANSFER", defaultCryptoAccountId2, defaultReceiveAmount),
+			dummyOperation(2, "CRYPTOTRANSFER", defaultCryptoAccountId1, defaultSendAmount),
+		},
+	}
+}
+
+func newExistenceCheckService(
+	accountRepo repositories.AccountRepository,
+	existenceCheck bool,
+) *constructionAPIService {
+	mockConstructor := &mockTransactionConstructor{}
+	mockConstructor.
+		On("Preprocess", mock.IsType([]*types.Operation{})).
+		Return([]hedera.AccountID{defaultAccountId1}, nilErr)
+	service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		AccountRepo:            accountRepo,
+		Network:                defaultNetwork,
+		Nodes:                  defaultNodes,
+		Broadcast:              defaultBroadcast,
+		ExistenceCheck:         existenceCheck,
+		Settings:               config.NewSettings(),
+		TransactionConstructor: mockConstructor,
+	})
+	return service.(*constructionAPIService)
+}
+
+func TestConstructionPreprocessExistenceCheck(t *testing.T) {
+	var tests = []struct {
+		name     string
+		expiry   *domainTypes.AccountExpiry
+		rErr     *types.Error
+		expected *types.Error
+	}{
+		{name: "Exists", expiry: &domainTypes.AccountExpiry{}},
+		{
+			name:     "NotFound",
+			expected: errors.AddErrorDetails(errors.ErrWrongNetwork, errors.DetailAccount, defaultCryptoAccountId1),
+		},
+		{name: "DatabaseError", rErr: errors.ErrDatabaseError, expected: errors.ErrDatabaseError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			mockAccountRepo := &repository.MockAccountRepository{}
+			mockAccountRepo.On("FindExpiry", defaultCryptoAccountId1).Return(tt.expiry, tt.rErr)
+			mockAccountRepo.On("FindExpiry", defaultCryptoAccountId2).Return(tt.expiry, tt.rErr)
+			service := newExistenceCheckService(mockAccountRepo, true)
+
+			// when
+			actual, err := service.ConstructionPreprocess(nil, existenceCheckPreprocessRequest())
+
+			// then
+			assert.Equal(t, tt.expected, err)
+			if tt.expected == nil {
+				assert.NotNil(t, actual)
+				mockAccountRepo.AssertNumberOfCalls(t, "FindExpiry", 2)
+			} else {
+				assert.Nil(t, actual)
+				mockAccountRepo.AssertNumberOfCalls(t, "FindExpiry", 1)
+			}
+		})
+	}
+}
+
+func TestConstructionPreprocessExistenceCheckDisabled(t *testing.T) {
+	// given
+	mockAccountRepo := &repository.MockAccountRepository{}
+	service := newExistenceCheckService(mockAccountRepo, false)
+
+	// when
+	actual, err := service.ConstructionPreprocess(nil, existenceCheckPreprocessRequest())
+
+	// then
+	assert.Nil(t, err)
+	assert.NotNil(t, actual)
+	mockAccountRepo.AssertNotCalled(t, "FindExpiry", mock.Anything)
+}
+
+func TestConstructionPreprocessExistenceCheckOffline(t *testing.T) {
+	// given
+	service := newExistenceCheckService(nil, true)
+
+	// when
+	actual, err := service.ConstructionPreprocess(nil, existenceCheckPreprocessRequest())
+
+	// then
+	assert.Nil(t, err)
+	assert.NotNil(t, actual)
+}
+
+func TestConstructionPreprocessExistenceCheckWithChecksum(t *testing.T) {
+	// given
+	mockAccountRepo := &repository.MockAccountRepository{}
+	mockAccountRepo.On("FindExpiry", "0.0.123").Return(&domainTypes.AccountExpiry{}, nilErr)
+	service := newExistenceCheckService(mockAccountRepo, true)
+	service.settings.ConfigureLedgerId("mainnet")
+	request := &types.ConstructionPreprocessRequest{
+		NetworkIdentifier: networkIdentifier(),
+		Operations:        []*types.Operation{dummyOperation(0, "CRYPTOTRANSFER", "0.0.123-vfmkw", defaultSendAmount)},
+	}
+
+	// when
+	actual, err := service.ConstructionPreprocess(nil, request)
+
+	// then
+	assert.Nil(t, err)
+	assert.NotNil(t, actual)
+	mockAccountRepo.AssertExpectations(t)
+}
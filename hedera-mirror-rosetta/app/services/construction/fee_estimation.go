@@ -0,0 +1,108 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package construction
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	"github.com/hashgraph/hedera-sdk-go/v2/proto"
+	log "github.com/sirupsen/logrus"
+)
+
+const optionOperationType = "operation_type"
+
+// operationFunctionalities maps the operation types to the hedera functionalities pricing their transactions
+var operationFunctionalities = map[string]proto.HederaFunctionality{
+	config.OperationTypeCryptoTransfer:  proto.HederaFunctionality_CryptoTransfer,
+	config.OperationTypeScheduleSign:    proto.HederaFunctionality_ScheduleSign,
+	config.OperationTypeTokenAssociate:  proto.HederaFunctionality_TokenAssociateToAccount,
+	config.OperationTypeTokenBurn:       proto.HederaFunctionality_TokenBurn,
+	config.OperationTypeTokenCreate:     proto.HederaFunctionality_TokenCreate,
+	config.OperationTypeTokenDelete:     proto.HederaFunctionality_TokenDelete,
+	config.OperationTypeTokenDissociate: proto.HederaFunctionality_TokenDissociateFromAccount,
+	config.OperationTypeTokenFreeze:     proto.HederaFunctionality_TokenFreezeAccount,
+	config.OperationTypeTokenGrantKyc:   proto.HederaFunctionality_TokenGrantKycToAccount,
+	config.OperationTypeTokenMint:       proto.HederaFunctionality_TokenMint,
+	config.OperationTypeTokenRevokeKyc:  proto.HederaFunctionality_TokenRevokeKycFromAccount,
+	config.OperationTypeTokenUnfreeze:   proto.HederaFunctionality_TokenUnfreezeAccount,
+	config.OperationTypeTokenUpdate:     proto.HederaFunctionality_TokenUpdate,
+	config.OperationTypeTokenWipe:       proto.HederaFunctionality_TokenAccountWipe,
+}
+
+// getSuggestedFee returns the fee in hbar of the transaction of the operation_type option signed by the number of
+// signers, estimated with the fee schedule and the exchange rate in effect now. It's nil if the fee can't be
+// estimated, e.g., in offline mode or when the fee schedule file doesn't exist yet
+func (c *constructionAPIService) getSuggestedFee(options map[string]interface{}, signers int) (
+	[]*rTypes.Amount,
+	*rTypes.Error,
+) {
+	if c.exchangeRateRepo == nil || c.feeScheduleRepo == nil {
+		return nil, nil
+	}
+
+	operationType, _ := options[optionOperationType].(string)
+	functionality, ok := operationFunctionalities[operationType]
+	if !ok {
+		return nil, nil
+	}
+
+	now := time.Now()
+	feeSchedule, rErr := c.feeScheduleRepo.FindAt(now.UnixNano())
+	if rErr == errors.ErrFeeScheduleNotFound {
+		return nil, nil
+	} else if rErr != nil {
+		return nil, rErr
+	}
+
+	exchangeRate, rErr := c.exchangeRateRepo.FindAt(now.UnixNano())
+	if rErr == errors.ErrExchangeRateNotFound {
+		return nil, nil
+	} else if rErr != nil {
+		return nil, rErr
+	}
+
+	schedule := feeSchedule.CurrentSchedule
+	if now.Unix() >= schedule.ExpiryTime {
+		schedule = feeSchedule.NextSchedule
+	}
+
+	rate := exchangeRate.CurrentRate
+	if now.Unix() >= rate.ExpirationTime {
+		rate = exchangeRate.NextRate
+	}
+
+	signatures := int64(math.Max(float64(signers), 1))
+	tinycents, ok := schedule.EstimateFee(functionality.String(), signatures)
+	if !ok {
+		log.Warnf("The fee schedule doesn't price %s", functionality)
+		return nil, nil
+	}
+
+	return []*rTypes.Amount{{
+		Value:    strconv.FormatInt(rate.ToTinybars(tinycents), 10),
+		Currency: c.settings.CurrencyHbar,
+	}}, nil
+}
@@ -0,0 +1,211 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package construction
+
+import (
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	domainTypes "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/mocks/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	expired   int64 = 1000
+	notExpire int64 = 4102444800
+)
+
+func TestConstructionMetadataWithSuggestedFee(t *testing.T) {
+	var tests = []struct {
+		name          string
+		operationType string
+		expiryTime    int64
+		publicKeys    int
+		expected      []*types.Amount
+	}{
+		{
+			name:          "CurrentSchedule",
+			operationType: config.OperationTypeCryptoTransfer,
+			expiryTime:    notExpire,
+			publicKeys:    2,
+			expected:      []*types.Amount{{Value: "667", Currency: config.CurrencyHbar}},
+		},
+		{
+			name:          "NoPublicKeys",
+			operationType: config.OperationTypeCryptoTransfer,
+			expiryTime:    notExpire,
+			expected:      []*types.Amount{{Value: "584", Currency: config.CurrencyHbar}},
+		},
+		{
+			name:          "NextSchedule",
+			operationType: config.OperationTypeCryptoTransfer,
+			expiryTime:    expired,
+			publicKeys:    2,
+			expected:      []*types.Amount{{Value: "1600", Currency: config.CurrencyHbar}},
+		},
+		{
+			name:          "NotPriced",
+			operationType: config.OperationTypeTokenMint,
+			expiryTime:    notExpire,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			mockExchangeRateRepo := &repository.MockExchangeRateRepository{}
+			mockExchangeRateRepo.On("FindAt", mock.AnythingOfType("int64")).
+				Return(exchangeRateSet(tt.expiryTime), repository.NilError)
+			mockFeeScheduleRepo := &repository.MockFeeScheduleRepository{}
+			mockFeeScheduleRepo.On("FindAt", mock.AnythingOfType("int64")).
+				Return(feeScheduleSet(tt.expiryTime), repository.NilError)
+			request := &types.ConstructionMetadataRequest{
+				Options:    map[string]interface{}{"operation_type": tt.operationType},
+				PublicKeys: make([]*types.PublicKey, tt.publicKeys),
+			}
+			service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+				ExchangeRateRepo: mockExchangeRateRepo,
+				FeeScheduleRepo:  mockFeeScheduleRepo,
+				Network:          defaultNetwork,
+				Nodes:            defaultNodes,
+				Broadcast:        defaultBroadcast,
+				Settings:         config.NewSettings(),
+			})
+
+			// when
+			res, e := service.ConstructionMetadata(nil, request)
+
+			// then
+			assert.Nil(t, e)
+			assert.Equal(t, tt.expected, res.SuggestedFee)
+		})
+	}
+}
+
+func TestConstructionMetadataWithoutSuggestedFee(t *testing.T) {
+	var tests = []struct {
+		name            string
+		options         map[string]interface{}
+		exchangeRateErr *types.Error
+		feeScheduleErr  *types.Error
+		expectedErr     *types.Error
+	}{
+		{name: "NoOperationType", options: map[string]interface{}{}},
+		{
+			name:           "FeeScheduleNotFound",
+			options:        map[string]interface{}{"operation_type": config.OperationTypeCryptoTransfer},
+			feeScheduleErr: errors.ErrFeeScheduleNotFound,
+		},
+		{
+			name:            "ExchangeRateNotFound",
+			options:         map[string]interface{}{"operation_type": config.OperationTypeCryptoTransfer},
+			exchangeRateErr: errors.ErrExchangeRateNotFound,
+		},
+		{
+			name:           "DatabaseError",
+			options:        map[string]interface{}{"operation_type": config.OperationTypeCryptoTransfer},
+			feeScheduleErr: errors.ErrDatabaseError,
+			expectedErr:    errors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			exchangeRate := exchangeRateSet(notExpire)
+			if tt.exchangeRateErr != nil {
+				exchangeRate = repository.NilExchangeRate
+			}
+			feeSchedule := feeScheduleSet(notExpire)
+			if tt.feeScheduleErr != nil {
+				feeSchedule = repository.NilFeeSchedule
+			}
+			mockExchangeRateRepo := &repository.MockExchangeRateRepository{}
+			mockExchangeRateRepo.On("FindAt", mock.AnythingOfType("int64")).Return(exchangeRate, tt.exchangeRateErr)
+			mockFeeScheduleRepo := &repository.MockFeeScheduleRepository{}
+			mockFeeScheduleRepo.On("FindAt", mock.AnythingOfType("int64")).Return(feeSchedule, tt.feeScheduleErr)
+			request := &types.ConstructionMetadataRequest{Options: tt.options}
+			service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+				ExchangeRateRepo: mockExchangeRateRepo,
+				FeeScheduleRepo:  mockFeeScheduleRepo,
+				Network:          defaultNetwork,
+				Nodes:            defaultNodes,
+				Broadcast:        defaultBroadcast,
+				Settings:         config.NewSettings(),
+			})
+
+			// when
+			res, e := service.ConstructionMetadata(nil, request)
+
+			// then
+			assert.Equal(t, tt.expectedErr, e)
+			if tt.expectedErr == nil {
+				assert.Nil(t, res.SuggestedFee)
+			} else {
+				assert.Nil(t, res)
+			}
+		})
+	}
+}
+
+func exchangeRateSet(expirationTime int64) *domainTypes.ExchangeRateSet {
+	return &domainTypes.ExchangeRateSet{
+		CurrentRate: domainTypes.ExchangeRate{CentEquiv: 12, ExpirationTime: expirationTime, HbarEquiv: 1},
+		NextRate:    domainTypes.ExchangeRate{CentEquiv: 10, ExpirationTime: notExpire, HbarEquiv: 1},
+	}
+}
+
+// feeScheduleSet returns the fee schedule set pricing crypto transfers at 6000 tinycents plus 1000 tinycents per
+// signature in the current schedule, and twice as much in the next
+func feeScheduleSet(expiryTime int64) *domainTypes.FeeScheduleSet {
+	feeSchedule := func(factor int64) domainTypes.FeeSchedule {
+		return domainTypes.FeeSchedule{
+			TransactionFeeSchedules: []domainTypes.TransactionFeeSchedule{
+				{
+					Fees: []domainTypes.FeeData{
+						{
+							Network: domainTypes.FeeComponents{Constant: factor * 2000000, Max: 1000000000000},
+							Node: domainTypes.FeeComponents{
+								Constant: factor * 1000000,
+								Max:      1000000000000,
+								Vpt:      factor * 1000000,
+							},
+							Service: domainTypes.FeeComponents{Constant: factor * 3000000, Max: 1000000000000},
+							SubType: "DEFAULT",
+						},
+					},
+					Functionality: "CryptoTransfer",
+				},
+			},
+		}
+	}
+
+	current := feeSchedule(1)
+	current.ExpiryTime = expiryTime
+	next := feeSchedule(2)
+	next.ExpiryTime = notExpire
+
+	return &domainTypes.FeeScheduleSet{CurrentSchedule: current, NextSchedule: next}
+}
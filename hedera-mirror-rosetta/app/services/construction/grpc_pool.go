@@ -0,0 +1,239 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package construction
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/types"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/hashgraph/hedera-sdk-go/v2/proto"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+const (
+	cryptoService   = "/proto.CryptoService/"
+	scheduleService = "/proto.ScheduleService/"
+	tokenService    = "/proto.TokenService/"
+)
+
+// channelPool is a set of gRPC connections to one consensus node, picked round-robin. streams bounds the number of
+// in-flight submits across the connections when the max concurrent streams is limited
+type channelPool struct {
+	conns   []*grpc.ClientConn
+	next    uint32
+	streams chan struct{}
+}
+
+func (c *channelPool) acquire(ctx context.Context) (*grpc.ClientConn, error) {
+	if c.streams != nil {
+		select {
+		case c.streams <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	index := atomic.AddUint32(&c.next, 1) % uint32(len(c.conns))
+	return c.conns[index], nil
+}
+
+func (c *channelPool) release() {
+	if c.streams != nil {
+		<-c.streams
+	}
+}
+
+// pooledGrpcBroadcaster sends the transaction to its node over a pool of warmed gRPC connections with the configured
+// keepalive and connect timeout, instead of the single lazily established connection per node of the SDK client
+type pooledGrpcBroadcaster struct {
+	pools   map[hedera.AccountID]*channelPool
+	timeout time.Duration
+}
+
+func (p *pooledGrpcBroadcaster) Broadcast(transaction ITransaction) error {
+	nodeAccountIds := transaction.GetNodeAccountIDs()
+	if len(nodeAccountIds) == 0 {
+		return fmt.Errorf("transaction has no node account id")
+	}
+
+	pool, ok := p.pools[nodeAccountIds[0]]
+	if !ok {
+		return fmt.Errorf("no gRPC connection to node %s", nodeAccountIds[0])
+	}
+
+	transactionBytes, err := transaction.ToBytes()
+	if err != nil {
+		return err
+	}
+
+	transactionList := &proto.TransactionList{}
+	if err = protobuf.Unmarshal(transactionBytes, transactionList); err != nil {
+		return err
+	}
+	if len(transactionList.TransactionList) == 0 {
+		return fmt.Errorf("transaction list is empty")
+	}
+	protoTransaction := transactionList.TransactionList[0]
+
+	method, err := getGrpcMethod(protoTransaction)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.Background(), context.CancelFunc(func() {})
+	if p.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+	}
+	defer cancel()
+
+	conn, err := pool.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer pool.release()
+
+	response := &proto.TransactionResponse{}
+	if err = conn.Invoke(ctx, method, protoTransaction, response, grpc.WaitForReady(true)); err != nil {
+		return err
+	}
+
+	if response.NodeTransactionPrecheckCode != proto.ResponseCodeEnum_OK {
+		return hedera.ErrHederaPreCheckStatus{
+			TxID:   transaction.GetTransactionID(),
+			Status: hedera.Status(response.NodeTransactionPrecheckCode),
+		}
+	}
+
+	return nil
+}
+
+// getGrpcMethod gets the full gRPC method name of the service call which submits the transaction
+func getGrpcMethod(transaction *proto.Transaction) (string, error) {
+	signedTransaction := &proto.SignedTransaction{}
+	if err := protobuf.Unmarshal(transaction.SignedTransactionBytes, signedTransaction); err != nil {
+		return "", err
+	}
+
+	body := &proto.TransactionBody{}
+	if err := protobuf.Unmarshal(signedTransaction.BodyBytes, body); err != nil {
+		return "", err
+	}
+
+	switch body.Data.(type) {
+	case *proto.TransactionBody_CryptoTransfer:
+		return cryptoService + "cryptoTransfer", nil
+	case *proto.TransactionBody_ScheduleSign:
+		return scheduleService + "signSchedule", nil
+	case *proto.TransactionBody_TokenAssociate:
+		return tokenService + "associateTokens", nil
+	case *proto.TransactionBody_TokenBurn:
+		return tokenService + "burnToken", nil
+	case *proto.TransactionBody_TokenCreation:
+		return tokenService + "createToken", nil
+	case *proto.TransactionBody_TokenDeletion:
+		return tokenService + "deleteToken", nil
+	case *proto.TransactionBody_TokenDissociate:
+		return tokenService + "dissociateTokens", nil
+	case *proto.TransactionBody_TokenFreeze:
+		return tokenService + "freezeTokenAccount", nil
+	case *proto.TransactionBody_TokenGrantKyc:
+		return tokenService + "grantKycToTokenAccount", nil
+	case *proto.TransactionBody_TokenMint:
+		return tokenService + "mintToken", nil
+	case *proto.TransactionBody_TokenRevokeKyc:
+		return tokenService + "revokeKycFromTokenAccount", nil
+	case *proto.TransactionBody_TokenUnfreeze:
+		return tokenService + "unfreezeTokenAccount", nil
+	case *proto.TransactionBody_TokenUpdate:
+		return tokenService + "updateToken", nil
+	case *proto.TransactionBody_TokenWipe:
+		return tokenService + "wipeTokenAccount", nil
+	default:
+		return "", fmt.Errorf("unsupported transaction type %T", body.Data)
+	}
+}
+
+// newPooledGrpcBroadcaster dials poolSize connections to each node in the network without blocking, so they are
+// established in the background and ready when the first burst of submits arrives. The connections of a node are
+// spread over its addresses
+func newPooledGrpcBroadcaster(config types.Broadcast, network map[string]hedera.AccountID) (Broadcaster, error) {
+	grpcConfig := config.Grpc
+	nodeAddresses := make(map[hedera.AccountID][]string)
+	for address, nodeAccountId := range network {
+		nodeAddresses[nodeAccountId] = append(nodeAddresses[nodeAccountId], address)
+	}
+
+	options := []grpc.DialOption{
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff:           backoff.DefaultConfig,
+			MinConnectTimeout: time.Duration(grpcConfig.ConnectTimeout) * time.Millisecond,
+		}),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                time.Duration(grpcConfig.KeepaliveTime) * time.Millisecond,
+			Timeout:             time.Duration(grpcConfig.KeepaliveTimeout) * time.Millisecond,
+			PermitWithoutStream: true,
+		}),
+	}
+
+	pools := make(map[hedera.AccountID]*channelPool, len(nodeAddresses))
+	for nodeAccountId, addresses := range nodeAddresses {
+		sort.Strings(addresses)
+		pool := &channelPool{conns: make([]*grpc.ClientConn, 0, grpcConfig.PoolSize)}
+		if grpcConfig.MaxConcurrentStreams > 0 {
+			pool.streams = make(chan struct{}, grpcConfig.MaxConcurrentStreams*grpcConfig.PoolSize)
+		}
+
+		for i := 0; i < grpcConfig.PoolSize; i++ {
+			address := addresses[i%len(addresses)]
+			conn, err := grpc.Dial(address, append(options, getTransportSecurity(address))...)
+			if err != nil {
+				return nil, err
+			}
+			pool.conns = append(pool.conns, conn)
+		}
+
+		pools[nodeAccountId] = pool
+		log.Infof("Created %d gRPC connections to node %s", grpcConfig.PoolSize, nodeAccountId)
+	}
+
+	return &pooledGrpcBroadcaster{pools: pools, timeout: time.Duration(config.Timeout) * time.Millisecond}, nil
+}
+
+// getTransportSecurity uses TLS for the consensus node TLS ports the same way as the SDK, otherwise plaintext
+func getTransportSecurity(address string) grpc.DialOption {
+	if strings.HasSuffix(address, ":443") || strings.HasSuffix(address, ":50212") {
+		return grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true}))
+	}
+
+	return grpc.WithInsecure()
+}
@@ -0,0 +1,180 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package construction
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/types"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/hashgraph/hedera-sdk-go/v2/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+type fakeCryptoService struct {
+	proto.UnimplementedCryptoServiceServer
+	mutex        sync.Mutex
+	precheckCode proto.ResponseCodeEnum
+	received     []*proto.Transaction
+}
+
+func (f *fakeCryptoService) CryptoTransfer(_ context.Context, transaction *proto.Transaction) (
+	*proto.TransactionResponse,
+	error,
+) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.received = append(f.received, transaction)
+	return &proto.TransactionResponse{NodeTransactionPrecheckCode: f.precheckCode}, nil
+}
+
+func newFakeConsensusNode(t *testing.T, service *fakeCryptoService) (string, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	proto.RegisterCryptoServiceServer(server, service)
+	go server.Serve(listener)
+	return listener.Addr().String(), server.Stop
+}
+
+func newPooledBroadcastConfig() types.Broadcast {
+	return types.Broadcast{
+		Grpc: types.GrpcBroadcast{
+			ConnectTimeout:       1000,
+			KeepaliveTime:        10000,
+			KeepaliveTimeout:     1000,
+			MaxConcurrentStreams: 10,
+			PoolSize:             2,
+		},
+		Timeout: 5000,
+		Type:    BroadcastTypeGrpc,
+	}
+}
+
+func TestNewBroadcasterPooledGrpc(t *testing.T) {
+	// given
+	network := map[string]hedera.AccountID{
+		"127.0.0.1:50211": {Account: 3},
+		"127.0.0.2:50211": {Account: 3},
+		"127.0.0.3:50211": {Account: 4},
+	}
+
+	// when
+	actual, err := NewBroadcaster(newPooledBroadcastConfig(), hedera.ClientForNetwork(network))
+
+	// then
+	assert.NoError(t, err)
+	assert.IsType(t, &pooledGrpcBroadcaster{}, actual)
+	pools := actual.(*pooledGrpcBroadcaster).pools
+	assert.Len(t, pools, 2)
+	for _, nodeAccountId := range []hedera.AccountID{{Account: 3}, {Account: 4}} {
+		assert.Len(t, pools[nodeAccountId].conns, 2)
+		assert.Equal(t, 20, cap(pools[nodeAccountId].streams))
+	}
+	assert.NotEqual(t, pools[hedera.AccountID{Account: 3}].conns[0].Target(),
+		pools[hedera.AccountID{Account: 3}].conns[1].Target())
+}
+
+func TestPooledGrpcBroadcast(t *testing.T) {
+	var tests = []struct {
+		name          string
+		precheckCode  proto.ResponseCodeEnum
+		submitFailure bool
+	}{
+		{name: "Success", precheckCode: proto.ResponseCodeEnum_OK},
+		{name: "PrecheckFailed", precheckCode: proto.ResponseCodeEnum_INSUFFICIENT_PAYER_BALANCE},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			service := &fakeCryptoService{precheckCode: tt.precheckCode}
+			address, stop := newFakeConsensusNode(t, service)
+			defer stop()
+			transaction, _, _ := getSignedTransaction(t)
+			network := map[string]hedera.AccountID{address: transaction.GetNodeAccountIDs()[0]}
+			broadcaster, err := newPooledGrpcBroadcaster(newPooledBroadcastConfig(), network)
+			assert.NoError(t, err)
+
+			// when
+			err = broadcaster.Broadcast(transaction)
+
+			// then
+			service.mutex.Lock()
+			assert.Len(t, service.received, 1)
+			service.mutex.Unlock()
+			if tt.precheckCode == proto.ResponseCodeEnum_OK {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, hedera.ErrHederaPreCheckStatus{
+					TxID:   transaction.GetTransactionID(),
+					Status: hedera.Status(tt.precheckCode),
+				}, err)
+				assert.False(t, isSubmitFailure(err))
+			}
+		})
+	}
+}
+
+func TestPooledGrpcBroadcastUnknownNode(t *testing.T) {
+	// given
+	broadcaster, err := newPooledGrpcBroadcaster(
+		newPooledBroadcastConfig(),
+		map[string]hedera.AccountID{"127.0.0.1:50211": {Account: 1000}},
+	)
+	assert.NoError(t, err)
+	transaction, _, _ := getSignedTransaction(t)
+
+	// when
+	err = broadcaster.Broadcast(transaction)
+
+	// then
+	assert.Error(t, err)
+	assert.True(t, isSubmitFailure(err))
+}
+
+func TestChannelPoolAcquire(t *testing.T) {
+	// given
+	conns := []*grpc.ClientConn{{}, {}}
+	pool := &channelPool{conns: conns, streams: make(chan struct{}, 2)}
+
+	// when
+	first, err1 := pool.acquire(context.Background())
+	second, err2 := pool.acquire(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err3 := pool.acquire(ctx)
+	pool.release()
+	third, err4 := pool.acquire(context.Background())
+
+	// then
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.NotSame(t, first, second)
+	assert.Equal(t, context.DeadlineExceeded, err3)
+	assert.NoError(t, err4)
+	assert.Same(t, first, third)
+}
@@ -0,0 +1,130 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package construction
+
+import (
+	"fmt"
+	"sort"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-sdk-go/v2/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// ParseModeLenient parses a transaction with unmodeled fields and lists them in the metadata
+	ParseModeLenient = "lenient"
+	// ParseModeStrict rejects a transaction with unmodeled fields, since signing it would authorize effects the
+	// operations don't show
+	ParseModeStrict = "strict"
+
+	metadataUnmodeledFields = "unmodeled_fields"
+)
+
+type fieldSet map[protoreflect.Name]bool
+
+func newFieldSet(names ...protoreflect.Name) fieldSet {
+	set := make(fieldSet, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
+
+// modeledFields are the fields of the transaction body and the nested messages which the constructors turn into
+// operations or use to build them. A message without an entry is modeled as a whole
+var modeledFields = map[protoreflect.FullName]fieldSet{
+	"proto.AccountAmount":                       newFieldSet("accountID", "amount"),
+	"proto.CryptoTransferTransactionBody":       newFieldSet("transfers", "tokenTransfers"),
+	"proto.ScheduleSignTransactionBody":         newFieldSet("scheduleID"),
+	"proto.TokenAssociateTransactionBody":       newFieldSet("account", "tokens"),
+	"proto.TokenBurnTransactionBody":            newFieldSet("token", "amount"),
+	"proto.TokenDeleteTransactionBody":          newFieldSet("token"),
+	"proto.TokenDissociateTransactionBody":      newFieldSet("account", "tokens"),
+	"proto.TokenFreezeAccountTransactionBody":   newFieldSet("token", "account"),
+	"proto.TokenGrantKycTransactionBody":        newFieldSet("token", "account"),
+	"proto.TokenMintTransactionBody":            newFieldSet("token", "amount"),
+	"proto.TokenRevokeKycTransactionBody":       newFieldSet("token", "account"),
+	"proto.TokenTransferList":                   newFieldSet("token", "transfers"),
+	"proto.TokenUnfreezeAccountTransactionBody": newFieldSet("token", "account"),
+	"proto.TokenWipeAccountTransactionBody":     newFieldSet("token", "account", "amount"),
+	"proto.TransferList":                        newFieldSet("accountAmounts"),
+	"proto.TokenCreateTransactionBody": newFieldSet("name", "symbol", "decimals", "initialSupply", "treasury",
+		"adminKey", "kycKey", "freezeKey", "wipeKey", "supplyKey", "freezeDefault", "expiry", "autoRenewAccount",
+		"autoRenewPeriod", "memo"),
+	"proto.TokenUpdateTransactionBody": newFieldSet("token", "symbol", "name", "treasury", "adminKey", "kycKey",
+		"freezeKey", "wipeKey", "supplyKey", "autoRenewAccount", "autoRenewPeriod", "expiry"),
+	"proto.TransactionBody": newFieldSet("transactionID", "nodeAccountID", "transactionFee",
+		"transactionValidDuration"),
+}
+
+// getUnmodeledFields returns the sorted paths of the fields set in the transaction body which aren't modeled, e.g.
+// memo or tokenCreation.custom_fees. The transaction data itself is modeled by the constructor of its type
+func getUnmodeledFields(body *proto.TransactionBody) []string {
+	fields := make([]string, 0)
+	collectUnmodeledFields(body.ProtoReflect(), "", &fields)
+	sort.Strings(fields)
+	return fields
+}
+
+func collectUnmodeledFields(message protoreflect.Message, prefix string, fields *[]string) {
+	modeled, ok := modeledFields[message.Descriptor().FullName()]
+	if !ok {
+		return
+	}
+
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		path := prefix + string(field.Name())
+		// the data oneof of the transaction body is the transaction type
+		isData := field.ContainingOneof() != nil && field.ContainingOneof().Name() == "data"
+		if !modeled[field.Name()] && !isData {
+			*fields = append(*fields, path)
+			return true
+		}
+
+		if field.Kind() != protoreflect.MessageKind {
+			return true
+		}
+
+		if field.IsList() {
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				collectUnmodeledFields(list.Get(i).Message(), fmt.Sprintf("%s[%d].", path, i), fields)
+			}
+		} else {
+			collectUnmodeledFields(value.Message(), path+".", fields)
+		}
+		return true
+	})
+}
+
+// checkUnmodeledFields rejects the transaction with unmodeled fields in strict mode, otherwise returns the unmodeled
+// fields to be reported as a warning
+func checkUnmodeledFields(body *proto.TransactionBody, parseMode string) ([]string, *rTypes.Error) {
+	fields := getUnmodeledFields(body)
+
+	if len(fields) != 0 && parseMode == ParseModeStrict {
+		return nil, errors.AddErrorDetails(errors.ErrTransactionUnmodeledFields, errors.DetailFields, fields)
+	}
+
+	return fields, nil
+}
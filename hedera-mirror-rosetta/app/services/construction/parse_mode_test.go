@@ -0,0 +1,200 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package construction
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/hashgraph/hedera-sdk-go/v2/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	parseModeNodeAccountId = hedera.AccountID{Account: 3}
+	parseModePayer         = hedera.AccountID{Account: 1001}
+	parseModeReceiver      = hedera.AccountID{Account: 1002}
+	parseModeTokenId       = hedera.TokenID{Token: 2001}
+)
+
+func newParseModeTransferTransaction(memo string, nft bool) ITransaction {
+	transaction := hedera.NewTransferTransaction().
+		AddHbarTransfer(parseModePayer, hedera.HbarFromTinybar(-10)).
+		AddHbarTransfer(parseModeReceiver, hedera.HbarFromTinybar(10)).
+		SetTransactionMemo(memo).
+		SetTransactionID(hedera.TransactionIDGenerate(parseModePayer)).
+		SetNodeAccountIDs([]hedera.AccountID{parseModeNodeAccountId})
+	if nft {
+		transaction.AddNftTransfer(parseModeTokenId.Nft(1), parseModePayer, parseModeReceiver)
+	}
+	_, _ = transaction.Freeze()
+	return transaction
+}
+
+func newParseModeTokenCreateTransaction(maxSupply int64) ITransaction {
+	transaction := hedera.NewTokenCreateTransaction().
+		SetTokenName("foo").
+		SetTokenSymbol("bar").
+		SetTreasuryAccountID(parseModePayer).
+		SetMaxSupply(maxSupply).
+		SetTransactionID(hedera.TransactionIDGenerate(parseModePayer)).
+		SetNodeAccountIDs([]hedera.AccountID{parseModeNodeAccountId})
+	_, _ = transaction.Freeze()
+	return transaction
+}
+
+func getParseModeTransactionBody(transaction ITransaction) *proto.TransactionBody {
+	transactionBytes, _ := transaction.ToBytes()
+	decoded, _ := decodeTransaction(hex.EncodeToString(transactionBytes))
+	return decoded.body
+}
+
+func TestGetUnmodeledFields(t *testing.T) {
+	var tests = []struct {
+		name        string
+		transaction ITransaction
+		expected    []string
+	}{
+		{name: "Transfer", transaction: newParseModeTransferTransaction("", false), expected: []string{}},
+		{name: "TransferWithMemo", transaction: newParseModeTransferTransaction("memo", false), expected: []string{"memo"}},
+		{
+			name:        "TransferWithNft",
+			transaction: newParseModeTransferTransaction("memo", true),
+			expected:    []string{"cryptoTransfer.tokenTransfers[0].nftTransfers", "memo"},
+		},
+		{name: "TokenCreate", transaction: newParseModeTokenCreateTransaction(0), expected: []string{}},
+		{
+			name:        "TokenCreateWithMaxSupply",
+			transaction: newParseModeTokenCreateTransaction(100),
+			expected:    []string{"tokenCreation.maxSupply"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			actual := getUnmodeledFields(getParseModeTransactionBody(tt.transaction))
+
+			// then
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestCheckUnmodeledFields(t *testing.T) {
+	var tests = []struct {
+		parseMode   string
+		memo        string
+		expected    []string
+		expectedErr bool
+	}{
+		{parseMode: ParseModeLenient, expected: []string{}},
+		{parseMode: ParseModeLenient, memo: "memo", expected: []string{"memo"}},
+		{parseMode: ParseModeStrict, expected: []string{}},
+		{parseMode: ParseModeStrict, memo: "memo", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.parseMode+tt.memo, func(t *testing.T) {
+			// when
+			body := getParseModeTransactionBody(newParseModeTransferTransaction(tt.memo, false))
+			actual, err := checkUnmodeledFields(body, tt.parseMode)
+
+			// then
+			if tt.expectedErr {
+				assert.Equal(t, errors.AddErrorDetails(
+					errors.ErrTransactionUnmodeledFields,
+					errors.DetailFields,
+					[]string{"memo"},
+				), err)
+				assert.Nil(t, actual)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestConstructionParseWithParseMode(t *testing.T) {
+	transactionBytes, _ := newParseModeTransferTransaction("memo", false).ToBytes()
+	transaction := hex.EncodeToString(transactionBytes)
+
+	var tests = []struct {
+		parseMode        string
+		expectedMetadata map[string]interface{}
+		expectedErr      bool
+	}{
+		{parseMode: "", expectedMetadata: map[string]interface{}{metadataUnmodeledFields: []string{"memo"}}},
+		{parseMode: ParseModeLenient, expectedMetadata: map[string]interface{}{metadataUnmodeledFields: []string{"memo"}}},
+		{parseMode: ParseModeStrict, expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.parseMode, func(t *testing.T) {
+			// given
+			mockConstructor := &mockTransactionConstructor{}
+			mockConstructor.On("Parse", mock.IsType(&hedera.TransferTransaction{})).
+				Return([]*types.Operation{}, []hedera.AccountID{}, nilError)
+			service, _ := NewConstructionAPIService(ConstructionAPIServiceOptions{
+				Network:                defaultNetwork,
+				Nodes:                  defaultNodes,
+				Broadcast:              defaultBroadcast,
+				ParseMode:              tt.parseMode,
+				Settings:               config.NewSettings(),
+				TransactionConstructor: mockConstructor,
+			})
+
+			// when
+			actual, err := service.ConstructionParse(nil, dummyConstructionParseRequest(transaction, false))
+
+			// then
+			if tt.expectedErr {
+				assert.Equal(t, errors.ErrTransactionUnmodeledFields.Code, err.Code)
+				assert.Nil(t, actual)
+				mockConstructor.AssertNotCalled(t, "Parse")
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expectedMetadata, actual.Metadata)
+			}
+		})
+	}
+}
+
+func TestNewConstructionAPIServiceWithUnsupportedParseMode(t *testing.T) {
+	// when
+	actual, err := NewConstructionAPIService(ConstructionAPIServiceOptions{
+		Network:   defaultNetwork,
+		Nodes:     defaultNodes,
+		Broadcast: defaultBroadcast,
+		ParseMode: "loose",
+		Settings:  config.NewSettings(),
+	})
+
+	// then
+	assert.Error(t, err)
+	assert.Nil(t, actual)
+}
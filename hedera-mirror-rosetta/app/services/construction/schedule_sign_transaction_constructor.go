@@ -0,0 +1,151 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package construction
+
+import (
+	"reflect"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/go-playground/validator/v10"
+	hErrors "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+)
+
+// scheduleSign holds the schedule id as a string since the sdk's ScheduleID json unmarshaller discards the result
+type scheduleSign struct {
+	ScheduleId string `json:"schedule_id" validate:"required"`
+}
+
+type scheduleSignTransactionConstructor struct {
+	settings        *config.Settings
+	transactionType string
+	validate        *validator.Validate
+}
+
+func (s *scheduleSignTransactionConstructor) Construct(
+	nodeAccountId hedera.AccountID,
+	operations []*rTypes.Operation,
+	maxTransactionFee hedera.Hbar,
+) (ITransaction, []hedera.AccountID, *rTypes.Error) {
+	payer, scheduleId, rErr := s.preprocess(operations)
+	if rErr != nil {
+		return nil, nil, rErr
+	}
+
+	tx, err := hedera.NewScheduleSignTransaction().
+		SetScheduleID(*scheduleId).
+		SetNodeAccountIDs([]hedera.AccountID{nodeAccountId}).
+		SetTransactionID(newTransactionId(*payer, s.settings.TransactionValidStartBackdate)).
+		SetTransactionValidDuration(s.settings.TransactionValidDuration).
+		SetMaxTransactionFee(maxTransactionFee).
+		Freeze()
+	if err != nil {
+		return nil, nil, hErrors.ErrTransactionFreezeFailed
+	}
+
+	return tx, []hedera.AccountID{*payer}, nil
+}
+
+func (s *scheduleSignTransactionConstructor) Parse(transaction ITransaction) (
+	[]*rTypes.Operation,
+	[]hedera.AccountID,
+	*rTypes.Error,
+) {
+	scheduleSignTransaction, ok := transaction.(*hedera.ScheduleSignTransaction)
+	if !ok {
+		return nil, nil, hErrors.ErrTransactionInvalidType
+	}
+
+	payer := scheduleSignTransaction.GetTransactionID().AccountID
+	scheduleId := scheduleSignTransaction.GetScheduleID()
+
+	if payer == nil || isZeroAccountId(*payer) || isZeroScheduleId(scheduleId) {
+		return nil, nil, hErrors.ErrInvalidTransaction
+	}
+
+	operation := &rTypes.Operation{
+		OperationIdentifier: &rTypes.OperationIdentifier{Index: 0},
+		Type:                s.GetOperationType(),
+		Account:             &rTypes.AccountIdentifier{Address: payer.String()},
+		Metadata: map[string]interface{}{
+			"schedule_id": scheduleId.String(),
+		},
+	}
+
+	return []*rTypes.Operation{operation}, []hedera.AccountID{*payer}, nil
+}
+
+func (s *scheduleSignTransactionConstructor) Preprocess(operations []*rTypes.Operation) (
+	[]hedera.AccountID,
+	*rTypes.Error,
+) {
+	payer, _, err := s.preprocess(operations)
+	if err != nil {
+		return nil, err
+	}
+
+	return []hedera.AccountID{*payer}, nil
+}
+
+func (s *scheduleSignTransactionConstructor) preprocess(operations []*rTypes.Operation) (
+	*hedera.AccountID,
+	*hedera.ScheduleID,
+	*rTypes.Error,
+) {
+	if rErr := validateOperations(operations, 1, s.GetOperationType(), true); rErr != nil {
+		return nil, nil, rErr
+	}
+
+	operation := operations[0]
+	scheduleSign := &scheduleSign{}
+	if rErr := parseOperationMetadata(s.validate, scheduleSign, operation.Metadata); rErr != nil {
+		return nil, nil, rErr
+	}
+
+	scheduleId, err := parseScheduleId(scheduleSign.ScheduleId, s.settings.LedgerId)
+	if err != nil || isZeroScheduleId(scheduleId) {
+		return nil, nil, invalidEntityIdError(err, hErrors.ErrInvalidSchedule)
+	}
+
+	payer, err := parseAccountId(operation.Account.Address, s.settings.LedgerId)
+	if err != nil || isZeroAccountId(payer) {
+		return nil, nil, invalidEntityIdError(err, hErrors.ErrInvalidAccount)
+	}
+
+	return &payer, &scheduleId, nil
+}
+
+func (s *scheduleSignTransactionConstructor) GetOperationType() string {
+	return config.OperationTypeScheduleSign
+}
+
+func (s *scheduleSignTransactionConstructor) GetSdkTransactionType() string {
+	return s.transactionType
+}
+
+func newScheduleSignTransactionConstructor(settings *config.Settings) transactionConstructorWithType {
+	return &scheduleSignTransactionConstructor{
+		settings:        settings,
+		transactionType: reflect.TypeOf(hedera.ScheduleSignTransaction{}).Name(),
+		validate:        validator.New(),
+	}
+}
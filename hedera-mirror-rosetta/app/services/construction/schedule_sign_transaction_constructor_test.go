@@ -0,0 +1,286 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package construction
+
+import (
+	"testing"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+var scheduleId = hedera.ScheduleID{Schedule: 1500}
+
+func TestScheduleSignTransactionConstructorSuite(t *testing.T) {
+	suite.Run(t, new(scheduleSignTransactionConstructorSuite))
+}
+
+type scheduleSignTransactionConstructorSuite struct {
+	suite.Suite
+}
+
+func (suite *scheduleSignTransactionConstructorSuite) TestNewTransactionConstructor() {
+	h := newScheduleSignTransactionConstructor(config.NewSettings())
+	assert.NotNil(suite.T(), h)
+}
+
+func (suite *scheduleSignTransactionConstructorSuite) TestGetOperationType() {
+	h := newScheduleSignTransactionConstructor(config.NewSettings())
+	assert.Equal(suite.T(), config.OperationTypeScheduleSign, h.GetOperationType())
+}
+
+func (suite *scheduleSignTransactionConstructorSuite) TestGetSdkTransactionType() {
+	h := newScheduleSignTransactionConstructor(config.NewSettings())
+	assert.Equal(suite.T(), "ScheduleSignTransaction", h.GetSdkTransactionType())
+}
+
+func (suite *scheduleSignTransactionConstructorSuite) TestConstruct() {
+	var tests = []struct {
+		name             string
+		updateOperations updateOperationsFunc
+		expectError      bool
+	}{
+		{
+			name: "Success",
+		},
+		{
+			name: "EmptyOperations",
+			updateOperations: func([]*rTypes.Operation) []*rTypes.Operation {
+				return make([]*rTypes.Operation, 0)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// given
+			operations := getScheduleSignOperations()
+			h := newScheduleSignTransactionConstructor(config.NewSettings())
+
+			if tt.updateOperations != nil {
+				operations = tt.updateOperations(operations)
+			}
+
+			// when
+			tx, signers, err := h.Construct(nodeAccountId, operations, maxTransactionFee)
+
+			// then
+			if tt.expectError {
+				assert.NotNil(t, err)
+				assert.Nil(t, signers)
+				assert.Nil(t, tx)
+			} else {
+				assert.Nil(t, err)
+				assert.ElementsMatch(t, []hedera.AccountID{payerId}, signers)
+				assertScheduleSignTransaction(t, operations[0], nodeAccountId, tx)
+			}
+		})
+	}
+}
+
+func (suite *scheduleSignTransactionConstructorSuite) TestParse() {
+	var tests = []struct {
+		name           string
+		getTransaction func() ITransaction
+		expectError    bool
+	}{
+		{
+			name: "Success",
+			getTransaction: func() ITransaction {
+				return hedera.NewScheduleSignTransaction().
+					SetNodeAccountIDs([]hedera.AccountID{nodeAccountId}).
+					SetScheduleID(scheduleId).
+					SetTransactionID(hedera.TransactionIDGenerate(payerId))
+			},
+		},
+		{
+			name: "InvalidTransaction",
+			getTransaction: func() ITransaction {
+				return hedera.NewTransferTransaction()
+			},
+			expectError: true,
+		},
+		{
+			name: "TransactionIDNotSet",
+			getTransaction: func() ITransaction {
+				return hedera.NewScheduleSignTransaction().
+					SetNodeAccountIDs([]hedera.AccountID{nodeAccountId}).
+					SetScheduleID(scheduleId)
+			},
+			expectError: true,
+		},
+		{
+			name: "ScheduleIDNotSet",
+			getTransaction: func() ITransaction {
+				return hedera.NewScheduleSignTransaction().
+					SetNodeAccountIDs([]hedera.AccountID{nodeAccountId}).
+					SetTransactionID(hedera.TransactionIDGenerate(payerId))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// given
+			expectedOperations := getScheduleSignOperations()
+			h := newScheduleSignTransactionConstructor(config.NewSettings())
+			tx := tt.getTransaction()
+
+			// when
+			operations, signers, err := h.Parse(tx)
+
+			// then
+			if tt.expectError {
+				assert.NotNil(t, err)
+				assert.Nil(t, operations)
+				assert.Nil(t, signers)
+			} else {
+				assert.Nil(t, err)
+				assert.ElementsMatch(t, []hedera.AccountID{payerId}, signers)
+				assert.ElementsMatch(t, expectedOperations, operations)
+			}
+		})
+	}
+}
+
+func (suite *scheduleSignTransactionConstructorSuite) TestPreprocess() {
+	var tests = []struct {
+		name             string
+		updateOperations updateOperationsFunc
+		expectError      bool
+	}{
+		{
+			name: "Success",
+		},
+		{
+			name: "InvalidAccountAddress",
+			updateOperations: func(operations []*rTypes.Operation) []*rTypes.Operation {
+				operations[0].Account.Address = "x.y.z"
+				return operations
+			},
+			expectError: true,
+		},
+		{
+			name: "NonNilAmount",
+			updateOperations: func(operations []*rTypes.Operation) []*rTypes.Operation {
+				operations[0].Amount = &rTypes.Amount{Value: "0", Currency: config.CurrencyHbar}
+				return operations
+			},
+			expectError: true,
+		},
+		{
+			name: "MissingMetadata",
+			updateOperations: func(operations []*rTypes.Operation) []*rTypes.Operation {
+				operations[0].Metadata = nil
+				return operations
+			},
+			expectError: true,
+		},
+		{
+			name: "InvalidScheduleId",
+			updateOperations: func(operations []*rTypes.Operation) []*rTypes.Operation {
+				operations[0].Metadata["schedule_id"] = "x.y.z"
+				return operations
+			},
+			expectError: true,
+		},
+		{
+			name: "ZeroScheduleId",
+			updateOperations: func(operations []*rTypes.Operation) []*rTypes.Operation {
+				operations[0].Metadata["schedule_id"] = "0.0.0"
+				return operations
+			},
+			expectError: true,
+		},
+		{
+			name: "MultipleOperations",
+			updateOperations: func(operations []*rTypes.Operation) []*rTypes.Operation {
+				return append(operations, &rTypes.Operation{})
+			},
+			expectError: true,
+		},
+		{
+			name: "InvalidOperationType",
+			updateOperations: func(operations []*rTypes.Operation) []*rTypes.Operation {
+				operations[0].Type = config.OperationTypeCryptoTransfer
+				return operations
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// given
+			operations := getScheduleSignOperations()
+			h := newScheduleSignTransactionConstructor(config.NewSettings())
+
+			if tt.updateOperations != nil {
+				operations = tt.updateOperations(operations)
+			}
+
+			// when
+			signers, err := h.Preprocess(operations)
+
+			// then
+			if tt.expectError {
+				assert.NotNil(t, err)
+				assert.Nil(t, signers)
+			} else {
+				assert.Nil(t, err)
+				assert.ElementsMatch(t, []hedera.AccountID{payerId}, signers)
+			}
+		})
+	}
+}
+
+func assertScheduleSignTransaction(
+	t *testing.T,
+	operation *rTypes.Operation,
+	nodeAccountId hedera.AccountID,
+	actual ITransaction,
+) {
+	assert.IsType(t, &hedera.ScheduleSignTransaction{}, actual)
+
+	tx, _ := actual.(*hedera.ScheduleSignTransaction)
+	payer := tx.GetTransactionID().AccountID.String()
+	schedule := tx.GetScheduleID().String()
+
+	assert.Equal(t, operation.Account.Address, payer)
+	assert.Equal(t, operation.Metadata["schedule_id"], schedule)
+	assert.ElementsMatch(t, []hedera.AccountID{nodeAccountId}, actual.GetNodeAccountIDs())
+}
+
+func getScheduleSignOperations() []*rTypes.Operation {
+	return []*rTypes.Operation{
+		{
+			OperationIdentifier: &rTypes.OperationIdentifier{Index: 0},
+			Type:                config.OperationTypeScheduleSign,
+			Account:             &rTypes.AccountIdentifier{Address: payerId.String()},
+			Metadata:            map[string]interface{}{"schedule_id": scheduleId.String()},
+		},
+	}
+}
@@ -32,6 +32,7 @@ import (
 
 type tokenAssociateDissociateTransactionConstructor struct {
 	operationType   string
+	settings        *config.Settings
 	tokenRepo       repositories.TokenRepository
 	transactionType string
 }
@@ -39,6 +40,7 @@ type tokenAssociateDissociateTransactionConstructor struct {
 func (t *tokenAssociateDissociateTransactionConstructor) Construct(
 	nodeAccountId hedera.AccountID,
 	operations []*rTypes.Operation,
+	maxTransactionFee hedera.Hbar,
 ) (ITransaction, []hedera.AccountID, *rTypes.Error) {
 	payer, tokenIds, rErr := t.preprocess(operations)
 	if rErr != nil {
@@ -52,14 +54,18 @@ func (t *tokenAssociateDissociateTransactionConstructor) Construct(
 			SetAccountID(*payer).
 			SetNodeAccountIDs([]hedera.AccountID{nodeAccountId}).
 			SetTokenIDs(tokenIds...).
-			SetTransactionID(hedera.TransactionIDGenerate(*payer)).
+			SetTransactionID(newTransactionId(*payer, t.settings.TransactionValidStartBackdate)).
+			SetTransactionValidDuration(t.settings.TransactionValidDuration).
+			SetMaxTransactionFee(maxTransactionFee).
 			Freeze()
 	} else {
 		tx, err = hedera.NewTokenDissociateTransaction().
 			SetAccountID(*payer).
 			SetNodeAccountIDs([]hedera.AccountID{nodeAccountId}).
 			SetTokenIDs(tokenIds...).
-			SetTransactionID(hedera.TransactionIDGenerate(*payer)).
+			SetTransactionID(newTransactionId(*payer, t.settings.TransactionValidStartBackdate)).
+			SetTransactionValidDuration(t.settings.TransactionValidDuration).
+			SetMaxTransactionFee(maxTransactionFee).
 			Freeze()
 	}
 
@@ -112,6 +118,14 @@ func (t *tokenAssociateDissociateTransactionConstructor) Parse(transaction ITran
 		return nil, nil, hErrors.ErrInvalidTransaction
 	}
 
+	seen := make(map[hedera.TokenID]bool, len(tokenIds))
+	for _, tokenId := range tokenIds {
+		if seen[tokenId] {
+			return nil, nil, hErrors.ErrInvalidTransaction
+		}
+		seen[tokenId] = true
+	}
+
 	account := &rTypes.AccountIdentifier{Address: accountId.String()}
 	operations := make([]*rTypes.Operation, 0, len(tokenIds))
 
@@ -156,7 +170,9 @@ func (t *tokenAssociateDissociateTransactionConstructor) preprocess(operations [
 		return nil, nil, rErr
 	}
 
+	// all tokens go in one transaction, which the network rejects if a token is repeated
 	tokenIds := make([]hedera.TokenID, 0, len(operations))
+	seen := make(map[hedera.TokenID]bool, len(operations))
 	address := operations[0].Account.Address
 	for _, operation := range operations {
 		if operation.Account.Address != address {
@@ -169,12 +185,16 @@ func (t *tokenAssociateDissociateTransactionConstructor) preprocess(operations [
 			return nil, nil, rErr
 		}
 
+		if seen[*token] {
+			return nil, nil, hErrors.AddErrorDetails(hErrors.ErrTokenRepeated, hErrors.DetailCurrency, currency.Symbol)
+		}
+		seen[*token] = true
 		tokenIds = append(tokenIds, *token)
 	}
 
-	payer, err := hedera.AccountIDFromString(address)
+	payer, err := parseAccountId(address, t.settings.LedgerId)
 	if err != nil {
-		return nil, nil, hErrors.ErrInvalidAccount
+		return nil, nil, invalidEntityIdError(err, hErrors.ErrInvalidAccount)
 	}
 
 	return &payer, tokenIds, nil
@@ -188,19 +208,28 @@ func (t *tokenAssociateDissociateTransactionConstructor) GetSdkTransactionType()
 	return t.transactionType
 }
 
-func newTokenAssociateTransactionConstructor(tokenRepo repositories.TokenRepository) transactionConstructorWithType {
+func newTokenAssociateTransactionConstructor(
+	tokenRepo repositories.TokenRepository,
+	settings *config.Settings,
+) transactionConstructorWithType {
 	transactionType := reflect.TypeOf(hedera.TokenAssociateTransaction{}).Name()
 	return &tokenAssociateDissociateTransactionConstructor{
 		operationType:   config.OperationTypeTokenAssociate,
+		settings:        settings,
 		tokenRepo:       tokenRepo,
 		transactionType: transactionType,
 	}
 }
 
-func newTokenDissociateTransactionConstructor(tokenRepo repositories.TokenRepository) transactionConstructorWithType {
+func newTokenDissociateTransactionConstructor(
+	tokenRepo repositories.TokenRepository,
+	settings *config.Settings,
+) transactionConstructorWithType {
 	transactionType := reflect.TypeOf(hedera.TokenDissociateTransaction{}).Name()
 	return &tokenAssociateDissociateTransactionConstructor{
 		operationType:   config.OperationTypeTokenDissociate,
+		settings:        settings,
 		tokenRepo:       tokenRepo,
-		transactionType: transactionType}
+		transactionType: transactionType,
+	}
 }
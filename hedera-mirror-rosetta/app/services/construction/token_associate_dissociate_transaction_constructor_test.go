@@ -59,6 +59,7 @@ var (
 		Name:     nameB,
 		Symbol:   symbolB,
 	}
+	maxTransactionFee = hedera.NewHbar(3)
 	nilErr            *rTypes.Error
 	nodeAccountId     = hedera.AccountID{Account: 7}
 	payerId           = hedera.AccountID{Account: 100}
@@ -91,7 +92,7 @@ var (
 	}
 )
 
-type newConstructorFunc func(repositories.TokenRepository) transactionConstructorWithType
+type newConstructorFunc func(repositories.TokenRepository, *config.Settings) transactionConstructorWithType
 type updateOperationsFunc func([]*rTypes.Operation) []*rTypes.Operation
 
 func TestTokenAssociateDissociateTransactionConstructorSuite(t *testing.T) {
@@ -103,12 +104,12 @@ type tokenAssociateDissociateTransactionConstructorSuite struct {
 }
 
 func (suite *tokenAssociateDissociateTransactionConstructorSuite) TestNewTokenAssociateTransactionConstructor() {
-	h := newTokenAssociateTransactionConstructor(&repository.MockTokenRepository{})
+	h := newTokenAssociateTransactionConstructor(&repository.MockTokenRepository{}, config.NewSettings())
 	assert.NotNil(suite.T(), h)
 }
 
 func (suite *tokenAssociateDissociateTransactionConstructorSuite) TestNewTokenDissociateTransactionConstructor() {
-	h := newTokenDissociateTransactionConstructor(&repository.MockTokenRepository{})
+	h := newTokenDissociateTransactionConstructor(&repository.MockTokenRepository{}, config.NewSettings())
 	assert.NotNil(suite.T(), h)
 }
 
@@ -132,7 +133,7 @@ func (suite *tokenAssociateDissociateTransactionConstructorSuite) TestGetOperati
 
 	for _, tt := range tests {
 		suite.T().Run(tt.name, func(t *testing.T) {
-			h := tt.newConsturctor(&repository.MockTokenRepository{})
+			h := tt.newConsturctor(&repository.MockTokenRepository{}, config.NewSettings())
 			assert.Equal(t, tt.expected, h.GetOperationType())
 		})
 	}
@@ -158,7 +159,7 @@ func (suite *tokenAssociateDissociateTransactionConstructorSuite) TestGetSdkTran
 
 	for _, tt := range tests {
 		suite.T().Run(tt.name, func(t *testing.T) {
-			h := tt.newHandler(&repository.MockTokenRepository{})
+			h := tt.newHandler(&repository.MockTokenRepository{}, config.NewSettings())
 			assert.Equal(t, tt.expected, h.GetSdkTransactionType())
 		})
 	}
@@ -190,6 +191,11 @@ func (suite *tokenAssociateDissociateTransactionConstructorSuite) TestConstruct(
 			},
 			expectError: true,
 		},
+		{
+			name:             "RepeatedToken",
+			updateOperations: repeatTokenA,
+			expectError:      true,
+		},
 	}
 
 	runTests := func(t *testing.T, operationType string, newHandler newConstructorFunc) {
@@ -198,7 +204,7 @@ func (suite *tokenAssociateDissociateTransactionConstructorSuite) TestConstruct(
 				// given
 				operations := suite.getOperations(operationType)
 				mockTokenRepo := &repository.MockTokenRepository{}
-				h := newHandler(mockTokenRepo)
+				h := newHandler(mockTokenRepo, config.NewSettings())
 
 				configMockTokenRepo(mockTokenRepo, mockTokenRepoConfigA)
 				configMockTokenRepo(mockTokenRepo, mockTokenRepoConfigB)
@@ -208,7 +214,7 @@ func (suite *tokenAssociateDissociateTransactionConstructorSuite) TestConstruct(
 				}
 
 				// when
-				tx, signers, err := h.Construct(nodeAccountId, operations)
+				tx, signers, err := h.Construct(nodeAccountId, operations, maxTransactionFee)
 
 				// then
 				if tt.expectError {
@@ -337,6 +343,25 @@ func (suite *tokenAssociateDissociateTransactionConstructorSuite) TestParse() {
 			},
 			expectError: true,
 		},
+		{
+			name: "TransactionTokenIDsRepeated",
+			getTransaction: func(operationType string) ITransaction {
+				if operationType == config.OperationTypeTokenAssociate {
+					return hedera.NewTokenAssociateTransaction().
+						SetAccountID(payerId).
+						SetNodeAccountIDs([]hedera.AccountID{nodeAccountId}).
+						SetTokenIDs(tokenIdA, tokenIdA).
+						SetTransactionID(hedera.TransactionIDGenerate(payerId))
+				}
+
+				return hedera.NewTokenDissociateTransaction().
+					SetNodeAccountIDs([]hedera.AccountID{nodeAccountId}).
+					SetAccountID(payerId).
+					SetTokenIDs(tokenIdA, tokenIdA).
+					SetTransactionID(hedera.TransactionIDGenerate(payerId))
+			},
+			expectError: true,
+		},
 		{
 			name: "TransactionAccountPayerMismatch",
 			getTransaction: func(operationType string) ITransaction {
@@ -365,7 +390,7 @@ func (suite *tokenAssociateDissociateTransactionConstructorSuite) TestParse() {
 				expectedOperations := suite.getOperations(operationType)
 
 				mockTokenRepo := &repository.MockTokenRepository{}
-				h := newHandler(mockTokenRepo)
+				h := newHandler(mockTokenRepo, config.NewSettings())
 				tx := tt.getTransaction(operationType)
 
 				if len(tt.mockTokenRepoConfigs) == 0 {
@@ -452,6 +477,11 @@ func (suite *tokenAssociateDissociateTransactionConstructorSuite) TestPreprocess
 			},
 			expectError: true,
 		},
+		{
+			name:             "RepeatedToken",
+			updateOperations: repeatTokenA,
+			expectError:      true,
+		},
 	}
 
 	runTests := func(t *testing.T, operationType string, newHandler newConstructorFunc) {
@@ -461,7 +491,7 @@ func (suite *tokenAssociateDissociateTransactionConstructorSuite) TestPreprocess
 				operations := suite.getOperations(operationType)
 
 				mockTokenRepo := &repository.MockTokenRepository{}
-				h := newHandler(mockTokenRepo)
+				h := newHandler(mockTokenRepo, config.NewSettings())
 
 				if len(tt.mockTokenRepoConfigs) == 0 {
 					configMockTokenRepo(mockTokenRepo, defaultMockTokenRepoConfigs...)
@@ -498,6 +528,22 @@ func (suite *tokenAssociateDissociateTransactionConstructorSuite) TestPreprocess
 	})
 }
 
+func (suite *tokenAssociateDissociateTransactionConstructorSuite) TestPreprocessRepeatedToken() {
+	// given
+	mockTokenRepo := &repository.MockTokenRepository{}
+	configMockTokenRepo(mockTokenRepo, defaultMockTokenRepoConfigs...)
+	h := newTokenAssociateTransactionConstructor(mockTokenRepo, config.NewSettings())
+	operations := repeatTokenA(suite.getOperations(config.OperationTypeTokenAssociate))
+
+	// when
+	signers, err := h.Preprocess(operations)
+
+	// then
+	expected := hErrors.AddErrorDetails(hErrors.ErrTokenRepeated, hErrors.DetailCurrency, tokenIdA.String())
+	assert.Equal(suite.T(), expected, err)
+	assert.Nil(suite.T(), signers)
+}
+
 func (suite *tokenAssociateDissociateTransactionConstructorSuite) getOperations(operationType string) []*rTypes.Operation {
 	return []*rTypes.Operation{
 		{
@@ -521,6 +567,12 @@ func (suite *tokenAssociateDissociateTransactionConstructorSuite) getOperations(
 	}
 }
 
+// repeatTokenA sets the currency of the second operation to token A, so token A is repeated in the operations
+func repeatTokenA(operations []*rTypes.Operation) []*rTypes.Operation {
+	operations[1].Amount.Currency = dbTokenA.ToRosettaCurrency()
+	return operations
+}
+
 func assertTokenAssociateDissociateTransaction(
 	t *testing.T,
 	operations []*rTypes.Operation,
@@ -39,6 +39,7 @@ type tokenAmount struct {
 
 type tokenBurnMintTransactionConstructor struct {
 	operationType   string
+	settings        *config.Settings
 	tokeRepo        repositories.TokenRepository
 	transactionType string
 }
@@ -46,6 +47,7 @@ type tokenBurnMintTransactionConstructor struct {
 func (t *tokenBurnMintTransactionConstructor) Construct(
 	nodeAccountId hedera.AccountID,
 	operations []*rTypes.Operation,
+	maxTransactionFee hedera.Hbar,
 ) (ITransaction, []hedera.AccountID, *rTypes.Error) {
 	payer, tokenAmount, rErr := t.preprocess(operations)
 	if rErr != nil {
@@ -60,14 +62,18 @@ func (t *tokenBurnMintTransactionConstructor) Construct(
 			SetAmount(tokenAmount.amount).
 			SetTokenID(tokenAmount.token).
 			SetNodeAccountIDs([]hedera.AccountID{nodeAccountId}).
-			SetTransactionID(hedera.TransactionIDGenerate(*payer)).
+			SetTransactionID(newTransactionId(*payer, t.settings.TransactionValidStartBackdate)).
+			SetTransactionValidDuration(t.settings.TransactionValidDuration).
+			SetMaxTransactionFee(maxTransactionFee).
 			Freeze()
 	} else {
 		tx, err = hedera.NewTokenMintTransaction().
 			SetAmount(tokenAmount.amount).
 			SetTokenID(tokenAmount.token).
 			SetNodeAccountIDs([]hedera.AccountID{nodeAccountId}).
-			SetTransactionID(hedera.TransactionIDGenerate(*payer)).
+			SetTransactionID(newTransactionId(*payer, t.settings.TransactionValidStartBackdate)).
+			SetTransactionValidDuration(t.settings.TransactionValidDuration).
+			SetMaxTransactionFee(maxTransactionFee).
 			Freeze()
 	}
 
@@ -169,9 +175,9 @@ func (t *tokenBurnMintTransactionConstructor) preprocess(operations []*rTypes.Op
 	}
 	tokenAmount.token = *tokenId
 
-	payer, err := hedera.AccountIDFromString(operation.Account.Address)
+	payer, err := parseAccountId(operation.Account.Address, t.settings.LedgerId)
 	if err != nil || isZeroAccountId(payer) {
-		return nil, nil, hErrors.ErrInvalidAccount
+		return nil, nil, invalidEntityIdError(err, hErrors.ErrInvalidAccount)
 	}
 
 	return &payer, tokenAmount, nil
@@ -185,19 +191,27 @@ func (t *tokenBurnMintTransactionConstructor) GetSdkTransactionType() string {
 	return t.transactionType
 }
 
-func newTokenBurnTransactionConstructor(tokenRepo repositories.TokenRepository) transactionConstructorWithType {
+func newTokenBurnTransactionConstructor(
+	tokenRepo repositories.TokenRepository,
+	settings *config.Settings,
+) transactionConstructorWithType {
 	transactionType := reflect.TypeOf(hedera.TokenBurnTransaction{}).Name()
 	return &tokenBurnMintTransactionConstructor{
 		operationType:   config.OperationTypeTokenBurn,
+		settings:        settings,
 		tokeRepo:        tokenRepo,
 		transactionType: transactionType,
 	}
 }
 
-func newTokenMintTransactionConstructor(tokenRepo repositories.TokenRepository) transactionConstructorWithType {
+func newTokenMintTransactionConstructor(
+	tokenRepo repositories.TokenRepository,
+	settings *config.Settings,
+) transactionConstructorWithType {
 	transactionType := reflect.TypeOf(hedera.TokenMintTransaction{}).Name()
 	return &tokenBurnMintTransactionConstructor{
 		operationType:   config.OperationTypeTokenMint,
+		settings:        settings,
 		tokeRepo:        tokenRepo,
 		transactionType: transactionType,
 	}
@@ -43,12 +43,12 @@ type tokenTokenBurnMintTransactionConstructorSuite struct {
 }
 
 func (suite *tokenTokenBurnMintTransactionConstructorSuite) TestNewTokenBurnTransactionConstructor() {
-	h := newTokenBurnTransactionConstructor(&repository.MockTokenRepository{})
+	h := newTokenBurnTransactionConstructor(&repository.MockTokenRepository{}, config.NewSettings())
 	assert.NotNil(suite.T(), h)
 }
 
 func (suite *tokenTokenBurnMintTransactionConstructorSuite) TestNewTokenMintTransactionConstructor() {
-	h := newTokenMintTransactionConstructor(&repository.MockTokenRepository{})
+	h := newTokenMintTransactionConstructor(&repository.MockTokenRepository{}, config.NewSettings())
 	assert.NotNil(suite.T(), h)
 }
 
@@ -72,7 +72,7 @@ func (suite *tokenTokenBurnMintTransactionConstructorSuite) TestGetOperationType
 
 	for _, tt := range tests {
 		suite.T().Run(tt.name, func(t *testing.T) {
-			h := tt.newHandler(&repository.MockTokenRepository{})
+			h := tt.newHandler(&repository.MockTokenRepository{}, config.NewSettings())
 			assert.Equal(t, tt.expected, h.GetOperationType())
 		})
 	}
@@ -98,7 +98,7 @@ func (suite *tokenTokenBurnMintTransactionConstructorSuite) TestGetSdkTransactio
 
 	for _, tt := range tests {
 		suite.T().Run(tt.name, func(t *testing.T) {
-			h := tt.newHandler(&repository.MockTokenRepository{})
+			h := tt.newHandler(&repository.MockTokenRepository{}, config.NewSettings())
 			assert.Equal(t, tt.expected, h.GetSdkTransactionType())
 		})
 	}
@@ -128,7 +128,7 @@ func (suite *tokenTokenBurnMintTransactionConstructorSuite) TestConstruct() {
 				// given
 				operations := suite.getOperations(operationType)
 				mockTokenRepo := &repository.MockTokenRepository{}
-				h := newHandler(mockTokenRepo)
+				h := newHandler(mockTokenRepo, config.NewSettings())
 
 				configMockTokenRepo(mockTokenRepo, defaultMockTokenRepoConfigs[0])
 
@@ -137,7 +137,7 @@ func (suite *tokenTokenBurnMintTransactionConstructorSuite) TestConstruct() {
 				}
 
 				// when
-				tx, signers, err := h.Construct(nodeAccountId, operations)
+				tx, signers, err := h.Construct(nodeAccountId, operations, maxTransactionFee)
 
 				// then
 				if tt.expectError {
@@ -258,7 +258,7 @@ func (suite *tokenTokenBurnMintTransactionConstructorSuite) TestParse() {
 				expectedOperations := suite.getOperations(operationType)
 
 				mockTokenRepo := &repository.MockTokenRepository{}
-				h := newHandler(mockTokenRepo)
+				h := newHandler(mockTokenRepo, config.NewSettings())
 				tx := tt.getTransaction(operationType)
 
 				if tt.tokenRepoErr {
@@ -366,7 +366,7 @@ func (suite *tokenTokenBurnMintTransactionConstructorSuite) TestPreprocess() {
 				operations := suite.getOperations(operationType)
 
 				mockTokenRepo := &repository.MockTokenRepository{}
-				h := newHandler(mockTokenRepo)
+				h := newHandler(mockTokenRepo, config.NewSettings())
 
 				if tt.tokenRepoErr {
 					configMockTokenRepo(mockTokenRepo, mockTokenRepoNotFoundConfigs[0])
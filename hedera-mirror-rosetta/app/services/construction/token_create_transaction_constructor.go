@@ -31,33 +31,41 @@ import (
 	"github.com/hashgraph/hedera-sdk-go/v2"
 )
 
+const (
+	// minAutoRenewPeriod and maxAutoRenewPeriod are the HAPI bounds in seconds of an entity's auto renew period
+	minAutoRenewPeriod int64 = 6999999
+	maxAutoRenewPeriod int64 = 8000001
+)
+
 type tokenCreate struct {
-	AdminKey         publicKey        `json:"admin_key"`
-	AutoRenewAccount hedera.AccountID `json:"auto_renew_account"`
-	AutoRenewPeriod  int64            `json:"auto_renew_period"`
-	Decimals         uint32           `json:"decimals"`
-	Expiry           int64            `json:"expiry"`
-	FreezeDefault    bool             `json:"freeze_default"`
-	FreezeKey        publicKey        `json:"freeze_key"`
-	InitialSupply    uint64           `json:"initial_supply"`
-	KycKey           publicKey        `json:"kyc_key"`
-	Memo             string           `json:"memo"`
-	Name             string           `json:"name" validate:"required"`
-	SupplyKey        publicKey        `json:"supply_key"`
-	Symbol           string           `json:"symbol" validate:"required"`
-	WipeKey          publicKey        `json:"wipe_key"`
+	AdminKey         publicKey         `json:"admin_key"`
+	AutoRenewAccount metadataAccountId `json:"auto_renew_account"`
+	AutoRenewPeriod  int64             `json:"auto_renew_period"`
+	Decimals         uint32            `json:"decimals"`
+	Expiry           int64             `json:"expiry"`
+	FreezeDefault    bool              `json:"freeze_default"`
+	FreezeKey        publicKey         `json:"freeze_key"`
+	InitialSupply    uint64            `json:"initial_supply"`
+	KycKey           publicKey         `json:"kyc_key"`
+	Memo             string            `json:"memo"`
+	Name             string            `json:"name" validate:"required"`
+	SupplyKey        publicKey         `json:"supply_key"`
+	Symbol           string            `json:"symbol" validate:"required"`
+	WipeKey          publicKey         `json:"wipe_key"`
 }
 
 type tokenCreateTransactionConstructor struct {
+	autoRenewPeriod int64
+	settings        *config.Settings
 	transactionType string
 	validate        *validator.Validate
 }
 
-func (t *tokenCreateTransactionConstructor) Construct(nodeAccountId hedera.AccountID, operations []*rTypes.Operation) (
-	ITransaction,
-	[]hedera.AccountID,
-	*rTypes.Error,
-) {
+func (t *tokenCreateTransactionConstructor) Construct(
+	nodeAccountId hedera.AccountID,
+	operations []*rTypes.Operation,
+	maxTransactionFee hedera.Hbar,
+) (ITransaction, []hedera.AccountID, *rTypes.Error) {
 	treasury, signers, tokenCreate, err := t.preprocess(operations)
 	if err != nil {
 		return nil, nil, err
@@ -68,22 +76,26 @@ func (t *tokenCreateTransactionConstructor) Construct(nodeAccountId hedera.Accou
 		SetDecimals(uint(tokenCreate.Decimals)).
 		SetFreezeDefault(tokenCreate.FreezeDefault).
 		SetInitialSupply(tokenCreate.InitialSupply).
+		SetMaxTransactionFee(maxTransactionFee).
 		SetTokenMemo(tokenCreate.Memo).
 		SetTokenName(tokenCreate.Name).
 		SetTokenSymbol(tokenCreate.Symbol).
-		SetTransactionID(hedera.TransactionIDGenerate(treasury)).
+		SetTransactionID(newTransactionId(treasury, t.settings.TransactionValidStartBackdate)).
+		SetTransactionValidDuration(t.settings.TransactionValidDuration).
 		SetTreasuryAccountID(treasury)
 
 	if !isEmptyPublicKey(tokenCreate.AdminKey) {
 		tx.SetAdminKey(tokenCreate.AdminKey.PublicKey)
 	}
 
-	if !isZeroAccountId(tokenCreate.AutoRenewAccount) {
-		tx.SetAutoRenewAccount(tokenCreate.AutoRenewAccount)
+	if !isZeroAccountId(tokenCreate.AutoRenewAccount.AccountID) {
+		tx.SetAutoRenewAccount(tokenCreate.AutoRenewAccount.AccountID)
 	}
 
 	if tokenCreate.AutoRenewPeriod != 0 {
 		tx.SetAutoRenewPeriod(time.Second * time.Duration(tokenCreate.AutoRenewPeriod))
+	} else if t.autoRenewPeriod != 0 {
+		tx.SetAutoRenewPeriod(time.Second * time.Duration(t.autoRenewPeriod))
 	}
 
 	if tokenCreate.Expiry != 0 {
@@ -225,24 +237,44 @@ func (t *tokenCreateTransactionConstructor) preprocess(operations []*rTypes.Oper
 		return hedera.AccountID{}, nil, nil, rErr
 	}
 
+	if rErr := resolveMetadataAccountIds(t.settings.LedgerId, &tokenCreate.AutoRenewAccount); rErr != nil {
+		return hedera.AccountID{}, nil, nil, rErr
+	}
+
+	if tokenCreate.AutoRenewPeriod != 0 && !isValidAutoRenewPeriod(tokenCreate.AutoRenewPeriod) {
+		return hedera.AccountID{}, nil, nil, hErrors.ErrInvalidOperationMetadata
+	}
+
 	var signers []hedera.AccountID
 
-	treasury, err := hedera.AccountIDFromString(operation.Account.Address)
+	treasury, err := parseAccountId(operation.Account.Address, t.settings.LedgerId)
 	if err != nil {
-		return hedera.AccountID{}, nil, nil, hErrors.ErrInvalidAccount
+		return hedera.AccountID{}, nil, nil, invalidEntityIdError(err, hErrors.ErrInvalidAccount)
 	}
 	signers = append(signers, treasury)
 
-	if !isZeroAccountId(tokenCreate.AutoRenewAccount) {
-		signers = append(signers, tokenCreate.AutoRenewAccount)
+	if !isZeroAccountId(tokenCreate.AutoRenewAccount.AccountID) {
+		signers = append(signers, tokenCreate.AutoRenewAccount.AccountID)
 	}
 
 	return treasury, signers, tokenCreate, nil
 }
 
-func newTokenCreateTransactionConstructor() transactionConstructorWithType {
+// isValidAutoRenewPeriod returns true if the auto renew period in seconds is within the HAPI bounds
+func isValidAutoRenewPeriod(autoRenewPeriod int64) bool {
+	return autoRenewPeriod >= minAutoRenewPeriod && autoRenewPeriod <= maxAutoRenewPeriod
+}
+
+// newTokenCreateTransactionConstructor creates the token create constructor. autoRenewPeriod in seconds is set when
+// the metadata omits it, 0 leaves it to the SDK default
+func newTokenCreateTransactionConstructor(
+	autoRenewPeriod int64,
+	settings *config.Settings,
+) transactionConstructorWithType {
 	transactionType := reflect.TypeOf(hedera.TokenCreateTransaction{}).Name()
 	return &tokenCreateTransactionConstructor{
+		autoRenewPeriod: autoRenewPeriod,
+		settings:        settings,
 		transactionType: transactionType,
 		validate:        validator.New(),
 	}
@@ -44,17 +44,17 @@ type tokenCreateTransactionConstructorSuite struct {
 }
 
 func (suite *tokenCreateTransactionConstructorSuite) TestNewTransactionConstructor() {
-	h := newTokenCreateTransactionConstructor()
+	h := newTokenCreateTransactionConstructor(0, config.NewSettings())
 	assert.NotNil(suite.T(), h)
 }
 
 func (suite *tokenCreateTransactionConstructorSuite) TestGetOperationType() {
-	h := newTokenCreateTransactionConstructor()
+	h := newTokenCreateTransactionConstructor(0, config.NewSettings())
 	assert.Equal(suite.T(), config.OperationTypeTokenCreate, h.GetOperationType())
 }
 
 func (suite *tokenCreateTransactionConstructorSuite) TestGetSdkTransactionType() {
-	h := newTokenCreateTransactionConstructor()
+	h := newTokenCreateTransactionConstructor(0, config.NewSettings())
 	assert.Equal(suite.T(), "TokenCreateTransaction", h.GetSdkTransactionType())
 }
 
@@ -80,14 +80,14 @@ func (suite *tokenCreateTransactionConstructorSuite) TestConstruct() {
 		suite.T().Run(tt.name, func(t *testing.T) {
 			// given
 			operations := getTokenCreateOperations()
-			h := newTokenCreateTransactionConstructor()
+			h := newTokenCreateTransactionConstructor(0, config.NewSettings())
 
 			if tt.updateOperations != nil {
 				operations = tt.updateOperations(operations)
 			}
 
 			// when
-			tx, signers, err := h.Construct(nodeAccountId, operations)
+			tx, signers, err := h.Construct(nodeAccountId, operations, maxTransactionFee)
 
 			// then
 			if tt.expectError {
@@ -103,6 +103,37 @@ func (suite *tokenCreateTransactionConstructorSuite) TestConstruct() {
 	}
 }
 
+func (suite *tokenCreateTransactionConstructorSuite) TestConstructDefaultAutoRenewPeriod() {
+	var tests = []struct {
+		name            string
+		autoRenewPeriod interface{}
+		expected        float64
+	}{
+		{name: "Default", expected: 7776000},
+		{name: "Metadata", autoRenewPeriod: int64(8000000), expected: 8000000},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// given
+			// the SDK clears the auto renew period when the expiry is set
+			operations := getTokenCreateOperations()
+			delete(operations[0].Metadata, "expiry")
+			if tt.autoRenewPeriod != nil {
+				operations[0].Metadata["auto_renew_period"] = tt.autoRenewPeriod
+			}
+			h := newTokenCreateTransactionConstructor(7776000, config.NewSettings())
+
+			// when
+			tx, _, err := h.Construct(nodeAccountId, operations, maxTransactionFee)
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, tx.(*hedera.TokenCreateTransaction).GetAutoRenewPeriod().Seconds())
+		})
+	}
+}
+
 func (suite *tokenCreateTransactionConstructorSuite) TestParse() {
 	defaultGetTransaction := func() ITransaction {
 		return hedera.NewTokenCreateTransaction().
@@ -177,7 +208,7 @@ func (suite *tokenCreateTransactionConstructorSuite) TestParse() {
 			// given
 			expectedOperations := getTokenCreateOperations()
 
-			h := newTokenCreateTransactionConstructor()
+			h := newTokenCreateTransactionConstructor(0, config.NewSettings())
 			tx := tt.getTransaction()
 
 			// when
@@ -254,6 +285,22 @@ func (suite *tokenCreateTransactionConstructorSuite) TestPreprocess() {
 			},
 			expectError: true,
 		},
+		{
+			name: "AutoRenewPeriodBelowMin",
+			updateOperations: func(operations []*rTypes.Operation) []*rTypes.Operation {
+				operations[0].Metadata["auto_renew_period"] = minAutoRenewPeriod - 1
+				return operations
+			},
+			expectError: true,
+		},
+		{
+			name: "AutoRenewPeriodAboveMax",
+			updateOperations: func(operations []*rTypes.Operation) []*rTypes.Operation {
+				operations[0].Metadata["auto_renew_period"] = maxAutoRenewPeriod + 1
+				return operations
+			},
+			expectError: true,
+		},
 		{
 			name: "InvalidMetadataExpiry",
 			updateOperations: func(operations []*rTypes.Operation) []*rTypes.Operation {
@@ -355,7 +402,7 @@ func (suite *tokenCreateTransactionConstructorSuite) TestPreprocess() {
 		suite.T().Run(tt.name, func(t *testing.T) {
 			// given
 			operations := getTokenCreateOperations()
-			h := newTokenCreateTransactionConstructor()
+			h := newTokenCreateTransactionConstructor(0, config.NewSettings())
 
 			if tt.updateOperations != nil {
 				operations = tt.updateOperations(operations)
@@ -389,6 +436,7 @@ func assertTokenCreateTransaction(
 
 	assert.Equal(t, operation.Account.Address, payer)
 	assert.ElementsMatch(t, []hedera.AccountID{nodeAccountId}, actual.GetNodeAccountIDs())
+	assert.Equal(t, maxTransactionFee, tx.GetMaxTransactionFee())
 
 	assert.Equal(t, operation.Metadata["admin_key"], tx.GetAdminKey().String())
 	assert.Equal(t, operation.Metadata["auto_renew_account"], tx.GetAutoRenewAccount().String())
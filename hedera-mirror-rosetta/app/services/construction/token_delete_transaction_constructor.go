@@ -31,6 +31,7 @@ import (
 )
 
 type tokenDeleteTransactionConstructor struct {
+	settings        *config.Settings
 	tokenRepo       repositories.TokenRepository
 	transactionType string
 }
@@ -38,6 +39,7 @@ type tokenDeleteTransactionConstructor struct {
 func (t *tokenDeleteTransactionConstructor) Construct(
 	nodeAccountId hedera.AccountID,
 	operations []*rTypes.Operation,
+	maxTransactionFee hedera.Hbar,
 ) (ITransaction, []hedera.AccountID, *rTypes.Error) {
 	payerId, tokenId, rErr := t.preprocess(operations)
 	if rErr != nil {
@@ -47,7 +49,9 @@ func (t *tokenDeleteTransactionConstructor) Construct(
 	tx, err := hedera.NewTokenDeleteTransaction().
 		SetTokenID(*tokenId).
 		SetNodeAccountIDs([]hedera.AccountID{nodeAccountId}).
-		SetTransactionID(hedera.TransactionIDGenerate(*payerId)).
+		SetTransactionID(newTransactionId(*payerId, t.settings.TransactionValidStartBackdate)).
+		SetTransactionValidDuration(t.settings.TransactionValidDuration).
+		SetMaxTransactionFee(maxTransactionFee).
 		Freeze()
 	if err != nil {
 		return nil, nil, hErrors.ErrTransactionFreezeFailed
@@ -115,9 +119,9 @@ func (t *tokenDeleteTransactionConstructor) preprocess(operations []*rTypes.Oper
 	}
 
 	operation := operations[0]
-	payerId, err := hedera.AccountIDFromString(operation.Account.Address)
+	payerId, err := parseAccountId(operation.Account.Address, t.settings.LedgerId)
 	if err != nil || isZeroAccountId(payerId) {
-		return nil, nil, hErrors.ErrInvalidAccount
+		return nil, nil, invalidEntityIdError(err, hErrors.ErrInvalidAccount)
 	}
 
 	if operation.Amount.Value != "0" {
@@ -140,8 +144,12 @@ func (t *tokenDeleteTransactionConstructor) GetSdkTransactionType() string {
 	return t.transactionType
 }
 
-func newTokenDeleteTransactionConstructor(tokenRepo repositories.TokenRepository) transactionConstructorWithType {
+func newTokenDeleteTransactionConstructor(
+	tokenRepo repositories.TokenRepository,
+	settings *config.Settings,
+) transactionConstructorWithType {
 	return &tokenDeleteTransactionConstructor{
+		settings:        settings,
 		tokenRepo:       tokenRepo,
 		transactionType: reflect.TypeOf(hedera.TokenDeleteTransaction{}).Name(),
 	}
@@ -40,17 +40,17 @@ type tokenDeleteTransactionConstructorSuite struct {
 }
 
 func (suite *tokenDeleteTransactionConstructorSuite) TestNewTransactionConstructor() {
-	h := newTokenDeleteTransactionConstructor(&repository.MockTokenRepository{})
+	h := newTokenDeleteTransactionConstructor(&repository.MockTokenRepository{}, config.NewSettings())
 	assert.NotNil(suite.T(), h)
 }
 
 func (suite *tokenDeleteTransactionConstructorSuite) TestGetOperationType() {
-	h := newTokenDeleteTransactionConstructor(&repository.MockTokenRepository{})
+	h := newTokenDeleteTransactionConstructor(&repository.MockTokenRepository{}, config.NewSettings())
 	assert.Equal(suite.T(), config.OperationTypeTokenDelete, h.GetOperationType())
 }
 
 func (suite *tokenDeleteTransactionConstructorSuite) TestGetSdkTransactionType() {
-	h := newTokenDeleteTransactionConstructor(&repository.MockTokenRepository{})
+	h := newTokenDeleteTransactionConstructor(&repository.MockTokenRepository{}, config.NewSettings())
 	assert.Equal(suite.T(), "TokenDeleteTransaction", h.GetSdkTransactionType())
 }
 
@@ -77,7 +77,7 @@ func (suite *tokenDeleteTransactionConstructorSuite) TestConstruct() {
 			// given
 			operations := getTokenDeleteOperations()
 			mockTokenRepo := &repository.MockTokenRepository{}
-			h := newTokenDeleteTransactionConstructor(mockTokenRepo)
+			h := newTokenDeleteTransactionConstructor(mockTokenRepo, config.NewSettings())
 			configMockTokenRepo(mockTokenRepo, defaultMockTokenRepoConfigs[0])
 
 			if tt.updateOperations != nil {
@@ -85,7 +85,7 @@ func (suite *tokenDeleteTransactionConstructorSuite) TestConstruct() {
 			}
 
 			// when
-			tx, signers, err := h.Construct(nodeAccountId, operations)
+			tx, signers, err := h.Construct(nodeAccountId, operations, maxTransactionFee)
 
 			// then
 			if tt.expectError {
@@ -159,7 +159,7 @@ func (suite *tokenDeleteTransactionConstructorSuite) TestParse() {
 			expectedOperations := getTokenDeleteOperations()
 
 			mockTokenRepo := &repository.MockTokenRepository{}
-			h := newTokenDeleteTransactionConstructor(mockTokenRepo)
+			h := newTokenDeleteTransactionConstructor(mockTokenRepo, config.NewSettings())
 			tx := tt.getTransaction()
 
 			if tt.tokenRepoErr {
@@ -250,7 +250,7 @@ func (suite *tokenDeleteTransactionConstructorSuite) TestPreprocess() {
 			operations := getTokenDeleteOperations()
 
 			mockTokenRepo := &repository.MockTokenRepository{}
-			h := newTokenDeleteTransactionConstructor(mockTokenRepo)
+			h := newTokenDeleteTransactionConstructor(mockTokenRepo, config.NewSettings())
 
 			if tt.tokenRepoErr {
 				configMockTokenRepo(mockTokenRepo, mockTokenRepoNotFoundConfigs[0])
@@ -293,6 +293,7 @@ func assertTokenDeleteTransaction(
 	assert.Equal(t, operation.Account.Address, payer)
 	assert.Equal(t, operation.Amount.Currency.Symbol, token)
 	assert.ElementsMatch(t, []hedera.AccountID{nodeAccountId}, actual.GetNodeAccountIDs())
+	assert.Equal(t, config.DefaultTransactionValidDuration, tx.GetTransactionValidDuration())
 }
 
 func getTokenDeleteOperations() []*rTypes.Operation {
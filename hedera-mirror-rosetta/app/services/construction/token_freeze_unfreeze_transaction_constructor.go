@@ -32,12 +32,13 @@ import (
 )
 
 type tokenFreezeUnfreeze struct {
-	Account *hedera.AccountID `json:"account" validate:"required"`
+	Account *metadataAccountId `json:"account" validate:"required"`
 	Token   *hedera.TokenID
 }
 
 type tokenFreezeUnfreezeTransactionConstructor struct {
 	operationType   string
+	settings        *config.Settings
 	tokenRepo       repositories.TokenRepository
 	transactionType string
 	validate        *validator.Validate
@@ -46,6 +47,7 @@ type tokenFreezeUnfreezeTransactionConstructor struct {
 func (t *tokenFreezeUnfreezeTransactionConstructor) Construct(
 	nodeAccountId hedera.AccountID,
 	operations []*rTypes.Operation,
+	maxTransactionFee hedera.Hbar,
 ) (ITransaction, []hedera.AccountID, *rTypes.Error) {
 	payer, tokenFreezeUnfreeze, rErr := t.preprocess(operations)
 	if rErr != nil {
@@ -57,17 +59,21 @@ func (t *tokenFreezeUnfreezeTransactionConstructor) Construct(
 
 	if t.operationType == config.OperationTypeTokenFreeze {
 		tx, err = hedera.NewTokenFreezeTransaction().
-			SetAccountID(*tokenFreezeUnfreeze.Account).
+			SetAccountID(tokenFreezeUnfreeze.Account.AccountID).
 			SetNodeAccountIDs([]hedera.AccountID{nodeAccountId}).
 			SetTokenID(*tokenFreezeUnfreeze.Token).
-			SetTransactionID(hedera.TransactionIDGenerate(*payer)).
+			SetTransactionID(newTransactionId(*payer, t.settings.TransactionValidStartBackdate)).
+			SetTransactionValidDuration(t.settings.TransactionValidDuration).
+			SetMaxTransactionFee(maxTransactionFee).
 			Freeze()
 	} else {
 		tx, err = hedera.NewTokenUnfreezeTransaction().
-			SetAccountID(*tokenFreezeUnfreeze.Account).
+			SetAccountID(tokenFreezeUnfreeze.Account.AccountID).
 			SetNodeAccountIDs([]hedera.AccountID{nodeAccountId}).
 			SetTokenID(*tokenFreezeUnfreeze.Token).
-			SetTransactionID(hedera.TransactionIDGenerate(*payer)).
+			SetTransactionID(newTransactionId(*payer, t.settings.TransactionValidStartBackdate)).
+			SetTransactionValidDuration(t.settings.TransactionValidDuration).
+			SetMaxTransactionFee(maxTransactionFee).
 			Unfreeze() // SDK typo
 	}
 
@@ -157,7 +163,11 @@ func (t *tokenFreezeUnfreezeTransactionConstructor) preprocess(operations []*rTy
 		return nil, nil, rErr
 	}
 
-	if isZeroAccountId(*tokenFreeze.Account) {
+	if rErr = resolveMetadataAccountIds(t.settings.LedgerId, tokenFreeze.Account); rErr != nil {
+		return nil, nil, rErr
+	}
+
+	if isZeroAccountId(tokenFreeze.Account.AccountID) {
 		return nil, nil, hErrors.ErrInvalidAccount
 	}
 
@@ -166,9 +176,9 @@ func (t *tokenFreezeUnfreezeTransactionConstructor) preprocess(operations []*rTy
 		return nil, nil, rErr
 	}
 
-	payer, err := hedera.AccountIDFromString(operations[0].Account.Address)
+	payer, err := parseAccountId(operations[0].Account.Address, t.settings.LedgerId)
 	if err != nil || isZeroAccountId(payer) {
-		return nil, nil, hErrors.ErrInvalidAccount
+		return nil, nil, invalidEntityIdError(err, hErrors.ErrInvalidAccount)
 	}
 
 	return &payer, tokenFreeze, nil
@@ -182,20 +192,28 @@ func (t *tokenFreezeUnfreezeTransactionConstructor) GetSdkTransactionType() stri
 	return t.transactionType
 }
 
-func newTokenFreezeTransactionConstructor(tokenRepo repositories.TokenRepository) transactionConstructorWithType {
+func newTokenFreezeTransactionConstructor(
+	tokenRepo repositories.TokenRepository,
+	settings *config.Settings,
+) transactionConstructorWithType {
 	transactionType := reflect.TypeOf(hedera.TokenFreezeTransaction{}).Name()
 	return &tokenFreezeUnfreezeTransactionConstructor{
 		operationType:   config.OperationTypeTokenFreeze,
+		settings:        settings,
 		tokenRepo:       tokenRepo,
 		transactionType: transactionType,
 		validate:        validator.New(),
 	}
 }
 
-func newTokenUnfreezeTransactionConstructor(tokenRepo repositories.TokenRepository) transactionConstructorWithType {
+func newTokenUnfreezeTransactionConstructor(
+	tokenRepo repositories.TokenRepository,
+	settings *config.Settings,
+) transactionConstructorWithType {
 	transactionType := reflect.TypeOf(hedera.TokenUnfreezeTransaction{}).Name()
 	return &tokenFreezeUnfreezeTransactionConstructor{
 		operationType:   config.OperationTypeTokenUnfreeze,
+		settings:        settings,
 		tokenRepo:       tokenRepo,
 		transactionType: transactionType,
 		validate:        validator.New(),
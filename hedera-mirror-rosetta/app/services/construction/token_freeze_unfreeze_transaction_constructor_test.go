@@ -40,12 +40,12 @@ type tokenFreezeUnfreezeTransactionConstructorSuite struct {
 }
 
 func (suite *tokenFreezeUnfreezeTransactionConstructorSuite) TestNewTokenFreezeTransactionConstructor() {
-	h := newTokenFreezeTransactionConstructor(&repository.MockTokenRepository{})
+	h := newTokenFreezeTransactionConstructor(&repository.MockTokenRepository{}, config.NewSettings())
 	assert.NotNil(suite.T(), h)
 }
 
 func (suite *tokenFreezeUnfreezeTransactionConstructorSuite) TestNewTokenUnfreezeTransactionConstructor() {
-	h := newTokenUnfreezeTransactionConstructor(&repository.MockTokenRepository{})
+	h := newTokenUnfreezeTransactionConstructor(&repository.MockTokenRepository{}, config.NewSettings())
 	assert.NotNil(suite.T(), h)
 }
 
@@ -69,7 +69,7 @@ func (suite *tokenFreezeUnfreezeTransactionConstructorSuite) TestGetOperationTyp
 
 	for _, tt := range tests {
 		suite.T().Run(tt.name, func(t *testing.T) {
-			h := tt.newHandler(&repository.MockTokenRepository{})
+			h := tt.newHandler(&repository.MockTokenRepository{}, config.NewSettings())
 			assert.Equal(t, tt.expected, h.GetOperationType())
 		})
 	}
@@ -95,7 +95,7 @@ func (suite *tokenFreezeUnfreezeTransactionConstructorSuite) TestGetSdkTransacti
 
 	for _, tt := range tests {
 		suite.T().Run(tt.name, func(t *testing.T) {
-			h := tt.newHandler(&repository.MockTokenRepository{})
+			h := tt.newHandler(&repository.MockTokenRepository{}, config.NewSettings())
 			assert.Equal(t, tt.expected, h.GetSdkTransactionType())
 		})
 	}
@@ -125,7 +125,7 @@ func (suite *tokenFreezeUnfreezeTransactionConstructorSuite) TestConstruct() {
 				// given
 				operations := getFreezeUnfreezeOperations(operationType)
 				mockTokenRepo := &repository.MockTokenRepository{}
-				h := newHandler(mockTokenRepo)
+				h := newHandler(mockTokenRepo, config.NewSettings())
 				configMockTokenRepo(mockTokenRepo, defaultMockTokenRepoConfigs[0])
 
 				if tt.updateOperations != nil {
@@ -133,7 +133,7 @@ func (suite *tokenFreezeUnfreezeTransactionConstructorSuite) TestConstruct() {
 				}
 
 				// when
-				tx, signers, err := h.Construct(nodeAccountId, operations)
+				tx, signers, err := h.Construct(nodeAccountId, operations, maxTransactionFee)
 
 				// then
 				if tt.expectError {
@@ -222,7 +222,7 @@ func (suite *tokenFreezeUnfreezeTransactionConstructorSuite) TestParse() {
 				expectedOperations := getFreezeUnfreezeOperations(operationType)
 
 				mockTokenRepo := &repository.MockTokenRepository{}
-				h := newHandler(mockTokenRepo)
+				h := newHandler(mockTokenRepo, config.NewSettings())
 				tx := tt.getTransaction(operationType)
 
 				if tt.tokenRepoErr {
@@ -341,7 +341,7 @@ func (suite *tokenFreezeUnfreezeTransactionConstructorSuite) TestPreprocess() {
 				operations := getFreezeUnfreezeOperations(operationType)
 
 				mockTokenRepo := &repository.MockTokenRepository{}
-				h := newHandler(mockTokenRepo)
+				h := newHandler(mockTokenRepo, config.NewSettings())
 
 				if tt.tokenRepoErr {
 					configMockTokenRepo(mockTokenRepo, mockTokenRepoNotFoundConfigs[0])
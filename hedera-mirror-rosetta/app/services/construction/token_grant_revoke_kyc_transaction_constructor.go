@@ -32,12 +32,13 @@ import (
 )
 
 type tokenKyc struct {
-	Account *hedera.AccountID `json:"account" validate:"required"`
+	Account *metadataAccountId `json:"account" validate:"required"`
 	Token   hedera.TokenID
 }
 
 type tokenGrantRevokeKycTransactionConstructor struct {
 	operationType   string
+	settings        *config.Settings
 	tokenRepo       repositories.TokenRepository
 	transactionType string
 	validate        *validator.Validate
@@ -46,6 +47,7 @@ type tokenGrantRevokeKycTransactionConstructor struct {
 func (t *tokenGrantRevokeKycTransactionConstructor) Construct(
 	nodeAccountId hedera.AccountID,
 	operations []*rTypes.Operation,
+	maxTransactionFee hedera.Hbar,
 ) (ITransaction, []hedera.AccountID, *rTypes.Error) {
 	payer, tokenKyc, rErr := t.preprocess(operations)
 	if rErr != nil {
@@ -57,17 +59,21 @@ func (t *tokenGrantRevokeKycTransactionConstructor) Construct(
 
 	if t.operationType == config.OperationTypeTokenGrantKyc {
 		tx, err = hedera.NewTokenGrantKycTransaction().
-			SetAccountID(*tokenKyc.Account).
+			SetAccountID(tokenKyc.Account.AccountID).
 			SetNodeAccountIDs([]hedera.AccountID{nodeAccountId}).
 			SetTokenID(tokenKyc.Token).
-			SetTransactionID(hedera.TransactionIDGenerate(*payer)).
+			SetTransactionID(newTransactionId(*payer, t.settings.TransactionValidStartBackdate)).
+			SetTransactionValidDuration(t.settings.TransactionValidDuration).
+			SetMaxTransactionFee(maxTransactionFee).
 			Freeze()
 	} else {
 		tx, err = hedera.NewTokenRevokeKycTransaction().
-			SetAccountID(*tokenKyc.Account).
+			SetAccountID(tokenKyc.Account.AccountID).
 			SetNodeAccountIDs([]hedera.AccountID{nodeAccountId}).
 			SetTokenID(tokenKyc.Token).
-			SetTransactionID(hedera.TransactionIDGenerate(*payer)).
+			SetTransactionID(newTransactionId(*payer, t.settings.TransactionValidStartBackdate)).
+			SetTransactionValidDuration(t.settings.TransactionValidDuration).
+			SetMaxTransactionFee(maxTransactionFee).
 			Freeze()
 	}
 
@@ -163,13 +169,15 @@ func (t *tokenGrantRevokeKycTransactionConstructor) preprocess(operations []*rTy
 	rErr := parseOperationMetadata(t.validate, tokenKyc, operation.Metadata)
 	if rErr != nil {
 		return nil, nil, rErr
-	} else if isZeroAccountId(*tokenKyc.Account) {
+	} else if rErr = resolveMetadataAccountIds(t.settings.LedgerId, tokenKyc.Account); rErr != nil {
+		return nil, nil, rErr
+	} else if isZeroAccountId(tokenKyc.Account.AccountID) {
 		return nil, nil, hErrors.ErrInvalidAccount
 	}
 
-	payer, err := hedera.AccountIDFromString(operations[0].Account.Address)
+	payer, err := parseAccountId(operations[0].Account.Address, t.settings.LedgerId)
 	if err != nil || isZeroAccountId(payer) {
-		return nil, nil, hErrors.ErrInvalidAccount
+		return nil, nil, invalidEntityIdError(err, hErrors.ErrInvalidAccount)
 	}
 
 	token, rErr := validateToken(t.tokenRepo, operation.Amount.Currency)
@@ -189,20 +197,28 @@ func (t *tokenGrantRevokeKycTransactionConstructor) GetSdkTransactionType() stri
 	return t.transactionType
 }
 
-func newTokenGrantKycTransactionConstructor(tokenRepo repositories.TokenRepository) transactionConstructorWithType {
+func newTokenGrantKycTransactionConstructor(
+	tokenRepo repositories.TokenRepository,
+	settings *config.Settings,
+) transactionConstructorWithType {
 	transactionType := reflect.TypeOf(hedera.TokenGrantKycTransaction{}).Name()
 	return &tokenGrantRevokeKycTransactionConstructor{
 		operationType:   config.OperationTypeTokenGrantKyc,
+		settings:        settings,
 		tokenRepo:       tokenRepo,
 		transactionType: transactionType,
 		validate:        validator.New(),
 	}
 }
 
-func newTokenRevokeKycTransactionConstructor(tokenRepo repositories.TokenRepository) transactionConstructorWithType {
+func newTokenRevokeKycTransactionConstructor(
+	tokenRepo repositories.TokenRepository,
+	settings *config.Settings,
+) transactionConstructorWithType {
 	transactionType := reflect.TypeOf(hedera.TokenRevokeKycTransaction{}).Name()
 	return &tokenGrantRevokeKycTransactionConstructor{
 		operationType:   config.OperationTypeTokenRevokeKyc,
+		settings:        settings,
 		tokenRepo:       tokenRepo,
 		transactionType: transactionType,
 		validate:        validator.New(),
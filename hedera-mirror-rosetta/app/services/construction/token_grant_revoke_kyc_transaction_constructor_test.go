@@ -40,12 +40,12 @@ type tokenGrantRevokeKycTransactionConstructorSuite struct {
 }
 
 func (suite *tokenGrantRevokeKycTransactionConstructorSuite) TestNewTokenGrantKycTransactionConstructor() {
-	h := newTokenGrantKycTransactionConstructor(&repository.MockTokenRepository{})
+	h := newTokenGrantKycTransactionConstructor(&repository.MockTokenRepository{}, config.NewSettings())
 	assert.NotNil(suite.T(), h)
 }
 
 func (suite *tokenGrantRevokeKycTransactionConstructorSuite) TestNewTokenRevokeKycTransactionConstructor() {
-	h := newTokenRevokeKycTransactionConstructor(&repository.MockTokenRepository{})
+	h := newTokenRevokeKycTransactionConstructor(&repository.MockTokenRepository{}, config.NewSettings())
 	assert.NotNil(suite.T(), h)
 }
 
@@ -69,7 +69,7 @@ func (suite *tokenGrantRevokeKycTransactionConstructorSuite) TestGetOperationTyp
 
 	for _, tt := range tests {
 		suite.T().Run(tt.name, func(t *testing.T) {
-			h := tt.newHandler(&repository.MockTokenRepository{})
+			h := tt.newHandler(&repository.MockTokenRepository{}, config.NewSettings())
 			assert.Equal(t, tt.expected, h.GetOperationType())
 		})
 	}
@@ -95,7 +95,7 @@ func (suite *tokenGrantRevokeKycTransactionConstructorSuite) TestGetSdkTransacti
 
 	for _, tt := range tests {
 		suite.T().Run(tt.name, func(t *testing.T) {
-			h := tt.newHandler(&repository.MockTokenRepository{})
+			h := tt.newHandler(&repository.MockTokenRepository{}, config.NewSettings())
 			assert.Equal(t, tt.expected, h.GetSdkTransactionType())
 		})
 	}
@@ -125,7 +125,7 @@ func (suite *tokenGrantRevokeKycTransactionConstructorSuite) TestConstruct() {
 				// given
 				operations := suite.getOperations(operationType)
 				mockTokenRepo := &repository.MockTokenRepository{}
-				h := newHandler(mockTokenRepo)
+				h := newHandler(mockTokenRepo, config.NewSettings())
 
 				configMockTokenRepo(mockTokenRepo, defaultMockTokenRepoConfigs[0])
 
@@ -134,7 +134,7 @@ func (suite *tokenGrantRevokeKycTransactionConstructorSuite) TestConstruct() {
 				}
 
 				// when
-				tx, signers, err := h.Construct(nodeAccountId, operations)
+				tx, signers, err := h.Construct(nodeAccountId, operations, maxTransactionFee)
 
 				// then
 				if tt.expectError {
@@ -274,7 +274,7 @@ func (suite *tokenGrantRevokeKycTransactionConstructorSuite) TestParse() {
 				expectedOperations := suite.getOperations(operationType)
 
 				mockTokenRepo := &repository.MockTokenRepository{}
-				h := newHandler(mockTokenRepo)
+				h := newHandler(mockTokenRepo, config.NewSettings())
 				tx := tt.getTransaction(operationType)
 
 				if tt.tokenRepoErr {
@@ -398,7 +398,7 @@ func (suite *tokenGrantRevokeKycTransactionConstructorSuite) TestPreprocess() {
 				operations := suite.getOperations(operationType)
 
 				mockTokenRepo := &repository.MockTokenRepository{}
-				h := newHandler(mockTokenRepo)
+				h := newHandler(mockTokenRepo, config.NewSettings())
 
 				if tt.tokenRepoErr {
 					configMockTokenRepo(mockTokenRepo, mockTokenRepoNotFoundConfigs[0])
@@ -33,30 +33,31 @@ import (
 
 type tokenUpdate struct {
 	tokenId          hedera.TokenID
-	AdminKey         publicKey        `json:"admin_key"`
-	AutoRenewAccount hedera.AccountID `json:"auto_renew_account"`
-	AutoRenewPeriod  int64            `json:"auto_renew_period"` // in seconds
-	Expiry           int64            `json:"expiry"`            // nanos since epoch
-	FreezeKey        publicKey        `json:"freeze_key"`
-	KycKey           publicKey        `json:"kyc_key"`
-	Memo             string           `json:"memo"`
-	Name             string           `json:"name"`
-	SupplyKey        publicKey        `json:"supply_key"`
-	Symbol           string           `json:"symbol"`
-	Treasury         hedera.AccountID `json:"treasury"`
-	WipeKey          publicKey        `json:"wipe_key"`
+	AdminKey         publicKey         `json:"admin_key"`
+	AutoRenewAccount metadataAccountId `json:"auto_renew_account"`
+	AutoRenewPeriod  int64             `json:"auto_renew_period"` // in seconds
+	Expiry           int64             `json:"expiry"`            // nanos since epoch
+	FreezeKey        publicKey         `json:"freeze_key"`
+	KycKey           publicKey         `json:"kyc_key"`
+	Memo             string            `json:"memo"`
+	Name             string            `json:"name"`
+	SupplyKey        publicKey         `json:"supply_key"`
+	Symbol           string            `json:"symbol"`
+	Treasury         metadataAccountId `json:"treasury"`
+	WipeKey          publicKey         `json:"wipe_key"`
 }
 
 type tokenUpdateTransactionConstructor struct {
-	transactionType string
+	settings        *config.Settings
 	tokenRepo       repositories.TokenRepository
+	transactionType string
 }
 
-func (t *tokenUpdateTransactionConstructor) Construct(nodeAccountId hedera.AccountID, operations []*rTypes.Operation) (
-	ITransaction,
-	[]hedera.AccountID,
-	*rTypes.Error,
-) {
+func (t *tokenUpdateTransactionConstructor) Construct(
+	nodeAccountId hedera.AccountID,
+	operations []*rTypes.Operation,
+	maxTransactionFee hedera.Hbar,
+) (ITransaction, []hedera.AccountID, *rTypes.Error) {
 	payer, tokenUpdate, err := t.preprocess(operations)
 	if err != nil {
 		return nil, nil, err
@@ -64,15 +65,17 @@ func (t *tokenUpdateTransactionConstructor) Construct(nodeAccountId hedera.Accou
 
 	tx := hedera.NewTokenUpdateTransaction().
 		SetNodeAccountIDs([]hedera.AccountID{nodeAccountId}).
+		SetMaxTransactionFee(maxTransactionFee).
 		SetTokenID(tokenUpdate.tokenId).
-		SetTransactionID(hedera.TransactionIDGenerate(*payer))
+		SetTransactionID(newTransactionId(*payer, t.settings.TransactionValidStartBackdate)).
+		SetTransactionValidDuration(t.settings.TransactionValidDuration)
 
 	if !tokenUpdate.AdminKey.isEmpty() {
 		tx.SetAdminKey(tokenUpdate.AdminKey.PublicKey)
 	}
 
-	if !isZeroAccountId(tokenUpdate.AutoRenewAccount) {
-		tx.SetAutoRenewAccount(tokenUpdate.AutoRenewAccount)
+	if !isZeroAccountId(tokenUpdate.AutoRenewAccount.AccountID) {
+		tx.SetAutoRenewAccount(tokenUpdate.AutoRenewAccount.AccountID)
 	}
 
 	if tokenUpdate.AutoRenewPeriod != 0 {
@@ -107,8 +110,8 @@ func (t *tokenUpdateTransactionConstructor) Construct(nodeAccountId hedera.Accou
 		tx.SetTokenSymbol(tokenUpdate.Symbol)
 	}
 
-	if !isZeroAccountId(tokenUpdate.Treasury) {
-		tx.SetTreasuryAccountID(tokenUpdate.Treasury)
+	if !isZeroAccountId(tokenUpdate.Treasury.AccountID) {
+		tx.SetTreasuryAccountID(tokenUpdate.Treasury.AccountID)
 	}
 
 	if !tokenUpdate.WipeKey.isEmpty() {
@@ -254,18 +257,27 @@ func (t *tokenUpdateTransactionConstructor) preprocess(operations []*rTypes.Oper
 		return nil, nil, rErr
 	}
 
-	payer, err := hedera.AccountIDFromString(operation.Account.Address)
+	rErr = resolveMetadataAccountIds(t.settings.LedgerId, &tokenUpdate.AutoRenewAccount, &tokenUpdate.Treasury)
+	if rErr != nil {
+		return nil, nil, rErr
+	}
+
+	payer, err := parseAccountId(operation.Account.Address, t.settings.LedgerId)
 	if err != nil {
-		return nil, nil, hErrors.ErrInvalidAccount
+		return nil, nil, invalidEntityIdError(err, hErrors.ErrInvalidAccount)
 	}
 
 	return &payer, tokenUpdate, nil
 }
 
-func newTokenUpdateTransactionConstructor(tokenRepo repositories.TokenRepository) transactionConstructorWithType {
+func newTokenUpdateTransactionConstructor(
+	tokenRepo repositories.TokenRepository,
+	settings *config.Settings,
+) transactionConstructorWithType {
 	transactionType := reflect.TypeOf(hedera.TokenUpdateTransaction{}).Name()
 	return &tokenUpdateTransactionConstructor{
-		transactionType: transactionType,
+		settings:        settings,
 		tokenRepo:       tokenRepo,
+		transactionType: transactionType,
 	}
 }
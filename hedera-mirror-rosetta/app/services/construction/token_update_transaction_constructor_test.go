@@ -64,17 +64,17 @@ type tokenUpdateTransactionConstructorSuite struct {
 }
 
 func (suite *tokenUpdateTransactionConstructorSuite) TestNewTransactionConstructor() {
-	h := newTokenUpdateTransactionConstructor(&repository.MockTokenRepository{})
+	h := newTokenUpdateTransactionConstructor(&repository.MockTokenRepository{}, config.NewSettings())
 	assert.NotNil(suite.T(), h)
 }
 
 func (suite *tokenUpdateTransactionConstructorSuite) TestGetOperationType() {
-	h := newTokenUpdateTransactionConstructor(&repository.MockTokenRepository{})
+	h := newTokenUpdateTransactionConstructor(&repository.MockTokenRepository{}, config.NewSettings())
 	assert.Equal(suite.T(), config.OperationTypeTokenUpdate, h.GetOperationType())
 }
 
 func (suite *tokenUpdateTransactionConstructorSuite) TestGetSdkTransactionType() {
-	h := newTokenUpdateTransactionConstructor(&repository.MockTokenRepository{})
+	h := newTokenUpdateTransactionConstructor(&repository.MockTokenRepository{}, config.NewSettings())
 	assert.Equal(suite.T(), "TokenUpdateTransaction", h.GetSdkTransactionType())
 }
 
@@ -101,7 +101,7 @@ func (suite *tokenUpdateTransactionConstructorSuite) TestConstruct() {
 			// given
 			operations := getTokenUpdateOperations()
 			mockTokenRepo := &repository.MockTokenRepository{}
-			h := newTokenUpdateTransactionConstructor(mockTokenRepo)
+			h := newTokenUpdateTransactionConstructor(mockTokenRepo, config.NewSettings())
 			configMockTokenRepo(mockTokenRepo, defaultMockTokenRepoConfigs[0])
 
 			if tt.updateOperations != nil {
@@ -109,7 +109,7 @@ func (suite *tokenUpdateTransactionConstructorSuite) TestConstruct() {
 			}
 
 			// when
-			tx, signers, err := h.Construct(nodeAccountId, operations)
+			tx, signers, err := h.Construct(nodeAccountId, operations, maxTransactionFee)
 
 			// then
 			if tt.expectError {
@@ -219,7 +219,7 @@ func (suite *tokenUpdateTransactionConstructorSuite) TestParse() {
 			expectedOperations := getTokenUpdateOperations()
 
 			mockTokenRepo := &repository.MockTokenRepository{}
-			h := newTokenUpdateTransactionConstructor(mockTokenRepo)
+			h := newTokenUpdateTransactionConstructor(mockTokenRepo, config.NewSettings())
 			tx := tt.getTransaction()
 
 			if tt.tokenRepoErr {
@@ -396,7 +396,7 @@ func (suite *tokenUpdateTransactionConstructorSuite) TestPreprocess() {
 			operations := getTokenUpdateOperations()
 
 			mockTokenRepo := &repository.MockTokenRepository{}
-			h := newTokenUpdateTransactionConstructor(mockTokenRepo)
+			h := newTokenUpdateTransactionConstructor(mockTokenRepo, config.NewSettings())
 
 			if tt.tokenRepoErr {
 				configMockTokenRepo(mockTokenRepo, mockTokenRepoNotFoundConfigs[0])
@@ -34,12 +34,13 @@ import (
 )
 
 type tokenWipe struct {
-	Account *hedera.AccountID `json:"account" validate:"required"`
+	Account *metadataAccountId `json:"account" validate:"required"`
 	Amount  uint64
 	Token   hedera.TokenID
 }
 
 type tokenWipeTransactionConstructor struct {
+	settings        *config.Settings
 	tokenRepo       repositories.TokenRepository
 	transactionType string
 	validate        *validator.Validate
@@ -48,6 +49,7 @@ type tokenWipeTransactionConstructor struct {
 func (t *tokenWipeTransactionConstructor) Construct(
 	nodeAccountId hedera.AccountID,
 	operations []*rTypes.Operation,
+	maxTransactionFee hedera.Hbar,
 ) (ITransaction, []hedera.AccountID, *rTypes.Error) {
 	payer, tokenWipe, rErr := t.preprocess(operations)
 	if rErr != nil {
@@ -55,11 +57,13 @@ func (t *tokenWipeTransactionConstructor) Construct(
 	}
 
 	tx, err := hedera.NewTokenWipeTransaction().
-		SetAccountID(*tokenWipe.Account).
+		SetAccountID(tokenWipe.Account.AccountID).
 		SetAmount(tokenWipe.Amount).
 		SetTokenID(tokenWipe.Token).
 		SetNodeAccountIDs([]hedera.AccountID{nodeAccountId}).
-		SetTransactionID(hedera.TransactionIDGenerate(*payer)).
+		SetTransactionID(newTransactionId(*payer, t.settings.TransactionValidStartBackdate)).
+		SetTransactionValidDuration(t.settings.TransactionValidDuration).
+		SetMaxTransactionFee(maxTransactionFee).
 		Freeze()
 	if err != nil {
 		return nil, nil, hErrors.ErrTransactionFreezeFailed
@@ -136,7 +140,11 @@ func (t *tokenWipeTransactionConstructor) preprocess(operations []*rTypes.Operat
 		return nil, nil, rErr
 	}
 
-	if isZeroAccountId(*tokenWipe.Account) {
+	if rErr := resolveMetadataAccountIds(t.settings.LedgerId, tokenWipe.Account); rErr != nil {
+		return nil, nil, rErr
+	}
+
+	if isZeroAccountId(tokenWipe.Account.AccountID) {
 		return nil, nil, hErrors.ErrInvalidAccount
 	}
 
@@ -152,9 +160,9 @@ func (t *tokenWipeTransactionConstructor) preprocess(operations []*rTypes.Operat
 	}
 	tokenWipe.Token = *token
 
-	payer, err := hedera.AccountIDFromString(operations[0].Account.Address)
+	payer, err := parseAccountId(operations[0].Account.Address, t.settings.LedgerId)
 	if err != nil || isZeroAccountId(payer) {
-		return nil, nil, hErrors.ErrInvalidAccount
+		return nil, nil, invalidEntityIdError(err, hErrors.ErrInvalidAccount)
 	}
 
 	return &payer, tokenWipe, nil
@@ -168,8 +176,12 @@ func (t *tokenWipeTransactionConstructor) GetSdkTransactionType() string {
 	return t.transactionType
 }
 
-func newTokenWipeTransactionConstructor(tokenRepo repositories.TokenRepository) transactionConstructorWithType {
+func newTokenWipeTransactionConstructor(
+	tokenRepo repositories.TokenRepository,
+	settings *config.Settings,
+) transactionConstructorWithType {
 	return &tokenWipeTransactionConstructor{
+		settings:        settings,
 		tokenRepo:       tokenRepo,
 		transactionType: reflect.TypeOf(hedera.TokenWipeTransaction{}).Name(),
 		validate:        validator.New(),
@@ -41,17 +41,17 @@ type tokenWipeTransactionConstructorSuite struct {
 }
 
 func (suite *tokenWipeTransactionConstructorSuite) TestNewTransactionConstructor() {
-	h := newTokenWipeTransactionConstructor(&repository.MockTokenRepository{})
+	h := newTokenWipeTransactionConstructor(&repository.MockTokenRepository{}, config.NewSettings())
 	assert.NotNil(suite.T(), h)
 }
 
 func (suite *tokenWipeTransactionConstructorSuite) TestGetOperationType() {
-	h := newTokenWipeTransactionConstructor(&repository.MockTokenRepository{})
+	h := newTokenWipeTransactionConstructor(&repository.MockTokenRepository{}, config.NewSettings())
 	assert.Equal(suite.T(), config.OperationTypeTokenWipe, h.GetOperationType())
 }
 
 func (suite *tokenWipeTransactionConstructorSuite) TestGetSdkTransactionType() {
-	h := newTokenWipeTransactionConstructor(&repository.MockTokenRepository{})
+	h := newTokenWipeTransactionConstructor(&repository.MockTokenRepository{}, config.NewSettings())
 	assert.Equal(suite.T(), "TokenWipeTransaction", h.GetSdkTransactionType())
 }
 
@@ -78,7 +78,7 @@ func (suite *tokenWipeTransactionConstructorSuite) TestConstruct() {
 			// given
 			operations := getTokenWipeOperations()
 			mockTokenRepo := &repository.MockTokenRepository{}
-			h := newTokenWipeTransactionConstructor(mockTokenRepo)
+			h := newTokenWipeTransactionConstructor(mockTokenRepo, config.NewSettings())
 			configMockTokenRepo(mockTokenRepo, defaultMockTokenRepoConfigs[0])
 
 			if tt.updateOperations != nil {
@@ -86,7 +86,7 @@ func (suite *tokenWipeTransactionConstructorSuite) TestConstruct() {
 			}
 
 			// when
-			tx, signers, err := h.Construct(nodeAccountId, operations)
+			tx, signers, err := h.Construct(nodeAccountId, operations, maxTransactionFee)
 
 			// then
 			if tt.expectError {
@@ -177,7 +177,7 @@ func (suite *tokenWipeTransactionConstructorSuite) TestParse() {
 			expectedOperations := getTokenWipeOperations()
 
 			mockTokenRepo := &repository.MockTokenRepository{}
-			h := newTokenWipeTransactionConstructor(mockTokenRepo)
+			h := newTokenWipeTransactionConstructor(mockTokenRepo, config.NewSettings())
 			tx := tt.getTransaction()
 
 			if tt.tokenRepoErr {
@@ -292,7 +292,7 @@ func (suite *tokenWipeTransactionConstructorSuite) TestPreprocess() {
 			operations := getTokenWipeOperations()
 
 			mockTokenRepo := &repository.MockTokenRepository{}
-			h := newTokenWipeTransactionConstructor(mockTokenRepo)
+			h := newTokenWipeTransactionConstructor(mockTokenRepo, config.NewSettings())
 
 			if tt.tokenRepoErr {
 				configMockTokenRepo(mockTokenRepo, mockTokenRepoNotFoundConfigs[0])
@@ -0,0 +1,176 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package construction
+
+import (
+	"crypto/ed25519"
+	"math"
+	"time"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/hashgraph/hedera-sdk-go/v2/proto"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// transactionPrechecker prechecks signed transactions against the state of the mirror node. Consensus nodes don't
+// have a precheck-only mode for transactions since any transaction submitted is executed, so the node's checks which
+// don't depend on the node's own state are replicated instead
+type transactionPrechecker struct {
+	accountRepo repositories.AccountRepository
+	now         func() time.Time
+}
+
+// Precheck checks in order the transaction id, the valid duration, the valid start, the signatures, that the payer
+// exists and its key has signed, and that the payer's balance covers the max transaction fee. The first failed check determines the status, which is
+// hedera.StatusOk if all checks pass. Since the fee charged is usually less than the max transaction fee, the balance
+// check is conservative
+func (t *transactionPrechecker) Precheck(signedTransaction string) (hedera.Status, *rTypes.Error) {
+	// the fee and the valid duration are only available in the body of a deserialized transaction
+	decoded, rErr := decodeTransaction(signedTransaction)
+	if rErr != nil {
+		return 0, rErr
+	}
+
+	body := decoded.body
+	frozenBodyBytes := decoded.frozenBodyBytes
+	transaction := decoded.transaction
+
+	transactionId := transaction.GetTransactionID()
+	if transactionId.AccountID == nil || isZeroAccountId(*transactionId.AccountID) || transactionId.ValidStart == nil {
+		return hedera.StatusInvalidTransactionID, nil
+	}
+
+	validDuration := time.Duration(body.GetTransactionValidDuration().GetSeconds()) * time.Second
+	if validDuration < config.MinTransactionValidDuration || validDuration > config.MaxTransactionValidDuration {
+		return hedera.StatusInvalidTransactionDuration, nil
+	}
+
+	now := t.now()
+	validStart := *transactionId.ValidStart
+	if validStart.After(now) {
+		return hedera.StatusInvalidTransactionStart, nil
+	}
+
+	if !validStart.Add(validDuration).After(now) {
+		return hedera.StatusTransactionExpired, nil
+	}
+
+	signers, status, rErr := verifySignatures(transaction, frozenBodyBytes)
+	if rErr != nil || status != hedera.StatusOk {
+		return status, rErr
+	}
+
+	payer := transactionId.AccountID.String()
+	serializedKey, rErr := t.accountRepo.FindKey(payer)
+	if rErr != nil {
+		return 0, rErr
+	}
+
+	if serializedKey == nil {
+		return hedera.StatusPayerAccountNotFound, nil
+	}
+
+	payerKey := &proto.Key{}
+	if err := protobuf.Unmarshal(serializedKey, payerKey); err != nil || !isKeySigned(payerKey, signers) {
+		return hedera.StatusInvalidSignature, nil
+	}
+
+	// no token ids to only retrieve the current hbar balance
+	balances, rErr := t.accountRepo.RetrieveBalanceAtBlock(payer, math.MaxInt64, []int64{}, 0, 0)
+	if rErr != nil {
+		return 0, rErr
+	}
+
+	for _, balance := range balances {
+		if hbarAmount, ok := balance.(*types.HbarAmount); ok &&
+			hbarAmount.Value < int64(body.GetTransactionFee()) {
+			return hedera.StatusInsufficientPayerBalance, nil
+		}
+	}
+
+	return hedera.StatusOk, nil
+}
+
+// NewTransactionPrechecker creates a TransactionPrechecker which reads the payer's key and balance from accountRepo
+func NewTransactionPrechecker(accountRepo repositories.AccountRepository) TransactionPrechecker {
+	return &transactionPrechecker{accountRepo: accountRepo, now: time.Now}
+}
+
+// isKeySigned returns true if the signers satisfy the key. A key list requires all of its keys, a threshold key
+// requires at least the threshold of its keys, and other keys than ed25519 keys can't be satisfied by signatures
+func isKeySigned(key *proto.Key, signers map[string]bool) bool {
+	switch k := key.GetKey().(type) {
+	case *proto.Key_Ed25519:
+		return signers[string(k.Ed25519)]
+	case *proto.Key_KeyList:
+		keys := k.KeyList.GetKeys()
+		return len(keys) != 0 && countSignedKeys(keys, signers) == len(keys)
+	case *proto.Key_ThresholdKey:
+		threshold := int(k.ThresholdKey.GetThreshold())
+		return threshold > 0 && countSignedKeys(k.ThresholdKey.GetKeys().GetKeys(), signers) >= threshold
+	default:
+		return false
+	}
+}
+
+func countSignedKeys(keys []*proto.Key, signers map[string]bool) int {
+	count := 0
+	for _, key := range keys {
+		if isKeySigned(key, signers) {
+			count++
+		}
+	}
+	return count
+}
+
+// verifySignatures returns the raw public keys of the signers, or hedera.StatusInvalidSignature if the transaction
+// has no signature or any of its signatures doesn't verify against the frozen transaction body
+func verifySignatures(transaction ITransaction, frozenBodyBytes []byte) (
+	map[string]bool,
+	hedera.Status,
+	*rTypes.Error,
+) {
+	signatures, err := transaction.GetSignatures()
+	if err != nil {
+		return nil, 0, errors.ErrTransactionUnmarshallingFailed
+	}
+
+	signers := make(map[string]bool)
+	for _, signatureMap := range signatures {
+		for pubKey, signature := range signatureMap {
+			if !ed25519.Verify(pubKey.Bytes(), frozenBodyBytes, signature) {
+				return nil, hedera.StatusInvalidSignature, nil
+			}
+			signers[string(pubKey.Bytes())] = true
+		}
+	}
+
+	if len(signers) == 0 {
+		return nil, hedera.StatusInvalidSignature, nil
+	}
+
+	return signers, hedera.StatusOk, nil
+}
@@ -0,0 +1,302 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package construction
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+	"time"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/mocks/repository"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/hashgraph/hedera-sdk-go/v2/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+var precheckValidStart = time.Unix(1623101500, 0)
+
+func toSerializedKey(key *proto.Key) []byte {
+	serialized, _ := protobuf.Marshal(key)
+	return serialized
+}
+
+func toEd25519Key(publicKey hedera.PublicKey) *proto.Key {
+	return &proto.Key{Key: &proto.Key_Ed25519{Ed25519: publicKey.Bytes()}}
+}
+
+func newPrecheckTransaction(payer hedera.AccountID, validDuration time.Duration) *hedera.TransferTransaction {
+	transaction, _ := hedera.NewTransferTransaction().
+		AddHbarTransfer(payer, hedera.HbarFromTinybar(-100)).
+		AddHbarTransfer(nodeAccountId, hedera.HbarFromTinybar(100)).
+		SetNodeAccountIDs([]hedera.AccountID{nodeAccountId}).
+		SetTransactionID(hedera.NewTransactionIDWithValidStart(payer, precheckValidStart)).
+		SetTransactionValidDuration(validDuration).
+		Freeze()
+	return transaction
+}
+
+func toSignedTransactionString(transaction ITransaction) string {
+	bytes, _ := transaction.ToBytes()
+	return hex.EncodeToString(bytes)
+}
+
+func newTransactionPrechecker(accountRepo *repository.MockAccountRepository, now time.Time) *transactionPrechecker {
+	prechecker := NewTransactionPrechecker(accountRepo).(*transactionPrechecker)
+	prechecker.now = func() time.Time { return now }
+	return prechecker
+}
+
+func TestPrecheck(t *testing.T) {
+	signed := newPrecheckTransaction(payerId, 120*time.Second).Sign(privateKey)
+	badSignature := newPrecheckTransaction(payerId, 120*time.Second)
+	_ = addSignature(badSignature, privateKey.PublicKey(), make([]byte, 64))
+	otherPrivateKey, _ := hedera.GeneratePrivateKey()
+	payerKey := toSerializedKey(toEd25519Key(privateKey.PublicKey()))
+	maxFee := hedera.NewHbar(1).AsTinybar()
+
+	var tests = []struct {
+		name        string
+		transaction ITransaction
+		now         time.Time
+		payerKey    []byte
+		hbarBalance int64
+		expected    hedera.Status
+	}{
+		{
+			name:        "Ok",
+			transaction: signed,
+			now:         precheckValidStart.Add(time.Second),
+			payerKey:    payerKey,
+			hbarBalance: maxFee,
+			expected:    hedera.StatusOk,
+		},
+		{
+			name:        "InsufficientPayerBalance",
+			transaction: signed,
+			now:         precheckValidStart.Add(time.Second),
+			payerKey:    payerKey,
+			hbarBalance: maxFee - 1,
+			expected:    hedera.StatusInsufficientPayerBalance,
+		},
+		{
+			name:        "PayerAccountNotFound",
+			transaction: signed,
+			now:         precheckValidStart.Add(time.Second),
+			expected:    hedera.StatusPayerAccountNotFound,
+		},
+		{
+			name:        "NotSignedByPayer",
+			transaction: newPrecheckTransaction(payerId, 120*time.Second).Sign(otherPrivateKey),
+			now:         precheckValidStart.Add(time.Second),
+			payerKey:    payerKey,
+			expected:    hedera.StatusInvalidSignature,
+		},
+		{
+			name:        "PayerWithoutKey",
+			transaction: signed,
+			now:         precheckValidStart.Add(time.Second),
+			payerKey:    []byte{},
+			expected:    hedera.StatusInvalidSignature,
+		},
+		{
+			name:        "InvalidTransactionStart",
+			transaction: signed,
+			now:         precheckValidStart.Add(-time.Second),
+			expected:    hedera.StatusInvalidTransactionStart,
+		},
+		{
+			name:        "TransactionExpired",
+			transaction: signed,
+			now:         precheckValidStart.Add(120 * time.Second),
+			expected:    hedera.StatusTransactionExpired,
+		},
+		{
+			name:        "TooShortValidDuration",
+			transaction: newPrecheckTransaction(payerId, 10*time.Second).Sign(privateKey),
+			now:         precheckValidStart,
+			expected:    hedera.StatusInvalidTransactionDuration,
+		},
+		{
+			name:        "TooLongValidDuration",
+			transaction: newPrecheckTransaction(payerId, 181*time.Second).Sign(privateKey),
+			now:         precheckValidStart,
+			expected:    hedera.StatusInvalidTransactionDuration,
+		},
+		{
+			name:        "ZeroPayer",
+			transaction: newPrecheckTransaction(hedera.AccountID{}, 120*time.Second).Sign(privateKey),
+			now:         precheckValidStart,
+			expected:    hedera.StatusInvalidTransactionID,
+		},
+		{
+			name:        "NoSignature",
+			transaction: newPrecheckTransaction(payerId, 120*time.Second),
+			now:         precheckValidStart.Add(time.Second),
+			expected:    hedera.StatusInvalidSignature,
+		},
+		{
+			name:        "InvalidSignature",
+			transaction: badSignature,
+			now:         precheckValidStart.Add(time.Second),
+			expected:    hedera.StatusInvalidSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			mockAccountRepo := &repository.MockAccountRepository{}
+			mockAccountRepo.On("FindKey", payerId.String()).Return(tt.payerKey, repository.NilError)
+			mockAccountRepo.
+				On("RetrieveBalanceAtBlock", payerId.String(), int64(math.MaxInt64), []int64{}, int64(0), 0).
+				Return([]types.Amount{&types.HbarAmount{Value: tt.hbarBalance}}, repository.NilError)
+			prechecker := newTransactionPrechecker(mockAccountRepo, tt.now)
+
+			// when
+			actual, err := prechecker.Precheck(toSignedTransactionString(tt.transaction))
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, actual)
+			if tt.expected != hedera.StatusOk && tt.expected != hedera.StatusInsufficientPayerBalance {
+				mockAccountRepo.AssertNotCalled(t, "RetrieveBalanceAtBlock")
+			}
+		})
+	}
+}
+
+func TestPrecheckThrows(t *testing.T) {
+	var tests = []struct {
+		name              string
+		signedTransaction string
+		expected          *rTypes.Error
+	}{
+		{name: "InvalidHexString", signedTransaction: invalidTransaction, expected: errors.ErrTransactionDecodeFailed},
+		{
+			name:              "InvalidTransactionBytes",
+			signedTransaction: corruptedTransaction,
+			expected:          errors.ErrTransactionUnmarshallingFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			prechecker := newTransactionPrechecker(&repository.MockAccountRepository{}, precheckValidStart)
+
+			// when
+			actual, err := prechecker.Precheck(tt.signedTransaction)
+
+			// then
+			assert.Equal(t, tt.expected, err)
+			assert.Equal(t, hedera.Status(0), actual)
+		})
+	}
+}
+
+func TestPrecheckThrowsDbError(t *testing.T) {
+	payerKey := toSerializedKey(toEd25519Key(privateKey.PublicKey()))
+	var tests = []struct {
+		name       string
+		findKeyErr *rTypes.Error
+		balanceErr *rTypes.Error
+	}{
+		{name: "FindKey", findKeyErr: errors.ErrDatabaseError},
+		{name: "RetrieveBalanceAtBlock", balanceErr: errors.ErrDatabaseError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			mockAccountRepo := &repository.MockAccountRepository{}
+			mockAccountRepo.On("FindKey", payerId.String()).Return(payerKey, tt.findKeyErr)
+			mockAccountRepo.
+				On("RetrieveBalanceAtBlock", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return([]types.Amount{}, tt.balanceErr)
+			prechecker := newTransactionPrechecker(mockAccountRepo, precheckValidStart.Add(time.Second))
+			signedTransaction := toSignedTransactionString(
+				newPrecheckTransaction(payerId, 120*time.Second).Sign(privateKey),
+			)
+
+			// when
+			actual, err := prechecker.Precheck(signedTransaction)
+
+			// then
+			assert.Equal(t, errors.ErrDatabaseError, err)
+			assert.Equal(t, hedera.Status(0), actual)
+		})
+	}
+}
+
+func TestIsKeySigned(t *testing.T) {
+	signer := toEd25519Key(privateKey.PublicKey())
+	otherPrivateKey, _ := hedera.GeneratePrivateKey()
+	other := toEd25519Key(otherPrivateKey.PublicKey())
+	signers := map[string]bool{string(privateKey.PublicKey().Bytes()): true}
+
+	var tests = []struct {
+		name     string
+		key      *proto.Key
+		expected bool
+	}{
+		{name: "Ed25519", key: signer, expected: true},
+		{name: "Ed25519NotSigned", key: other},
+		{
+			name:     "KeyList",
+			key:      &proto.Key{Key: &proto.Key_KeyList{KeyList: &proto.KeyList{Keys: []*proto.Key{signer}}}},
+			expected: true,
+		},
+		{
+			name: "KeyListPartiallySigned",
+			key:  &proto.Key{Key: &proto.Key_KeyList{KeyList: &proto.KeyList{Keys: []*proto.Key{signer, other}}}},
+		},
+		{name: "EmptyKeyList", key: &proto.Key{Key: &proto.Key_KeyList{KeyList: &proto.KeyList{}}}},
+		{
+			name: "ThresholdKey",
+			key: &proto.Key{Key: &proto.Key_ThresholdKey{ThresholdKey: &proto.ThresholdKey{
+				Keys:      &proto.KeyList{Keys: []*proto.Key{signer, other}},
+				Threshold: 1,
+			}}},
+			expected: true,
+		},
+		{
+			name: "ThresholdKeyNotMet",
+			key: &proto.Key{Key: &proto.Key_ThresholdKey{ThresholdKey: &proto.ThresholdKey{
+				Keys:      &proto.KeyList{Keys: []*proto.Key{signer, other}},
+				Threshold: 2,
+			}}},
+		},
+		{name: "ContractId", key: &proto.Key{Key: &proto.Key_ContractID{ContractID: &proto.ContractID{}}}},
+		{name: "Empty", key: &proto.Key{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isKeySigned(tt.key, signers))
+		})
+	}
+}
@@ -21,6 +21,8 @@
 package construction
 
 import (
+	"errors"
+
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/parse"
 	"github.com/hashgraph/hedera-sdk-go/v2"
@@ -39,8 +41,8 @@ type ITransaction interface {
 
 // TransactionConstructor defines the methods to construct a transaction
 type TransactionConstructor interface {
-	// Construct constructs a transaction from its operations
-	Construct(nodeAccountId hedera.AccountID, operations []*types.Operation) (
+	// Construct constructs a transaction from its operations with the max transaction fee the payer is willing to pay
+	Construct(nodeAccountId hedera.AccountID, operations []*types.Operation, maxTransactionFee hedera.Hbar) (
 		ITransaction,
 		[]hedera.AccountID,
 		*types.Error,
@@ -53,6 +55,39 @@ type TransactionConstructor interface {
 	Preprocess(operations []*types.Operation) ([]hedera.AccountID, *types.Error)
 }
 
+// TransactionPrechecker defines the methods to precheck a signed transaction without submitting it
+type TransactionPrechecker interface {
+	// Precheck runs the checks a consensus node does before accepting the signed transaction and returns the status
+	Precheck(signedTransaction string) (hedera.Status, *types.Error)
+}
+
+// embed SDK AccountID and implement the Unmarshaler interface. The unmarshalled address is parsed with the entity id
+// encoding by resolve, so its checksum is validated against the ledger id of the network
+type metadataAccountId struct {
+	hedera.AccountID
+	address string
+}
+
+func (a *metadataAccountId) UnmarshalJSON(data []byte) error {
+	a.address = parse.SafeUnquote(string(data))
+	if a.address == "" {
+		return errors.New("empty account id")
+	}
+	return nil
+}
+
+// resolve parses the unmarshalled address into the embedded AccountID, the optional checksum is validated against
+// ledgerId. It's a no-op if the account id isn't present in the metadata
+func (a *metadataAccountId) resolve(ledgerId []byte) error {
+	if a == nil || a.address == "" {
+		return nil
+	}
+
+	var err error
+	a.AccountID, err = parseAccountId(a.address, ledgerId)
+	return err
+}
+
 // embed SDK PublicKey and implement the Unmarshaler interface
 type publicKey struct {
 	hedera.PublicKey
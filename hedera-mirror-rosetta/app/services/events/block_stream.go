@@ -0,0 +1,133 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/hex"
+	log "github.com/sirupsen/logrus"
+)
+
+const blockStreamPath = "/stream/blocks"
+
+// blockSummary is the data of a block event in the block stream
+type blockSummary struct {
+	BlockIdentifier       *rTypes.BlockIdentifier `json:"block_identifier"`
+	ParentBlockIdentifier *rTypes.BlockIdentifier `json:"parent_block_identifier"`
+	Timestamp             int64                   `json:"timestamp"`
+}
+
+// blockStreamController implements the server.Router interface for the /stream/blocks endpoint, which pushes the new
+// blocks published to the hub as server-sent events
+type blockStreamController struct {
+	bufferSize        int
+	hub               *BlockHub
+	keepAliveInterval time.Duration
+}
+
+// NewBlockStreamController creates a server.Router serving the /stream/blocks endpoint. Each subscriber buffers up to
+// bufferSize blocks, and a comment is sent every keepAliveInterval without a new block so the idle connection isn't
+// closed by a proxy
+func NewBlockStreamController(hub *BlockHub, bufferSize int, keepAliveInterval time.Duration) server.Router {
+	return &blockStreamController{bufferSize: bufferSize, hub: hub, keepAliveInterval: keepAliveInterval}
+}
+
+// Routes returns the block stream route of the blockStreamController
+func (c *blockStreamController) Routes() server.Routes {
+	return server.Routes{
+		{
+			Name:        "StreamBlocks",
+			Method:      http.MethodGet,
+			Pattern:     blockStreamPath,
+			HandlerFunc: c.StreamBlocks,
+		},
+	}
+}
+
+// StreamBlocks implements the /stream/blocks endpoint. A block event has the block index as its id and the block
+// summary as its data. A block is dropped for a client too slow to keep up, so the client fetches the blocks between
+// two events whose ids aren't consecutive with /block
+func (c *blockStreamController) StreamBlocks(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming isn't supported", http.StatusInternalServerError)
+		return
+	}
+
+	blocks, cancel := c.hub.Subscribe(c.bufferSize)
+	defer cancel()
+
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(c.keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case block, ok := <-blocks:
+			if !ok {
+				return
+			}
+
+			if err := writeBlockEvent(w, block); err != nil {
+				log.Debugf("Failed to write the block stream event: %s", err)
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func writeBlockEvent(w http.ResponseWriter, block *types.Block) error {
+	data, err := json.Marshal(blockSummary{
+		BlockIdentifier: &rTypes.BlockIdentifier{
+			Index: block.Index,
+			Hash:  hex.SafeAddHexPrefix(block.Hash),
+		},
+		ParentBlockIdentifier: &rTypes.BlockIdentifier{
+			Index: block.ParentIndex,
+			Hash:  hex.SafeAddHexPrefix(block.ParentHash),
+		},
+		Timestamp: block.GetTimestampMillis(),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: block\ndata: %s\n\n", block.Index, data)
+	return err
+}
@@ -0,0 +1,125 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package events
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockStreamController(t *testing.T) {
+	// given
+	hub := NewBlockHub()
+	reader, cancel := openBlockStream(t, hub, time.Hour)
+	defer cancel()
+	block := &types.Block{
+		Index:               5,
+		Hash:                "0a",
+		ConsensusStartNanos: 2000000,
+		ParentIndex:         4,
+		ParentHash:          "09",
+	}
+	expected := []string{
+		"id: 5",
+		"event: block",
+		`data: {"block_identifier":{"index":5,"hash":"0x0a"},"parent_block_identifier":{"index":4,"hash":"0x09"},` +
+			`"timestamp":2}`,
+	}
+
+	// when
+	hub.Publish(block)
+
+	// then
+	assert.Equal(t, expected, readEvent(t, reader))
+}
+
+func TestBlockStreamControllerKeepAlive(t *testing.T) {
+	// given
+	hub := NewBlockHub()
+
+	// when
+	reader, cancel := openBlockStream(t, hub, 10*time.Millisecond)
+	defer cancel()
+
+	// then
+	assert.Equal(t, []string{": keep-alive"}, readEvent(t, reader))
+}
+
+func TestBlockStreamControllerUnsubscribes(t *testing.T) {
+	// given
+	hub := NewBlockHub()
+	_, cancel := openBlockStream(t, hub, time.Hour)
+
+	// when
+	cancel()
+
+	// then
+	assert.Eventually(t, func() bool {
+		hub.mutex.RLock()
+		defer hub.mutex.RUnlock()
+		return len(hub.subscribers) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+// openBlockStream opens the block stream served with the hub and returns the reader of the response body and the
+// function to close it. The client is subscribed to the hub once the response headers are received
+func openBlockStream(t *testing.T, hub *BlockHub, keepAliveInterval time.Duration) (*bufio.Reader, func()) {
+	httpServer := httptest.NewServer(server.NewRouter(NewBlockStreamController(hub, 4, keepAliveInterval)))
+	t.Cleanup(httpServer.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, httpServer.URL+blockStreamPath, nil)
+	require.NoError(t, err)
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+
+	return bufio.NewReader(response.Body), func() {
+		cancel()
+		response.Body.Close()
+	}
+}
+
+// readEvent returns the lines of the next event
+func readEvent(t *testing.T, reader *bufio.Reader) []string {
+	lines := make([]string, 0)
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return lines
+		}
+		lines = append(lines, line)
+	}
+}
@@ -0,0 +1,61 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package events
+
+import (
+	"sync"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// BlockWatcher refreshes the latest block when notified of a new record file, and publishes it to the hub if it's new
+type BlockWatcher struct {
+	blockRepo repositories.BlockRepository
+	hub       *BlockHub
+	latest    *types.Block
+	mutex     sync.Mutex
+}
+
+// NewBlockWatcher creates a new instance of a BlockWatcher
+func NewBlockWatcher(blockRepo repositories.BlockRepository, hub *BlockHub) *BlockWatcher {
+	return &BlockWatcher{blockRepo: blockRepo, hub: hub}
+}
+
+// OnRecordFile refreshes the latest block and publishes it if its index is greater than the last published block's
+func (w *BlockWatcher) OnRecordFile() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	block, err := w.blockRepo.RefreshLatest()
+	if err != nil {
+		log.Debugf("Failed to refresh the latest block: %s", err.Message)
+		return
+	}
+
+	if w.latest != nil && block.Index <= w.latest.Index {
+		return
+	}
+
+	w.latest = block
+	w.hub.Publish(block)
+}
@@ -0,0 +1,70 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package events
+
+import (
+	"testing"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/mocks/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockWatcherOnRecordFile(t *testing.T) {
+	// given
+	block1 := &types.Block{Index: 1}
+	block2 := &types.Block{Index: 2}
+	mockBlockRepo := &repository.MockBlockRepository{}
+	mockBlockRepo.On("RefreshLatest").Return(block1, repository.NilError).Twice()
+	mockBlockRepo.On("RefreshLatest").Return(block2, repository.NilError).Once()
+	hub := NewBlockHub()
+	subscriber, cancel := hub.Subscribe(3)
+	defer cancel()
+	watcher := NewBlockWatcher(mockBlockRepo, hub)
+
+	// when
+	watcher.OnRecordFile()
+	watcher.OnRecordFile()
+	watcher.OnRecordFile()
+
+	// then
+	assert.Len(t, subscriber, 2)
+	assert.Equal(t, block1, <-subscriber)
+	assert.Equal(t, block2, <-subscriber)
+	mockBlockRepo.AssertExpectations(t)
+}
+
+func TestBlockWatcherOnRecordFileRefreshFails(t *testing.T) {
+	// given
+	mockBlockRepo := &repository.MockBlockRepository{}
+	mockBlockRepo.On("RefreshLatest").Return(repository.NilBlock, errors.ErrDatabaseError)
+	hub := NewBlockHub()
+	subscriber, cancel := hub.Subscribe(1)
+	defer cancel()
+	watcher := NewBlockWatcher(mockBlockRepo, hub)
+
+	// when
+	watcher.OnRecordFile()
+
+	// then
+	assert.Len(t, subscriber, 0)
+}
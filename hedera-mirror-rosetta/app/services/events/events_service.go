@@ -0,0 +1,104 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package events
+
+import (
+	"context"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/base"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/hex"
+)
+
+// maxBlockEvents is the maximum number of block events returned in one /events/blocks response
+const maxBlockEvents = 100
+
+// EventsAPIService implements the server.EventsAPIServicer interface.
+type EventsAPIService struct {
+	base.BaseService
+}
+
+// NewEventsAPIService creates a new instance of a EventsAPIService.
+func NewEventsAPIService(base base.BaseService) *EventsAPIService {
+	return &EventsAPIService{BaseService: base}
+}
+
+// EventsBlocks implements the /events/blocks endpoint. Since the mirror node never removes a block, every block has
+// one block_added event with the block index as its sequence
+func (e *EventsAPIService) EventsBlocks(
+	ctx context.Context,
+	request *rTypes.EventsBlocksRequest,
+) (*rTypes.EventsBlocksResponse, *rTypes.Error) {
+	limit := int64(maxBlockEvents)
+	if request.Limit != nil {
+		if *request.Limit < 0 {
+			return nil, errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField, "limit")
+		}
+
+		if *request.Limit < limit {
+			limit = *request.Limit
+		}
+	}
+
+	latest, err := e.RetrieveLatest()
+	if err != nil {
+		return nil, err
+	}
+
+	// without offset, return the limit events backwards from tip
+	start := latest.Index - limit + 1
+	if request.Offset != nil {
+		if *request.Offset < 0 {
+			return nil, errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField, "offset")
+		}
+		start = *request.Offset
+	}
+	if start < 0 {
+		start = 0
+	}
+
+	end := start + limit - 1
+	if end > latest.Index {
+		end = latest.Index
+	}
+
+	events := make([]*rTypes.BlockEvent, 0)
+	if limit != 0 && start <= end {
+		blocks, err := e.FindBetweenIndexes(start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, block := range blocks {
+			events = append(events, &rTypes.BlockEvent{
+				Sequence: block.Index,
+				BlockIdentifier: &rTypes.BlockIdentifier{
+					Index: block.Index,
+					Hash:  hex.SafeAddHexPrefix(block.Hash),
+				},
+				Type: rTypes.ADDED,
+			})
+		}
+	}
+
+	return &rTypes.EventsBlocksResponse{MaxSequence: latest.Index, Events: events}, nil
+}
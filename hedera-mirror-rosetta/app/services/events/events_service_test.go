@@ -0,0 +1,168 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package events
+
+import (
+	"testing"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/base"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/mocks/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func blocks(start, end int64) []*types.Block {
+	result := make([]*types.Block, 0)
+	for index := start; index <= end; index++ {
+		result = append(result, &types.Block{Index: index, Hash: "0a"})
+	}
+	return result
+}
+
+func blockEvents(start, end int64) []*rTypes.BlockEvent {
+	result := make([]*rTypes.BlockEvent, 0)
+	for index := start; index <= end; index++ {
+		result = append(result, &rTypes.BlockEvent{
+			Sequence:        index,
+			BlockIdentifier: &rTypes.BlockIdentifier{Index: index, Hash: "0x0a"},
+			Type:            rTypes.ADDED,
+		})
+	}
+	return result
+}
+
+func int64Pointer(value int64) *int64 {
+	return &value
+}
+
+func TestEventsServiceSuite(t *testing.T) {
+	suite.Run(t, new(eventsServiceSuite))
+}
+
+type eventsServiceSuite struct {
+	suite.Suite
+	eventsService *EventsAPIService
+	mockBlockRepo *repository.MockBlockRepository
+}
+
+func (suite *eventsServiceSuite) SetupTest() {
+	suite.mockBlockRepo = &repository.MockBlockRepository{}
+	baseService := base.NewBaseService(suite.mockBlockRepo, &repository.MockTransactionRepository{})
+	suite.eventsService = NewEventsAPIService(baseService)
+}
+
+func (suite *eventsServiceSuite) TestEventsBlocks() {
+	var tests = []struct {
+		name          string
+		offset        *int64
+		limit         *int64
+		expectedStart int64
+		expectedEnd   int64
+	}{
+		{name: "FromTip", limit: int64Pointer(3), expectedStart: 148, expectedEnd: 150},
+		{name: "FromTipDefaultLimit", expectedStart: 51, expectedEnd: 150},
+		{name: "FromOffset", offset: int64Pointer(10), limit: int64Pointer(5), expectedStart: 10, expectedEnd: 14},
+		{name: "FromOffsetNearTip", offset: int64Pointer(149), limit: int64Pointer(5), expectedStart: 149, expectedEnd: 150},
+		{name: "LimitCapped", offset: int64Pointer(0), limit: int64Pointer(1000), expectedStart: 0, expectedEnd: 99},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// given
+			suite.SetupTest()
+			suite.mockBlockRepo.On("RetrieveLatest").Return(&types.Block{Index: 150}, repository.NilError)
+			suite.mockBlockRepo.On("FindBetweenIndexes", tt.expectedStart, tt.expectedEnd).
+				Return(blocks(tt.expectedStart, tt.expectedEnd), repository.NilError)
+
+			// when
+			actual, err := suite.eventsService.EventsBlocks(
+				nil,
+				&rTypes.EventsBlocksRequest{Offset: tt.offset, Limit: tt.limit},
+			)
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, &rTypes.EventsBlocksResponse{
+				MaxSequence: 150,
+				Events:      blockEvents(tt.expectedStart, tt.expectedEnd),
+			}, actual)
+			suite.mockBlockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func (suite *eventsServiceSuite) TestEventsBlocksOffsetAfterTip() {
+	// given
+	suite.mockBlockRepo.On("RetrieveLatest").Return(&types.Block{Index: 150}, repository.NilError)
+
+	// when
+	actual, err := suite.eventsService.EventsBlocks(nil, &rTypes.EventsBlocksRequest{Offset: int64Pointer(151)})
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), &rTypes.EventsBlocksResponse{MaxSequence: 150, Events: []*rTypes.BlockEvent{}}, actual)
+	suite.mockBlockRepo.AssertNotCalled(suite.T(), "FindBetweenIndexes")
+}
+
+func (suite *eventsServiceSuite) TestEventsBlocksInvalidArgument() {
+	var tests = []struct {
+		name   string
+		offset *int64
+		limit  *int64
+		field  string
+	}{
+		{name: "NegativeOffset", offset: int64Pointer(-1), field: "offset"},
+		{name: "NegativeLimit", limit: int64Pointer(-1), field: "limit"},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			// given
+			suite.SetupTest()
+			suite.mockBlockRepo.On("RetrieveLatest").Return(&types.Block{Index: 150}, repository.NilError)
+
+			// when
+			actual, err := suite.eventsService.EventsBlocks(
+				nil,
+				&rTypes.EventsBlocksRequest{Offset: tt.offset, Limit: tt.limit},
+			)
+
+			// then
+			assert.Equal(t, errors.AddErrorDetails(errors.ErrInvalidArgument, errors.DetailField, tt.field), err)
+			assert.Nil(t, actual)
+		})
+	}
+}
+
+func (suite *eventsServiceSuite) TestEventsBlocksThrowsWhenRetrieveLatestFails() {
+	// given
+	suite.mockBlockRepo.On("RetrieveLatest").Return(repository.NilBlock, errors.ErrBlockNotFound)
+
+	// when
+	actual, err := suite.eventsService.EventsBlocks(nil, &rTypes.EventsBlocksRequest{})
+
+	// then
+	assert.Equal(suite.T(), errors.ErrBlockNotFound, err)
+	assert.Nil(suite.T(), actual)
+}
@@ -0,0 +1,75 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package events
+
+import (
+	"sync"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// BlockHub broadcasts new blocks to its subscribers
+type BlockHub struct {
+	mutex       sync.RWMutex
+	subscribers map[chan *types.Block]struct{}
+}
+
+// NewBlockHub creates a new instance of a BlockHub
+func NewBlockHub() *BlockHub {
+	return &BlockHub{subscribers: make(map[chan *types.Block]struct{})}
+}
+
+// Subscribe returns a channel receiving the published blocks and the function to cancel the subscription. The channel
+// has bufferSize capacity, and a block is dropped for the subscriber if its buffer is full
+func (h *BlockHub) Subscribe(bufferSize int) (<-chan *types.Block, func()) {
+	subscriber := make(chan *types.Block, bufferSize)
+
+	h.mutex.Lock()
+	h.subscribers[subscriber] = struct{}{}
+	h.mutex.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			h.mutex.Lock()
+			delete(h.subscribers, subscriber)
+			h.mutex.Unlock()
+			close(subscriber)
+		})
+	}
+
+	return subscriber, cancel
+}
+
+// Publish sends the block to all subscribers without blocking
+func (h *BlockHub) Publish(block *types.Block) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	for subscriber := range h.subscribers {
+		select {
+		case subscriber <- block:
+		default:
+			log.Warnf("Dropped block %d for a slow subscriber", block.Index)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package events
+
+import (
+	"testing"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockHubPublish(t *testing.T) {
+	// given
+	hub := NewBlockHub()
+	first, cancelFirst := hub.Subscribe(1)
+	second, cancelSecond := hub.Subscribe(1)
+	defer cancelFirst()
+	defer cancelSecond()
+	block := &types.Block{Index: 5}
+
+	// when
+	hub.Publish(block)
+
+	// then
+	assert.Equal(t, block, <-first)
+	assert.Equal(t, block, <-second)
+}
+
+func TestBlockHubPublishDropsForSlowSubscriber(t *testing.T) {
+	// given
+	hub := NewBlockHub()
+	subscriber, cancel := hub.Subscribe(1)
+	defer cancel()
+	block1 := &types.Block{Index: 5}
+	block2 := &types.Block{Index: 6}
+
+	// when
+	hub.Publish(block1)
+	hub.Publish(block2)
+
+	// then
+	assert.Equal(t, block1, <-subscriber)
+	assert.Len(t, subscriber, 0)
+}
+
+func TestBlockHubCancel(t *testing.T) {
+	// given
+	hub := NewBlockHub()
+	subscriber, cancel := hub.Subscribe(1)
+
+	// when
+	cancel()
+	cancel()
+	hub.Publish(&types.Block{Index: 5})
+
+	// then
+	_, ok := <-subscriber
+	assert.False(t, ok)
+	assert.Empty(t, hub.subscribers)
+}
@@ -27,8 +27,10 @@ import (
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/nodehealth"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/transaction"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/base"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/tools/hex"
 )
 
@@ -36,7 +38,14 @@ import (
 type NetworkAPIService struct {
 	base.BaseService
 	addressBookEntryRepo repositories.AddressBookEntryRepository
+	addressBookRepo      repositories.AddressBookRepository
+	callMethods          []string
+	exemptAccounts       []string
+	exemptNodeAccounts   bool
 	network              *types.NetworkIdentifier
+	networkVersionRepo   repositories.NetworkVersionRepository
+	nodeHealth           *nodehealth.Tracker
+	settings             *config.Settings
 	version              *types.Version
 }
 
@@ -66,6 +75,16 @@ func (n *NetworkAPIService) NetworkOptions(
 		return nil, err
 	}
 
+	version, err := n.getVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	balanceExemptions, err := n.getBalanceExemptions()
+	if err != nil {
+		return nil, err
+	}
+
 	operationStatuses := make([]*types.OperationStatus, 0, len(results))
 	for value, name := range results {
 		operationStatuses = append(operationStatuses, &types.OperationStatus{
@@ -75,12 +94,14 @@ func (n *NetworkAPIService) NetworkOptions(
 	}
 
 	return &types.NetworkOptionsResponse{
-		Version: n.version,
+		Version: version,
 		Allow: &types.Allow{
 			OperationStatuses:       operationStatuses,
 			OperationTypes:          operationTypes,
 			Errors:                  errors.Errors,
 			HistoricalBalanceLookup: true,
+			CallMethods:             n.callMethods,
+			BalanceExemptions:       balanceExemptions,
 		},
 	}, nil
 }
@@ -100,7 +121,7 @@ func (n *NetworkAPIService) NetworkStatus(
 		return nil, err
 	}
 
-	peers, err := n.addressBookEntryRepo.Entries()
+	peers, err := n.getPeers()
 	if err != nil {
 		return nil, err
 	}
@@ -115,21 +136,138 @@ func (n *NetworkAPIService) NetworkStatus(
 			Index: genesisBlock.Index,
 			Hash:  hex.SafeAddHexPrefix(genesisBlock.Hash),
 		},
-		Peers: peers.ToRosetta(),
+		Peers: peers,
 	}, nil
 }
 
-// NewNetworkAPIService creates a new instance of a NetworkAPIService.
+// getPeers returns the consensus nodes in the current address book as the peers, with the health score derived from
+// the recent submits to a node added to its metadata. There are no peers before the first address book is imported
+func (n *NetworkAPIService) getPeers() ([]*types.Peer, *types.Error) {
+	addressBook, err := n.addressBookRepo.FindLatest()
+	if err == errors.ErrAddressBookNotFound {
+		return []*types.Peer{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	scores := make(map[string]nodehealth.Score)
+	if n.nodeHealth != nil {
+		for _, score := range n.nodeHealth.Scores() {
+			scores[score.NodeAccountId.String()] = score
+		}
+	}
+
+	peers := make([]*types.Peer, 0, len(addressBook.Nodes))
+	for _, node := range addressBook.Nodes {
+		peer := node.ToRosettaPeer()
+		if score, ok := scores[peer.PeerID]; ok {
+			peer.Metadata["health"] = score.ToMetadata()
+		}
+		peers = append(peers, peer)
+	}
+
+	return peers, nil
+}
+
+// getBalanceExemptions returns the hbar balance exemptions of the configured exempt accounts, and the node accounts in
+// the latest address book if enabled. Since a balance exemption can't be keyed by the account address, the account id is
+// set as the sub account address
+func (n *NetworkAPIService) getBalanceExemptions() ([]*types.BalanceExemption, *types.Error) {
+	accounts := n.exemptAccounts
+	if n.exemptNodeAccounts {
+		entries, err := n.addressBookEntryRepo.Entries()
+		if err != nil {
+			return nil, err
+		}
+
+		accounts = make([]string, 0, len(n.exemptAccounts)+len(entries.Entries))
+		accounts = append(accounts, n.exemptAccounts...)
+		for _, entry := range entries.Entries {
+			accounts = append(accounts, entry.PeerId.String())
+		}
+	}
+
+	balanceExemptions := make([]*types.BalanceExemption, 0, len(accounts))
+	exempted := make(map[string]bool, len(accounts))
+	for _, account := range accounts {
+		if exempted[account] {
+			continue
+		}
+
+		exempted[account] = true
+		subAccountAddress := account
+		balanceExemptions = append(balanceExemptions, &types.BalanceExemption{
+			SubAccountAddress: &subAccountAddress,
+			Currency:          n.settings.CurrencyHbar,
+			ExemptionType:     types.BalanceDynamic,
+		})
+	}
+
+	return balanceExemptions, nil
+}
+
+// getVersion returns the configured version with the node version replaced by the HAPI version of the latest record
+// file, the HAPI version and the mirror node schema version are also added to the metadata
+func (n *NetworkAPIService) getVersion() (*types.Version, *types.Error) {
+	if n.networkVersionRepo == nil {
+		return n.version, nil
+	}
+
+	networkVersion, err := n.networkVersionRepo.Find()
+	if err != nil {
+		return nil, err
+	}
+
+	version := *n.version
+	metadata := make(map[string]interface{})
+	for key, value := range n.version.Metadata {
+		metadata[key] = value
+	}
+
+	if networkVersion.HapiVersion != "" {
+		version.NodeVersion = networkVersion.HapiVersion
+		metadata["hapi_version"] = networkVersion.HapiVersion
+	}
+
+	if networkVersion.MirrorSchemaVersion != "" {
+		metadata["mirror_schema_version"] = networkVersion.MirrorSchemaVersion
+	}
+
+	if len(metadata) != 0 {
+		version.Metadata = metadata
+	}
+
+	return &version, nil
+}
+
+// NewNetworkAPIService creates a new instance of a NetworkAPIService. The exemptAccounts, and the node accounts if
+// exemptNodeAccounts is true, are reported as hbar balance exemptions in /network/options. The nodes in the address
+// book are reported as peers in /network/status, with their health scores if nodeHealth isn't nil. The callMethods are
+// the /call methods reported in /network/options, and the balance exemptions are in the hbar currency of the settings
 func NewNetworkAPIService(
 	commons base.BaseService,
 	addressBookEntryRepo repositories.AddressBookEntryRepository,
+	addressBookRepo repositories.AddressBookRepository,
+	networkVersionRepo repositories.NetworkVersionRepository,
+	nodeHealth *nodehealth.Tracker,
 	network *types.NetworkIdentifier,
 	version *types.Version,
+	callMethods []string,
+	exemptAccounts []string,
+	exemptNodeAccounts bool,
+	settings *config.Settings,
 ) server.NetworkAPIServicer {
 	return &NetworkAPIService{
 		BaseService:          commons,
 		addressBookEntryRepo: addressBookEntryRepo,
+		addressBookRepo:      addressBookRepo,
+		callMethods:          callMethods,
+		exemptAccounts:       exemptAccounts,
+		exemptNodeAccounts:   exemptNodeAccounts,
 		network:              network,
+		networkVersionRepo:   networkVersionRepo,
+		nodeHealth:           nodeHealth,
+		settings:             settings,
 		version:              version,
 	}
 }
@@ -26,10 +26,14 @@ import (
 	"github.com/coinbase/rosetta-sdk-go/server"
 	rTypes "github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
+	entityid "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/services/encoding"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/nodehealth"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/base"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
 	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/mocks/repository"
+	"github.com/hashgraph/hedera-sdk-go/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -56,10 +60,19 @@ func dummyLatestBlock() *types.Block {
 	}
 }
 
-func networkAPIService(abr repositories.AddressBookEntryRepository, base base.BaseService) server.NetworkAPIServicer {
+func networkAPIService(
+	aber repositories.AddressBookEntryRepository,
+	abr repositories.AddressBookRepository,
+	nvr repositories.NetworkVersionRepository,
+	nodeHealth *nodehealth.Tracker,
+	base base.BaseService,
+) server.NetworkAPIServicer {
 	return NewNetworkAPIService(
 		base,
+		aber,
 		abr,
+		nvr,
+		nodeHealth,
 		&rTypes.NetworkIdentifier{
 			Blockchain: "SomeBlockchain",
 			Network:    "SomeNetwork",
@@ -74,9 +87,21 @@ func networkAPIService(abr repositories.AddressBookEntryRepository, base base.Ba
 			MiddlewareVersion: nil,
 			Metadata:          nil,
 		},
+		config.CallMethods,
+		[]string{"0.0.98", "0.0.800"},
+		false,
+		config.NewSettings(),
 	)
 }
 
+func balanceExemption(account string) *rTypes.BalanceExemption {
+	return &rTypes.BalanceExemption{
+		SubAccountAddress: &account,
+		Currency:          config.CurrencyHbar,
+		ExemptionType:     rTypes.BalanceDynamic,
+	}
+}
+
 func TestNetworkServiceSuite(t *testing.T) {
 	suite.Run(t, new(networkServiceSuite))
 }
@@ -84,18 +109,30 @@ func TestNetworkServiceSuite(t *testing.T) {
 type networkServiceSuite struct {
 	suite.Suite
 	mockAddressBookEntryRepo *repository.MockAddressBookEntryRepository
+	mockAddressBookRepo      *repository.MockAddressBookRepository
 	mockBlockRepo            *repository.MockBlockRepository
+	mockNetworkVersionRepo   *repository.MockNetworkVersionRepository
 	mockTransactionRepo      *repository.MockTransactionRepository
 	networkService           server.NetworkAPIServicer
+	nodeHealth               *nodehealth.Tracker
 }
 
 func (suite *networkServiceSuite) BeforeTest(suiteName string, testName string) {
 	suite.mockAddressBookEntryRepo = &repository.MockAddressBookEntryRepository{}
+	suite.mockAddressBookRepo = &repository.MockAddressBookRepository{}
 	suite.mockBlockRepo = &repository.MockBlockRepository{}
+	suite.mockNetworkVersionRepo = &repository.MockNetworkVersionRepository{}
 	suite.mockTransactionRepo = &repository.MockTransactionRepository{}
+	suite.nodeHealth = nodehealth.NewTracker()
 
 	baseService := base.NewBaseService(suite.mockBlockRepo, suite.mockTransactionRepo)
-	suite.networkService = networkAPIService(suite.mockAddressBookEntryRepo, baseService)
+	suite.networkService = networkAPIService(
+		suite.mockAddressBookEntryRepo,
+		suite.mockAddressBookRepo,
+		suite.mockNetworkVersionRepo,
+		suite.nodeHealth,
+		baseService,
+	)
 }
 
 func (suite *networkServiceSuite) TestNetworkList() {
@@ -159,6 +196,29 @@ func (suite *networkServiceSuite) TestNetworkOptions() {
 		errors.ErrTokenNotFound,
 		errors.ErrInvalidTransaction,
 		errors.ErrInvalidCurrency,
+		errors.ErrInvalidSchedule,
+		errors.ErrScheduleNotFound,
+		errors.ErrCallMethodUnsupported,
+		errors.ErrTokenNotAssociated,
+		errors.ErrInvalidBatchSize,
+		errors.ErrExchangeRateNotFound,
+		errors.ErrServiceUnavailable,
+		errors.ErrInsufficientBalance,
+		errors.ErrNftNotFound,
+		errors.ErrAddressBookNotFound,
+		errors.ErrEntityIdChecksumMismatch,
+		errors.ErrTokenAssociationNotFound,
+		errors.ErrTransactionUnmodeledFields,
+		errors.ErrTokenRepeated,
+		errors.ErrUnauthorized,
+		errors.ErrFeeScheduleNotFound,
+		errors.ErrTransactionTypeUnsupported,
+		errors.ErrBlockHashAmbiguous,
+		errors.ErrAmountOverflow,
+		errors.ErrBlockAuditFailed,
+		errors.ErrTransactionBodiesMismatch,
+		errors.ErrWrongNetwork,
+		errors.ErrMultipleNodesUnsupported,
 		errors.ErrInternalServerError,
 	}
 
@@ -183,6 +243,24 @@ func (suite *networkServiceSuite) TestNetworkOptions() {
 			OperationTypes:          []string{"Transfer"},
 			Errors:                  expectedErrors,
 			HistoricalBalanceLookup: true,
+			BalanceExemptions:       []*rTypes.BalanceExemption{balanceExemption("0.0.98"), balanceExemption("0.0.800")},
+			CallMethods: []string{
+				"addressbook",
+				"block_timestamp",
+				"exchangerate",
+				"feeschedule",
+				"nfts",
+				"nodehealth",
+				"precheck",
+				"rawtransaction",
+				"reconcile",
+				"schedule_info",
+				"submissions",
+				"token_balances",
+				"tokeninfo",
+				"tokenrelationships",
+				"transaction",
+			},
 		},
 	}
 
@@ -190,6 +268,7 @@ func (suite *networkServiceSuite) TestNetworkOptions() {
 		On("Results").
 		Return(map[int]string{1: "Pending", 22: "Success"}, repository.NilError)
 	suite.mockTransactionRepo.On("TypesAsArray").Return([]string{"Transfer"}, repository.NilError)
+	suite.mockNetworkVersionRepo.On("Find").Return(&types.NetworkVersion{}, repository.NilError)
 
 	// when:
 	res, e := suite.networkService.NetworkOptions(nil, nil)
@@ -200,9 +279,122 @@ func (suite *networkServiceSuite) TestNetworkOptions() {
 	assert.ElementsMatch(suite.T(), expectedResult.Allow.OperationStatuses, res.Allow.OperationStatuses)
 	assert.ElementsMatch(suite.T(), expectedResult.Allow.OperationTypes, res.Allow.OperationTypes)
 	assert.ElementsMatch(suite.T(), expectedResult.Allow.Errors, res.Allow.Errors)
+	assert.ElementsMatch(suite.T(), expectedResult.Allow.CallMethods, res.Allow.CallMethods)
+	assert.ElementsMatch(suite.T(), expectedResult.Allow.BalanceExemptions, res.Allow.BalanceExemptions)
+	assert.Nil(suite.T(), e)
+}
+
+func (suite *networkServiceSuite) TestNetworkOptionsWithNodeAccountBalanceExemptions() {
+	// given:
+	baseService := base.NewBaseService(suite.mockBlockRepo, suite.mockTransactionRepo)
+	networkService := NewNetworkAPIService(
+		baseService,
+		suite.mockAddressBookEntryRepo,
+		suite.mockAddressBookRepo,
+		nil,
+		nil,
+		&rTypes.NetworkIdentifier{},
+		&rTypes.Version{},
+		nil,
+		[]string{"0.0.3", "0.0.98"},
+		true,
+		config.NewSettings(),
+	)
+	suite.mockTransactionRepo.
+		On("Results").
+		Return(map[int]string{1: "Pending", 22: "Success"}, repository.NilError)
+	suite.mockTransactionRepo.On("TypesAsArray").Return([]string{"Transfer"}, repository.NilError)
+	suite.mockAddressBookEntryRepo.On("Entries").Return(&types.AddressBookEntries{
+		Entries: []*types.AddressBookEntry{
+			{PeerId: types.Account{EntityId: entityid.EntityId{EntityNum: 3, EncodedId: 3}}},
+			{PeerId: types.Account{EntityId: entityid.EntityId{EntityNum: 4, EncodedId: 4}}},
+		},
+	}, repository.NilError)
+
+	// when:
+	res, e := networkService.NetworkOptions(nil, nil)
+
+	// then:
+	assert.Nil(suite.T(), e)
+	assert.Equal(suite.T(), []*rTypes.BalanceExemption{
+		balanceExemption("0.0.3"),
+		balanceExemption("0.0.98"),
+		balanceExemption("0.0.4"),
+	}, res.Allow.BalanceExemptions)
+}
+
+func (suite *networkServiceSuite) TestNetworkOptionsThrowsWhenEntriesFail() {
+	// given:
+	baseService := base.NewBaseService(suite.mockBlockRepo, suite.mockTransactionRepo)
+	networkService := NewNetworkAPIService(
+		baseService,
+		suite.mockAddressBookEntryRepo,
+		suite.mockAddressBookRepo,
+		nil,
+		nil,
+		&rTypes.NetworkIdentifier{},
+		&rTypes.Version{},
+		nil,
+		nil,
+		true,
+		config.NewSettings(),
+	)
+	suite.mockTransactionRepo.
+		On("Results").
+		Return(map[int]string{1: "Pending", 22: "Success"}, repository.NilError)
+	suite.mockTransactionRepo.On("TypesAsArray").Return([]string{"Transfer"}, repository.NilError)
+	suite.mockAddressBookEntryRepo.On("Entries").Return(repository.NilEntries, errors.ErrDatabaseError)
+
+	// when:
+	res, e := networkService.NetworkOptions(nil, nil)
+
+	// then:
+	assert.Nil(suite.T(), res)
+	assert.Equal(suite.T(), errors.ErrDatabaseError, e)
+}
+
+func (suite *networkServiceSuite) TestNetworkOptionsWithNetworkVersion() {
+	// given:
+	expectedVersion := &rTypes.Version{
+		RosettaVersion: "1",
+		NodeVersion:    "0.16.0",
+		Metadata: map[string]interface{}{
+			"hapi_version":          "0.16.0",
+			"mirror_schema_version": "1.41.0",
+		},
+	}
+	suite.mockTransactionRepo.
+		On("Results").
+		Return(map[int]string{1: "Pending", 22: "Success"}, repository.NilError)
+	suite.mockTransactionRepo.On("TypesAsArray").Return([]string{"Transfer"}, repository.NilError)
+	suite.mockNetworkVersionRepo.On("Find").Return(
+		&types.NetworkVersion{HapiVersion: "0.16.0", MirrorSchemaVersion: "1.41.0"},
+		repository.NilError,
+	)
+
+	// when:
+	res, e := suite.networkService.NetworkOptions(nil, nil)
+
+	// then:
+	assert.Equal(suite.T(), expectedVersion, res.Version)
 	assert.Nil(suite.T(), e)
 }
 
+func (suite *networkServiceSuite) TestNetworkOptionsThrowsWhenNetworkVersionFails() {
+	suite.mockTransactionRepo.
+		On("Results").
+		Return(map[int]string{1: "Pending", 22: "Success"}, repository.NilError)
+	suite.mockTransactionRepo.On("TypesAsArray").Return([]string{"Transfer"}, repository.NilError)
+	suite.mockNetworkVersionRepo.On("Find").Return(repository.NilNetworkVersion, errors.ErrDatabaseError)
+
+	// when:
+	res, e := suite.networkService.NetworkOptions(nil, nil)
+
+	// then:
+	assert.Nil(suite.T(), res)
+	assert.Equal(suite.T(), errors.ErrDatabaseError, e)
+}
+
 func (suite *networkServiceSuite) TestNetworkOptionsThrowsWhenStatusesFails() {
 	var nilStatuses map[int]string = nil
 	suite.mockTransactionRepo.On("TypesAsArray").Return([]string{"Transfer"}, repository.NilError)
@@ -229,7 +421,22 @@ func (suite *networkServiceSuite) TestNetworkOptionsThrowsWhenTypesAsArrayFails(
 
 func (suite *networkServiceSuite) TestNetworkStatus() {
 	// given:
-	exampleEntries := &types.AddressBookEntries{Entries: []*types.AddressBookEntry{}}
+	nodeAccountId3 := types.Account{EntityId: entityid.EntityId{EntityNum: 3, EncodedId: 3}}
+	nodeAccountId4 := types.Account{EntityId: entityid.EntityId{EntityNum: 4, EncodedId: 4}}
+	addressBook := &types.AddressBook{
+		Nodes: []*types.AddressBookNode{
+			{
+				Description:      "node 0",
+				NodeAccountId:    nodeAccountId3,
+				NodeId:           0,
+				PublicKey:        "308201a2",
+				ServiceEndpoints: []types.ServiceEndpoint{{IpAddressV4: "10.0.0.1", Port: 50211}},
+				Stake:            100,
+			},
+			{NodeAccountId: nodeAccountId4, NodeId: 1},
+		},
+	}
+	suite.nodeHealth.Record(hedera.AccountID{Account: 3}, true, 0)
 
 	expectedResult := &rTypes.NetworkStatusResponse{
 		CurrentBlockIdentifier: &rTypes.BlockIdentifier{
@@ -241,12 +448,41 @@ func (suite *networkServiceSuite) TestNetworkStatus() {
 			Index: 1,
 			Hash:  "0x123jsjs",
 		},
-		Peers: []*rTypes.Peer{},
+		Peers: []*rTypes.Peer{
+			{
+				PeerID: "0.0.3",
+				Metadata: map[string]interface{}{
+					"description": "node 0",
+					"health": map[string]interface{}{
+						"attempts":           uint64(1),
+						"average_latency_ms": int64(0),
+						"failures":           uint64(0),
+						"node_account_id":    "0.0.3",
+						"score":              1.0,
+						"success_rate":       1.0,
+					},
+					"node_id": int64(0),
+					"service_endpoints": []map[string]interface{}{
+						{"ip_address_v4": "10.0.0.1", "port": int32(50211)},
+					},
+					"stake": int64(100),
+				},
+			},
+			{
+				PeerID: "0.0.4",
+				Metadata: map[string]interface{}{
+					"description":       "",
+					"node_id":           int64(1),
+					"service_endpoints": []map[string]interface{}{},
+					"stake":             int64(0),
+				},
+			},
+		},
 	}
 
 	suite.mockBlockRepo.On("RetrieveGenesis").Return(dummyGenesisBlock(), repository.NilError)
 	suite.mockBlockRepo.On("RetrieveLatest").Return(dummyLatestBlock(), repository.NilError)
-	suite.mockAddressBookEntryRepo.On("Entries").Return(exampleEntries, repository.NilError)
+	suite.mockAddressBookRepo.On("FindLatest").Return(addressBook, repository.NilError)
 
 	// when:
 	res, e := suite.networkService.NetworkStatus(nil, nil)
@@ -256,6 +492,20 @@ func (suite *networkServiceSuite) TestNetworkStatus() {
 	assert.Nil(suite.T(), e)
 }
 
+func (suite *networkServiceSuite) TestNetworkStatusWithoutAddressBook() {
+	// given:
+	suite.mockBlockRepo.On("RetrieveGenesis").Return(dummyGenesisBlock(), repository.NilError)
+	suite.mockBlockRepo.On("RetrieveLatest").Return(dummyLatestBlock(), repository.NilError)
+	suite.mockAddressBookRepo.On("FindLatest").Return(repository.NilAddressBook, errors.ErrAddressBookNotFound)
+
+	// when:
+	res, e := suite.networkService.NetworkStatus(nil, nil)
+
+	// then:
+	assert.Nil(suite.T(), e)
+	assert.Equal(suite.T(), []*rTypes.Peer{}, res.Peers)
+}
+
 func (suite *networkServiceSuite) TestNetworkStatusThrowsWhenRetrieveGenesisFails() {
 	// given:
 	suite.mockBlockRepo.On("RetrieveGenesis").Return(repository.NilBlock, &rTypes.Error{})
@@ -281,11 +531,11 @@ func (suite *networkServiceSuite) TestNetworkStatusThrowsWhenRetrieveLatestFails
 	assert.NotNil(suite.T(), e)
 }
 
-func (suite *networkServiceSuite) TestNetworkStatusThrowsWhenEntriesFail() {
+func (suite *networkServiceSuite) TestNetworkStatusThrowsWhenAddressBookFails() {
 	// given:
 	suite.mockBlockRepo.On("RetrieveGenesis").Return(dummyGenesisBlock(), repository.NilError)
 	suite.mockBlockRepo.On("RetrieveLatest").Return(dummyLatestBlock(), repository.NilError)
-	suite.mockAddressBookEntryRepo.On("Entries").Return(repository.NilEntries, &rTypes.Error{})
+	suite.mockAddressBookRepo.On("FindLatest").Return(repository.NilAddressBook, errors.ErrDatabaseError)
 
 	// when:
 	res, e := suite.networkService.NetworkStatus(nil, nil)
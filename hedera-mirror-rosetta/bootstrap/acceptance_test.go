@@ -0,0 +1,366 @@
+//go:build acceptance
+// +build acceptance
+
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/asserter"
+	"github.com/coinbase/rosetta-sdk-go/fetcher"
+	"github.com/coinbase/rosetta-sdk-go/parser"
+	"github.com/coinbase/rosetta-sdk-go/syncer"
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/db"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/domain"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/types"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+const (
+	acceptancePayer     = 1001
+	acceptanceRecipient = 1002
+)
+
+var acceptanceNetwork = &rTypes.NetworkIdentifier{
+	Blockchain:           config.Blockchain,
+	Network:              "testnet",
+	SubNetworkIdentifier: &rTypes.SubNetworkIdentifier{Network: "shard 0 realm 0"},
+}
+
+// run the suite
+func TestAcceptanceSuite(t *testing.T) {
+	suite.Run(t, new(acceptanceSuite))
+}
+
+// acceptanceSuite serves the online router against a postgres container seeded with record data and drives the
+// rosetta-sdk-go fetcher, syncer and parser packages, which rosetta-cli's check:data and check:construction are
+// built on, against it in process. It doesn't run rosetta-cli or its configuration in scripts/validation. The suite
+// needs Docker so it's only built with the acceptance tag
+type acceptanceSuite struct {
+	suite.Suite
+	dbResource db.DbResource
+	fetcher    *fetcher.Fetcher
+	server     *httptest.Server
+}
+
+func (suite *acceptanceSuite) SetupSuite() {
+	suite.dbResource = db.SetupDb()
+	settings := config.NewSettings()
+	settings.ConfigureLedgerId(acceptanceNetwork.Network)
+
+	dbClient := suite.dbResource.GetGormDb()
+	seedAcceptanceFixture(dbClient)
+
+	serverAsserter, err := asserter.NewServer(
+		[]string{config.OperationTypeCryptoTransfer},
+		true,
+		[]*rTypes.NetworkIdentifier{acceptanceNetwork},
+		config.CallMethods,
+		false,
+	)
+	if err != nil {
+		suite.FailNow("Failed to create the server asserter", err.Error())
+	}
+
+	version := "acceptance"
+	repos := Repositories{}
+	repos.setDefaults(dbClient, types.Account{}, types.Block{}, false, false)
+	router, err := newBlockchainOnlineRouter(onlineRouterOptions{
+		asserter:        serverAsserter,
+		callMethodNames: config.CallMethods,
+		network:         acceptanceNetwork,
+		nodes:           types.NodeMap{"127.0.0.1:50211": hedera.AccountID{Account: 3}},
+		repos:           repos,
+		settings:        settings,
+		version:         &rTypes.Version{RosettaVersion: "1.4.10", NodeVersion: "0.19.0", MiddlewareVersion: &version},
+	})
+	if err != nil {
+		suite.FailNow("Failed to create the online router", err.Error())
+	}
+	suite.server = httptest.NewServer(router)
+
+	suite.fetcher = fetcher.New(suite.server.URL, fetcher.WithRetryElapsedTime(10*time.Second))
+	if _, _, fetchErr := suite.fetcher.InitializeAsserter(context.Background(), acceptanceNetwork); fetchErr != nil {
+		suite.FailNow("Failed to initialize the fetcher asserter", fetchErr.Err.Error())
+	}
+}
+
+func (suite *acceptanceSuite) TearDownSuite() {
+	suite.server.Close()
+	db.TeardownDb(suite.dbResource)
+}
+
+func (suite *acceptanceSuite) TestCheckData() {
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	status, fetchErr := suite.fetcher.NetworkStatusRetry(ctx, acceptanceNetwork, nil)
+	assert.Nil(suite.T(), fetchErr)
+	reconciler := &balanceReconciler{
+		balances: make(map[string]*big.Int),
+		fetcher:  suite.fetcher,
+		parser:   parser.New(suite.fetcher.Asserter, nil, nil),
+	}
+	blockSyncer := syncer.New(acceptanceNetwork, &fetcherHelper{suite.fetcher}, reconciler, cancel)
+
+	// when
+	err := blockSyncer.Sync(ctx, status.GenesisBlockIdentifier.Index, status.CurrentBlockIdentifier.Index)
+
+	// then
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), int64(3), reconciler.blocks)
+	assert.Empty(suite.T(), reconciler.mismatches)
+	assert.Len(suite.T(), reconciler.balances, 4)
+}
+
+func (suite *acceptanceSuite) TestCheckConstruction() {
+	// given
+	ctx := context.Background()
+	privateKey, err := hedera.GeneratePrivateKey()
+	assert.Nil(suite.T(), err)
+	publicKey := &rTypes.PublicKey{Bytes: privateKey.PublicKey().Bytes(), CurveType: rTypes.Edwards25519}
+	payer := hedera.AccountID{Account: acceptancePayer}
+	intent := []*rTypes.Operation{
+		newAcceptanceOperation(0, acceptancePayer, -10),
+		newAcceptanceOperation(1, acceptanceRecipient, 10),
+	}
+	intentParser := parser.New(suite.fetcher.Asserter, nil, nil)
+
+	// when
+	options, requiredPublicKeys, fetchErr := suite.fetcher.ConstructionPreprocess(ctx, acceptanceNetwork, intent, nil)
+	assert.Nil(suite.T(), fetchErr)
+	metadata, _, fetchErr := suite.fetcher.ConstructionMetadata(
+		ctx,
+		acceptanceNetwork,
+		options,
+		[]*rTypes.PublicKey{publicKey},
+	)
+	assert.Nil(suite.T(), fetchErr)
+	unsignedTransaction, payloads, fetchErr := suite.fetcher.ConstructionPayloads(
+		ctx,
+		acceptanceNetwork,
+		intent,
+		metadata,
+		[]*rTypes.PublicKey{publicKey},
+	)
+	assert.Nil(suite.T(), fetchErr)
+	unsignedOperations, _, _, fetchErr := suite.fetcher.ConstructionParse(
+		ctx,
+		acceptanceNetwork,
+		false,
+		unsignedTransaction,
+	)
+	assert.Nil(suite.T(), fetchErr)
+
+	signatures := make([]*rTypes.Signature, 0, len(payloads))
+	for _, payload := range payloads {
+		signatures = append(signatures, &rTypes.Signature{
+			SigningPayload: payload,
+			PublicKey:      publicKey,
+			SignatureType:  rTypes.Ed25519,
+			Bytes:          privateKey.Sign(payload.Bytes),
+		})
+	}
+	signedTransaction, fetchErr := suite.fetcher.ConstructionCombine(
+		ctx,
+		acceptanceNetwork,
+		unsignedTransaction,
+		signatures,
+	)
+	assert.Nil(suite.T(), fetchErr)
+	signedOperations, signers, _, fetchErr := suite.fetcher.ConstructionParse(
+		ctx,
+		acceptanceNetwork,
+		true,
+		signedTransaction,
+	)
+	assert.Nil(suite.T(), fetchErr)
+	transactionIdentifier, fetchErr := suite.fetcher.ConstructionHash(ctx, acceptanceNetwork, signedTransaction)
+
+	// then
+	assert.Nil(suite.T(), fetchErr)
+	assert.Equal(suite.T(), []*rTypes.AccountIdentifier{{Address: payer.String()}}, requiredPublicKeys)
+	assert.Len(suite.T(), payloads, 1)
+	assert.Nil(suite.T(), intentParser.ExpectedOperations(intent, unsignedOperations, false, false))
+	assert.Nil(suite.T(), intentParser.ExpectedOperations(intent, signedOperations, false, false))
+	assert.Equal(suite.T(), []*rTypes.AccountIdentifier{{Address: payer.String()}}, signers)
+	assert.NotEmpty(suite.T(), transactionIdentifier.Hash)
+}
+
+// balanceReconciler is the syncer handler reconciling the balance changes computed from the operations of each block
+// against the balances served by /account/balance at the same block
+type balanceReconciler struct {
+	balances   map[string]*big.Int
+	blocks     int64
+	fetcher    *fetcher.Fetcher
+	mismatches []string
+	parser     *parser.Parser
+}
+
+func (r *balanceReconciler) BlockSeen(context.Context, *rTypes.Block) error {
+	return nil
+}
+
+func (r *balanceReconciler) BlockAdded(ctx context.Context, block *rTypes.Block) error {
+	r.blocks++
+
+	changes, err := r.parser.BalanceChanges(ctx, block, false)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		address := change.Account.Address
+		balance, ok := r.balances[address]
+		if !ok {
+			if balance, err = r.getBalance(ctx, change.Account, block.ParentBlockIdentifier.Index); err != nil {
+				return err
+			}
+		}
+
+		difference, ok := new(big.Int).SetString(change.Difference, 10)
+		if !ok {
+			return fmt.Errorf("invalid balance difference %s of account %s", change.Difference, address)
+		}
+		r.balances[address] = balance.Add(balance, difference)
+
+		live, err := r.getBalance(ctx, change.Account, block.BlockIdentifier.Index)
+		if err != nil {
+			return err
+		}
+
+		if live.Cmp(r.balances[address]) != 0 {
+			r.mismatches = append(r.mismatches, fmt.Sprintf("account %s at block %d: computed %s, live %s", address,
+				block.BlockIdentifier.Index, r.balances[address], live))
+		}
+	}
+
+	return nil
+}
+
+func (r *balanceReconciler) BlockRemoved(_ context.Context, block *rTypes.BlockIdentifier) error {
+	return fmt.Errorf("unexpected reorg at block %d", block.Index)
+}
+
+func (r *balanceReconciler) getBalance(
+	ctx context.Context,
+	account *rTypes.AccountIdentifier,
+	index int64,
+) (*big.Int, error) {
+	_, amounts, _, fetchErr := r.fetcher.AccountBalanceRetry(
+		ctx,
+		acceptanceNetwork,
+		account,
+		&rTypes.PartialBlockIdentifier{Index: &index},
+		[]*rTypes.Currency{config.CurrencyHbar},
+	)
+	if fetchErr != nil {
+		return nil, fetchErr.Err
+	}
+
+	for _, amount := range amounts {
+		if rTypes.Hash(amount.Currency) == rTypes.Hash(config.CurrencyHbar) {
+			balance, _ := new(big.Int).SetString(amount.Value, 10)
+			return balance, nil
+		}
+	}
+
+	return big.NewInt(0), nil
+}
+
+// fetcherHelper adapts the fetcher to the syncer helper interface
+type fetcherHelper struct {
+	*fetcher.Fetcher
+}
+
+func (h *fetcherHelper) NetworkStatus(
+	ctx context.Context,
+	network *rTypes.NetworkIdentifier,
+) (*rTypes.NetworkStatusResponse, error) {
+	status, fetchErr := h.NetworkStatusRetry(ctx, network, nil)
+	if fetchErr != nil {
+		return nil, fetchErr.Err
+	}
+	return status, nil
+}
+
+func (h *fetcherHelper) Block(
+	ctx context.Context,
+	network *rTypes.NetworkIdentifier,
+	block *rTypes.PartialBlockIdentifier,
+) (*rTypes.Block, error) {
+	result, fetchErr := h.BlockRetry(ctx, network, block)
+	if fetchErr != nil {
+		return nil, fetchErr.Err
+	}
+	return result, nil
+}
+
+func newAcceptanceOperation(index int64, account int64, amount int64) *rTypes.Operation {
+	return &rTypes.Operation{
+		OperationIdentifier: &rTypes.OperationIdentifier{Index: index},
+		Type:                config.OperationTypeCryptoTransfer,
+		Account:             &rTypes.AccountIdentifier{Address: hedera.AccountID{Account: uint64(account)}.String()},
+		Amount:              &rTypes.Amount{Value: fmt.Sprintf("%d", amount), Currency: config.CurrencyHbar},
+	}
+}
+
+// seedAcceptanceFixture seeds a balance snapshot at 100, a record file before the snapshot, and the genesis block
+// followed by two blocks with one crypto transfer each
+func seedAcceptanceFixture(dbClient *gorm.DB) {
+	domain.NewAccountBalanceSnapshotBuilder(dbClient, 100).
+		AccountBalance(3, 1000).
+		AccountBalance(98, 1000).
+		AccountBalance(acceptancePayer, 100000).
+		AccountBalance(acceptanceRecipient, 0).
+		Persist()
+
+	prevHash := ""
+	for index, consensusRange := range [][2]int64{{1, 99}, {101, 200}, {201, 300}, {301, 400}} {
+		recordFile := domain.NewRecordFileBuilder(dbClient, int64(index), consensusRange[0], consensusRange[1]).
+			PrevHash(prevHash).
+			Persist()
+		prevHash = recordFile.Hash
+	}
+
+	for _, consensusTimestamp := range []int64{250, 350} {
+		domain.NewTransactionBuilder(dbClient, acceptancePayer, consensusTimestamp).
+			EntityId(acceptanceRecipient).
+			CryptoTransfer(acceptancePayer, -117).
+			CryptoTransfer(acceptanceRecipient, 100).
+			CryptoTransfer(3, 7).
+			CryptoTransfer(98, 10).
+			Persist()
+	}
+}
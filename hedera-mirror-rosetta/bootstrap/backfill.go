@@ -0,0 +1,284 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package bootstrap
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/encoder"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// BackfillCommand is the name of the command which walks the historical blocks with resumable checkpoints
+const BackfillCommand = "backfill"
+
+// backfillOptions are the command line options of the backfill command
+type backfillOptions struct {
+	checkpoint string
+	interval   int64
+	output     string
+	to         int64
+}
+
+// backfillCheckpoint is the progress of the backfill persisted in the checkpoint file. NextIndex is the index of the
+// next block to assemble, and OutputOffset is the size of the output once the blocks before it are written
+type backfillCheckpoint struct {
+	NextIndex    int64 `json:"next_index"`
+	OutputOffset int64 `json:"output_offset"`
+}
+
+// parseBackfillArgs parses the command line arguments of the backfill command
+func parseBackfillArgs(args []string) (*backfillOptions, error) {
+	options := &backfillOptions{}
+	flagSet := flag.NewFlagSet(BackfillCommand, flag.ContinueOnError)
+	flagSet.StringVar(&options.checkpoint, "checkpoint", "backfill.checkpoint", "file to persist the progress to")
+	flagSet.Int64Var(&options.interval, "interval", 1000, "number of blocks between checkpoints")
+	flagSet.StringVar(&options.output, "output", "", "file to append the blocks to, the blocks aren't written if not set")
+	flagSet.Int64Var(&options.to, "to", -1, "index of the last block, the latest block at start if not set")
+
+	if err := flagSet.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if flagSet.NArg() != 0 {
+		return nil, fmt.Errorf("unexpected arguments %v", flagSet.Args())
+	}
+
+	if options.checkpoint == "" || options.interval <= 0 || options.to < -1 {
+		return nil, errors.New("--checkpoint must be set, --interval must be positive, and --to must not be negative")
+	}
+
+	return options, nil
+}
+
+// readCheckpoint returns the checkpoint persisted in the file, or the checkpoint of the genesis block if the file
+// doesn't exist
+func readCheckpoint(path string) (*backfillCheckpoint, error) {
+	// Disable gosec since the checkpoint file is given by the operator
+	data, err := ioutil.ReadFile(path) // #nosec
+	if os.IsNotExist(err) {
+		return &backfillCheckpoint{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read the checkpoint file %s: %w", path, err)
+	}
+
+	checkpoint := &backfillCheckpoint{}
+	if err = json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint file %s: %w", path, err)
+	}
+
+	if checkpoint.NextIndex < 0 || checkpoint.OutputOffset < 0 {
+		return nil, fmt.Errorf("invalid checkpoint file %s: negative index or offset", path)
+	}
+
+	return checkpoint, nil
+}
+
+// writeCheckpoint persists the checkpoint to a temporary file and renames it to path, so an interruption never leaves
+// a partially written checkpoint behind
+func writeCheckpoint(path string, checkpoint *backfillCheckpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	tmpPath := path + ".tmp"
+	if err = ioutil.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write the checkpoint file %s: %w", tmpPath, err)
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// openBackfillOutput opens the output file at the offset of the checkpoint. The blocks written after the checkpoint
+// by an interrupted run are truncated, so they aren't duplicated when the backfill resumes
+func openBackfillOutput(path string, offset int64) (*os.File, error) {
+	// Disable gosec since the output file is given by the operator
+	output, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0600) // #nosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the output file %s: %w", path, err)
+	}
+
+	if err = output.Truncate(offset); err == nil {
+		_, err = output.Seek(offset, io.SeekStart)
+	}
+	if err != nil {
+		_ = output.Close()
+		return nil, fmt.Errorf("failed to resume the output file %s at %d: %w", path, offset, err)
+	}
+
+	return output, nil
+}
+
+// backfillBlocks assembles the blocks from the checkpoint's next index to the index to with the block service, and
+// appends them as newline-delimited JSON to output if it isn't nil. The progress is persisted to checkpointPath every
+// interval blocks and when the backfill stops, either finished, failed, or cancelled by ctx
+func backfillBlocks(
+	ctx context.Context,
+	blockAPIService server.BlockAPIServicer,
+	network *rTypes.NetworkIdentifier,
+	checkpointPath string,
+	checkpoint *backfillCheckpoint,
+	to int64,
+	interval int64,
+	output *os.File,
+) (err error) {
+	var writer *bufio.Writer
+	var blockEncoder encoder.JSONEncoder
+	if output != nil {
+		writer = bufio.NewWriter(output)
+		blockEncoder = encoder.NewCanonicalJSONEncoder(encoder.NewStdJSONEncoder())
+	}
+
+	saveCheckpoint := func() error {
+		if output != nil {
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+
+			if err := output.Sync(); err != nil {
+				return err
+			}
+
+			offset, err := output.Seek(0, io.SeekCurrent)
+			if err != nil {
+				return err
+			}
+			checkpoint.OutputOffset = offset
+		}
+
+		return writeCheckpoint(checkpointPath, checkpoint)
+	}
+	defer func() {
+		if saveErr := saveCheckpoint(); err == nil {
+			err = saveErr
+		}
+	}()
+
+	for checkpoint.NextIndex <= to {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		index := checkpoint.NextIndex
+		response, rErr := blockAPIService.Block(ctx, &rTypes.BlockRequest{
+			NetworkIdentifier: network,
+			BlockIdentifier:   &rTypes.PartialBlockIdentifier{Index: &index},
+		})
+		if rErr != nil {
+			return fmt.Errorf("failed to assemble block %d: %s", index, rErr.Message)
+		}
+
+		if blockEncoder != nil {
+			if err = blockEncoder.Encode(writer, response.Block); err != nil {
+				return err
+			}
+		}
+
+		checkpoint.NextIndex++
+		if checkpoint.NextIndex%interval == 0 {
+			if err = saveCheckpoint(); err != nil {
+				return err
+			}
+			log.Infof("Backfilled blocks up to %d", index)
+		}
+	}
+
+	return nil
+}
+
+// Backfill loads the configuration and walks the historical blocks from the checkpoint to the last block given by
+// args, assembling each with the same block service as the /block endpoint, e.g., to validate the whole history with
+// the audit mode after a schema or mapping change. args are the command line arguments following the backfill command,
+// e.g., "--checkpoint backfill.checkpoint --output blocks.json". An interrupted backfill resumes from the checkpoint
+// when run again with the same arguments. It exits the process if the backfill fails
+func (s *Server) Backfill(args []string) {
+	options, err := parseBackfillArgs(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(2)
+	}
+
+	configLogger("info", os.Stderr)
+	rosettaConfig, err := LoadConfig()
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+	network, settings, err := applyConfig(rosettaConfig, os.Stderr)
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+
+	blockAPIService, baseService, err := newBatchBlockService(rosettaConfig, settings)
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+
+	to := options.to
+	if to == -1 {
+		latest, rErr := baseService.RetrieveLatest()
+		if rErr != nil {
+			log.Fatalf("Failed to get the latest block: %s", rErr.Message)
+		}
+		to = latest.Index
+	}
+
+	checkpoint, err := readCheckpoint(options.checkpoint)
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+
+	var output *os.File
+	if options.output != "" {
+		if output, err = openBackfillOutput(options.output, checkpoint.OutputOffset); err != nil {
+			log.Fatalf("%s", err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	from := checkpoint.NextIndex
+	log.Infof("Backfilling blocks %d to %d", from, to)
+	err = backfillBlocks(ctx, blockAPIService, network, options.checkpoint, checkpoint, to, options.interval, output)
+	if output != nil {
+		if closeErr := output.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		log.Fatalf("Failed to backfill blocks, the next run resumes from block %d: %s", checkpoint.NextIndex, err)
+	}
+
+	log.Infof("Backfilled blocks %d to %d", from, to)
+}
@@ -0,0 +1,222 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBackfillArgs(t *testing.T) {
+	// when
+	options, err := parseBackfillArgs([]string{"--checkpoint", "cp", "--interval", "10", "--output", "blocks.json",
+		"--to", "20"})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, &backfillOptions{checkpoint: "cp", interval: 10, output: "blocks.json", to: 20}, options)
+}
+
+func TestParseBackfillArgsDefault(t *testing.T) {
+	// when
+	options, err := parseBackfillArgs([]string{})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, &backfillOptions{checkpoint: "backfill.checkpoint", interval: 1000, to: -1}, options)
+}
+
+func TestParseBackfillArgsInvalid(t *testing.T) {
+	tests := [][]string{
+		{"--checkpoint", ""},
+		{"--interval", "0"},
+		{"--to", "-2"},
+		{"--to", "a"},
+		{"extra"},
+		{"--unknown", "1"},
+	}
+
+	for _, args := range tests {
+		t.Run(fmt.Sprintf("%v", args), func(t *testing.T) {
+			// when
+			options, err := parseBackfillArgs(args)
+
+			// then
+			assert.Error(t, err)
+			assert.Nil(t, options)
+		})
+	}
+}
+
+func TestReadCheckpoint(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "cp")
+	require.NoError(t, writeCheckpoint(path, &backfillCheckpoint{NextIndex: 10, OutputOffset: 100}))
+
+	// when
+	checkpoint, err := readCheckpoint(path)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, &backfillCheckpoint{NextIndex: 10, OutputOffset: 100}, checkpoint)
+}
+
+func TestReadCheckpointNotExist(t *testing.T) {
+	// when
+	checkpoint, err := readCheckpoint(filepath.Join(t.TempDir(), "cp"))
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, &backfillCheckpoint{}, checkpoint)
+}
+
+func TestReadCheckpointInvalid(t *testing.T) {
+	for _, data := range []string{"{", `{"next_index":-1}`} {
+		t.Run(data, func(t *testing.T) {
+			// given
+			path := filepath.Join(t.TempDir(), "cp")
+			require.NoError(t, ioutil.WriteFile(path, []byte(data), 0600))
+
+			// when
+			checkpoint, err := readCheckpoint(path)
+
+			// then
+			assert.Error(t, err)
+			assert.Nil(t, checkpoint)
+		})
+	}
+}
+
+func TestBackfillBlocks(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	checkpointPath := filepath.Join(dir, "cp")
+	output, err := openBackfillOutput(filepath.Join(dir, "blocks.json"), 0)
+	require.NoError(t, err)
+	defer output.Close()
+	checkpoint := &backfillCheckpoint{}
+
+	// when
+	err = backfillBlocks(context.Background(), &stubBlockAPIService{latest: 9}, nil, checkpointPath, checkpoint, 9, 3,
+		output)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, readBackfillOutput(t, output.Name()))
+	assertCheckpoint(t, checkpointPath, 10, output.Name())
+}
+
+func TestBackfillBlocksResume(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	checkpointPath := filepath.Join(dir, "cp")
+	outputPath := filepath.Join(dir, "blocks.json")
+	output, err := openBackfillOutput(outputPath, 0)
+	require.NoError(t, err)
+	err = backfillBlocks(context.Background(), &stubBlockAPIService{latest: 9}, nil, checkpointPath,
+		&backfillCheckpoint{}, 3, 2, output)
+	require.NoError(t, err)
+	// the blocks written by an interrupted run after the checkpoint
+	_, err = output.WriteString(`{"block_identifier":{"index":4`)
+	require.NoError(t, err)
+	require.NoError(t, output.Close())
+
+	checkpoint, err := readCheckpoint(checkpointPath)
+	require.NoError(t, err)
+	output, err = openBackfillOutput(outputPath, checkpoint.OutputOffset)
+	require.NoError(t, err)
+	defer output.Close()
+
+	// when
+	err = backfillBlocks(context.Background(), &stubBlockAPIService{latest: 9}, nil, checkpointPath, checkpoint, 9, 2,
+		output)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, readBackfillOutput(t, outputPath))
+	assertCheckpoint(t, checkpointPath, 10, outputPath)
+}
+
+func TestBackfillBlocksNotFound(t *testing.T) {
+	// given
+	checkpointPath := filepath.Join(t.TempDir(), "cp")
+	checkpoint := &backfillCheckpoint{}
+
+	// when
+	err := backfillBlocks(context.Background(), &stubBlockAPIService{latest: 4}, nil, checkpointPath, checkpoint, 9, 3,
+		nil)
+
+	// then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "block 5")
+	actual, err := readCheckpoint(checkpointPath)
+	assert.NoError(t, err)
+	assert.Equal(t, &backfillCheckpoint{NextIndex: 5}, actual)
+}
+
+func TestBackfillBlocksCancelled(t *testing.T) {
+	// given
+	checkpointPath := filepath.Join(t.TempDir(), "cp")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when
+	err := backfillBlocks(ctx, &stubBlockAPIService{latest: 9}, nil, checkpointPath, &backfillCheckpoint{NextIndex: 2},
+		9, 3, nil)
+
+	// then
+	assert.Equal(t, context.Canceled, err)
+	actual, err := readCheckpoint(checkpointPath)
+	assert.NoError(t, err)
+	assert.Equal(t, &backfillCheckpoint{NextIndex: 2}, actual)
+}
+
+// readBackfillOutput returns the indexes of the blocks in the output file
+func readBackfillOutput(t *testing.T, path string) []int64 {
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+
+	indexes := make([]int64, 0)
+	for _, line := range strings.Split(strings.TrimSuffix(string(data), "\n"), "\n") {
+		var index int64
+		_, err = fmt.Sscanf(line, `{"block_identifier":{"index":%d,`, &index)
+		require.NoError(t, err, line)
+		indexes = append(indexes, index)
+	}
+	return indexes
+}
+
+func assertCheckpoint(t *testing.T, checkpointPath string, nextIndex int64, outputPath string) {
+	info, err := os.Stat(outputPath)
+	require.NoError(t, err)
+
+	actual, err := readCheckpoint(checkpointPath)
+	assert.NoError(t, err)
+	assert.Equal(t, &backfillCheckpoint{NextIndex: nextIndex, OutputOffset: info.Size()}, actual)
+}
@@ -0,0 +1,841 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+// Package bootstrap wires the rosetta server. An embedding main package can register extra HTTP middlewares and /call
+// methods, e.g., to authenticate or audit the requests, before running the server:
+//
+//	bootstrap.NewServer(buildVersion).
+//		Use(auditMiddleware).
+//		AddCallMethod("audit_log", auditLog).
+//		Run()
+//
+// Another Go service can embed the server with its own configuration and options instead, e.g., to serve it with
+// custom repositories on an in-memory listener in tests:
+//
+//	rosettaConfig, err := bootstrap.LoadConfig()
+//	...
+//	err = bootstrap.New(rosettaConfig, bootstrap.WithRepositories(repos), bootstrap.WithListener(listener)).Serve()
+package bootstrap
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/asserter"
+	"github.com/coinbase/rosetta-sdk-go/server"
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/breaker"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/canary"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/mapper"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/encoder"
+	hErrors "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/grpcapi"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/journal"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/metrics"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/middleware"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/nodehealth"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/account"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/block"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/notification"
+	accountService "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/account"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/base"
+	blockService "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/block"
+	callService "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/call"
+	constructionService "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/construction"
+	eventsService "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/events"
+	mempoolService "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/mempool"
+	networkService "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/network"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/types"
+	log "github.com/sirupsen/logrus"
+	prefixed "github.com/x-cray/logrus-prefixed-formatter"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+)
+
+const (
+	accountBalancePath = "/account/balance"
+	blockPath          = "/block"
+	metricsPath        = "/metrics"
+)
+
+// Middleware wraps the handler of the rosetta endpoints
+type Middleware func(http.Handler) http.Handler
+
+// Server bootstraps the rosetta server with the registered extensions
+type Server struct {
+	buildVersion string
+	callMethods  map[string]callService.CallMethod
+	config       *types.Rosetta
+	listener     net.Listener
+	logOutput    io.Writer
+	middlewares  []Middleware
+	repositories Repositories
+}
+
+// NewServer creates the bootstrap of the rosetta server. The buildVersion takes precedence over the configured version
+// when set
+func NewServer(buildVersion string) *Server {
+	return &Server{
+		buildVersion: buildVersion,
+		callMethods:  make(map[string]callService.CallMethod),
+		logOutput:    os.Stdout,
+	}
+}
+
+// New creates the rosetta server embedded in another Go service. Unlike NewServer, the configuration isn't loaded
+// from the config files and the env variables, see LoadConfig to start from them. The server keeps a copy of the
+// configuration, so the caller's is never modified
+func New(rosettaConfig *types.Rosetta, opts ...Option) *Server {
+	s := NewServer("")
+	if rosettaConfig != nil {
+		configCopy := *rosettaConfig
+		s.config = &configCopy
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Use registers the middleware of the rosetta endpoints. The middlewares wrap the endpoints in the registration order,
+// so the first one sees the request first. They run inside the panic recovery and don't wrap the metrics and pprof
+// endpoints
+func (s *Server) Use(middleware Middleware) *Server {
+	s.middlewares = append(s.middlewares, middleware)
+	return s
+}
+
+// AddCallMethod registers the /call method, which is only served in online mode. The name must not be one of the
+// builtin methods
+func (s *Server) AddCallMethod(name string, method callService.CallMethod) *Server {
+	s.callMethods[name] = method
+	return s
+}
+
+// wrap wraps the handler with the registered middlewares
+func (s *Server) wrap(handler http.Handler) http.Handler {
+	for i := len(s.middlewares) - 1; i >= 0; i-- {
+		handler = s.middlewares[i](handler)
+	}
+	return handler
+}
+
+// getCallMethods returns the sorted names of the builtin and the registered /call methods. config.CallMethods isn't
+// modified, so each server only serves its own registered methods
+func (s *Server) getCallMethods() ([]string, error) {
+	builtin := make(map[string]bool, len(config.CallMethods))
+	callMethods := make([]string, 0, len(config.CallMethods)+len(s.callMethods))
+	for _, name := range config.CallMethods {
+		builtin[name] = true
+		callMethods = append(callMethods, name)
+	}
+
+	for name := range s.callMethods {
+		if name == "" || builtin[name] {
+			return nil, fmt.Errorf("invalid /call method %q, it's empty or a builtin method", name)
+		}
+		callMethods = append(callMethods, name)
+	}
+
+	sort.Strings(callMethods)
+	return callMethods, nil
+}
+
+func configLogger(level string, output io.Writer) {
+	var err error
+	var logLevel log.Level
+
+	if logLevel, err = log.ParseLevel(strings.ToLower(level)); err != nil {
+		// if invalid, default to info
+		logLevel = log.InfoLevel
+	}
+
+	log.SetLevel(logLevel)
+	log.SetOutput(output)
+	log.SetFormatter(&prefixed.TextFormatter{
+		DisableColors:   true,
+		ForceFormatting: true,
+		FullTimestamp:   true,
+		TimestampFormat: "2006-01-02T15:04:05.000-0700",
+	})
+}
+
+// onlineRouterOptions are the dependencies and the settings of the online router. The block notification and the
+// block stream are only backed by the mirror node database with the dsn
+type onlineRouterOptions struct {
+	accountConfig           types.Account
+	asserter                *asserter.Asserter
+	balanceExemptionsConfig types.BalanceExemptions
+	blockConfig             types.Block
+	callMethodNames         []string
+	callMethods             map[string]callService.CallMethod
+	canaryConfig            types.Canary
+	constructionConfig      types.Construction
+	dsn                     string
+	grpcServer              *grpc.Server
+	network                 *rTypes.NetworkIdentifier
+	nodes                   types.NodeMap
+	registry                *metrics.Registry
+	repos                   Repositories
+	settings                *config.Settings
+	submissionJournal       *journal.Journal
+	submitBreaker           *breaker.CircuitBreaker
+	version                 *rTypes.Version
+}
+
+// newBlockchainOnlineRouter creates a Mux http.Handler from a collection
+// of server controllers, serving "online" mode.
+// ref: https://www.rosetta-api.org/docs/node_deployment.html#online-mode-endpoints
+func newBlockchainOnlineRouter(options onlineRouterOptions) (http.Handler, error) {
+	accountRepo := options.repos.Account
+	addressBookRepo := options.repos.AddressBook
+	addressBookEntryRepo := options.repos.AddressBookEntry
+	blockRepo := options.repos.Block
+	exchangeRateRepo := options.repos.ExchangeRate
+	feeScheduleRepo := options.repos.FeeSchedule
+	networkVersionRepo := options.repos.NetworkVersion
+	nftRepo := options.repos.Nft
+	scheduleRepo := options.repos.Schedule
+	tokenAssociationRepo := options.repos.TokenAssociation
+	tokenRepo := options.repos.Token
+	transactionRepo := options.repos.Transaction
+
+	baseService := base.NewBaseService(blockRepo, transactionRepo)
+	nodeHealthTracker := nodehealth.NewTracker()
+
+	blockHub := eventsService.NewBlockHub()
+	if options.blockConfig.Notification.Enabled && options.dsn != "" {
+		blockWatcher := eventsService.NewBlockWatcher(blockRepo, blockHub)
+		notification.NewRecordFileListener(
+			options.dsn,
+			options.blockConfig.Notification.Channel,
+			time.Duration(options.blockConfig.Notification.PollInterval)*time.Millisecond,
+			blockWatcher.OnRecordFile,
+		).Start()
+	}
+
+	networkAPIService := networkService.NewNetworkAPIService(
+		baseService,
+		addressBookEntryRepo,
+		addressBookRepo,
+		networkVersionRepo,
+		nodeHealthTracker,
+		options.network,
+		options.version,
+		options.callMethodNames,
+		options.balanceExemptionsConfig.Accounts,
+		options.balanceExemptionsConfig.NodeAccounts,
+		options.settings,
+	)
+	networkAPIController := server.NewNetworkAPIController(networkAPIService, options.asserter)
+
+	var blockExchangeRateRepo repositories.ExchangeRateRepository
+	if options.blockConfig.ExchangeRate {
+		blockExchangeRateRepo = exchangeRateRepo
+	}
+	blockAPIService, err := blockService.NewBlockAPIService(
+		baseService,
+		blockExchangeRateRepo,
+		options.blockConfig.OmitZeroAmounts,
+		options.blockConfig.MaxOperations,
+		options.blockConfig.AuditMode,
+		options.settings,
+	)
+	if err != nil {
+		return nil, err
+	}
+	blockAPIController := blockService.NewBlockAPIController(
+		blockAPIService,
+		options.asserter,
+		encoder.NewCanonicalJSONEncoder(encoder.NewJSONEncoder()),
+	)
+
+	eventsAPIService := eventsService.NewEventsAPIService(baseService)
+	eventsAPIController := server.NewEventsAPIController(eventsAPIService, options.asserter)
+
+	mempoolAPIService := mempoolService.NewMempoolAPIService()
+	mempoolAPIController := server.NewMempoolAPIController(mempoolAPIService, options.asserter)
+
+	defaultPayer, err := getDefaultPayer(options.constructionConfig.DefaultPayer)
+	if err != nil {
+		return nil, err
+	}
+	constructionAPIService, err := constructionService.NewConstructionAPIService(
+		constructionService.ConstructionAPIServiceOptions{
+			AccountRepo:      accountRepo,
+			Broadcast:        options.constructionConfig.Broadcast,
+			DefaultPayer:     defaultPayer,
+			ExchangeRateRepo: exchangeRateRepo,
+			ExistenceCheck:   options.constructionConfig.ExistenceCheck,
+			FeeScheduleRepo:  feeScheduleRepo,
+			Journal:          options.submissionJournal,
+			Network:          options.network.Network,
+			NodeHealth:       nodeHealthTracker,
+			Nodes:            options.nodes,
+			ParseMode:        options.constructionConfig.ParseMode,
+			Registry:         options.registry,
+			ScheduleRepo:     scheduleRepo,
+			Settings:         options.settings,
+			SubmitBreaker:    options.submitBreaker,
+			TransactionConstructor: constructionService.NewTransactionConstructor(
+				tokenAssociationRepo,
+				tokenRepo,
+				options.constructionConfig.MaxTransactionFees,
+				options.constructionConfig.AutoRenewPeriod,
+				options.settings,
+			),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	constructionAPIController := server.NewConstructionAPIController(constructionAPIService, options.asserter)
+	if options.canaryConfig.Enabled {
+		selfCheck, err := canary.New(baseService, constructionAPIService, options.network, options.canaryConfig,
+			options.registry, options.settings)
+		if err != nil {
+			return nil, err
+		}
+		selfCheck.Start()
+	}
+	constructionBatchAPIController := constructionService.NewConstructionBatchAPIController(
+		constructionAPIService,
+		options.asserter,
+	)
+
+	accountAPIService := accountService.NewAccountAPIService(
+		baseService,
+		accountRepo,
+		tokenRepo,
+		options.accountConfig.MaxTokenBalances,
+		options.settings,
+	)
+	accountAPIController := server.NewAccountAPIController(accountAPIService, options.asserter)
+
+	// the submissions method exposes the journaled signed transactions, so it's only served behind the construction auth
+	callJournal := options.submissionJournal
+	if callJournal != nil && !options.constructionConfig.Auth.Enabled {
+		log.Warn("The submissions /call method is disabled since the construction auth isn't enabled")
+		callJournal = nil
+	}
+
+	callAPIService := callService.NewCallAPIService(baseService, callService.CallAPIServiceOptions{
+		AccountRepo:          accountRepo,
+		AddressBookRepo:      addressBookRepo,
+		CallMethods:          options.callMethods,
+		ExchangeRateRepo:     exchangeRateRepo,
+		FeeScheduleRepo:      feeScheduleRepo,
+		MaxTokenBalances:     options.accountConfig.MaxTokenBalances,
+		NftRepo:              nftRepo,
+		NodeHealth:           nodeHealthTracker,
+		Prechecker:           constructionService.NewTransactionPrechecker(accountRepo),
+		ScheduleRepo:         scheduleRepo,
+		Settings:             options.settings,
+		SubmissionJournal:    callJournal,
+		TokenAssociationRepo: tokenAssociationRepo,
+		TokenRepo:            tokenRepo,
+	})
+	callAPIController := server.NewCallAPIController(callAPIService, options.asserter)
+
+	if options.grpcServer != nil {
+		grpcapi.Register(options.grpcServer, options.asserter, accountAPIService, blockAPIService)
+	}
+
+	routers := []server.Router{
+		networkAPIController,
+		blockAPIController,
+		eventsAPIController,
+		mempoolAPIController,
+		constructionAPIController,
+		constructionBatchAPIController,
+		accountAPIController,
+		callAPIController,
+	}
+	if streamConfig := options.blockConfig.Stream; streamConfig.Enabled {
+		if !options.blockConfig.Notification.Enabled || options.dsn == "" {
+			log.Warn("The block stream only pushes new blocks with the block notification on the mirror node database")
+		}
+
+		routers = append(routers, eventsService.NewBlockStreamController(
+			blockHub,
+			streamConfig.BufferSize,
+			time.Duration(streamConfig.KeepAliveInterval)*time.Millisecond,
+		))
+	}
+
+	return server.NewRouter(routers...), nil
+}
+
+// newBlockchainOfflineRouter creates a Mux http.Handler from a collection
+// of server controllers, serving "offline" mode.
+// ref: https://www.rosetta-api.org/docs/node_deployment.html#offline-mode-endpoints
+func newBlockchainOfflineRouter(
+	network string,
+	nodes types.NodeMap,
+	asserter *asserter.Asserter,
+	constructionConfig types.Construction,
+	registry *metrics.Registry,
+	settings *config.Settings,
+) (http.Handler, error) {
+	defaultPayer, err := getDefaultPayer(constructionConfig.DefaultPayer)
+	if err != nil {
+		return nil, err
+	}
+	constructionAPIService, err := constructionService.NewConstructionAPIService(
+		constructionService.ConstructionAPIServiceOptions{
+			Broadcast:    constructionConfig.Broadcast,
+			DefaultPayer: defaultPayer,
+			Network:      network,
+			Nodes:        nodes,
+			ParseMode:    constructionConfig.ParseMode,
+			Registry:     registry,
+			Settings:     settings,
+			TransactionConstructor: constructionService.NewTransactionConstructor(
+				nil,
+				nil,
+				constructionConfig.MaxTransactionFees,
+				constructionConfig.AutoRenewPeriod,
+				settings,
+			),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	constructionAPIController := server.NewConstructionAPIController(constructionAPIService, asserter)
+	constructionBatchAPIController := constructionService.NewConstructionBatchAPIController(
+		constructionAPIService,
+		asserter,
+	)
+
+	return server.NewRouter(constructionAPIController, constructionBatchAPIController), nil
+}
+
+// LoadConfig loads the rosetta configuration from the default config file, the optional config files and the env
+// variables, the same way as the rosetta server
+func LoadConfig() (*types.Rosetta, error) {
+	configuration, err := loadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	return &configuration.Hedera.Mirror.Rosetta, nil
+}
+
+// applyConfig configures the logger, and returns the network identifier and the settings of the server, or the error if
+// the settings can't be created from the configuration. The settings are per server, so servers with different
+// configurations can run in the same process
+func applyConfig(rosettaConfig *types.Rosetta, logOutput io.Writer) (
+	*rTypes.NetworkIdentifier,
+	*config.Settings,
+	error,
+) {
+	configLogger(rosettaConfig.Log.Level, logOutput)
+	settings, err := newSettings(rosettaConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &rTypes.NetworkIdentifier{
+		Blockchain: config.Blockchain,
+		Network:    strings.ToLower(rosettaConfig.Network),
+		SubNetworkIdentifier: &rTypes.SubNetworkIdentifier{
+			Network: fmt.Sprintf("shard %s realm %s", rosettaConfig.Shard, rosettaConfig.Realm),
+		},
+	}, settings, nil
+}
+
+// newSettings creates the settings of a server from the configuration, it returns the error if the transaction
+// validity config is invalid
+func newSettings(rosettaConfig *types.Rosetta) (*config.Settings, error) {
+	settings := config.NewSettings()
+	settings.ConfigureCurrencyHbar(rosettaConfig.Currency.Symbol, rosettaConfig.Currency.Metadata)
+	settings.ConfigureLedgerId(rosettaConfig.Network)
+	settings.TimestampStrings = rosettaConfig.TimestampStrings
+	settings.TokenSubAccounts = rosettaConfig.Account.TokenSubAccounts
+	if err := settings.ConfigureTransactionValidity(getTransactionValidity(rosettaConfig.Construction)); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// getDefaultPayer returns the account id of the default payer the construction service fills in for an operation
+// without an account, empty if the default payer is disabled
+func getDefaultPayer(defaultPayerConfig types.DefaultPayer) (string, error) {
+	if !defaultPayerConfig.Enabled {
+		return "", nil
+	}
+
+	if defaultPayerConfig.AccountId == "" {
+		return "", fmt.Errorf("the default payer account id must be set")
+	}
+
+	return defaultPayerConfig.AccountId, nil
+}
+
+// getTransactionValidity returns the valid start backdate and the valid duration of the constructed transactions
+func getTransactionValidity(constructionConfig types.Construction) (time.Duration, time.Duration) {
+	return time.Duration(constructionConfig.ValidStartBackdate) * time.Millisecond,
+		time.Duration(constructionConfig.ValidDuration) * time.Second
+}
+
+// getBlockMetadata returns the version metadata describing how the transactions are grouped into blocks, either a
+// block per record file or a block per fixed time window of block_window milliseconds
+func getBlockMetadata(blockConfig types.Block) map[string]interface{} {
+	if blockConfig.Window > 0 {
+		return map[string]interface{}{"block_grouping": "time_window", "block_window": blockConfig.Window}
+	}
+
+	return map[string]interface{}{"block_grouping": "record_file"}
+}
+
+// newOnlineRouter creates the online router with the registered repositories, the nil ones are created on the mirror
+// node database. The data services are also registered on grpcServer if it isn't nil
+func (s *Server) newOnlineRouter(
+	rosettaConfig *types.Rosetta,
+	network *rTypes.NetworkIdentifier,
+	callMethods []string,
+	asserter *asserter.Asserter,
+	version *rTypes.Version,
+	registry *metrics.Registry,
+	grpcServer *grpc.Server,
+	settings *config.Settings,
+) (http.Handler, error) {
+	breakerConfig := rosettaConfig.CircuitBreaker
+	openTimeout := time.Duration(breakerConfig.OpenTimeout) * time.Millisecond
+
+	dsn := ""
+	repos := s.repositories
+	if !repos.isComplete() {
+		dbClient, err := connectToDb(rosettaConfig.Db)
+		if err != nil {
+			return nil, err
+		}
+
+		if integrityCheck := rosettaConfig.Db.IntegrityCheck; integrityCheck.Enabled {
+			if err = block.CheckIntegrity(dbClient, integrityCheck.SampleSize); err != nil {
+				return nil, fmt.Errorf("database integrity check failed: %w", err)
+			}
+			log.Infof("Database integrity check passed for the latest %d record files", integrityCheck.SampleSize)
+		}
+
+		if rosettaConfig.Db.Metrics.Enabled {
+			if err = instrumentDb(dbClient, registry, rosettaConfig.Db.Metrics); err != nil {
+				return nil, err
+			}
+		}
+
+		if breakerConfig.Enabled {
+			if err = protectDb(dbClient, breaker.NewCircuitBreaker("database", breakerConfig.MaxFailures,
+				openTimeout)); err != nil {
+				return nil, err
+			}
+		}
+
+		dsn = getDsn(rosettaConfig.Db)
+		if len(rosettaConfig.Db.Tls.PinnedCertificates) != 0 && rosettaConfig.Block.Notification.Enabled {
+			log.Warn("The record file notification listener doesn't check the pinned database certificates")
+		}
+		if err = mapper.CheckFailedAmounts(rosettaConfig.Block.FailedAmounts); err != nil {
+			return nil, err
+		}
+		repos.setDefaults(dbClient, rosettaConfig.Account, rosettaConfig.Block, rosettaConfig.Db.RawQueries,
+			settings.TimestampStrings)
+
+		if rollingBalanceConfig := rosettaConfig.Account.RollingBalance; rollingBalanceConfig.Enabled {
+			updater, err := account.NewRollingBalanceUpdater(dbClient, rollingBalanceConfig.BatchSize)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create the rolling balance tables: %w", err)
+			}
+
+			channel := ""
+			if rosettaConfig.Block.Notification.Enabled {
+				channel = rosettaConfig.Block.Notification.Channel
+			}
+			notification.NewRecordFileListener(
+				dsn,
+				channel,
+				time.Duration(rollingBalanceConfig.PollInterval)*time.Millisecond,
+				updater.Update,
+			).Start()
+		}
+	}
+
+	var submitBreaker *breaker.CircuitBreaker
+	if breakerConfig.Enabled {
+		submitBreaker = breaker.NewCircuitBreaker("consensus nodes", breakerConfig.MaxFailures, openTimeout)
+	}
+
+	var submissionJournal *journal.Journal
+	if journalConfig := rosettaConfig.Construction.Journal; journalConfig.Enabled {
+		var err error
+		if submissionJournal, err = journal.Open(journalConfig.Path, journalConfig.MaxEntries); err != nil {
+			return nil, fmt.Errorf("failed to open submission journal: %w", err)
+		}
+
+		if err = submissionJournal.ResolvePending(newTransactionResultFinder(repos.Transaction)); err != nil {
+			return nil, fmt.Errorf("failed to resolve the pending submissions: %w", err)
+		}
+	}
+
+	return newBlockchainOnlineRouter(onlineRouterOptions{
+		accountConfig:           rosettaConfig.Account,
+		asserter:                asserter,
+		balanceExemptionsConfig: rosettaConfig.BalanceExemptions,
+		blockConfig:             rosettaConfig.Block,
+		callMethodNames:         callMethods,
+		callMethods:             s.callMethods,
+		canaryConfig:            rosettaConfig.Canary,
+		constructionConfig:      rosettaConfig.Construction,
+		dsn:                     dsn,
+		grpcServer:              grpcServer,
+		network:                 network,
+		nodes:                   rosettaConfig.Nodes,
+		registry:                registry,
+		repos:                   repos,
+		settings:                settings,
+		submissionJournal:       submissionJournal,
+		submitBreaker:           submitBreaker,
+		version:                 version,
+	})
+}
+
+// newTransactionResultFinder returns the function finding the result of the transaction with the hash in the mirror
+// node, which resolves the submissions left pending in the journal by a crash. The first transaction with the hash is
+// the one executed, the later ones are duplicates
+func newTransactionResultFinder(transactionRepo repositories.TransactionRepository) func(hash string) (string, bool) {
+	return func(hash string) (string, bool) {
+		rawTransactions, err := transactionRepo.FindRawByHash(hash)
+		if err != nil {
+			if err != hErrors.ErrTransactionNotFound {
+				log.Warnf("Failed to find transaction %s: %s", hash, err.Message)
+			}
+			return "", false
+		}
+
+		return rawTransactions[0].Result, true
+	}
+}
+
+// Serve serves the rosetta API with the configuration given to New, or loaded from the config files and the env
+// variables. It returns the error if the server fails to start or stops serving
+func (s *Server) Serve() error {
+	configLogger("info", s.logOutput)
+
+	rosettaConfig := s.config
+	if rosettaConfig == nil {
+		var err error
+		if rosettaConfig, err = LoadConfig(); err != nil {
+			return err
+		}
+	}
+	network, settings, err := applyConfig(rosettaConfig, s.logOutput)
+	if err != nil {
+		return err
+	}
+
+	if s.buildVersion != "" {
+		rosettaConfig.Version = s.buildVersion
+	}
+
+	callMethods, err := s.getCallMethods()
+	if err != nil {
+		return err
+	}
+
+	version := &rTypes.Version{
+		RosettaVersion:    rosettaConfig.ApiVersion,
+		NodeVersion:       rosettaConfig.NodeVersion,
+		MiddlewareVersion: &rosettaConfig.Version,
+		Metadata:          getBlockMetadata(rosettaConfig.Block),
+	}
+
+	asserter, err := asserter.NewServer(
+		[]string{config.OperationTypeCryptoTransfer},
+		true,
+		[]*rTypes.NetworkIdentifier{network},
+		callMethods,
+		false,
+	)
+	if err != nil {
+		return err
+	}
+
+	var router http.Handler
+	registry := metrics.NewRegistry()
+
+	if rosettaConfig.Online {
+		var grpcServer *grpc.Server
+		if rosettaConfig.Grpc.Enabled {
+			if grpcServer, err = newGrpcServer(rosettaConfig.Http.Tls); err != nil {
+				return err
+			}
+		}
+
+		if router, err = s.newOnlineRouter(rosettaConfig, network, callMethods, asserter, version, registry,
+			grpcServer, settings); err != nil {
+			return err
+		}
+
+		if grpcServer != nil {
+			if err = serveGrpc(grpcServer, rosettaConfig.Grpc.Port); err != nil {
+				return err
+			}
+		}
+
+		log.Info("Serving Rosetta API in ONLINE mode")
+	} else {
+		if rosettaConfig.Grpc.Enabled {
+			log.Warn("The gRPC API is only served in ONLINE mode")
+		}
+		if rosettaConfig.Canary.Enabled {
+			log.Warn("The canary self-check only runs in ONLINE mode")
+		}
+		if rosettaConfig.Construction.ExistenceCheck {
+			log.Warn("The account existence check only runs in ONLINE mode")
+		}
+
+		router, err = newBlockchainOfflineRouter(
+			network.Network,
+			rosettaConfig.Nodes,
+			asserter,
+			rosettaConfig.Construction,
+			registry,
+			settings,
+		)
+		if err != nil {
+			return err
+		}
+
+		log.Info("Serving Rosetta API in OFFLINE mode")
+	}
+
+	httpConfig := rosettaConfig.Http
+	if auth := rosettaConfig.Construction.Auth; auth.Enabled {
+		if auth.ClientCertificates && (!httpConfig.Tls.Enabled || httpConfig.Tls.ClientCaFile == "") {
+			return fmt.Errorf("client certificate authentication requires TLS with a client CA file")
+		}
+
+		if router, err = middleware.AuthMiddleware(router, auth.ApiKeys, auth.ClientCertificates); err != nil {
+			return err
+		}
+		log.Info("Authenticating the construction endpoints and the protected /call methods")
+	}
+
+	if concurrency := httpConfig.Concurrency; concurrency.Enabled {
+		if router, err = middleware.ConcurrencyMiddleware(
+			router,
+			map[string]int{accountBalancePath: concurrency.AccountBalance, blockPath: concurrency.Block},
+			concurrency.MaxQueued,
+			time.Duration(concurrency.QueueTimeout)*time.Millisecond,
+		); err != nil {
+			return err
+		}
+		log.Info("Limiting the concurrent block and account balance requests")
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, registry)
+	if httpConfig.Pprof {
+		registerPprofHandlers(mux)
+		log.Warnf("Serving pprof endpoints under %s", pprofPath)
+	}
+	var apiHandler http.Handler = middleware.RecoveryMiddleware(s.wrap(router), registry, nil)
+	if localization := httpConfig.Localization; localization.Enabled {
+		if localization.CatalogFile == "" {
+			return fmt.Errorf("error message localization requires a catalog file")
+		}
+
+		catalog, err := hErrors.LoadCatalog(localization.CatalogFile)
+		if err != nil {
+			return err
+		}
+
+		if apiHandler, err = middleware.LocalizationMiddleware(apiHandler, catalog); err != nil {
+			return err
+		}
+		log.Infof("Localizing the error messages to %d locales", len(catalog))
+	}
+	mux.Handle("/", apiHandler)
+
+	var handler http.Handler = mux
+	if compression := httpConfig.Compression; compression.Enabled {
+		if handler, err = middleware.CompressionMiddleware(mux, compression.Level, compression.MinSize); err != nil {
+			return err
+		}
+	}
+
+	if cors := httpConfig.Cors; cors.Enabled {
+		if handler, err = middleware.CorsMiddleware(
+			handler,
+			cors.AllowedOrigins,
+			cors.AllowedHeaders,
+			cors.MaxAge,
+		); err != nil {
+			return err
+		}
+	}
+
+	handler = server.LoggerMiddleware(handler)
+	httpServer := &http.Server{Addr: fmt.Sprintf(":%d", rosettaConfig.Port), Handler: handler}
+
+	if httpConfig.Tls.Enabled {
+		if httpServer.TLSConfig, err = newTlsConfig(httpConfig.Tls); err != nil {
+			return err
+		}
+
+		// HTTP/2 is negotiated in the TLS handshake
+		if s.listener != nil {
+			log.Infof("Listening with TLS on %s", s.listener.Addr())
+			return httpServer.ServeTLS(s.listener, httpConfig.Tls.CertFile, httpConfig.Tls.KeyFile)
+		}
+
+		log.Infof("Listening with TLS on port %d", rosettaConfig.Port)
+		return httpServer.ListenAndServeTLS(httpConfig.Tls.CertFile, httpConfig.Tls.KeyFile)
+	}
+
+	if httpConfig.Http2 {
+		// serve HTTP/2 over cleartext since TLS is terminated in front of the server
+		httpServer.Handler = h2c.NewHandler(handler, &http2.Server{})
+	}
+
+	if s.listener != nil {
+		log.Infof("Listening on %s", s.listener.Addr())
+		return httpServer.Serve(s.listener)
+	}
+
+	log.Infof("Listening on port %d", rosettaConfig.Port)
+	return httpServer.ListenAndServe()
+}
+
+// Run loads the configuration and serves the rosetta API, it exits the process if the server fails to start
+func (s *Server) Run() {
+	log.Fatal(s.Serve())
+}
@@ -0,0 +1,370 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package bootstrap
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	domainTypes "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/test/mocks/repository"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerWrap(t *testing.T) {
+	// given
+	var order []string
+	newMiddleware := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		order = append(order, "handler")
+	})
+	server := NewServer("").Use(newMiddleware("auth")).Use(newMiddleware("audit"))
+
+	// when
+	server.wrap(handler).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/block", nil))
+
+	// then
+	assert.Equal(t, []string{"auth", "audit", "handler"}, order)
+}
+
+func TestServerGetCallMethods(t *testing.T) {
+	// given
+	builtin := append([]string{}, config.CallMethods...)
+	method := func(map[string]interface{}) (map[string]interface{}, bool, *rTypes.Error) { return nil, true, nil }
+	server := NewServer("").AddCallMethod("audit_log", method)
+	other := NewServer("").AddCallMethod("custom", method)
+
+	// when
+	callMethods, err := server.getCallMethods()
+	again, againErr := server.getCallMethods()
+	otherCallMethods, otherErr := other.getCallMethods()
+
+	// then
+	assert.NoError(t, err)
+	assert.Contains(t, callMethods, "audit_log")
+	assert.NotContains(t, callMethods, "custom")
+	assert.Len(t, callMethods, len(builtin)+1)
+	assert.True(t, sort.StringsAreSorted(callMethods))
+	assert.NoError(t, againErr)
+	assert.Equal(t, callMethods, again)
+	assert.NoError(t, otherErr)
+	assert.Contains(t, otherCallMethods, "custom")
+	assert.NotContains(t, otherCallMethods, "audit_log")
+	assert.Equal(t, builtin, config.CallMethods)
+}
+
+func TestServerGetCallMethodsInvalidName(t *testing.T) {
+	for _, name := range []string{"", config.CallMethodAddressBook} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			method := func(map[string]interface{}) (map[string]interface{}, bool, *rTypes.Error) {
+				return nil, true, nil
+			}
+			server := NewServer("").AddCallMethod(name, method)
+
+			// when
+			callMethods, err := server.getCallMethods()
+
+			// then
+			assert.Error(t, err)
+			assert.Nil(t, callMethods)
+		})
+	}
+}
+
+func TestGetBlockMetadata(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{"block_grouping": "record_file"}, getBlockMetadata(types.Block{}))
+	assert.Equal(
+		t,
+		map[string]interface{}{"block_grouping": "time_window", "block_window": 2000},
+		getBlockMetadata(types.Block{Window: 2000}),
+	)
+}
+
+func TestGetDefaultPayer(t *testing.T) {
+	defaultPayer, err := getDefaultPayer(types.DefaultPayer{AccountId: "0.0.100"})
+	assert.NoError(t, err)
+	assert.Empty(t, defaultPayer)
+
+	defaultPayer, err = getDefaultPayer(types.DefaultPayer{AccountId: "0.0.100", Enabled: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "0.0.100", defaultPayer)
+
+	_, err = getDefaultPayer(types.DefaultPayer{Enabled: true})
+	assert.Error(t, err)
+}
+
+func TestNewTransactionResultFinder(t *testing.T) {
+	var tests = []struct {
+		name            string
+		rawTransactions []*domainTypes.RawTransaction
+		err             *rTypes.Error
+		expectedResult  string
+		expectedFound   bool
+	}{
+		{
+			name: "Found",
+			rawTransactions: []*domainTypes.RawTransaction{
+				{ConsensusTimestamp: 10, Result: "SUCCESS"},
+				{ConsensusTimestamp: 20, Result: "DUPLICATE_TRANSACTION"},
+			},
+			expectedResult: "SUCCESS",
+			expectedFound:  true,
+		},
+		{name: "NotFound", err: errors.ErrTransactionNotFound},
+		{name: "DatabaseError", err: errors.ErrDatabaseError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			transactionRepo := &repository.MockTransactionRepository{}
+			transactionRepo.On("FindRawByHash", "0x0102").Return(tt.rawTransactions, tt.err)
+
+			// when
+			result, found := newTransactionResultFinder(transactionRepo)("0x0102")
+
+			// then
+			assert.Equal(t, tt.expectedResult, result)
+			assert.Equal(t, tt.expectedFound, found)
+		})
+	}
+}
+
+func TestNewSettings(t *testing.T) {
+	// given
+	customConfig := &types.Rosetta{
+		Account:          types.Account{TokenSubAccounts: true},
+		Construction:     types.Construction{ValidDuration: 60, ValidStartBackdate: 1000},
+		Currency:         types.Currency{Symbol: "TINYBAR"},
+		Network:          "mainnet",
+		TimestampStrings: true,
+	}
+	defaultConfig := &types.Rosetta{Currency: types.Currency{Symbol: config.CurrencySymbol}, Network: "demo"}
+
+	// when
+	custom, customErr := newSettings(customConfig)
+	defaults, defaultErr := newSettings(defaultConfig)
+
+	// then
+	assert.NoError(t, customErr)
+	assert.Equal(t, "TINYBAR", custom.CurrencyHbar.Symbol)
+	assert.NotEmpty(t, custom.LedgerId)
+	assert.True(t, custom.TimestampStrings)
+	assert.True(t, custom.TokenSubAccounts)
+	assert.Equal(t, 60*time.Second, custom.TransactionValidDuration)
+	assert.Equal(t, time.Second, custom.TransactionValidStartBackdate)
+
+	assert.NoError(t, defaultErr)
+	assert.Equal(t, config.NewSettings(), defaults)
+	assert.Equal(t, config.CurrencySymbol, config.CurrencyHbar.Symbol)
+}
+
+func TestNewSettingsInvalidTransactionValidity(t *testing.T) {
+	// given
+	rosettaConfig := &types.Rosetta{
+		Construction: types.Construction{ValidDuration: 1000},
+		Currency:     types.Currency{Symbol: config.CurrencySymbol},
+	}
+
+	// when
+	settings, err := newSettings(rosettaConfig)
+
+	// then
+	assert.Error(t, err)
+	assert.Nil(t, settings)
+}
+
+func newMockRepositories() Repositories {
+	return Repositories{
+		Account:          &repository.MockAccountRepository{},
+		AddressBook:      &repository.MockAddressBookRepository{},
+		AddressBookEntry: &repository.MockAddressBookEntryRepository{},
+		Block:            &repository.MockBlockRepository{},
+		ExchangeRate:     &repository.MockExchangeRateRepository{},
+		FeeSchedule:      &repository.MockFeeScheduleRepository{},
+		NetworkVersion:   &repository.MockNetworkVersionRepository{},
+		Nft:              &repository.MockNftRepository{},
+		Schedule:         &repository.MockScheduleRepository{},
+		Token:            &repository.MockTokenRepository{},
+		TokenAssociation: &repository.MockTokenAssociationRepository{},
+		Transaction:      &repository.MockTransactionRepository{},
+	}
+}
+
+func TestNewCopiesConfig(t *testing.T) {
+	// given
+	rosettaConfig := &types.Rosetta{Network: "testnet", Version: "1.0.0"}
+
+	// when
+	server := New(rosettaConfig)
+	server.config.Version = "2.0.0"
+
+	// then
+	assert.NotSame(t, rosettaConfig, server.config)
+	assert.Equal(t, "testnet", server.config.Network)
+	assert.Equal(t, "1.0.0", rosettaConfig.Version)
+	assert.Nil(t, New(nil).config)
+}
+
+func TestNewServe(t *testing.T) {
+	// given
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	repos := newMockRepositories()
+	rosettaConfig := &types.Rosetta{
+		ApiVersion: "1.4.10",
+		Block:      types.Block{Notification: types.BlockNotification{Enabled: true}},
+		Construction: types.Construction{
+			Broadcast: types.Broadcast{Type: "grpc"},
+			ParseMode: "lenient",
+		},
+		Log:     types.Log{Level: "info"},
+		Network: "testnet",
+		Online:  true,
+		Realm:   "0",
+		Shard:   "0",
+	}
+	served := make(chan error, 1)
+
+	// when
+	server := New(rosettaConfig, WithRepositories(repos), WithListener(listener), WithLogOutput(ioutil.Discard))
+	go func() { served <- server.Serve() }()
+	response, err := http.Post("http://"+listener.Addr().String()+"/network/list", "application/json",
+		bytes.NewBufferString("{}"))
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	networkList := &rTypes.NetworkListResponse{}
+	assert.NoError(t, json.NewDecoder(response.Body).Decode(networkList))
+	_ = response.Body.Close()
+	assert.Equal(t, []*rTypes.NetworkIdentifier{{
+		Blockchain:           config.Blockchain,
+		Network:              "testnet",
+		SubNetworkIdentifier: &rTypes.SubNetworkIdentifier{Network: "shard 0 realm 0"},
+	}}, networkList.NetworkIdentifiers)
+
+	_ = listener.Close()
+	assert.Error(t, <-served)
+}
+
+func TestServeInvalidTransactionValidity(t *testing.T) {
+	var tests = []struct {
+		name         string
+		construction types.Construction
+	}{
+		{name: "ValidDurationTooLong", construction: types.Construction{ValidDuration: 181}},
+		{name: "BackdateExpired", construction: types.Construction{ValidDuration: 120, ValidStartBackdate: 120000}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			assert.NoError(t, err)
+			defer listener.Close()
+			tt.construction.Broadcast = types.Broadcast{Type: "grpc"}
+			rosettaConfig := &types.Rosetta{
+				Construction: tt.construction,
+				Log:          types.Log{Level: "info"},
+				Network:      "testnet",
+				Online:       true,
+				Realm:        "0",
+				Shard:        "0",
+			}
+			server := New(
+				rosettaConfig,
+				WithRepositories(newMockRepositories()),
+				WithListener(listener),
+				WithLogOutput(ioutil.Discard),
+			)
+
+			// when
+			err = server.Serve()
+
+			// then
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestServeCallMethodsPerServer(t *testing.T) {
+	// given
+	method := func(map[string]interface{}) (map[string]interface{}, bool, *rTypes.Error) {
+		return map[string]interface{}{"custom": true}, true, nil
+	}
+	addresses := make([]string, 0, 2)
+	for i := 0; i < 2; i++ {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		defer listener.Close()
+		rosettaConfig := &types.Rosetta{
+			Construction: types.Construction{Broadcast: types.Broadcast{Type: "grpc"}},
+			Log:          types.Log{Level: "info"},
+			Network:      "testnet",
+			Online:       true,
+			Realm:        "0",
+			Shard:        "0",
+		}
+		server := New(
+			rosettaConfig,
+			WithRepositories(newMockRepositories()),
+			WithListener(listener),
+			WithLogOutput(ioutil.Discard),
+		).AddCallMethod("custom", method)
+		go func() { _ = server.Serve() }()
+		addresses = append(addresses, listener.Addr().String())
+	}
+	body := `{"network_identifier": {"blockchain": "Hedera", "network": "testnet", ` +
+		`"sub_network_identifier": {"network": "shard 0 realm 0"}}, "method": "custom", "parameters": {}}`
+
+	for _, address := range addresses {
+		// when
+		response, err := http.Post("http://"+address+"/call", "application/json", bytes.NewBufferString(body))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		callResponse := &rTypes.CallResponse{}
+		assert.NoError(t, json.NewDecoder(response.Body).Decode(callResponse))
+		_ = response.Body.Close()
+		assert.Equal(t, map[string]interface{}{"custom": true}, callResponse.Result)
+	}
+}
@@ -18,7 +18,7 @@
  * ‍
  */
 
-package main
+package bootstrap
 
 import (
 	"os"
@@ -0,0 +1,236 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package bootstrap
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/breaker"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/metrics"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/types"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/stdlib"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// The database TLS modes, a subset of the Postgres sslmode values supported by both pgx and lib/pq without a plaintext
+// fallback
+const (
+	dbTlsModeDisable    = "disable"
+	dbTlsModeRequire    = "require"
+	dbTlsModeVerifyCa   = "verify-ca"
+	dbTlsModeVerifyFull = "verify-full"
+)
+
+// getDsn returns the data source name of the Postgres Database
+func getDsn(dbConfig types.Db) string {
+	tlsConfig := dbConfig.Tls
+	mode := tlsConfig.Mode
+	if mode == "" {
+		mode = dbTlsModeDisable
+	}
+
+	dsn := fmt.Sprintf(
+		"host=%s port=%d user=%s dbname=%s password=%s sslmode=%s",
+		dbConfig.Host,
+		dbConfig.Port,
+		dbConfig.Username,
+		dbConfig.Name,
+		dbConfig.Password,
+		mode,
+	)
+	if mode == dbTlsModeDisable {
+		return dsn
+	}
+
+	if tlsConfig.CertFile != "" {
+		dsn += fmt.Sprintf(" sslcert=%s sslkey=%s", tlsConfig.CertFile, tlsConfig.KeyFile)
+	}
+	if tlsConfig.RootCaFile != "" {
+		dsn += fmt.Sprintf(" sslrootcert=%s", tlsConfig.RootCaFile)
+	}
+	return dsn
+}
+
+// checkDbTls returns an error if the database TLS config is invalid, e.g., a certificate file can't be loaded or a
+// pinned certificate isn't a SHA-256 fingerprint
+func checkDbTls(tlsConfig types.DbTls) error {
+	switch tlsConfig.Mode {
+	case "", dbTlsModeDisable:
+		if tlsConfig.CertFile != "" || tlsConfig.KeyFile != "" || tlsConfig.RootCaFile != "" ||
+			len(tlsConfig.PinnedCertificates) != 0 {
+			return fmt.Errorf("the certificate files and the pinned certificates require a TLS mode other than %s",
+				dbTlsModeDisable)
+		}
+		return nil
+	case dbTlsModeRequire, dbTlsModeVerifyCa, dbTlsModeVerifyFull:
+	default:
+		return fmt.Errorf("unsupported database TLS mode %s", tlsConfig.Mode)
+	}
+
+	if (tlsConfig.CertFile == "") != (tlsConfig.KeyFile == "") {
+		return fmt.Errorf("the client certificate requires both the certificate file and the key file")
+	}
+
+	if tlsConfig.CertFile != "" {
+		if _, err := tls.LoadX509KeyPair(tlsConfig.CertFile, tlsConfig.KeyFile); err != nil {
+			return fmt.Errorf("failed to load the client certificate and key: %w", err)
+		}
+	}
+
+	if tlsConfig.RootCaFile != "" {
+		// Disable gosec since the CA file is configured by the operator
+		caPem, err := ioutil.ReadFile(tlsConfig.RootCaFile) // #nosec
+		if err != nil {
+			return fmt.Errorf("failed to read the root CA file %s: %w", tlsConfig.RootCaFile, err)
+		}
+
+		if !x509.NewCertPool().AppendCertsFromPEM(caPem) {
+			return fmt.Errorf("no certificate found in the root CA file %s", tlsConfig.RootCaFile)
+		}
+	}
+
+	_, err := parsePinnedCertificates(tlsConfig.PinnedCertificates)
+	return err
+}
+
+// parsePinnedCertificates decodes the hex encoded SHA-256 fingerprints, optionally separated by colons as printed by
+// openssl
+func parsePinnedCertificates(pinnedCertificates []string) ([][]byte, error) {
+	fingerprints := make([][]byte, 0, len(pinnedCertificates))
+	for _, pinnedCertificate := range pinnedCertificates {
+		fingerprint, err := hex.DecodeString(strings.ReplaceAll(pinnedCertificate, ":", ""))
+		if err != nil || len(fingerprint) != sha256.Size {
+			return nil, fmt.Errorf("pinned certificate %s is not a SHA-256 fingerprint", pinnedCertificate)
+		}
+		fingerprints = append(fingerprints, fingerprint)
+	}
+	return fingerprints, nil
+}
+
+// verifyPinnedCertificate returns an error unless a certificate in the chain presented by the server matches one of
+// the fingerprints
+func verifyPinnedCertificate(fingerprints [][]byte, rawCerts [][]byte) error {
+	for _, rawCert := range rawCerts {
+		sum := sha256.Sum256(rawCert)
+		for _, fingerprint := range fingerprints {
+			if bytes.Equal(sum[:], fingerprint) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("none of the %d certificates presented by the database server is pinned", len(rawCerts))
+}
+
+// newDbDialector returns the gorm dialector of the Postgres Database after validating the TLS config. With pinned
+// certificates, the connections are opened through pgx directly, since the pins can't be expressed in the dsn
+func newDbDialector(dbConfig types.Db) (gorm.Dialector, error) {
+	if err := checkDbTls(dbConfig.Tls); err != nil {
+		return nil, err
+	}
+
+	dsn := getDsn(dbConfig)
+	if len(dbConfig.Tls.PinnedCertificates) == 0 {
+		return postgres.Open(dsn), nil
+	}
+
+	fingerprints, _ := parsePinnedCertificates(dbConfig.Tls.PinnedCertificates)
+	connConfig, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	pinTlsConfig := func(tlsConfig *tls.Config) {
+		if tlsConfig == nil {
+			return
+		}
+
+		verify := tlsConfig.VerifyPeerCertificate
+		tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+			if verify != nil {
+				if err := verify(rawCerts, verifiedChains); err != nil {
+					return err
+				}
+			}
+			return verifyPinnedCertificate(fingerprints, rawCerts)
+		}
+	}
+	pinTlsConfig(connConfig.TLSConfig)
+	for _, fallback := range connConfig.Fallbacks {
+		pinTlsConfig(fallback.TLSConfig)
+	}
+
+	return postgres.New(postgres.Config{Conn: stdlib.OpenDB(*connConfig)}), nil
+}
+
+// Establish connection to the Postgres Database
+func connectToDb(dbConfig types.Db) (*gorm.DB, error) {
+	dialector, err := newDbDialector(dbConfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid database tls config: %w", err)
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Successfully connected to Database")
+
+	sqlDb, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDb.SetMaxIdleConns(dbConfig.Pool.MaxIdleConnections)
+	sqlDb.SetConnMaxLifetime(time.Duration(dbConfig.Pool.MaxLifetime) * time.Minute)
+	sqlDb.SetMaxOpenConns(dbConfig.Pool.MaxOpenConnections)
+
+	return db, nil
+}
+
+// instrumentDb records the duration of the queries run by dbClient and logs the slow ones
+func instrumentDb(dbClient *gorm.DB, registry *metrics.Registry, metricsConfig types.DbMetrics) error {
+	slowQueryThreshold := time.Duration(metricsConfig.SlowQueryThreshold) * time.Millisecond
+	if err := metrics.Instrument(dbClient, registry, slowQueryThreshold); err != nil {
+		return err
+	}
+	log.Infof("Instrumented database queries with slow query threshold %s", slowQueryThreshold)
+	return nil
+}
+
+// protectDb fast-fails the queries run by dbClient while the circuit breaker is open
+func protectDb(dbClient *gorm.DB, cb *breaker.CircuitBreaker) error {
+	if err := breaker.ProtectDb(dbClient, cb); err != nil {
+		return err
+	}
+	log.Info("Protected database queries with circuit breaker")
+	return nil
+}
@@ -0,0 +1,243 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+package bootstrap
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pinnedCertificate = "8f:43:28:8a:d2:72:f3:10:3b:6f:b1:42:84:85:ea:30:14:c0:bc:fe:" +
+	"56:8e:36:70:fc:5d:8c:bb:e3:94:bd:31"
+
+func TestGetDsn(t *testing.T) {
+	dbConfig := types.Db{Host: "127.0.0.1", Name: "mirror_node", Password: "pass", Port: 5432, Username: "user"}
+	prefix := "host=127.0.0.1 port=5432 user=user dbname=mirror_node password=pass"
+
+	var tests = []struct {
+		name     string
+		tls      types.DbTls
+		expected string
+	}{
+		{name: "Default", expected: prefix + " sslmode=disable"},
+		{name: "Disable", tls: types.DbTls{Mode: "disable"}, expected: prefix + " sslmode=disable"},
+		{name: "Require", tls: types.DbTls{Mode: "require"}, expected: prefix + " sslmode=require"},
+		{
+			name: "VerifyFullWithFiles",
+			tls: types.DbTls{
+				CertFile:   "client.crt",
+				KeyFile:    "client.key",
+				Mode:       "verify-full",
+				RootCaFile: "root.crt",
+			},
+			expected: prefix + " sslmode=verify-full sslcert=client.crt sslkey=client.key sslrootcert=root.crt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			dbConfig.Tls = tt.tls
+
+			// when
+			actual := getDsn(dbConfig)
+
+			// then
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestCheckDbTls(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	require.NoError(t, ioutil.WriteFile(caFile, newCaPem(t), 0600))
+	certFile, keyFile := newClientCertificateFiles(t, dir)
+
+	var tests = []struct {
+		name string
+		tls  types.DbTls
+	}{
+		{name: "Default"},
+		{name: "Disable", tls: types.DbTls{Mode: "disable"}},
+		{name: "Require", tls: types.DbTls{Mode: "require", PinnedCertificates: []string{pinnedCertificate}}},
+		{name: "VerifyCa", tls: types.DbTls{Mode: "verify-ca", RootCaFile: caFile}},
+		{
+			name: "VerifyFull",
+			tls: types.DbTls{
+				CertFile:           certFile,
+				KeyFile:            keyFile,
+				Mode:               "verify-full",
+				PinnedCertificates: []string{pinnedCertificate, "ab" + pinnedCertificate[3:]},
+				RootCaFile:         caFile,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, checkDbTls(tt.tls))
+		})
+	}
+}
+
+func TestCheckDbTlsInvalid(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	invalidCaFile := filepath.Join(dir, "invalid.pem")
+	require.NoError(t, ioutil.WriteFile(invalidCaFile, []byte("not a certificate"), 0600))
+	certFile, _ := newClientCertificateFiles(t, dir)
+
+	var tests = []struct {
+		name string
+		tls  types.DbTls
+	}{
+		{name: "UnsupportedMode", tls: types.DbTls{Mode: "prefer"}},
+		{name: "DisableWithRootCa", tls: types.DbTls{Mode: "disable", RootCaFile: invalidCaFile}},
+		{name: "DisableWithPins", tls: types.DbTls{PinnedCertificates: []string{pinnedCertificate}}},
+		{name: "NoKeyFile", tls: types.DbTls{CertFile: certFile, Mode: "require"}},
+		{name: "NoCertFile", tls: types.DbTls{KeyFile: certFile, Mode: "require"}},
+		{name: "MismatchedKeyFile", tls: types.DbTls{CertFile: certFile, KeyFile: certFile, Mode: "require"}},
+		{name: "MissingRootCaFile", tls: types.DbTls{Mode: "verify-ca", RootCaFile: "missing.pem"}},
+		{name: "InvalidRootCaFile", tls: types.DbTls{Mode: "verify-ca", RootCaFile: invalidCaFile}},
+		{name: "NonHexPin", tls: types.DbTls{Mode: "require", PinnedCertificates: []string{"xyz"}}},
+		{name: "ShortPin", tls: types.DbTls{Mode: "require", PinnedCertificates: []string{"8f43288a"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, checkDbTls(tt.tls))
+		})
+	}
+}
+
+func TestVerifyPinnedCertificate(t *testing.T) {
+	// given
+	leaf := []byte("leaf")
+	ca := []byte("ca")
+	leafSum := sha256.Sum256(leaf)
+	caSum := sha256.Sum256(ca)
+
+	var tests = []struct {
+		name         string
+		fingerprints [][]byte
+		expectError  bool
+	}{
+		{name: "Leaf", fingerprints: [][]byte{leafSum[:]}},
+		{name: "Ca", fingerprints: [][]byte{{0x1}, caSum[:]}},
+		{name: "NotPinned", fingerprints: [][]byte{{0x1}}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			err := verifyPinnedCertificate(tt.fingerprints, [][]byte{leaf, ca})
+
+			// then
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParsePinnedCertificates(t *testing.T) {
+	// given
+	expected, _ := hex.DecodeString("8f43288ad272f3103b6fb1428485ea3014c0bcfe568e3670fc5d8cbbe394bd31")
+
+	// when
+	actual, err := parsePinnedCertificates([]string{pinnedCertificate, hex.EncodeToString(expected)})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{expected, expected}, actual)
+}
+
+func TestNewDbDialector(t *testing.T) {
+	var tests = []struct {
+		name string
+		tls  types.DbTls
+	}{
+		{name: "Default"},
+		{name: "Pinned", tls: types.DbTls{Mode: "require", PinnedCertificates: []string{pinnedCertificate}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := newDbDialector(types.Db{Host: "127.0.0.1", Name: "mirror_node", Port: 5432, Tls: tt.tls})
+
+			// then
+			assert.NoError(t, err)
+			assert.NotNil(t, actual)
+		})
+	}
+}
+
+func TestNewDbDialectorInvalidTls(t *testing.T) {
+	// when
+	actual, err := newDbDialector(types.Db{Tls: types.DbTls{Mode: "prefer"}})
+
+	// then
+	assert.Error(t, err)
+	assert.Nil(t, actual)
+}
+
+func newClientCertificateFiles(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		NotAfter:     time.Now().Add(time.Hour),
+		NotBefore:    time.Now(),
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "mirror_rosetta"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+		0600))
+	return certFile, keyFile
+}
@@ -0,0 +1,77 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package bootstrap
+
+import (
+	"encoding/hex"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/memory"
+	log "github.com/sirupsen/logrus"
+)
+
+// DevCommand is the name of the command which serves the rosetta API with the in-memory demo data
+const DevCommand = "dev"
+
+// devBlockCount is the number of blocks following the genesis block in the demo data
+const devBlockCount = 100
+
+// newDevRepositories creates the repositories of the in-memory store, the transaction metadata has the RFC3339
+// timestamp strings if timestampStrings is true
+func newDevRepositories(store *memory.Store, timestampStrings bool) Repositories {
+	return Repositories{
+		Account:          memory.NewAccountRepository(store),
+		AddressBook:      memory.NewAddressBookRepository(store),
+		AddressBookEntry: memory.NewAddressBookEntryRepository(store),
+		Block:            memory.NewBlockRepository(store),
+		ExchangeRate:     memory.NewExchangeRateRepository(store),
+		FeeSchedule:      memory.NewFeeScheduleRepository(store),
+		NetworkVersion:   memory.NewNetworkVersionRepository(store),
+		Nft:              memory.NewNftRepository(store),
+		Schedule:         memory.NewScheduleRepository(store),
+		Token:            memory.NewTokenRepository(store),
+		TokenAssociation: memory.NewTokenAssociationRepository(store),
+		Transaction:      memory.NewTransactionRepository(store, timestampStrings),
+	}
+}
+
+// Dev serves the rosetta API in online mode with the in-memory demo data instead of the mirror node database, so a
+// wallet can be integrated without running the importer and the database. The keys of the funded demo accounts are
+// logged at start. It exits the process if the server fails to start
+func (s *Server) Dev() {
+	configLogger("info", s.logOutput)
+
+	rosettaConfig, err := LoadConfig()
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+	rosettaConfig.Online = true
+
+	store := memory.NewDemoStore(devBlockCount)
+	for _, account := range store.Accounts() {
+		log.Infof("Demo account %s with ed25519 private key %s", account.Account.String(),
+			hex.EncodeToString(account.PrivateKey.Seed()))
+	}
+
+	s.config = rosettaConfig
+	s.repositories = newDevRepositories(store, rosettaConfig.TimestampStrings)
+	log.Warn("Serving the in-memory demo data, don't use it in production")
+	log.Fatal(s.Serve())
+}
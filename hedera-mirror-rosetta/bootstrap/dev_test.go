@@ -0,0 +1,208 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package bootstrap
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"fmt"
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/memory"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDevRepositories(t *testing.T) {
+	repos := newDevRepositories(memory.NewDemoStore(1), false)
+	assert.True(t, repos.isComplete())
+}
+
+func TestServeDevRepositories(t *testing.T) {
+	// given
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+	rosettaConfig := &types.Rosetta{
+		Construction: types.Construction{Broadcast: types.Broadcast{Type: "grpc"}},
+		Log:          types.Log{Level: "info"},
+		Network:      "testnet",
+		Online:       true,
+		Realm:        "0",
+		Shard:        "0",
+	}
+	server := New(
+		rosettaConfig,
+		WithRepositories(newDevRepositories(memory.NewDemoStore(3), false)),
+		WithListener(listener),
+		WithLogOutput(ioutil.Discard),
+	)
+	go func() { _ = server.Serve() }()
+	body := `{"network_identifier": {"blockchain": "Hedera", "network": "testnet", ` +
+		`"sub_network_identifier": {"network": "shard 0 realm 0"}}, "block_identifier": {"index": 1}}`
+
+	// when
+	response, err := http.Post("http://"+listener.Addr().String()+"/block", "application/json",
+		bytes.NewBufferString(body))
+
+	// then
+	assert.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	blockResponse := &rTypes.BlockResponse{}
+	assert.NoError(t, json.NewDecoder(response.Body).Decode(blockResponse))
+	assert.Equal(t, int64(1), blockResponse.Block.BlockIdentifier.Index)
+	assert.Len(t, blockResponse.Block.Transactions, 1)
+}
+
+func TestServeDevSubmissionsUnauthenticated(t *testing.T) {
+	tests := []struct {
+		name     string
+		auth     types.ConstructionAuth
+		expected *rTypes.Error
+		status   int
+	}{
+		{
+			name:     "AuthEnabled",
+			auth:     types.ConstructionAuth{ApiKeys: []string{"key"}, Enabled: true},
+			expected: errors.ErrUnauthorized,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			name:     "AuthDisabled",
+			expected: errors.ErrNotImplemented,
+			status:   http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			assert.NoError(t, err)
+			defer listener.Close()
+			rosettaConfig := &types.Rosetta{
+				Construction: types.Construction{
+					Auth:      tt.auth,
+					Broadcast: types.Broadcast{Type: "grpc"},
+					Journal: types.Journal{
+						Enabled:    true,
+						MaxEntries: 10,
+						Path:       filepath.Join(t.TempDir(), "submissions.jsonl"),
+					},
+				},
+				Log:     types.Log{Level: "info"},
+				Network: "testnet",
+				Online:  true,
+				Realm:   "0",
+				Shard:   "0",
+			}
+			server := New(
+				rosettaConfig,
+				WithRepositories(newDevRepositories(memory.NewDemoStore(3), false)),
+				WithListener(listener),
+				WithLogOutput(ioutil.Discard),
+			)
+			go func() { _ = server.Serve() }()
+			body := `{"network_identifier": {"blockchain": "Hedera", "network": "testnet", ` +
+				`"sub_network_identifier": {"network": "shard 0 realm 0"}}, "method": "submissions", "parameters": {}}`
+
+			// when
+			response, err := http.Post("http://"+listener.Addr().String()+"/call", "application/json",
+				bytes.NewBufferString(body))
+
+			// then
+			assert.NoError(t, err)
+			defer response.Body.Close()
+			assert.Equal(t, tt.status, response.StatusCode)
+			actual := &rTypes.Error{}
+			assert.NoError(t, json.NewDecoder(response.Body).Decode(actual))
+			assert.Equal(t, tt.expected.Code, actual.Code)
+		})
+	}
+}
+
+func TestServeDevConstructionPreprocessExistenceCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		receiver string
+		expected *rTypes.Error
+		status   int
+	}{
+		{name: "DemoAccount", receiver: "0.0.1002", status: http.StatusOK},
+		{
+			name:     "UnknownAccount",
+			receiver: "0.0.9999",
+			expected: errors.ErrWrongNetwork,
+			status:   http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			assert.NoError(t, err)
+			defer listener.Close()
+			rosettaConfig := &types.Rosetta{
+				Construction: types.Construction{Broadcast: types.Broadcast{Type: "grpc"}, ExistenceCheck: true},
+				Log:          types.Log{Level: "info"},
+				Network:      "testnet",
+				Online:       true,
+				Realm:        "0",
+				Shard:        "0",
+			}
+			server := New(
+				rosettaConfig,
+				WithRepositories(newDevRepositories(memory.NewDemoStore(3), false)),
+				WithListener(listener),
+				WithLogOutput(ioutil.Discard),
+			)
+			go func() { _ = server.Serve() }()
+			operation := `{"operation_identifier": {"index": %d}, "type": "CRYPTOTRANSFER", ` +
+				`"account": {"address": "%s"}, "amount": {"value": "%s", ` +
+				`"currency": {"symbol": "HBAR", "decimals": 8, "metadata": {"issuer": "Hedera"}}}}`
+			body := `{"network_identifier": {"blockchain": "Hedera", "network": "testnet", ` +
+				`"sub_network_identifier": {"network": "shard 0 realm 0"}}, "operations": [` +
+				fmt.Sprintf(operation, 0, "0.0.1001", "-5") + `, ` + fmt.Sprintf(operation, 1, tt.receiver, "5") + `]}`
+
+			// when
+			response, err := http.Post("http://"+listener.Addr().String()+"/construction/preprocess",
+				"application/json", bytes.NewBufferString(body))
+
+			// then
+			assert.NoError(t, err)
+			defer response.Body.Close()
+			assert.Equal(t, tt.status, response.StatusCode)
+			if tt.expected != nil {
+				actual := &rTypes.Error{}
+				assert.NoError(t, json.NewDecoder(response.Body).Decode(actual))
+				assert.Equal(t, tt.expected.Code, actual.Code)
+			}
+		})
+	}
+}
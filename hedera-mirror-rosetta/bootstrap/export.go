@@ -0,0 +1,183 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package bootstrap
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/encoder"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/exchangerate"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/transaction"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/base"
+	blockService "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/block"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/types"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// ExportCommand is the name of the command which exports a range of blocks
+const ExportCommand = "export"
+
+// exportOptions are the command line options of the export command
+type exportOptions struct {
+	from   int64
+	output string
+	to     int64
+}
+
+// parseExportArgs parses the command line arguments of the export command
+func parseExportArgs(args []string) (*exportOptions, error) {
+	options := &exportOptions{}
+	flagSet := flag.NewFlagSet(ExportCommand, flag.ContinueOnError)
+	flagSet.Int64Var(&options.from, "from", -1, "index of the first block to export")
+	flagSet.Int64Var(&options.to, "to", -1, "index of the last block to export")
+	flagSet.StringVar(&options.output, "output", "", "file to write the blocks to, stdout if not set")
+
+	if err := flagSet.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if flagSet.NArg() != 0 {
+		return nil, fmt.Errorf("unexpected arguments %v", flagSet.Args())
+	}
+
+	if options.from < 0 || options.to < options.from {
+		return nil, errors.New("--from and --to must be set with 0 <= from <= to")
+	}
+
+	return options, nil
+}
+
+// exportBlocks writes the blocks with the index in [from, to] as newline-delimited JSON to w
+func exportBlocks(
+	ctx context.Context,
+	blockAPIService server.BlockAPIServicer,
+	network *rTypes.NetworkIdentifier,
+	from int64,
+	to int64,
+	w io.Writer,
+) error {
+	blockEncoder := encoder.NewCanonicalJSONEncoder(encoder.NewStdJSONEncoder())
+	for index := from; index <= to; index++ {
+		blockIndex := index
+		response, rErr := blockAPIService.Block(ctx, &rTypes.BlockRequest{
+			NetworkIdentifier: network,
+			BlockIdentifier:   &rTypes.PartialBlockIdentifier{Index: &blockIndex},
+		})
+		if rErr != nil {
+			return fmt.Errorf("failed to get block %d: %s", index, rErr.Message)
+		}
+
+		if err := blockEncoder.Encode(w, response.Block); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newBatchBlockService creates the block service of the export and the backfill commands on the mirror node database,
+// along with the base service to look up the blocks. The blocks are presented with the settings
+func newBatchBlockService(rosettaConfig *types.Rosetta, settings *config.Settings) (
+	server.BlockAPIServicer,
+	base.BaseService,
+	error,
+) {
+	dbClient, err := connectToDb(rosettaConfig.Db)
+	if err != nil {
+		return nil, base.BaseService{}, fmt.Errorf("failed to connect to the database: %w", err)
+	}
+	blockConfig := rosettaConfig.Block
+	rawQueries := rosettaConfig.Db.RawQueries
+	blockRepo := newBlockRepository(dbClient, blockConfig, rawQueries)
+	baseService := base.NewBaseService(blockRepo, transaction.NewTransactionRepository(dbClient,
+		blockConfig.FailedAmounts, settings.TimestampStrings))
+
+	var exchangeRateRepo repositories.ExchangeRateRepository
+	if blockConfig.ExchangeRate {
+		exchangeRateRepo = exchangerate.NewExchangeRateRepository(dbClient)
+	}
+	// a batch has every transaction in its block, so there is no limit on the operations of a block
+	blockAPIService, err := blockService.NewBlockAPIService(baseService, exchangeRateRepo, blockConfig.OmitZeroAmounts, 0,
+		blockConfig.AuditMode, settings)
+	if err != nil {
+		return nil, base.BaseService{}, fmt.Errorf("failed to create the block service: %w", err)
+	}
+
+	return blockAPIService, baseService, nil
+}
+
+// Export loads the configuration and writes the blocks in the range given by args as newline-delimited JSON, using the
+// same block service as the /block endpoint. args are the command line arguments following the export command, e.g.,
+// "--from 0 --to 100 --output blocks.json". It exits the process if the export fails
+func (s *Server) Export(args []string) {
+	options, err := parseExportArgs(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(2)
+	}
+
+	// keep stdout for the blocks
+	configLogger("info", os.Stderr)
+	rosettaConfig, err := LoadConfig()
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+	network, settings, err := applyConfig(rosettaConfig, os.Stderr)
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+
+	blockAPIService, _, err := newBatchBlockService(rosettaConfig, settings)
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+
+	output := os.Stdout
+	if options.output != "" {
+		if output, err = os.Create(options.output); err != nil {
+			log.Fatalf("Failed to create the output file: %s", err)
+		}
+	}
+
+	writer := bufio.NewWriter(output)
+	err = exportBlocks(context.Background(), blockAPIService, network, options.from, options.to, writer)
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err == nil && output != os.Stdout {
+		err = output.Close()
+	}
+	if err != nil {
+		log.Fatalf("Failed to export blocks: %s", err)
+	}
+
+	log.Infof("Exported blocks %d to %d", options.from, options.to)
+}
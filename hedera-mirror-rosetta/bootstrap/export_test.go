@@ -0,0 +1,124 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	rTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubBlockAPIService struct {
+	latest int64
+}
+
+func (s *stubBlockAPIService) Block(
+	_ context.Context,
+	request *rTypes.BlockRequest,
+) (*rTypes.BlockResponse, *rTypes.Error) {
+	index := *request.BlockIdentifier.Index
+	if index > s.latest {
+		return nil, errors.ErrBlockNotFound
+	}
+
+	return &rTypes.BlockResponse{
+		Block: &rTypes.Block{
+			BlockIdentifier: &rTypes.BlockIdentifier{Index: index, Hash: "0x0a"},
+			Transactions:    []*rTypes.Transaction{},
+		},
+	}, nil
+}
+
+func (s *stubBlockAPIService) BlockTransaction(
+	context.Context,
+	*rTypes.BlockTransactionRequest,
+) (*rTypes.BlockTransactionResponse, *rTypes.Error) {
+	return nil, errors.ErrNotImplemented
+}
+
+func TestParseExportArgs(t *testing.T) {
+	// when
+	options, err := parseExportArgs([]string{"--from", "10", "--to", "20", "--output", "blocks.json"})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, &exportOptions{from: 10, output: "blocks.json", to: 20}, options)
+}
+
+func TestParseExportArgsInvalid(t *testing.T) {
+	tests := [][]string{
+		{},
+		{"--from", "10"},
+		{"--to", "10"},
+		{"--from", "20", "--to", "10"},
+		{"--from", "-2", "--to", "10"},
+		{"--from", "a", "--to", "10"},
+		{"--from", "1", "--to", "10", "extra"},
+		{"--unknown", "1"},
+	}
+
+	for _, args := range tests {
+		t.Run(fmt.Sprintf("%v", args), func(t *testing.T) {
+			// when
+			options, err := parseExportArgs(args)
+
+			// then
+			assert.Error(t, err)
+			assert.Nil(t, options)
+		})
+	}
+}
+
+func TestExportBlocks(t *testing.T) {
+	// given
+	buf := &bytes.Buffer{}
+
+	// when
+	err := exportBlocks(context.Background(), &stubBlockAPIService{latest: 5}, nil, 1, 2, buf)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`{"block_identifier":{"index":1,"hash":"0x0a"},"parent_block_identifier":null,"timestamp":0,`+
+			`"transactions":[]}`+"\n"+
+			`{"block_identifier":{"index":2,"hash":"0x0a"},"parent_block_identifier":null,"timestamp":0,`+
+			`"transactions":[]}`+"\n",
+		buf.String(),
+	)
+}
+
+func TestExportBlocksNotFound(t *testing.T) {
+	// given
+	buf := &bytes.Buffer{}
+
+	// when
+	err := exportBlocks(context.Background(), &stubBlockAPIService{latest: 1}, nil, 1, 2, buf)
+
+	// then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "block 2")
+}
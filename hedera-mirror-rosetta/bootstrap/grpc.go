@@ -0,0 +1,71 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package bootstrap
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/types"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// newGrpcServer creates the gRPC server of the data endpoints. It shares the certificate and the client CA of the
+// HTTP server when TLS is enabled
+func newGrpcServer(httpTlsConfig types.HttpTls) (*grpc.Server, error) {
+	if !httpTlsConfig.Enabled {
+		return grpc.NewServer(), nil
+	}
+
+	tlsConfig, err := newTlsConfig(httpTlsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	certificate, err := tls.LoadX509KeyPair(httpTlsConfig.CertFile, httpTlsConfig.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the TLS certificate: %w", err)
+	}
+	tlsConfig.Certificates = []tls.Certificate{certificate}
+
+	return grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig))), nil
+}
+
+// serveGrpc listens on the port and serves the gRPC server in the background. The HTTP server keeps serving if the
+// gRPC server stops, so the error is only logged
+func serveGrpc(grpcServer *grpc.Server, port uint16) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return fmt.Errorf("failed to listen for gRPC: %w", err)
+	}
+
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Errorf("The gRPC server stopped: %s", err)
+		}
+	}()
+
+	log.Infof("Serving gRPC API on port %d", port)
+	return nil
+}
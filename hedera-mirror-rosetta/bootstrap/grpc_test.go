@@ -0,0 +1,76 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package bootstrap
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGrpcServer(t *testing.T) {
+	certFile, keyFile := newClientCertificateFiles(t, t.TempDir())
+	var tests = []struct {
+		name      string
+		tlsConfig types.HttpTls
+	}{
+		{name: "NoTls"},
+		{name: "Tls", tlsConfig: types.HttpTls{CertFile: certFile, Enabled: true, KeyFile: keyFile}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			grpcServer, err := newGrpcServer(tt.tlsConfig)
+
+			// then
+			assert.NoError(t, err)
+			assert.NotNil(t, grpcServer)
+		})
+	}
+}
+
+func TestNewGrpcServerInvalidTls(t *testing.T) {
+	var tests = []struct {
+		name      string
+		tlsConfig types.HttpTls
+	}{
+		{name: "NoKeyFile", tlsConfig: types.HttpTls{CertFile: "cert.pem", Enabled: true}},
+		{name: "MissingFiles", tlsConfig: types.HttpTls{
+			CertFile: filepath.Join(t.TempDir(), "cert.pem"),
+			Enabled:  true,
+			KeyFile:  filepath.Join(t.TempDir(), "key.pem"),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			grpcServer, err := newGrpcServer(tt.tlsConfig)
+
+			// then
+			assert.Error(t, err)
+			assert.Nil(t, grpcServer)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package bootstrap
+
+import (
+	"io"
+	"net"
+	"time"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/repositories"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/account"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/addressbook"
+	addressBookEntry "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/addressbook/entry"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/block"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/exchangerate"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/feeschedule"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/nft"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/schedule"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/token"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/tokenassociation"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/transaction"
+	networkVersion "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/version"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/types"
+	"gorm.io/gorm"
+)
+
+// Option configures the server created by New
+type Option func(*Server)
+
+// Repositories are the repositories of the online mode
+type Repositories struct {
+	Account          repositories.AccountRepository
+	AddressBook      repositories.AddressBookRepository
+	AddressBookEntry repositories.AddressBookEntryRepository
+	Block            repositories.BlockRepository
+	ExchangeRate     repositories.ExchangeRateRepository
+	FeeSchedule      repositories.FeeScheduleRepository
+	NetworkVersion   repositories.NetworkVersionRepository
+	Nft              repositories.NftRepository
+	Schedule         repositories.ScheduleRepository
+	Token            repositories.TokenRepository
+	TokenAssociation repositories.TokenAssociationRepository
+	Transaction      repositories.TransactionRepository
+}
+
+// isComplete returns true if none of the repositories is nil
+func (r *Repositories) isComplete() bool {
+	return r.Account != nil && r.AddressBook != nil && r.AddressBookEntry != nil && r.Block != nil &&
+		r.ExchangeRate != nil && r.FeeSchedule != nil && r.NetworkVersion != nil && r.Nft != nil &&
+		r.Schedule != nil && r.Token != nil && r.TokenAssociation != nil && r.Transaction != nil
+}
+
+// setDefaults creates the nil repositories on the mirror node database, the transaction metadata has the RFC3339
+// timestamp strings if timestampStrings is true
+func (r *Repositories) setDefaults(
+	dbClient *gorm.DB,
+	accountConfig types.Account,
+	blockConfig types.Block,
+	rawQueries bool,
+	timestampStrings bool,
+) {
+	if r.Account == nil {
+		r.Account = account.NewAccountRepository(
+			dbClient,
+			rawQueries,
+			accountConfig.BalanceWorkers,
+			accountConfig.RollingBalance.Enabled,
+		)
+	}
+	if r.AddressBook == nil {
+		r.AddressBook = addressbook.NewAddressBookRepository(dbClient)
+	}
+	if r.AddressBookEntry == nil {
+		r.AddressBookEntry = addressBookEntry.NewAddressBookEntryRepository(dbClient)
+	}
+	if r.Block == nil {
+		r.Block = newBlockRepository(dbClient, blockConfig, rawQueries)
+	}
+	if r.ExchangeRate == nil {
+		r.ExchangeRate = exchangerate.NewExchangeRateRepository(dbClient)
+	}
+	if r.FeeSchedule == nil {
+		r.FeeSchedule = feeschedule.NewFeeScheduleRepository(dbClient)
+	}
+	if r.NetworkVersion == nil {
+		r.NetworkVersion = networkVersion.NewNetworkVersionRepository(dbClient)
+	}
+	if r.Nft == nil {
+		r.Nft = nft.NewNftRepository(dbClient)
+	}
+	if r.Schedule == nil {
+		r.Schedule = schedule.NewScheduleRepository(dbClient)
+	}
+	if r.Token == nil {
+		r.Token = token.NewTokenRepository(dbClient)
+	}
+	if r.TokenAssociation == nil {
+		r.TokenAssociation = tokenassociation.NewTokenAssociationRepository(dbClient)
+	}
+	if r.Transaction == nil {
+		r.Transaction = transaction.NewTransactionRepository(dbClient, blockConfig.FailedAmounts, timestampStrings)
+	}
+}
+
+// newBlockRepository creates the block repository with a block per record file, or a block per fixed time window if
+// the block window is configured
+func newBlockRepository(dbClient *gorm.DB, blockConfig types.Block, rawQueries bool) repositories.BlockRepository {
+	latestCacheTtl := time.Duration(blockConfig.LatestCacheTtl) * time.Millisecond
+	if blockConfig.Window > 0 {
+		window := time.Duration(blockConfig.Window) * time.Millisecond
+		return block.NewWindowBlockRepository(dbClient, latestCacheTtl, rawQueries, window)
+	}
+
+	return block.NewBlockRepository(dbClient, latestCacheTtl, rawQueries, blockConfig.HashPrefixMinLength)
+}
+
+// WithRepositories replaces the repositories of the online mode with the non-nil ones in repos, the others are still
+// created on the mirror node database. The database isn't connected to when all the repositories are given, then the
+// block notification is disabled
+func WithRepositories(repos Repositories) Option {
+	return func(s *Server) {
+		s.repositories = repos
+	}
+}
+
+// WithLogOutput sets the output of the logrus standard logger, which the server logs with, stdout by default
+func WithLogOutput(output io.Writer) Option {
+	return func(s *Server) {
+		s.logOutput = output
+	}
+}
+
+// WithListener serves the rosetta API on the listener instead of the configured port, e.g., an in-memory listener in
+// tests. The server closes the listener when it stops
+func WithListener(listener net.Listener) Option {
+	return func(s *Server) {
+		s.listener = listener
+	}
+}
@@ -0,0 +1,47 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package bootstrap
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterPprofHandlers(t *testing.T) {
+	// given
+	mux := http.NewServeMux()
+	registerPprofHandlers(mux)
+
+	for _, path := range []string{pprofPath, pprofPath + "cmdline", pprofPath + "heap"} {
+		t.Run(path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+
+			// when
+			mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+			// then
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		})
+	}
+}
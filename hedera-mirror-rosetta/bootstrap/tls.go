@@ -0,0 +1,58 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package bootstrap
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/types"
+)
+
+// newTlsConfig creates the TLS config of the server. With a client CA file, the server requests a client certificate
+// and verifies it against the CA if one is given, so the data endpoints stay available to clients without one
+func newTlsConfig(tlsConfig types.HttpTls) (*tls.Config, error) {
+	if tlsConfig.CertFile == "" || tlsConfig.KeyFile == "" {
+		return nil, fmt.Errorf("TLS requires both the certificate file and the key file")
+	}
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if tlsConfig.ClientCaFile == "" {
+		return config, nil
+	}
+
+	// Disable gosec since the CA file is configured by the operator
+	caPem, err := ioutil.ReadFile(tlsConfig.ClientCaFile) // #nosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the client CA file %s: %w", tlsConfig.ClientCaFile, err)
+	}
+
+	clientCas := x509.NewCertPool()
+	if !clientCas.AppendCertsFromPEM(caPem) {
+		return nil, fmt.Errorf("no certificate found in the client CA file %s", tlsConfig.ClientCaFile)
+	}
+
+	config.ClientAuth = tls.VerifyClientCertIfGiven
+	config.ClientCAs = clientCas
+	return config, nil
+}
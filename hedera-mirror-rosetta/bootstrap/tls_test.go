@@ -0,0 +1,114 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package bootstrap
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTlsConfig(t *testing.T) {
+	// given
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, ioutil.WriteFile(caFile, newCaPem(t), 0600))
+
+	// when
+	actual, err := newTlsConfig(types.HttpTls{CertFile: "cert.pem", ClientCaFile: caFile, KeyFile: "key.pem"})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, tls.VerifyClientCertIfGiven, actual.ClientAuth)
+	assert.NotNil(t, actual.ClientCAs)
+}
+
+func TestNewTlsConfigNoClientCa(t *testing.T) {
+	// when
+	actual, err := newTlsConfig(types.HttpTls{CertFile: "cert.pem", KeyFile: "key.pem"})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, tls.NoClientCert, actual.ClientAuth)
+	assert.Nil(t, actual.ClientCAs)
+}
+
+func TestNewTlsConfigInvalid(t *testing.T) {
+	invalidCaFile := filepath.Join(t.TempDir(), "invalid.pem")
+	require.NoError(t, ioutil.WriteFile(invalidCaFile, []byte("not a certificate"), 0600))
+
+	var tests = []struct {
+		name      string
+		tlsConfig types.HttpTls
+	}{
+		{name: "NoCertFile", tlsConfig: types.HttpTls{KeyFile: "key.pem"}},
+		{name: "NoKeyFile", tlsConfig: types.HttpTls{CertFile: "cert.pem"}},
+		{
+			name:      "MissingClientCaFile",
+			tlsConfig: types.HttpTls{CertFile: "cert.pem", ClientCaFile: "missing.pem", KeyFile: "key.pem"},
+		},
+		{
+			name:      "InvalidClientCaFile",
+			tlsConfig: types.HttpTls{CertFile: "cert.pem", ClientCaFile: invalidCaFile, KeyFile: "key.pem"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := newTlsConfig(tt.tlsConfig)
+
+			// then
+			assert.Error(t, err)
+			assert.Nil(t, actual)
+		})
+	}
+}
+
+func newCaPem(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		NotAfter:              time.Now().Add(time.Hour),
+		NotBefore:             time.Now(),
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "rosetta test ca"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
@@ -0,0 +1,259 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package bootstrap
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/canary"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/domain/mapper"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/metrics"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/middleware"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/base"
+	blockService "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/block"
+	constructionService "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/services/construction"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/config"
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/types"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// ValidateConfigCommand is the name of the command which validates the configuration
+const ValidateConfigCommand = "validate-config"
+
+// checkConfig returns the problems of the configuration found without connecting to the database or the nodes
+func checkConfig(rosettaConfig *types.Rosetta) []error {
+	var problems []error
+	addProblem := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Errorf(format, args...))
+	}
+
+	if _, err := log.ParseLevel(strings.ToLower(rosettaConfig.Log.Level)); err != nil {
+		addProblem("invalid log level %q", rosettaConfig.Log.Level)
+	}
+
+	if rosettaConfig.Port == 0 {
+		addProblem("the port must be set")
+	}
+
+	if grpcConfig := rosettaConfig.Grpc; grpcConfig.Enabled && (grpcConfig.Port == 0 ||
+		grpcConfig.Port == rosettaConfig.Port) {
+		addProblem("the gRPC port must be set and differ from the port")
+	}
+
+	settings, err := newSettings(rosettaConfig)
+	if err != nil {
+		addProblem("invalid construction config: %s", err)
+		// keep checking the rest of the config with the default settings
+		settings = config.NewSettings()
+	}
+
+	// the reachability of the nodes is checked separately, so don't let the gRPC pool connect to them
+	constructionConfig := rosettaConfig.Construction
+	constructionConfig.Broadcast.Grpc.PoolSize = 0
+	network := strings.ToLower(rosettaConfig.Network)
+	if _, err := newBlockchainOfflineRouter(network, rosettaConfig.Nodes, nil, constructionConfig,
+		metrics.NewRegistry(), settings); err != nil {
+		addProblem("invalid construction config: %s", err)
+	}
+
+	if _, err := blockService.NewBlockAPIService(base.NewBaseService(nil, nil), nil, false, 0,
+		rosettaConfig.Block.AuditMode, settings); err != nil {
+		addProblem("invalid block config: %s", err)
+	}
+
+	if err := mapper.CheckFailedAmounts(rosettaConfig.Block.FailedAmounts); err != nil {
+		addProblem("invalid block config: %s", err)
+	}
+
+	if streamConfig := rosettaConfig.Block.Stream; streamConfig.Enabled {
+		if !rosettaConfig.Block.Notification.Enabled {
+			addProblem("the block stream requires the block notification")
+		}
+
+		if streamConfig.BufferSize <= 0 || streamConfig.KeepAliveInterval <= 0 {
+			addProblem("the block stream buffer size and keep alive interval must be positive")
+		}
+	}
+
+	if canaryConfig := rosettaConfig.Canary; canaryConfig.Enabled {
+		if _, err := canary.New(base.BaseService{}, nil, nil, canaryConfig, nil, settings); err != nil {
+			addProblem("invalid canary config: %s", err)
+		}
+	}
+
+	if rosettaConfig.Online {
+		if err := checkDbTls(rosettaConfig.Db.Tls); err != nil {
+			addProblem("invalid db tls config: %s", err)
+		}
+
+		if integrityCheck := rosettaConfig.Db.IntegrityCheck; integrityCheck.Enabled && integrityCheck.SampleSize <= 0 {
+			addProblem("the db integrity check sample size must be positive")
+		}
+
+		if journalConfig := rosettaConfig.Construction.Journal; journalConfig.Enabled && journalConfig.MaxEntries <= 0 {
+			addProblem("the construction journal max entries must be positive")
+		}
+	}
+
+	httpConfig := rosettaConfig.Http
+	handler := http.NotFoundHandler()
+	if auth := rosettaConfig.Construction.Auth; auth.Enabled {
+		if _, err := middleware.AuthMiddleware(handler, auth.ApiKeys, auth.ClientCertificates); err != nil {
+			addProblem("invalid construction auth config: %s", err)
+		}
+
+		if auth.ClientCertificates && (!httpConfig.Tls.Enabled || httpConfig.Tls.ClientCaFile == "") {
+			addProblem("client certificate authentication requires TLS with a client CA file")
+		}
+	}
+
+	if compression := httpConfig.Compression; compression.Enabled {
+		if _, err := middleware.CompressionMiddleware(handler, compression.Level, compression.MinSize); err != nil {
+			addProblem("invalid http compression config: %s", err)
+		}
+	}
+
+	if cors := httpConfig.Cors; cors.Enabled {
+		if _, err := middleware.CorsMiddleware(handler, cors.AllowedOrigins, cors.AllowedHeaders,
+			cors.MaxAge); err != nil {
+			addProblem("invalid http cors config: %s", err)
+		}
+	}
+
+	if tlsConfig := httpConfig.Tls; tlsConfig.Enabled {
+		if _, err := newTlsConfig(tlsConfig); err != nil {
+			addProblem("invalid http tls config: %s", err)
+		} else if _, err = tls.LoadX509KeyPair(tlsConfig.CertFile, tlsConfig.KeyFile); err != nil {
+			addProblem("failed to load the TLS certificate and key: %s", err)
+		}
+	}
+
+	return problems
+}
+
+// checkDb returns an error if the database can't be connected to
+func checkDb(dbConfig types.Db) error {
+	dialector, err := newDbDialector(dbConfig)
+	if err != nil {
+		return err
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		return fmt.Errorf("failed to connect to the database at %s:%d: %w", dbConfig.Host, dbConfig.Port, err)
+	}
+
+	sqlDb, err := db.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDb.Close()
+
+	if err = sqlDb.Ping(); err != nil {
+		return fmt.Errorf("failed to connect to the database at %s:%d: %w", dbConfig.Host, dbConfig.Port, err)
+	}
+
+	return nil
+}
+
+// checkNodes returns a problem for each node which doesn't accept a TCP connection within the timeout. Without
+// configured nodes, the nodes of the network known to the SDK are checked
+func checkNodes(network string, nodes types.NodeMap, timeout time.Duration) []error {
+	if len(nodes) == 0 {
+		if network == "demo" {
+			network = "testnet"
+		}
+
+		client, err := hedera.ClientForName(network)
+		if err != nil {
+			return []error{err}
+		}
+		nodes = client.GetNetwork()
+	}
+
+	var lock sync.Mutex
+	var problems []error
+	var wg sync.WaitGroup
+	for address, nodeAccountId := range nodes {
+		wg.Add(1)
+		go func(address string, nodeAccountId hedera.AccountID) {
+			defer wg.Done()
+			conn, err := net.DialTimeout("tcp", address, timeout)
+			if err != nil {
+				lock.Lock()
+				defer lock.Unlock()
+				problems = append(problems, fmt.Errorf("node %s at %s is unreachable: %w", nodeAccountId, address, err))
+				return
+			}
+			_ = conn.Close()
+		}(address, nodeAccountId)
+	}
+	wg.Wait()
+
+	sort.Slice(problems, func(i, j int) bool { return problems[i].Error() < problems[j].Error() })
+	return problems
+}
+
+// ValidateConfig loads the configuration and reports its problems, including the connectivity to the database and
+// the reachability of the nodes in online mode. It exits the process with a non-zero status if any problem is found
+func (s *Server) ValidateConfig() {
+	configLogger("info", os.Stdout)
+
+	rosettaConfig, err := LoadConfig()
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+
+	problems := checkConfig(rosettaConfig)
+	if rosettaConfig.Online {
+		if err = checkDb(rosettaConfig.Db); err != nil {
+			problems = append(problems, err)
+		}
+
+		broadcast := rosettaConfig.Construction.Broadcast
+		if broadcast.Type == "" || broadcast.Type == constructionService.BroadcastTypeGrpc {
+			timeout := time.Duration(broadcast.Grpc.ConnectTimeout) * time.Millisecond
+			problems = append(problems, checkNodes(strings.ToLower(rosettaConfig.Network), rosettaConfig.Nodes,
+				timeout)...)
+		}
+	}
+
+	for _, problem := range problems {
+		log.Error(problem)
+	}
+
+	if len(problems) != 0 {
+		log.Fatalf("Found %d problems in the configuration", len(problems))
+	}
+
+	log.Info("The configuration is valid")
+}
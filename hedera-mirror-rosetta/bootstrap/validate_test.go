@@ -0,0 +1,161 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package bootstrap
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/types"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func newValidRosettaConfig() *types.Rosetta {
+	return &types.Rosetta{
+		Construction: types.Construction{
+			Broadcast: types.Broadcast{Type: "grpc"},
+			ParseMode: "lenient",
+		},
+		Http: types.Http{
+			Compression: types.HttpCompression{Enabled: true, Level: 6, MinSize: 1024},
+			Cors:        types.HttpCors{AllowedOrigins: []string{"*"}, Enabled: true},
+		},
+		Log:     types.Log{Level: "info"},
+		Network: "testnet",
+		Port:    5700,
+	}
+}
+
+func TestCheckConfig(t *testing.T) {
+	assert.Empty(t, checkConfig(newValidRosettaConfig()))
+}
+
+func TestCheckConfigProblems(t *testing.T) {
+	tests := []struct {
+		name   string
+		update func(*types.Rosetta)
+	}{
+		{name: "log level", update: func(c *types.Rosetta) { c.Log.Level = "verbose" }},
+		{name: "port", update: func(c *types.Rosetta) { c.Port = 0 }},
+		{name: "grpc port", update: func(c *types.Rosetta) { c.Grpc = types.Grpc{Enabled: true, Port: c.Port} }},
+		{name: "network", update: func(c *types.Rosetta) { c.Network = "unknown" }},
+		{name: "audit mode", update: func(c *types.Rosetta) { c.Block.AuditMode = "strict" }},
+		{name: "failed amounts", update: func(c *types.Rosetta) { c.Block.FailedAmounts = "fee" }},
+		{name: "block stream notification", update: func(c *types.Rosetta) {
+			c.Block.Notification.Enabled = false
+			c.Block.Stream = types.BlockStream{BufferSize: 16, Enabled: true, KeepAliveInterval: 15000}
+		}},
+		{name: "block stream buffer size", update: func(c *types.Rosetta) {
+			c.Block.Notification.Enabled = true
+			c.Block.Stream = types.BlockStream{Enabled: true, KeepAliveInterval: 15000}
+		}},
+		{name: "canary operator", update: func(c *types.Rosetta) {
+			c.Canary = types.Canary{Enabled: true, Interval: 300000, OperatorId: "0.0.a", Sla: 30000}
+		}},
+		{name: "parse mode", update: func(c *types.Rosetta) { c.Construction.ParseMode = "loose" }},
+		{name: "broadcast type", update: func(c *types.Rosetta) { c.Construction.Broadcast.Type = "carrier" }},
+		{name: "default payer", update: func(c *types.Rosetta) {
+			c.Construction.DefaultPayer = types.DefaultPayer{AccountId: "0.0.0", Enabled: true}
+		}},
+		{name: "valid duration", update: func(c *types.Rosetta) { c.Construction.ValidDuration = 300 }},
+		{name: "valid start backdate", update: func(c *types.Rosetta) { c.Construction.ValidStartBackdate = -1 }},
+		{name: "auth", update: func(c *types.Rosetta) { c.Construction.Auth.Enabled = true }},
+		{name: "auth client certificates", update: func(c *types.Rosetta) {
+			c.Construction.Auth = types.ConstructionAuth{ClientCertificates: true, Enabled: true}
+		}},
+		{name: "compression", update: func(c *types.Rosetta) { c.Http.Compression.Level = 10 }},
+		{name: "cors", update: func(c *types.Rosetta) { c.Http.Cors.MaxAge = -1 }},
+		{name: "tls", update: func(c *types.Rosetta) { c.Http.Tls.Enabled = true }},
+		{name: "tls missing files", update: func(c *types.Rosetta) {
+			c.Http.Tls = types.HttpTls{CertFile: "missing.crt", Enabled: true, KeyFile: "missing.key"}
+		}},
+		{name: "db integrity check", update: func(c *types.Rosetta) {
+			c.Db.IntegrityCheck.Enabled = true
+			c.Online = true
+		}},
+		{name: "db tls", update: func(c *types.Rosetta) {
+			c.Db.Tls.Mode = "prefer"
+			c.Online = true
+		}},
+		{name: "journal max entries", update: func(c *types.Rosetta) {
+			c.Construction.Journal = types.Journal{Enabled: true, Path: "submissions.jsonl"}
+			c.Online = true
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			rosettaConfig := newValidRosettaConfig()
+			tt.update(rosettaConfig)
+
+			// when
+			problems := checkConfig(rosettaConfig)
+
+			// then
+			assert.Len(t, problems, 1)
+		})
+	}
+}
+
+func TestCheckDb(t *testing.T) {
+	// given
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	_ = listener.Close()
+
+	// when
+	err = checkDb(types.Db{Host: "127.0.0.1", Name: "mirror_node", Port: uint16(port), Username: "mirror_rosetta"})
+
+	// then
+	assert.Error(t, err)
+}
+
+func TestCheckNodes(t *testing.T) {
+	// given
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	closedAddress := closed.Addr().String()
+	_ = closed.Close()
+
+	nodes := types.NodeMap{
+		listener.Addr().String(): hedera.AccountID{Account: 3},
+		closedAddress:            hedera.AccountID{Account: 4},
+	}
+
+	// when
+	problems := checkNodes("testnet", nodes, time.Second)
+
+	// then
+	assert.Len(t, problems, 1)
+	assert.Contains(t, problems[0].Error(), closedAddress)
+}
+
+func TestCheckNodesUnknownNetwork(t *testing.T) {
+	assert.Len(t, checkNodes("unknown", nil, time.Second), 1)
+}
@@ -20,10 +20,17 @@
 
 package config
 
-import "github.com/coinbase/rosetta-sdk-go/types"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
 
 const (
 	OperationTypeCryptoTransfer  = "CRYPTOTRANSFER"
+	OperationTypeScheduleSign    = "SCHEDULESIGN"
 	OperationTypeTokenAssociate  = "TOKENASSOCIATE"
 	OperationTypeTokenBurn       = "TOKENBURN"
 	OperationTypeTokenCreate     = "TOKENCREATION"
@@ -38,18 +45,182 @@ const (
 	OperationTypeTokenWipe       = "TOKENWIPE"
 )
 
+const (
+	CallMethodAddressBook        = "addressbook"
+	CallMethodBlockTimestamp     = "block_timestamp"
+	CallMethodExchangeRate       = "exchangerate"
+	CallMethodFeeSchedule        = "feeschedule"
+	CallMethodNfts               = "nfts"
+	CallMethodNodeHealth         = "nodehealth"
+	CallMethodPrecheck           = "precheck"
+	CallMethodRawTransaction     = "rawtransaction"
+	CallMethodReconcile          = "reconcile"
+	CallMethodScheduleInfo       = "schedule_info"
+	CallMethodSubmissions        = "submissions"
+	CallMethodTokenBalances      = "token_balances"
+	CallMethodTokenInfo          = "tokeninfo"
+	CallMethodTokenRelationships = "tokenrelationships"
+	CallMethodTransaction        = "transaction"
+)
+
 const (
 	Blockchain       = "Hedera"
 	CurrencySymbol   = "HBAR"
 	CurrencyDecimals = 8
 )
 
+const (
+	// DefaultTransactionValidDuration is the valid duration the SDK sets on a constructed transaction
+	DefaultTransactionValidDuration = 120 * time.Second
+	// MaxTransactionValidDuration and MinTransactionValidDuration are the bounds the consensus nodes accept
+	MaxTransactionValidDuration = 180 * time.Second
+	MinTransactionValidDuration = 15 * time.Second
+)
+
 var (
+	// CallMethods is the sorted list of the builtin methods supported by the /call endpoint. It's never modified, a
+	// server serves the methods registered on it along with these
+	CallMethods = []string{
+		CallMethodAddressBook,
+		CallMethodBlockTimestamp,
+		CallMethodExchangeRate,
+		CallMethodFeeSchedule,
+		CallMethodNfts,
+		CallMethodNodeHealth,
+		CallMethodPrecheck,
+		CallMethodRawTransaction,
+		CallMethodReconcile,
+		CallMethodScheduleInfo,
+		CallMethodSubmissions,
+		CallMethodTokenBalances,
+		CallMethodTokenInfo,
+		CallMethodTokenRelationships,
+		CallMethodTransaction,
+	}
+
+	// CurrencyHbar is the default native currency definition. It's never modified, a server presents the native
+	// currency with the definition in its Settings
 	CurrencyHbar = &types.Currency{
 		Symbol:   CurrencySymbol,
 		Decimals: CurrencyDecimals,
-		Metadata: map[string]interface{}{
-			"issuer": Blockchain,
-		},
+		Metadata: defaultCurrencyMetadata(),
 	}
 )
+
+// Settings are the settings derived from the rosetta config and shared by the services of a server. Every server has
+// its own, so servers with different configs can run in the same process
+type Settings struct {
+	// CurrencyHbar is the native currency definition, every hbar amount must reference it
+	CurrencyHbar *types.Currency
+
+	// LedgerId is the ledger id of the network to validate the checksum of an entity id with, nil if the network
+	// doesn't have one, e.g., demo
+	LedgerId []byte
+
+	// TimestampStrings controls if a nanosecond timestamp in metadata is accompanied by its RFC3339 string, since the
+	// nanoseconds since epoch are easily misparsed as a float
+	TimestampStrings bool
+
+	// TokenSubAccounts controls if a token amount is held by the sub-account of the owning account with the token id
+	// as the address, instead of by the account itself
+	TokenSubAccounts bool
+
+	// TransactionValidDuration is how long a constructed transaction stays valid after its valid start, which is also
+	// how long the consensus nodes detect a duplicate of it
+	TransactionValidDuration time.Duration
+
+	// TransactionValidStartBackdate is how far before the construction time the valid start of a constructed
+	// transaction is set, to tolerate the clock skew between the server and the consensus nodes. 0 keeps the random
+	// backdate of the SDK
+	TransactionValidStartBackdate time.Duration
+}
+
+// NewSettings creates the default settings, i.e., the default native currency, no ledger id, no timestamp strings,
+// no token sub-accounts, and the default transaction validity
+func NewSettings() *Settings {
+	return &Settings{
+		CurrencyHbar:             newCurrencyHbar(CurrencySymbol, defaultCurrencyMetadata()),
+		TransactionValidDuration: DefaultTransactionValidDuration,
+	}
+}
+
+// ConfigureCurrencyHbar customizes the presentation of the native currency. The decimals are canonical and can't be
+// changed. An empty symbol keeps the default symbol, and the metadata is merged into the default metadata
+func (s *Settings) ConfigureCurrencyHbar(symbol string, metadata map[string]string) {
+	if symbol == "" {
+		symbol = CurrencySymbol
+	}
+
+	currencyMetadata := defaultCurrencyMetadata()
+	for key, value := range metadata {
+		currencyMetadata[key] = value
+	}
+
+	s.CurrencyHbar = newCurrencyHbar(symbol, currencyMetadata)
+}
+
+// ConfigureLedgerId sets LedgerId to the ledger id of the network defined in HIP-15, i.e., 0x00 for mainnet, 0x01 for
+// testnet, and 0x02 for previewnet. Any other network has no ledger id
+func (s *Settings) ConfigureLedgerId(network string) {
+	switch strings.ToLower(network) {
+	case "mainnet":
+		s.LedgerId = []byte{0}
+	case "testnet":
+		s.LedgerId = []byte{1}
+	case "previewnet":
+		s.LedgerId = []byte{2}
+	default:
+		s.LedgerId = nil
+	}
+}
+
+// CheckTransactionValidity returns an error if the backdate is negative, the valid duration is outside of the bounds
+// the consensus nodes accept, or the transaction would be expired when constructed. A zero validDuration stands for
+// DefaultTransactionValidDuration
+func CheckTransactionValidity(validStartBackdate time.Duration, validDuration time.Duration) error {
+	if validDuration == 0 {
+		validDuration = DefaultTransactionValidDuration
+	}
+
+	if validDuration < MinTransactionValidDuration || validDuration > MaxTransactionValidDuration {
+		return fmt.Errorf("the transaction valid duration %s is outside of [%s, %s]", validDuration,
+			MinTransactionValidDuration, MaxTransactionValidDuration)
+	}
+
+	if validStartBackdate < 0 || validStartBackdate >= validDuration {
+		return fmt.Errorf("the transaction valid start backdate %s must be non-negative and less than the valid "+
+			"duration %s", validStartBackdate, validDuration)
+	}
+
+	return nil
+}
+
+// ConfigureTransactionValidity sets TransactionValidStartBackdate and TransactionValidDuration, a zero validDuration
+// is replaced with DefaultTransactionValidDuration. Nothing is changed if CheckTransactionValidity fails
+func (s *Settings) ConfigureTransactionValidity(validStartBackdate time.Duration, validDuration time.Duration) error {
+	if err := CheckTransactionValidity(validStartBackdate, validDuration); err != nil {
+		return err
+	}
+
+	if validDuration == 0 {
+		validDuration = DefaultTransactionValidDuration
+	}
+
+	s.TransactionValidStartBackdate = validStartBackdate
+	s.TransactionValidDuration = validDuration
+	return nil
+}
+
+func defaultCurrencyMetadata() map[string]interface{} {
+	return map[string]interface{}{
+		"issuer": Blockchain,
+	}
+}
+
+func newCurrencyHbar(symbol string, metadata map[string]interface{}) *types.Currency {
+	return &types.Currency{
+		Symbol:   symbol,
+		Decimals: CurrencyDecimals,
+		Metadata: metadata,
+	}
+}
@@ -0,0 +1,139 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigureCurrencyHbar(t *testing.T) {
+	var tests = []struct {
+		name             string
+		symbol           string
+		metadata         map[string]string
+		expectedSymbol   string
+		expectedMetadata map[string]interface{}
+	}{
+		{
+			name:             "Default",
+			expectedSymbol:   CurrencySymbol,
+			expectedMetadata: map[string]interface{}{"issuer": Blockchain},
+		},
+		{
+			name:             "CustomSymbol",
+			symbol:           "ℏ",
+			expectedSymbol:   "ℏ",
+			expectedMetadata: map[string]interface{}{"issuer": Blockchain},
+		},
+		{
+			name:           "CustomMetadata",
+			metadata:       map[string]string{"issuer": "Hedera Hashgraph", "unit": "tinybar"},
+			expectedSymbol: CurrencySymbol,
+			expectedMetadata: map[string]interface{}{
+				"issuer": "Hedera Hashgraph",
+				"unit":   "tinybar",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := NewSettings()
+
+			settings.ConfigureCurrencyHbar(tt.symbol, tt.metadata)
+
+			assert.NotSame(t, CurrencyHbar, settings.CurrencyHbar)
+			assert.Equal(t, tt.expectedSymbol, settings.CurrencyHbar.Symbol)
+			assert.Equal(t, int32(CurrencyDecimals), settings.CurrencyHbar.Decimals)
+			assert.Equal(t, tt.expectedMetadata, settings.CurrencyHbar.Metadata)
+			assert.Equal(t, CurrencySymbol, CurrencyHbar.Symbol)
+			assert.Equal(t, map[string]interface{}{"issuer": Blockchain}, CurrencyHbar.Metadata)
+		})
+	}
+}
+
+func TestConfigureLedgerId(t *testing.T) {
+	var tests = []struct {
+		network  string
+		expected []byte
+	}{
+		{network: "MAINNET", expected: []byte{0}},
+		{network: "testnet", expected: []byte{1}},
+		{network: "PreviewNet", expected: []byte{2}},
+		{network: "demo"},
+		{network: "other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.network, func(t *testing.T) {
+			settings := NewSettings()
+
+			settings.ConfigureLedgerId(tt.network)
+
+			assert.Equal(t, tt.expected, settings.LedgerId)
+		})
+	}
+}
+
+func TestConfigureTransactionValidity(t *testing.T) {
+	var tests = []struct {
+		name                  string
+		validStartBackdate    time.Duration
+		validDuration         time.Duration
+		expectError           bool
+		expectedBackdate      time.Duration
+		expectedValidDuration time.Duration
+	}{
+		{name: "Default", expectedValidDuration: DefaultTransactionValidDuration},
+		{
+			name:                  "Custom",
+			validStartBackdate:    10 * time.Second,
+			validDuration:         MaxTransactionValidDuration,
+			expectedBackdate:      10 * time.Second,
+			expectedValidDuration: MaxTransactionValidDuration,
+		},
+		{name: "NegativeBackdate", validStartBackdate: -time.Second, expectError: true},
+		{name: "BackdateExpired", validStartBackdate: DefaultTransactionValidDuration, expectError: true},
+		{name: "ValidDurationTooShort", validDuration: 14 * time.Second, expectError: true},
+		{name: "ValidDurationTooLong", validDuration: 181 * time.Second, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := NewSettings()
+
+			err := settings.ConfigureTransactionValidity(tt.validStartBackdate, tt.validDuration)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Equal(t, time.Duration(0), settings.TransactionValidStartBackdate)
+				assert.Equal(t, DefaultTransactionValidDuration, settings.TransactionValidDuration)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedBackdate, settings.TransactionValidStartBackdate)
+				assert.Equal(t, tt.expectedValidDuration, settings.TransactionValidDuration)
+			}
+		})
+	}
+}
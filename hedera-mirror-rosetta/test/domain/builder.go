@@ -0,0 +1,388 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package domain
+
+import (
+	"fmt"
+
+	dbTypes "github.com/hashgraph/hedera-mirror-node/hedera-mirror-rosetta/app/persistence/types"
+	"gorm.io/gorm"
+)
+
+const (
+	accountBalanceFileTableName   = "account_balance_file"
+	accountBalanceTableName       = "account_balance"
+	defaultAccountBalanceFileName = "%d_Balances.csv"
+	defaultNodeAccountId          = 3
+	defaultRecordFileHapiVersion  = 19
+	defaultRecordFileName         = "%d.rcd"
+	defaultRecordFileVersion      = 5
+	defaultTransactionResult      = 22
+	defaultTransactionType        = 14
+	defaultValidDurationSeconds   = 120
+	nonFeeTransferTableName       = "non_fee_transfer"
+	recordFileTableName           = "record_file"
+	tokenBalanceTableName         = "token_balance"
+)
+
+// AccountBalance is the account_balance row
+type AccountBalance struct {
+	AccountId          int64 `gorm:"primaryKey"`
+	Balance            int64
+	ConsensusTimestamp int64 `gorm:"primaryKey"`
+}
+
+func (AccountBalance) TableName() string {
+	return accountBalanceTableName
+}
+
+// AccountBalanceFile is the account_balance_file row
+type AccountBalanceFile struct {
+	ConsensusTimestamp int64 `gorm:"primaryKey"`
+	Count              int64
+	LoadStart          int64
+	LoadEnd            int64
+	FileHash           string
+	Name               string
+	NodeAccountId      int64
+}
+
+func (AccountBalanceFile) TableName() string {
+	return accountBalanceFileTableName
+}
+
+// RecordFile is the record_file row
+type RecordFile struct {
+	ConsensusStart   int64
+	ConsensusEnd     int64 `gorm:"primaryKey"`
+	Count            int64
+	DigestAlgorithm  int
+	FileHash         string
+	HapiVersionMajor int
+	HapiVersionMinor int
+	HapiVersionPatch int
+	Hash             string
+	Index            int64
+	LoadEnd          int64
+	LoadStart        int64
+	Name             string
+	NodeAccountId    int64
+	PrevHash         string
+	Version          int
+}
+
+func (RecordFile) TableName() string {
+	return recordFileTableName
+}
+
+// TokenBalance is the token_balance row
+type TokenBalance struct {
+	AccountId          int64
+	Balance            int64
+	ConsensusTimestamp int64
+	TokenId            int64
+}
+
+func (TokenBalance) TableName() string {
+	return tokenBalanceTableName
+}
+
+// AccountBalanceSnapshotBuilder builds an account balance file with the account and token balances in it
+type AccountBalanceSnapshotBuilder struct {
+	accountBalances []AccountBalance
+	dbClient        *gorm.DB
+	file            AccountBalanceFile
+	tokenBalances   []TokenBalance
+}
+
+// NewAccountBalanceSnapshotBuilder creates a builder of the account balance snapshot at consensusTimestamp
+func NewAccountBalanceSnapshotBuilder(dbClient *gorm.DB, consensusTimestamp int64) *AccountBalanceSnapshotBuilder {
+	return &AccountBalanceSnapshotBuilder{
+		dbClient: dbClient,
+		file: AccountBalanceFile{
+			ConsensusTimestamp: consensusTimestamp,
+			LoadStart:          1,
+			LoadEnd:            2,
+			Name:               fmt.Sprintf(defaultAccountBalanceFileName, consensusTimestamp),
+			NodeAccountId:      defaultNodeAccountId,
+		},
+	}
+}
+
+func (b *AccountBalanceSnapshotBuilder) AccountBalance(account, balance int64) *AccountBalanceSnapshotBuilder {
+	b.accountBalances = append(b.accountBalances, AccountBalance{
+		AccountId:          account,
+		Balance:            balance,
+		ConsensusTimestamp: b.file.ConsensusTimestamp,
+	})
+	return b
+}
+
+func (b *AccountBalanceSnapshotBuilder) TokenBalance(account, token, balance int64) *AccountBalanceSnapshotBuilder {
+	b.tokenBalances = append(b.tokenBalances, TokenBalance{
+		AccountId:          account,
+		Balance:            balance,
+		ConsensusTimestamp: b.file.ConsensusTimestamp,
+		TokenId:            token,
+	})
+	return b
+}
+
+// Persist writes the account balance file and the balances added to the builder
+func (b *AccountBalanceSnapshotBuilder) Persist() AccountBalanceFile {
+	b.file.Count = int64(len(b.accountBalances))
+	b.dbClient.Create(&b.file)
+
+	if len(b.accountBalances) != 0 {
+		b.dbClient.Create(b.accountBalances)
+	}
+
+	if len(b.tokenBalances) != 0 {
+		b.dbClient.Create(b.tokenBalances)
+	}
+
+	return b.file
+}
+
+// EntityBuilder builds an entity
+type EntityBuilder struct {
+	dbClient *gorm.DB
+	entity   dbTypes.Entity
+}
+
+// NewEntityBuilder creates a builder of the entity with the id and type
+func NewEntityBuilder(dbClient *gorm.DB, id int64, entityType int) *EntityBuilder {
+	return &EntityBuilder{
+		dbClient: dbClient,
+		entity:   dbTypes.Entity{Id: id, Num: id, Type: entityType},
+	}
+}
+
+func (b *EntityBuilder) Deleted(deleted bool) *EntityBuilder {
+	b.entity.Deleted = deleted
+	return b
+}
+
+func (b *EntityBuilder) Key(key []byte) *EntityBuilder {
+	b.entity.Key = key
+	return b
+}
+
+func (b *EntityBuilder) PublicKey(publicKey string) *EntityBuilder {
+	b.entity.PublicKey = publicKey
+	return b
+}
+
+// Persist writes the entity
+func (b *EntityBuilder) Persist() dbTypes.Entity {
+	b.dbClient.Create(&b.entity)
+	return b.entity
+}
+
+// RecordFileBuilder builds a record file
+type RecordFileBuilder struct {
+	dbClient   *gorm.DB
+	recordFile RecordFile
+}
+
+// NewRecordFileBuilder creates a builder of the record file with the index and consensus range. The hash defaults to
+// one derived from the index
+func NewRecordFileBuilder(dbClient *gorm.DB, index, consensusStart, consensusEnd int64) *RecordFileBuilder {
+	hash := fmt.Sprintf("%096x", index)
+	return &RecordFileBuilder{
+		dbClient: dbClient,
+		recordFile: RecordFile{
+			ConsensusStart:   consensusStart,
+			ConsensusEnd:     consensusEnd,
+			Count:            1,
+			FileHash:         hash,
+			HapiVersionMinor: defaultRecordFileHapiVersion,
+			Hash:             hash,
+			Index:            index,
+			LoadStart:        1,
+			LoadEnd:          2,
+			Name:             fmt.Sprintf(defaultRecordFileName, consensusStart),
+			NodeAccountId:    defaultNodeAccountId,
+			Version:          defaultRecordFileVersion,
+		},
+	}
+}
+
+func (b *RecordFileBuilder) Hash(hash string) *RecordFileBuilder {
+	b.recordFile.Hash = hash
+	return b
+}
+
+func (b *RecordFileBuilder) HapiVersion(major, minor, patch int) *RecordFileBuilder {
+	b.recordFile.HapiVersionMajor = major
+	b.recordFile.HapiVersionMinor = minor
+	b.recordFile.HapiVersionPatch = patch
+	return b
+}
+
+func (b *RecordFileBuilder) PrevHash(prevHash string) *RecordFileBuilder {
+	b.recordFile.PrevHash = prevHash
+	return b
+}
+
+// Persist writes the record file
+func (b *RecordFileBuilder) Persist() RecordFile {
+	b.dbClient.Create(&b.recordFile)
+	return b.recordFile
+}
+
+// TokenBuilder builds a token and its entity
+type TokenBuilder struct {
+	dbClient *gorm.DB
+	token    dbTypes.Token
+}
+
+// NewTokenBuilder creates a builder of the token with the id and treasury
+func NewTokenBuilder(dbClient *gorm.DB, tokenId, treasury int64) *TokenBuilder {
+	return &TokenBuilder{
+		dbClient: dbClient,
+		token:    dbTypes.Token{TokenId: tokenId, TreasuryAccountId: treasury},
+	}
+}
+
+func (b *TokenBuilder) Decimals(decimals int64) *TokenBuilder {
+	b.token.Decimals = decimals
+	return b
+}
+
+func (b *TokenBuilder) FreezeDefault(freezeDefault bool) *TokenBuilder {
+	b.token.FreezeDefault = freezeDefault
+	return b
+}
+
+func (b *TokenBuilder) InitialSupply(initialSupply int64) *TokenBuilder {
+	b.token.InitialSupply = initialSupply
+	return b
+}
+
+func (b *TokenBuilder) Symbol(symbol string) *TokenBuilder {
+	b.token.Symbol = symbol
+	return b
+}
+
+// Persist writes the token and its entity
+func (b *TokenBuilder) Persist() dbTypes.Token {
+	b.dbClient.Create(&b.token)
+	NewEntityBuilder(b.dbClient, b.token.TokenId, tokenEntityType).Persist()
+	return b.token
+}
+
+// TransactionBuilder builds a transaction and its transfers. The transaction defaults to a successful crypto transfer
+// submitted to node 0.0.3
+type TransactionBuilder struct {
+	cryptoTransfers []dbTypes.CryptoTransfer
+	dbClient        *gorm.DB
+	nonFeeTransfers []dbTypes.CryptoTransfer
+	tokenTransfers  []dbTypes.TokenTransfer
+	transaction     dbTypes.Transaction
+}
+
+// NewTransactionBuilder creates a builder of the transaction reaching consensus at consensusTimestamp
+func NewTransactionBuilder(dbClient *gorm.DB, payer, consensusTimestamp int64) *TransactionBuilder {
+	return &TransactionBuilder{
+		dbClient: dbClient,
+		transaction: dbTypes.Transaction{
+			ConsensusNs:          consensusTimestamp,
+			NodeAccountId:        defaultNodeAccountId,
+			PayerAccountId:       payer,
+			Result:               defaultTransactionResult,
+			TransactionHash:      []byte(fmt.Sprintf("%d", consensusTimestamp)),
+			Type:                 defaultTransactionType,
+			ValidDurationSeconds: defaultValidDurationSeconds,
+			ValidStartNs:         consensusTimestamp - 1,
+		},
+	}
+}
+
+func (b *TransactionBuilder) ChargedTxFee(fee int64) *TransactionBuilder {
+	b.transaction.ChargedTxFee = fee
+	return b
+}
+
+// CryptoTransfer adds the hbar transfer of the account to the transaction
+func (b *TransactionBuilder) CryptoTransfer(account, amount int64) *TransactionBuilder {
+	b.cryptoTransfers = append(b.cryptoTransfers, dbTypes.CryptoTransfer{
+		Amount:             amount,
+		ConsensusTimestamp: b.transaction.ConsensusNs,
+		EntityId:           account,
+	})
+	return b
+}
+
+func (b *TransactionBuilder) EntityId(entityId int64) *TransactionBuilder {
+	b.transaction.EntityId = entityId
+	return b
+}
+
+// NonFeeTransfer adds the transfer of the account as the transaction body lists it
+func (b *TransactionBuilder) NonFeeTransfer(account, amount int64) *TransactionBuilder {
+	b.nonFeeTransfers = append(b.nonFeeTransfers, dbTypes.CryptoTransfer{
+		Amount:             amount,
+		ConsensusTimestamp: b.transaction.ConsensusNs,
+		EntityId:           account,
+	})
+	return b
+}
+
+func (b *TransactionBuilder) Result(result int16) *TransactionBuilder {
+	b.transaction.Result = result
+	return b
+}
+
+// TokenTransfer adds the fungible token transfer of the account to the transaction
+func (b *TransactionBuilder) TokenTransfer(token, account, amount int64) *TransactionBuilder {
+	b.tokenTransfers = append(b.tokenTransfers, dbTypes.TokenTransfer{
+		AccountId:          account,
+		Amount:             amount,
+		ConsensusTimestamp: b.transaction.ConsensusNs,
+		TokenId:            token,
+	})
+	return b
+}
+
+func (b *TransactionBuilder) Type(transactionType int16) *TransactionBuilder {
+	b.transaction.Type = transactionType
+	return b
+}
+
+// Persist writes the transaction and its transfers
+func (b *TransactionBuilder) Persist() dbTypes.Transaction {
+	b.dbClient.Create(&b.transaction)
+
+	if len(b.cryptoTransfers) != 0 {
+		b.dbClient.Create(b.cryptoTransfers)
+	}
+
+	if len(b.nonFeeTransfers) != 0 {
+		b.dbClient.Table(nonFeeTransferTableName).Create(b.nonFeeTransfers)
+	}
+
+	if len(b.tokenTransfers) != 0 {
+		b.dbClient.Create(b.tokenTransfers)
+	}
+
+	return b.transaction
+}
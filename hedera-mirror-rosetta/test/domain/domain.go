@@ -28,12 +28,7 @@ import (
 const tokenEntityType = 5
 
 func AddEntity(dbClient *gorm.DB, id int64, entityType int) {
-	entity := &dbTypes.Entity{
-		Id:   id,
-		Num:  id,
-		Type: entityType,
-	}
-	dbClient.Create(entity)
+	NewEntityBuilder(dbClient, id, entityType).Persist()
 }
 
 func AddTransaction(
@@ -85,15 +80,9 @@ func AddToken(
 	initialSupply int64,
 	treasury int64,
 ) {
-	token := &dbTypes.Token{
-		TokenId:           tokenId,
-		Decimals:          decimals,
-		FreezeDefault:     freezeDefault,
-		InitialSupply:     initialSupply,
-		Symbol:            "",
-		TreasuryAccountId: treasury,
-	}
-	dbClient.Create(token)
-
-	AddEntity(dbClient, tokenId, tokenEntityType)
+	NewTokenBuilder(dbClient, tokenId, treasury).
+		Decimals(decimals).
+		FreezeDefault(freezeDefault).
+		InitialSupply(initialSupply).
+		Persist()
 }
@@ -30,10 +30,28 @@ type MockAccountRepository struct {
 	mock.Mock
 }
 
-func (m *MockAccountRepository) RetrieveBalanceAtBlock(addressStr string, consensusEnd int64) (
-	[]types.Amount,
-	*rTypes.Error,
-) {
+func (m *MockAccountRepository) FindByPublicKey(publicKey []byte) ([]types.Account, *rTypes.Error) {
 	args := m.Called()
+	return args.Get(0).([]types.Account), args.Get(1).(*rTypes.Error)
+}
+
+func (m *MockAccountRepository) FindExpiry(addressStr string) (*types.AccountExpiry, *rTypes.Error) {
+	args := m.Called(addressStr)
+	return args.Get(0).(*types.AccountExpiry), args.Get(1).(*rTypes.Error)
+}
+
+func (m *MockAccountRepository) FindKey(addressStr string) ([]byte, *rTypes.Error) {
+	args := m.Called(addressStr)
+	return args.Get(0).([]byte), args.Get(1).(*rTypes.Error)
+}
+
+func (m *MockAccountRepository) RetrieveBalanceAtBlock(
+	addressStr string,
+	consensusEnd int64,
+	tokenIds []int64,
+	afterTokenId int64,
+	limit int,
+) ([]types.Amount, *rTypes.Error) {
+	args := m.Called(addressStr, consensusEnd, tokenIds, afterTokenId, limit)
 	return args.Get(0).([]types.Amount), args.Get(1).(*rTypes.Error)
 }
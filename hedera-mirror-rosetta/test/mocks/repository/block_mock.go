@@ -30,6 +30,16 @@ type MockBlockRepository struct {
 	mock.Mock
 }
 
+func (m *MockBlockRepository) FindBetweenIndexes(start int64, end int64) ([]*types.Block, *rTypes.Error) {
+	args := m.Called(start, end)
+	return args.Get(0).([]*types.Block), args.Get(1).(*rTypes.Error)
+}
+
+func (m *MockBlockRepository) FindByConsensusTimestamp(timestamp int64) (*types.Block, *rTypes.Error) {
+	args := m.Called(timestamp)
+	return args.Get(0).(*types.Block), args.Get(1).(*rTypes.Error)
+}
+
 func (m *MockBlockRepository) FindByIndex(index int64) (*types.Block, *rTypes.Error) {
 	args := m.Called()
 	return args.Get(0).(*types.Block), args.Get(1).(*rTypes.Error)
@@ -45,6 +55,10 @@ func (m *MockBlockRepository) FindByIdentifier(index int64, hash string) (*types
 	return args.Get(0).(*types.Block), args.Get(1).(*rTypes.Error)
 }
 
+func (m *MockBlockRepository) RefreshLatest() (*types.Block, *rTypes.Error) {
+	return m.retrieveBlock(m.Called())
+}
+
 func (m *MockBlockRepository) RetrieveGenesis() (*types.Block, *rTypes.Error) {
 	return m.retrieveBlock(m.Called())
 }
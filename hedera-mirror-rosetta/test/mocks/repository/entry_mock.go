@@ -30,7 +30,7 @@ type MockAddressBookEntryRepository struct {
 	mock.Mock
 }
 
-func (m MockAddressBookEntryRepository) Entries() (*types.AddressBookEntries, *rTypes.Error) {
+func (m *MockAddressBookEntryRepository) Entries() (*types.AddressBookEntries, *rTypes.Error) {
 	args := m.Called()
 	return args.Get(0).(*types.AddressBookEntries), args.Get(1).(*rTypes.Error)
 }
@@ -34,3 +34,16 @@ func (m *MockTokenRepository) Find(tokenIdStr string) (*types.Token, *rTypes.Err
 	args := m.Called(tokenIdStr)
 	return args.Get(0).(*types.Token), args.Get(1).(*rTypes.Error)
 }
+
+func (m *MockTokenRepository) FindAt(tokenIdStr string, consensusTimestamp int64) (*types.Token, *rTypes.Error) {
+	args := m.Called(tokenIdStr, consensusTimestamp)
+	return args.Get(0).(*types.Token), args.Get(1).(*rTypes.Error)
+}
+
+func (m *MockTokenRepository) FindInfoAt(tokenIdStr string, consensusTimestamp int64) (
+	*types.TokenInfo,
+	*rTypes.Error,
+) {
+	args := m.Called(tokenIdStr, consensusTimestamp)
+	return args.Get(0).(*types.TokenInfo), args.Get(1).(*rTypes.Error)
+}
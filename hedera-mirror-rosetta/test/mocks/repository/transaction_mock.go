@@ -39,11 +39,33 @@ func (m *MockTransactionRepository) FindByHashInBlock(
 	return args.Get(0).(*types.Transaction), args.Get(1).(*rTypes.Error)
 }
 
+func (m *MockTransactionRepository) FindByTransactionId(transactionId types.TransactionId) (
+	[]*types.Transaction,
+	*rTypes.Error,
+) {
+	args := m.Called(transactionId)
+	return args.Get(0).([]*types.Transaction), args.Get(1).(*rTypes.Error)
+}
+
+func (m *MockTransactionRepository) FindRawByHash(hash string) ([]*types.RawTransaction, *rTypes.Error) {
+	args := m.Called(hash)
+	return args.Get(0).([]*types.RawTransaction), args.Get(1).(*rTypes.Error)
+}
+
 func (m *MockTransactionRepository) FindBetween(start int64, end int64) ([]*types.Transaction, *rTypes.Error) {
 	args := m.Called()
 	return args.Get(0).([]*types.Transaction), args.Get(1).(*rTypes.Error)
 }
 
+func (m *MockTransactionRepository) FindBetweenWithMaxOperations(start int64, end int64, maxOperations int) (
+	[]*types.Transaction,
+	[]string,
+	*rTypes.Error,
+) {
+	args := m.Called(maxOperations)
+	return args.Get(0).([]*types.Transaction), args.Get(1).([]string), args.Get(2).(*rTypes.Error)
+}
+
 func (m *MockTransactionRepository) Types() (map[int]string, *rTypes.Error) {
 	panic("implement me")
 }
@@ -26,9 +26,17 @@ import (
 )
 
 var (
-	NilAmount      *types.Amount
-	NilBlock       *types.Block
-	NilEntries     *types.AddressBookEntries
-	NilError       *rTypes.Error
-	NilTransaction *types.Transaction
+	NilAccountExpiry    *types.AccountExpiry
+	NilAddressBook      *types.AddressBook
+	NilAmount           *types.Amount
+	NilBlock            *types.Block
+	NilEntries          *types.AddressBookEntries
+	NilError            *rTypes.Error
+	NilExchangeRate     *types.ExchangeRateSet
+	NilFeeSchedule      *types.FeeScheduleSet
+	NilNetworkVersion   *types.NetworkVersion
+	NilSchedule         *types.Schedule
+	NilToken            *types.Token
+	NilTokenAssociation *types.TokenAssociation
+	NilTransaction      *types.Transaction
 )
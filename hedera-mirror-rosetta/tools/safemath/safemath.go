@@ -0,0 +1,53 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package safemath
+
+import "math/big"
+
+// AddInt64 returns the sum of a and b, ok is false if the sum overflows int64
+func AddInt64(a, b int64) (sum int64, ok bool) {
+	sum = a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, false
+	}
+
+	return sum, true
+}
+
+// Sum adds up int64 values with arbitrary precision, so a total which fits in int64 is exact even if a running total
+// along the way doesn't. The zero value is a sum of 0
+type Sum struct {
+	value big.Int
+}
+
+// Add adds the value to the sum
+func (s *Sum) Add(value int64) {
+	s.value.Add(&s.value, big.NewInt(value))
+}
+
+// Int64 returns the sum, ok is false if it overflows int64
+func (s *Sum) Int64() (int64, bool) {
+	if !s.value.IsInt64() {
+		return 0, false
+	}
+
+	return s.value.Int64(), true
+}
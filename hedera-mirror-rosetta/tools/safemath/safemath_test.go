@@ -0,0 +1,94 @@
+/*-
+ * ‌
+ * Hedera Mirror Node
+ * ​
+ * Copyright (C) 2019 - 2021 Hedera Hashgraph, LLC
+ * ​
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ‍
+ */
+
+package safemath
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddInt64(t *testing.T) {
+	var tests = []struct {
+		name     string
+		a        int64
+		b        int64
+		expected int64
+		ok       bool
+	}{
+		{name: "Positive", a: 1, b: 2, expected: 3, ok: true},
+		{name: "Negative", a: -1, b: -2, expected: -3, ok: true},
+		{name: "MaxInt64", a: math.MaxInt64 - 1, b: 1, expected: math.MaxInt64, ok: true},
+		{name: "MinInt64", a: math.MinInt64 + 1, b: -1, expected: math.MinInt64, ok: true},
+		{name: "OppositeSigns", a: math.MaxInt64, b: math.MinInt64, expected: -1, ok: true},
+		{name: "PositiveOverflow", a: math.MaxInt64, b: 1},
+		{name: "NegativeOverflow", a: math.MinInt64, b: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			actual, ok := AddInt64(tt.a, tt.b)
+
+			// then
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestSum(t *testing.T) {
+	var tests = []struct {
+		name     string
+		values   []int64
+		expected int64
+		ok       bool
+	}{
+		{name: "Empty", ok: true},
+		{name: "Small", values: []int64{1, -5, 10}, expected: 6, ok: true},
+		{
+			name:     "RunningTotalOverflows",
+			values:   []int64{math.MaxInt64, math.MaxInt64, -math.MaxInt64},
+			expected: math.MaxInt64,
+			ok:       true,
+		},
+		{name: "Overflow", values: []int64{math.MaxInt64, 1}},
+		{name: "Underflow", values: []int64{math.MinInt64, -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			sum := Sum{}
+
+			// when
+			for _, value := range tt.values {
+				sum.Add(value)
+			}
+			actual, ok := sum.Int64()
+
+			// then
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
@@ -59,26 +59,169 @@ func (n *NodeMap) UnmarshalYAML(unmarshal func(interface{}) error) error {
 }
 
 type Rosetta struct {
-	ApiVersion  string  `yaml:"apiVersion" env:"HEDERA_MIRROR_ROSETTA_API_VERSION"`
-	Db          Db      `yaml:"db"`
-	Log         Log     `yaml:"log"`
-	Network     string  `yaml:"network" env:"HEDERA_MIRROR_ROSETTA_NETWORK"`
-	Nodes       NodeMap `yaml:"nodes" env:"HEDERA_MIRROR_ROSETTA_NODES"`
-	NodeVersion string  `yaml:"nodeVersion" env:"HEDERA_MIRROR_ROSETTA_NODE_VERSION"`
-	Online      bool    `yaml:"online" env:"HEDERA_MIRROR_ROSETTA_ONLINE"`
-	Port        uint16  `yaml:"port" env:"HEDERA_MIRROR_ROSETTA_PORT"`
-	Realm       string  `yaml:"realm" env:"HEDERA_MIRROR_ROSETTA_REALM"`
-	Shard       string  `yaml:"shard" env:"HEDERA_MIRROR_ROSETTA_SHARD"`
-	Version     string  `yaml:"version" env:"HEDERA_MIRROR_ROSETTA_VERSION"`
+	Account           Account           `yaml:"account"`
+	ApiVersion        string            `yaml:"apiVersion" env:"HEDERA_MIRROR_ROSETTA_API_VERSION"`
+	BalanceExemptions BalanceExemptions `yaml:"balanceExemptions"`
+	Block             Block             `yaml:"block"`
+	Canary            Canary            `yaml:"canary"`
+	CircuitBreaker    CircuitBreaker    `yaml:"circuitBreaker"`
+	Construction      Construction      `yaml:"construction"`
+	Currency          Currency          `yaml:"currency"`
+	Db                Db                `yaml:"db"`
+	Grpc              Grpc              `yaml:"grpc"`
+	Http              Http              `yaml:"http"`
+	Log               Log               `yaml:"log"`
+	Network           string            `yaml:"network" env:"HEDERA_MIRROR_ROSETTA_NETWORK"`
+	Nodes             NodeMap           `yaml:"nodes" env:"HEDERA_MIRROR_ROSETTA_NODES"`
+	NodeVersion       string            `yaml:"nodeVersion" env:"HEDERA_MIRROR_ROSETTA_NODE_VERSION"`
+	Online            bool              `yaml:"online" env:"HEDERA_MIRROR_ROSETTA_ONLINE"`
+	Port              uint16            `yaml:"port" env:"HEDERA_MIRROR_ROSETTA_PORT"`
+	Realm             string            `yaml:"realm" env:"HEDERA_MIRROR_ROSETTA_REALM"`
+	Shard             string            `yaml:"shard" env:"HEDERA_MIRROR_ROSETTA_SHARD"`
+	TimestampStrings  bool              `yaml:"timestampStrings" env:"HEDERA_MIRROR_ROSETTA_TIMESTAMP_STRINGS"`
+	Version           string            `yaml:"version" env:"HEDERA_MIRROR_ROSETTA_VERSION"`
+}
+
+type Account struct {
+	BalanceWorkers   int            `yaml:"balanceWorkers" env:"HEDERA_MIRROR_ROSETTA_ACCOUNT_BALANCE_WORKERS"`
+	MaxTokenBalances int            `yaml:"maxTokenBalances" env:"HEDERA_MIRROR_ROSETTA_ACCOUNT_MAX_TOKEN_BALANCES"`
+	RollingBalance   RollingBalance `yaml:"rollingBalance"`
+	TokenSubAccounts bool           `yaml:"tokenSubAccounts" env:"HEDERA_MIRROR_ROSETTA_ACCOUNT_TOKEN_SUB_ACCOUNTS"`
+}
+
+type RollingBalance struct {
+	BatchSize    int  `yaml:"batchSize" env:"HEDERA_MIRROR_ROSETTA_ACCOUNT_ROLLING_BALANCE_BATCH_SIZE"`
+	Enabled      bool `yaml:"enabled" env:"HEDERA_MIRROR_ROSETTA_ACCOUNT_ROLLING_BALANCE_ENABLED"`
+	PollInterval int  `yaml:"pollInterval" env:"HEDERA_MIRROR_ROSETTA_ACCOUNT_ROLLING_BALANCE_POLL_INTERVAL"`
+}
+
+type BalanceExemptions struct {
+	Accounts     []string `yaml:"accounts"`
+	NodeAccounts bool     `yaml:"nodeAccounts" env:"HEDERA_MIRROR_ROSETTA_BALANCE_EXEMPTIONS_NODE_ACCOUNTS"`
+}
+
+type Block struct {
+	AuditMode           string            `yaml:"auditMode" env:"HEDERA_MIRROR_ROSETTA_BLOCK_AUDIT_MODE"`
+	ExchangeRate        bool              `yaml:"exchangeRate" env:"HEDERA_MIRROR_ROSETTA_BLOCK_EXCHANGE_RATE"`
+	FailedAmounts       string            `yaml:"failedAmounts" env:"HEDERA_MIRROR_ROSETTA_BLOCK_FAILED_AMOUNTS"`
+	HashPrefixMinLength int               `yaml:"hashPrefixMinLength" env:"HEDERA_MIRROR_ROSETTA_BLOCK_HASH_PREFIX_MIN_LENGTH"`
+	LatestCacheTtl      int               `yaml:"latestCacheTtl" env:"HEDERA_MIRROR_ROSETTA_BLOCK_LATEST_CACHE_TTL"`
+	MaxOperations       int               `yaml:"maxOperations" env:"HEDERA_MIRROR_ROSETTA_BLOCK_MAX_OPERATIONS"`
+	Notification        BlockNotification `yaml:"notification"`
+	OmitZeroAmounts     bool              `yaml:"omitZeroAmounts" env:"HEDERA_MIRROR_ROSETTA_BLOCK_OMIT_ZERO_AMOUNTS"`
+	Stream              BlockStream       `yaml:"stream"`
+	Window              int               `yaml:"window" env:"HEDERA_MIRROR_ROSETTA_BLOCK_WINDOW"`
+}
+
+type BlockNotification struct {
+	Channel      string `yaml:"channel" env:"HEDERA_MIRROR_ROSETTA_BLOCK_NOTIFICATION_CHANNEL"`
+	Enabled      bool   `yaml:"enabled" env:"HEDERA_MIRROR_ROSETTA_BLOCK_NOTIFICATION_ENABLED"`
+	PollInterval int    `yaml:"pollInterval" env:"HEDERA_MIRROR_ROSETTA_BLOCK_NOTIFICATION_POLL_INTERVAL"`
+}
+
+type BlockStream struct {
+	BufferSize        int  `yaml:"bufferSize" env:"HEDERA_MIRROR_ROSETTA_BLOCK_STREAM_BUFFER_SIZE"`
+	Enabled           bool `yaml:"enabled" env:"HEDERA_MIRROR_ROSETTA_BLOCK_STREAM_ENABLED"`
+	KeepAliveInterval int  `yaml:"keepAliveInterval" env:"HEDERA_MIRROR_ROSETTA_BLOCK_STREAM_KEEP_ALIVE_INTERVAL"`
+}
+
+type Canary struct {
+	Enabled     bool   `yaml:"enabled" env:"HEDERA_MIRROR_ROSETTA_CANARY_ENABLED"`
+	Interval    int    `yaml:"interval" env:"HEDERA_MIRROR_ROSETTA_CANARY_INTERVAL"`
+	OperatorId  string `yaml:"operatorId" env:"HEDERA_MIRROR_ROSETTA_CANARY_OPERATOR_ID"`
+	OperatorKey string `yaml:"operatorKey" env:"HEDERA_MIRROR_ROSETTA_CANARY_OPERATOR_KEY"`
+	Sla         int    `yaml:"sla" env:"HEDERA_MIRROR_ROSETTA_CANARY_SLA"`
+}
+
+type CircuitBreaker struct {
+	Enabled     bool   `yaml:"enabled" env:"HEDERA_MIRROR_ROSETTA_CIRCUIT_BREAKER_ENABLED"`
+	MaxFailures uint32 `yaml:"maxFailures" env:"HEDERA_MIRROR_ROSETTA_CIRCUIT_BREAKER_MAX_FAILURES"`
+	OpenTimeout int    `yaml:"openTimeout" env:"HEDERA_MIRROR_ROSETTA_CIRCUIT_BREAKER_OPEN_TIMEOUT"`
+}
+
+type Construction struct {
+	Auth               ConstructionAuth `yaml:"auth"`
+	AutoRenewPeriod    int64            `yaml:"autoRenewPeriod" env:"HEDERA_MIRROR_ROSETTA_CONSTRUCTION_AUTO_RENEW_PERIOD"`
+	Broadcast          Broadcast        `yaml:"broadcast"`
+	DefaultPayer       DefaultPayer     `yaml:"defaultPayer"`
+	ExistenceCheck     bool             `yaml:"existenceCheck" env:"HEDERA_MIRROR_ROSETTA_CONSTRUCTION_EXISTENCE_CHECK"`
+	Journal            Journal          `yaml:"journal"`
+	MaxTransactionFees map[string]int64 `yaml:"maxTransactionFees"`
+	ParseMode          string           `yaml:"parseMode" env:"HEDERA_MIRROR_ROSETTA_CONSTRUCTION_PARSE_MODE"`
+	ValidDuration      int64            `yaml:"validDuration" env:"HEDERA_MIRROR_ROSETTA_CONSTRUCTION_VALID_DURATION"`
+	ValidStartBackdate int64            `yaml:"validStartBackdate" env:"HEDERA_MIRROR_ROSETTA_CONSTRUCTION_VALID_START_BACKDATE"`
+}
+
+type ConstructionAuth struct {
+	ApiKeys            []string `yaml:"apiKeys" env:"HEDERA_MIRROR_ROSETTA_CONSTRUCTION_AUTH_API_KEYS"`
+	ClientCertificates bool     `yaml:"clientCertificates" env:"HEDERA_MIRROR_ROSETTA_CONSTRUCTION_AUTH_CLIENT_CERTIFICATES"`
+	Enabled            bool     `yaml:"enabled" env:"HEDERA_MIRROR_ROSETTA_CONSTRUCTION_AUTH_ENABLED"`
+}
+
+type Broadcast struct {
+	Grpc    GrpcBroadcast  `yaml:"grpc"`
+	Kafka   KafkaBroadcast `yaml:"kafka"`
+	Relay   RelayBroadcast `yaml:"relay"`
+	Timeout int            `yaml:"timeout" env:"HEDERA_MIRROR_ROSETTA_CONSTRUCTION_BROADCAST_TIMEOUT"`
+	Type    string         `yaml:"type" env:"HEDERA_MIRROR_ROSETTA_CONSTRUCTION_BROADCAST_TYPE"`
+}
+
+type GrpcBroadcast struct {
+	ConnectTimeout       int `yaml:"connectTimeout" env:"HEDERA_MIRROR_ROSETTA_CONSTRUCTION_BROADCAST_GRPC_CONNECT_TIMEOUT"`
+	KeepaliveTime        int `yaml:"keepaliveTime" env:"HEDERA_MIRROR_ROSETTA_CONSTRUCTION_BROADCAST_GRPC_KEEPALIVE_TIME"`
+	KeepaliveTimeout     int `yaml:"keepaliveTimeout" env:"HEDERA_MIRROR_ROSETTA_CONSTRUCTION_BROADCAST_GRPC_KEEPALIVE_TIMEOUT"`
+	MaxConcurrentStreams int `yaml:"maxConcurrentStreams" env:"HEDERA_MIRROR_ROSETTA_CONSTRUCTION_BROADCAST_GRPC_MAX_CONCURRENT_STREAMS"`
+	PoolSize             int `yaml:"poolSize" env:"HEDERA_MIRROR_ROSETTA_CONSTRUCTION_BROADCAST_GRPC_POOL_SIZE"`
+}
+
+type KafkaBroadcast struct {
+	Topic string `yaml:"topic" env:"HEDERA_MIRROR_ROSETTA_CONSTRUCTION_BROADCAST_KAFKA_TOPIC"`
+	Url   string `yaml:"url" env:"HEDERA_MIRROR_ROSETTA_CONSTRUCTION_BROADCAST_KAFKA_URL"`
+}
+
+type RelayBroadcast struct {
+	Url string `yaml:"url" env:"HEDERA_MIRROR_ROSETTA_CONSTRUCTION_BROADCAST_RELAY_URL"`
+}
+
+type DefaultPayer struct {
+	AccountId string `yaml:"accountId" env:"HEDERA_MIRROR_ROSETTA_CONSTRUCTION_DEFAULT_PAYER_ACCOUNT_ID"`
+	Enabled   bool   `yaml:"enabled" env:"HEDERA_MIRROR_ROSETTA_CONSTRUCTION_DEFAULT_PAYER_ENABLED"`
+}
+
+type Currency struct {
+	Metadata map[string]string `yaml:"metadata"`
+	Symbol   string            `yaml:"symbol" env:"HEDERA_MIRROR_ROSETTA_CURRENCY_SYMBOL"`
 }
 
 type Db struct {
-	Host     string `yaml:"host" env:"HEDERA_MIRROR_ROSETTA_DB_HOST"`
-	Name     string `yaml:"name" env:"HEDERA_MIRROR_ROSETTA_DB_NAME"`
-	Password string `yaml:"password" env:"HEDERA_MIRROR_ROSETTA_DB_PASSWORD"`
-	Pool     Pool   `yaml:"pool"`
-	Port     uint16 `yaml:"port" env:"HEDERA_MIRROR_ROSETTA_DB_PORT"`
-	Username string `yaml:"username" env:"HEDERA_MIRROR_ROSETTA_DB_USERNAME"`
+	Host           string           `yaml:"host" env:"HEDERA_MIRROR_ROSETTA_DB_HOST"`
+	IntegrityCheck DbIntegrityCheck `yaml:"integrityCheck"`
+	Metrics        DbMetrics        `yaml:"metrics"`
+	Name           string           `yaml:"name" env:"HEDERA_MIRROR_ROSETTA_DB_NAME"`
+	Password       string           `yaml:"password" env:"HEDERA_MIRROR_ROSETTA_DB_PASSWORD"`
+	Pool           Pool             `yaml:"pool"`
+	Port           uint16           `yaml:"port" env:"HEDERA_MIRROR_ROSETTA_DB_PORT"`
+	RawQueries     bool             `yaml:"rawQueries" env:"HEDERA_MIRROR_ROSETTA_DB_RAW_QUERIES"`
+	Tls            DbTls            `yaml:"tls"`
+	Username       string           `yaml:"username" env:"HEDERA_MIRROR_ROSETTA_DB_USERNAME"`
+}
+
+type DbIntegrityCheck struct {
+	Enabled    bool `yaml:"enabled" env:"HEDERA_MIRROR_ROSETTA_DB_INTEGRITY_CHECK_ENABLED"`
+	SampleSize int  `yaml:"sampleSize" env:"HEDERA_MIRROR_ROSETTA_DB_INTEGRITY_CHECK_SAMPLE_SIZE"`
+}
+
+type DbTls struct {
+	CertFile           string   `yaml:"certFile" env:"HEDERA_MIRROR_ROSETTA_DB_TLS_CERT_FILE"`
+	KeyFile            string   `yaml:"keyFile" env:"HEDERA_MIRROR_ROSETTA_DB_TLS_KEY_FILE"`
+	Mode               string   `yaml:"mode" env:"HEDERA_MIRROR_ROSETTA_DB_TLS_MODE"`
+	PinnedCertificates []string `yaml:"pinnedCertificates" env:"HEDERA_MIRROR_ROSETTA_DB_TLS_PINNED_CERTIFICATES"`
+	RootCaFile         string   `yaml:"rootCaFile" env:"HEDERA_MIRROR_ROSETTA_DB_TLS_ROOT_CA_FILE"`
+}
+
+type DbMetrics struct {
+	Enabled            bool `yaml:"enabled" env:"HEDERA_MIRROR_ROSETTA_DB_METRICS_ENABLED"`
+	SlowQueryThreshold int  `yaml:"slowQueryThreshold" env:"HEDERA_MIRROR_ROSETTA_DB_METRICS_SLOW_QUERY_THRESHOLD"`
 }
 
 type Pool struct {
@@ -87,6 +230,60 @@ type Pool struct {
 	MaxOpenConnections int `yaml:"maxOpenConnections" env:"HEDERA_MIRROR_ROSETTA_DB_POOL_MAX_OPEN_CONNECTIONS"`
 }
 
+type Grpc struct {
+	Enabled bool   `yaml:"enabled" env:"HEDERA_MIRROR_ROSETTA_GRPC_ENABLED"`
+	Port    uint16 `yaml:"port" env:"HEDERA_MIRROR_ROSETTA_GRPC_PORT"`
+}
+
+type Http struct {
+	Compression  HttpCompression  `yaml:"compression"`
+	Concurrency  HttpConcurrency  `yaml:"concurrency"`
+	Cors         HttpCors         `yaml:"cors"`
+	Http2        bool             `yaml:"http2" env:"HEDERA_MIRROR_ROSETTA_HTTP_HTTP2"`
+	Localization HttpLocalization `yaml:"localization"`
+	Pprof        bool             `yaml:"pprof" env:"HEDERA_MIRROR_ROSETTA_HTTP_PPROF"`
+	Tls          HttpTls          `yaml:"tls"`
+}
+
+type HttpCompression struct {
+	Enabled bool `yaml:"enabled" env:"HEDERA_MIRROR_ROSETTA_HTTP_COMPRESSION_ENABLED"`
+	Level   int  `yaml:"level" env:"HEDERA_MIRROR_ROSETTA_HTTP_COMPRESSION_LEVEL"`
+	MinSize int  `yaml:"minSize" env:"HEDERA_MIRROR_ROSETTA_HTTP_COMPRESSION_MIN_SIZE"`
+}
+
+type HttpConcurrency struct {
+	AccountBalance int  `yaml:"accountBalance" env:"HEDERA_MIRROR_ROSETTA_HTTP_CONCURRENCY_ACCOUNT_BALANCE"`
+	Block          int  `yaml:"block" env:"HEDERA_MIRROR_ROSETTA_HTTP_CONCURRENCY_BLOCK"`
+	Enabled        bool `yaml:"enabled" env:"HEDERA_MIRROR_ROSETTA_HTTP_CONCURRENCY_ENABLED"`
+	MaxQueued      int  `yaml:"maxQueued" env:"HEDERA_MIRROR_ROSETTA_HTTP_CONCURRENCY_MAX_QUEUED"`
+	QueueTimeout   int  `yaml:"queueTimeout" env:"HEDERA_MIRROR_ROSETTA_HTTP_CONCURRENCY_QUEUE_TIMEOUT"`
+}
+
+type HttpCors struct {
+	AllowedHeaders []string `yaml:"allowedHeaders" env:"HEDERA_MIRROR_ROSETTA_HTTP_CORS_ALLOWED_HEADERS"`
+	AllowedOrigins []string `yaml:"allowedOrigins" env:"HEDERA_MIRROR_ROSETTA_HTTP_CORS_ALLOWED_ORIGINS"`
+	Enabled        bool     `yaml:"enabled" env:"HEDERA_MIRROR_ROSETTA_HTTP_CORS_ENABLED"`
+	MaxAge         int      `yaml:"maxAge" env:"HEDERA_MIRROR_ROSETTA_HTTP_CORS_MAX_AGE"`
+}
+
+type HttpLocalization struct {
+	CatalogFile string `yaml:"catalogFile" env:"HEDERA_MIRROR_ROSETTA_HTTP_LOCALIZATION_CATALOG_FILE"`
+	Enabled     bool   `yaml:"enabled" env:"HEDERA_MIRROR_ROSETTA_HTTP_LOCALIZATION_ENABLED"`
+}
+
+type HttpTls struct {
+	CertFile     string `yaml:"certFile" env:"HEDERA_MIRROR_ROSETTA_HTTP_TLS_CERT_FILE"`
+	ClientCaFile string `yaml:"clientCaFile" env:"HEDERA_MIRROR_ROSETTA_HTTP_TLS_CLIENT_CA_FILE"`
+	Enabled      bool   `yaml:"enabled" env:"HEDERA_MIRROR_ROSETTA_HTTP_TLS_ENABLED"`
+	KeyFile      string `yaml:"keyFile" env:"HEDERA_MIRROR_ROSETTA_HTTP_TLS_KEY_FILE"`
+}
+
+type Journal struct {
+	Enabled    bool   `yaml:"enabled" env:"HEDERA_MIRROR_ROSETTA_CONSTRUCTION_JOURNAL_ENABLED"`
+	MaxEntries int    `yaml:"maxEntries" env:"HEDERA_MIRROR_ROSETTA_CONSTRUCTION_JOURNAL_MAX_ENTRIES"`
+	Path       string `yaml:"path" env:"HEDERA_MIRROR_ROSETTA_CONSTRUCTION_JOURNAL_PATH"`
+}
+
 type Log struct {
 	Level string `yaml:"level" env:"HEDERA_MIRROR_ROSETTA_LOG_LEVEL"`
 }